@@ -1,11 +1,11 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "changed" {
-  content = <<-EOT
+  content = <<-EOF
 package changed // import "github.com/terramate-io/terramate/benchmarks/changed"
 
 Package changed contains benchmarks to the change detection system.
-EOT
+EOF
 
   filename = "${path.module}/mock-changed.ignore"
 }
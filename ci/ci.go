@@ -33,6 +33,7 @@ const (
 	PlatformMyGet
 	PlatformTeamCity
 	PlatformTravis
+	PlatformGerrit
 )
 
 // DetectPlatformFromEnv detects PlatformType based on environment variables.
@@ -63,6 +64,10 @@ func DetectPlatformFromEnv() PlatformType {
 		typ = PlatformCodeBuild
 	} else if isEnvVarSet("HEROKU_TEST_RUN_ID") {
 		typ = PlatformHeroku
+	} else if isGerrit() {
+		// Gerrit changes are usually built by Zuul or by the Jenkins Gerrit
+		// Trigger plugin, then it must be detected before Jenkins.
+		typ = PlatformGerrit
 	} else if strings.HasPrefix(os.Getenv("BUILD_TAG"), "hudson-") {
 		typ = PlatformHudson
 	} else if isEnvVarSet("JENKINS_URL") {
@@ -119,11 +124,17 @@ func (plat PlatformType) String() string {
 		return "teamcity"
 	case PlatformTravis:
 		return "travis"
+	case PlatformGerrit:
+		return "gerrit"
 	default:
 		return "unknown"
 	}
 }
 
+func isGerrit() bool {
+	return isEnvVarSet("ZUUL_PROJECT") || isEnvVarSet("GERRIT_PROJECT")
+}
+
 func isEnvVarSet(key string) bool {
 	val := os.Getenv(key)
 	return val != "" && val != "0" && val != "false"
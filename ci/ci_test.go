@@ -6,6 +6,7 @@ package ci_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/madlambda/spells/assert"
 	"github.com/terramate-io/terramate/ci"
 )
@@ -16,6 +17,8 @@ func TestDetectPlatformFromEnv(t *testing.T) {
 		"GITLAB_CI":              ci.PlatformGitlab,
 		"BITBUCKET_BUILD_NUMBER": ci.PlatformBitBucket,
 		"TF_BUILD":               ci.PlatformAzureDevops,
+		"GERRIT_PROJECT":         ci.PlatformGerrit,
+		"ZUUL_PROJECT":           ci.PlatformGerrit,
 		"CI":                     ci.PlatformGenericCI,
 	}
 
@@ -33,3 +36,190 @@ func TestDetectPlatformFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectMetadataFromEnv(t *testing.T) {
+	type testcase struct {
+		name     string
+		platform ci.PlatformType
+		env      map[string]string
+		want     ci.Metadata
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:     "github pull request",
+			platform: ci.PlatformGithub,
+			env: map[string]string{
+				"GITHUB_EVENT_NAME": "pull_request",
+				"GITHUB_SHA":        "abc",
+				"GITHUB_REF":        "refs/pull/42/merge",
+				"GITHUB_HEAD_REF":   "feature",
+				"GITHUB_BASE_REF":   "main",
+			},
+			want: ci.Metadata{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "42",
+			},
+		},
+		{
+			name:     "github push",
+			platform: ci.PlatformGithub,
+			env: map[string]string{
+				"GITHUB_EVENT_NAME": "push",
+				"GITHUB_SHA":        "abc",
+				"GITHUB_REF_TYPE":   "branch",
+				"GITHUB_REF_NAME":   "main",
+			},
+			want: ci.Metadata{
+				Branch: "main",
+				Commit: "abc",
+			},
+		},
+		{
+			name:     "gitlab merge request",
+			platform: ci.PlatformGitlab,
+			env: map[string]string{
+				"CI_COMMIT_SHA":                       "abc",
+				"CI_MERGE_REQUEST_IID":                "7",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature",
+				"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "main",
+			},
+			want: ci.Metadata{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "7",
+			},
+		},
+		{
+			name:     "bitbucket pull request",
+			platform: ci.PlatformBitBucket,
+			env: map[string]string{
+				"BITBUCKET_BRANCH":                "feature",
+				"BITBUCKET_PR_DESTINATION_BRANCH": "main",
+				"BITBUCKET_COMMIT":                "abc",
+				"BITBUCKET_PR_ID":                 "3",
+			},
+			want: ci.Metadata{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "3",
+			},
+		},
+		{
+			name:     "zuul change",
+			platform: ci.PlatformGerrit,
+			env: map[string]string{
+				"ZUUL_PROJECT": "infra",
+				"ZUUL_BRANCH":  "main",
+				"ZUUL_CHANGE":  "1234",
+				"ZUUL_COMMIT":  "abc",
+			},
+			want: ci.Metadata{
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "1234",
+			},
+		},
+		{
+			name:     "gerrit ref updated",
+			platform: ci.PlatformGerrit,
+			env: map[string]string{
+				"GERRIT_PROJECT": "infra",
+				"GERRIT_BRANCH":  "main",
+				"GERRIT_NEWREV":  "abc",
+			},
+			want: ci.Metadata{
+				Branch: "main",
+				Commit: "abc",
+			},
+		},
+		{
+			name:     "azure devops pull request of azure repos",
+			platform: ci.PlatformAzureDevops,
+			env: map[string]string{
+				"BUILD_REASON":                     "PullRequest",
+				"BUILD_SOURCEVERSION":              "abc",
+				"BUILD_SOURCEBRANCH":               "refs/pull/7/merge",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID": "7",
+				"SYSTEM_PULLREQUEST_SOURCEBRANCH":  "refs/heads/feature/x",
+				"SYSTEM_PULLREQUEST_TARGETBRANCH":  "refs/heads/main",
+			},
+			want: ci.Metadata{
+				Branch:        "feature/x",
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "7",
+			},
+		},
+		{
+			name:     "azure devops pull request of github repository",
+			platform: ci.PlatformAzureDevops,
+			env: map[string]string{
+				"BUILD_REASON":                         "PullRequest",
+				"BUILD_SOURCEVERSION":                  "abc",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID":     "123456789",
+				"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER": "12",
+				"SYSTEM_PULLREQUEST_SOURCEBRANCH":      "feature",
+				"SYSTEM_PULLREQUEST_TARGETBRANCH":      "main",
+			},
+			want: ci.Metadata{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				Commit:        "abc",
+				ReviewRequest: "12",
+			},
+		},
+		{
+			name:     "azure devops branch build",
+			platform: ci.PlatformAzureDevops,
+			env: map[string]string{
+				"BUILD_REASON":        "IndividualCI",
+				"BUILD_SOURCEVERSION": "abc",
+				"BUILD_SOURCEBRANCH":  "refs/heads/release/v1",
+			},
+			want: ci.Metadata{
+				Branch: "release/v1",
+				Commit: "abc",
+			},
+		},
+		{
+			name:     "azure devops tag build",
+			platform: ci.PlatformAzureDevops,
+			env: map[string]string{
+				"BUILD_REASON":        "IndividualCI",
+				"BUILD_SOURCEVERSION": "abc",
+				"BUILD_SOURCEBRANCH":  "refs/tags/v1",
+			},
+			want: ci.Metadata{
+				Commit: "abc",
+			},
+		},
+		{
+			name:     "unsupported platform",
+			platform: ci.PlatformGenericCI,
+			want:     ci.Metadata{},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{
+				"ZUUL_PROJECT", "ZUUL_CHANGE", "GERRIT_CHANGE_NUMBER",
+				"GERRIT_PATCHSET_REVISION", "GITHUB_REF_TYPE",
+				"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER",
+			} {
+				t.Setenv(k, "")
+			}
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			got := ci.DetectMetadataFromEnv(tc.platform)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected metadata (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package ci
+
+import (
+	"os"
+	"strings"
+)
+
+// Metadata is the branch, commit and review request information of a CI run,
+// normalized across the CI/CD platforms.
+type Metadata struct {
+	// Branch is the branch being built. For review requests, it is the
+	// source branch, if the platform provides it.
+	Branch string
+	// BaseBranch is the target branch of the review request, if any.
+	BaseBranch string
+	// Commit is the commit SHA being built.
+	Commit string
+	// ReviewRequest is the number of the review request being built, if any.
+	// Review requests are pull requests, merge requests or Gerrit changes,
+	// depending on the platform.
+	ReviewRequest string
+}
+
+// IsReviewRequest tells if the CI run builds a review request.
+func (md Metadata) IsReviewRequest() bool {
+	return md.ReviewRequest != ""
+}
+
+// DetectMetadataFromEnv detects the metadata of the CI run of the given
+// platform based on environment variables. Platforms without metadata
+// support return an empty Metadata.
+func DetectMetadataFromEnv(plat PlatformType) Metadata {
+	switch plat {
+	case PlatformGithub:
+		return githubMetadata()
+	case PlatformGitlab:
+		return gitlabMetadata()
+	case PlatformBitBucket:
+		return bitbucketMetadata()
+	case PlatformGerrit:
+		return gerritMetadata()
+	case PlatformAzureDevops:
+		return azureDevopsMetadata()
+	default:
+		return Metadata{}
+	}
+}
+
+func githubMetadata() Metadata {
+	md := Metadata{
+		Commit: os.Getenv("GITHUB_SHA"),
+	}
+	if strings.HasPrefix(os.Getenv("GITHUB_EVENT_NAME"), "pull_request") {
+		md.Branch = os.Getenv("GITHUB_HEAD_REF")
+		md.BaseBranch = os.Getenv("GITHUB_BASE_REF")
+		// GITHUB_REF is refs/pull/<number>/merge for pull request events.
+		ref := strings.TrimPrefix(os.Getenv("GITHUB_REF"), "refs/pull/")
+		if number, _, ok := strings.Cut(ref, "/"); ok {
+			md.ReviewRequest = number
+		}
+		return md
+	}
+	if os.Getenv("GITHUB_REF_TYPE") == "branch" {
+		md.Branch = os.Getenv("GITHUB_REF_NAME")
+	}
+	return md
+}
+
+func gitlabMetadata() Metadata {
+	md := Metadata{
+		Commit:        os.Getenv("CI_COMMIT_SHA"),
+		ReviewRequest: os.Getenv("CI_MERGE_REQUEST_IID"),
+	}
+	if md.IsReviewRequest() {
+		md.Branch = os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME")
+		md.BaseBranch = os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME")
+		return md
+	}
+	md.Branch = os.Getenv("CI_COMMIT_BRANCH")
+	return md
+}
+
+func bitbucketMetadata() Metadata {
+	return Metadata{
+		Branch:        os.Getenv("BITBUCKET_BRANCH"),
+		BaseBranch:    os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH"),
+		Commit:        os.Getenv("BITBUCKET_COMMIT"),
+		ReviewRequest: os.Getenv("BITBUCKET_PR_ID"),
+	}
+}
+
+// gerritMetadata supports both Zuul and the Jenkins Gerrit Trigger plugin.
+// Gerrit changes have no source branch, only the branch they target.
+func gerritMetadata() Metadata {
+	var md Metadata
+	var branch string
+	if isEnvVarSet("ZUUL_PROJECT") {
+		branch = os.Getenv("ZUUL_BRANCH")
+		md.ReviewRequest = os.Getenv("ZUUL_CHANGE")
+		md.Commit = firstEnv("ZUUL_COMMIT", "ZUUL_NEWREV")
+	} else {
+		branch = os.Getenv("GERRIT_BRANCH")
+		md.ReviewRequest = os.Getenv("GERRIT_CHANGE_NUMBER")
+		md.Commit = firstEnv("GERRIT_PATCHSET_REVISION", "GERRIT_NEWREV")
+	}
+	if md.IsReviewRequest() {
+		md.BaseBranch = branch
+	} else {
+		md.Branch = branch
+	}
+	return md
+}
+
+// azureDevopsMetadata supports pipelines of both Azure Repos and GitHub
+// repositories. The pull request number of GitHub repositories differs from
+// the pull request ID, which is only meaningful to Azure DevOps.
+func azureDevopsMetadata() Metadata {
+	md := Metadata{
+		Commit: os.Getenv("BUILD_SOURCEVERSION"),
+	}
+	if os.Getenv("BUILD_REASON") == "PullRequest" {
+		md.Branch = strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), "refs/heads/")
+		md.BaseBranch = strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), "refs/heads/")
+		md.ReviewRequest = firstEnv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID")
+		return md
+	}
+	if branch, ok := strings.CutPrefix(os.Getenv("BUILD_SOURCEBRANCH"), "refs/heads/"); ok {
+		md.Branch = branch
+	}
+	return md
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if val := os.Getenv(key); val != "" {
+			return val
+		}
+	}
+	return ""
+}
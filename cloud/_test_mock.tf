@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cloud" {
-  content = <<-EOT
+  content = <<-EOF
 package cloud // import "github.com/terramate-io/terramate/cloud"
 
 Package cloud implements a client SDK for communication with the cloud API.
@@ -81,7 +81,7 @@ type UpdateStackPreviewOpts struct{ ... }
 type UpdateStackPreviewPayloadRequest struct{ ... }
 type User struct{ ... }
 type WellKnown struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-cloud.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "deployment" {
-  content = <<-EOT
+  content = <<-EOF
 package deployment // import "github.com/terramate-io/terramate/cloud/deployment"
 
 Package deployment provides types and helpers for cloud deployments.
@@ -13,7 +13,7 @@ type FilterStatus Status
 type Status uint8
     const OK Status = 1 << iota ...
     func NewStatus(str string) Status
-EOT
+EOF
 
   filename = "${path.module}/mock-deployment.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "metadata" {
-  content = <<-EOT
+  content = <<-EOF
 package metadata // import "github.com/terramate-io/terramate/cloud/metadata"
 
 Package metadata contains data structures for platform metadata that is sent
@@ -50,7 +50,7 @@ type GitlabMergeRequestReviewer struct{ ... }
     func NewGitlabMergeRequestReviewer(in *gitlab.MRReviewer) (*GitlabMergeRequestReviewer, error)
 type GitlabUser struct{ ... }
     func NewGitlabUser(in *gitlab.User) (*GitlabUser, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-metadata.ignore"
 }
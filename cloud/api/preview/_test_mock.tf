@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "preview" {
-  content = <<-EOT
+  content = <<-EOF
 package preview // import "github.com/terramate-io/terramate/cloud/preview"
 
 Package preview contains functionality for the preview feature in Terramate
@@ -12,7 +12,7 @@ type Layer string
 type StackStatus string
     const StackStatusAffected StackStatus = "affected" ...
     func DerivePreviewStatus(exitCode int) StackStatus
-EOT
+EOF
 
   filename = "${path.module}/mock-preview.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "resources" {
-  content = <<-EOT
+  content = <<-EOF
 package resources // import "github.com/terramate-io/terramate/cloud/api/resources"
 
 type Author struct{ ... }
@@ -58,7 +58,7 @@ type UpdateDeploymentStacks struct{ ... }
 type UpdateStackPreviewPayloadRequest struct{ ... }
 type User struct{ ... }
 type WellKnown struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-resources.ignore"
 }
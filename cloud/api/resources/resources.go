@@ -218,6 +218,8 @@ type (
 		GithubMetadata
 		GitlabMetadata
 		BitbucketMetadata
+		GerritMetadata
+		AzureDevopsMetadata
 	}
 
 	// GitMetadata are the git related metadata.
@@ -368,6 +370,42 @@ type (
 		BitbucketPullRequest *metadata.BitbucketPullRequest `json:"bitbucket_pull_request,omitempty"`
 	}
 
+	// GerritMetadata holds the Gerrit specific metadata, collected from
+	// changes built by Zuul or by the Jenkins Gerrit Trigger plugin.
+	GerritMetadata struct {
+		GerritProject          string `json:"gerrit_project,omitempty"`
+		GerritBranch           string `json:"gerrit_branch,omitempty"`
+		GerritChangeNumber     string `json:"gerrit_change_number,omitempty"`
+		GerritChangeURL        string `json:"gerrit_change_url,omitempty"`
+		GerritPatchsetNumber   string `json:"gerrit_patchset_number,omitempty"`
+		GerritPatchsetRevision string `json:"gerrit_patchset_revision,omitempty"`
+		ZuulPipeline           string `json:"zuul_pipeline,omitempty"`
+		ZuulBuildUUID          string `json:"zuul_build_uuid,omitempty"`
+	}
+
+	// AzureDevopsMetadata holds the Azure DevOps Pipelines specific metadata.
+	AzureDevopsMetadata struct {
+		AzureDevopsOrganizationURL         string `json:"azure_devops_organization_url,omitempty"`
+		AzureDevopsProject                 string `json:"azure_devops_project,omitempty"`
+		AzureDevopsRepository              string `json:"azure_devops_repository,omitempty"`
+		AzureDevopsRepositoryProvider      string `json:"azure_devops_repository_provider,omitempty"`
+		AzureDevopsPipelineID              string `json:"azure_devops_pipeline_id,omitempty"`
+		AzureDevopsPipelineName            string `json:"azure_devops_pipeline_name,omitempty"`
+		AzureDevopsBuildID                 string `json:"azure_devops_build_id,omitempty"`
+		AzureDevopsBuildNumber             string `json:"azure_devops_build_number,omitempty"`
+		AzureDevopsBuildReason             string `json:"azure_devops_build_reason,omitempty"`
+		AzureDevopsBuildURL                string `json:"azure_devops_build_url,omitempty"`
+		AzureDevopsJobAttempt              string `json:"azure_devops_job_attempt,omitempty"`
+		AzureDevopsRequestedFor            string `json:"azure_devops_requested_for,omitempty"`
+		AzureDevopsSourceBranch            string `json:"azure_devops_source_branch,omitempty"`
+		AzureDevopsSourceVersion           string `json:"azure_devops_source_version,omitempty"`
+		AzureDevopsPullRequestID           string `json:"azure_devops_pull_request_id,omitempty"`     // only available in PR builds.
+		AzureDevopsPullRequestNumber       string `json:"azure_devops_pull_request_number,omitempty"` // only available in PR builds of GitHub repositories.
+		AzureDevopsPullRequestSourceBranch string `json:"azure_devops_pull_request_source_branch,omitempty"`
+		AzureDevopsPullRequestTargetBranch string `json:"azure_devops_pull_request_target_branch,omitempty"`
+		AzureDevopsPullRequestSourceCommit string `json:"azure_devops_pull_request_source_commit,omitempty"`
+	}
+
 	// ReviewRequest is the review_request object.
 	ReviewRequest struct {
 		Platform              string     `json:"platform"`
@@ -388,6 +426,7 @@ type (
 		ChecksTotalCount      int        `json:"checks_total_count"`
 		ChecksFailureCount    int        `json:"checks_failure_count"`
 		ChecksSuccessCount    int        `json:"checks_success_count"`
+		ChangedFilesCount     int        `json:"changed_files_count,omitempty"`
 		CreatedAt             *time.Time `json:"created_at,omitempty"`
 		UpdatedAt             *time.Time `json:"updated_at,omitempty"`
 		PushedAt              *int64     `json:"pushed_at,omitempty"`
@@ -635,6 +674,16 @@ func (orgs MemberOrganizations) LookupByName(name string) (org MemberOrganizatio
 	return MemberOrganization{}, false
 }
 
+// LookupByUUID lookup an organization by UUID in the org list.
+func (orgs MemberOrganizations) LookupByUUID(uuid UUID) (org MemberOrganization, found bool) {
+	for _, org := range orgs {
+		if org.UUID == uuid {
+			return org, true
+		}
+	}
+	return MemberOrganization{}, false
+}
+
 // Validate the well-known payload.
 func (wk WellKnown) Validate() error {
 	if wk.RequiredVersion == "" {
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "stack" {
-  content = <<-EOT
+  content = <<-EOF
 package stack // import "github.com/terramate-io/terramate/cloud/stack"
 
 Package stack provides types and helpers for cloud stacks.
@@ -14,7 +14,7 @@ type FilterStatus Status
 type Status uint8
     const OK Status = 1 << iota ...
     func NewStatus(str string) Status
-EOT
+EOF
 
   filename = "${path.module}/mock-stack.ignore"
 }
@@ -40,8 +40,16 @@ type Client struct {
 	httpClient *stdhttp.Client
 
 	logger *zerolog.Logger
+
+	// org is the organization the client is pinned to. If set, requests
+	// targeting other organizations fail with [ErrWrongOrg].
+	org resources.UUID
 }
 
+// ErrWrongOrg indicates that a request targets an organization other than
+// the one the client is pinned to.
+const ErrWrongOrg errors.Kind = "request targets the wrong organization"
+
 // Option is a functional option for the client.
 type Option func(*Client)
 
@@ -96,6 +104,32 @@ func WithHTTPClient(httpClient *stdhttp.Client) Option {
 	}
 }
 
+// WithOrg pins the client to the given organization.
+func WithOrg(orgUUID resources.UUID) Option {
+	return func(c *Client) {
+		c.org = orgUUID
+	}
+}
+
+// SetOrg pins the client to the given organization.
+func (c *Client) SetOrg(orgUUID resources.UUID) {
+	c.org = orgUUID
+}
+
+// Org returns the organization the client is pinned to, if any.
+func (c *Client) Org() resources.UUID {
+	return c.org
+}
+
+// checkOrg checks that the request for orgUUID can be sent by the client.
+func (c *Client) checkOrg(orgUUID resources.UUID) error {
+	if c.org != "" && orgUUID != c.org {
+		return errors.E(ErrWrongOrg,
+			"payload targets organization %s but the selected organization is %s", orgUUID, c.org)
+	}
+	return nil
+}
+
 // SetCredential sets the client cloud credential.
 func (c *Client) SetCredential(credential http.Credential) {
 	c.credential = credential
@@ -280,6 +314,9 @@ func (c *Client) CreateDeploymentStacks(
 	if deploymentUUID == "" {
 		panic(errors.E(errors.ErrInternal, "deploymentUUID must not be empty"))
 	}
+	if err := c.checkOrg(orgUUID); err != nil {
+		return resources.DeploymentStacksResponse{}, err
+	}
 	err := deploymentStacksPayload.Validate()
 	if err != nil {
 		return resources.DeploymentStacksResponse{}, errors.E(err, "failed to prepare the request")
@@ -294,6 +331,9 @@ func (c *Client) CreateDeploymentStacks(
 
 // UpdateDeploymentStacks updates the deployment status of each stack in the payload set.
 func (c *Client) UpdateDeploymentStacks(ctx context.Context, orgUUID resources.UUID, deploymentUUID resources.UUID, payload resources.UpdateDeploymentStacks) error {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
 	_, err := http.Patch[resources.EmptyResponse](
 		ctx,
 		c,
@@ -309,6 +349,9 @@ func (c *Client) CreateStackDrift(
 	orgUUID resources.UUID,
 	driftPayload resources.DriftStackPayloadRequest,
 ) (resources.EmptyResponse, error) {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return resources.EmptyResponse(""), err
+	}
 	err := driftPayload.Validate()
 	if err != nil {
 		return resources.EmptyResponse(""), errors.E(err, "failed to prepare the request")
@@ -321,6 +364,24 @@ func (c *Client) CreateStackDrift(
 	)
 }
 
+// PutStack creates or updates the given stack, independently of deployments.
+func (c *Client) PutStack(ctx context.Context, orgUUID resources.UUID, st resources.StackObject) error {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
+	err := st.Validate()
+	if err != nil {
+		return errors.E(err, "failed to prepare the request")
+	}
+	_, err = http.Put[resources.EmptyResponse](
+		ctx,
+		c,
+		st,
+		c.URL(path.Join(StacksPath, string(orgUUID), st.MetaID)),
+	)
+	return err
+}
+
 // SyncCommandLogs sends a batch of command logs to Terramate Cloud.
 func (c *Client) SyncCommandLogs(
 	ctx context.Context,
@@ -330,6 +391,9 @@ func (c *Client) SyncCommandLogs(
 	logs resources.CommandLogs,
 	stackPreviewID string,
 ) error {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
 	err := logs.Validate()
 	if err != nil {
 		return errors.E(err, "failed to prepare the request")
@@ -350,6 +414,9 @@ func (c *Client) SyncCommandLogs(
 
 // CreateStoreOutput creates a new output in the Terramate Cloud store.
 func (c *Client) CreateStoreOutput(ctx context.Context, orgUUID resources.UUID, output resources.StoreOutputRequest) (resources.StoreOutput, error) {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return resources.StoreOutput{}, err
+	}
 	err := output.Validate()
 	if err != nil {
 		return resources.StoreOutput{}, errors.E(err, "failed to prepare the request")
@@ -391,6 +458,9 @@ func (c *Client) LookupStoreOutput(ctx context.Context, orgUUID resources.UUID,
 
 // UpdateStoreOutputValue updates the value of the output in the Terramate Cloud store.
 func (c *Client) UpdateStoreOutputValue(ctx context.Context, orgUUID resources.UUID, id resources.UUID, value string) error {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
 	_, err := http.Put[resources.EmptyResponse](
 		ctx,
 		c,
@@ -402,6 +472,9 @@ func (c *Client) UpdateStoreOutputValue(ctx context.Context, orgUUID resources.U
 
 // DeleteStoreOutput deletes the output from the Terramate Cloud store.
 func (c *Client) DeleteStoreOutput(ctx context.Context, orgUUID resources.UUID, id resources.UUID) error {
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
 	return http.Delete[resources.EmptyResponse](
 		ctx,
 		c,
@@ -144,6 +144,9 @@ func (c *Client) createPreview(
 	if err := payload.Validate(); err != nil {
 		return resources.CreatePreviewResponse{}, errors.E(err, "invalid payload")
 	}
+	if err := c.checkOrg(orgUUID); err != nil {
+		return resources.CreatePreviewResponse{}, err
+	}
 
 	return http.Post[resources.CreatePreviewResponse](
 		ctx, c, payload,
@@ -161,6 +164,9 @@ func (c *Client) updateStackPreview(
 	if err := payload.Validate(); err != nil {
 		return errors.E(err, "invalid payload")
 	}
+	if err := c.checkOrg(orgUUID); err != nil {
+		return err
+	}
 
 	// Endpoint: /v1/stack_previews/{org_uuid}/{stack_preview_id}
 	_, err := http.Patch[resources.EmptyResponse](
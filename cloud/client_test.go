@@ -608,6 +608,39 @@ func TestCloudStacks(t *testing.T) {
 	}
 }
 
+func TestCloudClientPinnedOrg(t *testing.T) {
+	t.Parallel()
+	var calls int
+	s := httptest.NewTLSServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+		calls++
+		w.WriteHeader(stdhttp.StatusNoContent)
+	}))
+	defer s.Close()
+
+	const (
+		pinnedOrg = resources.UUID("b2f153e8-ceb1-4f26-898e-eb7789869bee")
+		otherOrg  = resources.UUID("0e4b7f6b-7d1b-4c2a-9f68-3c1b8f0a2e51")
+	)
+
+	sdk := cloud.NewClient(
+		cloud.WithBaseURL(s.URL),
+		cloud.WithHTTPClient(s.Client()),
+		cloud.WithCredential(credential()),
+		cloud.WithOrg(pinnedOrg),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := sdk.UpdateDeploymentStacks(ctx, otherOrg, "deployment", resources.UpdateDeploymentStacks{})
+	errtest.Assert(t, err, errors.E(cloud.ErrWrongOrg))
+	assert.EqualInts(t, 0, calls, "payload for the wrong org must not be sent")
+
+	err = sdk.UpdateDeploymentStacks(ctx, pinnedOrg, "deployment", resources.UpdateDeploymentStacks{})
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, calls)
+}
+
 func newTestServer(statusCode int, body string, headers stdhttp.Header) *httptest.Server {
 	return httptest.NewTLSServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
 		if len(headers) > 0 {
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "bitbucket" {
-  content = <<-EOT
+  content = <<-EOF
 package bitbucket // import "github.com/terramate-io/terramate/cmd/terramate/cli/bitbucket"
 
 Package bitbucket implements the client for Bitbucket Cloud.
@@ -17,7 +17,7 @@ type Rendered struct{ ... }
 type RenderedContent struct{ ... }
 type Summary RenderedContent
 type TargetBranch struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-bitbucket.ignore"
 }
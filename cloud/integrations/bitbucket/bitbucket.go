@@ -124,6 +124,7 @@ type (
 		Rendered          Rendered     `json:"rendered"`
 		Summary           Summary      `json:"summary"`
 		State             string       `json:"state"`
+		Draft             bool         `json:"draft"`
 		Author            User         `json:"author"`
 		Source            TargetBranch `json:"source"`
 		Destination       TargetBranch `json:"destination"`
@@ -161,6 +162,7 @@ func (c *Client) GetPullRequestsByCommit(ctx context.Context, commit string) (pr
 		"rendered",
 		"summary",
 		"state",
+		"draft",
 		"author.*",
 		"source.branch.name",
 		"source.commit.hash",
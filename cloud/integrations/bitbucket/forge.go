@@ -0,0 +1,109 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// maxDiffStatPages limits the number of diffstat pages fetched when counting
+// the files changed by a pull request.
+const maxDiffStatPages = 20
+
+var _ forge.Forge = (*Client)(nil)
+
+// Name returns the forge name.
+func (c *Client) Name() string { return "bitbucket" }
+
+// ReviewRequestMetadata returns the draft status and changed files count of
+// the pull request with the given ID. Bitbucket Cloud has no pull request
+// labels.
+func (c *Client) ReviewRequestMetadata(ctx context.Context, id int) (forge.Metadata, error) {
+	data, err := c.doGet(ctx, fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d?fields=draft",
+		c.baseURL(), c.Workspace, c.RepoSlug, id))
+	if err != nil {
+		return forge.Metadata{}, err
+	}
+	var pr struct {
+		Draft bool `json:"draft"`
+	}
+	if err := json.Unmarshal(data, &pr); err != nil {
+		return forge.Metadata{}, errors.E(err, "unmarshaling PR")
+	}
+
+	changedFiles, err := c.countPullRequestChangedFiles(ctx, id)
+	if err != nil {
+		return forge.Metadata{}, err
+	}
+	return forge.Metadata{
+		Draft:        pr.Draft,
+		ChangedFiles: changedFiles,
+	}, nil
+}
+
+func (c *Client) countPullRequestChangedFiles(ctx context.Context, id int) (int, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/diffstat?fields=next,values.status",
+		c.baseURL(), c.Workspace, c.RepoSlug, id)
+
+	var count int
+	for page := 0; url != "" && page < maxDiffStatPages; page++ {
+		data, err := c.doGet(ctx, url)
+		if err != nil {
+			return 0, err
+		}
+		var diffstat struct {
+			Next   string            `json:"next"`
+			Values []json.RawMessage `json:"values"`
+		}
+		if err := json.Unmarshal(data, &diffstat); err != nil {
+			return 0, errors.E(err, "unmarshaling PR diffstat")
+		}
+		count += len(diffstat.Values)
+		url = diffstat.Next
+	}
+	return count, nil
+}
+
+func (c *Client) doGet(ctx context.Context, url string) (data []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{}
+	}
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	defer func() {
+		err = errors.L(err, resp.Body.Close()).AsError()
+	}()
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.E(err, "reading response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d (%s)", resp.StatusCode, data)
+	}
+	return data, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+)
+
+func TestBitbucketReviewRequestMetadata(t *testing.T) {
+	const (
+		id       = 7
+		prPath   = "/repositories/terramate-io/terramate/pullrequests/7"
+		diffPath = prPath + "/diffstat"
+	)
+
+	for _, tc := range []struct {
+		name  string
+		pages []string
+		want  forge.Metadata
+	}{
+		{
+			name:  "no changed files",
+			pages: []string{`{"values": []}`},
+			want:  forge.Metadata{Draft: true},
+		},
+		{
+			name: "paginated diffstat",
+			pages: []string{
+				`{"values": [{"status": "added"}, {"status": "modified"}], "next": "{url}?page=2"}`,
+				`{"values": [{"status": "removed"}]}`,
+			},
+			want: forge.Metadata{Draft: true, ChangedFiles: 3},
+		},
+		{
+			name: "diffstat pages are limited",
+			pages: func() []string {
+				pages := make([]string, maxDiffStatPages+1)
+				for i := range pages {
+					pages[i] = `{"values": [{"status": "added"}], "next": "{url}?page=next"}`
+				}
+				return pages
+			}(),
+			want: forge.Metadata{Draft: true, ChangedFiles: maxDiffStatPages},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var srv *httptest.Server
+			page := 0
+			srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				switch r.URL.Path {
+				case prPath:
+					_, _ = fmt.Fprint(w, `{"draft": true}`)
+				case diffPath:
+					if page >= len(tc.pages) {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					body := tc.pages[page]
+					page++
+					_, _ = fmt.Fprint(w, strings.ReplaceAll(body, "{url}", srv.URL+diffPath))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			t.Cleanup(srv.Close)
+
+			c := &Client{
+				BaseURL:   srv.URL,
+				Token:     "token",
+				Workspace: "terramate-io",
+				RepoSlug:  "terramate",
+			}
+			got, err := c.ReviewRequestMetadata(context.Background(), id)
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("-(want) +(got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBitbucketReviewRequestMetadataFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Client{
+		BaseURL:   srv.URL,
+		Workspace: "terramate-io",
+		RepoSlug:  "terramate",
+	}
+	_, err := c.ReviewRequestMetadata(context.Background(), 1)
+	assert.IsTrue(t, err != nil, "want error")
+}
@@ -0,0 +1,17 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "forge" {
+  content = <<-EOF
+package forge // import "github.com/terramate-io/terramate/cloud/integrations/forge"
+
+Package forge defines the common interface of the code hosting platforms
+(GitHub, GitLab and Bitbucket) used to enrich the review requests synchronized
+with Terramate Cloud.
+
+type Forge interface{ ... }
+type Label struct{ ... }
+type Metadata struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-forge.ignore"
+}
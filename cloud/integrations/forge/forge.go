@@ -0,0 +1,38 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package forge defines the common interface of the code hosting platforms
+// (GitHub, GitLab and Bitbucket) used to enrich the review requests
+// synchronized with Terramate Cloud.
+package forge
+
+import "context"
+
+// Label is a review request label.
+type Label struct {
+	Name        string
+	Color       string
+	Description string
+}
+
+// Metadata is the review request metadata gathered from a forge.
+type Metadata struct {
+	// Labels are the labels of the review request.
+	// Forges without labels support leave it empty.
+	Labels []Label
+	// Draft tells if the review request is a draft.
+	Draft bool
+	// ChangedFiles is the number of files changed by the review request.
+	ChangedFiles int
+}
+
+// Forge is a code hosting platform providing review requests metadata.
+type Forge interface {
+	// Name returns the platform name, as used in the review requests
+	// synchronized with Terramate Cloud.
+	Name() string
+
+	// ReviewRequestMetadata returns the metadata of the review request with
+	// the given number.
+	ReviewRequestMetadata(ctx context.Context, number int) (Metadata, error)
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package forge // import \"github.com/terramate-io/terramate/cloud/integrations/forge\""
+  description = "package forge // import \"github.com/terramate-io/terramate/cloud/integrations/forge\"\n\nPackage forge defines the common interface of the code hosting platforms\n(GitHub, GitLab and Bitbucket) used to enrich the review requests synchronized\nwith Terramate Cloud.\n\ntype Forge interface{ ... }\ntype Label struct{ ... }\ntype Metadata struct{ ... }"
+  tags        = ["cloud", "forge", "golang", "integrations"]
+  id          = "6f2b6e30-9389-4fe5-ba1f-c9a3480b05cd"
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "github" {
-  content = <<-EOT
+  content = <<-EOF
 package github // import "github.com/terramate-io/terramate/cmd/terramate/cli/github"
 
 Package github implements a client SDK for the Github API.
@@ -14,7 +14,7 @@ func OIDCToken(ctx context.Context, cfg OIDCVars) (token string, err error)
 type OAuthDeviceFlowContext struct{ ... }
     func OAuthDeviceFlowAuthStart(clientID string) (oauthCtx OAuthDeviceFlowContext, err error)
 type OIDCVars struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-github.ignore"
 }
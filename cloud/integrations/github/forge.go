@@ -0,0 +1,56 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// Forge is the GitHub repository forge.
+type Forge struct {
+	Client *github.Client
+	Owner  string
+	Repo   string
+
+	// Pull is the already fetched pull request, if any. It is reused if it
+	// has all the needed details, avoiding a new request.
+	Pull *github.PullRequest
+}
+
+var _ forge.Forge = (*Forge)(nil)
+
+// Name returns the forge name.
+func (f *Forge) Name() string { return "github" }
+
+// ReviewRequestMetadata returns the labels, draft status and changed files
+// count of the pull request with the given number.
+func (f *Forge) ReviewRequestMetadata(ctx context.Context, number int) (forge.Metadata, error) {
+	pull := f.Pull
+	// changed_files is only present when the pull request is fetched alone,
+	// not when listed.
+	if pull == nil || pull.GetNumber() != number || pull.ChangedFiles == nil {
+		var err error
+		pull, _, err = f.Client.PullRequests.Get(ctx, f.Owner, f.Repo, number)
+		if err != nil {
+			return forge.Metadata{}, errors.E(err, "fetching pull request")
+		}
+	}
+
+	md := forge.Metadata{
+		Draft:        pull.GetDraft(),
+		ChangedFiles: pull.GetChangedFiles(),
+	}
+	for _, l := range pull.Labels {
+		md.Labels = append(md.Labels, forge.Label{
+			Name:        l.GetName(),
+			Color:       l.GetColor(),
+			Description: l.GetDescription(),
+		})
+	}
+	return md, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gh "github.com/google/go-github/v58/github"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+	"github.com/terramate-io/terramate/cloud/integrations/github"
+)
+
+func TestGithubReviewRequestMetadata(t *testing.T) {
+	const number = 7
+
+	want := forge.Metadata{
+		Labels: []forge.Label{
+			{Name: "infra", Color: "00ff00", Description: "Infrastructure"},
+			{Name: "prod"},
+		},
+		Draft:        true,
+		ChangedFiles: 3,
+	}
+
+	for _, tc := range []struct {
+		name         string
+		pull         *gh.PullRequest
+		wantRequests int
+	}{
+		{
+			name:         "no pull request",
+			wantRequests: 1,
+		},
+		{
+			name: "listed pull request has no changed files",
+			pull: &gh.PullRequest{
+				Number: gh.Int(number),
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "other pull request",
+			pull: &gh.PullRequest{
+				Number:       gh.Int(number + 1),
+				ChangedFiles: gh.Int(1),
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "fetched pull request is reused",
+			pull: &gh.PullRequest{
+				Number: gh.Int(number),
+				Labels: []*gh.Label{
+					{Name: gh.String("infra"), Color: gh.String("00ff00"), Description: gh.String("Infrastructure")},
+					{Name: gh.String("prod")},
+				},
+				Draft:        gh.Bool(true),
+				ChangedFiles: gh.Int(3),
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Method != "GET" || r.URL.Path != fmt.Sprintf("/repos/terramate-io/terramate/pulls/%d", number) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{
+					"number": %d,
+					"labels": [
+						{"name": "infra", "color": "00ff00", "description": "Infrastructure"},
+						{"name": "prod"}
+					],
+					"draft": true,
+					"changed_files": 3
+				}`, number)
+			}))
+			t.Cleanup(srv.Close)
+
+			client := gh.NewClient(nil)
+			baseURL, err := url.Parse(srv.URL + "/")
+			assert.NoError(t, err)
+			client.BaseURL = baseURL
+
+			f := &github.Forge{
+				Client: client,
+				Owner:  "terramate-io",
+				Repo:   "terramate",
+				Pull:   tc.pull,
+			}
+			got, err := f.ReviewRequestMetadata(context.Background(), number)
+			assert.NoError(t, err)
+			assert.EqualInts(t, tc.wantRequests, requests)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("-(want) +(got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGithubReviewRequestMetadataFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := gh.NewClient(nil)
+	baseURL, err := url.Parse(srv.URL + "/")
+	assert.NoError(t, err)
+	client.BaseURL = baseURL
+
+	f := &github.Forge{
+		Client: client,
+		Owner:  "terramate-io",
+		Repo:   "terramate",
+	}
+	_, err = f.ReviewRequestMetadata(context.Background(), 1)
+	assert.IsTrue(t, err != nil, "want error")
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "gitlab" {
-  content = <<-EOT
+  content = <<-EOF
 package gitlab // import "github.com/terramate-io/terramate/cmd/terramate/cli/gitlab"
 
 Package gitlab provides a SDK and helpers for the gitlab provider.
@@ -11,7 +11,7 @@ type Client struct{ ... }
 type MR struct{ ... }
 type MRs []MR
 type User struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-gitlab.ignore"
 }
@@ -0,0 +1,63 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+	"github.com/terramate-io/terramate/errors"
+)
+
+var _ forge.Forge = (*Client)(nil)
+
+// Name returns the forge name.
+func (c *Client) Name() string { return "gitlab" }
+
+// ReviewRequestMetadata returns the labels, draft status and changed files
+// count of the Merge Request with the given IID.
+func (c *Client) ReviewRequestMetadata(ctx context.Context, mrIID int) (forge.Metadata, error) {
+	var url string
+	if c.ProjectID != 0 {
+		url = fmt.Sprintf("%s/projects/%d/merge_requests/%d", c.baseURL(), c.ProjectID, mrIID)
+	} else {
+		url = fmt.Sprintf("%s/projects/%s/merge_requests/%d", c.baseURL(), c.projectRef(), mrIID)
+	}
+	data, err := c.doGet(ctx, url)
+	if err != nil {
+		return forge.Metadata{}, err
+	}
+
+	var mr struct {
+		Labels       []string `json:"labels"`
+		Draft        bool     `json:"draft"`
+		ChangesCount string   `json:"changes_count"`
+	}
+	if err := json.Unmarshal(data, &mr); err != nil {
+		return forge.Metadata{}, errors.E(err, "unmarshaling MR")
+	}
+
+	md := forge.Metadata{
+		Draft:        mr.Draft,
+		ChangedFiles: parseChangesCount(mr.ChangesCount),
+	}
+	for _, l := range mr.Labels {
+		md.Labels = append(md.Labels, forge.Label{Name: l})
+	}
+	return md, nil
+}
+
+// parseChangesCount parses the changes_count field of a Merge Request.
+// Gitlab caps the count and reports it as, for example, "1000+".
+func parseChangesCount(count string) int {
+	n, err := strconv.Atoi(strings.TrimSuffix(count, "+"))
+	if err != nil {
+		return 0
+	}
+	return n
+}
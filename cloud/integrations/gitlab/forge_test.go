@@ -0,0 +1,62 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package gitlab_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+)
+
+func TestGitlabReviewRequestMetadata(t *testing.T) {
+	const (
+		projectID = 1
+		mrIID     = 7
+	)
+
+	for _, tc := range []struct {
+		changesCount string
+		want         int
+	}{
+		{changesCount: "3", want: 3},
+		{changesCount: "1000+", want: 1000},
+		{changesCount: "", want: 0},
+	} {
+		tc := tc
+		t.Run(fmt.Sprintf("changes_count=%q", tc.changesCount), func(t *testing.T) {
+			mux, client := setup(t, projectID)
+			endpoint := fmt.Sprintf("/api/v4/projects/%d/merge_requests/%d", projectID, mrIID)
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(t, "GET", r)
+				if r.URL.Path != endpoint {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{
+					"iid": %d,
+					"labels": ["infra", "prod"],
+					"draft": true,
+					"changes_count": %q
+				}`, mrIID, tc.changesCount)
+			})
+
+			got, err := client.ReviewRequestMetadata(context.Background(), mrIID)
+			assert.NoError(t, err)
+
+			want := forge.Metadata{
+				Labels:       []forge.Label{{Name: "infra"}, {Name: "prod"}},
+				Draft:        true,
+				ChangedFiles: tc.want,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("-(want) +(got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "queue" {
+  content = <<-EOF
+package queue // import "github.com/terramate-io/terramate/cloud/queue"
+
+Package queue implements an on-disk queue of Terramate Cloud sync payloads that
+could not be delivered because the Cloud API was unreachable.
+
+const DefaultTTL = 7 * 24 * time.Hour ...
+const ErrPush errors.Kind = "queueing cloud payload" ...
+const Dir = ".terramate/cloud-queue"
+func IsUnreachable(err error) bool
+func Send(ctx context.Context, client *cloud.Client, orgUUID resources.UUID, entry Entry) error
+type DeploymentStackStatus struct{ ... }
+type Entry struct{ ... }
+type Kind string
+    const KindDrift Kind = "drift" ...
+type Option func(*Queue)
+    func WithClock(now func() time.Time) Option
+    func WithMaxSize(size int64) Option
+    func WithTTL(ttl time.Duration) Option
+type Queue struct{ ... }
+    func ForProject(root *config.Root, opts ...Option) *Queue
+    func New(rootdir string, opts ...Option) *Queue
+EOF
+
+  filename = "${path.module}/mock-queue.ignore"
+}
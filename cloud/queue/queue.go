@@ -0,0 +1,406 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package queue implements an on-disk queue of Terramate Cloud sync payloads
+// that could not be delivered because the Cloud API was unreachable.
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/deployment"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// Dir is the directory, relative to the project root, where queued payloads
+// are saved.
+const Dir = ".terramate/cloud-queue"
+
+const (
+	// DefaultTTL is the default time a payload is kept in the queue.
+	DefaultTTL = 7 * 24 * time.Hour
+
+	// DefaultMaxSize is the default maximum size, in bytes, of the queue.
+	DefaultMaxSize int64 = 10 << 20
+)
+
+const (
+	// ErrPush indicates that a payload could not be queued.
+	ErrPush errors.Kind = "queueing cloud payload"
+
+	// ErrList indicates that the queued payloads could not be listed.
+	ErrList errors.Kind = "listing queued cloud payloads"
+
+	// ErrTooLarge indicates that a payload exceeds the maximum queue size.
+	ErrTooLarge errors.Kind = "cloud payload exceeds the queue size limit"
+)
+
+// Kind is the kind of a queued payload.
+type Kind string
+
+const (
+	// KindDrift is a drift status created with [cloud.Client.CreateStackDrift].
+	KindDrift Kind = "drift"
+
+	// KindDeploymentStatus is a deployment status update sent with
+	// [cloud.Client.UpdateDeploymentStacks].
+	KindDeploymentStatus Kind = "deployment-status"
+
+	// KindDeployment is a deployment created with
+	// [cloud.Client.CreateDeploymentStacks].
+	KindDeployment Kind = "deployment"
+
+	// KindDeploymentStackStatus is a status update of a stack of a queued
+	// deployment. Its payload is a [DeploymentStackStatus].
+	KindDeploymentStackStatus Kind = "deployment-stack-status"
+)
+
+// DeploymentStackStatus is a status update of a stack of a deployment which
+// was queued before being created, so the stack is identified by its meta ID
+// instead of its cloud ID, which is looked up when the update is sent.
+type DeploymentStackStatus struct {
+	Repository string                      `json:"repository"`
+	Target     string                      `json:"target,omitempty"`
+	MetaID     string                      `json:"meta_id"`
+	Status     deployment.Status           `json:"status"`
+	Details    *resources.ChangesetDetails `json:"changeset_details,omitempty"`
+}
+
+// Entry is a queued payload.
+type Entry struct {
+	// ID is the unique identifier of the entry. IDs sort by creation time.
+	ID string `json:"id"`
+	// Kind is the kind of the payload.
+	Kind Kind `json:"kind"`
+	// CreatedAt is the time the entry was queued.
+	CreatedAt time.Time `json:"created_at"`
+	// OrgUUID is the organization the payload belongs to.
+	// It's empty if the payload was queued before the organization could be
+	// looked up, in which case the OrgName is used.
+	OrgUUID resources.UUID `json:"org_uuid"`
+	// OrgName is the name of the organization the payload belongs to.
+	OrgName string `json:"org_name,omitempty"`
+	// DeploymentUUID is the deployment of a [KindDeploymentStatus] payload.
+	DeploymentUUID resources.UUID `json:"deployment_uuid,omitempty"`
+	// Payload is the JSON encoded request payload.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Queue is an on-disk queue of cloud payloads.
+type Queue struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+	now     func() time.Time
+}
+
+// Option is a functional option for the queue.
+type Option func(*Queue)
+
+// WithTTL sets the time payloads are kept in the queue.
+func WithTTL(ttl time.Duration) Option {
+	return func(q *Queue) {
+		q.ttl = ttl
+	}
+}
+
+// WithMaxSize sets the maximum size, in bytes, of the queue.
+func WithMaxSize(size int64) Option {
+	return func(q *Queue) {
+		q.maxSize = size
+	}
+}
+
+// WithClock sets the function used to obtain the current time.
+func WithClock(now func() time.Time) Option {
+	return func(q *Queue) {
+		q.now = now
+	}
+}
+
+// New creates a queue stored in the Dir of the given project root.
+func New(rootdir string, opts ...Option) *Queue {
+	q := &Queue{
+		dir:     filepath.Join(rootdir, filepath.FromSlash(Dir)),
+		ttl:     DefaultTTL,
+		maxSize: DefaultMaxSize,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// ForProject creates the queue of the project with the TTL and maximum size
+// set in the terramate.config.cloud.queue block, if any. The given options
+// are applied after the configured ones.
+func ForProject(root *config.Root, opts ...Option) *Queue {
+	var cfgopts []Option
+	cfg := root.CloudQueueConfig()
+	if cfg.TTL > 0 {
+		cfgopts = append(cfgopts, WithTTL(cfg.TTL))
+	}
+	if cfg.MaxSize > 0 {
+		cfgopts = append(cfgopts, WithMaxSize(cfg.MaxSize))
+	}
+	return New(root.HostDir(), append(cfgopts, opts...)...)
+}
+
+// Push adds the payload to the queue.
+//
+// Expired entries are removed and, if the queue would exceed its maximum
+// size, the oldest entries are dropped to make room for the new one.
+func (q *Queue) Push(kind Kind, orgUUID, deploymentUUID resources.UUID, payload any) (Entry, error) {
+	return q.push(kind, orgUUID, "", deploymentUUID, payload)
+}
+
+// PushForOrgName adds the payload to the queue like [Queue.Push], but for an
+// organization whose UUID is unknown, because the Terramate Cloud was
+// unreachable when it had to be looked up.
+func (q *Queue) PushForOrgName(kind Kind, orgName string, deploymentUUID resources.UUID, payload any) (Entry, error) {
+	return q.push(kind, "", orgName, deploymentUUID, payload)
+}
+
+func (q *Queue) push(kind Kind, orgUUID resources.UUID, orgName string, deploymentUUID resources.UUID, payload any) (Entry, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	now := q.now()
+	entry := Entry{
+		ID:             newID(now),
+		Kind:           kind,
+		CreatedAt:      now.UTC(),
+		OrgUUID:        orgUUID,
+		OrgName:        orgName,
+		DeploymentUUID: deploymentUUID,
+		Payload:        data,
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	if int64(len(content)) > q.maxSize {
+		return Entry{}, errors.E(ErrTooLarge, "payload has %d bytes, limit is %d", len(content), q.maxSize)
+	}
+
+	files, err := q.files()
+	if err != nil {
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	size := int64(len(content))
+	for _, f := range files {
+		size += f.size
+	}
+	for i := 0; size > q.maxSize && i < len(files); i++ {
+		log.Warn().
+			Str("entry", files[i].id).
+			Msg("cloud queue size limit reached: dropping oldest payload")
+		if err := q.Remove(files[i].id); err != nil {
+			return Entry{}, errors.E(ErrPush, err)
+		}
+		size -= files[i].size
+	}
+
+	if err := q.init(); err != nil {
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	path := q.path(entry.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return Entry{}, errors.E(ErrPush, err)
+	}
+	return entry, nil
+}
+
+// List returns the queued entries, oldest first. Expired entries are removed
+// from the queue and not returned.
+func (q *Queue) List() ([]Entry, error) {
+	files, err := q.files()
+	if err != nil {
+		return nil, errors.E(ErrList, err)
+	}
+	entries := make([]Entry, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(q.path(f.id))
+		if err != nil {
+			return nil, errors.E(ErrList, err)
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, errors.E(ErrList, err, "decoding %s", q.path(f.id))
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Remove removes the entry with the given ID from the queue.
+func (q *Queue) Remove(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return errors.E("invalid queued cloud payload ID %q", id)
+	}
+	err := os.Remove(q.path(id))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.E(err, "removing queued cloud payload %s", id)
+	}
+	return nil
+}
+
+// Send sends the entry payload to the Terramate Cloud, for the organization
+// with the given UUID. See [Entry.OrgUUID].
+func Send(ctx context.Context, client *cloud.Client, orgUUID resources.UUID, entry Entry) error {
+	switch entry.Kind {
+	case KindDrift:
+		var payload resources.DriftStackPayloadRequest
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return errors.E(err, "decoding queued drift payload %s", entry.ID)
+		}
+		_, err := client.CreateStackDrift(ctx, orgUUID, payload)
+		return err
+	case KindDeploymentStatus:
+		var payload resources.UpdateDeploymentStacks
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return errors.E(err, "decoding queued deployment payload %s", entry.ID)
+		}
+		return client.UpdateDeploymentStacks(ctx, orgUUID, entry.DeploymentUUID, payload)
+	case KindDeployment:
+		var payload resources.DeploymentStacksPayloadRequest
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return errors.E(err, "decoding queued deployment payload %s", entry.ID)
+		}
+		_, err := client.CreateDeploymentStacks(ctx, orgUUID, entry.DeploymentUUID, payload)
+		return err
+	case KindDeploymentStackStatus:
+		var payload DeploymentStackStatus
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return errors.E(err, "decoding queued deployment stack payload %s", entry.ID)
+		}
+		st, found, err := client.GetStack(ctx, orgUUID, payload.Repository, payload.Target, payload.MetaID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.E("stack %s of queued payload %s not found", payload.MetaID, entry.ID)
+		}
+		return client.UpdateDeploymentStacks(ctx, orgUUID, entry.DeploymentUUID, resources.UpdateDeploymentStacks{
+			Stacks: []resources.UpdateDeploymentStack{
+				{
+					StackID: st.ID,
+					Status:  payload.Status,
+					Details: payload.Details,
+				},
+			},
+		})
+	default:
+		return errors.E("queued payload %s has unknown kind %q", entry.ID, entry.Kind)
+	}
+}
+
+// IsUnreachable tells if the error returned by a cloud request indicates
+// that the Terramate Cloud could not be reached, as opposed to the request
+// being rejected by it. Only the failures to resolve the Cloud address or to
+// connect to it are considered, as the payload of any other failure may have
+// been received already.
+func IsUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return stderrors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+type file struct {
+	id   string
+	size int64
+}
+
+// files returns the queued files sorted by ID, removing the expired ones.
+func (q *Queue) files() ([]file, error) {
+	dirEntries, err := os.ReadDir(q.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []file
+	for _, dirEntry := range dirEntries {
+		id, ok := strings.CutSuffix(dirEntry.Name(), ".json")
+		if !ok || dirEntry.IsDir() {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if q.expired(id, info) {
+			log.Debug().Str("entry", id).Msg("removing expired cloud queue payload")
+			if err := q.Remove(id); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		files = append(files, file{id: id, size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].id < files[j].id
+	})
+	return files, nil
+}
+
+func (q *Queue) expired(id string, info os.FileInfo) bool {
+	createdAt := info.ModTime()
+	if stamp, _, ok := strings.Cut(id, "-"); ok {
+		if t, err := time.Parse(idTimeFormat, stamp); err == nil {
+			createdAt = t
+		}
+	}
+	return q.now().Sub(createdAt) > q.ttl
+}
+
+func (q *Queue) init() error {
+	if err := os.MkdirAll(q.dir, 0755); err != nil {
+		return err
+	}
+	gitignore := filepath.Join(q.dir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		return os.WriteFile(gitignore, []byte("*\n"), 0644)
+	}
+	return nil
+}
+
+func (q *Queue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+const idTimeFormat = "20060102T150405.000000000Z"
+
+func newID(t time.Time) string {
+	var suffix [4]byte
+	_, _ = rand.Read(suffix[:])
+	return t.UTC().Format(idTimeFormat) + "-" + hex.EncodeToString(suffix[:])
+}
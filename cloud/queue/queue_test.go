@@ -0,0 +1,301 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package queue_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/deployment"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestQueuePushListRemove(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	q := queue.New(rootdir)
+
+	entries, err := q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0, len(entries))
+
+	payload := resources.UpdateDeploymentStacks{
+		Stacks: []resources.UpdateDeploymentStack{
+			{StackID: 1, Status: deployment.OK},
+		},
+	}
+	first, err := q.Push(queue.KindDeploymentStatus, "org", "deployment", payload)
+	assert.NoError(t, err)
+	second, err := q.Push(queue.KindDrift, "org", "", map[string]string{"a": "b"})
+	assert.NoError(t, err)
+
+	gitignore, err := os.ReadFile(filepath.Join(rootdir, filepath.FromSlash(queue.Dir), ".gitignore"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "*\n", string(gitignore))
+
+	entries, err = q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(entries))
+	assert.EqualStrings(t, first.ID, entries[0].ID)
+	assert.EqualStrings(t, second.ID, entries[1].ID)
+	assert.EqualStrings(t, string(queue.KindDeploymentStatus), string(entries[0].Kind))
+	assert.EqualStrings(t, "deployment", string(entries[0].DeploymentUUID))
+
+	var got resources.UpdateDeploymentStacks
+	assert.NoError(t, json.Unmarshal(entries[0].Payload, &got))
+	assert.EqualInts(t, 1, int(got.Stacks[0].StackID))
+
+	assert.NoError(t, q.Remove(first.ID))
+	entries, err = q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(entries))
+	assert.EqualStrings(t, second.ID, entries[0].ID)
+
+	assert.Error(t, q.Remove("../entry"))
+}
+
+func TestQueueTTL(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	clock := func() time.Time { return now }
+	q := queue.New(rootdir, queue.WithTTL(time.Hour), queue.WithClock(clock))
+
+	_, err := q.Push(queue.KindDrift, "org", "", "old")
+	assert.NoError(t, err)
+
+	now = now.Add(30 * time.Minute)
+	recent, err := q.Push(queue.KindDrift, "org", "", "recent")
+	assert.NoError(t, err)
+
+	now = now.Add(45 * time.Minute)
+	entries, err := q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(entries))
+	assert.EqualStrings(t, recent.ID, entries[0].ID)
+}
+
+func TestQueueMaxSize(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	q := queue.New(rootdir, queue.WithClock(clock))
+	entry, err := q.Push(queue.KindDrift, "org", "", "payload")
+	assert.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(rootdir, filepath.FromSlash(queue.Dir), entry.ID+".json"))
+	assert.NoError(t, err)
+
+	// room for two entries only.
+	q = queue.New(rootdir, queue.WithClock(clock), queue.WithMaxSize(2*info.Size()+1))
+	var ids []string
+	for i := 0; i < 3; i++ {
+		entry, err := q.Push(queue.KindDrift, "org", "", "payload")
+		assert.NoError(t, err)
+		ids = append(ids, entry.ID)
+	}
+
+	entries, err := q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(entries))
+	assert.EqualStrings(t, ids[1], entries[0].ID)
+	assert.EqualStrings(t, ids[2], entries[1].ID)
+
+	_, err = q.Push(queue.KindDrift, "org", "", string(make([]byte, 3*info.Size())))
+	assert.IsError(t, err, errors.E(queue.ErrTooLarge))
+}
+
+func TestQueueSend(t *testing.T) {
+	t.Parallel()
+
+	paths := make(chan string, 1)
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		paths <- r.URL.Path
+		w.WriteHeader(stdhttp.StatusNoContent)
+	}))
+	defer server.Close()
+
+	q := queue.New(t.TempDir())
+	entry, err := q.Push(queue.KindDeploymentStatus, "org", "deployment", resources.UpdateDeploymentStacks{
+		Stacks: []resources.UpdateDeploymentStack{
+			{StackID: 1, Status: deployment.OK},
+		},
+	})
+	assert.NoError(t, err)
+
+	client := cloud.NewClient(cloud.WithBaseURL(server.URL))
+	assert.NoError(t, queue.Send(context.Background(), client, "org", entry))
+	assert.EqualStrings(t, fmt.Sprintf("%s/org/deployment/stacks", cloud.DeploymentsPath), <-paths)
+
+	server.Close()
+	err = queue.Send(context.Background(), client, "org", entry)
+	assert.Error(t, err)
+	assert.IsTrue(t, queue.IsUnreachable(err), "error must be unreachable: %v", err)
+}
+
+func TestQueueSendQueuedDeployment(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		method, path string
+		body         string
+	}
+	requests := make(chan request, 3)
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{method: r.Method, path: r.URL.Path, body: string(body)}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == stdhttp.MethodGet:
+			assert.EqualStrings(t, "stack-id", r.URL.Query().Get("meta_id"))
+			_, _ = w.Write([]byte(`{"stacks": [{"stack_id": 42, "meta_id": "stack-id", "repository": "github.com/terramate-io/terramate"}], "paginated_result": {"total": 1, "page": 1, "per_page": 10}}`))
+		case strings.HasSuffix(r.URL.Path, "/stacks") && r.Method == stdhttp.MethodPost:
+			_, _ = w.Write([]byte(`[{"stack_id": 42, "meta_id": "stack-id", "status": "pending"}]`))
+		default:
+			w.WriteHeader(stdhttp.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	q := queue.New(t.TempDir())
+	created, err := q.PushForOrgName(queue.KindDeployment, "terramate", "deployment", resources.DeploymentStacksPayloadRequest{
+		Workdir: project.NewPath("/"),
+		Stacks: resources.DeploymentStackRequests{
+			{
+				Stack: resources.Stack{
+					MetaID:        "stack-id",
+					Repository:    "github.com/terramate-io/terramate",
+					DefaultBranch: "main",
+					Path:          "/stack",
+				},
+				DeploymentCommand: "terraform apply",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "", string(created.OrgUUID))
+	assert.EqualStrings(t, "terramate", created.OrgName)
+
+	updated, err := q.PushForOrgName(queue.KindDeploymentStackStatus, "terramate", "deployment", queue.DeploymentStackStatus{
+		Repository: "github.com/terramate-io/terramate",
+		MetaID:     "stack-id",
+		Status:     deployment.OK,
+	})
+	assert.NoError(t, err)
+
+	client := cloud.NewClient(cloud.WithBaseURL(server.URL))
+	assert.NoError(t, queue.Send(context.Background(), client, "org", created))
+	req := <-requests
+	assert.EqualStrings(t, stdhttp.MethodPost, req.method)
+	assert.EqualStrings(t, fmt.Sprintf("%s/org/deployment/stacks", cloud.DeploymentsPath), req.path)
+
+	assert.NoError(t, queue.Send(context.Background(), client, "org", updated))
+	req = <-requests
+	assert.EqualStrings(t, stdhttp.MethodGet, req.method)
+	assert.EqualStrings(t, fmt.Sprintf("%s/org", cloud.StacksPath), req.path)
+	req = <-requests
+	assert.EqualStrings(t, fmt.Sprintf("%s/org/deployment/stacks", cloud.DeploymentsPath), req.path)
+
+	var got resources.UpdateDeploymentStacks
+	assert.NoError(t, json.Unmarshal([]byte(req.body), &got))
+	assert.EqualInts(t, 1, len(got.Stacks))
+	assert.EqualInts(t, 42, int(got.Stacks[0].StackID))
+	assert.EqualStrings(t, deployment.OK.String(), got.Stacks[0].Status.String())
+}
+
+func TestIsUnreachable(t *testing.T) {
+	t.Parallel()
+
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Post", URL: "https://api.terramate.io", Err: err}
+	}
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no error"},
+		{name: "rejected request", err: errors.E("rejected")},
+		{name: "timeout", err: urlErr(context.DeadlineExceeded)},
+		{name: "canceled", err: urlErr(context.Canceled)},
+		{name: "connection reset", err: urlErr(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})},
+		{name: "EOF", err: urlErr(io.EOF)},
+		{
+			name: "connection refused",
+			err:  urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}),
+			want: true,
+		},
+		{
+			name: "DNS failure",
+			err:  urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "api.terramate.io", IsNotFound: true}}),
+			want: true,
+		},
+		{
+			name: "wrapped dial failure",
+			err:  errors.E(urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), "request failed"),
+			want: true,
+		},
+	} {
+		assert.IsTrue(t, queue.IsUnreachable(tc.err) == tc.want,
+			"%s: IsUnreachable(%v) must be %t", tc.name, tc.err, tc.want)
+	}
+}
+
+func TestQueueForProject(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.RootEntry().CreateFile("terramate.tm", `terramate {
+  config {
+    cloud {
+      queue {
+        ttl      = "1h"
+        max_size = 1000
+      }
+    }
+  }
+}
+`)
+
+	now := time.Now()
+	q := queue.ForProject(s.Config())
+	_, err := q.Push(queue.KindDrift, "org", "", strings.Repeat("a", 1000))
+	assert.IsError(t, err, errors.E(queue.ErrTooLarge))
+
+	entry, err := q.Push(queue.KindDrift, "org", "", "a")
+	assert.NoError(t, err)
+
+	q = queue.ForProject(s.Config(), queue.WithClock(func() time.Time {
+		return now.Add(2 * time.Hour)
+	}))
+	entries, err := q.List()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0, len(entries), "entry %s must be expired", entry.ID)
+}
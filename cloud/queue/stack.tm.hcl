@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package queue // import \"github.com/terramate-io/terramate/cloud/queue\""
+  description = "package queue // import \"github.com/terramate-io/terramate/cloud/queue\"\n\nPackage queue implements an on-disk queue of Terramate Cloud sync payloads that\ncould not be delivered because the Cloud API was unreachable.\n\nconst DefaultTTL = 7 * 24 * time.Hour ...\nconst ErrPush errors.Kind = \"queueing cloud payload\" ...\nconst Dir = \".terramate/cloud-queue\"\nfunc IsUnreachable(err error) bool\nfunc Send(ctx context.Context, client *cloud.Client, orgUUID resources.UUID, entry Entry) error\ntype DeploymentStackStatus struct{ ... }\ntype Entry struct{ ... }\ntype Kind string\n    const KindDrift Kind = \"drift\" ...\ntype Option func(*Queue)\n    func WithClock(now func() time.Time) Option\n    func WithMaxSize(size int64) Option\n    func WithTTL(ttl time.Duration) Option\ntype Queue struct{ ... }\n    func ForProject(root *config.Root, opts ...Option) *Queue\n    func New(rootdir string, opts ...Option) *Queue"
+  tags        = ["cloud", "golang", "queue"]
+  id          = "03d0009a-7fdc-4795-8b6f-e13ee9e65449"
+}
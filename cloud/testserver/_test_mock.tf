@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "testserver" {
-  content = <<-EOT
+  content = <<-EOF
 package testserver // import "github.com/terramate-io/terramate/cloud/testserver"
 
 Package testserver provides fake Terramate Cloud endpoints for testing purposes.
@@ -40,7 +40,7 @@ func RouterWith(store *cloudstore.Data, enabled map[string]bool) *httprouter.Rou
 type Custom struct{ ... }
 type Handler func(store *cloudstore.Data, w http.ResponseWriter, r *http.Request, ...)
 type Route struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-testserver.ignore"
 }
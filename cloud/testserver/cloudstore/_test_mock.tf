@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cloudstore" {
-  content = <<-EOT
+  content = <<-EOF
 package cloudstore // import "github.com/terramate-io/terramate/cloud/testserver/cloudstore"
 
 Package cloudstore provides the in-memory store used by the fake Terramate Cloud
@@ -20,7 +20,7 @@ type Stack struct{ ... }
 type StackPreview struct{ ... }
 type StackState struct{ ... }
     func NewState() StackState
-EOT
+EOF
 
   filename = "${path.module}/mock-cloudstore.ignore"
 }
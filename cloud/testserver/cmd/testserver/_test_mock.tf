@@ -1,9 +1,9 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "testserver" {
-  content = <<-EOT
+  content = <<-EOF
 Package main implements the cloudmock service.
-EOT
+EOF
 
   filename = "${path.module}/mock-testserver.ignore"
 }
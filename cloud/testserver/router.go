@@ -84,7 +84,6 @@ func RouterAdd(store *cloudstore.Data, router *httprouter.Router, enabled map[st
 
 		router.GET(cloud.StacksPath+"/:orguuid/:stackid/drifts", handler(store, GetStackDrifts))
 
-		// not a real TMC handler, only used by tests to populate the stacks state.
 		router.PUT(cloud.StacksPath+"/:orguuid/:stackuuid", handler(store, PutStack))
 	}
 
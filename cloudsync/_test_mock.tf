@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cloudsync" {
-  content = <<-EOT
+  content = <<-EOF
 package cloudsync // import "github.com/terramate-io/terramate/cloudsync"
 
 Package cloudsync provides helper functions for cloud sync operations.
@@ -14,7 +14,7 @@ func CreateCloudPreview(e *engine.Engine, gitfilter engine.GitFilter, runs []eng
 func DetectCloudMetadata(e *engine.Engine, state *CloudRunState)
 func Logs(logger *zerolog.Logger, e *engine.Engine, run engine.StackRun, ...)
 type CloudRunState struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-cloudsync.ignore"
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/terramate-io/terramate/cloud/api/deployment"
 	"github.com/terramate-io/terramate/cloud/api/resources"
 	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/hcl/eval"
 )
 
 // BeforeRun is called before a cloud run.
@@ -50,9 +51,18 @@ func AfterRun(e *engine.Engine, run engine.StackCloudRun, state *CloudRunState,
 
 // Logs synchronizes the logs of a command with the Terramate Cloud.
 func Logs(logger *zerolog.Logger, e *engine.Engine, run engine.StackRun, state *CloudRunState, logs resources.CommandLogs) {
-	if !e.IsCloudEnabled() {
+	if !e.IsCloudEnabled() || e.IsCloudQueued() {
 		return
 	}
+	var sensitive []string
+	for _, task := range run.Tasks {
+		sensitive = append(sensitive, task.Sensitive...)
+	}
+	if len(sensitive) > 0 {
+		for _, l := range logs {
+			l.Message = eval.Redact(l.Message, sensitive)
+		}
+	}
 	data, _ := json.Marshal(logs)
 	logger.Debug().RawJSON("logs", data).Msg("synchronizing logs")
 	ctx, cancel := context.WithTimeout(context.Background(), cloud.DefaultTimeout)
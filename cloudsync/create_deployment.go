@@ -14,6 +14,7 @@ import (
 	"github.com/terramate-io/terramate/cloud"
 	"github.com/terramate-io/terramate/cloud/api/deployment"
 	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/project"
@@ -109,12 +110,24 @@ func CreateCloudDeployment(e *engine.Engine, wd string, deployRuns []engine.Stac
 				Path:            run.Stack.Dir.String(),
 			},
 			CommitSHA:         deploymentCommitSHA,
-			DeploymentCommand: strings.Join(run.Task.Cmd, " "),
+			DeploymentCommand: strings.Join(run.Task.RedactedCmd(), " "),
 			DeploymentURL:     deploymentURL,
 		})
 	}
+	if e.IsCloudQueued() {
+		if !enqueue(logger, e, queue.KindDeployment, state.RunUUID, payload) {
+			return e.HandleCloudCriticalError(errors.E("failed to queue cloud deployment"))
+		}
+		state.DeploymentQueued = true
+		return nil
+	}
+
 	res, err := e.CloudClient().CreateDeploymentStacks(ctx, orgUUID, state.RunUUID, payload)
 	if err != nil {
+		if enqueueIfUnreachable(logger, e, queue.KindDeployment, state.RunUUID, payload, err) {
+			state.DeploymentQueued = true
+			return nil
+		}
 		logger.Error().
 			Err(err).
 			Msg("failed to create cloud deployment")
@@ -167,12 +180,6 @@ func doCloudSyncDeployment(e *engine.Engine, run engine.StackCloudRun, state *Cl
 		Stringer("status", status).
 		Logger()
 
-	stackID, ok := state.StackCloudID(run.Stack.ID)
-	if !ok {
-		logger.Error().Msg("unable to update deployment status due to invalid API response")
-		return
-	}
-
 	var details *resources.ChangesetDetails
 
 	if run.Task.CloudPlanFile != "" {
@@ -183,6 +190,28 @@ func doCloudSyncDeployment(e *engine.Engine, run engine.StackCloudRun, state *Cl
 		}
 	}
 
+	if state.DeploymentQueued {
+		prettyRepo, err := e.Project().PrettyRepo()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to get pretty repo")
+			return
+		}
+		enqueue(logger, e, queue.KindDeploymentStackStatus, state.RunUUID, queue.DeploymentStackStatus{
+			Repository: prettyRepo,
+			Target:     run.Task.CloudTarget,
+			MetaID:     strings.ToLower(run.Stack.ID),
+			Status:     status,
+			Details:    details,
+		})
+		return
+	}
+
+	stackID, ok := state.StackCloudID(run.Stack.ID)
+	if !ok {
+		logger.Error().Msg("unable to update deployment status due to invalid API response")
+		return
+	}
+
 	payload := resources.UpdateDeploymentStacks{
 		Stacks: []resources.UpdateDeploymentStack{
 			{
@@ -199,6 +228,9 @@ func doCloudSyncDeployment(e *engine.Engine, run engine.StackCloudRun, state *Cl
 	defer cancel()
 	err := e.CloudClient().UpdateDeploymentStacks(ctx, orgUUID, state.RunUUID, payload)
 	if err != nil {
+		if enqueueIfUnreachable(logger, e, queue.KindDeploymentStatus, state.RunUUID, payload, err) {
+			return
+		}
 		logger.Err(err).Str("stack_id", run.Stack.ID).Msg("failed to update deployment status for each")
 	} else {
 		logger.Debug().Msg("deployment status synced successfully")
@@ -11,6 +11,7 @@ import (
 	"github.com/terramate-io/terramate/cloud"
 	"github.com/terramate-io/terramate/cloud/api/drift"
 	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/ui/tui/clitest"
@@ -23,7 +24,7 @@ func cloudSyncDriftStatus(e *engine.Engine, run engine.StackCloudRun, state *Clo
 		Str("action", "cloudSyncDriftStatus").
 		Stringer("stack", st.Dir).
 		Int("exit_code", res.ExitCode).
-		Strs("command", run.Task.Cmd).
+		Strs("command", run.Task.RedactedCmd()).
 		Err(err).
 		Logger()
 
@@ -64,7 +65,7 @@ func cloudSyncDriftStatus(e *engine.Engine, run engine.StackCloudRun, state *Clo
 		return
 	}
 
-	_, err = e.CloudClient().CreateStackDrift(ctx, e.CloudState().Org.UUID, resources.DriftStackPayloadRequest{
+	payload := resources.DriftStackPayloadRequest{
 		Stack: resources.Stack{
 			Repository:      prettyRepo,
 			Target:          run.Task.CloudTarget,
@@ -81,10 +82,19 @@ func cloudSyncDriftStatus(e *engine.Engine, run engine.StackCloudRun, state *Clo
 		Metadata:   state.Metadata,
 		StartedAt:  res.StartedAt,
 		FinishedAt: res.FinishedAt,
-		Command:    run.Task.Cmd,
-	})
+		Command:    run.Task.RedactedCmd(),
+	}
 
+	if e.IsCloudQueued() {
+		enqueue(logger, e, queue.KindDrift, "", payload)
+		return
+	}
+
+	_, err = e.CloudClient().CreateStackDrift(ctx, e.CloudState().Org.UUID, payload)
 	if err != nil {
+		if enqueueIfUnreachable(logger, e, queue.KindDrift, "", payload, err) {
+			return
+		}
 		logger.Error().Err(err).Msg(clitest.CloudSyncDriftFailedMessage)
 	} else {
 		logger.Debug().Msg("synced drift_status successfully")
@@ -23,7 +23,7 @@ func CreateCloudPreview(e *engine.Engine, gitfilter engine.GitFilter, runs []eng
 	for i, run := range runs {
 		previewRuns[i] = cloud.RunContext{
 			StackID: run.Stack.ID,
-			Cmd:     run.Task.Cmd,
+			Cmd:     run.Task.RedactedCmd(),
 		}
 	}
 
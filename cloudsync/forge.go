@@ -0,0 +1,49 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cloudsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/integrations/forge"
+)
+
+// forgeTimeout is the maximum time spent gathering review request metadata.
+const forgeTimeout = 30 * time.Second
+
+// enrichReviewRequest sets the labels, draft status and changed files count
+// of the review request from the metadata gathered from the forge.
+// Failures are logged and leave the review request unchanged.
+func enrichReviewRequest(logger zerolog.Logger, f forge.Forge, rr *resources.ReviewRequest) {
+	if rr == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), forgeTimeout)
+	defer cancel()
+
+	md, err := f.ReviewRequestMetadata(ctx, rr.Number)
+	if err != nil {
+		logger.Warn().
+			Err(err).
+			Str("forge", f.Name()).
+			Int("number", rr.Number).
+			Msg("failed to gather review request metadata")
+		return
+	}
+	if len(md.Labels) > 0 {
+		rr.Labels = make([]resources.Label, len(md.Labels))
+		for i, l := range md.Labels {
+			rr.Labels[i] = resources.Label{
+				Name:        l.Name,
+				Color:       l.Color,
+				Description: l.Description,
+			}
+		}
+	}
+	rr.Draft = md.Draft
+	rr.ChangedFilesCount = md.ChangedFiles
+}
@@ -110,6 +110,10 @@ func DetectCloudMetadata(e *engine.Engine, state *CloudRunState) {
 		detectGitlabMetadata(e, repo.Owner, repo.Name, state)
 	case ci.PlatformBitBucket:
 		detectBitbucketMetadata(e, repo.Owner, repo.Name, state)
+	case ci.PlatformGerrit:
+		setGerritMetadata(md)
+	case ci.PlatformAzureDevops:
+		setAzureDevopsMetadata(md)
 	case ci.PlatformLocal:
 		// in case of running locally, we collect the metadata based on the repository host.
 		switch repo.Host {
@@ -249,6 +253,12 @@ func detectGithubMetadata(e *engine.Engine, owner, reponame string, state *Cloud
 	}
 
 	state.ReviewRequest = newGithubReviewRequest(e, pull, reviews, checks, merged, reviewDecision, state)
+	enrichReviewRequest(logger, &github.Forge{
+		Client: githubClient,
+		Owner:  owner,
+		Repo:   reponame,
+		Pull:   pull,
+	}, state.ReviewRequest)
 
 	// New grouping structure.
 	md.GithubPullRequest = metadata.NewGithubPullRequest(pull, reviews)
@@ -349,6 +359,7 @@ func detectGitlabMetadata(e *engine.Engine, group string, projectName string, st
 
 	state.RREvent.CommitSHA = mr.SHA
 	state.ReviewRequest = newGitlabReviewRequest(e, mr, state)
+	enrichReviewRequest(logger, &client, state.ReviewRequest)
 
 	reviewers, err := client.MRReviewers(ctx, mr.IID)
 	if err != nil {
@@ -460,6 +471,7 @@ func detectBitbucketMetadata(e *engine.Engine, owner, reponame string, state *Cl
 	state.RREvent.PushedAt = &buildNumber
 	state.RREvent.CommitSHA = commitHash
 	state.ReviewRequest = newBitbucketReviewRequest(e, pullRequest)
+	enrichReviewRequest(logger, &client, state.ReviewRequest)
 
 	// New grouping structure.
 	md.BitbucketPullRequest = metadata.NewBitbucketPullRequest(pullRequest)
@@ -506,6 +518,53 @@ func setGitlabCIMetadata(_ *engine.Engine, md *resources.DeploymentMetadata, sta
 	md.GitlabCICDMergeRequestApproved = mrApproved
 }
 
+func setGerritMetadata(md *resources.DeploymentMetadata) {
+	if os.Getenv("ZUUL_PROJECT") != "" {
+		md.GerritProject = os.Getenv("ZUUL_PROJECT")
+		md.GerritBranch = os.Getenv("ZUUL_BRANCH")
+		md.GerritChangeNumber = os.Getenv("ZUUL_CHANGE")
+		md.GerritChangeURL = os.Getenv("ZUUL_CHANGE_URL")
+		md.GerritPatchsetNumber = os.Getenv("ZUUL_PATCHSET")
+		md.GerritPatchsetRevision = os.Getenv("ZUUL_COMMIT")
+		md.ZuulPipeline = os.Getenv("ZUUL_PIPELINE")
+		md.ZuulBuildUUID = os.Getenv("ZUUL_UUID")
+		return
+	}
+	md.GerritProject = os.Getenv("GERRIT_PROJECT")
+	md.GerritBranch = os.Getenv("GERRIT_BRANCH")
+	md.GerritChangeNumber = os.Getenv("GERRIT_CHANGE_NUMBER")
+	md.GerritChangeURL = os.Getenv("GERRIT_CHANGE_URL")
+	md.GerritPatchsetNumber = os.Getenv("GERRIT_PATCHSET_NUMBER")
+	md.GerritPatchsetRevision = os.Getenv("GERRIT_PATCHSET_REVISION")
+}
+
+func setAzureDevopsMetadata(md *resources.DeploymentMetadata) {
+	md.AzureDevopsOrganizationURL = os.Getenv("SYSTEM_COLLECTIONURI")
+	md.AzureDevopsProject = os.Getenv("SYSTEM_TEAMPROJECT")
+	md.AzureDevopsRepository = os.Getenv("BUILD_REPOSITORY_NAME")
+	md.AzureDevopsRepositoryProvider = os.Getenv("BUILD_REPOSITORY_PROVIDER")
+	md.AzureDevopsPipelineID = os.Getenv("SYSTEM_DEFINITIONID")
+	md.AzureDevopsPipelineName = os.Getenv("BUILD_DEFINITIONNAME")
+	md.AzureDevopsBuildID = os.Getenv("BUILD_BUILDID")
+	md.AzureDevopsBuildNumber = os.Getenv("BUILD_BUILDNUMBER")
+	md.AzureDevopsBuildReason = os.Getenv("BUILD_REASON")
+	md.AzureDevopsJobAttempt = os.Getenv("SYSTEM_JOBATTEMPT")
+	md.AzureDevopsRequestedFor = os.Getenv("BUILD_REQUESTEDFOR")
+	md.AzureDevopsSourceBranch = os.Getenv("BUILD_SOURCEBRANCH")
+	md.AzureDevopsSourceVersion = os.Getenv("BUILD_SOURCEVERSION")
+	md.AzureDevopsPullRequestID = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")
+	md.AzureDevopsPullRequestNumber = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER")
+	md.AzureDevopsPullRequestSourceBranch = os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH")
+	md.AzureDevopsPullRequestTargetBranch = os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH")
+	md.AzureDevopsPullRequestSourceCommit = os.Getenv("SYSTEM_PULLREQUEST_SOURCECOMMITID")
+
+	if md.AzureDevopsOrganizationURL != "" && md.AzureDevopsProject != "" && md.AzureDevopsBuildID != "" {
+		md.AzureDevopsBuildURL = fmt.Sprintf("%s/%s/_build/results?buildId=%s",
+			strings.TrimSuffix(md.AzureDevopsOrganizationURL, "/"),
+			url.PathEscape(md.AzureDevopsProject), md.AzureDevopsBuildID)
+	}
+}
+
 func setBitbucketPipelinesMetadata(e *engine.Engine, md *resources.DeploymentMetadata) {
 	md.BitbucketPipelinesBuildNumber = os.Getenv("BITBUCKET_BUILD_NUMBER")
 	md.BitbucketPipelinesPipelineUUID = os.Getenv("BITBUCKET_PIPELINE_UUID")
@@ -620,7 +679,7 @@ func newBitbucketReviewRequest(e *engine.Engine, pr *bitbucket.PR) *resources.Re
 		Title:       pr.Title,
 		Description: pr.Summary.Raw,
 		CommitSHA:   pr.Source.Commit.SHA,
-		Draft:       false, // Bitbucket Cloud does not support draft PRs.
+		Draft:       pr.Draft,
 		CreatedAt:   &createdAt,
 		UpdatedAt:   &updatedAt,
 		Status:      pr.State,
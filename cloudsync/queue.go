@@ -0,0 +1,61 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cloudsync
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
+	"github.com/terramate-io/terramate/engine"
+)
+
+// enqueueIfUnreachable saves the payload in the project cloud queue if the
+// given request error indicates the Terramate Cloud is unreachable, so it can
+// be sent later with `terramate cloud flush`. It returns true if the payload
+// was queued.
+func enqueueIfUnreachable(
+	logger zerolog.Logger,
+	e *engine.Engine,
+	kind queue.Kind,
+	deploymentUUID resources.UUID,
+	payload any,
+	reqErr error,
+) bool {
+	if !queue.IsUnreachable(reqErr) {
+		return false
+	}
+	return enqueue(logger.With().Err(reqErr).Logger(), e, kind, deploymentUUID, payload)
+}
+
+// enqueue saves the payload in the project cloud queue, so it can be sent
+// later with `terramate cloud flush`. If the cloud is in queue mode, the
+// organization UUID is unknown and the payload is queued for the organization
+// name. It returns true if the payload was queued.
+func enqueue(
+	logger zerolog.Logger,
+	e *engine.Engine,
+	kind queue.Kind,
+	deploymentUUID resources.UUID,
+	payload any,
+) bool {
+	q := queue.ForProject(e.Config())
+	org := e.CloudState().Org
+	var (
+		entry queue.Entry
+		err   error
+	)
+	if org.UUID != "" {
+		entry, err = q.Push(kind, org.UUID, deploymentUUID, payload)
+	} else {
+		entry, err = q.PushForOrgName(kind, org.Name, deploymentUUID, payload)
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to queue cloud payload")
+		return false
+	}
+	logger.Warn().
+		Str("entry", entry.ID).
+		Msg("Terramate Cloud is unreachable: payload queued, run `terramate cloud flush` to retry")
+	return true
+}
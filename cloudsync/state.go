@@ -32,6 +32,11 @@ type CloudRunState struct {
 		CommitSHA string
 	}
 	Metadata *resources.DeploymentMetadata
+
+	// DeploymentQueued tells if the deployment creation was queued because
+	// the Terramate Cloud was unreachable, in which case the status updates
+	// of its stacks are queued too.
+	DeploymentQueued bool
 }
 
 // SetMeta2CloudID sets the cloud ID of a stack given its metadata ID.
@@ -41,7 +41,7 @@ func getTerraformChangeset(e *engine.Engine, run engine.StackCloudRun) (*resourc
 		return nil, errors.E(clitest.ErrCloudInvalidTerraformPlanFilePath, "path must be relative to the running stack")
 	}
 
-	absPlanFilePath := filepath.Join(run.Stack.HostDir(e.Config()), planfile)
+	absPlanFilePath := filepath.Join(run.Stack.ExecHostDir(e.Config()), planfile)
 
 	// Terragrunt writes the plan to a temporary directory, so we cannot check for its existence.
 	if !run.Task.UseTerragrunt {
@@ -146,7 +146,7 @@ func runTerraformShow(e *engine.Engine, run engine.StackCloudRun, flags ...strin
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, cmdPath, args...)
-	cmd.Dir = run.Stack.Dir.HostPath(e.Config().HostDir())
+	cmd.Dir = run.Stack.ExecHostDir(e.Config())
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	cmd.Env = env
@@ -1,11 +1,11 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "terramate-ls" {
-  content = <<-EOT
+  content = <<-EOF
 Terramate-ls is a language server. For details on how to use it just run:
 
     terramate-ls --help
-EOT
+EOF
 
   filename = "${path.module}/mock-terramate-ls.ignore"
 }
@@ -1,9 +1,9 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "tgdeps" {
-  content = <<-EOT
+  content = <<-EOF
 Package main implements tgdeps.
-EOT
+EOF
 
   filename = "${path.module}/mock-tgdeps.ignore"
 }
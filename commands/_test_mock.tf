@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "commands" {
-  content = <<-EOT
+  content = <<-EOF
 package commands // import "github.com/terramate-io/terramate/commands"
 
 Package commands define all Terramate commands. All commands must: - Be defined
@@ -10,7 +10,7 @@ declaring all variables that control the command behavior. - Implement the
 commands.Executor interface. - Never abort, panic or exit the process.
 
 type Executor interface{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-commands.ignore"
 }
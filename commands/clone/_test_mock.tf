@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "clone" {
-  content = <<-EOT
+  content = <<-EOF
 package clone // import "github.com/terramate-io/terramate/commands/clone"
 
 Package clone provides the clone command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-clone.ignore"
 }
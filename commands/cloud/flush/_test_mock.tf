@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "flush" {
+  content = <<-EOF
+package flush // import "github.com/terramate-io/terramate/commands/cloud/flush"
+
+Package flush provides the cloud flush command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-flush.ignore"
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package flush provides the cloud flush command.
+package flush
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+)
+
+// Spec is the command specification for the cloud flush command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "cloud flush" }
+
+// Exec executes the cloud flush command.
+// Payloads rejected by the Terramate Cloud are dropped, as retrying them
+// would never succeed. Payloads targeting an organization the credential is
+// not an active member of are kept, to be sent with other credentials.
+// Flushing stops at the first payload that cannot be delivered because the
+// Terramate Cloud is unreachable.
+func (s *Spec) Exec(ctx context.Context) error {
+	logger := log.With().
+		Str("action", "commands/cloud/flush").
+		Logger()
+
+	q := queue.ForProject(s.Engine.Config())
+	entries, err := q.List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		s.Printers.Stdout.Println("No queued payloads.")
+		return nil
+	}
+
+	if err := s.Engine.LoadCredential(); err != nil {
+		return errors.E(err, "failed to load the cloud credentials")
+	}
+
+	orgs := s.Engine.Credential().Organizations()
+
+	var sent, dropped, kept int
+	for _, entry := range entries {
+		logger := logger.With().
+			Str("entry", entry.ID).
+			Str("kind", string(entry.Kind)).
+			Logger()
+
+		org, found := lookupOrg(orgs, entry)
+		if !found || (org.Status != "active" && org.Status != "trusted") {
+			orgName := string(entry.OrgUUID)
+			if orgName == "" {
+				orgName = entry.OrgName
+			}
+			s.Printers.Stderr.WarnWithDetails(
+				fmt.Sprintf("keeping %s payload %s queued", entry.Kind, entry.ID),
+				errors.E(cloud.ErrWrongOrg,
+					"payload targets organization %s which you are not an active member of", orgName))
+			kept++
+			continue
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, cloud.DefaultTimeout)
+		err := queue.Send(reqCtx, s.Engine.CloudClient(), org.UUID, entry)
+		cancel()
+
+		if queue.IsUnreachable(err) {
+			return errors.E(err, "Terramate Cloud is unreachable: %d payloads sent, %d remaining",
+				sent, len(entries)-sent-dropped)
+		}
+		if err != nil {
+			s.Printers.Stderr.WarnWithDetails(
+				fmt.Sprintf("dropping %s payload %s rejected by Terramate Cloud", entry.Kind, entry.ID), err)
+			dropped++
+		} else {
+			logger.Debug().Msg("queued payload sent")
+			sent++
+		}
+		if err := q.Remove(entry.ID); err != nil {
+			return err
+		}
+	}
+
+	if kept > 0 {
+		s.Printers.Stdout.Println(fmt.Sprintf("%d payloads sent, %d dropped, %d kept for other organizations.", sent, dropped, kept))
+		return nil
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("%d payloads sent, %d dropped.", sent, dropped))
+	return nil
+}
+
+// lookupOrg looks up the organization of the entry, by name if the entry was
+// queued before its UUID could be looked up.
+func lookupOrg(orgs resources.MemberOrganizations, entry queue.Entry) (resources.MemberOrganization, bool) {
+	if entry.OrgUUID != "" {
+		return orgs.LookupByUUID(entry.OrgUUID)
+	}
+	for _, org := range orgs {
+		if strings.EqualFold(org.Name, entry.OrgName) {
+			return org, true
+		}
+	}
+	return resources.MemberOrganization{}, false
+}
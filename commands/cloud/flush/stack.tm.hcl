@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package flush // import \"github.com/terramate-io/terramate/commands/cloud/flush\""
+  description = "package flush // import \"github.com/terramate-io/terramate/commands/cloud/flush\"\n\nPackage flush provides the cloud flush command.\n\ntype Spec struct{ ... }"
+  tags        = ["cloud", "commands", "flush", "golang"]
+  id          = "082f1c61-cfae-4a9c-9485-ed24ae706552"
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "login" {
-  content = <<-EOT
+  content = <<-EOF
 package login // import "github.com/terramate-io/terramate/commands/cloud/login"
 
 Package login provides login commands.
@@ -9,7 +9,7 @@ Package login provides login commands.
 type GithubSpec struct{ ... }
 type GoogleSpec struct{ ... }
 type SSOSpec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-login.ignore"
 }
@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "org" {
+  content = <<-EOF
+package org // import "github.com/terramate-io/terramate/commands/cloud/org"
+
+Package org provides the cloud org commands.
+
+type ListSpec struct{ ... }
+type SwitchSpec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-org.ignore"
+}
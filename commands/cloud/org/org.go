@@ -0,0 +1,103 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package org provides the cloud org commands.
+package org
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/ui/tui/cliauth"
+)
+
+// ListSpec is the command specification for the cloud org list command.
+type ListSpec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+}
+
+// SwitchSpec is the command specification for the cloud org switch command.
+type SwitchSpec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+	OrgName  string
+}
+
+// Name returns the name of the command.
+func (s *ListSpec) Name() string { return "cloud org list" }
+
+// Exec executes the cloud org list command.
+// The organization used by the project is marked with "*".
+func (s *ListSpec) Exec(_ context.Context) error {
+	orgs, err := loadOrganizations(s.Engine)
+	if err != nil {
+		return err
+	}
+	selected := s.Engine.CloudOrgName()
+	for _, org := range orgs {
+		mark := " "
+		if strings.EqualFold(org.Name, selected) {
+			mark = "*"
+		}
+		s.Printers.Stdout.Println(fmt.Sprintf("%s %s (%s): %s", mark, org.Name, org.DisplayName, org.Status))
+	}
+	return nil
+}
+
+// Name returns the name of the command.
+func (s *SwitchSpec) Name() string { return "cloud org switch" }
+
+// Exec executes the cloud org switch command.
+// The organization is stored together with the cached credentials and used
+// when no organization is set with the --org flag, the TM_CLOUD_ORGANIZATION
+// environment variable or the project configuration.
+func (s *SwitchSpec) Exec(_ context.Context) error {
+	orgs, err := loadOrganizations(s.Engine)
+	if err != nil {
+		return err
+	}
+
+	var org resources.MemberOrganization
+	found := false
+	for _, o := range orgs {
+		if strings.EqualFold(o.Name, s.OrgName) {
+			org, found = o, true
+			break
+		}
+	}
+	if !found {
+		return errors.E(
+			"You are not a member of organization %q or the organization does not exist. Available organizations: %s",
+			s.OrgName, orgs,
+		)
+	}
+	if org.Status != "active" && org.Status != "trusted" {
+		return errors.E(
+			"You are not yet an active member of organization %s. Please accept the invitation first.",
+			org.Name,
+		)
+	}
+
+	if err := cliauth.SelectOrganization(s.Engine.CLIConfig(), org.Name); err != nil {
+		return err
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("Switched to organization %s.", org.Name))
+
+	if current := s.Engine.CloudOrgName(); !strings.EqualFold(current, org.Name) {
+		s.Printers.Stderr.Warnf("organization %s is set for this project and takes precedence", current)
+	}
+	return nil
+}
+
+func loadOrganizations(e *engine.Engine) (resources.MemberOrganizations, error) {
+	if err := e.LoadCredential(); err != nil {
+		return nil, errors.E(err, "failed to load the cloud credentials")
+	}
+	return e.Credential().Organizations(), nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package org // import \"github.com/terramate-io/terramate/commands/cloud/org\""
+  description = "package org // import \"github.com/terramate-io/terramate/commands/cloud/org\"\n\nPackage org provides the cloud org commands.\n\ntype ListSpec struct{ ... }\ntype SwitchSpec struct{ ... }"
+  tags        = ["cloud", "commands", "golang", "org"]
+  id          = "21908fbf-349a-4289-aa28-ba05a494b1e3"
+}
@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "inventory" {
+  content = <<-EOF
+package inventory // import "github.com/terramate-io/terramate/commands/cloud/sync/inventory"
+
+Package inventory provides the cloud sync inventory command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-inventory.ignore"
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package inventory provides the cloud sync inventory command.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/warnings"
+)
+
+// Spec is the command specification for the cloud sync inventory command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+
+	// Target is the deployment target of the synced stacks.
+	Target string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "cloud sync inventory" }
+
+// Exec executes the cloud sync inventory command.
+// It syncs all the stacks of the project, with their metadata, so the
+// Terramate Cloud is current even if the stacks are deployed by other systems.
+func (s *Spec) Exec(ctx context.Context) error {
+	err := s.Engine.SetupCloudConfig([]string{fmt.Sprintf("%q command syncs the stack inventory", s.Name())})
+	if err != nil {
+		return err
+	}
+	err = s.Engine.CheckTargetsConfiguration(s.Target, "", func(isTargetSet bool) error {
+		if !isTargetSet {
+			return errors.E("--target must be set when terramate.config.cloud.targets.enabled is true")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	prj := s.Engine.Project()
+	if !prj.IsGitFeaturesEnabled() {
+		return errors.E("%s requires a git repository with commits", s.Name())
+	}
+	repo, err := prj.PrettyRepo()
+	if err != nil {
+		return err
+	}
+
+	stacks, err := s.inventory(repo)
+	if err != nil {
+		return err
+	}
+	if len(stacks) == 0 {
+		s.Printers.Stdout.Println("No stacks with an ID to sync.")
+		return nil
+	}
+
+	client := s.Engine.CloudClient()
+	orgUUID := s.Engine.CloudState().Org.UUID
+	for _, st := range stacks {
+		err := s.sync(ctx, client, orgUUID, st)
+		if err != nil {
+			return errors.E(err, "syncing the stack %s", st.Path)
+		}
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("Synced %d stacks to Terramate Cloud.", len(stacks)))
+	return nil
+}
+
+// sync upserts the stack, keeping the status of the stack if it already
+// exists in the Terramate Cloud.
+func (s *Spec) sync(ctx context.Context, client *cloud.Client, orgUUID resources.UUID, st resources.Stack) error {
+	reqCtx, cancel := context.WithTimeout(ctx, cloud.DefaultTimeout)
+	defer cancel()
+	existing, found, err := client.GetStack(reqCtx, orgUUID, st.Repository, st.Target, st.MetaID)
+	if err != nil {
+		return err
+	}
+	obj := resources.StackObject{Stack: st}
+	if found {
+		obj.Status = existing.Status
+		obj.DeploymentStatus = existing.DeploymentStatus
+		obj.DriftStatus = existing.DriftStatus
+	}
+
+	reqCtx, cancel = context.WithTimeout(ctx, cloud.DefaultTimeout)
+	defer cancel()
+	return client.PutStack(reqCtx, orgUUID, obj)
+}
+
+func (s *Spec) inventory(repo string) ([]resources.Stack, error) {
+	cfg := s.Engine.Config()
+	entries, err := stack.List(cfg, cfg.Tree())
+	if err != nil {
+		return nil, errors.E(err, "listing stacks")
+	}
+
+	defaultBranch := s.Engine.Project().GitConfig().DefaultBranch
+	var stacks []resources.Stack
+	for _, e := range entries {
+		st := e.Stack
+		if st.ID == "" {
+			msg := fmt.Sprintf("skipping stack %s without an ID", st.Dir)
+			s.Printers.Stderr.Warn(msg)
+			warnings.Record(warnings.Warning{Code: warnings.SkippedStack, Message: msg})
+			continue
+		}
+		stacks = append(stacks, resources.Stack{
+			Repository:      repo,
+			Target:          s.Target,
+			DefaultBranch:   defaultBranch,
+			Path:            st.Dir.String(),
+			MetaID:          strings.ToLower(st.ID),
+			MetaName:        st.Name,
+			MetaDescription: st.Description,
+			MetaTags:        st.Tags,
+		})
+	}
+	return stacks, nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package inventory // import \"github.com/terramate-io/terramate/commands/cloud/sync/inventory\""
+  description = "package inventory // import \"github.com/terramate-io/terramate/commands/cloud/sync/inventory\"\n\nPackage inventory provides the cloud sync inventory command.\n\ntype Spec struct{ ... }"
+  tags        = ["cloud", "commands", "golang", "inventory", "sync"]
+  id          = "634c711d-6849-48b7-9e8a-90ff462cde42"
+}
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "completions" {
-  content = <<-EOT
+  content = <<-EOF
 package completions // import "github.com/terramate-io/terramate/commands/completions"
 
 Package completions provides the install-completions command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-completions.ignore"
 }
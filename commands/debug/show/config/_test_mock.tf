@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "config" {
+  content = <<-EOF
+package config // import "github.com/terramate-io/terramate/commands/debug/show/config"
+
+Package config provides the debug show config command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-config.ignore"
+}
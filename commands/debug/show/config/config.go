@@ -0,0 +1,173 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package config provides the debug show config command.
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Spec is the command specification for the debug show config command.
+type Spec struct {
+	WorkingDir string
+	Engine     *engine.Engine
+	Printers   printer.Printers
+	GitFilter  engine.GitFilter
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "debug show config" }
+
+// Exec executes the debug show config command.
+// It prints the globals, generate blocks and run environment seen by each
+// selected stack, annotating every value with the location it is defined and
+// the parent definitions it overrides.
+func (s *Spec) Exec(_ context.Context) error {
+	report, err := s.Engine.ListStacks(s.GitFilter, cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "listing stacks")
+	}
+
+	vendorDir, err := s.Engine.VendorDir()
+	if err != nil {
+		return err
+	}
+
+	cfg := s.Engine.Config()
+	results, err := generate.Load(cfg, 0, vendorDir)
+	if err != nil {
+		return errors.E(err, "loading generated code")
+	}
+	genfiles := map[project.Path][]generate.GenFile{}
+	for _, res := range results {
+		if res.Err != nil {
+			return errors.E(res.Err, "loading generated code of %s", res.Dir)
+		}
+		genfiles[res.Dir] = res.Files
+	}
+
+	for _, stackEntry := range s.Engine.FilterStacks(report.Stacks, filter.TagClause{}) {
+		s.Printers.Stdout.Println(fmt.Sprintf("\nstack %q:", stackEntry.Stack.Dir))
+
+		if err := s.printGlobals(stackEntry); err != nil {
+			return err
+		}
+		s.printGenerate(genfiles[stackEntry.Stack.Dir])
+		if err := s.printEnv(stackEntry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Spec) printGlobals(stackEntry stack.Entry) error {
+	cfg := s.Engine.Config()
+	st := stackEntry.Stack
+	tree, _ := cfg.Lookup(st.Dir)
+	exprs, err := globals.LoadExprs(tree)
+	if err != nil {
+		return errors.E(err, "loading globals of stack %s", st.Dir)
+	}
+	report := globals.ForStack(cfg, st)
+	if err := report.AsError(); err != nil {
+		return errors.E(err, "evaluating globals of stack %s", st.Dir)
+	}
+
+	var printed bool
+	for _, def := range exprs.Definitions() {
+		val, ok := report.Globals.GetKeyPath(def.Path)
+		if !ok {
+			// unset globals.
+			continue
+		}
+		if !printed {
+			s.Printers.Stdout.Println("\tglobals:")
+			printed = true
+		}
+		name := "global." + strings.Join(def.Path, ".")
+		s.printDefinition(name, formatValue(val), def.Origin, def.Overrides)
+	}
+	return nil
+}
+
+func (s *Spec) printGenerate(files []generate.GenFile) {
+	var printed bool
+	for _, file := range files {
+		if !file.Condition() {
+			continue
+		}
+		if !printed {
+			s.Printers.Stdout.Println("\tgenerate:")
+			printed = true
+		}
+		s.Printers.Stdout.Println(fmt.Sprintf("\t\t%s", file.Label()))
+		s.Printers.Stdout.Println(fmt.Sprintf("\t\t\tdefined at %s", formatRange(file.Range())))
+	}
+}
+
+func (s *Spec) printEnv(stackEntry stack.Entry) error {
+	envVars, err := run.LoadEnvVars(s.Engine.Config(), stackEntry.Stack)
+	if err != nil {
+		return errors.E(err, "loading stack run environment")
+	}
+	if len(envVars) == 0 {
+		return nil
+	}
+	s.Printers.Stdout.Println("\tenv:")
+	for _, envVar := range envVars {
+		value := fmt.Sprintf("%q", envVar.Value)
+		if envVar.Sensitive {
+			value = eval.SensitiveMask
+		}
+		s.printDefinition(envVar.Name, value, envVar.Origin, envVar.Overrides)
+	}
+	return nil
+}
+
+func (s *Spec) printDefinition(name, value string, origin info.Range, overrides []info.Range) {
+	lines := strings.Split(value, "\n")
+	s.Printers.Stdout.Println(fmt.Sprintf("\t\t%s = %s", name, lines[0]))
+	for _, line := range lines[1:] {
+		s.Printers.Stdout.Println(fmt.Sprintf("\t\t%s", line))
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("\t\t\tdefined at %s", formatRange(origin)))
+	for _, override := range overrides {
+		s.Printers.Stdout.Println(fmt.Sprintf("\t\t\toverrides %s", formatRange(override)))
+	}
+}
+
+func formatValue(val eval.Value) string {
+	if val.Info().Sensitive {
+		return eval.SensitiveMask
+	}
+	var ctyVal cty.Value
+	if val.IsObject() {
+		ctyVal = cty.ObjectVal(val.(*eval.Object).AsMaskedValueMap())
+	} else {
+		ctyVal = val.(eval.CtyValue).Raw()
+	}
+	return string(hclwrite.TokensForValue(ctyVal).Bytes())
+}
+
+func formatRange(r info.Range) string {
+	return fmt.Sprintf("%s:%d", r.Path(), r.Start().Line())
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package config // import \"github.com/terramate-io/terramate/commands/debug/show/config\""
+  description = "package config // import \"github.com/terramate-io/terramate/commands/debug/show/config\"\n\nPackage config provides the debug show config command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "config", "debug", "golang", "show"]
+  id          = "5a21a78e-2f35-4741-a866-56cd61cd4416"
+}
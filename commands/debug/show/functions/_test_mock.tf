@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "functions" {
+  content = <<-EOF
+package functions // import "github.com/terramate-io/terramate/commands/debug/show/functions"
+
+Package functions provides the debug show functions command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-functions.ignore"
+}
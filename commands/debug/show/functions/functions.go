@@ -0,0 +1,61 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package functions provides the debug show functions command.
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/stdlib"
+)
+
+// Spec is the command specification for the debug show functions command.
+type Spec struct {
+	WorkingDir string
+	Engine     *engine.Engine
+	Printers   printer.Printers
+
+	// Pattern is a glob pattern filtering the function names.
+	Pattern string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "debug show functions" }
+
+// Exec executes the debug show functions command.
+// It prints the signature and description of all the functions available in
+// the project, including the custom functions of the [stdlib.Registry] given
+// to the configuration parser.
+func (s *Spec) Exec(_ context.Context) error {
+	cfg := s.Engine.Config()
+	funcs := cfg.Functions(s.WorkingDir)
+	for name := range funcs {
+		if experiment, ok := stdlib.FunctionExperiment(name); ok && !cfg.HasExperiment(experiment) {
+			delete(funcs, name)
+		}
+	}
+
+	docs, err := stdlib.Describes(funcs, s.Pattern)
+	if err != nil {
+		return errors.E(err, "invalid function name pattern %q", s.Pattern)
+	}
+	for _, doc := range docs {
+		s.Printers.Stdout.Println(doc.Signature())
+		if doc.Description != "" {
+			s.Printers.Stdout.Println(fmt.Sprintf("\t%s", summary(doc.Description)))
+		}
+	}
+	return nil
+}
+
+// summary returns the first line of the description.
+func summary(desc string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(desc), "\n")
+	return line
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package functions // import \"github.com/terramate-io/terramate/commands/debug/show/functions\""
+  description = "package functions // import \"github.com/terramate-io/terramate/commands/debug/show/functions\"\n\nPackage functions provides the debug show functions command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "debug", "functions", "golang", "show"]
+  id          = "2107dc90-aeac-448a-b571-5e3967c5d3ed"
+}
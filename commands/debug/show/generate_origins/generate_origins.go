@@ -55,7 +55,7 @@ func (s *Spec) Exec(_ context.Context) error {
 		return err
 	}
 
-	results, err := generate.Load(cfg, vendorDir)
+	results, err := generate.Load(cfg, 0, vendorDir)
 	if err != nil {
 		return errors.E(err, "generate debug: loading generated code")
 	}
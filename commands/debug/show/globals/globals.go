@@ -9,13 +9,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/terramate-io/hcl/v2/hclwrite"
 	"github.com/terramate-io/terramate/cloud/api/resources"
 	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Spec is the command specification for the debug globals command.
@@ -24,6 +29,10 @@ type Spec struct {
 	Engine     *engine.Engine
 	Printers   printer.Printers
 	GitFilter  engine.GitFilter
+
+	// Trace is the global whose override chain is shown instead of the
+	// globals values.
+	Trace string
 }
 
 // Name returns the name of the command.
@@ -43,6 +52,13 @@ func (s *Spec) Exec(_ context.Context) error {
 			return errors.E(err, "listing stacks globals: loading stack at %s", stack.Dir)
 		}
 
+		if s.Trace != "" {
+			if err := s.printTrace(stack, report); err != nil {
+				return err
+			}
+			continue
+		}
+
 		globalsStrRepr := report.Globals.String()
 		if globalsStrRepr == "" {
 			continue
@@ -55,3 +71,56 @@ func (s *Spec) Exec(_ context.Context) error {
 	}
 	return nil
 }
+
+// printTrace prints the value of the traced global and all the definitions
+// which set or extend it, from the root directory to the stack.
+func (s *Spec) printTrace(stack *config.Stack, report globals.EvalReport) error {
+	path := strings.Split(strings.TrimPrefix(s.Trace, "global."), ".")
+	tree, ok := s.Engine.Config().Lookup(stack.Dir)
+	if !ok {
+		return errors.E("stack %s not found", stack.Dir)
+	}
+	exprs, err := globals.LoadExprs(tree)
+	if err != nil {
+		return errors.E(err, "loading globals of stack %s", stack.Dir)
+	}
+	traces := exprs.Trace(path)
+	if len(traces) == 0 {
+		return nil
+	}
+
+	name := "global." + strings.Join(path, ".")
+	s.Printers.Stdout.Println(fmt.Sprintf("\nstack %q:", stack.Dir))
+	if val, ok := maskedValue(report.Globals, path); ok {
+		s.Printers.Stdout.Println(fmt.Sprintf("\t%s = %s", name,
+			strings.TrimSpace(string(hclwrite.Format(ast.TokensForValue(val).Bytes())))))
+	} else {
+		s.Printers.Stdout.Println(fmt.Sprintf("\t%s is not set", name))
+	}
+	for _, trace := range traces {
+		action := "set"
+		if trace.Extend {
+			action = "extend"
+		}
+		block := "globals"
+		for _, label := range trace.Labels {
+			block += fmt.Sprintf(" %q", label)
+		}
+		s.Printers.Stdout.Println(fmt.Sprintf("\t%s global.%s in %s (%s) at %s",
+			action, strings.Join(trace.Path, "."), trace.Dir, block, trace.Origin))
+	}
+	return nil
+}
+
+// maskedValue returns the value of the global at the given path, with the
+// sensitive values masked.
+func maskedValue(obj *eval.Object, path []string) (cty.Value, bool) {
+	val := cty.ObjectVal(obj.AsMaskedValueMap())
+	for _, key := range path {
+		if !val.Type().IsObjectType() || !val.Type().HasAttribute(key) {
+			return cty.NilVal, false
+		}
+		val = val.GetAttr(key)
+	}
+	return val, true
+}
@@ -0,0 +1,17 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "imports" {
+  content = <<-EOF
+package imports // import "github.com/terramate-io/terramate/commands/debug/show/imports"
+
+Package imports provides the show-imports command.
+
+const FormatDot = "dot" ...
+type Graph struct{ ... }
+    func Load(rootdir string) (*Graph, error)
+type Import struct{ ... }
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-imports.ignore"
+}
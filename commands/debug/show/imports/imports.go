@@ -0,0 +1,429 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package imports provides the show-imports command.
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/dot"
+	"github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/fs"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Supported output formats.
+const (
+	FormatDot  = "dot"
+	FormatJSON = "json"
+)
+
+// Spec is the command specification for the show-imports command.
+// The command does not need a loaded project, so it reports the import cycles
+// which make the configuration loading fail.
+type Spec struct {
+	WorkingDir string
+	Printers   printer.Printers
+	Format     string
+}
+
+// Graph is the import graph of the configuration files of the project.
+type Graph struct {
+	// Files are the files importing or imported by other files.
+	Files []string `json:"files"`
+
+	// Imports are the edges of the graph, one for each file matched by the
+	// source of each import block.
+	Imports []Import `json:"imports"`
+
+	// Unused are the files in directories of imported files which are not
+	// imported by any file.
+	Unused []string `json:"unused"`
+
+	// Cycles are the import cycles, each one as the imports forming it.
+	Cycles [][]Import `json:"cycles"`
+}
+
+// Import is a file imported by an import block.
+type Import struct {
+	// File is the file with the import block.
+	File string `json:"file"`
+
+	// Imported is the imported file.
+	Imported string `json:"imported"`
+
+	// Source is the import.source of the import block.
+	Source string `json:"source"`
+
+	// Range is the range of the import.source attribute.
+	Range string `json:"range"`
+
+	// Conditional tells if the import block has a condition, in which case
+	// the file is not always imported.
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "debug show imports" }
+
+// Exec executes the show-imports command.
+func (s *Spec) Exec(_ context.Context) error {
+	if s.Format == "" {
+		s.Format = FormatDot
+	}
+	if s.Format != FormatDot && s.Format != FormatJSON {
+		return errors.E("--format expects the values %q or %q", FormatDot, FormatJSON)
+	}
+
+	rootdir, err := findRootDir(s.WorkingDir)
+	if err != nil {
+		return err
+	}
+	graph, err := Load(rootdir)
+	if err != nil {
+		return errors.E(err, "building the import graph")
+	}
+
+	for _, cycle := range graph.Cycles {
+		chain := []string{cycle[0].File}
+		for _, imp := range cycle {
+			chain = append(chain, imp.Imported)
+		}
+		s.Printers.Stderr.Warnf("import cycle: %s", strings.Join(chain, " -> "))
+		for _, imp := range cycle {
+			s.Printers.Stderr.Println("  " + imp.Range + ": imports " + imp.Imported)
+		}
+	}
+	for _, file := range graph.Unused {
+		s.Printers.Stderr.Warnf("unused imported file: %s", file)
+	}
+
+	if s.Format == FormatJSON {
+		data, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return errors.E(err, "encoding import graph as JSON")
+		}
+		s.Printers.Stdout.Println(string(data))
+		return nil
+	}
+	s.Printers.Stdout.Println(graph.Dot())
+	return nil
+}
+
+// Dot returns the graph in the Graphviz dot format. The imports forming
+// cycles are red and the unused files are dashed.
+func (g *Graph) Dot() string {
+	inCycle := map[Import]bool{}
+	for _, cycle := range g.Cycles {
+		for _, imp := range cycle {
+			inCycle[imp] = true
+		}
+	}
+	dotGraph := dot.NewGraph(dot.Directed)
+	nodes := map[string]dot.Node{}
+	for _, file := range g.Files {
+		nodes[file] = dotGraph.Node(file)
+	}
+	for _, file := range g.Unused {
+		dotGraph.Node(file).Attr("style", "dashed")
+	}
+	for _, imp := range g.Imports {
+		edge := dotGraph.Edge(nodes[imp.File], nodes[imp.Imported], imp.Source)
+		edge.Attr("tooltip", imp.Range)
+		if imp.Conditional {
+			edge.Attr("style", "dashed")
+		}
+		if inCycle[imp] {
+			edge.Attr("color", "red")
+		}
+	}
+	return dotGraph.String()
+}
+
+// Load builds the import graph of the project at rootdir. Only the import
+// blocks are parsed, so the graph is built even when the imports fail to be
+// applied.
+func Load(rootdir string) (*Graph, error) {
+	b := &builder{
+		rootdir:    rootdir,
+		suffixes:   configSuffixes(rootdir),
+		parsed:     map[string]bool{},
+		stackDirs:  map[string]bool{},
+		importDirs: map[string]bool{},
+		imported:   map[string]bool{},
+		files:      map[string]bool{},
+	}
+	if err := b.walk(rootdir); err != nil {
+		return nil, err
+	}
+	return b.graph()
+}
+
+type builder struct {
+	rootdir    string
+	suffixes   []string
+	parsed     map[string]bool
+	stackDirs  map[string]bool
+	importDirs map[string]bool
+	imported   map[string]bool
+	files      map[string]bool
+	imports    []Import
+}
+
+func (b *builder) walk(dir string) error {
+	res, err := fs.ListTerramateFiles(dir, b.suffixes...)
+	if err != nil {
+		return err
+	}
+	for _, name := range res.Skipped {
+		if name == terramate.SkipFilename {
+			return nil
+		}
+	}
+	errs := errors.L()
+	for _, name := range res.TmFiles {
+		errs.Append(b.parse(filepath.Join(dir, name)))
+	}
+	for _, name := range res.Dirs {
+		errs.Append(b.walk(filepath.Join(dir, name)))
+	}
+	return errs.AsError()
+}
+
+// parse adds the imports of the file to the graph, parsing the imported files
+// recursively.
+func (b *builder) parse(file string) error {
+	if b.parsed[file] {
+		return nil
+	}
+	b.parsed[file] = true
+
+	dir := filepath.Dir(file)
+	parser, err := hcl.NewTerramateParser(b.rootdir, dir)
+	if err != nil {
+		return err
+	}
+	if err := parser.AddFile(file); err != nil {
+		return err
+	}
+	importBlocks, err := parser.Imports()
+	if err != nil {
+		return err
+	}
+	for _, body := range parser.ParsedBodies() {
+		for _, block := range body.Blocks {
+			if block.Type == "stack" {
+				b.stackDirs[dir] = true
+			}
+		}
+	}
+
+	errs := errors.L()
+	for _, importBlock := range importBlocks {
+		srcAttr := importBlock.Attributes["source"]
+		srcVal, diags := srcAttr.Expr.Value(nil)
+		if diags.HasErrors() || srcVal.Type() != cty.String {
+			errs.Append(errors.E(hcl.ErrTerramateSchema, srcAttr.Expr.Range(),
+				"import.source must be a string"))
+			continue
+		}
+		src := srcVal.AsString()
+		srcDir := path.Dir(src)
+		if path.IsAbs(srcDir) { // project-path
+			srcDir = filepath.Join(b.rootdir, srcDir)
+		} else {
+			srcDir = filepath.Join(dir, srcDir)
+		}
+		matches, err := filepath.Glob(filepath.Join(srcDir, path.Base(src)))
+		if err != nil || matches == nil {
+			errs.Append(errors.E(hcl.ErrImport, srcAttr.Expr.Range(),
+				"import path %q returned no matches", src))
+			continue
+		}
+		_, conditional := importBlock.Attributes["condition"]
+		for _, match := range matches {
+			if st, err := os.Stat(match); err != nil || st.IsDir() {
+				continue
+			}
+			b.files[file] = true
+			b.files[match] = true
+			b.imported[match] = true
+			b.importDirs[filepath.Dir(match)] = true
+			b.imports = append(b.imports, Import{
+				File:        b.path(file),
+				Imported:    b.path(match),
+				Source:      src,
+				Range:       srcAttr.Range.String(),
+				Conditional: conditional,
+			})
+			errs.Append(b.parse(match))
+		}
+	}
+	return errs.AsError()
+}
+
+func (b *builder) graph() (*Graph, error) {
+	g := &Graph{
+		Files:   []string{},
+		Imports: b.imports,
+		Unused:  []string{},
+		Cycles:  [][]Import{},
+	}
+	if g.Imports == nil {
+		g.Imports = []Import{}
+	}
+	for file := range b.files {
+		g.Files = append(g.Files, b.path(file))
+	}
+	sort.Strings(g.Files)
+
+	for dir := range b.importDirs {
+		if b.stackDirs[dir] {
+			continue
+		}
+		res, err := fs.ListTerramateFiles(dir, b.suffixes...)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range res.TmFiles {
+			file := filepath.Join(dir, name)
+			if !b.imported[file] {
+				g.Unused = append(g.Unused, b.path(file))
+			}
+		}
+	}
+	sort.Strings(g.Unused)
+
+	g.Cycles = findCycles(g.Files, g.Imports)
+	return g, nil
+}
+
+func (b *builder) path(file string) string {
+	return project.PrjAbsPath(b.rootdir, file).String()
+}
+
+// findCycles returns the import cycles, each one starting at its lowest
+// file, found by a depth-first search from each file.
+func findCycles(files []string, imports []Import) [][]Import {
+	edges := map[string][]Import{}
+	for _, imp := range imports {
+		edges[imp.File] = append(edges[imp.File], imp)
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	seen := map[string]bool{}
+	cycles := [][]Import{}
+	var stack []Import
+
+	var visit func(file string)
+	visit = func(file string) {
+		state[file] = visiting
+		for _, imp := range edges[file] {
+			switch state[imp.Imported] {
+			case visiting:
+				start := len(stack)
+				if imp.File != imp.Imported {
+					start--
+					for stack[start].File != imp.Imported {
+						start--
+					}
+				}
+				cycle := append(append([]Import{}, stack[start:]...), imp)
+				cycle = rotateCycle(cycle)
+				key := cycleKey(cycle)
+				if !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			case 0:
+				stack = append(stack, imp)
+				visit(imp.Imported)
+				stack = stack[:len(stack)-1]
+			}
+		}
+		state[file] = visited
+	}
+	for _, file := range files {
+		if state[file] == 0 {
+			visit(file)
+		}
+	}
+	return cycles
+}
+
+// rotateCycle rotates the cycle to start at its lowest file, so the same
+// cycle is always reported the same way.
+func rotateCycle(cycle []Import) []Import {
+	lowest := 0
+	for i, imp := range cycle {
+		if imp.File < cycle[lowest].File {
+			lowest = i
+		}
+	}
+	return append(append([]Import{}, cycle[lowest:]...), cycle[:lowest]...)
+}
+
+func cycleKey(cycle []Import) string {
+	parts := make([]string, 0, len(cycle))
+	for _, imp := range cycle {
+		parts = append(parts, imp.Range+">"+imp.Imported)
+	}
+	return strings.Join(parts, "|")
+}
+
+// findRootDir returns the root directory of the project of the working dir,
+// which is the first directory, walking up from it, with a root config or a
+// git repository.
+// configSuffixes returns the terramate.config.config_file_suffixes of the
+// root config. The root config fails to parse if its imports are broken, in
+// which case only the standard files are part of the graph.
+func configSuffixes(rootdir string) []string {
+	cfg, err := hcl.ParseDir(rootdir, rootdir)
+	if err != nil {
+		return nil
+	}
+	return cfg.ConfigFileSuffixes()
+}
+
+func findRootDir(wd string) (string, error) {
+	dir := wd
+	for {
+		parser, err := hcl.NewTerramateParser(dir, dir)
+		if err != nil {
+			return "", err
+		}
+		if err := parser.AddDir(dir); err != nil {
+			return "", err
+		}
+		isRoot, err := parser.IsRootConfig()
+		if err != nil {
+			return "", err
+		}
+		if isRoot {
+			return dir, nil
+		}
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.E("no Terramate project found from %s", wd)
+		}
+		dir = parent
+	}
+}
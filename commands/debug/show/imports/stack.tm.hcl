@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package imports // import \"github.com/terramate-io/terramate/commands/debug/show/imports\""
+  description = "package imports // import \"github.com/terramate-io/terramate/commands/debug/show/imports\"\n\nPackage imports provides the show-imports command.\n\nconst FormatDot = \"dot\" ...\ntype Graph struct{ ... }\n    func Load(rootdir string) (*Graph, error)\ntype Import struct{ ... }\ntype Spec struct{ ... }"
+  tags        = ["commands", "debug", "golang", "imports", "show"]
+  id          = "d4efd4b0-5913-42f4-95bf-0bd30592fa99"
+}
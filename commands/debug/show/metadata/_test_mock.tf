@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "metadata" {
-  content = <<-EOT
+  content = <<-EOF
 package metadata // import "github.com/terramate-io/terramate/commands/debug/show/metadata"
 
 Package metadata provides the show-metadata command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-metadata.ignore"
 }
@@ -0,0 +1,15 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "run_env" {
+  content = <<-EOF
+package runenv // import "github.com/terramate-io/terramate/commands/debug/show/run_env"
+
+Package runenv provides the show-run-env command.
+
+const FormatText = "text" ...
+type Spec struct{ ... }
+type StackEnv struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-run_env.ignore"
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package runenv provides the show-run-env command.
+package runenv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/run"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Spec is the command specification for the show-run-env command.
+type Spec struct {
+	Engine    *engine.Engine
+	Printers  printer.Printers
+	GitFilter engine.GitFilter
+	Format    string
+
+	// EnableSharing adds the TF_VAR_* variables of the stack inputs, as set
+	// by the commands executed with the outputs sharing enabled.
+	EnableSharing bool
+}
+
+// StackEnv is the resolved environment of the commands executed in a stack.
+type StackEnv struct {
+	Dir string            `json:"dir"`
+	Env map[string]string `json:"env"`
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "debug show run-env" }
+
+// Exec executes the show-run-env command.
+func (s *Spec) Exec(_ context.Context) error {
+	if s.Format == "" {
+		s.Format = FormatText
+	}
+	if s.Format != FormatText && s.Format != FormatJSON {
+		return errors.E("--format expects the values %q or %q", FormatText, FormatJSON)
+	}
+
+	report, err := s.Engine.ListStacks(s.GitFilter, cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "listing stacks")
+	}
+
+	cfg := s.Engine.Config()
+	stacks := []StackEnv{}
+	for _, stackEntry := range s.Engine.FilterStacks(report.Stacks, filter.TagClause{}) {
+		vars, err := run.LoadEnvVars(cfg, stackEntry.Stack)
+		if err != nil {
+			return errors.E(err, "loading stack run environment")
+		}
+		env := run.EnvWith(vars)
+		if s.EnableSharing {
+			inputs, err := s.Engine.StackInputsEnv(stackEntry.Stack)
+			if err != nil {
+				return errors.E(err, "loading stack inputs")
+			}
+			for _, kv := range inputs {
+				name, value, _ := strings.Cut(kv, "=")
+				env[name] = value
+			}
+		}
+		for _, v := range vars {
+			if v.Sensitive {
+				env[v.Name] = eval.SensitiveMask
+			}
+		}
+		stacks = append(stacks, StackEnv{
+			Dir: stackEntry.Stack.Dir.String(),
+			Env: env,
+		})
+	}
+
+	if s.Format == FormatJSON {
+		data, err := json.MarshalIndent(struct {
+			Stacks []StackEnv `json:"stacks"`
+		}{Stacks: stacks}, "", "  ")
+		if err != nil {
+			return errors.E(err, "encoding run environment as JSON")
+		}
+		s.Printers.Stdout.Println(string(data))
+		return nil
+	}
+
+	for _, st := range stacks {
+		s.Printers.Stdout.Println(fmt.Sprintf("\nstack %q:", st.Dir))
+
+		names := make([]string, 0, len(st.Env))
+		for name := range st.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			s.Printers.Stdout.Println(fmt.Sprintf("\t%s=%s", name, st.Env[name]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package runenv // import \"github.com/terramate-io/terramate/commands/debug/show/run_env\""
+  description = "package runenv // import \"github.com/terramate-io/terramate/commands/debug/show/run_env\"\n\nPackage runenv provides the show-run-env command.\n\nconst FormatText = \"text\" ...\ntype Spec struct{ ... }\ntype StackEnv struct{ ... }"
+  tags        = ["commands", "debug", "golang", "run_env", "show"]
+  id          = "09be49de-30c1-4905-90b2-bddf8e11a8a6"
+}
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "runtime_env" {
-  content = <<-EOT
+  content = <<-EOF
 package runtimeenv // import "github.com/terramate-io/terramate/commands/debug/show/runtime_env"
 
 Package runtimeenv provides the show-runtime-env command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-runtime_env.ignore"
 }
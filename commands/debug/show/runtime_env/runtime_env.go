@@ -11,6 +11,7 @@ import (
 	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/run"
 
@@ -38,7 +39,7 @@ func (s *Spec) Exec(_ context.Context) error {
 
 	cfg := s.Engine.Config()
 	for _, stackEntry := range s.Engine.FilterStacks(report.Stacks, filter.TagClause{}) {
-		envVars, err := run.LoadEnv(cfg, stackEntry.Stack)
+		envVars, err := run.LoadEnvVars(cfg, stackEntry.Stack)
 		if err != nil {
 			return errors.E(err, "loading stack run environment")
 		}
@@ -46,7 +47,11 @@ func (s *Spec) Exec(_ context.Context) error {
 		s.Printers.Stdout.Println(fmt.Sprintf("\nstack %q:", stackEntry.Stack.Dir))
 
 		for _, envVar := range envVars {
-			s.Printers.Stdout.Println(fmt.Sprintf("\t%s", envVar))
+			value := envVar.Value
+			if envVar.Sensitive {
+				value = eval.SensitiveMask
+			}
+			s.Printers.Stdout.Println(fmt.Sprintf("\t%s=%s", envVar.Name, value))
 		}
 	}
 	return nil
@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "docs" {
+  content = <<-EOF
+package docs // import "github.com/terramate-io/terramate/commands/experimental/docs"
+
+Package docs provides the experimental docs generate command.
+
+const DefaultOutput = "/STACKS.md"
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-docs.ignore"
+}
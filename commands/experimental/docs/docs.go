@@ -0,0 +1,205 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package docs provides the experimental docs generate command.
+package docs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/exit"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/generate/genfile"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/run"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DefaultOutput is the default project path of the generated docs.
+const DefaultOutput = "/STACKS.md"
+
+// header is the first line of the generated docs.
+const header = "<!-- Generated by `terramate experimental docs generate`. DO NOT EDIT. -->"
+
+// Spec is the command specification for the experimental docs generate command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+
+	// Output is the project path of the generated Markdown file.
+	Output string
+
+	// Globals are the paths of the globals documented for each stack, eg.: aws.region.
+	Globals []string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental docs generate" }
+
+// Exec executes the experimental docs generate command.
+// It generates a Markdown inventory of all the stacks of the project, grouped
+// by their parent directory, and writes it with the generate engine as a
+// file with context = root.
+func (s *Spec) Exec(_ context.Context) error {
+	output := s.Output
+	if output == "" {
+		output = DefaultOutput
+	}
+	if !path.IsAbs(output) || path.Clean(output) != output || output == "/" {
+		return errors.E("--output must be a clean absolute project path but given %q", output)
+	}
+
+	root := s.Engine.Config()
+	stacks, err := config.LoadAllStacks(root, root.Tree())
+	if err != nil {
+		return errors.E(err, "loading stacks")
+	}
+	levels, reason, err := run.Levels(root, stacks,
+		func(s *config.SortableStack) *config.Stack { return s.Stack })
+	if err != nil {
+		return errors.E(err, "Invalid stack configuration: "+reason)
+	}
+	levelOf := map[string]int{}
+	for level, group := range levels {
+		for _, st := range group {
+			levelOf[st.Dir().String()] = level
+		}
+	}
+
+	body, err := s.render(root, stacks, levelOf, output)
+	if err != nil {
+		return err
+	}
+
+	report := generate.RootFiles(root, []generate.GenFile{&file{label: output, body: body}})
+	s.Printers.Stdout.Println(report.Full())
+	if report.HasFailures() {
+		return errors.E(exit.Failed)
+	}
+	return nil
+}
+
+func (s *Spec) render(root *config.Root, stacks config.List[*config.SortableStack], levelOf map[string]int, output string) (string, error) {
+	byDir := map[string][]*config.Stack{}
+	for _, elem := range stacks {
+		dir := path.Dir(elem.Stack.Dir.String())
+		byDir[dir] = append(byDir[dir], elem.Stack)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	columns := []string{"Stack", "Description", "Tags", "Level", "After", "Before"}
+	for _, name := range s.Globals {
+		columns = append(columns, "global."+name)
+	}
+
+	var b strings.Builder
+	b.WriteString(header + "\n\n# Stacks\n")
+	for _, dir := range dirs {
+		fmt.Fprintf(&b, "\n## %s\n\n", dir)
+		b.WriteString(row(columns))
+		b.WriteString(strings.Repeat("|---", len(columns)) + "|\n")
+
+		dirStacks := byDir[dir]
+		sort.Slice(dirStacks, func(i, j int) bool {
+			return dirStacks[i].Dir.String() < dirStacks[j].Dir.String()
+		})
+		for _, st := range dirStacks {
+			cells := []string{
+				fmt.Sprintf("[%s](%s)", st.Name, relLink(path.Dir(output), st.Dir.String())),
+				st.Description,
+				codeList(st.Tags),
+				fmt.Sprint(levelOf[st.Dir.String()]),
+				codeList(st.After),
+				codeList(st.Before),
+			}
+			if len(s.Globals) > 0 {
+				report := globals.ForStack(root, st)
+				if err := report.AsError(); err != nil {
+					return "", errors.E(err, "evaluating globals of stack %s", st.Dir)
+				}
+				obj := cty.ObjectVal(report.Globals.AsMaskedValueMap())
+				for _, name := range s.Globals {
+					cells = append(cells, globalCell(obj, name))
+				}
+			}
+			b.WriteString(row(cells))
+		}
+	}
+	return b.String(), nil
+}
+
+func row(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(strings.TrimSpace(cell), "|", `\|`)
+		escaped[i] = strings.ReplaceAll(cell, "\n", "<br>")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+func codeList(items []string) string {
+	codes := make([]string, len(items))
+	for i, item := range items {
+		codes[i] = "`" + item + "`"
+	}
+	return strings.Join(codes, " ")
+}
+
+// globalCell returns the value of the global at the dotted path, encoded as
+// HCL, or an empty string if it's not defined.
+func globalCell(obj cty.Value, name string) string {
+	val := obj
+	for _, key := range strings.Split(name, ".") {
+		if val.IsNull() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+			return ""
+		}
+		attr, ok := val.AsValueMap()[key]
+		if !ok {
+			return ""
+		}
+		val = attr
+	}
+	return "`" + string(ast.TokensForValue(val).Bytes()) + "`"
+}
+
+// relLink returns the link of the target directory relative to dir.
+func relLink(dir, target string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+	return filepath.ToSlash(rel)
+}
+
+// file is the generated docs file.
+type file struct {
+	label string
+	body  string
+}
+
+func (f *file) Builtin() bool                 { return true }
+func (f *file) Header() string                { return "" }
+func (f *file) Body() string                  { return f.body }
+func (f *file) Label() string                 { return f.label }
+func (f *file) Context() string               { return genfile.RootContext }
+func (f *file) Range() info.Range             { return info.Range{} }
+func (f *file) Condition() bool               { return true }
+func (f *file) Asserts() []config.Assert      { return nil }
+func (f *file) EvalDuration() time.Duration   { return 0 }
+func (f *file) FormatDuration() time.Duration { return 0 }
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package docs // import \"github.com/terramate-io/terramate/commands/experimental/docs\""
+  description = "package docs // import \"github.com/terramate-io/terramate/commands/experimental/docs\"\n\nPackage docs provides the experimental docs generate command.\n\nconst DefaultOutput = \"/STACKS.md\"\ntype Spec struct{ ... }"
+  tags        = ["commands", "docs", "experimental", "golang"]
+  id          = "9d2956ff-8fb7-4d11-9171-1bd0fb51fb61"
+}
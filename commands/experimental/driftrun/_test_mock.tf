@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "driftrun" {
+  content = <<-EOF
+package driftrun // import "github.com/terramate-io/terramate/commands/experimental/driftrun"
+
+Package driftrun provides the experimental drift run command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-driftrun.ignore"
+}
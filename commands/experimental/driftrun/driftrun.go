@@ -0,0 +1,342 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package driftrun provides the experimental drift run command.
+package driftrun
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloudsync"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/notify"
+
+	runcmd "github.com/terramate-io/terramate/commands/run"
+)
+
+const cloudFeatSyncDriftStatus = "'--sync-drift-status' is a Terramate Cloud feature to synchronize drift and health check results to Terramate Cloud."
+
+// Spec is the command specification for the experimental drift run command.
+type Spec struct {
+	Engine     *engine.Engine
+	WorkingDir string
+	Printers   printer.Printers
+	Stdout     io.Writer
+	Stderr     io.Writer
+	Stdin      io.Reader
+
+	// Command is the drift detection command, expected to exit with 0 when
+	// there is no drift and with 2 when the stack drifted.
+	Command []string
+
+	// All selects all the stacks of the project instead of the stacks inside
+	// the working directory.
+	All bool
+
+	// MaxParallel is the maximum number of stacks executed concurrently.
+	MaxParallel int
+
+	// Shard is the shard of the selected stacks to run, in the N/TOTAL form.
+	Shard string
+
+	// Jitter is the maximum random delay before starting the execution.
+	Jitter time.Duration
+
+	// Seed of the randomized execution order. If zero, a random seed is used.
+	Seed int64
+
+	SyncDriftStatus   bool
+	TerraformPlanFile string
+	TofuPlanFile      string
+	Terragrunt        bool
+	Target            string
+	Tags              []string
+	NoTags            []string
+
+	state cloudsync.CloudRunState
+}
+
+// shard is a deterministic partition of the stacks.
+type shard struct {
+	index int // 1-based
+	total int
+}
+
+// result is the outcome of the drift detection of a stack.
+type result struct {
+	run engine.StackCloudRun
+	res engine.RunResult
+	err error
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental drift run" }
+
+// Exec executes the experimental drift run command.
+// It is designed for scheduled CI jobs: the selected stacks are split in
+// deterministic shards, so the jobs can be spread over multiple machines, and
+// the stacks of the shard are executed in a random order, with a limited
+// concurrency. The drift status of each stack is only synchronized to
+// Terramate Cloud after all the stacks are executed.
+func (s *Spec) Exec(ctx context.Context) error {
+	if len(s.Command) == 0 {
+		return errors.E("drift run expects a command")
+	}
+	if s.MaxParallel < 1 {
+		return errors.E("--max-parallel must be greater than zero")
+	}
+	shard, err := parseShard(s.Shard)
+	if err != nil {
+		return err
+	}
+	if s.TerraformPlanFile != "" && s.TofuPlanFile != "" {
+		return errors.E(runcmd.ErrConflictOptions, "--terraform-plan-file conflicts with --tofu-plan-file")
+	}
+	planFile, planProvisioner := runcmd.SelectPlanFile(s.TerraformPlanFile, s.TofuPlanFile)
+	if planFile != "" && !s.SyncDriftStatus {
+		return errors.E(runcmd.ErrConflictOptions, "--terraform-plan-file and --tofu-plan-file require --sync-drift-status")
+	}
+
+	err = runcmd.CheckOutdatedGeneratedCode(s.Engine, runcmd.Safeguards{}, s.WorkingDir, s.MaxParallel)
+	if err != nil {
+		return err
+	}
+
+	if s.SyncDriftStatus {
+		err := s.Engine.SetupCloudSyncConfig([]string{cloudFeatSyncDriftStatus})
+		err = s.Engine.HandleCloudCriticalError(err)
+		if err != nil {
+			return err
+		}
+	}
+
+	stacks, err := s.selectStacks(shard)
+	if err != nil {
+		return err
+	}
+
+	if s.SyncDriftStatus {
+		if !s.Engine.Project().IsRepo() {
+			return errors.E("cloud features requires a git repository")
+		}
+		err = s.Engine.EnsureAllStackHaveIDs(stacks)
+		if err != nil {
+			return err
+		}
+		cloudsync.DetectCloudMetadata(s.Engine, &s.state)
+	}
+
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	rnd.Shuffle(len(stacks), func(i, j int) { stacks[i], stacks[j] = stacks[j], stacks[i] })
+
+	runs := make([]engine.StackRun, len(stacks))
+	for i, st := range stacks {
+		runs[i] = engine.StackRun{
+			SyncTaskIndex: -1,
+			Stack:         st.Stack,
+			Tasks: []engine.StackRunTask{
+				{
+					Cmd:                  s.Command,
+					CloudTarget:          s.Target,
+					CloudSyncDriftStatus: true,
+					CloudPlanFile:        planFile,
+					CloudPlanProvisioner: planProvisioner,
+					UseTerragrunt:        s.Terragrunt,
+				},
+			},
+		}
+	}
+
+	if s.Jitter > 0 {
+		time.Sleep(time.Duration(rnd.Int63n(int64(s.Jitter))))
+	}
+
+	var (
+		mu      sync.Mutex
+		results []result
+	)
+	runErr := s.Engine.RunAll(runs, engine.RunAllOptions{
+		ContinueOnError: true,
+		Parallel:        s.MaxParallel,
+		Unordered:       true,
+		Stdout:          s.Stdout,
+		Stderr:          s.Stderr,
+		Stdin:           s.Stdin,
+		Hooks: &engine.Hooks{
+			Before: func(_ *engine.Engine, _ engine.StackCloudRun) {},
+			After: func(_ *engine.Engine, run engine.StackCloudRun, res engine.RunResult, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				results = append(results, result{run: run, res: res, err: err})
+			},
+			LogSyncCondition: func(_ engine.StackRunTask, _ engine.StackRun) bool { return false },
+			LogSyncer:        func(_ *zerolog.Logger, _ *engine.Engine, _ engine.StackRun, _ resources.CommandLogs) {},
+		},
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].run.Stack.Dir.String() < results[j].run.Stack.Dir.String()
+	})
+
+	if s.SyncDriftStatus {
+		for _, r := range results {
+			cloudsync.AfterRun(s.Engine, r.run, &s.state, r.res, r.err)
+		}
+	}
+
+	if runErr != nil && len(results) == 0 {
+		return runErr
+	}
+
+	s.printSummary(shard, results)
+	s.notify(ctx, results)
+
+	if failed := countStatus(results, "failed"); failed > 0 {
+		return errors.E(runErr, "drift detection failed in %d stacks", failed)
+	}
+	return runErr
+}
+
+// selectStacks returns the stacks of the shard.
+func (s *Spec) selectStacks(shard shard) (config.List[*config.SortableStack], error) {
+	report, err := s.Engine.ListStacks(engine.NoGitFilter(), s.Target, resources.NoStatusFilters(), true)
+	if err != nil {
+		return nil, errors.E(err, "listing stacks")
+	}
+	tags, err := engine.ParseFilterTags(s.Tags, s.NoTags)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := report.Stacks
+	if !s.All {
+		rootdir := s.Engine.Config().HostDir()
+		entries = s.Engine.FilterStacksByBasePath(project.PrjAbsPath(rootdir, s.WorkingDir), entries)
+	}
+
+	var stacks config.List[*config.SortableStack]
+	for _, entry := range entries {
+		st := entry.Stack
+		if !tags.IsEmpty() && !filter.MatchTags(tags, st.Tags) {
+			continue
+		}
+		if !shard.contains(st.Dir) {
+			continue
+		}
+		stacks = append(stacks, st.Sortable())
+	}
+	return stacks, nil
+}
+
+func (s *Spec) printSummary(shard shard, results []result) {
+	stdout := s.Printers.Stdout
+	if shard.total > 1 {
+		stdout.Println(fmt.Sprintf("Drift detection of shard %d/%d:", shard.index, shard.total))
+	} else {
+		stdout.Println("Drift detection:")
+	}
+	for _, r := range results {
+		stdout.Println(fmt.Sprintf("\t%s: %s", r.run.Stack.Dir, status(r)))
+	}
+	stdout.Println(fmt.Sprintf("%d stacks: %d ok, %d drifted, %d failed",
+		len(results),
+		countStatus(results, "ok"),
+		countStatus(results, "drifted"),
+		countStatus(results, "failed"),
+	))
+}
+
+// notify sends the results to the webhook configured in the
+// terramate.config.notifications block. Failing to notify doesn't fail the
+// command.
+func (s *Spec) notify(ctx context.Context, results []result) {
+	report := notify.Report{Command: "terramate experimental drift run"}
+	for _, r := range results {
+		report.Stacks = append(report.Stacks, notify.StackResult{
+			Stack:  r.run.Stack.Dir.String(),
+			Status: status(r),
+		})
+	}
+	err := notify.Send(ctx, s.Engine.Config(), report)
+	if err != nil {
+		s.Printers.Stderr.WarnWithDetails("failed to send notification", err)
+	}
+}
+
+// status returns the drift status of the stack, as synchronized to
+// Terramate Cloud.
+func status(r result) string {
+	switch {
+	case r.res.ExitCode == 0 && r.err == nil:
+		return "ok"
+	case r.res.ExitCode == 2:
+		return "drifted"
+	case r.res.ExitCode < 0 && errors.IsKind(r.err, engine.ErrRunCanceled):
+		return "canceled"
+	default:
+		return "failed"
+	}
+}
+
+func countStatus(results []result, want string) int {
+	n := 0
+	for _, r := range results {
+		if status(r) == want {
+			n++
+		}
+	}
+	return n
+}
+
+// parseShard parses a shard in the N/TOTAL form. An empty string is the
+// single shard with all the stacks.
+func parseShard(str string) (shard, error) {
+	if str == "" {
+		return shard{index: 1, total: 1}, nil
+	}
+	index, total, ok := strings.Cut(str, "/")
+	if !ok {
+		return shard{}, errors.E("invalid --shard %q: expected N/TOTAL", str)
+	}
+	n, err := strconv.Atoi(index)
+	if err != nil {
+		return shard{}, errors.E(err, "invalid --shard %q: expected N/TOTAL", str)
+	}
+	t, err := strconv.Atoi(total)
+	if err != nil {
+		return shard{}, errors.E(err, "invalid --shard %q: expected N/TOTAL", str)
+	}
+	if t < 1 || n < 1 || n > t {
+		return shard{}, errors.E("invalid --shard %q: N must be between 1 and TOTAL", str)
+	}
+	return shard{index: n, total: t}, nil
+}
+
+// contains tells if the stack belongs to the shard. The shard of a stack only
+// depends on its path, so adding or removing stacks doesn't move the other
+// stacks between shards.
+func (s shard) contains(dir project.Path) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(dir.String()))
+	return int(h.Sum32()%uint32(s.total)) == s.index-1
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package driftrun // import \"github.com/terramate-io/terramate/commands/experimental/driftrun\""
+  description = "package driftrun // import \"github.com/terramate-io/terramate/commands/experimental/driftrun\"\n\nPackage driftrun provides the experimental drift run command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "driftrun", "experimental", "golang"]
+  id          = "f88fe88c-5efc-4323-9ff6-09114fe39487"
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "eval" {
-  content = <<-EOT
+  content = <<-EOF
 package eval // import "github.com/terramate-io/terramate/commands/experimental/eval"
 
 Package eval provides the "experimental eval" and "experimental partial-eval"
@@ -10,7 +10,7 @@ commands.
 type GetConfigValueSpec struct{ ... }
 type PartialSpec struct{ ... }
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-eval.ignore"
 }
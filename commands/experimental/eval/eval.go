@@ -13,6 +13,7 @@ import (
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl/ast"
+	hcleval "github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -46,6 +47,9 @@ func (s *Spec) Exec(_ context.Context) error {
 		if err != nil {
 			return errors.E(err, "eval %q", exprStr)
 		}
+		if evalctx.IsSensitive(expr) {
+			val = cty.StringVal(hcleval.SensitiveMask)
+		}
 		err = outputEvalResult(s.Printers.Stdout, val, s.AsJSON)
 		if err != nil {
 			return err
@@ -9,9 +9,11 @@ import (
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl/ast"
+	hcleval "github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 
 	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // GetConfigValueSpec is the command specification for the experimental get-config-value command.
@@ -53,6 +55,9 @@ func (s *GetConfigValueSpec) Exec(_ context.Context) error {
 		if err != nil {
 			return errors.E(err, "evaluating expression: %s", exprStr)
 		}
+		if evalctx.IsSensitive(expr) {
+			val = cty.StringVal(hcleval.SensitiveMask)
+		}
 
 		err = outputEvalResult(s.Printers.Stdout, val, s.AsJSON)
 		if err != nil {
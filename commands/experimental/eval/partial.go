@@ -10,6 +10,7 @@ import (
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl/ast"
+	hcleval "github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 )
 
@@ -40,6 +41,10 @@ func (s *PartialSpec) Exec(_ context.Context) error {
 		if err != nil {
 			return errors.E(err, "partial eval %q", exprStr)
 		}
+		if evalctx.IsSensitive(expr) {
+			s.Printers.Stdout.Println(hcleval.SensitiveMask)
+			continue
+		}
 		s.Printers.Stdout.Println(string(hclwrite.Format(ast.TokensForExpression(newexpr).Bytes())))
 	}
 
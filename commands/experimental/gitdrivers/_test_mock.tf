@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "gitdrivers" {
+  content = <<-EOF
+package gitdrivers // import "github.com/terramate-io/terramate/commands/experimental/gitdrivers"
+
+Package gitdrivers provides the experimental git-install-drivers command.
+
+const DriverName = "terramate-generated" ...
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-gitdrivers.ignore"
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package gitdrivers provides the experimental git-install-drivers command.
+package gitdrivers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/printer"
+)
+
+const (
+	// DriverName is the name of the git merge and diff drivers registered for
+	// the generated files.
+	DriverName = "terramate-generated"
+
+	// AttributesFile is the name of the git attributes file managed by the command.
+	AttributesFile = ".gitattributes"
+
+	// BeginMarker starts the section of the attributes file managed by Terramate.
+	BeginMarker = "# BEGIN terramate generated files"
+	// EndMarker ends the section of the attributes file managed by Terramate.
+	EndMarker = "# END terramate generated files"
+
+	hookMarker = "# installed by terramate experimental git-install-drivers"
+
+	// mergeDriver is the command of the merge driver, executed by git with
+	// the shell when both sides of the merge changed a generated file. It
+	// keeps the current version of the file when both sides generated the
+	// same content, otherwise it fails, leaving the current version of the
+	// file without conflict markers, so the configuration can still be
+	// loaded to regenerate it.
+	mergeDriver = `cmp -s %A %B || { echo "terramate: generated file" %P "has conflicting changes, run 'terramate generate' to regenerate it" >&2; exit 1; }`
+)
+
+// Spec is the command specification for the experimental git-install-drivers command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+
+	// Hook installs a post-merge hook regenerating the code after merges.
+	Hook bool
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental git-install-drivers" }
+
+// Exec executes the experimental git-install-drivers command.
+// It registers a merge driver which fails the merge of generated files changed
+// differently by both sides, asking to regenerate them, and marks the
+// generated files in the .gitattributes of the project root. The conflicting
+// files keep their current version instead of the conflict markers, so the
+// configuration can be loaded by 'terramate generate' to resolve them. The
+// files are also assigned the diff driver, so users can customize how they
+// are diffed.
+func (s *Spec) Exec(_ context.Context) error {
+	prj := s.Engine.Project()
+	if !prj.IsRepo() {
+		return errors.E("git-install-drivers requires a git repository")
+	}
+
+	files, err := s.generatedFiles()
+	if err != nil {
+		return err
+	}
+
+	g := prj.Git.Wrapper
+	for _, kv := range [][2]string{
+		{"merge." + DriverName + ".name", "Terramate generated files (resolved by regeneration)"},
+		{"merge." + DriverName + ".driver", mergeDriver},
+	} {
+		if err := g.SetConfigValue(kv[0], kv[1]); err != nil {
+			return errors.E(err, "setting git config %s", kv[0])
+		}
+	}
+
+	rootdir := s.Engine.Config().HostDir()
+	attrfile := filepath.Join(rootdir, AttributesFile)
+	if err := updateAttributes(attrfile, files); err != nil {
+		return err
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("Configured git drivers for %d generated files in %s", len(files), AttributesFile))
+
+	if !s.Hook {
+		s.Printers.Stdout.Println("Run 'terramate generate' after merging, or to resolve conflicting generated files.")
+		return nil
+	}
+
+	hooksdir, err := g.GitPath("hooks")
+	if err != nil {
+		return errors.E(err, "resolving git hooks directory")
+	}
+	if !filepath.IsAbs(hooksdir) {
+		hooksdir = filepath.Join(rootdir, hooksdir)
+	}
+	if err := installHook(filepath.Join(hooksdir, "post-merge")); err != nil {
+		return err
+	}
+	s.Printers.Stdout.Println("Installed post-merge hook running 'terramate generate'")
+	return nil
+}
+
+func (s *Spec) generatedFiles() ([]string, error) {
+	vendorDir, err := s.Engine.VendorDir()
+	if err != nil {
+		return nil, err
+	}
+	results, err := generate.Load(s.Engine.Config(), 0, vendorDir)
+	if err != nil {
+		return nil, errors.E(err, "loading generated code")
+	}
+
+	errs := errors.L()
+	var files []string
+	for _, res := range results {
+		if res.Err != nil {
+			errs.Append(res.Err)
+			continue
+		}
+		for _, f := range res.Files {
+			if f.Condition() {
+				files = append(files, path.Join(res.Dir.String(), f.Label()))
+			}
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// updateAttributes rewrites the Terramate section of the given attributes
+// file, keeping any content outside of it untouched.
+func updateAttributes(fname string, files []string) error {
+	current, err := os.ReadFile(fname)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.E(err, "reading %s", AttributesFile)
+	}
+
+	var section bytes.Buffer
+	section.WriteString(BeginMarker + "\n")
+	for _, f := range files {
+		fmt.Fprintf(&section, "%s merge=%s diff=%s linguist-generated=true\n",
+			attrPattern(f), DriverName, DriverName)
+	}
+	section.WriteString(EndMarker + "\n")
+
+	var out bytes.Buffer
+	content := string(current)
+	begin := strings.Index(content, BeginMarker)
+	end := strings.Index(content, EndMarker)
+	switch {
+	case begin == -1 && end == -1:
+		out.WriteString(content)
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			out.WriteString("\n")
+		}
+		out.Write(section.Bytes())
+	case begin != -1 && end > begin:
+		out.WriteString(content[:begin])
+		out.Write(section.Bytes())
+		rest := strings.TrimPrefix(content[end+len(EndMarker):], "\n")
+		out.WriteString(rest)
+	default:
+		return errors.E("%s has an unbalanced terramate section", AttributesFile)
+	}
+
+	if err := os.WriteFile(fname, out.Bytes(), 0644); err != nil {
+		return errors.E(err, "writing %s", AttributesFile)
+	}
+	return nil
+}
+
+// attrPattern returns the attributes pattern matching exactly the given
+// project path. The glob characters are escaped and the whitespaces, which
+// separate the pattern from the attributes, are matched by a class.
+func attrPattern(file string) string {
+	var pattern strings.Builder
+	for _, r := range file {
+		switch r {
+		case '\\', '*', '?', '[':
+			pattern.WriteRune('\\')
+			pattern.WriteRune(r)
+		case ' ', '\t':
+			pattern.WriteString("[[:space:]]")
+		default:
+			pattern.WriteRune(r)
+		}
+	}
+	return pattern.String()
+}
+
+func installHook(fname string) error {
+	const script = "#!/bin/sh\n" + hookMarker + "\nexec terramate generate\n"
+
+	current, err := os.ReadFile(fname)
+	switch {
+	case err == nil && !strings.Contains(string(current), hookMarker):
+		return errors.E("refusing to overwrite existing hook %s", fname)
+	case err != nil && !os.IsNotExist(err):
+		return errors.E(err, "reading hook %s", fname)
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0755); err != nil {
+		return errors.E(err, "creating hooks directory")
+	}
+	if err := os.WriteFile(fname, []byte(script), 0755); err != nil {
+		return errors.E(err, "writing hook %s", fname)
+	}
+	return nil
+}
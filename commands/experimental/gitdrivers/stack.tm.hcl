@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package gitdrivers // import \"github.com/terramate-io/terramate/commands/experimental/gitdrivers\""
+  description = "package gitdrivers // import \"github.com/terramate-io/terramate/commands/experimental/gitdrivers\"\n\nPackage gitdrivers provides the experimental git-install-drivers command.\n\nconst DriverName = \"terramate-generated\" ...\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "gitdrivers", "golang"]
+  id          = "9905a6be-c614-491e-9d9c-95fbf05d3225"
+}
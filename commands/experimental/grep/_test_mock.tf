@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "grep" {
+  content = <<-EOF
+package grep // import "github.com/terramate-io/terramate/commands/experimental/grep"
+
+Package grep provides the experimental grep command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-grep.ignore"
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package grep provides the experimental grep command.
+package grep
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Spec is the command specification for the experimental grep command.
+type Spec struct {
+	Engine    *engine.Engine
+	Printers  printer.Printers
+	GitFilter engine.GitFilter
+
+	// Pattern is the regular expression searched for.
+	Pattern string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental grep" }
+
+// Exec executes the experimental grep command.
+// It searches the evaluated globals and the generated code of the selected
+// stacks, so values computed by expressions or inherited from parent
+// directories are also found.
+func (s *Spec) Exec(_ context.Context) error {
+	re, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return errors.E(err, "invalid pattern %q", s.Pattern)
+	}
+
+	report, err := s.Engine.ListStacks(s.GitFilter, cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "listing stacks")
+	}
+
+	cfg := s.Engine.Config()
+	stacks := s.Engine.FilterStacks(report.Stacks, filter.TagClause{})
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	vendorDir, err := s.Engine.VendorDir()
+	if err != nil {
+		return err
+	}
+	results, err := generate.Load(cfg, 0, vendorDir)
+	if err != nil {
+		return errors.E(err, "loading generated code")
+	}
+	generated := map[project.Path]generate.LoadResult{}
+	for _, res := range results {
+		generated[res.Dir] = res
+	}
+
+	for _, entry := range stacks {
+		st := entry.Stack
+
+		globalsReport := globals.ForStack(cfg, st)
+		if err := globalsReport.AsError(); err != nil {
+			return errors.E(err, "evaluating globals of stack %s", st.Dir)
+		}
+		walkValue("global", cty.ObjectVal(globalsReport.Globals.AsMaskedValueMap()), func(key, str string) {
+			if re.MatchString(str) {
+				s.Printers.Stdout.Println(fmt.Sprintf("%s: %s = %q", st.Dir, key, str))
+			}
+		})
+
+		res := generated[st.Dir]
+		if res.Err != nil {
+			return errors.E(res.Err, "loading generated code of stack %s", st.Dir)
+		}
+		for _, file := range res.Files {
+			if !file.Condition() {
+				continue
+			}
+			for i, line := range strings.Split(file.Body(), "\n") {
+				if re.MatchString(line) {
+					s.Printers.Stdout.Println(fmt.Sprintf("%s: %s:%d: %s",
+						st.Dir, path.Join(st.Dir.String(), file.Label()), i+1, strings.TrimSpace(line)))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// walkValue calls fn for each primitive value nested in val, with its
+// accessor key and its string representation.
+func walkValue(key string, val cty.Value, fn func(key, str string)) {
+	if !val.IsKnown() || val.IsNull() {
+		return
+	}
+	typ := val.Type()
+	switch {
+	case typ == cty.String:
+		fn(key, val.AsString())
+	case typ == cty.Number:
+		fn(key, val.AsBigFloat().Text('f', -1))
+	case typ == cty.Bool:
+		fn(key, fmt.Sprint(val.True()))
+	case typ.IsObjectType() || typ.IsMapType():
+		elems := val.AsValueMap()
+		names := make([]string, 0, len(elems))
+		for name := range elems {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			walkValue(key+"."+name, elems[name], fn)
+		}
+	case typ.IsListType() || typ.IsTupleType() || typ.IsSetType():
+		for i, elem := range val.AsValueSlice() {
+			walkValue(fmt.Sprintf("%s[%d]", key, i), elem, fn)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package grep // import \"github.com/terramate-io/terramate/commands/experimental/grep\""
+  description = "package grep // import \"github.com/terramate-io/terramate/commands/experimental/grep\"\n\nPackage grep provides the experimental grep command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "grep"]
+  id          = "d51974c1-31fe-4a3e-8c04-ccd0e0baf48e"
+}
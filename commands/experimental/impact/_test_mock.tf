@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "impact" {
+  content = <<-EOF
+package impact // import "github.com/terramate-io/terramate/commands/experimental/impact"
+
+Package impact provides the experimental impact command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-impact.ignore"
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package impact provides the experimental impact command.
+package impact
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+)
+
+// Spec is the command specification for the experimental impact command.
+type Spec struct {
+	WorkingDir string
+	Engine     *engine.Engine
+	Printers   printer.Printers
+
+	// File is the configuration file to analyze.
+	File string
+
+	// Global is the global accessor path (eg.: "a.b") to analyze.
+	Global string
+}
+
+// impact is a single configuration item of a stack affected by the change.
+type impact struct {
+	kind  string
+	name  string
+	where info.Range
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental impact" }
+
+// Exec executes the experimental impact command.
+// It reports the generate blocks and run environment variables of each stack
+// which depend, directly or through other globals, on the given file or global.
+func (s *Spec) Exec(_ context.Context) error {
+	if (s.File == "") == (s.Global == "") {
+		return errors.E("either --file or --global must be provided")
+	}
+
+	var file project.Path
+	if s.File != "" {
+		file = s.projectPath(s.File)
+	}
+
+	report, err := s.Engine.ListStacks(engine.NoGitFilter(), cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "listing stacks")
+	}
+
+	cfg := s.Engine.Config()
+	for _, stackEntry := range s.Engine.FilterStacks(report.Stacks, filter.TagClause{}) {
+		st := stackEntry.Stack
+		tree, _ := cfg.Lookup(st.Dir)
+
+		var seeds [][]string
+		if s.Global != "" {
+			seeds = append(seeds, strings.Split(s.Global, "."))
+		}
+		impacts, err := stackImpact(tree, file, seeds)
+		if err != nil {
+			return errors.E(err, "analyzing stack %s", st.Dir)
+		}
+		if len(impacts) == 0 {
+			continue
+		}
+		s.Printers.Stdout.Println(fmt.Sprintf("stack %q:", st.Dir))
+		for _, imp := range impacts {
+			s.Printers.Stdout.Println(fmt.Sprintf("\t%s %s (defined at %s:%d)",
+				imp.kind, imp.name, imp.where.Path(), imp.where.Start().Line()))
+		}
+	}
+	return nil
+}
+
+func (s *Spec) projectPath(file string) project.Path {
+	rootdir := s.Engine.Config().HostDir()
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(s.WorkingDir, file)
+	} else if file != rootdir && !strings.HasPrefix(file, rootdir+string(filepath.Separator)) {
+		// not a host path, then it's a project path.
+		return project.NewPath(filepath.ToSlash(file))
+	}
+	return project.PrjAbsPath(rootdir, file)
+}
+
+// stackImpact computes the configuration items of the stack at tree which
+// are impacted by a change in the given file or in the globals denoted by the
+// seeds accessor paths.
+func stackImpact(tree *config.Tree, file project.Path, seeds [][]string) ([]impact, error) {
+	exprs, err := globals.LoadExprs(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := seeds
+	defs := exprs.Definitions()
+	pending := make([]globals.Definition, 0, len(defs))
+	for _, def := range defs {
+		if file.String() != "" && def.Origin.Path() == file {
+			changed = append(changed, def.Path)
+			continue
+		}
+		pending = append(pending, def)
+	}
+
+	// propagate the change to globals depending on changed globals until no
+	// more globals are affected.
+	for {
+		var next []globals.Definition
+		for _, def := range pending {
+			if referencesAny(def.Expr.Variables(), changed) {
+				changed = append(changed, def.Path)
+				continue
+			}
+			next = append(next, def)
+		}
+		if len(next) == len(pending) {
+			break
+		}
+		pending = next
+	}
+
+	affected := func(where info.Range, traversals []hhcl.Traversal) bool {
+		return (file.String() != "" && where.Path() == file) || referencesAny(traversals, changed)
+	}
+
+	var impacts []impact
+	for _, block := range genHCLBlocks(tree) {
+		if affected(block.Range, generate.HCLBlockTraversals(block)) {
+			impacts = append(impacts, impact{
+				kind:  "generate_hcl",
+				name:  fmt.Sprintf("%q", block.Label),
+				where: block.Range,
+			})
+		}
+	}
+	for _, block := range genFileBlocks(tree) {
+		if affected(block.Range, generate.FileBlockTraversals(block)) {
+			impacts = append(impacts, impact{
+				kind:  "generate_file",
+				name:  fmt.Sprintf("%q", block.Label),
+				where: block.Range,
+			})
+		}
+	}
+	for _, attr := range envAttributes(tree) {
+		if affected(attr.Range, attr.Expr.Variables()) {
+			impacts = append(impacts, impact{
+				kind:  "env",
+				name:  attr.Name,
+				where: attr.Range,
+			})
+		}
+	}
+	return impacts, nil
+}
+
+func genHCLBlocks(tree *config.Tree) []hcl.GenHCLBlock {
+	var blocks []hcl.GenHCLBlock
+	for node := tree; node != nil; node = node.Parent {
+		blocks = append(blocks, node.Node.Generate.HCLs...)
+	}
+	return blocks
+}
+
+func genFileBlocks(tree *config.Tree) []hcl.GenFileBlock {
+	var blocks []hcl.GenFileBlock
+	for node := tree; node != nil; node = node.Parent {
+		for _, block := range node.Node.Generate.Files {
+			if block.Context == "root" {
+				continue
+			}
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// envAttributes returns the effective terramate.config.run.env attributes of
+// the stack, ie. the closest definition of each variable.
+func envAttributes(tree *config.Tree) []ast.Attribute {
+	var attrs []ast.Attribute
+	seen := map[string]struct{}{}
+	for node := tree; node != nil; node = node.Parent {
+		if !node.Node.HasRunEnv() {
+			continue
+		}
+		for _, attr := range node.Node.Terramate.Config.Run.Env.Attributes.SortedList() {
+			if _, ok := seen[attr.Name]; ok {
+				continue
+			}
+			seen[attr.Name] = struct{}{}
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// referencesAny tells if any of the traversals references a global which
+// overlaps with any of the given global accessor paths.
+func referencesAny(traversals []hhcl.Traversal, paths [][]string) bool {
+	for _, traversal := range traversals {
+		ref, ok := generate.GlobalRef(traversal)
+		if !ok {
+			continue
+		}
+		for _, path := range paths {
+			if overlaps(ref, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps tells if one of the paths is a prefix of the other, meaning that
+// a change in one of them can change the value of the other.
+func overlaps(a, b []string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package impact // import \"github.com/terramate-io/terramate/commands/experimental/impact\""
+  description = "package impact // import \"github.com/terramate-io/terramate/commands/experimental/impact\"\n\nPackage impact provides the experimental impact command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "impact"]
+  id          = "317b5176-274d-4e80-a560-8957864a2d03"
+}
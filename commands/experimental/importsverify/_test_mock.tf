@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "importsverify" {
+  content = <<-EOF
+package importsverify // import "github.com/terramate-io/terramate/commands/experimental/importsverify"
+
+Package importsverify provides the experimental imports verify command.
+
+const ErrNotPinned errors.Kind = "import not pinned"
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-importsverify.ignore"
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package importsverify provides the experimental imports verify command.
+package importsverify
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+)
+
+// ErrNotPinned indicates that an imported file has no pinned integrity hash.
+const ErrNotPinned errors.Kind = "import not pinned"
+
+// Spec is the command specification for the experimental imports verify command.
+type Spec struct {
+	WorkingDir string
+	Engine     *engine.Engine
+	Printers   printer.Printers
+
+	// Strict fails the verification if any import is not pinned.
+	Strict bool
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental imports verify" }
+
+// Exec executes the experimental imports verify command.
+// The integrity of the pinned imports is already checked when parsing the
+// configuration, so this command reports the state of all imports, showing
+// the digest of the files which are not pinned yet.
+func (s *Spec) Exec(_ context.Context) error {
+	root := s.Engine.Config()
+
+	type key struct {
+		origin string
+		file   string
+	}
+	seen := map[key]struct{}{}
+	var files []hcl.ImportedFile
+	for _, tree := range root.Tree().AsList() {
+		if len(tree.TerramateFiles) == 0 {
+			continue
+		}
+		p, err := hcl.NewTerramateParser(root.HostDir(), tree.HostDir(), root.HCLOptions()...)
+		if err != nil {
+			return err
+		}
+		for _, filename := range tree.TerramateFiles {
+			if err := p.AddFile(filepath.Join(tree.HostDir(), filename)); err != nil {
+				return err
+			}
+		}
+		if err := p.ParseHCL(); err != nil {
+			return err
+		}
+		for _, file := range p.ImportedFiles {
+			k := key{origin: file.Origin.String(), file: file.HostPath}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			files = append(files, file)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].HostPath != files[j].HostPath {
+			return files[i].HostPath < files[j].HostPath
+		}
+		return files[i].Origin.String() < files[j].Origin.String()
+	})
+
+	notPinned := 0
+	for _, file := range files {
+		path := project.PrjAbsPath(root.HostDir(), file.HostPath)
+		if file.Pinned {
+			s.Printers.Stdout.Println(fmt.Sprintf("%s: ok (imported at %s)", path, file.Origin))
+			continue
+		}
+		notPinned++
+		s.Printers.Stdout.Println(fmt.Sprintf("%s: not pinned, sha256 = %q (imported at %s)",
+			path, file.SHA256, file.Origin))
+	}
+
+	if s.Strict && notPinned > 0 {
+		return errors.E(ErrNotPinned, "%d imported files are not pinned with import.sha256", notPinned)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package importsverify // import \"github.com/terramate-io/terramate/commands/experimental/importsverify\""
+  description = "package importsverify // import \"github.com/terramate-io/terramate/commands/experimental/importsverify\"\n\nPackage importsverify provides the experimental imports verify command.\n\nconst ErrNotPinned errors.Kind = \"import not pinned\"\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "importsverify"]
+  id          = "52ff954c-8517-44d9-b661-558b3a00b2a6"
+}
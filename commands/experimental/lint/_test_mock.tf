@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "lint" {
+  content = <<-EOF
+package lint // import "github.com/terramate-io/terramate/commands/experimental/lint"
+
+Package lint provides the experimental lint command.
+
+const ErrIssues errors.Kind = "lint issues found"
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-lint.ignore"
+}
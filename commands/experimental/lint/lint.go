@@ -0,0 +1,467 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package lint provides the experimental lint command.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrIssues indicates that the lint found issues in the configuration.
+const ErrIssues errors.Kind = "lint issues found"
+
+// Spec is the command specification for the experimental lint command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+
+	// Ordering reports the stacks likely missing before/after ordering
+	// instead of the dead configuration.
+	Ordering bool
+}
+
+// issue is a finding of the lint at some configuration item.
+type issue struct {
+	where info.Range
+	msg   string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental lint" }
+
+// Exec executes the experimental lint command.
+// It reports the dead configuration of the project: globals which are never
+// used by the generate blocks, scripts, run environment, exported terraform
+// variables, asserts, providers and sharing inputs and outputs of any stack,
+// directly or through other globals,
+// globals overridden by child directories in all stacks, lets never
+// referenced in their block and generate blocks whose condition is false in
+// all the stacks.
+// With the Ordering option, it reports instead the stacks which are likely to
+// depend on each other but have no run order defined between them.
+func (s *Spec) Exec(_ context.Context) error {
+	cfg := s.Engine.Config()
+
+	issues, err := s.issues(cfg)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].where, issues[j].where
+		if a.Path() != b.Path() {
+			return a.Path().String() < b.Path().String()
+		}
+		return a.Start().Line() < b.Start().Line()
+	})
+	for _, is := range issues {
+		s.Printers.Stdout.Println(fmt.Sprintf("%s:%d: %s", is.where.Path(), is.where.Start().Line(), is.msg))
+	}
+	if len(issues) > 0 {
+		return errors.E(ErrIssues, "%d issues found", len(issues))
+	}
+	return nil
+}
+
+func (s *Spec) issues(cfg *config.Root) ([]issue, error) {
+	if s.Ordering {
+		return orderingIssues(cfg)
+	}
+
+	issues, err := globalIssues(cfg)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, letIssues(cfg)...)
+
+	condIssues, err := s.conditionIssues(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, condIssues...), nil
+}
+
+// globalIssues reports the unused and always overridden globals.
+func globalIssues(cfg *config.Root) ([]issue, error) {
+	type globalDef struct {
+		path      []string
+		where     info.Range
+		effective bool
+		used      bool
+	}
+	defs := map[string]*globalDef{}
+	var order []string
+	lookup := func(path []string, where info.Range) *globalDef {
+		key := where.String()
+		def, ok := defs[key]
+		if !ok {
+			def = &globalDef{path: path, where: where}
+			defs[key] = def
+			order = append(order, key)
+		}
+		return def
+	}
+
+	for _, tree := range cfg.Tree().Stacks() {
+		exprs, err := globals.LoadExprs(tree)
+		if err != nil {
+			return nil, errors.E(err, "loading globals of stack %s", tree.Dir())
+		}
+		stackDefs := exprs.Definitions()
+		used, all := usedGlobals(stackDefs, stackTraversals(tree))
+		for _, def := range stackDefs {
+			gdef := lookup(def.Path, def.Origin)
+			gdef.effective = true
+			if all || referenced(def.Path, used) {
+				gdef.used = true
+			}
+			for _, override := range def.Overrides {
+				lookup(def.Path, override)
+			}
+		}
+	}
+
+	var issues []issue
+	for _, key := range order {
+		def := defs[key]
+		name := strings.Join(def.path, ".")
+		switch {
+		case !def.effective:
+			issues = append(issues, issue{
+				where: def.where,
+				msg:   fmt.Sprintf("global %s is overridden by child directories in all stacks", name),
+			})
+		case !def.used:
+			issues = append(issues, issue{
+				where: def.where,
+				msg:   fmt.Sprintf("global %s is never used", name),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// usedGlobals returns the accessor paths of the globals used by the given
+// traversals, directly or through the definitions of other globals. If any
+// traversal references the globals dynamically then all of them are used.
+func usedGlobals(defs []globals.Definition, traversals []hhcl.Traversal) ([][]string, bool) {
+	var used [][]string
+	for _, traversal := range traversals {
+		if traversal.RootName() != "global" {
+			continue
+		}
+		ref, ok := generate.GlobalRef(traversal)
+		if !ok {
+			return nil, true
+		}
+		used = append(used, ref)
+	}
+
+	pending := defs
+	for {
+		var next []globals.Definition
+		for _, def := range pending {
+			if !referenced(def.Path, used) {
+				next = append(next, def)
+				continue
+			}
+			for _, traversal := range def.Expr.Variables() {
+				if traversal.RootName() != "global" {
+					continue
+				}
+				ref, ok := generate.GlobalRef(traversal)
+				if !ok {
+					return nil, true
+				}
+				used = append(used, ref)
+			}
+		}
+		if len(next) == len(pending) {
+			return used, false
+		}
+		pending = next
+	}
+}
+
+// referenced tells if the global path overlaps with any of the used paths.
+func referenced(path []string, used [][]string) bool {
+	for _, ref := range used {
+		if overlaps(path, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps tells if one of the paths is a prefix of the other.
+func overlaps(a, b []string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stackTraversals returns the variable traversals of all the configuration
+// evaluated for the stack at tree, except globals. The globals exported by
+// terramate.config.run.export_globals_as_tfvars are returned as traversals
+// of the literal prefix of their patterns.
+func stackTraversals(tree *config.Tree) []hhcl.Traversal {
+	traversals := exportedGlobalsTraversals(tree.RootTree())
+	seenEnv := map[string]struct{}{}
+	for node := tree; node != nil; node = node.Parent {
+		cfg := node.Node
+		for _, block := range cfg.Generate.HCLs {
+			traversals = append(traversals, generate.HCLBlockTraversals(block)...)
+		}
+		// the generate_yaml blocks are parsed as generate_file blocks.
+		for _, block := range cfg.Generate.Files {
+			if block.Context == "root" {
+				continue
+			}
+			traversals = append(traversals, generate.FileBlockTraversals(block)...)
+		}
+		for _, script := range cfg.Scripts {
+			traversals = append(traversals, scriptTraversals(script)...)
+		}
+		for _, assert := range cfg.Asserts {
+			traversals = append(traversals, exprsTraversals(
+				assert.Assertion, assert.Message, assert.Warning, assert.Code, assert.DocsURL)...)
+		}
+		for _, input := range cfg.Inputs {
+			traversals = append(traversals, exprsTraversals(
+				input.Backend, input.FromStackID, input.Value, input.Sensitive, input.Mock)...)
+		}
+		for _, output := range cfg.Outputs {
+			traversals = append(traversals, exprsTraversals(
+				output.Backend, output.Description, output.Value, output.Sensitive)...)
+		}
+		for _, block := range cfg.Providers {
+			traversals = append(traversals, exprsTraversals(block.Filename, block.Environment)...)
+			for _, provider := range block.Providers {
+				traversals = append(traversals, exprsTraversals(
+					provider.Source, provider.Version, provider.Environments)...)
+			}
+		}
+		if node.Node.HasRunEnv() {
+			for _, attr := range cfg.Terramate.Config.Run.Env.Attributes.SortedList() {
+				// only the closest definition of each variable is evaluated.
+				if _, ok := seenEnv[attr.Name]; ok {
+					continue
+				}
+				seenEnv[attr.Name] = struct{}{}
+				traversals = append(traversals, attr.Expr.Variables()...)
+			}
+		}
+	}
+	return traversals
+}
+
+// exportedGlobalsTraversals returns a traversal for each pattern of
+// terramate.config.run.export_globals_as_tfvars, up to its first glob key.
+// Eg.: "tfvars.*" returns global.tfvars and "*" returns global, which uses
+// all the globals.
+func exportedGlobalsTraversals(root *config.Tree) []hhcl.Traversal {
+	cfg := root.Node
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil || cfg.Terramate.Config.Run == nil {
+		return nil
+	}
+	var traversals []hhcl.Traversal
+	for _, pattern := range cfg.Terramate.Config.Run.ExportGlobalsAsTFVars {
+		traversal := hhcl.Traversal{hhcl.TraverseRoot{Name: "global"}}
+		for _, key := range strings.Split(pattern, ".") {
+			if strings.ContainsAny(key, `*?[\`) {
+				break
+			}
+			traversal = append(traversal, hhcl.TraverseAttr{Name: key})
+		}
+		traversals = append(traversals, traversal)
+	}
+	return traversals
+}
+
+func scriptTraversals(script *hcl.Script) []hhcl.Traversal {
+	attrs := []*ast.Attribute{script.Name, script.Description}
+	for _, job := range script.Jobs {
+		attrs = append(attrs, job.Name, job.Description, job.Artifacts, job.DependsOnFiles)
+		if job.Command != nil {
+			attrs = append(attrs, (*ast.Attribute)(job.Command))
+		}
+		if job.Commands != nil {
+			attrs = append(attrs, (*ast.Attribute)(job.Commands))
+		}
+		if job.Approval != nil {
+			attrs = append(attrs, job.Approval.Message, job.Approval.File)
+		}
+	}
+	var traversals []hhcl.Traversal
+	for _, attr := range attrs {
+		if attr != nil {
+			traversals = append(traversals, attr.Expr.Variables()...)
+		}
+	}
+	traversals = append(traversals, letsTraversals(script.Lets)...)
+	return traversals
+}
+
+func letsTraversals(lets *ast.MergedBlock) []hhcl.Traversal {
+	var traversals []hhcl.Traversal
+	if lets != nil {
+		for _, attr := range lets.Attributes {
+			traversals = append(traversals, attr.Expr.Variables()...)
+		}
+	}
+	return traversals
+}
+
+func exprsTraversals(exprs ...hhcl.Expression) []hhcl.Traversal {
+	var traversals []hhcl.Traversal
+	for _, expr := range exprs {
+		if expr != nil {
+			traversals = append(traversals, expr.Variables()...)
+		}
+	}
+	return traversals
+}
+
+// letIssues reports the lets never referenced in the generate block or
+// script defining them.
+func letIssues(cfg *config.Root) []issue {
+	var issues []issue
+	for _, tree := range cfg.Tree().AsList() {
+		for _, block := range tree.Node.Generate.HCLs {
+			issues = append(issues, unusedLets(block.Lets,
+				generate.HCLBlockTraversals(block), fmt.Sprintf("generate_hcl %q", block.Label))...)
+		}
+		for _, block := range tree.Node.Generate.Files {
+			issues = append(issues, unusedLets(block.Lets,
+				generate.FileBlockTraversals(block), fmt.Sprintf("generate_file %q", block.Label))...)
+		}
+		for _, script := range tree.Node.Scripts {
+			issues = append(issues, unusedLets(script.Lets,
+				scriptTraversals(script), fmt.Sprintf("script %q", strings.Join(script.Labels, " ")))...)
+		}
+	}
+	return issues
+}
+
+func unusedLets(lets *ast.MergedBlock, traversals []hhcl.Traversal, block string) []issue {
+	if lets == nil {
+		return nil
+	}
+	used := map[string]bool{}
+	for _, traversal := range traversals {
+		if traversal.RootName() != "let" {
+			continue
+		}
+		if len(traversal) < 2 {
+			// let referenced dynamically, so all of them are used.
+			return nil
+		}
+		switch step := traversal[1].(type) {
+		case hhcl.TraverseAttr:
+			used[step.Name] = true
+		case hhcl.TraverseIndex:
+			if !step.Key.IsKnown() || !step.Key.Type().Equals(cty.String) {
+				return nil
+			}
+			used[step.Key.AsString()] = true
+		}
+	}
+	var issues []issue
+	for _, attr := range lets.Attributes.SortedList() {
+		if !used[attr.Name] {
+			issues = append(issues, issue{
+				where: attr.Range,
+				msg:   fmt.Sprintf("let %s of %s is never used", attr.Name, block),
+			})
+		}
+	}
+	return issues
+}
+
+// conditionIssues reports the generate blocks with a condition which is
+// false in all the directories where the block is evaluated.
+func (s *Spec) conditionIssues(cfg *config.Root) ([]issue, error) {
+	type condBlock struct {
+		name  string
+		where info.Range
+	}
+	blocks := map[string]condBlock{}
+	var order []string
+	addBlock := func(kind, label string, where info.Range, cond *hclsyntax.Attribute) {
+		if cond == nil {
+			return
+		}
+		blocks[where.String()] = condBlock{
+			name:  fmt.Sprintf("%s %q", kind, label),
+			where: where,
+		}
+		order = append(order, where.String())
+	}
+	for _, tree := range cfg.Tree().AsList() {
+		for _, block := range tree.Node.Generate.HCLs {
+			addBlock("generate_hcl", block.Label, block.Range, block.Condition)
+		}
+		for _, block := range tree.Node.Generate.Files {
+			addBlock("generate_file", block.Label, block.Range, block.Condition)
+		}
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+
+	vendorDir, err := s.Engine.VendorDir()
+	if err != nil {
+		return nil, err
+	}
+	results, err := generate.Load(cfg, 0, vendorDir)
+	if err != nil {
+		return nil, errors.E(err, "loading generated code")
+	}
+	evaluated := map[string]bool{}
+	enabled := map[string]bool{}
+	for _, res := range results {
+		if res.Err != nil {
+			return nil, errors.E(res.Err, "loading generated code of %s", res.Dir)
+		}
+		for _, file := range res.Files {
+			key := file.Range().String()
+			evaluated[key] = true
+			enabled[key] = enabled[key] || file.Condition()
+		}
+	}
+
+	var issues []issue
+	for _, key := range order {
+		if evaluated[key] && !enabled[key] {
+			block := blocks[key]
+			issues = append(issues, issue{
+				where: block.where,
+				msg:   fmt.Sprintf("condition of %s is false in all stacks", block.name),
+			})
+		}
+	}
+	return issues, nil
+}
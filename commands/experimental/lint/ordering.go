@@ -0,0 +1,295 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclparse"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/run/dag"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// stateLocationAttrs are the backend attributes identifying the location of
+// a state. The other attributes, like credentials and locking, are ignored
+// when comparing the backends and the terraform_remote_state data sources.
+var stateLocationAttrs = []string{
+	"address",
+	"bucket",
+	"container_name",
+	"conn_str",
+	"hostname",
+	"key",
+	"organization",
+	"path",
+	"prefix",
+	"schema_name",
+	"storage_account_name",
+	"workspace_key_prefix",
+}
+
+// stackState is the state related configuration of the Terraform files of a
+// stack.
+type stackState struct {
+	dir          string
+	backends     []stateRef
+	remoteStates []stateRef
+	imports      []importRef
+}
+
+// stateRef is a backend configuration or a terraform_remote_state data
+// source, identified by its backend type and location.
+type stateRef struct {
+	location string
+	where    info.Range
+}
+
+// importRef is an import block with a literal id.
+type importRef struct {
+	id    string
+	where info.Range
+}
+
+// orderingIssues reports the stacks which are likely to depend on each other
+// but have no run order defined between them: stacks reading the state of
+// other stacks, with terraform_remote_state data sources or with input blocks,
+// stacks sharing the same state and stacks importing the same resource.
+func orderingIssues(cfg *config.Root) ([]issue, error) {
+	stacks, err := config.LoadAllStacks(cfg, cfg.Tree())
+	if err != nil {
+		return nil, errors.E(err, "loading stacks")
+	}
+	ordered, reason, err := run.OrderRelation(cfg, stacks,
+		func(s *config.SortableStack) *config.Stack { return s.Stack })
+	if err != nil {
+		if errors.IsKind(err, dag.ErrCycleDetected) {
+			return nil, errors.E(err, "cycle detected: %s", reason)
+		}
+		return nil, errors.E(err, "computing the run order")
+	}
+
+	var states []stackState
+	stackByID := map[string]string{}
+	for _, st := range stacks {
+		states = append(states, loadStackState(cfg, st.Stack))
+		if st.Stack.ID != "" {
+			stackByID[strings.ToLower(st.Stack.ID)] = st.Stack.Dir.String()
+		}
+	}
+
+	var issues []issue
+	reported := map[string]bool{}
+	report := func(first, second string, where info.Range, msg string) {
+		if first == second || ordered(first, second) {
+			return
+		}
+		key := fmt.Sprintf("%s|%s|%s", where.String(), first, second)
+		if reported[key] {
+			return
+		}
+		reported[key] = true
+		issues = append(issues, issue{
+			where: where,
+			msg:   fmt.Sprintf("%s but their run order is not defined: consider adding after = [%q] to stack %s", msg, first, second),
+		})
+	}
+
+	backends := map[string][]string{}
+	imports := map[string][]string{}
+	for _, state := range states {
+		for _, backend := range state.backends {
+			backends[backend.location] = appendUniq(backends[backend.location], state.dir)
+		}
+		for _, imp := range state.imports {
+			imports[imp.id] = appendUniq(imports[imp.id], state.dir)
+		}
+	}
+
+	for _, state := range states {
+		for _, remote := range state.remoteStates {
+			for _, other := range backends[remote.location] {
+				report(other, state.dir, remote.where,
+					fmt.Sprintf("stack %s reads the state of stack %s with a terraform_remote_state data source", state.dir, other))
+			}
+		}
+		for _, backend := range state.backends {
+			for _, other := range backends[backend.location] {
+				if other < state.dir {
+					report(other, state.dir, backend.where,
+						fmt.Sprintf("stacks %s and %s use the same backend state", other, state.dir))
+				}
+			}
+		}
+		for _, imp := range state.imports {
+			for _, other := range imports[imp.id] {
+				if other < state.dir {
+					report(other, state.dir, imp.where,
+						fmt.Sprintf("stacks %s and %s import the resource ID %q", other, state.dir, imp.id))
+				}
+			}
+		}
+		tree, ok := cfg.Lookup(project.NewPath(state.dir))
+		if !ok {
+			continue
+		}
+		for _, input := range tree.Node.Inputs {
+			val, diags := input.FromStackID.Value(nil)
+			if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+				continue
+			}
+			other, ok := stackByID[strings.ToLower(val.AsString())]
+			if !ok {
+				continue
+			}
+			report(other, state.dir, input.Range,
+				fmt.Sprintf("stack %s uses the outputs of stack %s in input.%s", state.dir, other, input.Name))
+		}
+	}
+	return issues, nil
+}
+
+// loadStackState parses the Terraform files of the stack directory.
+// The files with invalid syntax and the non-literal values are ignored.
+func loadStackState(cfg *config.Root, st *config.Stack) stackState {
+	state := stackState{dir: st.Dir.String()}
+	tree, ok := cfg.Lookup(st.Dir)
+	if !ok {
+		return state
+	}
+	hostdir := st.HostDir(cfg)
+	files := append([]string{}, tree.OtherFiles...)
+	sort.Strings(files)
+	for _, fname := range files {
+		if filepath.Ext(fname) != ".tf" {
+			continue
+		}
+		f, diags := hclparse.NewParser().ParseHCLFile(filepath.Join(hostdir, fname))
+		if diags.HasErrors() {
+			continue
+		}
+		body := f.Body.(*hclsyntax.Body)
+		for _, block := range body.Blocks {
+			where := info.NewRange(cfg.HostDir(), block.DefRange())
+			switch block.Type {
+			case "terraform":
+				for _, backend := range block.Body.Blocks {
+					if backend.Type != "backend" || len(backend.Labels) != 1 {
+						continue
+					}
+					attrs := map[string]string{}
+					for name, attr := range backend.Body.Attributes {
+						if val, ok := literalString(attr.Expr); ok {
+							attrs[name] = val
+						}
+					}
+					if loc, ok := stateLocation(hostdir, backend.Labels[0], attrs); ok {
+						state.backends = append(state.backends, stateRef{
+							location: loc,
+							where:    info.NewRange(cfg.HostDir(), backend.DefRange()),
+						})
+					}
+				}
+			case "data":
+				if len(block.Labels) != 2 || block.Labels[0] != "terraform_remote_state" {
+					continue
+				}
+				backendAttr, ok := block.Body.Attributes["backend"]
+				if !ok {
+					continue
+				}
+				backendType, ok := literalString(backendAttr.Expr)
+				if !ok {
+					continue
+				}
+				attrs := map[string]string{}
+				if configAttr, ok := block.Body.Attributes["config"]; ok {
+					items, diags := hhcl.ExprMap(configAttr.Expr)
+					if diags.HasErrors() {
+						continue
+					}
+					for _, item := range items {
+						key := hhcl.ExprAsKeyword(item.Key)
+						if key == "" {
+							keyVal, diags := item.Key.Value(nil)
+							if diags.HasErrors() || keyVal.Type() != cty.String {
+								continue
+							}
+							key = keyVal.AsString()
+						}
+						if val, ok := literalString(item.Value); ok {
+							attrs[key] = val
+						}
+					}
+				}
+				if loc, ok := stateLocation(hostdir, backendType, attrs); ok {
+					state.remoteStates = append(state.remoteStates, stateRef{
+						location: loc,
+						where:    where,
+					})
+				}
+			case "import":
+				idAttr, ok := block.Body.Attributes["id"]
+				if !ok {
+					continue
+				}
+				if id, ok := literalString(idAttr.Expr); ok && id != "" {
+					state.imports = append(state.imports, importRef{id: id, where: where})
+				}
+			}
+		}
+	}
+	return state
+}
+
+// stateLocation returns the identifier of the state location of the backend.
+// The path of the local backend is relative to the stack directory.
+func stateLocation(hostdir, backendType string, attrs map[string]string) (string, bool) {
+	if backendType == "local" {
+		path, ok := attrs["path"]
+		if !ok {
+			path = "terraform.tfstate"
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(hostdir, path)
+		}
+		attrs = map[string]string{"path": filepath.Clean(path)}
+	}
+	var parts []string
+	for _, name := range stateLocationAttrs {
+		if val, ok := attrs[name]; ok {
+			parts = append(parts, name+"="+val)
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return backendType + ":" + strings.Join(parts, ","), true
+}
+
+func literalString(expr hhcl.Expression) (string, bool) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+func appendUniq(dirs []string, dir string) []string {
+	for _, d := range dirs {
+		if d == dir {
+			return dirs
+		}
+	}
+	return append(dirs, dir)
+}
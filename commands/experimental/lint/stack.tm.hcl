@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package lint // import \"github.com/terramate-io/terramate/commands/experimental/lint\""
+  description = "package lint // import \"github.com/terramate-io/terramate/commands/experimental/lint\"\n\nPackage lint provides the experimental lint command.\n\nconst ErrIssues errors.Kind = \"lint issues found\"\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "lint"]
+  id          = "3552664f-30fd-4f91-9682-9202729aa638"
+}
@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "providerscheck" {
+  content = <<-EOF
+package providerscheck // import "github.com/terramate-io/terramate/commands/experimental/providerscheck"
+
+Package providerscheck provides the experimental providers check command.
+
+const ErrDrift errors.Kind = "providers drift from the central constraints"
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-providerscheck.ignore"
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerscheck provides the experimental providers check command.
+package providerscheck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/generate/providers"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/tf"
+)
+
+// ErrDrift indicates that the Terraform code of some stack requires provider
+// versions different from the central constraints.
+const ErrDrift errors.Kind = "providers drift from the central constraints"
+
+// defaultRegistry is the registry of the provider sources without hostname.
+const defaultRegistry = "registry.terraform.io/"
+
+// Spec is the command specification for the experimental providers check command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental providers check" }
+
+// Exec executes the experimental providers check command.
+// It reports the provider requirements declared by the Terraform and Terraform
+// Stacks files of the stacks, not generated by Terramate, which differ from the requirements
+// resolved from the providers blocks.
+func (s *Spec) Exec(_ context.Context) error {
+	report, err := s.Engine.ListStacks(engine.NoGitFilter(), cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "listing stacks")
+	}
+
+	root := s.Engine.Config()
+	drifts := 0
+	for _, stackEntry := range s.Engine.FilterStacks(report.Stacks, filter.TagClause{}) {
+		st := stackEntry.Stack
+		tree, _ := root.Lookup(st.Dir)
+
+		globalsReport := globals.ForStack(root, st)
+		if err := globalsReport.AsError(); err != nil {
+			return errors.E(err, "evaluating globals of stack %s", st.Dir)
+		}
+		evalctx := stack.NewEvalCtx(root, st, globalsReport.Globals)
+		reqs, found, err := providers.Resolve(tree, evalctx.Context)
+		if err != nil {
+			return errors.E(err, "resolving providers of stack %s", st.Dir)
+		}
+		if !found {
+			continue
+		}
+		central := map[string]providers.Provider{}
+		for _, provider := range reqs.Providers {
+			central[provider.Name] = provider
+		}
+
+		stackdir := st.HostDir(root)
+		genfiles, err := generate.ListStackGenFiles(root, stackdir)
+		if err != nil {
+			return errors.E(err, "listing generated files of stack %s", st.Dir)
+		}
+		skip := map[string]bool{reqs.Filename: true}
+		for _, genfile := range genfiles {
+			skip[genfile] = true
+		}
+
+		entries, err := os.ReadDir(stackdir)
+		if err != nil {
+			return errors.E(err, "listing files of stack %s", st.Dir)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !isRequirementsFile(entry.Name()) || skip[entry.Name()] {
+				continue
+			}
+			required, err := tf.ParseRequiredProviders(filepath.Join(stackdir, entry.Name()))
+			if err != nil {
+				return errors.E(err, "parsing %s of stack %s", entry.Name(), st.Dir)
+			}
+			for _, req := range required {
+				want, ok := central[req.Name]
+				if !ok {
+					continue
+				}
+				if req.Source != "" && normalizeSource(req.Source) != normalizeSource(want.Source) {
+					drifts++
+					s.Printers.Stdout.Println(fmt.Sprintf(
+						"stack %q: %s requires provider %s from %q but the central source is %q",
+						st.Dir, entry.Name(), req.Name, req.Source, want.Source))
+				}
+				if req.Version != "" && req.Version != want.Version {
+					drifts++
+					s.Printers.Stdout.Println(fmt.Sprintf(
+						"stack %q: %s requires provider %s version %q but the central constraint is %q (defined at %s:%d)",
+						st.Dir, entry.Name(), req.Name, req.Version, want.Version,
+						want.Origin.Path(), want.Origin.Start().Line()))
+				}
+			}
+		}
+	}
+	if drifts > 0 {
+		return errors.E(ErrDrift, "%d provider requirements drift from the central constraints", drifts)
+	}
+	return nil
+}
+
+// isRequirementsFile tells if the file can declare provider requirements,
+// which is the case of the Terraform files and the Terraform Stacks files.
+func isRequirementsFile(fname string) bool {
+	return filepath.Ext(fname) == ".tf" || strings.HasSuffix(fname, tf.StackFileExt)
+}
+
+func normalizeSource(source string) string {
+	return strings.TrimPrefix(strings.ToLower(source), defaultRegistry)
+}
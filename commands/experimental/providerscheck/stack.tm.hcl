@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package providerscheck // import \"github.com/terramate-io/terramate/commands/experimental/providerscheck\""
+  description = "package providerscheck // import \"github.com/terramate-io/terramate/commands/experimental/providerscheck\"\n\nPackage providerscheck provides the experimental providers check command.\n\nconst ErrDrift errors.Kind = \"providers drift from the central constraints\"\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "providerscheck"]
+  id          = "2e6dae5f-e523-40c4-9d39-c0281d70a1d7"
+}
@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "rerun" {
+  content = <<-EOF
+package rerun // import "github.com/terramate-io/terramate/commands/experimental/rerun"
+
+Package rerun provides the experimental rerun command.
+
+func Compare(recorded, current record.Record) []string
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-rerun.ignore"
+}
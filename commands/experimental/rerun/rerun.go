@@ -0,0 +1,158 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package rerun provides the experimental rerun command.
+package rerun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	runcmd "github.com/terramate-io/terramate/commands/run"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/diff"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/record"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Spec is the command specification for the experimental rerun command.
+type Spec struct {
+	Engine          *engine.Engine
+	ID              string
+	Quiet           bool
+	ContinueOnError bool
+	Printers        printer.Printers
+	Stdout          io.Writer
+	Stderr          io.Writer
+	Stdin           io.Reader
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental rerun" }
+
+// Exec executes the experimental rerun command.
+func (s *Spec) Exec(_ context.Context) error {
+	logger := log.With().
+		Str("action", "commands/experimental/rerun").
+		Str("id", s.ID).
+		Logger()
+
+	logger.Debug().Msgf("executing %s", s.Name())
+
+	cfg := s.Engine.Config()
+	rec, err := record.Load(cfg.HostDir(), s.ID)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]engine.StackRun, 0, len(rec.Stacks))
+	for _, recStack := range rec.Stacks {
+		st, found, err := config.TryLoadStack(cfg, project.NewPath(recStack.Dir))
+		if err != nil {
+			return errors.E(err, "loading stack %s", recStack.Dir)
+		}
+		if !found {
+			return errors.E("stack %s of run %s not found", recStack.Dir, rec.ID)
+		}
+		runs = append(runs, engine.StackRun{
+			SyncTaskIndex: -1,
+			Stack:         st,
+			Tasks: []engine.StackRunTask{
+				{Cmd: rec.Command},
+			},
+		})
+	}
+
+	current, err := runcmd.NewRecord(s.Engine, runs, rec.Command, rec.Reverse)
+	if err != nil {
+		return errors.E(err, "fingerprinting run")
+	}
+	for _, warning := range Compare(rec, current) {
+		s.Printers.Stderr.Warn(warning)
+	}
+
+	// the runs are in the recorded order, which already accounts for
+	// --reverse, and they are replayed in this order even if the order of
+	// the stacks changed since then.
+	err = s.Engine.RunAll(runs, engine.RunAllOptions{
+		Quiet:           s.Quiet,
+		Unordered:       true,
+		ContinueOnError: s.ContinueOnError,
+		Stdout:          s.Stdout,
+		Stderr:          s.Stderr,
+		Stdin:           s.Stdin,
+	})
+	if err != nil {
+		return errors.D("%s", "one or more commands failed").WithError(err)
+	}
+	return nil
+}
+
+// Compare returns a description of each difference between the environment
+// of the recorded run and the current one.
+func Compare(recorded, current record.Record) []string {
+	var diffs []string
+	if recorded.TerramateVersion != current.TerramateVersion {
+		diffs = append(diffs, fmt.Sprintf("terramate version changed from %s to %s",
+			recorded.TerramateVersion, current.TerramateVersion))
+	}
+	if recorded.GitSHA != current.GitSHA {
+		diffs = append(diffs, fmt.Sprintf("git commit changed from %q to %q",
+			recorded.GitSHA, current.GitSHA))
+	}
+	for _, tool := range slices.Sorted(maps.Keys(recorded.Tools)) {
+		if recorded.Tools[tool] != current.Tools[tool] {
+			diffs = append(diffs, fmt.Sprintf("%s version changed from %q to %q",
+				tool, recorded.Tools[tool], current.Tools[tool]))
+		}
+	}
+
+	recordedOrder := make([]string, len(recorded.Stacks))
+	for i, st := range recorded.Stacks {
+		recordedOrder[i] = st.Dir
+	}
+	currentOrder := make([]string, len(current.Stacks))
+	for i, st := range current.Stacks {
+		currentOrder[i] = st.Dir
+	}
+	if !slices.Equal(recordedOrder, currentOrder) {
+		diffs = append(diffs, fmt.Sprintf("run order changed from %v to %v, replaying the recorded order",
+			recordedOrder, currentOrder))
+	}
+
+	currentEnv := map[string]map[string]record.EnvVar{}
+	for _, st := range current.Stacks {
+		currentEnv[st.Dir] = st.Env
+	}
+	for _, st := range recorded.Stacks {
+		if !maps.Equal(st.Env, currentEnv[st.Dir]) {
+			var vars []string
+			for _, change := range diff.Values(envValue(st.Env), envValue(currentEnv[st.Dir])) {
+				vars = append(vars, fmt.Sprintf("%s %s", change.Kind, diff.PathString(change.Path)))
+			}
+			diffs = append(diffs, fmt.Sprintf("environment of stack %s changed: %s",
+				st.Dir, strings.Join(vars, ", ")))
+		}
+	}
+	return diffs
+}
+
+func envValue(env map[string]record.EnvVar) cty.Value {
+	vals := make(map[string]cty.Value, len(env))
+	for name, v := range env {
+		vals[name] = cty.ObjectVal(map[string]cty.Value{
+			"origin": cty.StringVal(v.Origin),
+			"value":  cty.StringVal(v.Hash),
+		})
+	}
+	return cty.ObjectVal(vals)
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package rerun // import \"github.com/terramate-io/terramate/commands/experimental/rerun\""
+  description = "package rerun // import \"github.com/terramate-io/terramate/commands/experimental/rerun\"\n\nPackage rerun provides the experimental rerun command.\n\nfunc Compare(recorded, current record.Record) []string\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "rerun"]
+  id          = "32e63db7-b13b-4244-b1e4-c6433f76ce7b"
+}
@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "rewritemetadata" {
+  content = <<-EOF
+package rewritemetadata // import "github.com/terramate-io/terramate/commands/experimental/rewritemetadata"
+
+Package rewritemetadata provides the experimental rewrite-metadata command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-rewritemetadata.ignore"
+}
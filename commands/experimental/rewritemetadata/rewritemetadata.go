@@ -0,0 +1,75 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package rewritemetadata provides the experimental rewrite-metadata command.
+package rewritemetadata
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/upgrade"
+	"github.com/terramate-io/terramate/printer"
+)
+
+// Spec is the command specification for the experimental rewrite-metadata command.
+type Spec struct {
+	WorkingDir string
+	DryRun     bool
+	Printers   printer.Printers
+
+	// ConfigFileSuffixes are the suffixes set in
+	// terramate.config.config_file_suffixes.
+	ConfigFileSuffixes []string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental rewrite-metadata" }
+
+// Exec executes the experimental rewrite-metadata command.
+// It rewrites the references to the deprecated metadata of the old terramate
+// namespace (eg.: terramate.path) to their replacements in the terramate.stack
+// namespace, preserving the rest of the files.
+func (s *Spec) Exec(_ context.Context) error {
+	logger := log.With().
+		Str("action", "commands/experimental/rewritemetadata").
+		Str("workingDir", s.WorkingDir).
+		Bool("dry-run", s.DryRun).
+		Logger()
+
+	logger.Debug().Msgf("executing %s", s.Name())
+
+	results, err := upgrade.MetadataTree(s.WorkingDir, s.ConfigFileSuffixes...)
+	if err != nil {
+		return errors.E(err, "rewriting metadata of directory %s", s.WorkingDir)
+	}
+
+	for _, res := range results {
+		path := strings.TrimPrefix(res.Path(), s.WorkingDir+string(filepath.Separator))
+		if s.DryRun {
+			s.Printers.Stdout.Println(res.Diff())
+			continue
+		}
+		s.Printers.Stdout.Println(path)
+		for _, change := range res.Changes() {
+			s.Printers.Stdout.Println("\t" + change)
+		}
+	}
+
+	if s.DryRun {
+		return nil
+	}
+
+	errs := errors.L()
+	for _, res := range results {
+		errs.Append(res.Save())
+	}
+
+	if err := errs.AsError(); err != nil {
+		return errors.E(err, "saving rewritten files")
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package rewritemetadata // import \"github.com/terramate-io/terramate/commands/experimental/rewritemetadata\""
+  description = "package rewritemetadata // import \"github.com/terramate-io/terramate/commands/experimental/rewritemetadata\"\n\nPackage rewritemetadata provides the experimental rewrite-metadata command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "rewritemetadata"]
+  id          = "0db07567-bba0-43a7-b58c-bc3c09ddead2"
+}
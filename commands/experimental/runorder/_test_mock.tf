@@ -0,0 +1,15 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "runorder" {
+  content = <<-EOF
+package runorder // import "github.com/terramate-io/terramate/commands/experimental/runorder"
+
+Package runorder provides the run-order command.
+
+const FormatText = "text" ...
+type Level struct{ ... }
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-runorder.ignore"
+}
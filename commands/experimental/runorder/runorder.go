@@ -0,0 +1,137 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package runorder provides the run-order command.
+package runorder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/run"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Spec is the command specification for the run-order command.
+type Spec struct {
+	Engine    *engine.Engine
+	GitFilter engine.GitFilter
+	Tags      []string
+	NoTags    []string
+	Levels    bool
+	Format    string
+	Printers  printer.Printers
+}
+
+// Level is a group of stacks that can be executed in parallel.
+type Level struct {
+	Level  int      `json:"level"`
+	Stacks []string `json:"stacks"`
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental run-order" }
+
+// Exec executes the run-order command.
+func (s *Spec) Exec(_ context.Context) error {
+	if s.Format == "" {
+		s.Format = FormatText
+	}
+	if s.Format != FormatText && s.Format != FormatJSON {
+		return errors.E("--format expects the values %q or %q", FormatText, FormatJSON)
+	}
+
+	tags, err := engine.ParseFilterTags(s.Tags, s.NoTags)
+	if err != nil {
+		return err
+	}
+
+	stacks, err := s.Engine.ComputeSelectedStacks(s.GitFilter, tags, engine.OutputsSharingOptions{}, "", resources.NoStatusFilters())
+	if err != nil {
+		return err
+	}
+
+	var groups []config.List[*config.SortableStack]
+	if s.Levels {
+		var reason string
+		groups, reason, err = run.Levels(s.Engine.Config(), stacks,
+			func(s *config.SortableStack) *config.Stack { return s.Stack })
+		if err != nil {
+			return errors.E(err, "Invalid stack configuration: "+reason)
+		}
+	} else {
+		reason, err := run.Sort(s.Engine.Config(), stacks,
+			func(s *config.SortableStack) *config.Stack { return s.Stack })
+		if err != nil {
+			return errors.E(err, "Invalid stack configuration: "+reason)
+		}
+		groups = []config.List[*config.SortableStack]{stacks}
+	}
+
+	levels := make([]Level, len(groups))
+	for i, group := range groups {
+		levels[i] = Level{
+			Level:  i,
+			Stacks: make([]string, 0, len(group)),
+		}
+		for _, st := range group {
+			levels[i].Stacks = append(levels[i].Stacks, st.Dir().String())
+		}
+	}
+
+	if s.Format == FormatJSON {
+		return s.printJSON(levels)
+	}
+	return s.printText(levels)
+}
+
+func (s *Spec) printJSON(levels []Level) error {
+	var (
+		data []byte
+		err  error
+	)
+	if s.Levels {
+		data, err = json.MarshalIndent(struct {
+			Levels []Level `json:"levels"`
+		}{Levels: levels}, "", "  ")
+	} else {
+		data, err = json.MarshalIndent(struct {
+			Stacks []string `json:"stacks"`
+		}{Stacks: levels[0].Stacks}, "", "  ")
+	}
+	if err != nil {
+		return errors.E(err, "encoding run order as JSON")
+	}
+	s.Printers.Stdout.Println(string(data))
+	return nil
+}
+
+func (s *Spec) printText(levels []Level) error {
+	for _, level := range levels {
+		if s.Levels {
+			s.Printers.Stdout.Println(fmt.Sprintf("Level %d:", level.Level))
+		}
+		for _, dir := range level.Stacks {
+			friendlyDir, ok := s.Engine.FriendlyFmtDir(dir)
+			if !ok {
+				friendlyDir = dir
+			}
+			if s.Levels {
+				friendlyDir = "\t" + friendlyDir
+			}
+			s.Printers.Stdout.Println(friendlyDir)
+		}
+	}
+	return nil
+}
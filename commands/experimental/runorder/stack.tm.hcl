@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package runorder // import \"github.com/terramate-io/terramate/commands/experimental/runorder\""
+  description = "package runorder // import \"github.com/terramate-io/terramate/commands/experimental/runorder\"\n\nPackage runorder provides the run-order command.\n\nconst FormatText = \"text\" ...\ntype Level struct{ ... }\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "runorder"]
+  id          = "c068288e-929e-4fb7-80e5-f7cae5280110"
+}
@@ -0,0 +1,24 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "serve" {
+  content = <<-EOF
+package serve // import "github.com/terramate-io/terramate/commands/experimental/serve"
+
+Package serve provides the experimental serve command.
+
+const MethodStacksList = "stacks.list" ...
+type ChangedParams struct{ ... }
+type Error struct{ ... }
+type GlobalsParams struct{ ... }
+type GlobalsResult struct{ ... }
+type Request struct{ ... }
+type Response struct{ ... }
+type Server struct{ ... }
+    func NewServer(e *engine.Engine) *Server
+type Spec struct{ ... }
+type Stack struct{ ... }
+type StacksResult struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-serve.ignore"
+}
@@ -0,0 +1,420 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package serve provides the experimental serve command.
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Methods supported by the server.
+const (
+	MethodStacksList     = "stacks.list"
+	MethodStacksChanged  = "stacks.changed"
+	MethodStacksRunOrder = "stacks.run_order"
+	MethodStackGlobals   = "stack.globals"
+	MethodProjectReload  = "project.reload"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Spec is the command specification for the experimental serve command.
+type Spec struct {
+	Engine   *engine.Engine
+	Printers printer.Printers
+
+	// Address is the TCP address the server listens on.
+	Address string
+}
+
+// Server answers the JSON-RPC requests with the project kept loaded in the
+// engine. The requests are serialized since the engine is not safe for
+// concurrent use.
+//
+// The project is reloaded before answering a request if any of its files or
+// the git HEAD changed since the last request.
+type Server struct {
+	mu     sync.Mutex
+	engine *engine.Engine
+	state  projectState
+}
+
+// projectState identifies the state of the project files and of the git HEAD.
+type projectState struct {
+	head    string
+	modTime int64
+	entries int
+}
+
+// Request is a JSON-RPC 2.0 request.
+type Request struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC 2.0 response.
+type Response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Stack is a stack in the results.
+type Stack struct {
+	Dir    string   `json:"dir"`
+	ID     string   `json:"id,omitempty"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags,omitempty"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+// StacksResult is the result of the methods returning stacks.
+type StacksResult struct {
+	Stacks []Stack `json:"stacks"`
+}
+
+// ChangedParams are the parameters of the stacks.changed and
+// stacks.run_order methods.
+type ChangedParams struct {
+	// Changed selects only the changed stacks. It's always true for
+	// stacks.changed.
+	Changed bool `json:"changed"`
+
+	// GitChangeBase is the git revision the changes are compared to.
+	GitChangeBase string `json:"git_change_base"`
+}
+
+// GlobalsParams are the parameters of the stack.globals method.
+type GlobalsParams struct {
+	// Stack is the absolute project path of the stack.
+	Stack string `json:"stack"`
+}
+
+// GlobalsResult is the result of the stack.globals method.
+type GlobalsResult struct {
+	Stack   string          `json:"stack"`
+	Globals json.RawMessage `json:"globals"`
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental serve" }
+
+// Exec executes the experimental serve command.
+// It serves the requests until the process is interrupted.
+func (s *Spec) Exec(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", s.Address)
+	if err != nil {
+		return errors.E(err, "listening on %s", s.Address)
+	}
+
+	srv := &http.Server{
+		Handler:           NewServer(s.Engine),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
+
+	s.Printers.Stdout.Println(fmt.Sprintf("Listening on http://%s", listener.Addr()))
+
+	select {
+	case err := <-served:
+		return errors.E(err, "serving requests")
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return errors.E(err, "shutting down the server")
+	}
+	s.Printers.Stdout.Println("Server stopped")
+	return nil
+}
+
+// NewServer creates a server answering the requests with the given engine.
+func NewServer(e *engine.Engine) *Server {
+	srv := &Server{engine: e}
+	srv.state, _ = srv.currentState()
+	return srv
+}
+
+// ServeHTTP answers the JSON-RPC requests posted to any path.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		http.Error(w, "only application/json requests are supported", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var (
+		req  Request
+		resp Response
+	)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp = errorResponse(nil, codeParseError, err)
+	} else {
+		resp = srv.Handle(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Handle answers a single JSON-RPC request.
+func (srv *Server) Handle(req Request) Response {
+	if req.Version != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, errors.E("invalid JSON-RPC 2.0 request"))
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if err := srv.reload(req.Method == MethodProjectReload); err != nil {
+		return errorResponse(req.ID, codeServerError, errors.E(err, "reloading the project"))
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch req.Method {
+	case MethodStacksList:
+		result, err = srv.listStacks(engine.GitFilter{})
+	case MethodStacksChanged:
+		var params ChangedParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, err)
+		}
+		result, err = srv.listStacks(engine.GitFilter{
+			IsChanged:  true,
+			ChangeBase: params.GitChangeBase,
+		})
+	case MethodStacksRunOrder:
+		var params ChangedParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, err)
+		}
+		result, err = srv.runOrder(engine.GitFilter{
+			IsChanged:  params.Changed,
+			ChangeBase: params.GitChangeBase,
+		})
+	case MethodStackGlobals:
+		var params GlobalsParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, err)
+		}
+		if !path.IsAbs(params.Stack) {
+			return errorResponse(req.ID, codeInvalidParams,
+				errors.E("stack must be an absolute project path, got %q", params.Stack))
+		}
+		result, err = srv.stackGlobals(project.NewPath(params.Stack))
+	case MethodProjectReload:
+		result, err = srv.listStacks(engine.GitFilter{})
+	default:
+		return errorResponse(req.ID, codeMethodNotFound, errors.E("method %q not found", req.Method))
+	}
+	if err != nil {
+		return errorResponse(req.ID, codeServerError, err)
+	}
+	return Response{
+		Version: "2.0",
+		ID:      req.ID,
+		Result:  result,
+	}
+}
+
+// reload reloads the project if forced or if its files or the git HEAD
+// changed since it was loaded.
+func (srv *Server) reload(force bool) error {
+	state, err := srv.currentState()
+	if err != nil {
+		return err
+	}
+	if !force && state == srv.state {
+		return nil
+	}
+	if err := srv.engine.ReloadConfig(); err != nil {
+		return err
+	}
+	srv.state = state
+	return nil
+}
+
+// currentState returns the latest modification time and the number of the
+// files and directories of the project, out of the .git directory, and the
+// git HEAD commit.
+func (srv *Server) currentState() (projectState, error) {
+	var state projectState
+	prj := srv.engine.Project()
+	if prj.IsRepo() {
+		// WHY: HEAD doesn't exist in repositories without commits.
+		state.head, _ = prj.Git.Wrapper.RevParse("HEAD")
+	}
+	err := filepath.WalkDir(srv.engine.Config().HostDir(), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// WHY: the file was removed while walking the project, which is
+			// detected by the modification time of its directory.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		state.entries++
+		if modTime := info.ModTime().UnixNano(); modTime > state.modTime {
+			state.modTime = modTime
+		}
+		return nil
+	})
+	if err != nil {
+		return projectState{}, errors.E(err, "checking the project files")
+	}
+	return state, nil
+}
+
+func (srv *Server) listStacks(gitfilter engine.GitFilter) (StacksResult, error) {
+	report, err := srv.engine.ListStacks(gitfilter, "", resources.NoStatusFilters(), false)
+	if err != nil {
+		return StacksResult{}, err
+	}
+	entries := srv.engine.FilterStacks(report.Stacks, filter.TagClause{})
+	result := StacksResult{Stacks: make([]Stack, 0, len(entries))}
+	for _, entry := range entries {
+		result.Stacks = append(result.Stacks, newStack(entry))
+	}
+	return result, nil
+}
+
+func (srv *Server) runOrder(gitfilter engine.GitFilter) (StacksResult, error) {
+	stacks, err := srv.engine.ComputeSelectedStacks(gitfilter, filter.TagClause{}, engine.OutputsSharingOptions{}, "", resources.NoStatusFilters())
+	if err != nil {
+		return StacksResult{}, err
+	}
+	reason, err := run.Sort(srv.engine.Config(), stacks,
+		func(s *config.SortableStack) *config.Stack { return s.Stack })
+	if err != nil {
+		return StacksResult{}, errors.E(err, "Invalid stack configuration: "+reason)
+	}
+	result := StacksResult{Stacks: make([]Stack, 0, len(stacks))}
+	for _, st := range stacks {
+		result.Stacks = append(result.Stacks, newStack(stack.Entry{Stack: st.Stack}))
+	}
+	return result, nil
+}
+
+func (srv *Server) stackGlobals(dir project.Path) (GlobalsResult, error) {
+	cfg := srv.engine.Config()
+	st, err := config.LoadStack(cfg, dir)
+	if err != nil {
+		return GlobalsResult{}, err
+	}
+	report := globals.ForStack(cfg, st)
+	if err := report.AsError(); err != nil {
+		return GlobalsResult{}, errors.E(err, "evaluating globals of stack %s", dir)
+	}
+	val := cty.ObjectVal(report.Globals.AsMaskedValueMap())
+	data, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return GlobalsResult{}, errors.E(err, "encoding globals of stack %s", dir)
+	}
+	return GlobalsResult{
+		Stack:   dir.String(),
+		Globals: data,
+	}, nil
+}
+
+func newStack(entry stack.Entry) Stack {
+	return Stack{
+		Dir:    entry.Stack.Dir.String(),
+		ID:     entry.Stack.ID,
+		Name:   entry.Stack.Name,
+		Tags:   entry.Stack.Tags,
+		Reason: entry.Reason,
+	}
+}
+
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return errors.E(err, "invalid params")
+	}
+	return nil
+}
+
+func errorResponse(id json.RawMessage, code int, err error) Response {
+	return Response{
+		Version: "2.0",
+		ID:      id,
+		Error: &Error{
+			Code:    code,
+			Message: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package serve // import \"github.com/terramate-io/terramate/commands/experimental/serve\""
+  description = "package serve // import \"github.com/terramate-io/terramate/commands/experimental/serve\"\n\nPackage serve provides the experimental serve command.\n\nconst MethodStacksList = \"stacks.list\" ...\ntype ChangedParams struct{ ... }\ntype Error struct{ ... }\ntype GlobalsParams struct{ ... }\ntype GlobalsResult struct{ ... }\ntype Request struct{ ... }\ntype Response struct{ ... }\ntype Server struct{ ... }\n    func NewServer(e *engine.Engine) *Server\ntype Spec struct{ ... }\ntype Stack struct{ ... }\ntype StacksResult struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "serve"]
+  id          = "33f4ea83-41b8-4cb0-bf5a-95f6a304c5fd"
+}
@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "unlock" {
+  content = <<-EOF
+package unlock // import "github.com/terramate-io/terramate/commands/experimental/unlock"
+
+Package unlock provides the experimental unlock command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-unlock.ignore"
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package unlock // import \"github.com/terramate-io/terramate/commands/experimental/unlock\""
+  description = "package unlock // import \"github.com/terramate-io/terramate/commands/experimental/unlock\"\n\nPackage unlock provides the experimental unlock command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "unlock"]
+  id          = "e5f627d2-9599-4289-aa6b-b0bfbfaf5f06"
+}
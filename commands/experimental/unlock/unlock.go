@@ -0,0 +1,63 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package unlock provides the experimental unlock command.
+package unlock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/lock"
+)
+
+// Spec is the command specification for the experimental unlock command.
+type Spec struct {
+	WorkingDir string
+	Engine     *engine.Engine
+	Printers   printer.Printers
+
+	// Stack is the path of the stack to unlock.
+	Stack string
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string { return "experimental unlock" }
+
+// Exec executes the experimental unlock command.
+// It forcibly releases the lock of the stack, eg.: when left behind by an
+// aborted pipeline.
+func (s *Spec) Exec(_ context.Context) error {
+	root := s.Engine.Config()
+	cfg := root.Tree().Node
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil ||
+		cfg.Terramate.Config.Run == nil || cfg.Terramate.Config.Run.Lock == nil {
+		return errors.E("stack locking is not enabled, see terramate.config.run.lock")
+	}
+
+	locks, err := lock.New(root.HostDir(), cfg.Terramate.Config.Run.Lock)
+	if err != nil {
+		return err
+	}
+
+	dir := s.Stack
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(s.WorkingDir, dir)
+	}
+	stack := project.PrjAbsPath(root.HostDir(), dir)
+	if _, found := root.Lookup(stack); !found {
+		return errors.E("stack %s not found", stack)
+	}
+
+	info, err := locks.ForceRelease(stack)
+	if err != nil {
+		return err
+	}
+	s.Printers.Stdout.Println(fmt.Sprintf("Released lock of stack %s: %s", stack, info))
+	return nil
+}
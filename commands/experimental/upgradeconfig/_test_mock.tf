@@ -0,0 +1,13 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "upgradeconfig" {
+  content = <<-EOF
+package upgradeconfig // import "github.com/terramate-io/terramate/commands/experimental/upgradeconfig"
+
+Package upgradeconfig provides the experimental upgrade-config command.
+
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-upgradeconfig.ignore"
+}
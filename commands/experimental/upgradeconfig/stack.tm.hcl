@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package upgradeconfig // import \"github.com/terramate-io/terramate/commands/experimental/upgradeconfig\""
+  description = "package upgradeconfig // import \"github.com/terramate-io/terramate/commands/experimental/upgradeconfig\"\n\nPackage upgradeconfig provides the experimental upgrade-config command.\n\ntype Spec struct{ ... }"
+  tags        = ["commands", "experimental", "golang", "upgradeconfig"]
+  id          = "e37214ac-2b2e-41a8-9f55-95b5b572b228"
+}
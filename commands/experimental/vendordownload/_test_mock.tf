@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "vendordownload" {
-  content = <<-EOT
+  content = <<-EOF
 package vendordownload // import "github.com/terramate-io/terramate/commands/experimental/vendordownload"
 
 Package vendordownload provides the vendor download command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-vendordownload.ignore"
 }
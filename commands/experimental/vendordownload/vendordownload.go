@@ -16,6 +16,7 @@ import (
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/tf"
+	"github.com/terramate-io/terramate/ui/tui/progress"
 )
 
 // Spec represents the vendor download specification.
@@ -27,6 +28,9 @@ type Spec struct {
 	Dir       string
 	Source    string
 	Reference string
+
+	// Progress, if set, receives the progress events of the vendoring.
+	Progress *progress.Reporter
 }
 
 // Name returns the name of the vendor download command.
@@ -83,6 +87,12 @@ func (s *Spec) Exec(_ context.Context) error {
 
 	logger.Debug().Msg("vendor events handled, creating final report")
 
+	s.Progress.Send(progress.Event{
+		Phase:   "vendor",
+		Percent: 100,
+		Message: fmt.Sprintf("finished: %d vendored, %d ignored", len(report.Vendored), len(report.Ignored)),
+	})
+
 	if report.Error != nil {
 		if errs, ok := report.Error.(*errors.List); ok {
 			for _, err := range errs.Errors() {
@@ -108,6 +118,11 @@ func (s *Spec) handleVendorProgressEvents(eventsStream download.ProgressEventStr
 				Str("module", event.Module.Raw).
 				Stringer("vendorDir", event.TargetDir).
 				Msg(event.Message)
+
+			s.Progress.Send(progress.Event{
+				Phase:   "vendor",
+				Message: fmt.Sprintf("%s %s at %s", event.Message, event.Module.Raw, event.TargetDir),
+			})
 		}
 		close(eventsHandled)
 	}()
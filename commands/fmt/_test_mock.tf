@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "fmt" {
-  content = <<-EOT
+  content = <<-EOF
 package fmt // import "github.com/terramate-io/terramate/commands/fmt"
 
 Package fmt provides the fmt command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-fmt.ignore"
 }
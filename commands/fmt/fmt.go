@@ -26,6 +26,10 @@ type Spec struct {
 	DetailedExitCode bool
 	Files            []string
 	Printers         printer.Printers
+
+	// ConfigFileSuffixes are the suffixes set in
+	// terramate.config.config_file_suffixes.
+	ConfigFileSuffixes []string
 }
 
 // Name returns the name of the command.
@@ -51,7 +55,7 @@ func (s *Spec) Exec(_ context.Context) error {
 	switch len(s.Files) {
 	case 0:
 		var err error
-		results, err = fmt.FormatTree(s.WorkingDir)
+		results, err = fmt.FormatTree(s.WorkingDir, s.ConfigFileSuffixes...)
 		if err != nil {
 			return errors.E(err, "formatting directory %s", s.WorkingDir)
 		}
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "generate" {
-  content = <<-EOT
+  content = <<-EOF
 package generate // import "github.com/terramate-io/terramate/commands/generate"
 
 Package generate provides the generate command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-generate.ignore"
 }
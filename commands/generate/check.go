@@ -0,0 +1,49 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"strings"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/exit"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/project"
+)
+
+// check lists the outdated generated files, without changing them, and with
+// Why the changes of their content. The time and randomness functions must be
+// pinned, otherwise the check fails, as the generated code would never be
+// reproducible.
+func (s *Spec) check(vendorDir project.Path) error {
+	cfg := s.Engine.Config()
+	cfg.SetStrictDeterminism(true)
+	defer cfg.SetStrictDeterminism(false)
+
+	target, ok := cfg.Lookup(project.PrjAbsPath(cfg.HostDir(), s.WorkingDir))
+	if !ok {
+		return errors.E("directory %s not found in the project", s.WorkingDir)
+	}
+	outdated, err := generate.DetectOutdated(cfg, target, s.Parallel, vendorDir)
+	if err != nil {
+		return errors.E(err, "checking generated code")
+	}
+	var why map[string][]string
+	if s.Why {
+		why, err = generate.WhyOutdated(cfg, target, vendorDir, outdated)
+		if err != nil {
+			return errors.E(err, "explaining outdated generated code")
+		}
+	}
+	for _, file := range outdated {
+		s.Printers.Stdout.Println(file)
+		for _, line := range why[file] {
+			s.Printers.Stdout.Println("\t" + strings.ReplaceAll(line, "\n", "\n\t"))
+		}
+	}
+	if len(outdated) > 0 {
+		return errors.E(exit.Failed)
+	}
+	return nil
+}
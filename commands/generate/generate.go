@@ -6,25 +6,27 @@ package generate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"path"
 	"path/filepath"
 
 	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/event"
 	"github.com/terramate-io/terramate/exit"
 	"github.com/terramate-io/terramate/generate"
-	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/modvendor/download"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/ui/tui/picker"
+	"github.com/terramate-io/terramate/ui/tui/progress"
 )
 
-const defaultVendorDir = "/modules"
-
 // Spec is the command specification for the generate command.
 type Spec struct {
 	Engine           *engine.Engine
@@ -34,6 +36,47 @@ type Spec struct {
 	Printers         printer.Printers
 	MinimalReport    bool
 	PrintReport      bool
+	SlowestBlocks    int
+
+	// RecordInputs saves a snapshot of the inputs of the generated files.
+	RecordInputs bool
+	// VerifyInputs reports the inputs which drifted since the last recorded
+	// generation instead of generating code.
+	VerifyInputs bool
+
+	// Check lists the outdated generated files instead of generating code.
+	Check bool
+	// Why lists the outdated generated files with the changes of their
+	// content instead of generating code. It implies Check.
+	Why bool
+
+	// JSON prints the report of the generation as JSON. The vendor report
+	// is printed to stderr.
+	JSON bool
+
+	// Progress, if set, receives the progress events of the generation.
+	Progress *progress.Reporter
+
+	// GitFilter, if it selects the changed stacks, exposes them in the
+	// terramate.changed namespace.
+	GitFilter engine.GitFilter
+
+	// Stacks restricts the generation to the stacks in the given
+	// directories.
+	Stacks []string
+
+	// Pick shows a picker to select the stacks to generate interactively.
+	// The Args are the CLI arguments, used to print the equivalent command of
+	// the picked stacks.
+	Pick bool
+	Args []string
+}
+
+// phaseDoneMessages are the messages of the progress events sent when a stack
+// finishes a phase of the code generation.
+var phaseDoneMessages = map[string]string{
+	generate.PhaseEvaluate: "evaluated",
+	generate.PhaseSave:     "saved",
 }
 
 // Name returns the name of the command.
@@ -45,19 +88,94 @@ func (s *Spec) Exec(_ context.Context) error {
 		Str("action", "commands/generate").
 		Logger()
 
+	if s.Why && s.DetailedExitCode {
+		return errors.E("generate --why conflicts with --detailed-exit-code")
+	}
+	if s.Check && s.DetailedExitCode {
+		return errors.E("generate --check conflicts with --detailed-exit-code")
+	}
+	if s.RecordInputs && s.VerifyInputs {
+		return errors.E("generate --record-inputs conflicts with --verify-inputs")
+	}
+	if (s.Pick || len(s.Stacks) > 0) && (s.Check || s.Why || s.RecordInputs || s.VerifyInputs) {
+		return errors.E("generate --pick and --stack conflict with --check, --why, --record-inputs and --verify-inputs")
+	}
+	if s.JSON && (s.Check || s.Why || s.VerifyInputs) {
+		return errors.E("generate --json conflicts with --check, --why and --verify-inputs")
+	}
+
+	if s.GitFilter.IsChanged {
+		if err := s.setChangedStacks(); err != nil {
+			return err
+		}
+	}
+
+	if s.VerifyInputs {
+		vdir, err := s.vendorDir()
+		if err != nil {
+			return err
+		}
+		return s.verifyInputs(vdir)
+	}
+
+	if s.Check || s.Why {
+		vdir, err := s.vendorDir()
+		if err != nil {
+			return err
+		}
+		return s.check(vdir)
+	}
+
+	var genopts []generate.Option
+	if s.Pick || len(s.Stacks) > 0 {
+		stacks, err := s.selectStacks()
+		if err != nil {
+			return err
+		}
+		genopts = append(genopts, generate.WithStacks(stacks))
+	}
+	if s.Engine.Project().IsRepo() {
+		tracked, err := s.trackedFiles()
+		if err != nil {
+			return err
+		}
+		genopts = append(genopts, generate.WithTrackedFiles(func(path string) bool {
+			_, ok := tracked[path]
+			return ok
+		}))
+	}
+
+	if s.Progress != nil {
+		genopts = append(genopts, generate.WithProgress(func(phase string, stack project.Path, done, total int) {
+			s.Progress.Send(progress.Event{
+				Phase:   phase,
+				Stack:   stack.String(),
+				Percent: progress.Percent(done, total),
+				Message: phaseDoneMessages[phase],
+			})
+		}))
+	}
+
 	vendorProgressEvents := download.NewEventStream()
 
 	progressHandlerDone := make(chan struct{})
 
 	go func() {
 		for event := range vendorProgressEvents {
-			s.Printers.Stdout.Println(fmt.Sprintf("vendor: %s %s at %s",
-				event.Message, event.Module.Raw, event.TargetDir))
+			if !s.JSON {
+				s.Printers.Stdout.Println(fmt.Sprintf("vendor: %s %s at %s",
+					event.Message, event.Module.Raw, event.TargetDir))
+			}
 
 			logger.Info().
 				Str("module", event.Module.Raw).
 				Stringer("vendorDir", event.TargetDir).
 				Msg(event.Message)
+
+			s.Progress.Send(progress.Event{
+				Phase:   "vendor",
+				Message: fmt.Sprintf("%s %s at %s", event.Message, event.Module.Raw, event.TargetDir),
+			})
 		}
 
 		close(progressHandlerDone)
@@ -81,7 +199,7 @@ func (s *Spec) Exec(_ context.Context) error {
 	if err != nil {
 		return err
 	}
-	report := generate.Do(cfg, cwd, s.Parallel, vdir, vendorRequestEvents)
+	report := generate.Do(cfg, cwd, s.Parallel, vdir, vendorRequestEvents, genopts...)
 
 	logger.Trace().Msg("code generation finished, waiting for vendor requests to be handled")
 
@@ -98,7 +216,19 @@ func (s *Spec) Exec(_ context.Context) error {
 
 	log.Trace().Msg("all handlers stopped, generating final report")
 
-	if s.PrintReport || report.HasFailures() {
+	finished := "finished"
+	if report.HasFailures() {
+		finished = "finished with failures"
+	}
+	s.Progress.Send(progress.Event{Phase: "generate", Percent: 100, Message: finished})
+
+	if s.JSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.E(err, "encoding the generate report")
+		}
+		s.Printers.Stdout.Println(string(data))
+	} else if s.PrintReport || report.HasFailures() {
 		if s.MinimalReport {
 			if minimalReport := report.Minimal(); minimalReport != "" {
 				s.Printers.Stdout.Println(minimalReport)
@@ -108,10 +238,26 @@ func (s *Spec) Exec(_ context.Context) error {
 		}
 	}
 
+	if s.SlowestBlocks > 0 && !s.JSON {
+		if slowest := report.SlowestReport(s.SlowestBlocks); slowest != "" {
+			s.Printers.Stdout.Println(slowest)
+		}
+	}
+
 	vendorReport.RemoveIgnoredByKind(download.ErrAlreadyVendored)
 
 	if !vendorReport.IsEmpty() {
-		s.Printers.Stdout.Println(vendorReport.String())
+		if s.JSON {
+			s.Printers.Stderr.Println(vendorReport.String())
+		} else {
+			s.Printers.Stdout.Println(vendorReport.String())
+		}
+	}
+
+	if s.RecordInputs && !report.HasFailures() {
+		if err := s.recordInputs(vdir); err != nil {
+			return errors.E(err, "recording generation inputs")
+		}
 	}
 
 	if s.DetailedExitCode {
@@ -126,34 +272,69 @@ func (s *Spec) Exec(_ context.Context) error {
 	return nil
 }
 
-func (s *Spec) vendorDir() (project.Path, error) {
-	checkVendorDir := func(dir string) (project.Path, error) {
-		if !path.IsAbs(dir) {
-			return project.Path{}, errors.E("vendorDir %s defined is not an absolute path", dir)
+// selectStacks returns the directories of the stacks selected with the
+// --stack flags and, if enabled, the picker.
+func (s *Spec) selectStacks() (project.Paths, error) {
+	report, err := s.Engine.ListStacks(engine.NoGitFilter(), cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return nil, err
+	}
+	stacks := make(config.List[*config.SortableStack], len(report.Stacks))
+	for i, entry := range report.Stacks {
+		stacks[i] = entry.Stack.Sortable()
+	}
+	stacks, err = s.Engine.SelectStackDirs(stacks, s.Stacks)
+	if err != nil {
+		return nil, err
+	}
+	if s.Pick {
+		stacks, err = picker.PickStacks(s.Engine, s.GitFilter, stacks, os.Stdin, os.Stderr)
+		if err != nil {
+			return nil, err
 		}
-		return project.NewPath(dir), nil
 	}
+	dirs := make(project.Paths, len(stacks))
+	args := make([]string, len(stacks))
+	for i, st := range stacks {
+		dirs[i] = st.Dir()
+		args[i] = st.Dir().String()
+	}
+	if s.Pick {
+		s.Printers.Stderr.Println("Equivalent command: " + picker.Command(s.Args, 0, args))
+	}
+	return dirs, nil
+}
 
+// trackedFiles returns the host paths of the files tracked by git.
+func (s *Spec) trackedFiles() (map[string]struct{}, error) {
 	rootdir := s.Engine.Config().HostDir()
-	dotTerramate := filepath.Join(rootdir, ".terramate")
-	dotTerramateInfo, err := os.Stat(dotTerramate)
+	files, err := s.Engine.Project().Git.Wrapper.ListTrackedFiles()
+	if err != nil {
+		return nil, errors.E(err, "listing files tracked by git")
+	}
+	tracked := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		tracked[filepath.Join(rootdir, filepath.FromSlash(file))] = struct{}{}
+	}
+	return tracked, nil
+}
 
-	if err == nil && dotTerramateInfo.IsDir() {
-		cfg, err := hcl.ParseDir(rootdir, dotTerramate, s.Engine.HCLOptions()...)
-		if err != nil {
-			return project.Path{}, errors.E(err, "parsing vendor dir configuration on .terramate")
-		}
-		if hasVendorDirConfig(cfg) {
-			return checkVendorDir(cfg.Vendor.Dir)
-		}
+// setChangedStacks computes the changed stacks exposed in the
+// terramate.changed namespace.
+func (s *Spec) setChangedStacks() error {
+	report, err := s.Engine.ListStacks(s.GitFilter, cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return errors.E(err, "computing the changed stacks")
 	}
-	hclcfg := s.Engine.Config().Tree().Node
-	if hasVendorDirConfig(&hclcfg) {
-		return checkVendorDir(hclcfg.Vendor.Dir)
+	changed := project.Paths{}
+	for _, entry := range report.Stacks {
+		changed = append(changed, entry.Stack.Dir)
 	}
-	return project.NewPath(defaultVendorDir), nil
+	changed.Sort()
+	s.Engine.Config().SetChangedStacks(changed)
+	return nil
 }
 
-func hasVendorDirConfig(cfg *hcl.Config) bool {
-	return cfg.Vendor != nil && cfg.Vendor.Dir != ""
+func (s *Spec) vendorDir() (project.Path, error) {
+	return s.Engine.VendorDir()
 }
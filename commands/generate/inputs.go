@@ -0,0 +1,124 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/exit"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/generate/inputs"
+	"github.com/terramate-io/terramate/project"
+)
+
+// recordInputs saves a snapshot of the inputs of the files generated in the
+// stacks inside the working directory.
+func (s *Spec) recordInputs(vendorDir project.Path) error {
+	cwd, current, err := s.computeInputs(vendorDir)
+	if err != nil {
+		return err
+	}
+	rootdir := s.Engine.Config().HostDir()
+	state, err := inputs.Load(rootdir)
+	if err != nil && !errors.IsKind(err, inputs.ErrNotFound) {
+		return err
+	}
+	state.Merge(cwd, current)
+	return inputs.Save(rootdir, state)
+}
+
+// verifyInputs reports the inputs which drifted since the last generation
+// recorded with --record-inputs.
+func (s *Spec) verifyInputs(vendorDir project.Path) error {
+	cwd, current, err := s.computeInputs(vendorDir)
+	if err != nil {
+		return err
+	}
+	state, err := inputs.Load(s.Engine.Config().HostDir())
+	if err != nil {
+		if errors.IsKind(err, inputs.ErrNotFound) {
+			return errors.E(err, "run 'terramate generate --record-inputs' first")
+		}
+		return err
+	}
+
+	paths := map[string]struct{}{}
+	for path := range current {
+		paths[path] = struct{}{}
+	}
+	for path := range state.Files {
+		if project.NewPath(path).HasDirPrefix(cwd.String()) {
+			paths[path] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var drifted bool
+	for _, path := range sorted {
+		recorded, wasRecorded := state.Files[path]
+		cur, isGenerated := current[path]
+		var drifts []string
+		switch {
+		case !wasRecorded:
+			drifts = []string{"not recorded"}
+		case !isGenerated:
+			drifts = []string{"no longer generated"}
+		default:
+			drifts = inputs.Drift(recorded, cur)
+		}
+		if len(drifts) == 0 {
+			continue
+		}
+		drifted = true
+		s.Printers.Stdout.Println(fmt.Sprintf("%s:", path))
+		for _, drift := range drifts {
+			s.Printers.Stdout.Println(fmt.Sprintf("\t%s", drift))
+		}
+	}
+	if drifted {
+		return errors.E(exit.Failed)
+	}
+	s.Printers.Stdout.Println("no inputs drifted since last generation")
+	return nil
+}
+
+func (s *Spec) computeInputs(vendorDir project.Path) (project.Path, map[string]inputs.Inputs, error) {
+	cfg := s.Engine.Config()
+	cwd := project.PrjAbsPath(cfg.HostDir(), s.WorkingDir)
+	results, err := generate.Load(cfg, s.Parallel, vendorDir)
+	if err != nil {
+		return project.Path{}, nil, errors.E(err, "loading generated code")
+	}
+	current := map[string]inputs.Inputs{}
+	for _, res := range results {
+		if !res.Dir.HasDirPrefix(cwd.String()) {
+			continue
+		}
+		tree, ok := cfg.Lookup(res.Dir)
+		if !ok || !tree.IsStack() {
+			continue
+		}
+		if res.Err != nil {
+			return project.Path{}, nil, errors.E(res.Err, "loading generated code of %s", res.Dir)
+		}
+		st, err := tree.Stack()
+		if err != nil {
+			return project.Path{}, nil, err
+		}
+		files, err := inputs.Compute(cfg, st, res.Files)
+		if err != nil {
+			return project.Path{}, nil, err
+		}
+		for path, in := range files {
+			current[path] = in
+		}
+	}
+	return cwd, current, nil
+}
@@ -0,0 +1,14 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "initproject" {
+  content = <<-EOF
+package initproject // import "github.com/terramate-io/terramate/commands/initproject"
+
+Package initproject provides the init-project command.
+
+const ErrInitProject errors.Kind = "initializing project"
+type Spec struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-initproject.ignore"
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package initproject provides the init-project command.
+package initproject
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"golang.org/x/term"
+)
+
+// ErrInitProject indicates that the project could not be initialized.
+const ErrInitProject errors.Kind = "initializing project"
+
+// Spec is the command specification for the init-project command.
+type Spec struct {
+	// Dir is the directory where the project is created.
+	Dir string
+
+	// Profile is the cloud provider of the project: aws, gcp or azure.
+	// It's asked interactively if empty.
+	Profile string
+
+	// CI is the CI/CD system of the workflow files: github, gitlab or none.
+	// It's asked interactively if empty, defaulting to github when the input
+	// is not a terminal.
+	CI string
+
+	// Force overwrites existing files.
+	Force bool
+
+	// Version is the Terramate version required by the project.
+	Version string
+
+	Stdin    io.Reader
+	Printers printer.Printers
+}
+
+// Name returns the name of the command.
+func (s *Spec) Name() string {
+	return "init-project"
+}
+
+// Exec executes the init-project command.
+func (s *Spec) Exec(_ context.Context) error {
+	var in *bufio.Reader
+	if s.interactive() {
+		in = bufio.NewReader(s.Stdin)
+	}
+
+	profileName := s.Profile
+	if profileName == "" {
+		if in == nil {
+			return errors.E(ErrInitProject, "--profile is required when the input is not a terminal (one of: %s)",
+				strings.Join(profileNames(), ", "))
+		}
+		var err error
+		profileName, err = s.ask(in, "Cloud provider", profileNames(), "aws")
+		if err != nil {
+			return err
+		}
+	}
+	p, ok := profiles[profileName]
+	if !ok {
+		return errors.E(ErrInitProject, "unknown profile %q (one of: %s)",
+			profileName, strings.Join(profileNames(), ", "))
+	}
+
+	ciName := s.CI
+	if ciName == "" {
+		ciName = "github"
+		if in != nil {
+			var err error
+			ciName, err = s.ask(in, "CI/CD system", ciNames(), "github")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	ci, ok := ciSystems[ciName]
+	if !ok {
+		return errors.E(ErrInitProject, "unknown CI/CD system %q (one of: %s)",
+			ciName, strings.Join(ciNames(), ", "))
+	}
+
+	files, err := layout(s.Version, p, ci)
+	if err != nil {
+		return errors.E(ErrInitProject, err)
+	}
+
+	if !s.Force {
+		var existing []string
+		for _, f := range files {
+			if _, err := os.Lstat(filepath.Join(s.Dir, filepath.FromSlash(f.path))); err == nil {
+				existing = append(existing, f.path)
+			}
+		}
+		if len(existing) > 0 {
+			return errors.E(ErrInitProject,
+				"files already exist, use --force to overwrite them: %s", strings.Join(existing, ", "))
+		}
+	}
+
+	for _, f := range files {
+		abspath := filepath.Join(s.Dir, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(abspath), 0755); err != nil {
+			return errors.E(ErrInitProject, err)
+		}
+		if err := os.WriteFile(abspath, []byte(f.content), 0644); err != nil {
+			return errors.E(ErrInitProject, err)
+		}
+		s.Printers.Stdout.Println(fmt.Sprintf("[+] %s", f.path))
+	}
+
+	s.Printers.Stdout.Println("")
+	s.Printers.Stdout.Println(fmt.Sprintf("Terramate project initialized for %s. Next steps:", p.title))
+	if !isGitRepo(s.Dir) {
+		s.Printers.Stdout.Println("  git init -b main")
+	}
+	s.Printers.Stdout.Println("  terramate generate")
+	s.Printers.Stdout.Println("  terramate list")
+	return nil
+}
+
+func (s *Spec) interactive() bool {
+	f, ok := s.Stdin.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// ask prompts for one of the choices, returning def if the answer is empty.
+func (s *Spec) ask(in *bufio.Reader, question string, choices []string, def string) (string, error) {
+	for {
+		s.Printers.Stderr.Printf("%s (%s) [%s]: ", question, strings.Join(choices, ", "), def)
+		answer, err := in.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if err != nil && (err != io.EOF || answer == "") {
+			return "", errors.E(ErrInitProject, err, "reading the answer")
+		}
+		if answer == "" {
+			return def, nil
+		}
+		for _, c := range choices {
+			if answer == c {
+				return answer, nil
+			}
+		}
+		s.Printers.Stderr.Printf("invalid answer %q\n", answer)
+	}
+}
+
+func isGitRepo(dir string) bool {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+func profileNames() []string {
+	return sortedKeys(profiles)
+}
+
+func ciNames() []string {
+	return sortedKeys(ciSystems)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package initproject
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	hclversion "github.com/hashicorp/go-version"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/fmt"
+)
+
+// profile is the cloud provider specific part of the layout.
+type profile struct {
+	title          string
+	provider       string
+	source         string
+	version        string
+	globals        []attr
+	providerConfig string
+}
+
+type attr struct {
+	Name  string
+	Value string
+}
+
+// ciSystem is the set of workflow files of a CI/CD system, by path.
+type ciSystem map[string]string
+
+type file struct {
+	path    string
+	content string
+}
+
+// environments are the example stacks created in the stacks/ directory.
+var environments = []string{"dev", "prod"}
+
+var profiles = map[string]profile{
+	"aws": {
+		title:    "AWS",
+		provider: "aws",
+		source:   "hashicorp/aws",
+		version:  "~> 5.0",
+		globals: []attr{
+			{"region", `"us-east-1"`},
+		},
+		providerConfig: "region = global.region",
+	},
+	"gcp": {
+		title:    "Google Cloud",
+		provider: "google",
+		source:   "hashicorp/google",
+		version:  "~> 5.0",
+		globals: []attr{
+			{"project", `"my-project"`},
+			{"region", `"us-central1"`},
+		},
+		providerConfig: "project = global.project\nregion = global.region",
+	},
+	"azure": {
+		title:    "Azure",
+		provider: "azurerm",
+		source:   "hashicorp/azurerm",
+		version:  "~> 3.0",
+		globals: []attr{
+			{"location", `"westeurope"`},
+		},
+		providerConfig: "features {}",
+	},
+}
+
+var ciSystems = map[string]ciSystem{
+	"github": {
+		".github/workflows/terramate-preview.yml": githubPreviewWorkflow,
+		".github/workflows/terramate-deploy.yml":  githubDeployWorkflow,
+	},
+	"gitlab": {
+		".gitlab-ci.yml": gitlabPipeline,
+	},
+	"none": {},
+}
+
+// layout returns the files of the project, in the order they are created.
+func layout(tmVersion string, p profile, ci ciSystem) ([]file, error) {
+	semver, err := hclversion.NewSemver(tmVersion)
+	if err != nil {
+		return nil, errors.E(err, "invalid Terramate version")
+	}
+	// a prerelease doesn't match the constraints of its release.
+	requiredVersion := ">= " + semver.Core().String()
+	allowPrereleases := semver.Prerelease() != ""
+	if allowPrereleases {
+		requiredVersion = ">= " + semver.String()
+	}
+
+	data := map[string]any{
+		"RequiredVersion":  requiredVersion,
+		"AllowPrereleases": allowPrereleases,
+		"Provider":         p.provider,
+		"Source":           p.source,
+		"Version":          p.version,
+		"Globals":          p.globals,
+		"ProviderConfig":   p.providerConfig,
+	}
+
+	var files []file
+	addHCL := func(filepath string, tmpl *template.Template, data any) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return errors.E(err, "rendering %s", filepath)
+		}
+		formatted, err := fmt.Format(strings.TrimLeft(buf.String(), "\n"), filepath)
+		if err != nil {
+			return errors.E(err, "formatting %s", filepath)
+		}
+		files = append(files, file{path: filepath, content: formatted})
+		return nil
+	}
+
+	if err := addHCL("terramate.tm.hcl", rootConfigTmpl, data); err != nil {
+		return nil, err
+	}
+	if err := addHCL("globals.tm.hcl", globalsTmpl, data); err != nil {
+		return nil, err
+	}
+	if err := addHCL("stacks/config.tm.hcl", generateTmpl, data); err != nil {
+		return nil, err
+	}
+	for _, env := range environments {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return nil, errors.E(err, "creating stack UUID")
+		}
+		stackData := map[string]any{
+			"Name": env,
+			"ID":   id.String(),
+		}
+		if err := addHCL(path.Join("stacks", env, "stack.tm.hcl"), stackTmpl, stackData); err != nil {
+			return nil, err
+		}
+	}
+
+	files = append(files, file{path: ".gitignore", content: gitignore})
+	for _, name := range sortedKeys(ci) {
+		files = append(files, file{path: name, content: ci[name]})
+	}
+	return files, nil
+}
+
+var rootConfigTmpl = template.Must(template.New("terramate.tm.hcl").Parse(`
+// Configuration of the Terramate project.
+terramate {
+  required_version = "{{.RequiredVersion}}"
+{{- if .AllowPrereleases}}
+  required_version_allow_prereleases = true
+{{- end}}
+
+  config {
+    git {
+      default_branch = "main"
+    }
+  }
+}
+`))
+
+var globalsTmpl = template.Must(template.New("globals.tm.hcl").Parse(`
+// Globals shared by all the stacks of the project.
+// Stacks and directories can override them.
+globals {
+  terraform_version = "~> 1.7"
+  provider_version = "{{.Version}}"
+{{- range .Globals}}
+  {{.Name}} = {{.Value}}
+{{- end}}
+}
+`))
+
+var generateTmpl = template.Must(template.New("config.tm.hcl").Parse(`
+// Code generated in all the stacks of this directory.
+// Run "terramate generate" after changing it.
+
+generate_hcl "_terramate_generated_terraform.tf" {
+  content {
+    terraform {
+      required_version = global.terraform_version
+
+      required_providers {
+        {{.Provider}} = {
+          source = "{{.Source}}"
+          version = global.provider_version
+        }
+      }
+    }
+  }
+}
+
+generate_hcl "_terramate_generated_provider.tf" {
+  content {
+    provider "{{.Provider}}" {
+      {{.ProviderConfig}}
+    }
+  }
+}
+`))
+
+var stackTmpl = template.Must(template.New("stack.tm.hcl").Parse(`
+stack {
+  name = "{{.Name}}"
+  description = "The {{.Name}} environment."
+  tags = ["{{.Name}}"]
+  id = "{{.ID}}"
+}
+
+globals {
+  environment = "{{.Name}}"
+}
+`))
+
+const gitignore = `.terraform/
+*.tfstate
+*.tfstate.*
+.terramate/cache/
+`
+
+const githubPreviewWorkflow = `name: Terramate Preview
+
+on:
+  pull_request:
+
+jobs:
+  preview:
+    runs-on: ubuntu-latest
+
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - uses: terramate-io/terramate-action@v2
+
+      - uses: hashicorp/setup-terraform@v3
+        with:
+          terraform_wrapper: false
+
+      # Configure the credentials of the cloud provider here.
+
+      - name: Check the generated code is up to date
+        run: terramate generate --check
+
+      - name: Plan the changed stacks
+        run: |
+          terramate run --changed -- terraform init
+          terramate run --changed -- terraform plan
+`
+
+const githubDeployWorkflow = `name: Terramate Deploy
+
+on:
+  push:
+    branches:
+      - main
+
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - uses: terramate-io/terramate-action@v2
+
+      - uses: hashicorp/setup-terraform@v3
+        with:
+          terraform_wrapper: false
+
+      # Configure the credentials of the cloud provider here.
+
+      - name: Apply the changed stacks
+        run: |
+          terramate run --changed -- terraform init
+          terramate run --changed -- terraform apply -auto-approve
+`
+
+const gitlabPipeline = `# Install terramate and terraform in the image used by the jobs and
+# configure the credentials of the cloud provider as CI/CD variables.
+
+stages:
+  - preview
+  - deploy
+
+preview:
+  stage: preview
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+  variables:
+    GIT_DEPTH: 0
+  script:
+    - terramate generate --check
+    - terramate run --changed -- terraform init
+    - terramate run --changed -- terraform plan
+
+deploy:
+  stage: deploy
+  rules:
+    - if: $CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH
+  variables:
+    GIT_DEPTH: 0
+  script:
+    - terramate run --changed -- terraform init
+    - terramate run --changed -- terraform apply -auto-approve
+`
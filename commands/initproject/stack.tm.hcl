@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package initproject // import \"github.com/terramate-io/terramate/commands/initproject\""
+  description = "package initproject // import \"github.com/terramate-io/terramate/commands/initproject\"\n\nPackage initproject provides the init-project command.\n\nconst ErrInitProject errors.Kind = \"initializing project\"\ntype Spec struct{ ... }"
+  tags        = ["commands", "golang", "initproject"]
+  id          = "2c017330-9419-41e5-84d3-e410add0f441"
+}
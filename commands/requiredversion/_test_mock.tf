@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "requiredversion" {
-  content = <<-EOT
+  content = <<-EOF
 package requiredversion // import "github.com/terramate-io/terramate/commands/requiredversion"
 
 Package requiredversion provides the required-version command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-requiredversion.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "run" {
-  content = <<-EOT
+  content = <<-EOF
 package run // import "github.com/terramate-io/terramate/commands/run"
 
 Package run provides the run command.
@@ -22,7 +22,7 @@ type CloudRunState struct{ ... }
 type Safeguards struct{ ... }
 type Spec struct{ ... }
 type StatusFilters struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-run.ignore"
 }
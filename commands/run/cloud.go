@@ -32,7 +32,12 @@ func (s *Spec) checkCloudSync() error {
 		feats = append(feats, cloudFeatSyncPreview)
 	}
 
-	err := s.Engine.SetupCloudConfig(feats)
+	setup := s.Engine.SetupCloudSyncConfig
+	if s.SyncPreview {
+		// previews need the responses of the Terramate Cloud.
+		setup = s.Engine.SetupCloudConfig
+	}
+	err := setup(feats)
 	err = s.Engine.HandleCloudCriticalError(err)
 	if err != nil {
 		return err
@@ -0,0 +1,74 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/ui/tui/live"
+	"golang.org/x/term"
+)
+
+// liveRefreshInterval is the refresh interval of the live view.
+const liveRefreshInterval = 200 * time.Millisecond
+
+// showLiveView shows the live view of the runs, updated by the hooks of the
+// given options, instead of the output of the commands. The output of the
+// successful stacks is written to stdout and the output of the failed and
+// canceled ones to stderr when the returned stop function is called.
+func (s *Spec) showLiveView(runs []engine.StackRun, opts *engine.RunAllOptions) (stop func()) {
+	stacks := make([]string, len(runs))
+	for i, run := range runs {
+		stacks[i] = run.Stack.Dir.String()
+	}
+	view := live.New(s.Stdout, stacks)
+	view.OnInterrupt = func() {
+		// the terminal is in raw mode, so ctrl-c doesn't send the signal.
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			_ = p.Signal(os.Interrupt)
+		}
+	}
+
+	// the keyboard input is used by the view.
+	opts.Stdin = nil
+	opts.Quiet = true
+	opts.Outputs = func(stack *config.Stack) (io.Writer, io.Writer) {
+		out := view.Output(stack.Dir.String())
+		return out, out
+	}
+
+	before, after := opts.Hooks.Before, opts.Hooks.After
+	opts.Hooks.Before = func(e *engine.Engine, run engine.StackCloudRun) {
+		before(e, run)
+		view.Running(run.Stack.Dir.String())
+	}
+	opts.Hooks.After = func(e *engine.Engine, run engine.StackCloudRun, res engine.RunResult, err error) {
+		after(e, run, res, err)
+		switch {
+		case err == nil:
+			view.Done(run.Stack.Dir.String(), live.Success)
+		case errors.IsKind(err, engine.ErrRunCanceled):
+			view.Done(run.Stack.Dir.String(), live.Canceled)
+		default:
+			view.Done(run.Stack.Dir.String(), live.Failed)
+		}
+	}
+
+	stopView := view.Show(os.Stdin, liveRefreshInterval)
+	return func() {
+		stopView()
+		view.WriteOutputs(s.Stdout, live.Success)
+		view.WriteOutputs(s.Stderr, live.Failed, live.Canceled)
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -4,7 +4,10 @@
 package run
 
 import (
+	"strings"
+
 	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/generate"
@@ -13,11 +16,9 @@ import (
 )
 
 // CheckOutdatedGeneratedCode checks if the generated code is outdated.
-func CheckOutdatedGeneratedCode(e *engine.Engine, sf Safeguards, wd string) error {
-	logger := log.With().
-		Str("action", "checkOutdatedGeneratedCode()").
-		Logger()
-
+// The stacks are checked in parallel, using at most parallel goroutines or
+// the number of CPUs if parallel is zero.
+func CheckOutdatedGeneratedCode(e *engine.Engine, sf Safeguards, wd string, parallel int) error {
 	if !checkGenCode(e, sf) {
 		return nil
 	}
@@ -33,23 +34,65 @@ func CheckOutdatedGeneratedCode(e *engine.Engine, sf Safeguards, wd string) erro
 	if err != nil {
 		return err
 	}
-	outdatedFiles, err := generate.DetectOutdated(cfg, targetTree, vendorDir)
+	outdatedFiles, err := generate.DetectOutdated(cfg, targetTree, parallel, vendorDir)
 	if err != nil {
 		return errors.E(err, "failed to check outdated code on project")
 	}
 
+	return outdatedCodeError(outdatedFiles)
+}
+
+// CheckSelectedOutdatedGeneratedCode checks if the code generated in the
+// stack context of the stacks selected to run is outdated. It's only checked
+// if enabled by the safeguards and the outdated code of the whole working
+// directory is not already checked by [CheckOutdatedGeneratedCode].
+func CheckSelectedOutdatedGeneratedCode(e *engine.Engine, sf Safeguards, stacks config.List[*config.SortableStack], parallel int) error {
+	if !sf.CheckSelectedGenCode || checkGenCode(e, sf) {
+		return nil
+	}
+
+	cfg := e.Config()
+	trees := make([]*config.Tree, 0, len(stacks))
+	seen := map[project.Path]bool{}
+	for _, st := range stacks {
+		if seen[st.Stack.Dir] {
+			continue
+		}
+		seen[st.Stack.Dir] = true
+		tree, ok := cfg.Lookup(st.Stack.Dir)
+		if !ok {
+			return errors.E("config not found at %s", st.Stack.Dir)
+		}
+		trees = append(trees, tree)
+	}
+
+	vendorDir, err := e.VendorDir()
+	if err != nil {
+		return err
+	}
+	outdatedFiles, err := generate.DetectOutdatedStacks(cfg, trees, parallel, vendorDir)
+	if err != nil {
+		return errors.E(err, "failed to check outdated code of the selected stacks")
+	}
+	return outdatedCodeError(outdatedFiles)
+}
+
+// outdatedCodeError returns the error listing the outdated files, if any.
+func outdatedCodeError(outdatedFiles []string) error {
+	if len(outdatedFiles) == 0 {
+		return nil
+	}
+
 	for _, outdated := range outdatedFiles {
-		logger.Error().
+		log.Error().
 			Str("filename", outdated).
 			Msg("outdated code found")
 	}
 
-	if len(outdatedFiles) > 0 {
-		return errors.E(errors.E("please run: 'terramate generate' to update generated code"),
-			errors.E(ErrOutdatedGenCodeDetected).Error(),
-		)
-	}
-	return nil
+	return errors.E(
+		errors.E("please run: 'terramate generate' to update generated code:\n\t"+strings.Join(outdatedFiles, "\n\t")),
+		errors.E(ErrOutdatedGenCodeDetected).Error(),
+	)
 }
 
 func checkGenCode(engine *engine.Engine, safeguards Safeguards) bool {
@@ -0,0 +1,31 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"os"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/ui/tui/picker"
+)
+
+// pickStacks shows the picker of the stacks on the standard error, printing
+// the equivalent command of the picked stacks.
+func (s *Spec) pickStacks(stacks config.List[*config.SortableStack]) (config.List[*config.SortableStack], error) {
+	in, ok := s.Stdin.(*os.File)
+	if !ok {
+		return nil, errors.E(picker.ErrNotTerminal)
+	}
+	picked, err := picker.PickStacks(s.Engine, s.GitFilter, stacks, in, s.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, len(picked))
+	for i, st := range picked {
+		dirs[i] = st.Dir().String()
+	}
+	s.Printers.Stderr.Println("Equivalent command: " + picker.Command(s.Args, len(s.Command), dirs))
+	return picked, nil
+}
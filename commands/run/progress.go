@@ -0,0 +1,43 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"fmt"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// reportProgress sends the progress events of the runs, updated by the hooks
+// of the given options. The returned function sends the final event.
+func (s *Spec) reportProgress(runs []engine.StackRun, opts *engine.RunAllOptions) (finish func(err error)) {
+	counter := s.Progress.Phase("run", len(runs), fmt.Sprintf("running %d stacks", len(runs)))
+
+	before, after := opts.Hooks.Before, opts.Hooks.After
+	opts.Hooks.Before = func(e *engine.Engine, run engine.StackCloudRun) {
+		before(e, run)
+		counter.Start(run.Stack.Dir.String(), "running")
+	}
+	opts.Hooks.After = func(e *engine.Engine, run engine.StackCloudRun, res engine.RunResult, err error) {
+		after(e, run, res, err)
+		switch {
+		case err == nil && res.Skipped:
+			counter.Done(run.Stack.Dir.String(), "skipped")
+		case err == nil:
+			counter.Done(run.Stack.Dir.String(), "succeeded")
+		case errors.IsKind(err, engine.ErrRunCanceled):
+			counter.Done(run.Stack.Dir.String(), "canceled")
+		default:
+			counter.Done(run.Stack.Dir.String(), "failed")
+		}
+	}
+	return func(err error) {
+		if err != nil {
+			counter.Finish("finished with failures")
+			return
+		}
+		counter.Finish("finished")
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"os"
+	"slices"
+	"time"
+
+	"github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+	runutil "github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/run/record"
+)
+
+// NewRecord creates the fingerprint of a run executing the given command on
+// the given stack runs. The stacks are recorded in the order they are going
+// to be executed.
+func NewRecord(e *engine.Engine, runs []engine.StackRun, cmd []string, reverse bool) (record.Record, error) {
+	now := time.Now()
+	rec := record.Record{
+		ID:               record.NewID(now),
+		CreatedAt:        now.UTC(),
+		TerramateVersion: terramate.Version(),
+		Tools:            record.DetectTools(os.Environ()),
+		Command:          cmd,
+		Reverse:          reverse,
+	}
+
+	if e.Project().IsRepo() {
+		sha, err := e.Project().HeadCommit()
+		if err != nil {
+			return record.Record{}, err
+		}
+		rec.GitSHA = sha
+	}
+
+	cfg := e.Config()
+	ordered := slices.Clone(runs)
+	reason, err := runutil.Sort(cfg, ordered, func(run engine.StackRun) *config.Stack { return run.Stack })
+	if err != nil {
+		return record.Record{}, errors.E(err, "computing run order: %s", reason)
+	}
+	if reverse {
+		slices.Reverse(ordered)
+	}
+
+	for _, run := range ordered {
+		env, err := runutil.LoadEnvVars(cfg, run.Stack)
+		if err != nil {
+			return record.Record{}, err
+		}
+		rec.Stacks = append(rec.Stacks, record.Stack{
+			Dir: run.Stack.Dir.String(),
+			Env: record.EnvMap(env),
+		})
+	}
+	return rec, nil
+}
+
+func (s *Spec) saveRecord(runs []engine.StackRun) error {
+	rec, err := NewRecord(s.Engine, runs, s.Command, s.Reverse)
+	if err != nil {
+		return errors.E(err, "fingerprinting run")
+	}
+	if _, err := record.Save(s.Engine.Config().HostDir(), rec); err != nil {
+		return err
+	}
+	if !s.Quiet {
+		s.Printers.Stderr.Println("terramate: recorded run " + rec.ID)
+	}
+	return nil
+}
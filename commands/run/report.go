@@ -0,0 +1,129 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// Report is the report of a run, written by the --report flag, with the
+// status and the resources used by each stack.
+type Report struct {
+	Command []string      `json:"command"`
+	Stacks  []StackReport `json:"stacks"`
+
+	// Failures are the failed stacks grouped by the fingerprint of their
+	// error, the groups with more stacks first.
+	Failures []FailureGroup `json:"failures,omitempty"`
+}
+
+// StackReport is the report of a single stack. When multiple commands are
+// executed in the stack, the durations and CPU times are summed and the peak
+// memory is the maximum of the commands.
+type StackReport struct {
+	Stack      string     `json:"stack"`
+	Status     string     `json:"status"`
+	ExitCode   int        `json:"exit_code"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMS int64      `json:"duration_ms"`
+
+	// ApprovedBy identifies who approved the approval task of the stack.
+	ApprovedBy string `json:"approved_by,omitempty"`
+
+	// The resource usage is omitted if the operating system doesn't support
+	// the accounting of the child processes.
+	UserCPUMS   *int64 `json:"user_cpu_ms,omitempty"`
+	SystemCPUMS *int64 `json:"system_cpu_ms,omitempty"`
+	MaxRSSBytes *int64 `json:"max_rss_bytes,omitempty"`
+
+	// ErrorFingerprint identifies the failure of the stack in the failure
+	// groups.
+	ErrorFingerprint string `json:"error_fingerprint,omitempty"`
+}
+
+// reportCollector collects the results of the stacks as they finish.
+type reportCollector struct {
+	mu     sync.Mutex
+	stacks map[string]*StackReport
+}
+
+func newReportCollector() *reportCollector {
+	return &reportCollector{stacks: map[string]*StackReport{}}
+}
+
+// add accounts the result of a command executed in the stack.
+func (c *reportCollector) add(stack string, status string, res engine.RunResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st, ok := c.stacks[stack]
+	if !ok {
+		st = &StackReport{Stack: stack}
+		c.stacks[stack] = st
+	}
+	st.Status = status
+	st.ExitCode = res.ExitCode
+	if st.StartedAt == nil {
+		st.StartedAt = res.StartedAt
+	}
+	if res.FinishedAt != nil {
+		st.FinishedAt = res.FinishedAt
+	}
+	if res.ApprovedBy != "" {
+		st.ApprovedBy = res.ApprovedBy
+	}
+	if res.StartedAt != nil && res.FinishedAt != nil {
+		st.DurationMS += res.FinishedAt.Sub(*res.StartedAt).Milliseconds()
+	}
+	if u := res.Usage; u != nil {
+		st.UserCPUMS = addInt64(st.UserCPUMS, u.UserCPU.Milliseconds())
+		st.SystemCPUMS = addInt64(st.SystemCPUMS, u.SystemCPU.Milliseconds())
+		if u.MaxRSS > 0 && (st.MaxRSSBytes == nil || u.MaxRSS > *st.MaxRSSBytes) {
+			maxRSS := u.MaxRSS
+			st.MaxRSSBytes = &maxRSS
+		}
+	}
+}
+
+// report returns the report of the collected stacks, sorted by stack, with
+// their failures grouped by the triage.
+func (c *reportCollector) report(cmd []string, triage *failureTriage, failures []FailureGroup) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := Report{Command: cmd, Stacks: []StackReport{}, Failures: failures}
+	for _, st := range c.stacks {
+		report := *st
+		report.ErrorFingerprint = triage.fingerprint(st.Stack)
+		r.Stacks = append(r.Stacks, report)
+	}
+	sort.Slice(r.Stacks, func(i, j int) bool { return r.Stacks[i].Stack < r.Stacks[j].Stack })
+	return r
+}
+
+func (s *Spec) writeReport(r Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.E(errors.ErrInternal, err, "encoding run report")
+	}
+	if err := os.WriteFile(s.ReportFile, append(data, '\n'), 0644); err != nil {
+		return errors.E(err, "writing run report")
+	}
+	return nil
+}
+
+func addInt64(p *int64, v int64) *int64 {
+	if p != nil {
+		v += *p
+	}
+	return &v
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/engine"
+)
+
+func TestReportRecordsApprover(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	approved := start.Add(time.Second)
+	finish := approved.Add(time.Second)
+
+	collector := newReportCollector()
+	collector.add("/stack", "ok", engine.RunResult{
+		StartedAt:  &start,
+		FinishedAt: &approved,
+		ApprovedBy: "alice",
+	})
+	collector.add("/stack", "ok", engine.RunResult{
+		StartedAt:  &approved,
+		FinishedAt: &finish,
+	})
+	collector.add("/other", "ok", engine.RunResult{
+		StartedAt:  &start,
+		FinishedAt: &finish,
+	})
+
+	report := collector.report([]string{"deploy"}, &failureTriage{}, nil)
+	assert.EqualInts(t, 2, len(report.Stacks))
+	assert.EqualStrings(t, "/other", report.Stacks[0].Stack)
+	assert.EqualStrings(t, "", report.Stacks[0].ApprovedBy)
+	assert.EqualStrings(t, "/stack", report.Stacks[1].Stack)
+	assert.EqualStrings(t, "alice", report.Stacks[1].ApprovedBy)
+	assert.EqualInts(t, 2000, int(report.Stacks[1].DurationMS))
+
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Stacks []map[string]any `json:"stacks"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	if _, ok := decoded.Stacks[0]["approved_by"]; ok {
+		t.Fatalf("approved_by must be omitted for stacks without approvals: %s", data)
+	}
+	assert.EqualStrings(t, "alice", decoded.Stacks[1]["approved_by"].(string))
+}
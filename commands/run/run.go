@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"sync"
 
 	"github.com/rs/zerolog"
 	"github.com/terramate-io/terramate/cloud"
@@ -22,6 +24,8 @@ import (
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/notify"
+	"github.com/terramate-io/terramate/ui/tui/progress"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -62,10 +66,27 @@ type Spec struct {
 	TechnologyLayer   preview.Layer
 	TerraformPlanFile string
 	TofuPlanFile      string
+	SkipNoOpPlans     bool
 	Terragrunt        bool
 	EnableSharing     bool
 	MockOnFail        bool
 	EvalCmd           bool
+	Record            bool
+
+	// TUI shows a live table of the stacks status instead of the output of
+	// the commands.
+	TUI bool
+
+	// Progress, if set, receives the progress events of the runs.
+	Progress *progress.Reporter
+
+	// ReportFile, if set, is the file where the JSON report of the run, with
+	// the status and resource usage of each stack, is written.
+	ReportFile string
+
+	// DedupErrors buffers the stderr of the stacks, to print the stderr of
+	// the stacks failing with the same error only once.
+	DedupErrors bool
 
 	GitFilter     engine.GitFilter
 	StatusFilters StatusFilters
@@ -74,6 +95,15 @@ type Spec struct {
 	Tags          []string
 	NoTags        []string
 
+	// Stacks selects only the stacks in the given directories.
+	Stacks []string
+
+	// Pick shows a picker to select the stacks interactively. The Args are
+	// the CLI arguments, used to print the equivalent command of the picked
+	// stacks.
+	Pick bool
+	Args []string
+
 	engine.OutputsSharingOptions
 
 	Safeguards Safeguards
@@ -95,6 +125,10 @@ type Safeguards struct {
 	DisableCheckGitRemote             bool
 	DisableCheckGenerateOutdatedCheck bool
 
+	// CheckSelectedGenCode checks the generated code of the selected stacks
+	// when the outdated code of the whole working directory is not checked.
+	CheckSelectedGenCode bool
+
 	ReEnabled bool
 }
 
@@ -102,12 +136,12 @@ type Safeguards struct {
 func (s *Spec) Name() string { return "run" }
 
 // Exec executes the run command.
-func (s *Spec) Exec(_ context.Context) error {
+func (s *Spec) Exec(ctx context.Context) error {
 	if len(s.Command) == 0 {
 		return errors.E("run expects a command")
 	}
 
-	err := CheckOutdatedGeneratedCode(s.Engine, s.Safeguards, s.WorkingDir)
+	err := CheckOutdatedGeneratedCode(s.Engine, s.Safeguards, s.WorkingDir, s.Parallel)
 	if err != nil {
 		return err
 	}
@@ -120,6 +154,9 @@ func (s *Spec) Exec(_ context.Context) error {
 	rootdir := cfg.HostDir()
 	var stacks config.List[*config.SortableStack]
 	if s.NoRecursive {
+		if s.Pick || len(s.Stacks) > 0 {
+			return errors.E(ErrConflictOptions, "--no-recursive conflicts with --pick and --stack")
+		}
 		st, found, err := config.TryLoadStack(cfg, project.PrjAbsPath(rootdir, s.WorkingDir))
 		if err != nil {
 			return errors.E(err, "loading stack in current directory")
@@ -147,6 +184,16 @@ func (s *Spec) Exec(_ context.Context) error {
 		if err != nil {
 			return err
 		}
+		stacks, err = s.Engine.SelectStackDirs(stacks, s.Stacks)
+		if err != nil {
+			return err
+		}
+		if s.Pick {
+			stacks, err = s.pickStacks(stacks)
+			if err != nil {
+				return err
+			}
+		}
 
 		if !s.DryRun {
 			err = GitFileSafeguards(s.Engine, true, s.Safeguards)
@@ -156,6 +203,11 @@ func (s *Spec) Exec(_ context.Context) error {
 		}
 	}
 
+	err = CheckSelectedOutdatedGeneratedCode(s.Engine, s.Safeguards, stacks, s.Parallel)
+	if err != nil {
+		return err
+	}
+
 	err = GitSafeguardDefaultBranchIsReachable(s.Engine, s.Safeguards)
 	if err != nil {
 		return err
@@ -173,12 +225,20 @@ func (s *Spec) Exec(_ context.Context) error {
 		return errors.E(ErrConflictOptions, "--terraform-plan-file conflicts with --tofu-plan-file")
 	}
 
+	if s.TUI && !isTerminal(s.Stdout) {
+		return errors.E(ErrConflictOptions, "--tui requires the output to be a terminal")
+	}
+
 	planFile, planProvisioner := SelectPlanFile(s.TerraformPlanFile, s.TofuPlanFile)
 
 	if planFile == "" && s.SyncPreview {
 		return errors.E(ErrConflictOptions, "--sync-preview requires --terraform-plan-file or -tofu-plan-file")
 	}
 
+	if planFile == "" && s.SkipNoOpPlans {
+		return errors.E(ErrConflictOptions, "--skip-no-op-plans requires --terraform-plan-file or --tofu-plan-file")
+	}
+
 	cloudSyncEnabled := s.SyncDeployment || s.SyncDriftStatus || s.SyncPreview
 
 	if s.TerraformPlanFile != "" && !cloudSyncEnabled {
@@ -240,6 +300,7 @@ func (s *Spec) Exec(_ context.Context) error {
 					CloudSyncPreview:     s.SyncPreview,
 					CloudPlanFile:        planFile,
 					CloudPlanProvisioner: planProvisioner,
+					SkipNoOpPlan:         s.SkipNoOpPlans,
 					CloudSyncLayer:       s.TechnologyLayer,
 					UseTerragrunt:        s.Terragrunt,
 					EnableSharing:        s.EnableSharing,
@@ -248,7 +309,7 @@ func (s *Spec) Exec(_ context.Context) error {
 			},
 		}
 		if s.EvalCmd {
-			run.Tasks[0].Cmd, err = s.evalRunArgs(run.Stack, s.Target, run.Tasks[0].Cmd)
+			run.Tasks[0].Cmd, run.Tasks[0].Sensitive, err = s.evalRunArgs(run.Stack, s.Target, run.Tasks[0].Cmd)
 			if err != nil {
 				return errors.D("%s", "unable to evaluate command").WithError(err)
 			}
@@ -278,13 +339,26 @@ func (s *Spec) Exec(_ context.Context) error {
 		}
 	}
 
-	err = s.Engine.RunAll(runs, engine.RunAllOptions{
+	if s.Record && !s.DryRun {
+		err := s.saveRecord(runs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		results []notify.StackResult
+	)
+	report := newReportCollector()
+	opts := engine.RunAllOptions{
 		Quiet:           s.Quiet,
 		DryRun:          s.DryRun,
 		Reverse:         s.Reverse,
 		ScriptRun:       false,
 		ContinueOnError: s.ContinueOnError,
 		Parallel:        s.Parallel,
+		RefreshOutputs:  s.RefreshOutputs,
 		Stdout:          s.Stdout,
 		Stderr:          s.Stderr,
 		Stdin:           s.Stdin,
@@ -294,6 +368,16 @@ func (s *Spec) Exec(_ context.Context) error {
 			},
 			After: func(e *engine.Engine, run engine.StackCloudRun, res engine.RunResult, err error) {
 				cloudsync.AfterRun(e, run, &s.state, res, err)
+
+				status := notifyStatus(res, err, run.Task.CloudSyncDriftStatus)
+				report.add(run.Stack.Dir.String(), status, res)
+
+				mu.Lock()
+				defer mu.Unlock()
+				results = append(results, notify.StackResult{
+					Stack:  run.Stack.Dir.String(),
+					Status: status,
+				})
 			},
 			LogSyncCondition: func(task engine.StackRunTask, _ engine.StackRun) bool {
 				return task.CloudSyncDeployment || task.CloudSyncPreview
@@ -302,13 +386,69 @@ func (s *Spec) Exec(_ context.Context) error {
 				cloudsync.Logs(logger, e, run, &s.state, logs)
 			},
 		},
-	})
+	}
+	finishProgress := s.reportProgress(runs, &opts)
+	stopLiveView := func() {}
+	if s.TUI {
+		stopLiveView = s.showLiveView(runs, &opts)
+	}
+	triage := s.triageFailures(&opts)
+	err = s.Engine.RunAll(runs, opts)
+	stopLiveView()
+	finishProgress(err)
+
+	failures := triage.groups()
+	s.printGroups(triage, failures)
+
+	if !s.DryRun {
+		s.notify(ctx, results)
+	}
+
+	if s.ReportFile != "" {
+		if reportErr := s.writeReport(report.report(s.Command, triage, failures)); reportErr != nil {
+			if err == nil {
+				return reportErr
+			}
+			s.Printers.Stderr.ErrorWithDetails("failed to write the run report", reportErr)
+		}
+	}
+
 	if err != nil {
 		return errors.D("%s", "one or more commands failed").WithError(err)
 	}
 	return nil
 }
 
+// notify sends the results to the webhook configured in the
+// terramate.config.notifications block. Failing to notify doesn't fail the
+// command.
+func (s *Spec) notify(ctx context.Context, results []notify.StackResult) {
+	sort.Slice(results, func(i, j int) bool { return results[i].Stack < results[j].Stack })
+	err := notify.Send(ctx, s.Engine.Config(), notify.Report{
+		Command: "terramate run",
+		Stacks:  results,
+	})
+	if err != nil {
+		s.Printers.Stderr.WarnWithDetails("failed to send notification", err)
+	}
+}
+
+// notifyStatus returns the notification status of a stack run.
+func notifyStatus(res engine.RunResult, err error, detectsDrift bool) string {
+	switch {
+	case err == nil && detectsDrift && res.ExitCode == 2:
+		return notify.StatusDrifted
+	case err == nil && res.Skipped:
+		return notify.StatusSkipped
+	case err == nil:
+		return notify.StatusOK
+	case errors.IsKind(err, engine.ErrRunCanceled):
+		return notify.StatusCanceled
+	default:
+		return notify.StatusFailed
+	}
+}
+
 func (s *Spec) cloudEnabled() bool { return s.Engine.IsCloudEnabled() }
 
 // SelectPlanFile returns the plan file and provisioner to use based on the provided flags.
@@ -323,29 +463,34 @@ func SelectPlanFile(terraformPlan, tofuPlan string) (planfile, provisioner strin
 	return
 }
 
-func (s *Spec) evalRunArgs(st *config.Stack, target string, cmd []string) ([]string, error) {
+// evalRunArgs evaluates the command arguments, returning the evaluated
+// arguments and the ones referencing sensitive globals.
+func (s *Spec) evalRunArgs(st *config.Stack, target string, cmd []string) ([]string, []string, error) {
 	ctx, err := s.Engine.SetupEvalContext(st.HostDir(s.Engine.Config()), st, target, map[string]string{})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	var newargs []string
+	var newargs, sensitive []string
 	for _, arg := range cmd {
 		exprStr := `"` + arg + `"`
 		expr, err := ast.ParseExpression(exprStr, "<cmd arg>")
 		if err != nil {
-			return nil, errors.E(err, "parsing %s", exprStr)
+			return nil, nil, errors.E(err, "parsing %s", exprStr)
 		}
 		val, err := ctx.Eval(expr)
 		if err != nil {
-			return nil, errors.E(err, "eval %s", exprStr)
+			return nil, nil, errors.E(err, "eval %s", exprStr)
 		}
 		if !val.Type().Equals(cty.String) {
-			return nil, errors.E("cmd line evaluates to type %s but only string is permitted", val.Type().FriendlyName())
+			return nil, nil, errors.E("cmd line evaluates to type %s but only string is permitted", val.Type().FriendlyName())
 		}
 
 		newargs = append(newargs, val.AsString())
+		if ctx.IsSensitive(expr) {
+			sensitive = append(sensitive, val.AsString())
+		}
 	}
-	return newargs, nil
+	return newargs, sensitive, nil
 }
 
 func (s *Spec) writePreviewURL() {
@@ -0,0 +1,308 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+	"github.com/terramate-io/terramate/errors"
+)
+
+const (
+	// maxTriageOutputBytes is the size of the tail of the stderr kept for
+	// each stack to fingerprint its failure.
+	maxTriageOutputBytes = 16 * 1024
+
+	// maxTriageLines is the number of last lines of the stderr used as the
+	// representative log of a failure.
+	maxTriageLines = 20
+
+	// maxDedupOutputBytes is the size of the tail of the stderr buffered for
+	// each stack when the logs of the identical failures are deduplicated.
+	maxDedupOutputBytes = 1024 * 1024
+)
+
+var (
+	ansiEscapeRE = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	uuidRE       = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	hexRE        = regexp.MustCompile(`\b[0-9a-fA-F]{16,}\b`)
+	numberRE     = regexp.MustCompile(`[0-9]+`)
+	spacesRE     = regexp.MustCompile(`\s+`)
+)
+
+// FailureGroup is a group of stacks which failed with the same error, as
+// identified by the fingerprint of the normalized error output.
+type FailureGroup struct {
+	Fingerprint string   `json:"fingerprint"`
+	Count       int      `json:"count"`
+	Stacks      []string `json:"stacks"`
+
+	// Log is the representative error output of the group, taken from its
+	// first stack.
+	Log string `json:"log"`
+}
+
+// failureTriage collects the failures of the stacks, with the tail of their
+// stderr, to group the identical errors.
+//
+// If dedup is set, the stderr of the stacks is buffered instead of written.
+// The stderr of the successful stacks is written once they finish, and the
+// stderr of the failed stacks is written once for each group of identical
+// failures.
+type failureTriage struct {
+	mu       sync.Mutex
+	dedup    bool
+	outputs  map[string]*tailBuffer
+	stderrs  map[string]io.Writer
+	failures map[string]string
+	logs     map[string]string
+}
+
+// triageFailures collects the failures of the runs, updated by the hooks of
+// the given options. It must be called after any other option changing the
+// outputs of the commands.
+func (s *Spec) triageFailures(opts *engine.RunAllOptions) *failureTriage {
+	triage := &failureTriage{
+		dedup:    s.DedupErrors,
+		outputs:  map[string]*tailBuffer{},
+		stderrs:  map[string]io.Writer{},
+		failures: map[string]string{},
+		logs:     map[string]string{},
+	}
+
+	outputs := opts.Outputs
+	opts.Outputs = func(stack *config.Stack) (io.Writer, io.Writer) {
+		stdout, stderr := opts.Stdout, opts.Stderr
+		if outputs != nil {
+			stdout, stderr = outputs(stack)
+		}
+		out := triage.output(stack.Dir.String(), stderr)
+		if stderr == nil || triage.dedup {
+			return stdout, out
+		}
+		return stdout, io.MultiWriter(stderr, out)
+	}
+
+	after := opts.Hooks.After
+	opts.Hooks.After = func(e *engine.Engine, run engine.StackCloudRun, res engine.RunResult, err error) {
+		after(e, run, res, err)
+		if err != nil && !errors.IsKind(err, engine.ErrRunCanceled) {
+			triage.fail(run.Stack.Dir.String(), err)
+			return
+		}
+		triage.flush(run.Stack.Dir.String())
+	}
+	return triage
+}
+
+// output returns the buffer of the stderr of the stack, which is written to
+// the given stderr when it's deduplicated.
+func (t *failureTriage) output(stack string, stderr io.Writer) *tailBuffer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out, ok := t.outputs[stack]
+	if !ok {
+		out = &tailBuffer{max: maxTriageOutputBytes}
+		if t.dedup {
+			out.max = maxDedupOutputBytes
+		}
+		t.outputs[stack] = out
+	}
+	if stderr != nil {
+		t.stderrs[stack] = stderr
+	}
+	return out
+}
+
+// fail records the failure of the stack. Only the first failure of each
+// stack is recorded.
+func (t *failureTriage) fail(stack string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.failures[stack]; ok {
+		return
+	}
+	var log, full string
+	if out, ok := t.outputs[stack]; ok {
+		full = out.String()
+		log = lastLines(ansiEscapeRE.ReplaceAllString(full, ""), maxTriageLines)
+	}
+	if log == "" {
+		log = err.Error()
+	}
+	t.failures[stack] = log
+	if t.dedup {
+		t.logs[stack] = full
+	}
+}
+
+// flush writes the buffered stderr of the stack, if deduplicated, to its
+// stderr.
+func (t *failureTriage) flush(stack string) {
+	if !t.dedup {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out, ok := t.outputs[stack]
+	if !ok {
+		return
+	}
+	if stderr, ok := t.stderrs[stack]; ok {
+		_, _ = io.WriteString(stderr, out.String())
+	}
+	delete(t.outputs, stack)
+}
+
+// groups returns the failures grouped by fingerprint, the groups with more
+// stacks first.
+func (t *failureTriage) groups() []FailureGroup {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stacks := make([]string, 0, len(t.failures))
+	for stack := range t.failures {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	var groups []FailureGroup
+	index := map[string]int{}
+	for _, stack := range stacks {
+		log := t.failures[stack]
+		fingerprint := failureFingerprint(stack, log)
+		i, ok := index[fingerprint]
+		if !ok {
+			i = len(groups)
+			index[fingerprint] = i
+			groups = append(groups, FailureGroup{
+				Fingerprint: fingerprint,
+				Log:         log,
+			})
+		}
+		groups[i].Count++
+		groups[i].Stacks = append(groups[i].Stacks, stack)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Count > groups[j].Count })
+	return groups
+}
+
+// fingerprint returns the fingerprint of the failure of the stack, if any.
+func (t *failureTriage) fingerprint(stack string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	log, ok := t.failures[stack]
+	if !ok {
+		return ""
+	}
+	return failureFingerprint(stack, log)
+}
+
+// printGroups prints one representative log for each group of stacks which
+// failed with the same error. If the stderr of the stacks was deduplicated,
+// it's the whole stderr of the first stack of the group, otherwise its last
+// lines.
+func (s *Spec) printGroups(triage *failureTriage, groups []FailureGroup) {
+	for _, group := range groups {
+		if group.Count < 2 {
+			if triage.dedup {
+				triage.writeLog(group.Stacks[0])
+			}
+			continue
+		}
+		s.Printers.Stderr.Errorf("%d stacks failed with the same error (fingerprint %s): %s",
+			group.Count, group.Fingerprint, strings.Join(group.Stacks, ", "))
+		if triage.dedup {
+			triage.writeLog(group.Stacks[0])
+			continue
+		}
+		for _, line := range strings.Split(group.Log, "\n") {
+			s.Printers.Stderr.Println("  " + line)
+		}
+	}
+}
+
+// writeLog writes the buffered stderr of the failed stack to its stderr.
+func (t *failureTriage) writeLog(stack string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if stderr, ok := t.stderrs[stack]; ok {
+		_, _ = io.WriteString(stderr, t.logs[stack])
+	}
+}
+
+// failureFingerprint is the hash of the normalized failure log, ignoring the
+// stack path and the values usually changing between identical errors, like
+// numbers, UUIDs and request IDs.
+func failureFingerprint(stack, log string) string {
+	normalized := log
+	if stack != "/" {
+		normalized = strings.ReplaceAll(normalized, stack, "<stack>")
+	}
+	normalized = uuidRE.ReplaceAllString(normalized, "<uuid>")
+	normalized = hexRE.ReplaceAllString(normalized, "<hex>")
+	normalized = numberRE.ReplaceAllString(normalized, "<n>")
+
+	var lines []string
+	for _, line := range strings.Split(normalized, "\n") {
+		line = strings.TrimSpace(spacesRE.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// lastLines returns the last n non-empty lines of the text.
+func lastLines(text string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tailBuffer is a writer keeping the last max bytes written.
+type tailBuffer struct {
+	mu   sync.Mutex
+	max  int
+	data []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.max {
+		b.data = append([]byte(nil), b.data[len(b.data)-b.max:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "script" {
-  content = <<-EOT
+  content = <<-EOF
 package script // import "github.com/terramate-io/terramate/commands/script"
 
 Package script provides the script command.
@@ -9,7 +9,7 @@ Package script provides the script command.
 type InfoEntry struct{ ... }
 type Matcher struct{ ... }
     func NewMatcher(labels []string) *Matcher
-EOT
+EOF
 
   filename = "${path.module}/mock-script.ignore"
 }
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "info" {
-  content = <<-EOT
+  content = <<-EOF
 package info // import "github.com/terramate-io/terramate/commands/script/info"
 
 Package info provides the "script info" command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-info.ignore"
 }
@@ -15,7 +15,6 @@ import (
 
 	hhcl "github.com/terramate-io/hcl/v2"
 
-	"github.com/fatih/color"
 	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/cloud/api/resources"
 	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
@@ -60,7 +59,7 @@ func (s *Spec) Exec(_ context.Context) error {
 	m.Search(root, stacks)
 
 	if len(m.Results) == 0 {
-		s.Printers.Stderr.Println(color.RedString("script not found: ") +
+		s.Printers.Stderr.Println(printer.Sprint(printer.RoleError, "script not found: ") +
 			strings.Join(s.Labels, " "))
 		return errors.E("failed to execute command")
 	}
@@ -73,6 +72,19 @@ func (s *Spec) Exec(_ context.Context) error {
 		if x.ScriptCfg.Description != nil {
 			s.Printers.Stdout.Println(fmt.Sprintf("Description: %s", descTruncation(exprString(x.ScriptCfg.Description.Expr), "script.description")))
 		}
+		if len(x.ScriptCfg.Args) > 0 {
+			s.Printers.Stdout.Println("Args:")
+			for _, arg := range x.ScriptCfg.Args {
+				line := fmt.Sprintf("  --%s (%s)", arg.Name, arg.Type.FriendlyName())
+				if arg.Default != nil {
+					line += fmt.Sprintf(" default: %s", exprString(arg.Default.Expr))
+				}
+				if arg.Description != nil {
+					line += fmt.Sprintf(": %s", exprString(arg.Description.Expr))
+				}
+				s.Printers.Stdout.Println(line)
+			}
+		}
 		if len(x.Stacks) > 0 {
 			s.Printers.Stdout.Println("Stacks:")
 			for _, st := range x.Stacks {
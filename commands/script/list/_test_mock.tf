@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "list" {
-  content = <<-EOT
+  content = <<-EOF
 package list // import "github.com/terramate-io/terramate/commands/script/list"
 
 Package list provides the script list command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-list.ignore"
 }
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "run" {
-  content = <<-EOT
+  content = <<-EOF
 package run // import "github.com/terramate-io/terramate/commands/script/run"
 
 Package run provides the script run command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-run.ignore"
 }
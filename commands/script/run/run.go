@@ -9,9 +9,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/hashicorp/go-uuid"
 	"github.com/rs/zerolog"
 	"github.com/terramate-io/terramate/cloud/api/resources"
@@ -24,10 +24,10 @@ import (
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
-	"github.com/terramate-io/terramate/stdlib"
 	"github.com/zclconf/go-cty/cty"
 
 	tel "github.com/terramate-io/terramate/ui/tui/telemetry"
@@ -57,6 +57,7 @@ type Spec struct {
 	Target          string
 	FromTarget      string
 	NoRecursive     bool
+	SkipNoOpPlans   bool
 	NoTags          []string
 	Tags            []string
 	engine.OutputsSharingOptions
@@ -68,6 +69,9 @@ type Spec struct {
 
 	Labels []string
 
+	// Args are the arguments given to the script, after the "--" separator.
+	Args []string
+
 	state cloudsync.CloudRunState
 }
 
@@ -76,7 +80,7 @@ func (s *Spec) Name() string { return "script run" }
 
 // Exec executes the script run command.
 func (s *Spec) Exec(_ context.Context) error {
-	err := runcmd.CheckOutdatedGeneratedCode(s.Engine, s.Safeguards, s.WorkingDir)
+	err := runcmd.CheckOutdatedGeneratedCode(s.Engine, s.Safeguards, s.WorkingDir, s.Parallel)
 	if err != nil {
 		return err
 	}
@@ -139,12 +143,28 @@ func (s *Spec) Exec(_ context.Context) error {
 		return err
 	}
 
+	if !s.NoRecursive {
+		stacks, err = s.withProducers(root, stacks)
+		if err != nil {
+			return err
+		}
+	}
+
 	// search for the script and prepare a list of script/stack entries
 	m := script.NewMatcher(s.Labels)
 	m.Search(root, stacks)
 
 	if len(m.Results) == 0 {
-		return errors.E(color.RedString("script not found: ") + strings.Join(s.Labels, " "))
+		return errors.E(printer.Sprint(printer.RoleError, "script not found: ") + strings.Join(s.Labels, " "))
+	}
+
+	var scriptStacks config.List[*config.SortableStack]
+	for _, result := range m.Results {
+		scriptStacks = append(scriptStacks, result.Stacks...)
+	}
+	err = runcmd.CheckSelectedOutdatedGeneratedCode(s.Engine, s.Safeguards, scriptStacks, s.Parallel)
+	if err != nil {
+		return err
 	}
 
 	if s.DryRun {
@@ -153,33 +173,79 @@ func (s *Spec) Exec(_ context.Context) error {
 
 	var runs []engine.StackRun
 
+	// artifacts handed off between the jobs are kept in a temporary
+	// directory per stack, removed after all stacks are executed.
+	var artifactsDirs []string
+	defer func() {
+		for _, dir := range artifactsDirs {
+			if err := os.RemoveAll(dir); err != nil {
+				s.Printers.Stderr.Warnf("failed to remove script artifacts directory %s: %v", dir, err)
+			}
+		}
+	}()
+
 	for scriptIdx, result := range m.Results {
 		if len(result.Stacks) == 0 {
 			continue
 		}
 
+		scriptArgs, err := config.ParseScriptArgs(*result.ScriptCfg, s.Args)
+		if err != nil {
+			return errors.E(err, "script at %s", result.ScriptCfg.Range.String())
+		}
+
 		if !s.Quiet {
 			s.Printers.Stderr.Println(fmt.Sprintf("Script %s at %s having %s job(s)",
-				color.GreenString(fmt.Sprintf("%d", scriptIdx)),
-				color.BlueString(result.ScriptCfg.Range.String()),
-				color.BlueString(fmt.Sprintf("%d", len(result.ScriptCfg.Jobs))),
+				printer.Sprint(printer.RoleSuccess, scriptIdx),
+				printer.Sprint(printer.RoleInfo, result.ScriptCfg.Range.String()),
+				printer.Sprint(printer.RoleInfo, len(result.ScriptCfg.Jobs)),
 			))
 		}
 
 		for _, st := range result.Stacks {
 			run := engine.StackRun{Stack: st.Stack}
 
-			ectx, err := scriptEvalContext(root, st.Stack, s.Target)
+			ectx, sensitive, err := scriptEvalContext(root, st.Stack, s.Target)
 			if err != nil {
 				return errors.E(err, "failed to get context")
 			}
 
-			evalScript, err := config.EvalScript(ectx, *result.ScriptCfg)
+			var artifactsDir string
+			if hasArtifacts(result.ScriptCfg) {
+				artifactsDir, err = os.MkdirTemp("", "terramate-artifacts-")
+				if err != nil {
+					return errors.E(err, "creating script artifacts directory")
+				}
+				artifactsDirs = append(artifactsDirs, artifactsDir)
+			}
+
+			evalScript, err := config.EvalScript(ectx, *result.ScriptCfg, artifactsDir, scriptArgs)
 			if err != nil {
 				return errors.E(err, "failed to eval script")
 			}
 
+			// the stacks producing the files the jobs depend on run first.
+			var producers []string
 			for jobIdx, job := range evalScript.Jobs {
+				if job.Approval != nil {
+					run.Tasks = append(run.Tasks, engine.StackRunTask{
+						CloudTarget:     s.Target,
+						CloudFromTarget: s.FromTarget,
+						ScriptIdx:       scriptIdx,
+						ScriptJobIdx:    jobIdx,
+						Approval: &engine.Approval{
+							Message: job.Approval.Message,
+							File:    job.Approval.File,
+						},
+					})
+					continue
+				}
+				dependsOnFiles := engine.NewDependencyFiles(root, st.Stack, job.DependsOnFiles)
+				for _, file := range dependsOnFiles {
+					if file.Producer != nil && file.Producer.Dir != st.Stack.Dir {
+						producers = append(producers, file.Producer.Dir.String())
+					}
+				}
 				for cmdIdx, cmd := range job.Commands() {
 					task := engine.StackRunTask{
 						Cmd:             cmd.Args,
@@ -188,6 +254,10 @@ func (s *Spec) Exec(_ context.Context) error {
 						ScriptIdx:       scriptIdx,
 						ScriptJobIdx:    jobIdx,
 						ScriptCmdIdx:    cmdIdx,
+						Sensitive:       sensitive,
+					}
+					if cmdIdx == 0 {
+						task.DependsOnFiles = dependsOnFiles
 					}
 
 					if cmd.Options != nil {
@@ -224,6 +294,7 @@ func (s *Spec) Exec(_ context.Context) error {
 				}
 			}
 
+			run.Stack = withAfter(st.Stack, producers)
 			runs = append(runs, run)
 		}
 	}
@@ -240,6 +311,8 @@ func (s *Spec) Exec(_ context.Context) error {
 		Reverse:         s.Reverse,
 		ContinueOnError: s.ContinueOnError,
 		Parallel:        s.Parallel,
+		SkipNoOpPlans:   s.SkipNoOpPlans,
+		RefreshOutputs:  s.RefreshOutputs,
 		Stdout:          s.Stdout,
 		Stderr:          s.Stderr,
 		Stdin:           s.Stdin,
@@ -264,6 +337,79 @@ func (s *Spec) Exec(_ context.Context) error {
 	return nil
 }
 
+// withProducers adds to the stacks the stacks producing the files which the
+// jobs of the script depend on, recursively, so they run before the stacks
+// depending on their files.
+func (s *Spec) withProducers(root *config.Root, stacks config.List[*config.SortableStack]) (config.List[*config.SortableStack], error) {
+	selected := map[project.Path]struct{}{}
+	for _, st := range stacks {
+		selected[st.Dir()] = struct{}{}
+	}
+	pending := stacks
+	for len(pending) > 0 {
+		m := script.NewMatcher(s.Labels)
+		m.Search(root, pending)
+		pending = nil
+		for _, result := range m.Results {
+			scriptArgs, err := config.ParseScriptArgs(*result.ScriptCfg, s.Args)
+			if err != nil {
+				return nil, errors.E(err, "script at %s", result.ScriptCfg.Range.String())
+			}
+			for _, st := range result.Stacks {
+				ectx, _, err := scriptEvalContext(root, st.Stack, s.Target)
+				if err != nil {
+					return nil, errors.E(err, "failed to get context")
+				}
+				evalScript, err := config.EvalScript(ectx, *result.ScriptCfg, "", scriptArgs)
+				if err != nil {
+					return nil, errors.E(err, "failed to eval script")
+				}
+				for _, job := range evalScript.Jobs {
+					for _, file := range engine.NewDependencyFiles(root, st.Stack, job.DependsOnFiles) {
+						if file.Producer == nil {
+							continue
+						}
+						if _, ok := selected[file.Producer.Dir]; ok {
+							continue
+						}
+						selected[file.Producer.Dir] = struct{}{}
+						pending = append(pending, file.Producer.Sortable())
+					}
+				}
+			}
+		}
+		stacks = append(stacks, pending...)
+	}
+	return stacks, nil
+}
+
+// withAfter returns the stack running after the given stacks too. The stack
+// is copied if changed, as it's shared by the configuration.
+func withAfter(st *config.Stack, after []string) *config.Stack {
+	var missing []string
+	for _, dir := range after {
+		if !slices.Contains(st.After, dir) && !slices.Contains(missing, dir) {
+			missing = append(missing, dir)
+		}
+	}
+	if len(missing) == 0 {
+		return st
+	}
+	c := *st
+	c.After = append(slices.Clone(st.After), missing...)
+	c.Before = slices.Clone(st.Before)
+	return &c
+}
+
+func hasArtifacts(script *hcl.Script) bool {
+	for _, job := range script.Jobs {
+		if job.Artifacts != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Spec) prepareScriptForCloudSync(runs []engine.StackRun) error {
 	if s.DryRun {
 		return nil
@@ -298,7 +444,12 @@ func (s *Spec) prepareScriptForCloudSync(runs []engine.StackRun) error {
 		return nil
 	}
 
-	err := s.Engine.SetupCloudConfig(feats)
+	setup := s.Engine.SetupCloudSyncConfig
+	if len(previewRuns) > 0 {
+		// previews need the responses of the Terramate Cloud.
+		setup = s.Engine.SetupCloudConfig
+	}
+	err := setup(feats)
 	err = s.Engine.HandleCloudCriticalError(err)
 	if err != nil {
 		return err
@@ -369,13 +520,15 @@ func (s *Spec) prepareScriptForCloudSync(runs []engine.StackRun) error {
 	return nil
 }
 
-func scriptEvalContext(root *config.Root, st *config.Stack, target string) (*eval.Context, error) {
+// scriptEvalContext returns the evaluation context of the scripts of the
+// stack and the values of its sensitive globals.
+func scriptEvalContext(root *config.Root, st *config.Stack, target string) (*eval.Context, []string, error) {
 	globalsReport := globals.ForStack(root, st)
 	if err := globalsReport.AsError(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	evalctx := eval.NewContext(stdlib.Functions(st.HostDir(root), root.Tree().Node.Experiments()))
+	evalctx := root.NewEvalContext(st.HostDir(root))
 	runtime := root.Runtime()
 	runtime.Merge(st.RuntimeValues(root))
 
@@ -385,7 +538,9 @@ func scriptEvalContext(root *config.Root, st *config.Stack, target string) (*eva
 
 	evalctx.SetNamespace("terramate", runtime)
 	evalctx.SetNamespace("global", globalsReport.Globals.AsValueMap())
-	evalctx.SetEnv(os.Environ())
+	evalctx.DeprecateFrom("global", globalsReport.Globals)
+	evalctx.SensitiveFrom("global", globalsReport.Globals)
+	evalctx.SetEnv(root.Environ())
 
-	return evalctx, nil
+	return evalctx, globalsReport.Globals.SensitiveStrings(), nil
 }
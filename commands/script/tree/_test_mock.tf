@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "tree" {
-  content = <<-EOT
+  content = <<-EOF
 package tree // import "github.com/terramate-io/terramate/commands/script/tree"
 
 Package tree provides the script tree command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-tree.ignore"
 }
@@ -17,7 +17,6 @@ import (
 
 	hhcl "github.com/terramate-io/hcl/v2"
 
-	"github.com/fatih/color"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/hcl"
@@ -65,9 +64,9 @@ type scriptsTreeNode struct {
 }
 
 func (node *scriptsTreeNode) format(w io.Writer, prefix string, parentScripts []string) {
-	stackColor := color.New(color.FgGreen).SprintFunc()
-	scriptColor := color.New(color.FgYellow).SprintFunc()
-	parentScriptColor := color.New(color.Faint).SprintFunc()
+	stackColor := func(a ...any) string { return printer.Sprint(printer.RoleSuccess, a...) }
+	scriptColor := func(a ...any) string { return printer.Sprint(printer.RoleHighlight, a...) }
+	parentScriptColor := func(a ...any) string { return printer.Sprint(printer.RoleFaint, a...) }
 
 	var text string
 	if node.DirName != "" {
@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "create" {
-  content = <<-EOT
+  content = <<-EOF
 package create // import "github.com/terramate-io/terramate/commands/stack/create"
 
 Package create provides the create stack command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-create.ignore"
 }
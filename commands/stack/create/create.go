@@ -358,7 +358,7 @@ func (s *Spec) initTerraformDir(baseDir string) error {
 			continue
 		}
 
-		if filepath.Ext(f.Name()) != ".tf" {
+		if !tf.IsConfigFile(f.Name()) {
 			continue
 		}
 
@@ -7,6 +7,7 @@ package list
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/terramate-io/terramate/cloud/api/status"
 	"github.com/terramate-io/terramate/config"
@@ -15,6 +16,7 @@ import (
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/run"
 	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/ui/tui/picker"
 )
 
 // Spec is the command specification for the list command.
@@ -28,6 +30,19 @@ type Spec struct {
 	Tags          []string
 	NoTags        []string
 	Printers      printer.Printers
+
+	// Tree shows the run order as a tree of levels with the constraints
+	// ordering each stack. It requires RunOrder.
+	Tree bool
+
+	// Stacks selects only the stacks in the given directories.
+	Stacks []string
+
+	// Pick shows a picker to select the stacks interactively. The Args are
+	// the CLI arguments, used to print the equivalent command of the picked
+	// stacks.
+	Pick bool
+	Args []string
 }
 
 // StatusFilters contains the status filters for the list command.
@@ -45,6 +60,9 @@ func (s *Spec) Exec(_ context.Context) error {
 	if s.Reason && !s.GitFilter.IsChanged {
 		return errors.E("the --why flag must be used together with --changed")
 	}
+	if s.Tree && !s.RunOrder {
+		return errors.E("the --tree flag must be used together with --run-order")
+	}
 
 	err := s.Engine.CheckTargetsConfiguration(s.Target, "", func(isTargetSet bool) error {
 		isStatusSet := s.StatusFilters.StackStatus != ""
@@ -88,6 +106,26 @@ func (s *Spec) printStacksList(allStacks []stack.Entry) error {
 		reasons[entry.Stack.ID] = entry.Reason
 	}
 
+	stacks, err = s.Engine.SelectStackDirs(stacks, s.Stacks)
+	if err != nil {
+		return err
+	}
+	if s.Pick {
+		stacks, err = picker.PickStacks(s.Engine, s.GitFilter, stacks, os.Stdin, os.Stderr)
+		if err != nil {
+			return err
+		}
+		dirs := make([]string, len(stacks))
+		for i, st := range stacks {
+			dirs[i] = st.Dir().String()
+		}
+		printer.Stderr.Println("Equivalent command: " + picker.Command(s.Args, 0, dirs))
+	}
+
+	if s.Tree {
+		return s.printRunOrderTree(stacks)
+	}
+
 	if s.RunOrder {
 		var failReason string
 		var err error
@@ -114,3 +152,47 @@ func (s *Spec) printStacksList(allStacks []stack.Entry) error {
 	}
 	return nil
 }
+
+// printRunOrderTree prints the levels of the run order, each stack followed
+// by the stacks it runs after and the constraint ordering them.
+func (s *Spec) printRunOrderTree(stacks config.List[*config.SortableStack]) error {
+	levels, edges, reason, err := run.LevelsWithEdges(s.Engine.Config(), stacks,
+		func(s *config.SortableStack) *config.Stack { return s.Stack })
+	if err != nil {
+		return errors.E(err, "Invalid stack configuration: "+reason)
+	}
+
+	friendly := func(dir string) string {
+		if friendlyDir, ok := s.Engine.FriendlyFmtDir(dir); ok {
+			return friendlyDir
+		}
+		return dir
+	}
+	edgesTo := map[string][]run.Edge{}
+	for _, edge := range edges {
+		edgesTo[edge.To] = append(edgesTo[edge.To], edge)
+	}
+
+	for i, level := range levels {
+		printer.Stdout.Println(fmt.Sprintf("Level %d", i))
+		for j, st := range level {
+			branch, indent := "├── ", "│   "
+			if j == len(level)-1 {
+				branch, indent = "└── ", "    "
+			}
+			dir := st.Dir().String()
+			printer.Stdout.Println(branch + friendly(dir))
+
+			deps := edgesTo[dir]
+			for k, edge := range deps {
+				depBranch := "├── "
+				if k == len(deps)-1 {
+					depBranch = "└── "
+				}
+				printer.Stdout.Println(fmt.Sprintf("%s%safter %s: %s",
+					indent, depBranch, friendly(edge.From), edge.Constraint))
+			}
+		}
+	}
+	return nil
+}
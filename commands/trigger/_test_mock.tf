@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "trigger" {
-  content = <<-EOT
+  content = <<-EOF
 package trigger // import "github.com/terramate-io/terramate/commands/trigger"
 
 Package trigger provides the trigger command.
@@ -9,7 +9,7 @@ Package trigger provides the trigger command.
 type FilterSpec struct{ ... }
 type PathSpec struct{ ... }
 type StatusFilters struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-trigger.ignore"
 }
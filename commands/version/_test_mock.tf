@@ -1,13 +1,13 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "version" {
-  content = <<-EOT
+  content = <<-EOF
 package version // import "github.com/terramate-io/terramate/commands/version"
 
 Package version provides the version command.
 
 type Spec struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-version.ignore"
 }
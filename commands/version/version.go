@@ -6,19 +6,73 @@ package version
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/go-checkpoint"
+	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
+	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/ui/tui/cliconfig"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
 // Spec represents the version command specification.
 type Spec struct {
 	Version string
 
+	// Format is the output format, text by default.
+	Format string
+
+	// WorkingDir is used to look up the project with the enabled experiments
+	// reported in the JSON format.
+	WorkingDir string
+
 	InfoChan chan *checkpoint.CheckResponse
+	Printers printer.Printers
+}
+
+// Report is the machine-readable version report.
+type Report struct {
+	Version      string       `json:"version"`
+	Build        Build        `json:"build"`
+	Experiments  []string     `json:"experiments"`
+	Capabilities Capabilities `json:"capabilities"`
+}
+
+// Build is the information about the build of the binary.
+type Build struct {
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+// Capabilities are the features supported by the binary, so tooling can
+// detect them instead of comparing versions.
+type Capabilities struct {
+	// Blocks are the supported configuration block types.
+	Blocks []string `json:"blocks"`
+	// Functions are the supported functions, including the ones enabled by
+	// experiments.
+	Functions []string `json:"functions"`
+	// Experiments are the names of the experiments known by this version.
+	Experiments []string `json:"experiments"`
 }
 
 // Name returns the name of the version command.
@@ -26,6 +80,14 @@ func (s *Spec) Name() string { return "version" }
 
 // Exec executes the version command.
 func (s *Spec) Exec(ctx context.Context) error {
+	switch s.Format {
+	case "", FormatText:
+	case FormatJSON:
+		return s.printJSON()
+	default:
+		return errors.E("--format expects the values %q or %q", FormatText, FormatJSON)
+	}
+
 	fmt.Println(s.Version)
 
 	if s.InfoChan == nil {
@@ -67,3 +129,71 @@ func (s *Spec) Exec(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (s *Spec) printJSON() error {
+	report := Report{
+		Version:     s.Version,
+		Build:       buildInfo(),
+		Experiments: s.experiments(),
+		Capabilities: Capabilities{
+			Blocks: hcl.BlockTypes(),
+		},
+	}
+	if report.Experiments == nil {
+		report.Experiments = []string{}
+	}
+	var allExperiments []string
+	for _, exp := range experiments.All() {
+		allExperiments = append(allExperiments, exp.Name)
+	}
+	report.Capabilities.Experiments = allExperiments
+	for name := range stdlib.Functions(s.WorkingDir, allExperiments) {
+		report.Capabilities.Functions = append(report.Capabilities.Functions, name)
+	}
+	sort.Strings(report.Capabilities.Functions)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.E(err, "encoding version as JSON")
+	}
+	s.Printers.Stdout.Println(string(data))
+	return nil
+}
+
+// experiments returns the experiments enabled in the project of the working
+// directory, if any. The version must be reported even if the project has
+// an invalid configuration, then loading errors are only logged.
+func (s *Spec) experiments() []string {
+	e, found, err := engine.Load(s.WorkingDir, false, cliconfig.Config{}, engine.HumanMode, s.Printers, 0)
+	if err != nil {
+		log.Warn().Err(err).Msg("loading project configuration for the enabled experiments")
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return e.Config().Tree().Node.Experiments()
+}
+
+func buildInfo() Build {
+	build := Build{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
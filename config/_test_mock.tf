@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "config" {
-  content = <<-EOT
+  content = <<-EOF
 package config // import "github.com/terramate-io/terramate/config"
 
 Package config provides high level Terramate configuration facilities.
@@ -44,7 +44,7 @@ type Stack struct{ ... }
     func TryLoadStack(root *Root, cfgdir project.Path) (stack *Stack, found bool, err error)
 type Tree struct{ ... }
     func NewTree(cfgdir string) *Tree
-EOT
+EOF
 
   filename = "${path.module}/mock-config.ignore"
 }
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/hcl/eval"
@@ -16,9 +18,25 @@ type Assert struct {
 	Assertion bool
 	Warning   bool
 	Message   string
+	Code      string
+	DocsURL   string
 	Range     hhcl.Range
 }
 
+// ErrAssertion indicates that an assertion failed.
+const ErrAssertion errors.Kind = "assertion failed"
+
+// AssertionError represents a failed assertion.
+// It carries the optional metadata of the assert block (code and docs_url),
+// so it can be reported to the user together with the message.
+// It's an [ErrAssertion] error described by the range and the message.
+type AssertionError struct {
+	Range   hhcl.Range
+	Message string
+	Code    string
+	DocsURL string
+}
+
 // EvalAssert evaluates a given assert configuration and returns its
 // evaluated form.
 func EvalAssert(evalctx *eval.Context, cfg hcl.AssertConfig) (Assert, error) {
@@ -49,9 +67,49 @@ func EvalAssert(evalctx *eval.Context, cfg hcl.AssertConfig) (Assert, error) {
 		}
 	}
 
+	if cfg.Code != nil {
+		code, err := EvalString(evalctx, cfg.Code, "assert.code")
+		if err != nil {
+			errs.Append(err)
+		} else {
+			res.Code = code
+		}
+	}
+
+	if cfg.DocsURL != nil {
+		docsURL, err := EvalString(evalctx, cfg.DocsURL, "assert.docs_url")
+		if err != nil {
+			errs.Append(err)
+		} else {
+			res.DocsURL = docsURL
+		}
+	}
+
 	if err := errs.AsError(); err != nil {
 		return Assert{}, err
 	}
 
 	return res, nil
 }
+
+// Error returns the error message of the failed assertion.
+func (e *AssertionError) Error() string {
+	return e.asError().Error()
+}
+
+// Is tells if the failed assertion, as an [ErrAssertion] error, matches the
+// target.
+func (e *AssertionError) Is(target error) bool {
+	return errors.Is(e.asError(), target)
+}
+
+func (e *AssertionError) asError() *errors.Error {
+	msg := e.Message
+	if e.Code != "" {
+		msg = fmt.Sprintf("[%s] %s", e.Code, msg)
+	}
+	if e.DocsURL != "" {
+		msg = fmt.Sprintf("%s (see %s)", msg, e.DocsURL)
+	}
+	return errors.E(ErrAssertion, "%s: %s", e.Range.String(), msg)
+}
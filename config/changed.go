@@ -0,0 +1,43 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"slices"
+
+	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SetChangedStacks sets the changed stacks exposed in the terramate.changed
+// namespace. A nil list exposes no changed stacks, which is the namespace
+// when generating without --changed.
+func (root *Root) SetChangedStacks(stacks project.Paths) {
+	root.changedStacks = stacks
+}
+
+// ChangedStacks returns the changed stacks exposed in the terramate.changed
+// namespace, if any.
+func (root *Root) ChangedStacks() (project.Paths, bool) {
+	return root.changedStacks, root.changedStacks != nil
+}
+
+// RootContextRuntime returns the runtime of the terramate namespace in the
+// root context, which is the [Root.Runtime] and the terramate.changed.stacks.
+func (root *Root) RootContextRuntime() project.Runtime {
+	runtime := root.Runtime()
+	runtime["changed"] = cty.ObjectVal(map[string]cty.Value{
+		"stacks": toCtyStringList(root.changedStacks.Strings()),
+	})
+	return runtime
+}
+
+// changedValue returns the terramate.changed namespace of the stack, telling
+// if the stack changed and listing all the changed stacks.
+func (root *Root) changedValue(st *Stack) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"stack":  cty.BoolVal(slices.Contains(root.changedStacks, st.Dir)),
+		"stacks": toCtyStringList(root.changedStacks.Strings()),
+	})
+}
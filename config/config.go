@@ -23,14 +23,19 @@ import (
 	"github.com/terramate-io/terramate"
 	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/event"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/fs"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/hcl/info"
-	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/stdlib"
 	"github.com/terramate-io/terramate/tg"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 const (
@@ -64,7 +69,14 @@ type Root struct {
 	tgTransientErrs  map[string]error
 	tgProcessedFiles map[string]struct{}
 
-	runtime project.Runtime
+	runtime       project.Runtime
+	gitMetadata   *GitMetadata
+	changedStacks project.Paths
+
+	strictDeterminism bool
+
+	// warnings collects the warnings emitted while evaluating the root.
+	warnings *warnings.Collector
 
 	hclOpts []hcl.Option
 }
@@ -78,6 +90,9 @@ type Tree struct {
 
 	Skipped bool // tells if this node subdirs were skipped
 
+	// skippedStacks are the stacks nested inside the skipped directory.
+	skippedStacks []project.Path
+
 	TerramateFiles []string
 	OtherFiles     []string
 	TmGenFiles     []string
@@ -138,6 +153,11 @@ func TryLoadConfig(fromdir string, changeDetectionEnabled bool, hclOpts ...hcl.O
 			if err != nil {
 				return nil, fromdir, true, err
 			}
+			cfg, err = reparseWithConfigSuffixes(fromdir, cfg, hclOpts)
+			if err != nil {
+				return nil, fromdir, true, err
+			}
+			setWarningsAsErrors(cfg)
 			rootTree := NewTree(fromdir)
 			rootTree.Node = *cfg
 			root := NewRoot(rootTree, hclOpts...)
@@ -177,8 +197,10 @@ func (root *Root) initTgWorkers() {
 	if !root.changeDetectionEnabled {
 		return
 	}
-	root.tgTransientErrs = map[string]error{}
-	root.tgProcessedFiles = make(map[string]struct{})
+	if root.tgTransientErrs == nil {
+		root.tgTransientErrs = map[string]error{}
+		root.tgProcessedFiles = make(map[string]struct{})
+	}
 	for i := 0; i < root.maxTgWorkers; i++ {
 		go root.tgWorker()
 	}
@@ -209,10 +231,11 @@ func (root *Root) tgWorker() {
 
 // LoadRoot loads the root configuration tree.
 func LoadRoot(rootdir string, changeDetectionEnabled bool, hclOpts ...hcl.Option) (*Root, error) {
-	rootcfg, err := hcl.ParseDir(rootdir, rootdir, hclOpts...)
+	rootcfg, err := parseRootDir(rootdir, hclOpts)
 	if err != nil {
 		return nil, err
 	}
+	setWarningsAsErrors(rootcfg)
 	rootTree := NewTree(rootdir)
 	rootTree.Node = *rootcfg
 	root := NewRoot(rootTree, hclOpts...)
@@ -227,6 +250,59 @@ func LoadRoot(rootdir string, changeDetectionEnabled bool, hclOpts ...hcl.Option
 	return root, nil
 }
 
+// parseRootDir parses the root directory, including the files with the
+// configuration file suffixes set in the root config.
+func parseRootDir(rootdir string, hclOpts []hcl.Option) (*hcl.Config, error) {
+	rootcfg, err := hcl.ParseDir(rootdir, rootdir, hclOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return reparseWithConfigSuffixes(rootdir, rootcfg, hclOpts)
+}
+
+// reparseWithConfigSuffixes parses the root directory again if the root
+// config sets terramate.config.config_file_suffixes, as the files with these
+// suffixes are only known after the standard files are parsed.
+func reparseWithConfigSuffixes(rootdir string, rootcfg *hcl.Config, hclOpts []hcl.Option) (*hcl.Config, error) {
+	suffixes := rootcfg.ConfigFileSuffixes()
+	if len(suffixes) == 0 {
+		return rootcfg, nil
+	}
+	opts := append([]hcl.Option{hcl.WithConfigFileSuffixes(suffixes...)}, hclOpts...)
+	return hcl.ParseDir(rootdir, rootdir, opts...)
+}
+
+// Warnings returns the collector of the warnings emitted while evaluating
+// the root, which is [warnings.Default] unless set with SetWarnings.
+func (root *Root) Warnings() *warnings.Collector {
+	if root.warnings == nil {
+		return warnings.Default
+	}
+	return root.warnings
+}
+
+// SetWarnings sets the collector of the warnings emitted while evaluating the
+// root. The warnings of the root config terramate.config.warnings.as_errors
+// attribute are promoted to errors in the collector.
+func (root *Root) SetWarnings(c *warnings.Collector) {
+	c.SetAsErrors(warningsAsErrors(&root.tree.Node))
+	root.warnings = c
+}
+
+// setWarningsAsErrors promotes to errors the warnings set in the
+// terramate.config.warnings.as_errors attribute of the root config, so the
+// ones emitted from now on abort the operation emitting them.
+func setWarningsAsErrors(rootcfg *hcl.Config) {
+	warnings.SetAsErrors(warningsAsErrors(rootcfg))
+}
+
+func warningsAsErrors(rootcfg *hcl.Config) []warnings.Code {
+	if rootcfg.Terramate != nil && rootcfg.Terramate.Config != nil && rootcfg.Terramate.Config.Warnings != nil {
+		return rootcfg.Terramate.Config.Warnings.AsErrors
+	}
+	return nil
+}
+
 // Tree returns the root configuration tree.
 func (root *Root) Tree() *Tree { return root.tree }
 
@@ -301,7 +377,7 @@ func (root *Root) StacksByTagsFilters(filters []string) (project.Paths, error) {
 		if !hasFilter || !tree.IsStack() {
 			return false
 		}
-		return filter.MatchTags(clauses, tree.Node.Stack.Tags)
+		return filter.MatchTags(clauses, tree.StackTags())
 	}).Paths(), nil
 }
 
@@ -314,7 +390,10 @@ func (root *Root) StackByID(id string) (*Stack, bool, error) {
 		return nil, false, nil
 	}
 	if len(stacks) > 1 {
-		printer.Stderr.Warnf("multiple stacks with the same ID %q found.", id)
+		err := warnings.Emit(warnings.DuplicatedStackID, fmt.Sprintf("multiple stacks with the same ID %q found.", id), nil)
+		if err != nil {
+			return nil, true, err
+		}
 	}
 	stack, err := stacks[0].Stack()
 	if err != nil {
@@ -357,7 +436,9 @@ func (root *Root) LoadSubTree(cfgdir project.Path) error {
 
 	if subtreeDir == rootdir {
 		// root configuration reloaded
+		gitMetadata, strict, changed := root.gitMetadata, root.strictDeterminism, root.changedStacks
 		*root = *NewRoot(root.Tree(), root.hclOpts...)
+		root.gitMetadata, root.strictDeterminism, root.changedStacks = gitMetadata, strict, changed
 		root.initRuntime()
 	}
 	return nil
@@ -378,6 +459,86 @@ func (root *Root) Runtime() project.Runtime {
 	return runtime
 }
 
+// Functions returns the functions available to the configuration of the
+// given host directory. The time and randomness sources are pinned as
+// configured and the functions are restricted if the project is evaluated
+// in sandboxed mode, each call being bounded by the sandbox limits.
+// Use [Root.NewEvalContext] for evaluating expressions.
+func (root *Root) Functions(dir string) map[string]function.Function {
+	funcs := root.functions(dir)
+	if sandbox, ok := root.tree.Node.Sandbox(); ok {
+		funcs = stdlib.Sandboxed(funcs, sandbox.NewLimiter())
+	}
+	return funcs
+}
+
+// NewEvalContext returns an evaluation context with the functions available
+// to the configuration of the given host directory. If the project is
+// evaluated in sandboxed mode, the evaluations of the context are bounded by
+// the sandbox limits.
+func (root *Root) NewEvalContext(dir string) *eval.Context {
+	funcs := root.functions(dir)
+	if sandbox, ok := root.tree.Node.Sandbox(); ok {
+		return sandbox.NewContext(funcs)
+	}
+	return eval.NewContext(funcs)
+}
+
+// VendorFunc returns the `tm_vendor` function vendoring into vendordir the
+// dependencies referenced from basedir. It's not available if the project is
+// evaluated in sandboxed mode, as it accesses the network and writes into the
+// project.
+func (root *Root) VendorFunc(basedir, vendordir project.Path, stream chan<- event.VendorRequest) function.Function {
+	name := stdlib.Name("vendor")
+	fn := stdlib.VendorFunc(basedir, vendordir, stream)
+	if sandbox, ok := root.tree.Node.Sandbox(); ok {
+		return stdlib.Sandboxed(map[string]function.Function{name: fn}, sandbox.NewLimiter())[name]
+	}
+	return fn
+}
+
+func (root *Root) functions(dir string) map[string]function.Function {
+	funcs := root.tree.Node.Functions().Functions(dir, root.tree.Node.Experiments())
+	return stdlib.Deterministic(funcs, root.Determinism())
+}
+
+// Environ returns the environment exposed to the configuration in the env
+// namespace, in the same format as os.Environ(). It's empty if the project
+// is evaluated in sandboxed mode.
+func (root *Root) Environ() []string {
+	if _, ok := root.tree.Node.Sandbox(); ok {
+		return nil
+	}
+	return os.Environ()
+}
+
+// dirOptions returns the parser options of the directories below the root,
+// which inherit the experiments, the sandbox and the strict mode of the root
+// configuration.
+func (root *Root) dirOptions(hclOpts []hcl.Option) []hcl.Option {
+	opts := []hcl.Option{hcl.WithExperiments(root.tree.Node.Experiments()...)}
+	if sandbox, ok := root.tree.Node.Sandbox(); ok {
+		opts = append(opts, hcl.WithSandbox(sandbox))
+	}
+	if root.tree.Node.Strict() {
+		opts = append(opts, hcl.WithStrictMode())
+	}
+	if suffixes := root.tree.Node.ConfigFileSuffixes(); len(suffixes) > 0 {
+		opts = append(opts, hcl.WithConfigFileSuffixes(suffixes...))
+	}
+	return append(opts, hclOpts...)
+}
+
+// isSubproject tells if the directory is an embedded project declared with
+// terramate.config.subprojects, which is not loaded as part of this project.
+func (root *Root) isSubproject(dir string) bool {
+	subprojects := root.tree.Node.Subprojects()
+	if len(subprojects) == 0 {
+		return false
+	}
+	return slices.Contains(subprojects, project.PrjAbsPath(root.HostDir(), dir))
+}
+
 func (root *Root) initRuntime() {
 	rootfs := cty.ObjectVal(map[string]cty.Value{
 		"absolute": cty.StringVal(root.HostDir()),
@@ -397,6 +558,9 @@ func (root *Root) initRuntime() {
 		"stacks":  stacksNs,
 		"version": cty.StringVal(terramate.Version()),
 	}
+	if root.gitMetadata != nil {
+		root.runtime["git"] = root.gitMetadata.value()
+	}
 }
 
 // HostDir is the node absolute directory in the host.
@@ -451,6 +615,89 @@ func (tree *Tree) IsStack() bool {
 	return tree.Node.Stack != nil
 }
 
+// StackTags returns the tags of the stack defined at the tree node, including
+// the tags inherited from the terramate.config.stack_defaults blocks of the
+// node, its parent directories and the template it extends. Tags inherited
+// from a parent directory can be removed by a subdirectory with the
+// remove_tags attribute.
+func (tree *Tree) StackTags() []string {
+	var tags []string
+	for _, node := range tree.inheritedDefaultsNodes() {
+		defaults := node.Node.StackDefaults()
+		tags = slices.DeleteFunc(tags, func(tag string) bool {
+			return slices.Contains(defaults.RemoveTags, tag)
+		})
+		tags = appendUniq(tags, defaults.Tags...)
+	}
+	if tree.IsStack() {
+		tags = appendUniq(tags, tree.Node.Stack.Tags...)
+	}
+	return tags
+}
+
+// stackDefaultsNodes returns the nodes declaring a stack_defaults block from
+// the root directory down to the tree node.
+func (tree *Tree) stackDefaultsNodes() []*Tree {
+	var nodes []*Tree
+	if tree.Parent != nil {
+		nodes = tree.Parent.stackDefaultsNodes()
+	}
+	if tree.Node.StackDefaults() != nil {
+		nodes = append(nodes, tree)
+	}
+	return nodes
+}
+
+// inheritedDefaultsNodes returns the nodes declaring a stack_defaults block
+// from the root directory down to the tree node, followed by the template
+// extended by the stack, if any.
+func (tree *Tree) inheritedDefaultsNodes() []*Tree {
+	nodes := tree.stackDefaultsNodes()
+	template, ok, err := tree.StackTemplate()
+	if err == nil && ok && template.Node.StackDefaults() != nil {
+		nodes = append(nodes, template)
+	}
+	return nodes
+}
+
+// StackTemplate returns the node of the template directory extended by the
+// stack defined at the tree node. It returns false if the node is not a stack
+// or the stack doesn't extend a template.
+func (tree *Tree) StackTemplate() (*Tree, bool, error) {
+	if !tree.IsStack() || tree.Node.Stack.Extends == "" {
+		return nil, false, nil
+	}
+	dir := stackTemplateDir(tree.Dir(), tree.Node.Stack.Extends)
+	template, ok := tree.Root().Lookup(dir)
+	if !ok {
+		return nil, false, errors.E(ErrStackInvalidExtends,
+			"stack %s extends template %s which does not exist", tree.Dir(), dir)
+	}
+	if template.IsStack() || template.IsInsideStack() {
+		return nil, false, errors.E(ErrStackInvalidExtends,
+			"stack %s extends template %s which is a stack or is inside a stack", tree.Dir(), dir)
+	}
+	return template, true, nil
+}
+
+// stackTemplateDir resolves the extends attribute of the stack at stackdir.
+// Relative paths are relative to the stack directory.
+func stackTemplateDir(stackdir project.Path, extends string) project.Path {
+	if path.IsAbs(extends) {
+		return project.NewPath(extends)
+	}
+	return stackdir.Join(extends)
+}
+
+func appendUniq(list []string, elems ...string) []string {
+	for _, elem := range elems {
+		if !slices.Contains(list, elem) {
+			list = append(list, elem)
+		}
+	}
+	return list
+}
+
 // IsInsideStack tells if current tree node is inside a parent stack.
 func (tree *Tree) IsInsideStack() bool {
 	if tree.Parent == nil {
@@ -580,7 +827,7 @@ func (root *Root) loadTree(parentTree *Tree, cfgdir string, hclOpts ...hcl.Optio
 		Str("dir", cfgdir).
 		Logger()
 
-	filesResult, err := fs.ListTerramateFiles(cfgdir)
+	filesResult, err := fs.ListTerramateFiles(cfgdir, root.tree.Node.ConfigFileSuffixes()...)
 	if err != nil {
 		return err
 	}
@@ -588,7 +835,7 @@ func (root *Root) loadTree(parentTree *Tree, cfgdir string, hclOpts ...hcl.Optio
 	for _, fname := range filesResult.Skipped {
 		if fname == terramate.SkipFilename {
 			logger.Debug().Msg("skip file found: skipping whole subtree")
-			tree := newSkippedTree(cfgdir)
+			tree := newSkippedTree(root.HostDir(), cfgdir)
 			tree.Parent = parentTree
 			parentTree.Children[filepath.Base(cfgdir)] = tree
 			return nil
@@ -596,39 +843,15 @@ func (root *Root) loadTree(parentTree *Tree, cfgdir string, hclOpts ...hcl.Optio
 	}
 
 	rootdir := root.HostDir()
-	rootOpts := append([]hcl.Option{hcl.WithExperiments(root.tree.Node.Experiments()...)}, hclOpts...)
+	rootOpts := root.dirOptions(hclOpts)
 
 	if cfgdir != rootdir {
 		tree := NewTree(cfgdir)
 
-		p, err := hcl.NewTerramateParser(
-			rootdir,
-			cfgdir,
-			rootOpts...,
-		)
+		cfg, err := parseDirConfig(rootdir, cfgdir, filesResult.TmFiles, rootOpts...)
 		if err != nil {
 			return err
 		}
-		for _, filename := range filesResult.TmFiles {
-			path := filepath.Join(cfgdir, filename)
-
-			data, err := os.ReadFile(path)
-			if err != nil {
-				return errors.E(err, "reading config file %q", path)
-			}
-
-			if err := p.AddFileContent(path, data); err != nil {
-				return err
-			}
-		}
-		cfg, err := p.ParseConfig()
-		if err != nil {
-			return err
-		}
-
-		if cfg.IsRootConfig() {
-			printer.Stderr.Warnf("root config found outside root dir: %s", cfgdir)
-		}
 
 		tree.Node = *cfg
 		tree.TerramateFiles = filesResult.TmFiles
@@ -662,6 +885,29 @@ func (root *Root) loadTree(parentTree *Tree, cfgdir string, hclOpts ...hcl.Optio
 		}
 
 		dir := filepath.Join(cfgdir, fname)
+		if root.isSubproject(dir) {
+			logger.Debug().Str("subproject", dir).Msg("skipping subproject")
+			continue
+		}
+		err = root.loadTree(parentTree, dir, rootOpts...)
+		if err != nil {
+			return errors.E(err, "loading from %s", dir)
+		}
+	}
+
+	if !root.tree.Node.FollowSymlinks() {
+		return nil
+	}
+
+	for _, fname := range filesResult.SymlinkDirs {
+		dir := filepath.Join(cfgdir, fname)
+		follow, err := canFollowSymlink(parentTree, dir)
+		if err != nil {
+			return err
+		}
+		if !follow || root.isSubproject(dir) {
+			continue
+		}
 		err = root.loadTree(parentTree, dir, rootOpts...)
 		if err != nil {
 			return errors.E(err, "loading from %s", dir)
@@ -670,21 +916,89 @@ func (root *Root) loadTree(parentTree *Tree, cfgdir string, hclOpts ...hcl.Optio
 	return nil
 }
 
+// canFollowSymlink tells if the symlinked directory dir, child of tree, can
+// be loaded. Links pointing outside of the project, or to the directory of
+// tree or of any of its parents, which would create a cycle, are ignored.
+func canFollowSymlink(tree *Tree, dir string) (bool, error) {
+	target, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false, errors.E(err, "resolving symlink %s", dir)
+	}
+	rootdir, err := filepath.EvalSymlinks(tree.RootDir())
+	if err != nil {
+		return false, errors.E(err, "resolving project root")
+	}
+	if target != rootdir && !isSubdir(rootdir, target) {
+		err := warnings.Emit(warnings.IgnoredSymlink, fmt.Sprintf("ignoring symlink %s: it points outside of the project", dir), nil)
+		return false, err
+	}
+	for node := tree; node != nil; node = node.Parent {
+		realdir, err := filepath.EvalSymlinks(node.HostDir())
+		if err != nil {
+			return false, errors.E(err, "resolving directory %s", node.HostDir())
+		}
+		if realdir == target || isSubdir(target, realdir) {
+			err := warnings.Emit(warnings.IgnoredSymlink, fmt.Sprintf("ignoring symlink %s: it points to a parent directory, creating a cycle", dir), nil)
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// parseDirConfig parses the Terramate files of a non-root directory.
+func parseDirConfig(rootdir, cfgdir string, tmFiles []string, opts ...hcl.Option) (*hcl.Config, error) {
+	p, err := hcl.NewTerramateParser(rootdir, cfgdir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filename := range tmFiles {
+		path := filepath.Join(cfgdir, filename)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.E(err, "reading config file %q", path)
+		}
+
+		if err := p.AddFileContent(path, data); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := p.ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.IsRootConfig() {
+		err := warnings.Emit(warnings.RootConfigLocation, fmt.Sprintf("root config found outside root dir: %s", cfgdir), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// TmgenExperimentName is the name of the experiment generating the .tmgen files.
+const TmgenExperimentName = experiments.Tmgen
+
 func processTmGenFiles(root *Root, parentTree *Tree, cfgdir string, files []string) error {
 	const tmgenSuffix = ".tmgen"
 
-	tmgenEnabled := root.HasExperiment("tmgen")
+	tmgenEnabled := root.HasExperiment(TmgenExperimentName)
 
 	// process all .tmgen files.
 	for _, fname := range files {
 		absFname := filepath.Join(cfgdir, fname)
 
 		if !tmgenEnabled {
-			printer.Stderr.Warn(
+			err := warnings.Emit(warnings.TmgenDisabled,
 				fmt.Sprintf("found %q but `tmgen` is not enabled in the `terramate.config.experiments` attribute",
 					absFname,
 				),
+				nil,
 			)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -777,12 +1091,67 @@ func NewTree(cfgdir string) *Tree {
 	}
 }
 
-func newSkippedTree(cfgdir string) *Tree {
+func newSkippedTree(rootdir, cfgdir string) *Tree {
 	t := NewTree(cfgdir)
 	t.Skipped = true
+	t.skippedStacks = findNestedStacks(rootdir, cfgdir)
 	return t
 }
 
+// findNestedStacks returns the stacks in the subdirectories of the skipped
+// directory cfgdir. The stack of cfgdir itself is not reported, as the skip
+// file is the way of disabling a single stack. The search is best effort:
+// unreadable directories and files with invalid syntax are ignored.
+func findNestedStacks(rootdir, cfgdir string) []project.Path {
+	var stacks []project.Path
+	var walk func(dir string)
+	walk = func(dir string) {
+		res, err := fs.ListTerramateFiles(dir)
+		if err != nil {
+			return
+		}
+		if dir != cfgdir {
+			if slices.Contains(res.Skipped, terramate.SkipFilename) {
+				return
+			}
+			if definesStack(dir, res.TmFiles) {
+				stacks = append(stacks, project.PrjAbsPath(rootdir, dir))
+			}
+		}
+		for _, name := range res.Dirs {
+			if !Skip(name) {
+				walk(filepath.Join(dir, name))
+			}
+		}
+	}
+	walk(cfgdir)
+	return stacks
+}
+
+// definesStack tells if the Terramate files of dir have a stack block.
+func definesStack(dir string, tmFiles []string) bool {
+	for _, fname := range tmFiles {
+		src, err := os.ReadFile(filepath.Join(dir, fname))
+		if err != nil {
+			continue
+		}
+		file, _ := hclsyntax.ParseConfig(src, fname, hhcl.InitialPos)
+		if file == nil {
+			continue
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type == "stack" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (tree *Tree) hasExperiment(name string) bool {
 	if tree.Parent != nil {
 		return tree.Parent.hasExperiment(name)
@@ -856,6 +1225,18 @@ func (root *Root) IsTargetsEnabled() bool {
 	return false
 }
 
+// CloudQueueConfig returns the configured `terramate.config.cloud.queue`
+// options. The zero values mean the defaults of the queue.
+func (root *Root) CloudQueueConfig() hcl.CloudQueueConfig {
+	if root.tree.Node.Terramate != nil &&
+		root.tree.Node.Terramate.Config != nil &&
+		root.tree.Node.Terramate.Config.Cloud != nil &&
+		root.tree.Node.Terramate.Config.Cloud.Queue != nil {
+		return *root.tree.Node.Terramate.Config.Cloud.Queue
+	}
+	return hcl.CloudQueueConfig{}
+}
+
 // Skip returns true if the given file/dir name should be ignored by Terramate.
 func Skip(name string) bool {
 	// assumes filename length > 0
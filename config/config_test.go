@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/madlambda/spells/assert"
 	"github.com/rs/zerolog"
 	"github.com/terramate-io/terramate"
@@ -195,6 +196,184 @@ func TestConfigLookup(t *testing.T) {
 	assert.EqualStrings(t, "/stacks/child/non-stack/stack", stacks[2].Dir().String())
 }
 
+func TestStackTagsInheritance(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"f:/team/terramate.tm:" + Terramate(
+			Config(
+				Block("stack_defaults",
+					Expr("tags", `["team-a", "legacy"]`),
+				),
+			),
+		).String(),
+		"s:/team/old",
+		"s:/team/new:tags=[\"app\"]",
+		"f:/team/new/terramate.tm:" + Terramate(
+			Config(
+				Block("stack_defaults",
+					Expr("tags", `["team-a", "v2"]`),
+					Expr("remove_tags", `["legacy"]`),
+				),
+			),
+		).String(),
+		"s:/team/new/child",
+		"s:/other:tags=[\"app\"]",
+	})
+
+	root := s.Config()
+	for dir, want := range map[string][]string{
+		"/team/old":       {"team-a", "legacy"},
+		"/team/new":       {"team-a", "v2", "app"},
+		"/team/new/child": {"team-a", "v2"},
+		"/other":          {"app"},
+	} {
+		st, err := config.LoadStack(root, project.NewPath(dir))
+		assert.NoError(t, err)
+		if diff := cmp.Diff(want, st.Tags); diff != "" {
+			t.Errorf("%s: unexpected tags (-want +got):\n%s", dir, diff)
+		}
+	}
+
+	stacks, err := root.StacksByTagsFilters([]string{"team-a:v2"})
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(stacks))
+	assert.EqualStrings(t, "/team/new", stacks[0].String())
+	assert.EqualStrings(t, "/team/new/child", stacks[1].String())
+}
+
+func TestStackDefaults(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"f:/infra/terramate.tm:" + Terramate(
+			Config(
+				Block("stack_defaults",
+					Str("description", "infra stack"),
+					Expr("watch", `["shared.hcl"]`),
+					Expr("after", `["network", "tag:base"]`),
+				),
+			),
+		).String(),
+		"f:/infra/shared.hcl:",
+		"s:/infra/network",
+		`s:/infra/app:description=app stack;after=["/other"]`,
+	})
+
+	root := s.Config()
+
+	network, err := config.LoadStack(root, project.NewPath("/infra/network"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "infra stack", network.Description)
+	if diff := cmp.Diff([]string{"tag:base"}, network.After); diff != "" {
+		t.Errorf("unexpected network after (-want +got):\n%s", diff)
+	}
+	assert.EqualInts(t, 1, len(network.Watch))
+	assert.EqualStrings(t, "/infra/shared.hcl", network.Watch[0].String())
+
+	app, err := config.LoadStack(root, project.NewPath("/infra/app"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "app stack", app.Description)
+	if diff := cmp.Diff([]string{"/other", "/infra/network", "tag:base"}, app.After); diff != "" {
+		t.Errorf("unexpected app after (-want +got):\n%s", diff)
+	}
+}
+
+func TestStackExtends(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"f:/terramate.tm:" + Terramate(
+			Config(
+				Block("stack_defaults",
+					Str("description", "root stack"),
+					Expr("tags", `["root"]`),
+				),
+			),
+		).String(),
+		"f:/templates/base/terramate.tm:" + Terramate(
+			Config(
+				Block("stack_defaults",
+					Str("description", "base stack"),
+					Expr("tags", `["base"]`),
+					Expr("watch", `["shared.hcl"]`),
+				),
+			),
+		).String(),
+		"f:/templates/base/shared.hcl:",
+		"s:/stacks/a:extends=/templates/base",
+		"s:/stacks/b:extends=../../templates/base;description=b stack;tags=[\"b\"]",
+		"s:/stacks/c",
+		"s:/stacks/missing:extends=/templates/missing",
+		"s:/stacks/stack:extends=/stacks/c",
+	})
+
+	root := s.Config()
+
+	a, err := config.LoadStack(root, project.NewPath("/stacks/a"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/templates/base", a.Extends.String())
+	assert.EqualStrings(t, "base stack", a.Description)
+	if diff := cmp.Diff([]string{"root", "base"}, a.Tags); diff != "" {
+		t.Errorf("unexpected a tags (-want +got):\n%s", diff)
+	}
+	assert.EqualInts(t, 1, len(a.Watch))
+	assert.EqualStrings(t, "/templates/base/shared.hcl", a.Watch[0].String())
+
+	b, err := config.LoadStack(root, project.NewPath("/stacks/b"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/templates/base", b.Extends.String())
+	assert.EqualStrings(t, "b stack", b.Description)
+	if diff := cmp.Diff([]string{"root", "base", "b"}, b.Tags); diff != "" {
+		t.Errorf("unexpected b tags (-want +got):\n%s", diff)
+	}
+
+	c, err := config.LoadStack(root, project.NewPath("/stacks/c"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "root stack", c.Description)
+	assert.EqualStrings(t, "", c.Extends.String())
+
+	_, err = config.LoadStack(root, project.NewPath("/stacks/missing"))
+	assert.IsError(t, err, errors.E(config.ErrStackInvalidExtends))
+
+	_, err = config.LoadStack(root, project.NewPath("/stacks/stack"))
+	assert.IsError(t, err, errors.E(config.ErrStackInvalidExtends))
+}
+
+func TestStackExecDir(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/default",
+		"s:stacks/infra:exec_dir=./infra",
+		"s:stacks/self:exec_dir=.",
+		"s:stacks/absolute:exec_dir=/stacks/infra",
+		"s:stacks/outside:exec_dir=../infra",
+		"s:stacks/file:exec_dir=file.txt",
+		"f:stacks/file/file.txt:",
+	})
+	root := s.Config()
+
+	st, err := config.LoadStack(root, project.NewPath("/stacks/default"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "", st.ExecDir.String())
+	assert.EqualStrings(t, st.HostDir(root), st.ExecHostDir(root))
+
+	st, err = config.LoadStack(root, project.NewPath("/stacks/infra"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/stacks/infra/infra", st.ExecDir.String())
+	assert.EqualStrings(t, filepath.Join(st.HostDir(root), "infra"), st.ExecHostDir(root))
+
+	st, err = config.LoadStack(root, project.NewPath("/stacks/self"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, st.HostDir(root), st.ExecHostDir(root))
+
+	for _, dir := range []string{"/stacks/absolute", "/stacks/outside", "/stacks/file"} {
+		_, err = config.LoadStack(root, project.NewPath(dir))
+		assert.IsError(t, err, errors.E(config.ErrStackInvalidExecDir))
+	}
+}
+
 func TestConfigStacksByPaths(t *testing.T) {
 	t.Parallel()
 	type testcase struct {
@@ -406,6 +585,48 @@ func TestConfigSkipdir(t *testing.T) {
 	assert.IsTrue(t, !found)
 }
 
+func TestConfigFileSuffixesAndSubprojects(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:project.tm:terramate {
+  config {
+    config_file_suffixes = [".terramate.hcl"]
+    subprojects          = ["/embedded"]
+  }
+}`,
+		`f:stack/stack.terramate.hcl:stack {
+  name = "custom"
+}`,
+		"f:stack/main.hcl:not a config file",
+		`f:embedded/terramate.tm:terramate {
+  required_version = "> 0.0.1"
+  config {
+  }
+}`,
+		"s:embedded/stack",
+	})
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(root.Stacks()), "stacks: %v", root.Stacks())
+	assert.EqualStrings(t, "/stack", root.Stacks()[0].String())
+
+	st, err := config.LoadStack(root, project.NewPath("/stack"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "custom", st.Name)
+
+	_, found := root.Lookup(project.NewPath("/embedded"))
+	assert.IsTrue(t, !found, "subproject must not be loaded")
+
+	subroot, rootdir, found, err := config.TryLoadConfig(filepath.Join(s.RootDir(), "embedded"), false)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found)
+	assert.EqualStrings(t, filepath.Join(s.RootDir(), "embedded"), rootdir)
+	assert.EqualInts(t, 1, len(subroot.Stacks()), "stacks: %v", subroot.Stacks())
+	assert.EqualStrings(t, "/stack", subroot.Stacks()[0].String())
+}
+
 func isStack(root *config.Root, dir string) bool {
 	return config.IsStack(root, filepath.Join(root.HostDir(), dir))
 }
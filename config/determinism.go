@@ -0,0 +1,40 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+
+	"github.com/terramate-io/terramate/stdlib"
+)
+
+// Environment variables pinning the time and randomness sources of the
+// functions. They take precedence over the terramate.config.generate block.
+const (
+	TimestampEnv  = "TM_TIMESTAMP"
+	RandomSeedEnv = "TM_RANDOM_SEED"
+)
+
+// SetStrictDeterminism sets if the functions depending on unpinned time and
+// randomness sources fail, which is the case when checking the generated code.
+func (root *Root) SetStrictDeterminism(strict bool) {
+	root.strictDeterminism = strict
+}
+
+// Determinism returns the time and randomness sources pinned by the
+// terramate.config.generate block or the environment.
+func (root *Root) Determinism() stdlib.Determinism {
+	det := stdlib.Determinism{Strict: root.strictDeterminism}
+	if tm := root.tree.Node.Terramate; tm != nil && tm.Config != nil && tm.Config.Generate != nil {
+		det.Timestamp = tm.Config.Generate.Timestamp
+		det.RandomSeed = tm.Config.Generate.RandomSeed
+	}
+	if ts := os.Getenv(TimestampEnv); ts != "" {
+		det.Timestamp = ts
+	}
+	if seed := os.Getenv(RandomSeedEnv); seed != "" {
+		det.RandomSeed = seed
+	}
+	return det
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "filter" {
-  content = <<-EOT
+  content = <<-EOF
 package filter // import "github.com/terramate-io/terramate/config/filter"
 
 Package filter provides helpers for filtering objects.
@@ -12,7 +12,7 @@ type Operation int
     const EQ Operation = iota + 1 ...
 type TagClause struct{ ... }
     func ParseTagClauses(filters ...string) (TagClause, bool, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-filter.ignore"
 }
@@ -0,0 +1,51 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"github.com/terramate-io/terramate/experiments"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GitMetadataExperimentName is the name of the experiment exposing the
+// terramate.git namespace.
+const GitMetadataExperimentName = experiments.GitMetadata
+
+// GitMetadata is the git metadata of the repository exposed in the
+// terramate.git namespace.
+type GitMetadata struct {
+	// Commit is the full SHA of the HEAD commit.
+	Commit string
+	// ShortCommit is the abbreviated SHA of the HEAD commit.
+	ShortCommit string
+	// Branch is the current branch, empty when HEAD is detached.
+	Branch string
+	// RemoteURL is the URL of the default remote, empty if not configured.
+	RemoteURL string
+	// Dirty tells if the repository has uncommitted or untracked files.
+	Dirty bool
+}
+
+// SetGitMetadata sets the git metadata exposed in the terramate.git namespace.
+// A nil metadata removes the namespace.
+func (root *Root) SetGitMetadata(md *GitMetadata) {
+	root.gitMetadata = md
+	root.initRuntime()
+}
+
+// GitMetadata returns the git metadata exposed in the terramate.git namespace,
+// if any.
+func (root *Root) GitMetadata() (*GitMetadata, bool) {
+	return root.gitMetadata, root.gitMetadata != nil
+}
+
+func (md *GitMetadata) value() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"commit":       cty.StringVal(md.Commit),
+		"short_commit": cty.StringVal(md.ShortCommit),
+		"branch":       cty.StringVal(md.Branch),
+		"remote_url":   cty.StringVal(md.RemoteURL),
+		"dirty":        cty.BoolVal(md.Dirty),
+	})
+}
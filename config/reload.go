@@ -0,0 +1,272 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/fs"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+)
+
+// ErrReload indicates that the configuration could not be reloaded.
+const ErrReload errors.Kind = "reloading configuration"
+
+// Reload incrementally reloads the configuration of the directories affected
+// by the changed paths, which can be files or directories, created, changed or
+// deleted. Only the directories containing the changed paths are parsed again,
+// new directories are loaded and deleted directories are removed from the tree.
+//
+// It returns the stacks invalidated by the changes, which are all the stacks
+// inside the changed directories, including the created and deleted ones.
+// Stacks are invalidated recursively because their configuration is
+// inherited from parent directories (eg.: globals).
+//
+// The tree is only updated if all the affected directories are successfully
+// parsed, so on errors the previous configuration is kept untouched and the
+// root can still be used.
+//
+// Changes to the terramate.config.experiments attribute are not applied to
+// already loaded directories and require a full load with [LoadRoot].
+//
+// Reload updates the root in place and does no locking, so it must not be
+// called concurrently with any other use of the root. The callers sharing the
+// root between goroutines must synchronize its reloads with its uses, eg.:
+// with a [sync.RWMutex]. The trees of the reloaded directories are replaced,
+// so the ones obtained before the reload must not be used after it.
+func (root *Root) Reload(changedPaths project.Paths) (project.Paths, error) {
+	targets := root.reloadTargets(changedPaths)
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	changedDirs := root.changedDirs(changedPaths)
+	invalidate := func(stacks List[*Tree], invalidated map[project.Path]struct{}) {
+		for _, stack := range stacks {
+			for _, dir := range changedDirs {
+				if stack.Dir().HasDirPrefix(dir.String()) {
+					invalidated[stack.Dir()] = struct{}{}
+					break
+				}
+			}
+		}
+	}
+
+	if root.changeDetectionEnabled {
+		root.tgTaskChan = make(chan *Tree, runtime.NumCPU()*3)
+		root.initTgWorkers()
+		defer close(root.tgTaskChan)
+	}
+
+	targetDirs := make([]string, len(targets))
+	for i, target := range targets {
+		targetDirs[i] = target.HostDir()
+	}
+
+	invalidated := map[project.Path]struct{}{}
+	reloaded := make([]*Tree, len(targets))
+	for i, target := range targets {
+		invalidate(target.stacks((*Tree).IsStack), invalidated)
+		tree, err := root.reloadNode(target, target.Parent, targetDirs)
+		if err != nil {
+			return nil, errors.E(ErrReload, err)
+		}
+		reloaded[i] = tree
+	}
+
+	// all directories were parsed successfully, then the tree is updated.
+	for _, tree := range reloaded {
+		tree.relinkChildren()
+		if tree.Parent == nil {
+			root.tree = tree
+		} else {
+			tree.Parent.Children[filepath.Base(tree.HostDir())] = tree
+		}
+		invalidate(tree.stacks((*Tree).IsStack), invalidated)
+	}
+	root.initRuntime()
+
+	paths := make(project.Paths, 0, len(invalidated))
+	for dir := range invalidated {
+		paths = append(paths, dir)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].String() < paths[j].String()
+	})
+	return paths, nil
+}
+
+// changedDirs returns the directories affected by the changed paths. Loaded
+// and existent directories are changed themselves, other paths change their
+// parent directory.
+func (root *Root) changedDirs(changedPaths project.Paths) project.Paths {
+	dirs := make(project.Paths, 0, len(changedPaths))
+	for _, changed := range changedPaths {
+		if _, found := root.Lookup(changed); found || isDir(changed.HostPath(root.HostDir())) {
+			dirs = append(dirs, changed)
+		} else {
+			dirs = append(dirs, changed.Dir())
+		}
+	}
+	return dirs
+}
+
+// reloadTargets returns the outermost loaded tree nodes containing the changed
+// paths.
+func (root *Root) reloadTargets(changedPaths project.Paths) []*Tree {
+	var targets []*Tree
+	for _, changed := range changedPaths {
+		dir := changed
+		if !isDir(changed.HostPath(root.HostDir())) {
+			dir = changed.Dir()
+		}
+
+		// deleted and new directories are handled by reloading the closest
+		// loaded parent directory.
+		node, found := root.Lookup(dir)
+		for (!found || !isDir(node.HostDir())) && dir.String() != "/" {
+			dir = dir.Dir()
+			node, found = root.Lookup(dir)
+		}
+		if !found {
+			node = root.tree
+		}
+		targets = append(targets, node)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return len(targets[i].HostDir()) < len(targets[j].HostDir())
+	})
+
+	var outermost []*Tree
+outer:
+	for _, target := range targets {
+		for _, other := range outermost {
+			if other == target || isSubdir(other.HostDir(), target.HostDir()) {
+				continue outer
+			}
+		}
+		outermost = append(outermost, target)
+	}
+	return outermost
+}
+
+// reloadNode parses the directory of the old node again into a new detached
+// node. Subdirectories which contain any of the target directories are
+// reloaded recursively, the others are reused from the old node.
+func (root *Root) reloadNode(old *Tree, parent *Tree, targetDirs []string) (*Tree, error) {
+	cfgdir := old.HostDir()
+	rootdir := root.HostDir()
+
+	filesResult, err := fs.ListTerramateFiles(cfgdir, root.tree.Node.ConfigFileSuffixes()...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fname := range filesResult.Skipped {
+		if fname == terramate.SkipFilename {
+			tree := newSkippedTree(rootdir, cfgdir)
+			tree.Parent = parent
+			return tree, nil
+		}
+	}
+
+	tree := NewTree(cfgdir)
+	tree.Parent = parent
+
+	var cfg *hcl.Config
+	if cfgdir == rootdir {
+		tree.root = root
+		cfg, err = parseRootDir(rootdir, root.hclOpts)
+	} else {
+		cfg, err = parseDirConfig(rootdir, cfgdir, filesResult.TmFiles, root.dirOptions(root.hclOpts)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tree.Node = *cfg
+	tree.TerramateFiles = filesResult.TmFiles
+	tree.OtherFiles = filesResult.OtherFiles
+	tree.TmGenFiles = filesResult.TmGenFiles
+	tree.TgRootFile = filesResult.TgRootFile
+
+	if filesResult.TgRootFile != "" && root.changeDetectionEnabled {
+		tree.terragruntModuleLoadFinished = make(chan struct{})
+		root.tgTaskChan <- tree
+	}
+
+	err = processTmGenFiles(root, tree, cfgdir, filesResult.TmGenFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fname := range filesResult.Dirs {
+		if Skip(fname) {
+			continue
+		}
+
+		dir := filepath.Join(cfgdir, fname)
+		if root.isSubproject(dir) {
+			continue
+		}
+		oldChild, ok := old.Children[fname]
+		if !ok {
+			// WHY: new children are loaded into the new node, keeping the
+			// old tree untouched in case of errors.
+			err := root.loadTree(tree, dir, root.hclOpts...)
+			if err != nil {
+				return nil, errors.E(err, "loading from %s", dir)
+			}
+			continue
+		}
+
+		child := oldChild
+		if oldChild.Skipped || containsAnyDir(dir, targetDirs) {
+			child, err = root.reloadNode(oldChild, tree, targetDirs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		tree.Children[fname] = child
+	}
+	return tree, nil
+}
+
+// relinkChildren sets the parent of the reused children to the reloaded node
+// and drops their cached stacks, which may inherit outdated configuration.
+func (tree *Tree) relinkChildren() {
+	for _, child := range tree.Children {
+		child.Parent = tree
+		child.muStacks.Lock()
+		child.stack = nil
+		child.muStacks.Unlock()
+		child.relinkChildren()
+	}
+}
+
+func isDir(path string) bool {
+	st, err := os.Stat(path)
+	return err == nil && st.IsDir()
+}
+
+func containsAnyDir(dir string, dirs []string) bool {
+	for _, other := range dirs {
+		if other == dir || isSubdir(dir, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSubdir tells if dir is a subdirectory of parent.
+func isSubdir(parent, dir string) bool {
+	return strings.HasPrefix(dir, parent+string(filepath.Separator))
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRootReload(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:/a",
+		"s:/a/b",
+		"s:/c",
+		`f:/a/globals.tm:globals {
+  a = 1
+}`,
+	})
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+
+	assertReload := func(changed []string, want string) {
+		t.Helper()
+		var paths project.Paths
+		for _, p := range changed {
+			paths = append(paths, project.NewPath(p))
+		}
+		got, err := root.Reload(paths)
+		assert.NoError(t, err)
+		assert.EqualStrings(t, want, joinPaths(got))
+	}
+
+	// changing a file invalidates the stacks of its directory and subdirectories.
+	s.RootEntry().CreateFile("a/globals.tm", "globals {\n  a = 2\n}\n")
+	assertReload([]string{"/a/globals.tm"}, "/a /a/b")
+
+	node, found := root.Lookup(project.NewPath("/a/b"))
+	assert.IsTrue(t, found && node.IsStack())
+	assert.IsTrue(t, node.Parent.Node.HasGlobals())
+
+	// new directories are loaded.
+	s.BuildTree([]string{"s:/d"})
+	assertReload([]string{"/d"}, "/d")
+	assert.EqualStrings(t, "/a /a/b /c /d", joinPaths(root.Stacks()))
+
+	// deleted directories are removed.
+	assert.NoError(t, os.RemoveAll(filepath.Join(s.RootDir(), "c")))
+	assertReload([]string{"/c/terramate.tm.hcl"}, "/c")
+	assert.EqualStrings(t, "/a /a/b /d", joinPaths(root.Stacks()))
+
+	// invalid configuration keeps the previous tree.
+	s.RootEntry().CreateFile("a/b/invalid.tm", "stack {")
+	_, err = root.Reload(project.Paths{project.NewPath("/a/b/invalid.tm")})
+	assert.IsTrue(t, errors.IsKind(err, config.ErrReload))
+	assert.EqualStrings(t, "/a /a/b /d", joinPaths(root.Stacks()))
+}
+
+func joinPaths(paths project.Paths) string {
+	var str string
+	for i, p := range paths {
+		if i > 0 {
+			str += " "
+		}
+		str += p.String()
+	}
+	return str
+}
@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	hhcl "github.com/terramate-io/hcl/v2"
@@ -14,7 +15,7 @@ import (
 	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/hcl/info"
 	"github.com/terramate-io/terramate/lets"
-	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -26,6 +27,8 @@ const (
 	ErrScriptInvalidTypeCommands errors.Kind = "invalid type for script.job.commands"
 	ErrScriptEmptyCmds           errors.Kind = "job command or commands evaluated to empty list"
 	ErrScriptInvalidCmdOptions   errors.Kind = "invalid options for script command"
+	ErrScriptInvalidArtifacts    errors.Kind = "invalid script.job.artifacts"
+	ErrScriptInvalidDependsOn    errors.Kind = "invalid script.job.depends_on_files"
 )
 
 // MaxScriptNameRunes defines the maximum number of runes allowed for a script name.
@@ -59,6 +62,23 @@ type ScriptJob struct {
 	Description string
 	Cmd         *ScriptCmd
 	Cmds        []*ScriptCmd
+
+	// Artifacts are the names of the artifacts produced by the job.
+	Artifacts []string
+
+	// Approval is set for jobs which pause the script until approved.
+	Approval *ScriptApproval
+
+	// DependsOnFiles are the paths of the files which must be up to date
+	// before the job runs. Relative paths are relative to the stack and
+	// absolute paths are relative to the project root.
+	DependsOnFiles []string
+}
+
+// ScriptApproval represents an evaluated approval block
+type ScriptApproval struct {
+	Message string
+	File    string
 }
 
 // Script represents an evaluated script block
@@ -81,8 +101,15 @@ func (es ScriptJob) Commands() []*ScriptCmd {
 	return es.Cmds
 }
 
-// EvalScript evaluates a script block using the provided evaluation context
-func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
+// EvalScript evaluates a script block using the provided evaluation context.
+//
+// The artifacts declared by the jobs are available to the commands of the
+// job declaring it and of the subsequent jobs as artifact.<name>, which
+// evaluates to a path inside artifactsDir.
+//
+// The args are the values given to the arguments declared by the script, as
+// returned by [ParseScriptArgs], which are available as script.args.<name>.
+func EvalScript(evalctx *eval.Context, script hcl.Script, artifactsDir string, args map[string]string) (Script, error) {
 	evaluatedScript := Script{
 		Range:  script.Range,
 		Labels: script.Labels,
@@ -91,6 +118,9 @@ func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
 	errs := errors.L()
 
 	localctx := evalctx.ChildContext()
+	if err := evalScriptArgs(localctx, script, args); err != nil {
+		return Script{}, err
+	}
 	localctx.SetNamespace("let", map[string]cty.Value{})
 
 	errs.Append(lets.Load(script.Lets, localctx))
@@ -104,9 +134,10 @@ func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
 		if len(name) > MaxScriptNameRunes {
 			name = name[:MaxScriptNameRunes]
 
-			printer.Stderr.Warn(
+			errs.Append(warnings.Emit(warnings.TruncatedField,
 				fmt.Sprintf("`script.name` exceeds the maximum allowed characters (%d): field truncated", MaxScriptNameRunes),
-			)
+				nil,
+			))
 		}
 		evaluatedScript.Name = name
 	}
@@ -117,25 +148,43 @@ func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
 		if len(desc) > MaxScriptDescRunes {
 			desc = desc[:MaxScriptDescRunes]
 
-			printer.Stderr.Warn(
+			errs.Append(warnings.Emit(warnings.TruncatedField,
 				fmt.Sprintf("`script.description` exceeds the maximum allowed characters (%d): field truncated", MaxScriptDescRunes),
-			)
+				nil,
+			))
 		}
 		evaluatedScript.Description = desc
 	}
 
+	artifacts := map[string]cty.Value{}
+	localctx.SetNamespace("artifact", artifacts)
+
 	for _, job := range script.Jobs {
 		evaluatedJob := ScriptJob{}
 
+		if job.Artifacts != nil {
+			names, err := evalScriptArtifacts(localctx, job.Artifacts.Expr, artifacts)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			for _, name := range names {
+				artifacts[name] = cty.StringVal(filepath.Join(artifactsDir, name))
+			}
+			localctx.SetNamespace("artifact", artifacts)
+			evaluatedJob.Artifacts = names
+		}
+
 		if job.Name != nil {
 			name, err := evalScriptStringField(localctx, job.Name.Expr, "script.job.name")
 			errs.Append(err)
 			if len(name) > MaxScriptNameRunes {
 				name = name[:MaxScriptNameRunes]
 
-				printer.Stderr.Warn(
+				errs.Append(warnings.Emit(warnings.TruncatedField,
 					fmt.Sprintf("`script.job.name` exceeds the maximum allowed characters (%d): field truncated", MaxScriptNameRunes),
-				)
+					nil,
+				))
 			}
 			evaluatedJob.Name = name
 		}
@@ -146,14 +195,39 @@ func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
 			if len(desc) > MaxScriptDescRunes {
 				desc = desc[:MaxScriptDescRunes]
 
-				printer.Stderr.Warn(
+				errs.Append(warnings.Emit(warnings.TruncatedField,
 					fmt.Sprintf("`script.job.description` exceeds the maximum allowed characters (%d): field truncated", MaxScriptDescRunes),
-				)
+					nil,
+				))
 			}
 
 			evaluatedJob.Description = desc
 		}
 
+		if job.DependsOnFiles != nil {
+			files, err := evalScriptDependsOnFiles(localctx, job.DependsOnFiles.Expr)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			evaluatedJob.DependsOnFiles = files
+		}
+
+		if job.Approval != nil {
+			approval := &ScriptApproval{}
+			if job.Approval.Message != nil {
+				msg, err := evalScriptStringField(localctx, job.Approval.Message.Expr, "script.job.approval.message")
+				errs.Append(err)
+				approval.Message = msg
+			}
+			if job.Approval.File != nil {
+				file, err := evalScriptStringField(localctx, job.Approval.File.Expr, "script.job.approval.file")
+				errs.Append(err)
+				approval.File = file
+			}
+			evaluatedJob.Approval = approval
+		}
+
 		if job.Command != nil {
 			expr := job.Command.Expr
 			v, err := localctx.Eval(expr)
@@ -227,6 +301,54 @@ func EvalScript(evalctx *eval.Context, script hcl.Script) (Script, error) {
 	return evaluatedScript, nil
 }
 
+func evalScriptArtifacts(evalctx *eval.Context, expr hhcl.Expression, declared map[string]cty.Value) ([]string, error) {
+	val, err := evalctx.Eval(expr)
+	if err != nil {
+		return nil, errors.E(ErrScriptInvalidArtifacts, expr.Range(), err)
+	}
+	names, err := hcl.ValueAsStringList(val)
+	if err != nil {
+		return nil, errors.E(ErrScriptInvalidArtifacts, expr.Range(), err)
+	}
+	seen := map[string]struct{}{}
+	for _, name := range names {
+		if !validArtifactName(name) {
+			return nil, errors.E(ErrScriptInvalidArtifacts, expr.Range(),
+				"artifact name %q must be a file name, without path separators", name)
+		}
+		_, dup := seen[name]
+		if _, ok := declared[name]; ok || dup {
+			return nil, errors.E(ErrScriptInvalidArtifacts, expr.Range(),
+				"artifact %q is declared more than once", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return names, nil
+}
+
+// validArtifactName tells if name can be used as the file name of an artifact.
+func validArtifactName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+func evalScriptDependsOnFiles(evalctx *eval.Context, expr hhcl.Expression) ([]string, error) {
+	val, err := evalctx.Eval(expr)
+	if err != nil {
+		return nil, errors.E(ErrScriptInvalidDependsOn, expr.Range(), err)
+	}
+	files, err := hcl.ValueAsStringList(val)
+	if err != nil {
+		return nil, errors.E(ErrScriptInvalidDependsOn, expr.Range(), err)
+	}
+	for _, file := range files {
+		if file == "" || strings.HasSuffix(file, "/") {
+			return nil, errors.E(ErrScriptInvalidDependsOn, expr.Range(),
+				"%q is not a file path", file)
+		}
+	}
+	return files, nil
+}
+
 func evalScriptStringField(evalctx *eval.Context, expr hhcl.Expression, name string) (string, error) {
 	f, err := EvalString(evalctx, expr, name)
 	if err != nil {
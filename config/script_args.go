@@ -0,0 +1,173 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Errors for indicating invalid script arguments.
+const (
+	ErrScriptArgs           errors.Kind = "invalid script arguments"
+	ErrScriptArgValidation  errors.Kind = "script argument validation failed"
+	ErrScriptArgInvalidType errors.Kind = "invalid type for script argument"
+)
+
+// ParseScriptArgs parses the command line arguments given to a script, in the
+// form --name=value or --name value. Boolean arguments can also be given as a
+// bare --name, which sets them to true. The values are returned unevaluated,
+// keyed by the argument name.
+func ParseScriptArgs(script hcl.Script, raw []string) (map[string]string, error) {
+	declared := map[string]*hcl.ScriptArg{}
+	for _, arg := range script.Args {
+		declared[arg.Name] = arg
+	}
+
+	args := map[string]string{}
+	for i := 0; i < len(raw); i++ {
+		token := raw[i]
+		if !strings.HasPrefix(token, "--") || token == "--" {
+			return nil, errors.E(ErrScriptArgs, "unexpected argument %q: arguments must be given as --name=value", token)
+		}
+		name, value, hasValue := strings.Cut(token[2:], "=")
+		arg, ok := declared[name]
+		if !ok {
+			return nil, errors.E(ErrScriptArgs, "script does not declare the argument %q", name)
+		}
+		if _, dup := args[name]; dup {
+			return nil, errors.E(ErrScriptArgs, "argument %q given more than once", name)
+		}
+		if !hasValue {
+			if arg.Type == cty.Bool && (i+1 == len(raw) || strings.HasPrefix(raw[i+1], "--")) {
+				value = "true"
+			} else if i+1 < len(raw) {
+				i++
+				value = raw[i]
+			} else {
+				return nil, errors.E(ErrScriptArgs, "missing value for argument %q", name)
+			}
+		}
+		args[name] = value
+	}
+	return args, nil
+}
+
+// evalScriptArgs evaluates the arguments declared by the script into the
+// script.args namespace. The given values are converted to the declared type
+// of the arguments, and the defaults are used for the ones not given.
+func evalScriptArgs(evalctx *eval.Context, script hcl.Script, given map[string]string) error {
+	errs := errors.L()
+	for name := range given {
+		if !scriptDeclaresArg(script, name) {
+			errs.Append(errors.E(ErrScriptArgs, script.Range, "script does not declare the argument %q", name))
+		}
+	}
+
+	values := map[string]cty.Value{}
+	for _, arg := range script.Args {
+		var (
+			val cty.Value
+			err error
+		)
+		if raw, ok := given[arg.Name]; ok {
+			val, err = parseScriptArgValue(arg, raw)
+		} else if arg.Default != nil {
+			val, err = evalctx.Eval(arg.Default.Expr)
+			if err != nil {
+				err = errors.E(ErrScriptArgs, arg.Default.Expr.Range(), err,
+					"evaluating the default of argument %q", arg.Name)
+			} else {
+				val, err = convertScriptArg(arg, val)
+			}
+		} else {
+			err = errors.E(ErrScriptArgs, arg.Range, "missing required argument %q", arg.Name)
+		}
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		values[arg.Name] = val
+	}
+	if err := errs.AsError(); err != nil {
+		return err
+	}
+
+	evalctx.SetNamespace("script", map[string]cty.Value{
+		"args": cty.ObjectVal(values),
+	})
+
+	for _, arg := range script.Args {
+		for _, validation := range arg.Validations {
+			errs.Append(validateScriptArg(evalctx, arg, validation))
+		}
+	}
+	return errs.AsError()
+}
+
+func parseScriptArgValue(arg *hcl.ScriptArg, raw string) (cty.Value, error) {
+	if arg.Type == cty.String {
+		return cty.StringVal(raw), nil
+	}
+	expr, diags := hclsyntax.ParseExpression([]byte(raw), "<arg "+arg.Name+">", hhcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, errors.E(ErrScriptArgInvalidType, diags,
+			"parsing the value of argument %q", arg.Name)
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, errors.E(ErrScriptArgInvalidType, diags,
+			"evaluating the value of argument %q", arg.Name)
+	}
+	return convertScriptArg(arg, val)
+}
+
+func convertScriptArg(arg *hcl.ScriptArg, val cty.Value) (cty.Value, error) {
+	converted, err := convert.Convert(val, arg.Type)
+	if err != nil {
+		return cty.NilVal, errors.E(ErrScriptArgInvalidType, arg.Range,
+			"argument %q must be %s: %s", arg.Name, arg.Type.FriendlyName(), err)
+	}
+	return converted, nil
+}
+
+func validateScriptArg(evalctx *eval.Context, arg *hcl.ScriptArg, validation *hcl.ScriptArgValidation) error {
+	cond := validation.Condition.Expr
+	val, err := evalctx.Eval(cond)
+	if err != nil {
+		return errors.E(ErrScriptArgValidation, cond.Range(), err,
+			"evaluating the validation of argument %q", arg.Name)
+	}
+	if val.Type() != cty.Bool || val.IsNull() {
+		return errors.E(ErrScriptArgValidation, cond.Range(),
+			"validation condition of argument %q must be a bool but %s given", arg.Name, val.Type().FriendlyName())
+	}
+	if val.True() {
+		return nil
+	}
+	if validation.ErrorMessage == nil {
+		return errors.E(ErrScriptArgValidation, cond.Range(), "invalid value for argument %q", arg.Name)
+	}
+	msg, err := EvalString(evalctx, validation.ErrorMessage.Expr, "script.arg.validation.error_message")
+	if err != nil {
+		return errors.E(ErrScriptArgValidation, validation.ErrorMessage.Expr.Range(), err)
+	}
+	return errors.E(ErrScriptArgValidation, cond.Range(), "argument %q: %s", arg.Name, msg)
+}
+
+func scriptDeclaresArg(script hcl.Script, name string) bool {
+	for _, arg := range script.Args {
+		if arg.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestScriptArgsEval(t *testing.T) {
+	t.Parallel()
+
+	labels := []string{"deploy"}
+
+	for _, tc := range []scriptTestcase{
+		{
+			name: "string arg given in the command line",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			args: []string{"--env=prod"},
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd: &config.ScriptCmd{
+							Args: []string{"echo", "prod"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "arg value given as the next argument",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			args: []string{"--env", "prod"},
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd: &config.ScriptCmd{
+							Args: []string{"echo", "prod"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "typed args are converted and defaults are used",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("replicas"),
+					Expr("type", "number"),
+				),
+				Block("arg",
+					Labels("dry"),
+					Expr("type", "bool"),
+				),
+				Block("arg",
+					Labels("regions"),
+					Expr("type", "list(string)"),
+					Expr("default", `["us-east-1"]`),
+				),
+				Lets(
+					Expr("regions", `tm_join(",", script.args.regions)`),
+				),
+				Block("job",
+					Expr("command", `["deploy", tm_tostring(script.args.replicas + 1), tm_tostring(script.args.dry), let.regions]`),
+				),
+			),
+			args: []string{"--replicas=2", "--dry"},
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd: &config.ScriptCmd{
+							Args: []string{"deploy", "3", "true", "us-east-1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "default evaluated in the stack context",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+					Expr("default", "global.env"),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			globals: map[string]cty.Value{
+				"env": cty.StringVal("staging"),
+			},
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd: &config.ScriptCmd{
+							Args: []string{"echo", "staging"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing required arg",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptArgs),
+		},
+		{
+			name: "unknown arg",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("command", `["echo"]`),
+				),
+			),
+			args:    []string{"--env=prod"},
+			wantErr: errors.E(config.ErrScriptArgs),
+		},
+		{
+			name: "arg value with wrong type",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("replicas"),
+					Expr("type", "number"),
+				),
+				Block("job",
+					Expr("command", `["echo"]`),
+				),
+			),
+			args:    []string{"--replicas=many"},
+			wantErr: errors.E(config.ErrScriptArgInvalidType),
+		},
+		{
+			name: "failed validation",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+					Block("validation",
+						Expr("condition", `tm_contains(["dev", "prod"], script.args.env)`),
+						Str("error_message", "env must be dev or prod"),
+					),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			args:    []string{"--env=qa"},
+			wantErr: errors.E(config.ErrScriptArgValidation),
+		},
+		{
+			name: "successful validation",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+					Block("validation",
+						Expr("condition", `tm_contains(["dev", "prod"], script.args.env)`),
+						Str("error_message", "env must be dev or prod"),
+					),
+				),
+				Block("job",
+					Expr("command", `["echo", script.args.env]`),
+				),
+			),
+			args: []string{"--env=dev"},
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd: &config.ScriptCmd{
+							Args: []string{"echo", "dev"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicated arg declaration",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+				),
+				Block("arg",
+					Labels("env"),
+				),
+				Block("job",
+					Expr("command", `["echo"]`),
+				),
+			),
+			wantErr: errors.E(hcl.ErrScriptInvalidArg),
+		},
+		{
+			name: "invalid arg type",
+			config: Script(
+				Labels(labels...),
+				Block("arg",
+					Labels("env"),
+					Expr("type", "text"),
+				),
+				Block("job",
+					Expr("command", `["echo"]`),
+				),
+			),
+			wantErr: errors.E(hcl.ErrScriptInvalidArg),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			testScriptEval(t, tc)
+		})
+	}
+}
@@ -370,7 +370,7 @@ func TestScriptLetsArelocalToTheirBlocks(t *testing.T) {
 	if len(rootTree.Node.Scripts) != 2 {
 		panic("test expects two scripts")
 	}
-	got, err := config.EvalScript(hclctx, *rootTree.Node.Scripts[0])
+	got, err := config.EvalScript(hclctx, *rootTree.Node.Scripts[0], "", nil)
 	assert.NoError(t, err)
 
 	want := config.Script{
@@ -388,6 +388,6 @@ func TestScriptLetsArelocalToTheirBlocks(t *testing.T) {
 	}
 
 	// must fail because let.A is not set
-	_, err = config.EvalScript(hclctx, *rootTree.Node.Scripts[1])
+	_, err = config.EvalScript(hclctx, *rootTree.Node.Scripts[1], "", nil)
 	errtest.AssertIsKind(t, err, config.ErrScriptSchema)
 }
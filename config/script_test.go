@@ -29,6 +29,7 @@ type scriptTestcase struct {
 	name    string
 	config  fmt.Stringer
 	globals map[string]cty.Value
+	args    []string
 	want    config.Script
 	wantErr error
 }
@@ -705,6 +706,183 @@ func TestScriptEval(t *testing.T) {
 			),
 			wantErr: errors.E(config.ErrScriptInvalidCmdOptions),
 		},
+		{
+			name: "artifacts handed off between jobs",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `["plan"]`),
+					Expr("command", `["terraform", "plan", "-out=${artifact.plan}"]`),
+				),
+				Block("job",
+					Expr("command", `["terraform", "apply", artifact.plan]`),
+				),
+			),
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd:       &config.ScriptCmd{Args: []string{"terraform", "plan", "-out=plan"}},
+						Artifacts: []string{"plan"},
+					},
+					{Cmd: &config.ScriptCmd{Args: []string{"terraform", "apply", "plan"}}},
+				},
+			},
+		},
+		{
+			name: "artifact consumed before being produced",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("command", `["terraform", "apply", artifact.plan]`),
+				),
+				Block("job",
+					Expr("artifacts", `["plan"]`),
+					Expr("command", `["terraform", "plan", "-out=${artifact.plan}"]`),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptSchema),
+		},
+		{
+			name: "artifact declared by more than one job",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `["plan"]`),
+					Command("echo", "hello"),
+				),
+				Block("job",
+					Expr("artifacts", `["plan"]`),
+					Command("echo", "hello"),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidArtifacts),
+		},
+		{
+			name: "artifact with a file name",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `["out.tfplan"]`),
+					Expr("command", `["terraform", "plan", "-out=${artifact["out.tfplan"]}"]`),
+				),
+			),
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd:       &config.ScriptCmd{Args: []string{"terraform", "plan", "-out=out.tfplan"}},
+						Artifacts: []string{"out.tfplan"},
+					},
+				},
+			},
+		},
+		{
+			name: "artifact name with a path separator",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `["plans/out.tfplan"]`),
+					Command("echo", "hello"),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidArtifacts),
+		},
+		{
+			name: "artifact name referencing the parent directory",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `[".."]`),
+					Command("echo", "hello"),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidArtifacts),
+		},
+		{
+			name: "empty artifact name",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("artifacts", `[""]`),
+					Command("echo", "hello"),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidArtifacts),
+		},
+		{
+			name: "job depending on files",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("depends_on_files", `["../network/outputs.json", "/shared/outputs.json"]`),
+					Command("terraform", "apply"),
+				),
+			),
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{
+						Cmd:            &config.ScriptCmd{Args: []string{"terraform", "apply"}},
+						DependsOnFiles: []string{"../network/outputs.json", "/shared/outputs.json"},
+					},
+				},
+			},
+		},
+		{
+			name: "job depending on a directory",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Expr("depends_on_files", `["../network/"]`),
+					Command("terraform", "apply"),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidDependsOn),
+		},
+		{
+			name: "approval job",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Command("terraform", "plan"),
+				),
+				Block("job",
+					Block("approval",
+						Expr("message", `"apply ${terramate.stack.path.absolute}?"`),
+						Str("file", "approved.txt"),
+					),
+				),
+				Block("job",
+					Command("terraform", "apply"),
+				),
+			),
+			want: config.Script{
+				Labels: labels,
+				Jobs: []config.ScriptJob{
+					{Cmd: &config.ScriptCmd{Args: []string{"terraform", "plan"}}},
+					{
+						Approval: &config.ScriptApproval{
+							Message: "apply /?",
+							File:    "approved.txt",
+						},
+					},
+					{Cmd: &config.ScriptCmd{Args: []string{"terraform", "apply"}}},
+				},
+			},
+		},
+		{
+			name: "approval message with invalid type",
+			config: Script(
+				Labels(labels...),
+				Block("job",
+					Block("approval",
+						Expr("message", `["apply"]`),
+					),
+				),
+			),
+			wantErr: errors.E(config.ErrScriptInvalidType),
+		},
 	}
 
 	for _, tcase := range tcases {
@@ -728,7 +906,7 @@ func testScriptEval(t *testing.T, tcase scriptTestcase) {
 	).String())
 
 	cfg, err := config.LoadRoot(tempdir, false)
-	if errors.IsAnyKind(tcase.wantErr, hcl.ErrHCLSyntax, hcl.ErrTerramateSchema) {
+	if errors.IsAnyKind(tcase.wantErr, hcl.ErrHCLSyntax, hcl.ErrTerramateSchema, hcl.ErrScriptInvalidArg) {
 		errtest.Assert(t, err, tcase.wantErr)
 		return
 	}
@@ -751,7 +929,12 @@ func testScriptEval(t *testing.T, tcase scriptTestcase) {
 	if len(rootTree.Node.Scripts) != 1 {
 		panic("test expects one script")
 	}
-	got, err := config.EvalScript(hclctx, *rootTree.Node.Scripts[0])
+	args, err := config.ParseScriptArgs(*rootTree.Node.Scripts[0], tcase.args)
+	if err != nil {
+		assert.IsError(t, err, tcase.wantErr)
+		return
+	}
+	got, err := config.EvalScript(hclctx, *rootTree.Node.Scripts[0], "", args)
 	assert.IsError(t, err, tcase.wantErr)
 	// ignoring info.Range comparisons for now
 	if diff := cmp.Diff(tcase.want, got, cmpopts.IgnoreUnexported(info.Range{})); diff != "" {
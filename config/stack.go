@@ -4,17 +4,22 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/terramate-io/terramate"
 	"github.com/terramate-io/terramate/config/tag"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/exp/slices"
 )
 
 type (
@@ -53,6 +58,15 @@ type (
 		// Watch is the list of files to be watched for changes.
 		Watch project.Paths
 
+		// Extends is the template directory the stack inherits from.
+		// It's empty if the stack doesn't extend a template.
+		Extends project.Path
+
+		// ExecDir is the directory where the commands of the stack are
+		// executed. It's empty if the commands are executed in the stack
+		// directory.
+		ExecDir project.Path
+
 		// IsChanged tells if this is a changed stack.
 		IsChanged bool
 	}
@@ -81,6 +95,16 @@ const (
 
 	// ErrStackInvalidWantedBy indicates the stack.wanted_by is invalid.
 	ErrStackInvalidWantedBy errors.Kind = "invalid stack.wanted_by entry"
+
+	// ErrStackInvalidExtends indicates the stack.extends is invalid.
+	ErrStackInvalidExtends errors.Kind = "invalid stack.extends attribute"
+
+	// ErrStackInvalidExecDir indicates the stack.exec_dir is invalid.
+	ErrStackInvalidExecDir errors.Kind = "invalid stack.exec_dir attribute"
+
+	// ErrStackInSkippedDir indicates a stack nested inside a directory
+	// skipped by a skip file, reported as a warning, is promoted to error.
+	ErrStackInSkippedDir errors.Kind = "stack nested inside skipped directory"
 )
 
 // NewStackFromHCL creates a new stack from raw configuration cfg.
@@ -95,6 +119,11 @@ func NewStackFromHCL(root string, cfg hcl.Config) (*Stack, error) {
 		return nil, errors.E(err, ErrStackInvalidWatch)
 	}
 
+	execDir, err := validateExecDir(root, cfg.AbsDir(), cfg.Stack.ExecDir)
+	if err != nil {
+		return nil, errors.E(err, ErrStackInvalidExecDir)
+	}
+
 	stack := &Stack{
 		Name:        name,
 		ID:          cfg.Stack.ID,
@@ -105,6 +134,7 @@ func NewStackFromHCL(root string, cfg hcl.Config) (*Stack, error) {
 		Wants:       cfg.Stack.Wants,
 		WantedBy:    cfg.Stack.WantedBy,
 		Watch:       watchFiles,
+		ExecDir:     execDir,
 		Dir:         project.PrjAbsPath(root, cfg.AbsDir()),
 	}
 	err = stack.Validate()
@@ -219,6 +249,24 @@ func (s *Stack) HostDir(root *Root) string {
 	return project.AbsPath(root.HostDir(), s.Dir.String())
 }
 
+// ExecHostDir returns the host directory where the commands of the stack are
+// executed, which is the stack.exec_dir directory if set, or the stack
+// directory otherwise.
+func (s *Stack) ExecHostDir(root *Root) string {
+	if s.ExecDir == (project.Path{}) {
+		return s.HostDir(root)
+	}
+	return project.AbsPath(root.HostDir(), s.ExecDir.String())
+}
+
+// IsDirectlyIn tells if the stack is at dir or directly inside it, which
+// selects the stacks of the generate blocks with visibility = "directory".
+// The directory of the stack template extended by the stack counts as the
+// stack directory.
+func (s *Stack) IsDirectlyIn(dir project.Path) bool {
+	return s.Dir == dir || s.Dir.Dir() == dir || s.Extends == dir
+}
+
 // RuntimeValues returns the runtime "terramate" namespace for the stack.
 func (s *Stack) RuntimeValues(root *Root) map[string]cty.Value {
 	stackpath := cty.ObjectVal(map[string]cty.Value{
@@ -252,12 +300,14 @@ func (s *Stack) RuntimeValues(root *Root) map[string]cty.Value {
 		})
 	}
 	stack := cty.ObjectVal(stackMapVals)
-	return map[string]cty.Value{
+	values := map[string]cty.Value{
 		"name":        cty.StringVal(s.Name),         // DEPRECATED
 		"path":        cty.StringVal(s.Dir.String()), // DEPRECATED
 		"description": cty.StringVal(s.Description),  // DEPRECATED
 		"stack":       stack,
+		"changed":     root.changedValue(s),
 	}
+	return values
 }
 
 // Sortable returns an implementation of stack which can be sorted by [config.List].
@@ -298,6 +348,27 @@ func ValidateWatchPaths(rootdir string, stackpath string, paths []string) (proje
 	return projectPaths, nil
 }
 
+// validateExecDir validates the stack.exec_dir attribute and returns its
+// project path. The directory must be relative to the stack and inside it, but
+// it's not required to exist as it may be created by code generation.
+func validateExecDir(rootdir string, stackpath string, dir string) (project.Path, error) {
+	if dir == "" {
+		return project.Path{}, nil
+	}
+	if path.IsAbs(dir) || filepath.IsAbs(dir) {
+		return project.Path{}, errors.E("path %s must be relative to the stack directory", dir)
+	}
+	abspath := filepath.Join(stackpath, filepath.FromSlash(dir))
+	if abspath != stackpath && !strings.HasPrefix(abspath, stackpath+string(filepath.Separator)) {
+		return project.Path{}, errors.E("path %s is outside the stack directory", dir)
+	}
+	st, err := os.Stat(abspath)
+	if err == nil && !st.IsDir() {
+		return project.Path{}, errors.E("path %s is not a directory", dir)
+	}
+	return project.PrjAbsPath(rootdir, abspath), nil
+}
+
 // StacksFromTrees converts a List[*Tree] into a List[*Stack].
 func StacksFromTrees(trees List[*Tree]) (List[*SortableStack], error) {
 	var stacks List[*SortableStack]
@@ -312,16 +383,20 @@ func StacksFromTrees(trees List[*Tree]) (List[*SortableStack], error) {
 }
 
 // LoadAllStacks loads all stacks inside the given rootdir.
+// All the invalid stacks, the stacks with duplicated IDs and the stacks nested
+// inside skipped directories are reported together in the returned error.
 func LoadAllStacks(root *Root, cfg *Tree) (List[*SortableStack], error) {
 	falsy := false
 	root.hasTerragruntStacks = &falsy
 	stacks := List[*SortableStack]{}
-	stacksIDs := map[string]*Stack{}
+	stacksIDs := map[string][]*Stack{}
+	errs := errors.L()
 
 	for _, stackNode := range cfg.Stacks() {
 		stack, err := stackNode.Stack()
 		if err != nil {
-			return nil, err
+			errs.Append(err)
+			continue
 		}
 
 		stacks = append(stacks, stack.Sortable())
@@ -336,21 +411,61 @@ func LoadAllStacks(root *Root, cfg *Tree) (List[*SortableStack], error) {
 		}
 
 		if stack.ID != "" {
-			if otherStack, ok := stacksIDs[strings.ToLower(stack.ID)]; ok {
-				return List[*SortableStack]{}, errors.E(ErrStackDuplicatedID,
-					"stack %q and %q have same ID %q",
-					stack.Dir,
-					otherStack.Dir,
-					stack.ID,
-				)
+			id := strings.ToLower(stack.ID)
+			stacksIDs[id] = append(stacksIDs[id], stack)
+		}
+	}
+
+	ids := make([]string, 0, len(stacksIDs))
+	for id := range stacksIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if dirs := distinctStackDirs(root, stacksIDs[id]); len(dirs) > 1 {
+			errs.Append(errors.E(ErrStackDuplicatedID,
+				"stacks %s have same ID %q", strings.Join(dirs, ", "), stacksIDs[id][0].ID))
+		}
+	}
+
+	for _, node := range cfg.AsList() {
+		for _, dir := range node.skippedStacks {
+			err := root.Warnings().Log(warnings.SkippedSubtree,
+				fmt.Sprintf("stack %s is ignored because %s has a %s file",
+					dir, node.Dir(), terramate.SkipFilename),
+				nil, nil)
+			if err != nil {
+				errs.Append(errors.E(ErrStackInSkippedDir, err))
 			}
-			stacksIDs[strings.ToLower(stack.ID)] = stack
 		}
 	}
 
+	if err := errs.AsError(); err != nil {
+		return List[*SortableStack]{}, err
+	}
 	return stacks, nil
 }
 
+// distinctStackDirs returns the quoted directories of the stacks, ignoring the
+// stacks discovered through symlinks which share the directory of a previous
+// stack, as they are the same stack.
+func distinctStackDirs(root *Root, stacks []*Stack) []string {
+	var dirs []string
+	realdirs := map[string]struct{}{}
+	for _, stack := range stacks {
+		realdir, err := filepath.EvalSymlinks(stack.HostDir(root))
+		if err != nil {
+			realdir = stack.HostDir(root)
+		}
+		if _, ok := realdirs[realdir]; ok {
+			continue
+		}
+		realdirs[realdir] = struct{}{}
+		dirs = append(dirs, fmt.Sprintf("%q", stack.Dir))
+	}
+	return dirs
+}
+
 // LoadStack a single stack from dir.
 func LoadStack(root *Root, dir project.Path) (*Stack, error) {
 	node, ok := root.Lookup(dir)
@@ -360,7 +475,73 @@ func LoadStack(root *Root, dir project.Path) (*Stack, error) {
 	if !node.IsStack() {
 		return nil, errors.E("config at %q is not a stack", dir)
 	}
-	return NewStackFromHCL(root.HostDir(), node.Node)
+	stack, err := NewStackFromHCL(root.HostDir(), node.Node)
+	if err != nil {
+		return nil, err
+	}
+	template, ok, err := node.StackTemplate()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		stack.Extends = template.Dir()
+	}
+	err = applyStackDefaults(root.HostDir(), node, stack)
+	if err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// applyStackDefaults merges the terramate.config.stack_defaults blocks of the
+// stack directory, its parent directories and the template it extends into the
+// stack. The description of the closest block, the template being the closest,
+// applies to stacks without description, the watch, after and before lists are
+// appended to the stack lists.
+func applyStackDefaults(rootdir string, tree *Tree, stack *Stack) error {
+	stack.Tags = tree.StackTags()
+
+	var description string
+	for _, node := range tree.inheritedDefaultsNodes() {
+		defaults := node.Node.StackDefaults()
+		if defaults.Description != "" {
+			description = defaults.Description
+		}
+
+		watchFiles, err := ValidateWatchPaths(rootdir, node.HostDir(), defaults.Watch)
+		if err != nil {
+			return errors.E(err, ErrStackInvalidWatch,
+				"terramate.config.stack_defaults.watch in %s", node.Dir())
+		}
+		for _, file := range watchFiles {
+			if !slices.Contains(stack.Watch, file) {
+				stack.Watch = append(stack.Watch, file)
+			}
+		}
+
+		stack.After = appendUniq(stack.After, defaultOrderPaths(node.Dir(), stack.Dir, defaults.After)...)
+		stack.Before = appendUniq(stack.Before, defaultOrderPaths(node.Dir(), stack.Dir, defaults.Before)...)
+	}
+	if stack.Description == "" {
+		stack.Description = description
+	}
+	return nil
+}
+
+// defaultOrderPaths resolves the relative paths of a stack_defaults ordering
+// list declared at dir. References to the stack itself are dropped.
+func defaultOrderPaths(dir project.Path, stackdir project.Path, paths []string) []string {
+	var resolved []string
+	for _, pathstr := range paths {
+		if !strings.HasPrefix(pathstr, "tag:") && !path.IsAbs(pathstr) {
+			pathstr = dir.Join(pathstr).String()
+		}
+		if pathstr == stackdir.String() {
+			continue
+		}
+		resolved = append(resolved, pathstr)
+	}
+	return resolved
 }
 
 // TryLoadStack tries to load a single stack from dir. It sets found as true in case
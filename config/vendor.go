@@ -0,0 +1,52 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package config
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+)
+
+// DefaultVendorDir is the vendor directory used when none is configured.
+const DefaultVendorDir = "/modules"
+
+// VendorDir returns the vendor directory configured with vendor.dir in the
+// .terramate directory or in the root configuration, in this order of
+// precedence, or [DefaultVendorDir] if none is configured.
+func (root *Root) VendorDir() (project.Path, error) {
+	checkVendorDir := func(dir string) (project.Path, error) {
+		if !path.IsAbs(dir) {
+			return project.Path{}, errors.E("vendorDir %s defined is not an absolute path", dir)
+		}
+		return project.NewPath(dir), nil
+	}
+
+	rootdir := root.HostDir()
+	dotTerramate := filepath.Join(rootdir, ".terramate")
+	dotTerramateInfo, err := os.Stat(dotTerramate)
+
+	if err == nil && dotTerramateInfo.IsDir() {
+		cfg, err := hcl.ParseDir(rootdir, dotTerramate, root.hclOpts...)
+		if err != nil {
+			return project.Path{}, errors.E(err, "parsing vendor dir configuration on .terramate")
+		}
+		if hasVendorDirConfig(cfg) {
+			return checkVendorDir(cfg.Vendor.Dir)
+		}
+	}
+	hclcfg := root.tree.Node
+	if hasVendorDirConfig(&hclcfg) {
+		return checkVendorDir(hclcfg.Vendor.Dir)
+	}
+	return project.NewPath(DefaultVendorDir), nil
+}
+
+func hasVendorDirConfig(cfg *hcl.Config) bool {
+	return cfg.Vendor != nil && cfg.Vendor.Dir != ""
+}
@@ -0,0 +1,22 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "writer" {
+  content = <<-EOF
+package writer // import "github.com/terramate-io/terramate/config/writer"
+
+Package writer implements the programmatic creation and update of the stack
+configuration, preserving the comments and the formatting of the existing
+configuration files.
+
+const ErrParse errors.Kind = "parsing configuration file" ...
+const ID Attribute = "id" ...
+type Attribute string
+type File struct{ ... }
+    func Open(path string) (*File, error)
+    func OpenStack(dir string) (*File, error)
+type ListAttribute string
+type Stack struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-writer.ignore"
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package writer // import \"github.com/terramate-io/terramate/config/writer\""
+  description = "package writer // import \"github.com/terramate-io/terramate/config/writer\"\n\nPackage writer implements the programmatic creation and update of the stack\nconfiguration, preserving the comments and the formatting of the existing\nconfiguration files.\n\nconst ErrParse errors.Kind = \"parsing configuration file\" ...\nconst ID Attribute = \"id\" ...\ntype Attribute string\ntype File struct{ ... }\n    func Open(path string) (*File, error)\n    func OpenStack(dir string) (*File, error)\ntype ListAttribute string\ntype Stack struct{ ... }"
+  tags        = ["config", "golang", "writer"]
+  id          = "21854ff8-60ec-4c33-b232-f3b4272e62c6"
+}
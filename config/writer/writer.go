@@ -0,0 +1,314 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package writer implements the programmatic creation and update of the stack
+// configuration, preserving the comments and the formatting of the existing
+// configuration files.
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/config/tag"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Errors returned when editing the configuration.
+const (
+	// ErrParse indicates that a configuration file could not be parsed.
+	ErrParse errors.Kind = "parsing configuration file"
+
+	// ErrNoStack indicates that the file has no stack block.
+	ErrNoStack errors.Kind = "stack block not found"
+
+	// ErrMultipleStacks indicates that more than one stack block was found.
+	ErrMultipleStacks errors.Kind = "multiple stack blocks found"
+
+	// ErrInvalidAttribute indicates that an attribute can't be read or
+	// written programmatically, eg.: its value is not a literal.
+	ErrInvalidAttribute errors.Kind = "invalid stack attribute"
+)
+
+const defaultFileMode = 0644
+
+// Attribute is a string attribute of the stack block.
+type Attribute string
+
+// ListAttribute is a list of strings attribute of the stack block.
+type ListAttribute string
+
+// Stack block attributes supported by the writer.
+const (
+	ID          Attribute = "id"
+	Name        Attribute = "name"
+	Description Attribute = "description"
+
+	Tags     ListAttribute = "tags"
+	After    ListAttribute = "after"
+	Before   ListAttribute = "before"
+	Wants    ListAttribute = "wants"
+	WantedBy ListAttribute = "wanted_by"
+	Watch    ListAttribute = "watch"
+)
+
+// File is a Terramate configuration file opened for editing.
+type File struct {
+	path string
+	mode os.FileMode
+	file *hclwrite.File
+}
+
+// Stack is the stack block of a configuration file.
+type Stack struct {
+	block *hclwrite.Block
+}
+
+// Open opens the configuration file at path for editing.
+// If the file doesn't exist, an empty file is returned, which is created when
+// saved.
+func Open(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.E(err, "reading %s", path)
+		}
+		return &File{
+			path: path,
+			mode: defaultFileMode,
+			file: hclwrite.NewEmptyFile(),
+		}, nil
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.E(err, "stating %s", path)
+	}
+	parsed, diags := hclwrite.ParseConfig(data, path, hhcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, errors.E(ErrParse, diags)
+	}
+	return &File{
+		path: path,
+		mode: st.Mode(),
+		file: parsed,
+	}, nil
+}
+
+// OpenStack opens the configuration file defining the stack block of the
+// given directory. If no file in the directory defines a stack, the
+// [stack.DefaultFilename] file is opened.
+func OpenStack(dir string) (*File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.E(err, "listing %s", dir)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() ||
+			(!strings.HasSuffix(name, ".tm") && !strings.HasSuffix(name, ".tm.hcl")) {
+			continue
+		}
+		f, err := Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if len(f.stackBlocks()) > 0 {
+			return f, nil
+		}
+	}
+	return Open(filepath.Join(dir, stack.DefaultFilename))
+}
+
+// Path returns the path of the file.
+func (f *File) Path() string { return f.path }
+
+// Stack returns the stack block of the file.
+func (f *File) Stack() (*Stack, error) {
+	blocks := f.stackBlocks()
+	switch len(blocks) {
+	case 0:
+		return nil, errors.E(ErrNoStack, "file %s", f.path)
+	case 1:
+		return &Stack{block: blocks[0]}, nil
+	default:
+		return nil, errors.E(ErrMultipleStacks, "file %s", f.path)
+	}
+}
+
+// EnsureStack returns the stack block of the file, appending a new one if
+// the file has no stack block.
+func (f *File) EnsureStack() (*Stack, error) {
+	st, err := f.Stack()
+	if err == nil || !errors.IsKind(err, ErrNoStack) {
+		return st, err
+	}
+	body := f.file.Body()
+	if len(body.Attributes()) > 0 || len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	return &Stack{block: body.AppendNewBlock("stack", nil)}, nil
+}
+
+// Bytes returns the contents of the file.
+func (f *File) Bytes() []byte {
+	return f.file.Bytes()
+}
+
+// Save writes the file, creating it and its parent directories if needed.
+func (f *File) Save() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return errors.E(err, "creating directory of %s", f.path)
+	}
+	if err := os.WriteFile(f.path, f.Bytes(), f.mode); err != nil {
+		return errors.E(err, "writing %s", f.path)
+	}
+	return nil
+}
+
+func (f *File) stackBlocks() []*hclwrite.Block {
+	var blocks []*hclwrite.Block
+	for _, block := range f.file.Body().Blocks() {
+		if block.Type() == "stack" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// Get returns the value of the attribute and false if it's not set.
+func (s *Stack) Get(attr Attribute) (string, bool, error) {
+	val, ok, err := s.value(string(attr))
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	if val.Type() != cty.String || val.IsNull() {
+		return "", false, errors.E(ErrInvalidAttribute,
+			"stack.%s must be a string but %s given", attr, val.Type().FriendlyName())
+	}
+	return val.AsString(), true, nil
+}
+
+// Set sets the value of the attribute.
+func (s *Stack) Set(attr Attribute, value string) {
+	s.block.Body().SetAttributeValue(string(attr), cty.StringVal(value))
+}
+
+// Unset removes the attribute.
+func (s *Stack) Unset(attr Attribute) {
+	s.block.Body().RemoveAttribute(string(attr))
+}
+
+// List returns the values of the list attribute.
+func (s *Stack) List(attr ListAttribute) ([]string, error) {
+	val, ok, err := s.value(string(attr))
+	if err != nil || !ok {
+		return nil, err
+	}
+	if !val.Type().IsListType() && !val.Type().IsSetType() && !val.Type().IsTupleType() {
+		return nil, errors.E(ErrInvalidAttribute,
+			"stack.%s must be a list of strings but %s given", attr, val.Type().FriendlyName())
+	}
+	var values []string
+	for it := val.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if elem.Type() != cty.String || elem.IsNull() {
+			return nil, errors.E(ErrInvalidAttribute,
+				"stack.%s must be a list of strings but has a %s element", attr, elem.Type().FriendlyName())
+		}
+		values = append(values, elem.AsString())
+	}
+	return values, nil
+}
+
+// SetList sets the values of the list attribute, removing the attribute if
+// no value is given. The values are deduplicated and sorted.
+func (s *Stack) SetList(attr ListAttribute, values []string) error {
+	values = dedup(values)
+	if attr == Tags {
+		errs := errors.L()
+		for _, t := range values {
+			errs.Append(tag.Validate(t))
+		}
+		if err := errs.AsError(); err != nil {
+			return err
+		}
+	}
+	if len(values) == 0 {
+		s.block.Body().RemoveAttribute(string(attr))
+		return nil
+	}
+	elems := make([]cty.Value, len(values))
+	for i, v := range values {
+		elems[i] = cty.StringVal(v)
+	}
+	s.block.Body().SetAttributeValue(string(attr), cty.ListVal(elems))
+	return nil
+}
+
+// Add adds the values to the list attribute.
+func (s *Stack) Add(attr ListAttribute, values ...string) error {
+	current, err := s.List(attr)
+	if err != nil {
+		return err
+	}
+	return s.SetList(attr, append(current, values...))
+}
+
+// Remove removes the values from the list attribute.
+func (s *Stack) Remove(attr ListAttribute, values ...string) error {
+	current, err := s.List(attr)
+	if err != nil {
+		return err
+	}
+	remove := map[string]bool{}
+	for _, v := range values {
+		remove[v] = true
+	}
+	var kept []string
+	for _, v := range current {
+		if !remove[v] {
+			kept = append(kept, v)
+		}
+	}
+	return s.SetList(attr, kept)
+}
+
+// value evaluates the attribute, which must be a literal.
+func (s *Stack) value(name string) (cty.Value, bool, error) {
+	attr := s.block.Body().GetAttribute(name)
+	if attr == nil {
+		return cty.NilVal, false, nil
+	}
+	src := attr.Expr().BuildTokens(nil).Bytes()
+	expr, diags := hclsyntax.ParseExpression(src, "stack."+name, hhcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, false, errors.E(ErrInvalidAttribute, diags)
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, false, errors.E(ErrInvalidAttribute, diags,
+			"stack.%s must be a literal", name)
+	}
+	return val, true, nil
+}
+
+func dedup(values []string) []string {
+	seen := map[string]bool{}
+	var res []string
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		res = append(res, v)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package writer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/tag"
+	"github.com/terramate-io/terramate/config/writer"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestWriterUpdatesStackPreservingComments(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:stack/stack.tm.hcl:# managed by the platform team
+stack {
+  # the name shown in the cloud
+  name = "app"
+  tags = ["b", "a"]
+
+  after = ["/network"]
+}
+
+globals {
+  env = "prod" # the environment
+}
+`,
+	})
+
+	f, err := writer.OpenStack(filepath.Join(s.RootDir(), "stack"))
+	assert.NoError(t, err)
+	st, err := f.Stack()
+	assert.NoError(t, err)
+
+	name, ok, err := st.Get(writer.Name)
+	assert.NoError(t, err)
+	assert.IsTrue(t, ok)
+	assert.EqualStrings(t, "app", name)
+
+	st.Set(writer.Description, "the app")
+	assert.NoError(t, st.Add(writer.Tags, "c", "a"))
+	assert.NoError(t, st.Remove(writer.After, "/network"))
+	assert.NoError(t, st.Add(writer.Before, "/monitoring"))
+	assert.NoError(t, f.Save())
+
+	test.AssertFileContentEquals(t, filepath.Join(s.RootDir(), "stack", "stack.tm.hcl"), `# managed by the platform team
+stack {
+  # the name shown in the cloud
+  name = "app"
+  tags = ["a", "b", "c"]
+
+  description = "the app"
+  before      = ["/monitoring"]
+}
+
+globals {
+  env = "prod" # the environment
+}
+`)
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	cfgStack, err := config.LoadStack(root, project.NewPath("/stack"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "the app", cfgStack.Description)
+	assert.EqualInts(t, 3, len(cfgStack.Tags))
+}
+
+func TestWriterCreatesStack(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:stack/globals.tm:globals {
+  a = 1
+}
+`,
+	})
+
+	f, err := writer.OpenStack(filepath.Join(s.RootDir(), "stack"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, filepath.Join(s.RootDir(), "stack", "stack.tm.hcl"), f.Path())
+
+	_, err = f.Stack()
+	assert.IsTrue(t, errors.IsKind(err, writer.ErrNoStack))
+
+	st, err := f.EnsureStack()
+	assert.NoError(t, err)
+	st.Set(writer.Name, "new")
+	assert.NoError(t, st.SetList(writer.Tags, []string{"x"}))
+	assert.NoError(t, f.Save())
+
+	test.AssertFileContentEquals(t, f.Path(), `stack {
+  name = "new"
+  tags = ["x"]
+}
+`)
+}
+
+func TestWriterErrors(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:stack/stack.tm.hcl:stack {
+  tags = global.tags
+}
+`,
+	})
+
+	f, err := writer.OpenStack(filepath.Join(s.RootDir(), "stack"))
+	assert.NoError(t, err)
+	st, err := f.Stack()
+	assert.NoError(t, err)
+
+	err = st.Add(writer.Tags, "a")
+	assert.IsTrue(t, errors.IsKind(err, writer.ErrInvalidAttribute), "unexpected error: %v", err)
+
+	err = st.SetList(writer.Tags, []string{"Invalid"})
+	assert.IsTrue(t, errors.IsKind(err, tag.ErrInvalidTag), "unexpected error: %v", err)
+}
@@ -1,9 +1,9 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cloud" {
-  content = <<-EOT
+  content = <<-EOF
 cloud
-EOT
+EOF
 
   filename = "${path.module}/mock-cloud.ignore"
 }
@@ -0,0 +1,97 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud/api/drift"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/testserver/cloudstore"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestCloudOrgSelection(t *testing.T) {
+	t.Parallel()
+
+	store, defaultOrg, err := cloudstore.LoadDatastore(testserverJSONFile)
+	assert.NoError(t, err)
+
+	const otherOrg = "other-org"
+	store.UpsertOrg(cloudstore.Org{
+		UUID:        "0e4b7f6b-7d1b-4c2a-9f68-3c1b8f0a2e51",
+		Name:        otherOrg,
+		DisplayName: "Other",
+		Status:      "active",
+		Members: []cloudstore.Member{
+			{
+				UserUUID: store.MustGetUser("batman@terramate.io").UUID,
+				Role:     "member",
+				Status:   "active",
+			},
+		},
+	})
+	_, err = store.UpsertStack(store.MustOrgByName(otherOrg).UUID, cloudstore.Stack{
+		Stack: resources.Stack{
+			Repository: "github.com/terramate-io/terramate",
+			MetaID:     "stack",
+			Target:     "default",
+		},
+		State: cloudstore.StackState{
+			DriftStatus: drift.OK,
+		},
+	})
+	assert.NoError(t, err)
+	addr := startFakeTMCServer(t, store)
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{"s:stack:id=stack"})
+	git := s.Git()
+	git.SetRemoteURL("origin", "git@github.com:terramate-io/terramate.git")
+	git.CommitAll("all stacks committed")
+
+	env := RemoveEnv(os.Environ(), "CI", "TM_CLOUD_ORGANIZATION")
+	env = append(env, "TMC_API_URL=http://"+addr, "CI=")
+	tm := NewCLI(t, s.RootDir(), env...)
+	tmStack := tm
+	tmStack.Chdir = filepath.Join(s.RootDir(), "stack")
+
+	AssertRunResult(t, tmStack.Run("cloud", "drift", "show"), RunExpected{
+		Status:      1,
+		StderrRegex: "select one with `terramate cloud org switch`",
+	})
+
+	AssertRunResult(t, tm.Run("cloud", "org", "list"), RunExpected{
+		StdoutRegexes: []string{
+			`  ` + defaultOrg + ` \(.*\): active`,
+			`  ` + otherOrg + ` \(Other\): active`,
+		},
+	})
+
+	AssertRunResult(t, tm.Run("cloud", "org", "switch", "unknown"), RunExpected{
+		Status:      1,
+		StderrRegex: `You are not a member of organization "unknown"`,
+	})
+
+	AssertRunResult(t, tm.Run("cloud", "org", "switch", otherOrg), RunExpected{
+		Stdout: "Switched to organization " + otherOrg + ".\n",
+	})
+	AssertRunResult(t, tm.Run("cloud", "org", "list"), RunExpected{
+		StdoutRegex: `\* ` + otherOrg + ` \(Other\): active`,
+	})
+
+	AssertRunResult(t, tmStack.Run("cloud", "drift", "show"), RunExpected{
+		Stdout: "Stack /stack is not drifted.\n",
+	})
+
+	tmWithEnv := NewCLI(t, filepath.Join(s.RootDir(), "stack"), append(env, "TM_CLOUD_ORGANIZATION="+otherOrg)...)
+	AssertRunResult(t, tmWithEnv.Run("--org", defaultOrg, "cloud", "drift", "show"), RunExpected{
+		Status:      1,
+		StderrRegex: "Stack /stack was not yet synced with the Terramate Cloud",
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud/api/drift"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/testserver/cloudstore"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestCloudSyncInventory(t *testing.T) {
+	t.Parallel()
+
+	store, defaultOrg, err := cloudstore.LoadDatastore(testserverJSONFile)
+	assert.NoError(t, err)
+	_, err = store.UpsertStack(store.MustOrgByName(defaultOrg).UUID, cloudstore.Stack{
+		Stack: resources.Stack{
+			Repository:    "github.com/terramate-io/terramate",
+			DefaultBranch: "main",
+			Path:          "/old/s2",
+			MetaID:        "s2",
+		},
+		State: cloudstore.StackState{
+			DriftStatus: drift.Drifted,
+		},
+	})
+	assert.NoError(t, err)
+	addr := startFakeTMCServer(t, store)
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:s1:id=S1;tags=["app"];description=first stack`,
+		`s:s2:id=s2;after=["/s1"]`,
+		`s:s3:id=s3;after=["/s1", "/s2", "/noid"]`,
+		"s:noid",
+	})
+	git := s.Git()
+	git.SetRemoteURL("origin", "git@github.com:terramate-io/terramate.git")
+	git.CommitAll("all stacks committed")
+
+	env := RemoveEnv(os.Environ(), "CI")
+	env = append(env, "TMC_API_URL=http://"+addr, "CI=")
+	env = append(env, "TM_CLOUD_ORGANIZATION="+defaultOrg)
+
+	tm := NewCLI(t, s.RootDir(), env...)
+	AssertRunResult(t, tm.Run("cloud", "sync", "inventory"), RunExpected{
+		Stdout:      "Synced 3 stacks to Terramate Cloud.\n",
+		StderrRegex: "skipping stack /noid without an ID",
+	})
+
+	org := store.MustOrgByName(defaultOrg)
+	stack := func(path, id string) resources.Stack {
+		return resources.Stack{
+			Repository:    "github.com/terramate-io/terramate",
+			DefaultBranch: "main",
+			Path:          path,
+			MetaID:        id,
+			MetaName:      id,
+		}
+	}
+	want := []resources.Stack{
+		stack("/s1", "s1"),
+		stack("/s2", "s2"),
+		stack("/s3", "s3"),
+	}
+	want[0].MetaDescription = "first stack"
+	want[0].MetaTags = []string{"app"}
+	var got []resources.Stack
+	for _, st := range want {
+		synced, _, found := store.GetStackByMetaID(org, st.MetaID, "")
+		if !found {
+			t.Fatalf("stack %s not upserted", st.MetaID)
+		}
+		got = append(got, synced.Stack)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected inventory: %s", diff)
+	}
+
+	s2, _, _ := store.GetStackByMetaID(org, "s2", "")
+	if s2.State.DriftStatus != drift.Drifted {
+		t.Fatalf("state of the existing stack not kept: %s", s2.State.DriftStatus)
+	}
+}
@@ -1,11 +1,11 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "helper" {
-  content = <<-EOT
+  content = <<-EOF
 helper is a utility command that implements behaviors that are useful when
 testing terramate run features in a way that reduces dependencies on the
 environment to run the tests.
-EOT
+EOF
 
   filename = "${path.module}/mock-helper.ignore"
 }
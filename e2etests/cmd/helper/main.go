@@ -55,6 +55,8 @@ func main() {
 		os.Exit(1)
 	case "exit":
 		exit(os.Args[2])
+	case "fail":
+		fail(os.Args[2:]...)
 	case "hang":
 		hang()
 	case "sleep":
@@ -67,6 +69,8 @@ func main() {
 		cat(os.Args[2])
 	case "rm":
 		rm(os.Args[2])
+	case "write":
+		write(os.Args[2], os.Args[3])
 	case "tempdir":
 		tempDir()
 	case "stack-abs-path":
@@ -116,6 +120,12 @@ func exit(exitCodeStr string) {
 	os.Exit(code)
 }
 
+// fail prints the args on stderr and exits with 1.
+func fail(args ...string) {
+	fmt.Fprintln(os.Stderr, strings.Join(args, " "))
+	os.Exit(1)
+}
+
 // env sends os.Environ() on stdout and exits.
 func env(rootdir string, names ...string) {
 	if len(names) > 0 {
@@ -157,6 +167,12 @@ func cat(fname string) {
 	fmt.Printf("%s", string(bytes))
 }
 
+// write writes the content to the given file.
+func write(fname, content string) {
+	err := os.WriteFile(fname, []byte(content), 0644)
+	checkerr(err)
+}
+
 // rm remove the given path.
 func rm(fname string) {
 	err := os.RemoveAll(fname)
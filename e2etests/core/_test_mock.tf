@@ -1,9 +1,9 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "core" {
-  content = <<-EOT
+  content = <<-EOF
 core
-EOT
+EOF
 
   filename = "${path.module}/mock-core.ignore"
 }
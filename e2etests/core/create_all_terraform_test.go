@@ -176,3 +176,27 @@ func TestCreateWithAllTerraformDetectModulesInsideStacks(t *testing.T) {
 		},
 	)
 }
+
+func TestCreateWithAllTerraformDetectsTerraformStacks(t *testing.T) {
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:platform/components.tfstack.hcl:` + Block("component",
+			Labels("vpc"),
+			Str("source", "./modules/vpc"),
+		).String(),
+		`f:platform/deployments.tfdeploy.hcl:` + Block("deployment",
+			Labels("production"),
+			Expr("inputs", "{}"),
+		).String(),
+		`f:platform/modules/vpc/main.tf:# empty module`,
+	})
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t,
+		tm.Run("create", "--all-terraform"),
+		RunExpected{
+			Stdout: "Created stack /platform\n",
+		},
+	)
+	_, err := os.Lstat(filepath.Join(s.RootDir(), "platform", stack.DefaultFilename))
+	assert.NoError(t, err)
+}
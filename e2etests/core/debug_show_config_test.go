@@ -0,0 +1,88 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDebugShowConfig(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		layout []string
+		wd     string
+		want   RunExpected
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:   "stack without config",
+			layout: []string{"s:stack"},
+			want: RunExpected{
+				Stdout: "\nstack \"/stack\":\n",
+			},
+		},
+		{
+			name: "globals, generate blocks and env with provenance",
+			layout: []string{
+				"s:stack",
+				"s:other",
+				`f:globals.tm:globals {
+  a = 1
+  b = "root"
+}`,
+				`f:terramate.tm:terramate {
+  config {
+    run {
+      env {
+        FOO = global.b
+      }
+    }
+  }
+}`,
+				`f:stack/globals.tm:globals {
+  a = 2
+}`,
+				`f:stack/gen.tm:generate_hcl "main.tf" {
+  content {
+    a = global.a
+  }
+}`,
+			},
+			wd: "stack",
+			want: RunExpected{
+				Stdout: `
+stack "/stack":
+	globals:
+		global.a = 2
+			defined at /stack/globals.tm:2
+			overrides /globals.tm:2
+		global.b = "root"
+			defined at /globals.tm:3
+	generate:
+		main.tf
+			defined at /stack/gen.tm:1
+	env:
+		FOO = "root"
+			defined at /terramate.tm:5
+`,
+			},
+		},
+	} {
+		tcase := tc
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree(tcase.layout)
+			ts := NewCLI(t, project.AbsPath(s.RootDir(), tcase.wd))
+			AssertRunResult(t, ts.Run("debug", "show", "config"), tcase.want)
+		})
+	}
+}
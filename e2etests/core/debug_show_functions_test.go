@@ -0,0 +1,30 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDebugShowFunctions(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("debug", "show", "functions"), RunExpected{
+		StdoutRegexes: []string{
+			`(?m)^tm_upper\(str string\) string$`,
+			`(?m)^tm_ternary\(cond bool, val1 any, val2 any\) any$`,
+		},
+		NoStdoutRegex: `(?m)^tm_tomlencode`,
+	})
+	AssertRunResult(t, tm.Run("debug", "show", "functions", "tm_upper"), RunExpected{
+		StdoutRegex:   `^tm_upper\(str string\) string\n`,
+		NoStdoutRegex: `(?m)^tm_ternary`,
+	})
+}
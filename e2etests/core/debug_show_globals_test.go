@@ -258,3 +258,41 @@ stack "/stacks/stack-name":
 		})
 	}
 }
+
+func TestStacksGlobalsTrace(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:envs/prod/app",
+		"s:envs/dev/app",
+		`f:globals.tm:globals {
+  team = {
+    name  = "platform"
+    owner = "ops"
+  }
+}`,
+		`f:envs/prod/globals.tm:globals "team" {
+  name = "prod-platform"
+}`,
+		`f:envs/prod/app/globals.tm:globals "team" {
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("debug", "show", "globals", "--trace", "global.team.name"), RunExpected{
+		Stdout: `
+stack "/envs/dev/app":
+	global.team.name = "platform"
+	set global.team in / (globals) at /globals.tm:2,3-5,4
+
+stack "/envs/prod/app":
+	global.team.name = "prod-platform"
+	set global.team in / (globals) at /globals.tm:2,3-5,4
+	set global.team.name in /envs/prod (globals "team") at /envs/prod/globals.tm:2,3-25
+	extend global.team in /envs/prod/app (globals "team") at /envs/prod/app/globals.tm:1,1-2,2
+`,
+	})
+
+	AssertRunResult(t, tm.Run("debug", "show", "globals", "--trace", "undefined"), RunExpected{})
+}
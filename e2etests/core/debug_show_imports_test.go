@@ -0,0 +1,118 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/commands/debug/show/imports"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDebugShowImportsGraph(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		"f:shared/a.tm:globals {\n  a = 1\n}\n",
+		"f:shared/unused.tm:globals {\n  b = 1\n}\n",
+		`f:stack/imports.tm:import {
+  source = "/shared/a.tm"
+}
+`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("debug", "show", "imports", "--format", "json"), RunExpected{
+		IgnoreStdout: true,
+		StderrRegex:  "unused imported file: /shared/unused.tm",
+	})
+	got := decodeImportGraph(t, tm.Run("debug", "show", "imports", "--format", "json").Stdout)
+	want := imports.Graph{
+		Files: []string{"/shared/a.tm", "/stack/imports.tm"},
+		Imports: []imports.Import{
+			{
+				File:     "/stack/imports.tm",
+				Imported: "/shared/a.tm",
+				Source:   "/shared/a.tm",
+				Range:    "/stack/imports.tm:2,3-26",
+			},
+		},
+		Unused: []string{"/shared/unused.tm"},
+		Cycles: [][]imports.Import{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected import graph: %s", diff)
+	}
+
+	AssertRunResult(t, tm.Run("debug", "show", "imports"), RunExpected{
+		StdoutRegex: `n2->n1\[label="/shared/a.tm",tooltip="/stack/imports.tm:2,3-26"\]`,
+		StderrRegex: "unused imported file",
+	})
+}
+
+func TestDebugShowImportsCycles(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/imports.tm:import {
+  source = "/shared/a.tm"
+}
+`,
+		`f:shared/a.tm:import {
+  source = "/other/b.tm"
+}
+`,
+		`f:other/b.tm:import {
+  source = "/shared/a.tm"
+}
+`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("list"), RunExpected{
+		Status:      1,
+		StderrRegex: "import",
+	})
+
+	res := tm.Run("debug", "show", "imports", "--format", "json")
+	AssertRunResult(t, res, RunExpected{
+		IgnoreStdout: true,
+		StderrRegex:  `import cycle: /other/b.tm -> /shared/a.tm -> /other/b.tm`,
+	})
+	got := decodeImportGraph(t, res.Stdout)
+	want := [][]imports.Import{
+		{
+			{
+				File:     "/other/b.tm",
+				Imported: "/shared/a.tm",
+				Source:   "/shared/a.tm",
+				Range:    "/other/b.tm:2,3-26",
+			},
+			{
+				File:     "/shared/a.tm",
+				Imported: "/other/b.tm",
+				Source:   "/other/b.tm",
+				Range:    "/shared/a.tm:2,3-25",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got.Cycles); diff != "" {
+		t.Fatalf("unexpected import cycles: %s", diff)
+	}
+}
+
+func decodeImportGraph(t *testing.T, stdout string) imports.Graph {
+	t.Helper()
+	var graph imports.Graph
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &graph))
+	return graph
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDocsGenerate(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:stacks/network/stack.tm:stack {
+  name        = "network"
+  description = "VPC | subnets"
+  tags        = ["infra"]
+}`,
+		`f:stacks/app/stack.tm:stack {
+  name        = "app"
+  description = "Application"
+  after       = ["/stacks/network"]
+}`,
+		`f:other/stack.tm:stack {
+  name = "other"
+}`,
+		`f:globals.tm:globals {
+  region = "eu-west-1"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "docs", "generate", "--global", "region", "--global", "undefined"), RunExpected{
+		IgnoreStdout: true,
+	})
+
+	want := "<!-- Generated by `terramate experimental docs generate`. DO NOT EDIT. -->\n" +
+		"\n" +
+		"# Stacks\n" +
+		"\n" +
+		"## /\n" +
+		"\n" +
+		"| Stack | Description | Tags | Level | After | Before | global.region | global.undefined |\n" +
+		"|---|---|---|---|---|---|---|---|\n" +
+		"| [other](other) |  |  | 0 |  |  | `\"eu-west-1\"` |  |\n" +
+		"\n" +
+		"## /stacks\n" +
+		"\n" +
+		"| Stack | Description | Tags | Level | After | Before | global.region | global.undefined |\n" +
+		"|---|---|---|---|---|---|---|---|\n" +
+		"| [app](stacks/app) | Application |  | 1 | `/stacks/network` |  | `\"eu-west-1\"` |  |\n" +
+		"| [network](stacks/network) | VPC \\| subnets | `infra` | 0 |  |  | `\"eu-west-1\"` |  |\n"
+	assert.EqualStrings(t, want, string(test.ReadFile(t, s.RootDir(), "STACKS.md")))
+
+	AssertRunResult(t, tm.Run("experimental", "docs", "generate", "--global", "region", "--global", "undefined"), RunExpected{
+		Stdout: "Nothing to do, generated code is up to date\n",
+	})
+
+	AssertRunResult(t, tm.Run("experimental", "docs", "generate", "--output", "/docs/stacks.md"), RunExpected{
+		IgnoreStdout: true,
+	})
+	got := string(test.ReadFile(t, s.RootDir(), "docs/stacks.md"))
+	if !strings.Contains(got, "| [app](../stacks/app) |") {
+		t.Fatalf("stack links must be relative to the docs file:\n%s", got)
+	}
+
+	AssertRunResult(t, tm.Run("experimental", "docs", "generate", "--output", "docs.md"), RunExpected{
+		Status:      1,
+		StderrRegex: "must be a clean absolute project path",
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDriftRun(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		"s:stacks/c",
+		"s:stacks/d",
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	tm.PrependToPath(filepath.Dir(HelperPath))
+	helper := filepath.Base(HelperPath)
+	driftRun := func(args ...string) RunResult {
+		return tm.Run(append([]string{"experimental", "drift", "run", "--seed", "42"}, args...)...)
+	}
+
+	AssertRunResult(t, driftRun("--max-parallel", "2", "--", helper, "exit", "0"), RunExpected{
+		Stdout: "Drift detection:\n" +
+			"\t/stacks/a: ok\n" +
+			"\t/stacks/b: ok\n" +
+			"\t/stacks/c: ok\n" +
+			"\t/stacks/d: ok\n" +
+			"4 stacks: 4 ok, 0 drifted, 0 failed\n",
+		IgnoreStderr: true,
+	})
+
+	AssertRunResult(t, driftRun("--shard", "1/2", "--", helper, "exit", "2"), RunExpected{
+		Stdout: "Drift detection of shard 1/2:\n" +
+			"\t/stacks/b: drifted\n" +
+			"\t/stacks/d: drifted\n" +
+			"2 stacks: 0 ok, 2 drifted, 0 failed\n",
+		IgnoreStderr: true,
+	})
+
+	AssertRunResult(t, driftRun("--shard", "2/2", "--", helper, "exit", "1"), RunExpected{
+		Status: 1,
+		Stdout: "Drift detection of shard 2/2:\n" +
+			"\t/stacks/a: failed\n" +
+			"\t/stacks/c: failed\n" +
+			"2 stacks: 0 ok, 0 drifted, 2 failed\n",
+		StderrRegex: "drift detection failed in 2 stacks",
+	})
+
+	// without --all only the stacks inside the working directory are selected.
+	tmStack := NewCLI(t, filepath.Join(s.RootDir(), "stacks", "a"))
+	tmStack.PrependToPath(filepath.Dir(HelperPath))
+	AssertRunResult(t, tmStack.Run("experimental", "drift", "run", "--", helper, "exit", "0"), RunExpected{
+		Stdout: "Drift detection:\n" +
+			"\t/stacks/a: ok\n" +
+			"1 stacks: 1 ok, 0 drifted, 0 failed\n",
+		IgnoreStderr: true,
+	})
+	AssertRunResult(t, tmStack.Run("experimental", "drift", "run", "--all", "--", helper, "exit", "0"), RunExpected{
+		StdoutRegex:  "4 stacks: 4 ok",
+		IgnoreStderr: true,
+	})
+
+	AssertRunResult(t, driftRun("--shard", "3/2", "--", helper, "exit", "0"), RunExpected{
+		Status:      1,
+		StderrRegex: "N must be between 1 and TOTAL",
+	})
+}
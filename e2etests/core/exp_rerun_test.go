@@ -0,0 +1,128 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/run/record"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestExpRerunRecordedRun(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:stack1:after=["/stack2"]`,
+		"s:stack2",
+		"s:stack3",
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+
+	cli := NewCLI(t, s.RootDir())
+	wantOutput := "stack2\nstack1\nstack3\n"
+	AssertRunResult(t, cli.Run(
+		"run", "--quiet", "--record", "--", HelperPath, "stack-rel-path", s.RootDir(),
+	), RunExpected{Stdout: wantOutput})
+
+	runsDir := filepath.Join(s.RootDir(), filepath.FromSlash(record.Dir))
+	entries, err := os.ReadDir(runsDir)
+	assert.NoError(t, err)
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok {
+			ids = append(ids, id)
+		}
+	}
+	assert.EqualInts(t, 1, len(ids), "want a single recorded run: %v", ids)
+
+	data, err := os.ReadFile(filepath.Join(runsDir, ids[0]+".json"))
+	assert.NoError(t, err)
+
+	var rec record.Record
+	assert.NoError(t, json.Unmarshal(data, &rec))
+	assert.EqualStrings(t, ids[0], rec.ID)
+	assert.EqualStrings(t, git.RevParse("HEAD"), rec.GitSHA)
+
+	var gotStacks []string
+	for _, st := range rec.Stacks {
+		gotStacks = append(gotStacks, st.Dir)
+	}
+	if diff := cmp.Diff([]string{"/stack2", "/stack1", "/stack3"}, gotStacks); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+
+	// the records must not be seen as untracked files by the safeguards.
+	AssertRunResult(t, cli.Run(
+		"run", "--quiet", "--record", "--", HelperPath, "stack-rel-path", s.RootDir(),
+	), RunExpected{Stdout: wantOutput})
+
+	AssertRunResult(t, cli.Run("--quiet", "experimental", "rerun", rec.ID),
+		RunExpected{Stdout: wantOutput})
+}
+
+func TestExpRerunReplaysRecordedOrder(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:stack1:after=["/stack2"]`,
+		"s:stack2",
+		"s:stack3",
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+
+	cli := NewCLI(t, s.RootDir())
+	wantOutput := "stack2\nstack1\nstack3\n"
+	AssertRunResult(t, cli.Run(
+		"run", "--quiet", "--record", "--", HelperPath, "stack-rel-path", s.RootDir(),
+	), RunExpected{Stdout: wantOutput})
+
+	entries, err := os.ReadDir(filepath.Join(s.RootDir(), filepath.FromSlash(record.Dir)))
+	assert.NoError(t, err)
+	var id string
+	for _, entry := range entries {
+		if recID, ok := strings.CutSuffix(entry.Name(), ".json"); ok {
+			id = recID
+		}
+	}
+
+	s.DirEntry("stack3").CreateConfig(`stack {
+  before = ["/stack2"]
+}`)
+	git.CommitAll("run stack3 first")
+
+	AssertRunResult(t, cli.Run(
+		"run", "--quiet", "--", HelperPath, "stack-rel-path", s.RootDir(),
+	), RunExpected{Stdout: "stack3\nstack2\nstack1\n"})
+
+	AssertRunResult(t, cli.Run("--quiet", "experimental", "rerun", id), RunExpected{
+		Stdout:      wantOutput,
+		StderrRegex: "run order changed",
+	})
+}
+
+func TestExpRerunNotFound(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{"s:stack"})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("experimental", "rerun", "unknown"), RunExpected{
+		Status:      1,
+		StderrRegex: string(record.ErrNotFound),
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestExpRunOrderLevels(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		layout []string
+		args   []string
+		want   RunExpected
+	}
+
+	for _, tcase := range []testcase{
+		{
+			name: "flat order",
+			layout: []string{
+				`s:stack1:after=["/stack2"]`,
+				"s:stack2",
+				"s:stack3",
+			},
+			want: RunExpected{
+				Stdout: "stack2\nstack1\nstack3\n",
+			},
+		},
+		{
+			name: "levels as text",
+			layout: []string{
+				`s:stack1:after=["/stack2"]`,
+				"s:stack2",
+				"s:stack3",
+			},
+			args: []string{"--levels"},
+			want: RunExpected{
+				Stdout: "Level 0:\n\tstack2\n\tstack3\nLevel 1:\n\tstack1\n",
+			},
+		},
+		{
+			name: "parent stacks run in previous levels",
+			layout: []string{
+				"s:parent",
+				"s:parent/child",
+				"s:other",
+			},
+			args: []string{"--levels"},
+			want: RunExpected{
+				Stdout: "Level 0:\n\tother\n\tparent\nLevel 1:\n\tparent/child\n",
+			},
+		},
+		{
+			name: "cycle between stack1 and stack2",
+			layout: []string{
+				`s:stack1:after=["/stack2"]`,
+				`s:stack2:after=["/stack1"]`,
+			},
+			args: []string{"--levels"},
+			want: RunExpected{
+				Status: 1,
+				StderrRegexes: []string{
+					"Invalid stack configuration",
+					"cycle detected",
+				},
+			},
+		},
+	} {
+		tc := tcase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := sandbox.New(t)
+			s.BuildTree(tc.layout)
+
+			cli := NewCLI(t, s.RootDir())
+			args := append([]string{"experimental", "run-order"}, tc.args...)
+			AssertRunResult(t, cli.Run(args...), tc.want)
+		})
+	}
+}
+
+func TestExpRunOrderLevelsJSON(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:stack1:after=["/stack2"]`,
+		`s:stack2:after=["/stack3"]`,
+		"s:stack3",
+		"s:stack4",
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	res := cli.Run("experimental", "run-order", "--levels", "--format", "json")
+	AssertRunResult(t, res, RunExpected{IgnoreStdout: true})
+
+	type level struct {
+		Level  int      `json:"level"`
+		Stacks []string `json:"stacks"`
+	}
+	var got struct {
+		Levels []level `json:"levels"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(res.Stdout), &got))
+
+	want := []level{
+		{Level: 0, Stacks: []string{"/stack3", "/stack4"}},
+		{Level: 1, Stacks: []string{"/stack2"}},
+		{Level: 2, Stacks: []string{"/stack1"}},
+	}
+	if diff := cmp.Diff(want, got.Levels); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
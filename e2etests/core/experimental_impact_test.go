@@ -0,0 +1,104 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestExperimentalImpact(t *testing.T) {
+	t.Parallel()
+
+	layout := []string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:globals.tm:globals {
+  env  = "prod"
+  name = "${global.env}-app"
+}`,
+		`f:terramate.tm:terramate {
+  config {
+    run {
+      env {
+        ENV = global.env
+      }
+    }
+  }
+}`,
+		`f:stacks/a/gen.tm:generate_hcl "main.tf" {
+  content {
+    name = global.name
+  }
+}`,
+		`f:stacks/b/gen.tm:generate_file "other.txt" {
+  content = "static"
+}`,
+	}
+
+	type testcase struct {
+		name string
+		args []string
+		want RunExpected
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "global used directly and through other globals",
+			args: []string{"--global", "env"},
+			want: RunExpected{
+				Stdout: `stack "/stacks/a":
+	generate_hcl "main.tf" (defined at /stacks/a/gen.tm:1)
+	env ENV (defined at /terramate.tm:5)
+stack "/stacks/b":
+	env ENV (defined at /terramate.tm:5)
+`,
+			},
+		},
+		{
+			name: "global not referenced",
+			args: []string{"--global", "unused"},
+		},
+		{
+			name: "file defining globals",
+			args: []string{"--file", "globals.tm"},
+			want: RunExpected{
+				Stdout: `stack "/stacks/a":
+	generate_hcl "main.tf" (defined at /stacks/a/gen.tm:1)
+	env ENV (defined at /terramate.tm:5)
+stack "/stacks/b":
+	env ENV (defined at /terramate.tm:5)
+`,
+			},
+		},
+		{
+			name: "file defining a generate block",
+			args: []string{"--file", "stacks/b/gen.tm"},
+			want: RunExpected{
+				Stdout: `stack "/stacks/b":
+	generate_file "other.txt" (defined at /stacks/b/gen.tm:1)
+`,
+			},
+		},
+		{
+			name: "no file or global",
+			want: RunExpected{
+				Status:      1,
+				StderrRegex: "either --file or --global must be provided",
+			},
+		},
+	} {
+		tcase := tc
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree(layout)
+			tm := NewCLI(t, s.RootDir())
+			args := append([]string{"experimental", "impact"}, tcase.args...)
+			AssertRunResult(t, tm.Run(args...), tcase.want)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestExperimentalLint(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		layout []string
+		want   RunExpected
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "no issues",
+			layout: []string{
+				"s:stacks/a",
+				`f:globals.tm:globals {
+  env  = "prod"
+  name = "${global.env}-app"
+}`,
+				`f:stacks/a/gen.tm:generate_hcl "main.tf" {
+  lets {
+    name = global.name
+  }
+  content {
+    name = let.name
+  }
+}`,
+			},
+		},
+		{
+			name: "dead configuration",
+			layout: []string{
+				"s:stacks/a",
+				"s:stacks/b",
+				`f:globals.tm:globals {
+  env    = "prod"
+  name   = "${global.env}-app"
+  unused = "x"
+  region = "us-east-1"
+}`,
+				`f:stacks/globals.tm:globals {
+  region = "eu-west-1"
+}`,
+				`f:terramate.tm:terramate {
+  config {
+    run {
+      env {
+        REGION = global.region
+      }
+    }
+  }
+}`,
+				`f:stacks/a/gen.tm:generate_hcl "main.tf" {
+  lets {
+    used   = global.env
+    unused = "y"
+  }
+  content {
+    env = let.used
+  }
+}
+
+generate_file "never.txt" {
+  condition = global.env == "dev"
+  content   = "never"
+}`,
+			},
+			want: RunExpected{
+				Status: 1,
+				Stdout: `/globals.tm:3: global name is never used
+/globals.tm:4: global unused is never used
+/globals.tm:5: global region is overridden by child directories in all stacks
+/stacks/a/gen.tm:4: let unused of generate_hcl "main.tf" is never used
+/stacks/a/gen.tm:11: condition of generate_file "never.txt" is false in all stacks
+`,
+				StderrRegex: "5 issues found",
+			},
+		},
+		{
+			name: "globals used by exported variables, providers and generate_yaml",
+			layout: []string{
+				"s:stacks/a",
+				`f:terramate.tm:terramate {
+  config {
+    experiments = ["k8s", "providers"]
+    run {
+      export_globals_as_tfvars = ["tfvars.*", "single"]
+    }
+  }
+}`,
+				`f:globals.tm:globals {
+  tfvars = {
+    region = "us-east-1"
+  }
+  single   = "x"
+  env      = "prod"
+  version  = "~> 5.0"
+  replicas = 3
+  unused   = "y"
+}`,
+				`f:providers.tm:providers {
+  environment = global.env
+
+  provider "aws" {
+    source  = "hashicorp/aws"
+    version = global.version
+  }
+}`,
+				`f:stacks/a/gen.tm:generate_yaml "deploy.yaml" {
+  content = {
+    replicas = global.replicas
+  }
+}`,
+			},
+			want: RunExpected{
+				Status:      1,
+				Stdout:      "/globals.tm:9: global unused is never used\n",
+				StderrRegex: "1 issues found",
+			},
+		},
+		{
+			name: "globals referenced dynamically",
+			layout: []string{
+				"s:stacks/a",
+				`f:globals.tm:globals {
+  a = 1
+  b = 2
+}`,
+				`f:stacks/a/gen.tm:generate_file "all.json" {
+  content = tm_jsonencode(global)
+}`,
+			},
+		},
+	} {
+		tcase := tc
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree(tcase.layout)
+			tm := NewCLI(t, s.RootDir())
+			AssertRunResult(t, tm.Run("experimental", "lint"), tcase.want)
+		})
+	}
+}
+
+func TestExperimentalLintOrdering(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		layout []string
+		want   RunExpected
+	}
+
+	const networkBackend = `f:network/backend.tf:terraform {
+  backend "s3" {
+    bucket = "states"
+    key    = "network/terraform.tfstate"
+    region = "us-east-1"
+  }
+}`
+	const appRemoteState = `f:app/remote.tf:data "terraform_remote_state" "network" {
+  backend = "s3"
+  config = {
+    bucket = "states"
+    key    = "network/terraform.tfstate"
+  }
+}`
+
+	for _, tc := range []testcase{
+		{
+			name: "remote state with ordering",
+			layout: []string{
+				`s:app:after=["/network"]`,
+				"s:network",
+				networkBackend,
+				appRemoteState,
+			},
+		},
+		{
+			name: "missing ordering",
+			layout: []string{
+				"s:app",
+				"s:network:id=network",
+				"s:shared",
+				`f:terramate.tm:terramate {
+  config {
+    experiments = ["outputs-sharing"]
+  }
+}`,
+				networkBackend,
+				appRemoteState,
+				`f:shared/backend.tf:terraform {
+  backend "s3" {
+    bucket = "states"
+    key    = "network/terraform.tfstate"
+  }
+}`,
+				`f:app/import.tf:import {
+  to = aws_vpc.main
+  id = "vpc-123"
+}`,
+				`f:network/import.tf:import {
+  to = aws_vpc.main
+  id = "vpc-123"
+}`,
+				`f:app/input.tm:input "vpc_id" {
+  backend       = "default"
+  from_stack_id = "network"
+  value         = outputs.vpc_id.value
+}
+
+sharing_backend "default" {
+  type     = terraform
+  command  = ["terraform", "output", "-json"]
+  filename = "sharing.tf"
+}`,
+			},
+			want: RunExpected{
+				Status: 1,
+				Stdout: `/app/input.tm:1: stack /app uses the outputs of stack /network in input.vpc_id but their run order is not defined: consider adding after = ["/network"] to stack /app
+/app/remote.tf:1: stack /app reads the state of stack /network with a terraform_remote_state data source but their run order is not defined: consider adding after = ["/network"] to stack /app
+/app/remote.tf:1: stack /app reads the state of stack /shared with a terraform_remote_state data source but their run order is not defined: consider adding after = ["/shared"] to stack /app
+/network/import.tf:1: stacks /app and /network import the resource ID "vpc-123" but their run order is not defined: consider adding after = ["/app"] to stack /network
+/shared/backend.tf:2: stacks /network and /shared use the same backend state but their run order is not defined: consider adding after = ["/network"] to stack /shared
+`,
+				StderrRegex: "5 issues found",
+			},
+		},
+	} {
+		tcase := tc
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree(tcase.layout)
+			tm := NewCLI(t, s.RootDir())
+			AssertRunResult(t, tm.Run("experimental", "lint", "--ordering"), tcase.want)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateChanged(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:changed.tm:
+		generate_file "changed.txt" {
+		  content = terramate.changed.stack ? "true" : "false"
+		}
+
+		generate_file "/ci/changed.txt" {
+		  context = root
+		  content = tm_join(",", terramate.changed.stacks)
+		}`,
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+	git.CheckoutNew("change-a")
+	s.RootEntry().CreateFile("stacks/a/main.tf", "# changed")
+	git.CommitAll("change a")
+
+	tmcli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tmcli.Run("generate", "--changed"), RunExpected{IgnoreStdout: true})
+
+	for file, want := range map[string]string{
+		"stacks/a/changed.txt": "true",
+		"stacks/b/changed.txt": "false",
+		"ci/changed.txt":       "/stacks/a",
+	} {
+		got, err := os.ReadFile(filepath.Join(s.RootDir(), file))
+		assert.NoError(t, err)
+		assert.EqualStrings(t, want, string(got), "content of %s", file)
+	}
+
+	t.Run("changed files are not outdated", func(t *testing.T) {
+		git.CommitAll("generate")
+		tmcli.PrependToPath(filepath.Dir(HelperPath))
+		AssertRunResult(t, tmcli.Run("run", "--quiet", "--", filepath.Base(HelperPath), "echo", "ok"),
+			RunExpected{Stdout: "ok\nok\n"})
+	})
+
+	t.Run("namespace has no changed stacks without --changed", func(t *testing.T) {
+		AssertRunResult(t, tmcli.Run("generate"), RunExpected{IgnoreStdout: true})
+		for file, want := range map[string]string{
+			"stacks/a/changed.txt": "false",
+			"stacks/b/changed.txt": "false",
+			"ci/changed.txt":       "",
+		} {
+			got, err := os.ReadFile(filepath.Join(s.RootDir(), file))
+			assert.NoError(t, err)
+			assert.EqualStrings(t, want, string(got), "content of %s", file)
+		}
+	})
+}
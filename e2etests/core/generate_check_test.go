@@ -0,0 +1,161 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateCheck(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/gen.tm:generate_file "file.txt" {
+  content = "static"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate", "--check"), RunExpected{
+		Status: 1,
+		Stdout: "stack/file.txt\n",
+	})
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	AssertRun(t, tm.Run("generate", "--check"))
+
+	AssertRunResult(t, tm.Run("generate", "--check", "--detailed-exit-code"), RunExpected{
+		Status:      1,
+		StderrRegex: "conflicts with --detailed-exit-code",
+	})
+}
+
+func TestGenerateCheckRequiresPinnedTimeAndRandomness(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/gen.tm:generate_file "file.txt" {
+  content = "${tm_timestamp()} ${tm_random("id") < 1}"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	AssertRunResult(t, tm.Run("generate", "--check"), RunExpected{
+		Status:      1,
+		StderrRegex: `non-deterministic function: tm_timestamp: pin the timestamp`,
+	})
+
+	pinned := NewCLI(t, s.RootDir(), append(os.Environ(),
+		"TM_TIMESTAMP=2026-01-02T03:04:05Z",
+		"TM_RANDOM_SEED=ci",
+	)...)
+	AssertRunResult(t, pinned.Run("generate", "--check"), RunExpected{
+		Status: 1,
+		Stdout: "stack/file.txt\n",
+	})
+	AssertRunResult(t, pinned.Run("generate"), RunExpected{IgnoreStdout: true})
+	AssertRun(t, pinned.Run("generate", "--check"))
+	assert.EqualStrings(t, "2026-01-02T03:04:05Z true", string(s.RootEntry().ReadFile("stack/file.txt")))
+
+	// the config pins the sources too, but the environment takes precedence.
+	s.RootEntry().CreateFile("terramate.tm", `terramate {
+  config {
+    generate {
+      timestamp   = "2026-01-02T03:04:05Z"
+      random_seed = "ci"
+    }
+  }
+}`)
+	AssertRun(t, tm.Run("generate", "--check"))
+	AssertRunResult(t, NewCLI(t, s.RootDir(), append(os.Environ(),
+		"TM_TIMESTAMP=2027-01-02T03:04:05Z",
+	)...).Run("generate", "--check"), RunExpected{
+		Status: 1,
+		Stdout: "stack/file.txt\n",
+	})
+}
+
+func TestGenerateWhy(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/globals.tm:globals {
+  region = "us-east-1"
+}`,
+		`f:stack/gen.tm:generate_hcl "main.tf" {
+  content {
+    provider "aws" {
+      region = global.region
+    }
+  }
+}
+
+generate_file "file.txt" {
+  content = "a\nb\nc\n"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	AssertRun(t, tm.Run("generate", "--why"))
+
+	s.RootEntry().CreateFile("stack/globals.tm", `globals {
+  region = "eu-west-1"
+}`)
+	s.RootEntry().CreateFile("stack/gen.tm", `generate_hcl "main.tf" {
+  content {
+    provider "aws" {
+      region = global.region
+    }
+    backend "s3" {}
+  }
+}
+
+generate_hcl "new.tf" {
+  content {
+    a = 1
+  }
+}
+
+generate_file "file.txt" {
+  content = "a\nB\nc\n"
+}`)
+	AssertRunResult(t, tm.Run("generate", "--why"), RunExpected{
+		Status: 1,
+		Stdout: `stack/file.txt
+	- b
+	+ B
+stack/main.tf
+	+ backend["s3"] = backend "s3" {
+	}
+	~ provider["aws"].region: "us-east-1" => "eu-west-1"
+stack/new.tf
+	file is not generated yet
+`,
+	})
+
+	s.RootEntry().RemoveFile("stack/gen.tm")
+	AssertRunResult(t, tm.Run("generate", "--why"), RunExpected{
+		Status: 1,
+		Stdout: `stack/main.tf
+	file is no longer generated
+`,
+	})
+
+	AssertRunResult(t, tm.Run("generate", "--why", "--detailed-exit-code"), RunExpected{
+		Status:      1,
+		StderrRegex: "--why conflicts with --detailed-exit-code",
+	})
+}
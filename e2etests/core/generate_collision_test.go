@@ -0,0 +1,38 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateFailsOnCollisionWithTrackedManualCode(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stack-1",
+		"s:stack-2",
+		"s:stack-3",
+		"f:stack-1/main.tf:# manual",
+		"f:stack-2/main.tf:# manual",
+		`f:gen.tm:generate_hcl "main.tf" {
+  content {
+    a = 1
+  }
+}`,
+	})
+	s.Git().CommitAll("manual code")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:      1,
+		StdoutRegex: "collide with manually written files tracked by git: /stack-1/main.tf, /stack-2/main.tf",
+	})
+	test.DoesNotExist(t, s.DirEntry("stack-3").Path(), "main.tf")
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateFileTypes(t *testing.T) {
+	t.Parallel()
+
+	const fileTypes = `f:file_types.tm:terramate {
+  config {
+    generate {
+      file_types = {
+        "*.tf"   = "generate_hcl"
+        "*.yml"  = "yaml"
+        "*.json" = "json"
+      }
+    }
+  }
+}`
+
+	for _, tc := range []struct {
+		name    string
+		gen     string
+		want    RunExpected
+		wantErr bool
+	}{
+		{
+			name: "valid files",
+			gen: `generate_hcl "main.tf" {
+  content {
+    a = 1
+  }
+}
+
+generate_file "ci.yml" {
+  content = "a: [1, 2]\n---\nb: 1\n"
+}
+
+generate_file "data.json" {
+  content = tm_jsonencode({ a = 1 })
+}
+
+generate_file "notes.txt" {
+  content = "anything { goes"
+}`,
+		},
+		{
+			name: "terraform from generate_file",
+			gen: `generate_file "main.tf" {
+  content = "resource \"a\" \"b\" {}"
+}`,
+			wantErr: true,
+			want: RunExpected{
+				StdoutRegex: `main.tf: files matching "\*.tf" must be of type "generate_hcl"`,
+			},
+		},
+		{
+			name: "invalid yaml",
+			gen: `generate_file "ci.yml" {
+  content = "a: [1, 2"
+}`,
+			wantErr: true,
+			want: RunExpected{
+				StdoutRegex: `ci.yml: files matching "\*.yml" must be of type "yaml"`,
+			},
+		},
+		{
+			name: "invalid json",
+			gen: `generate_file "data.json" {
+  content = "{a: 1}"
+}`,
+			wantErr: true,
+			want: RunExpected{
+				StdoutRegex: `data.json: files matching "\*.json" must be of type "json"`,
+			},
+		},
+		{
+			name: "disabled files are not checked",
+			gen: `generate_file "main.tf" {
+  condition = false
+  content   = "invalid"
+}`,
+		},
+		{
+			name: "root context files",
+			gen: `generate_file "/main.tf" {
+  context = root
+  content = "invalid"
+}`,
+			wantErr: true,
+			want: RunExpected{
+				StdoutRegex: `/main.tf: files matching "\*.tf" must be of type "generate_hcl"`,
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree([]string{
+				fileTypes,
+				"s:stack",
+				"f:stack/gen.tm:" + tc.gen,
+			})
+			tm := NewCLI(t, s.RootDir())
+			want := tc.want
+			if tc.wantErr {
+				want.Status = 1
+			} else {
+				want.IgnoreStdout = true
+			}
+			AssertRunResult(t, tm.Run("generate"), want)
+		})
+	}
+}
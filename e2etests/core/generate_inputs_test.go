@@ -0,0 +1,59 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateVerifyInputs(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals {
+  env    = "prod"
+  unused = 1
+}`,
+		`f:stack/gen.tm:generate_hcl "main.tf" {
+  content {
+    env = global.env
+  }
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+
+	AssertRunResult(t, tm.Run("generate", "--record-inputs", "--verify-inputs"), RunExpected{
+		Status:      1,
+		StderrRegex: "--record-inputs conflicts with --verify-inputs",
+	})
+
+	AssertRunResult(t, tm.Run("generate", "--verify-inputs"), RunExpected{
+		Status:      1,
+		StderrRegex: "generation state not found",
+	})
+
+	AssertRunResult(t, tm.Run("generate", "--record-inputs"), RunExpected{
+		IgnoreStdout: true,
+	})
+
+	AssertRunResult(t, tm.Run("generate", "--verify-inputs"), RunExpected{
+		Stdout: "no inputs drifted since last generation\n",
+	})
+
+	s.RootEntry().CreateFile("globals.tm", `globals {
+  env    = "dev"
+  unused = 2
+}`)
+
+	AssertRunResult(t, tm.Run("generate", "--verify-inputs"), RunExpected{
+		Status: 1,
+		Stdout: "/stack/main.tf:\n\t~ global.env: \"prod\" => \"dev\"\n",
+	})
+}
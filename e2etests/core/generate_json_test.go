@@ -0,0 +1,72 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateJSONReport(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:ok",
+		`f:ok/gen.tm:generate_file "file.txt" {
+  content = "ok"
+}`,
+		"s:failed",
+		`f:failed/gen.tm:assert {
+  assertion = false
+  message   = "missing owner tag"
+  code      = "TAG001"
+  docs_url  = "https://wiki.example.com/tags"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	res := tm.Run("generate", "--json")
+	AssertRunResult(t, res, RunExpected{
+		Status:       1,
+		IgnoreStdout: true,
+	})
+
+	var got struct {
+		Successes []struct {
+			Dir     string   `json:"dir"`
+			Created []string `json:"created"`
+		} `json:"successes"`
+		Failures []struct {
+			Dir    string `json:"dir"`
+			Errors []struct {
+				Message string `json:"message"`
+				Code    string `json:"code"`
+				DocsURL string `json:"docs_url"`
+			} `json:"errors"`
+		} `json:"failures"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(res.Stdout), &got), "stdout: %s", res.Stdout)
+
+	assert.EqualInts(t, 1, len(got.Successes))
+	assert.EqualStrings(t, "/ok", got.Successes[0].Dir)
+	if diff := cmp.Diff([]string{"file.txt"}, got.Successes[0].Created); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+	assert.EqualInts(t, 1, len(got.Failures))
+	assert.EqualStrings(t, "/failed", got.Failures[0].Dir)
+	assert.EqualInts(t, 1, len(got.Failures[0].Errors))
+	assert.EqualStrings(t, "TAG001", got.Failures[0].Errors[0].Code)
+	assert.EqualStrings(t, "https://wiki.example.com/tags", got.Failures[0].Errors[0].DocsURL)
+
+	AssertRunResult(t, tm.Run("generate", "--json", "--check"), RunExpected{
+		Status:      1,
+		StderrRegex: "--json conflicts with",
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateSandboxFlag(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		"f:stack/secret.txt:secret",
+		`f:stack/gen.tm:generate_file "file.txt" {
+  content = tm_file("secret.txt")
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate", "--sandbox"), RunExpected{
+		Status:      1,
+		StdoutRegex: "function tm_file is not available in sandboxed mode",
+	})
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		IgnoreStdout: true,
+	})
+}
+
+func TestGenerateSandboxConfig(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:sandbox.tm:terramate {
+  config {
+    sandbox {
+      enabled        = true
+      max_value_size = 100
+    }
+  }
+}`,
+		`f:stack/gen.tm:generate_file "file.txt" {
+  content = tm_join(",", tm_range(1000))
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:      1,
+		StdoutRegex: "exceeds the maximum size of 100 creating tm_range",
+	})
+}
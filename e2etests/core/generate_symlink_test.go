@@ -0,0 +1,84 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateSymlinkedStacks(t *testing.T) {
+	t.Parallel()
+
+	layout := func(genContent string) []string {
+		return []string{
+			`f:terramate.tm:terramate {
+  config {
+    follow_symlinks = true
+  }
+}`,
+			"s:skel",
+			`f:skel/gen.tm:generate_file "main.txt" {
+  content = ` + genContent + `
+}`,
+			"d:envs",
+			"l:skel:envs/a",
+			"l:skel:envs/b",
+			"l:envs:envs/loop",
+		}
+	}
+
+	t.Run("stacks sharing a directory generate once", func(t *testing.T) {
+		t.Parallel()
+
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout(`"shared"`))
+
+		tm := NewCLI(t, s.RootDir())
+		AssertRunResult(t, tm.Run("list"), RunExpected{
+			Stdout:      "envs/a\nenvs/b\nskel\n",
+			StderrRegex: "ignoring symlink .*loop: it points to a parent directory",
+		})
+		AssertRunResult(t, tm.Run("generate"), RunExpected{
+			IgnoreStdout: true,
+			IgnoreStderr: true,
+		})
+
+		if got := string(s.RootEntry().ReadFile("skel/main.txt")); got != "shared" {
+			t.Fatalf("generated content: got %q, want %q", got, "shared")
+		}
+	})
+
+	t.Run("stacks sharing a directory must generate the same content", func(t *testing.T) {
+		t.Parallel()
+
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout("terramate.stack.path.absolute"))
+
+		tm := NewCLI(t, s.RootDir())
+		AssertRunResult(t, tm.Run("generate"), RunExpected{
+			Status:       1,
+			StdoutRegex:  "main.txt: generated differently than in stack /envs/a",
+			IgnoreStderr: true,
+		})
+	})
+
+	t.Run("symlinks are not followed by default", func(t *testing.T) {
+		t.Parallel()
+
+		s := sandbox.NoGit(t, true)
+		s.BuildTree([]string{
+			"s:skel",
+			"d:envs",
+			"l:skel:envs/a",
+		})
+
+		tm := NewCLI(t, s.RootDir())
+		AssertRunResult(t, tm.Run("list"), RunExpected{
+			Stdout: "skel\n",
+		})
+	})
+}
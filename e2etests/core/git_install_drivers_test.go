@@ -0,0 +1,146 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGitInstallDriversFailsConflictingGeneratedFiles(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/gen.tm:generate_file "file.txt" {
+  content = "${global.a}-${global.b}"
+}`,
+		`f:stack/a.tm:globals {
+  a = "a"
+}`,
+		`f:stack/b.tm:globals {
+  b = "b"
+}`,
+		"f:.gitattributes:*.sh text eol=lf\n",
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers"), RunExpected{
+		Stdout: "Configured git drivers for 1 generated files in .gitattributes\n" +
+			"Run 'terramate generate' after merging, or to resolve conflicting generated files.\n",
+	})
+	assert.EqualStrings(t, "*.sh text eol=lf\n"+
+		"# BEGIN terramate generated files\n"+
+		"/stack/file.txt merge=terramate-generated diff=terramate-generated linguist-generated=true\n"+
+		"# END terramate generated files\n",
+		string(s.RootEntry().ReadFile(".gitattributes")))
+
+	// running again keeps the file stable.
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t, "*.sh text eol=lf\n"+
+		"# BEGIN terramate generated files\n"+
+		"/stack/file.txt merge=terramate-generated diff=terramate-generated linguist-generated=true\n"+
+		"# END terramate generated files\n",
+		string(s.RootEntry().ReadFile(".gitattributes")))
+
+	git := s.Git()
+	git.CommitAll("install drivers")
+	mainBranch := git.CurrentBranch()
+
+	git.CheckoutNew("feature")
+	s.RootEntry().CreateFile("stack/b.tm", `globals {
+  b = "feature"
+}`)
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	git.CommitAll("feature change")
+
+	git.Checkout(mainBranch)
+	s.RootEntry().CreateFile("stack/a.tm", `globals {
+  a = "main"
+}`)
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	git.CommitAll("main change")
+
+	// the generated file conflicts, the merge driver fails the merge keeping
+	// the current version of the file, without conflict markers.
+	err := git.Unwrap().Merge("feature")
+	assert.IsTrue(t, err != nil, "merge of conflicting generated files must fail")
+	assert.EqualStrings(t, "main-b", string(s.RootEntry().ReadFile("stack/file.txt")))
+
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t, "main-feature", string(s.RootEntry().ReadFile("stack/file.txt")))
+	git.CommitAll("merge feature")
+}
+
+func TestGitInstallDriversEscapesPatterns(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/gen.tm:generate_file "a b*[c]?.txt" {
+  content = "x"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t, "# BEGIN terramate generated files\n"+
+		`/stack/a[[:space:]]b\*\[c]\?.txt merge=terramate-generated diff=terramate-generated linguist-generated=true`+"\n"+
+		"# END terramate generated files\n",
+		string(s.RootEntry().ReadFile(".gitattributes")))
+}
+
+func TestGitInstallDriversHook(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{"s:stack"})
+	git := s.Git()
+	git.CommitAll("first commit")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers", "--hook"), RunExpected{
+		Stdout: "Configured git drivers for 0 generated files in .gitattributes\n" +
+			"Installed post-merge hook running 'terramate generate'\n",
+	})
+
+	hook := filepath.Join(s.RootDir(), ".git", "hooks", "post-merge")
+	content, err := os.ReadFile(hook)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "#!/bin/sh\n"+
+		"# installed by terramate experimental git-install-drivers\n"+
+		"exec terramate generate\n", string(content))
+
+	// reinstalling our own hook is fine.
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers", "--hook"), RunExpected{IgnoreStdout: true})
+
+	assert.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\necho custom\n"), 0755))
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers", "--hook"), RunExpected{
+		Status:       1,
+		IgnoreStdout: true,
+		StderrRegex:  "refusing to overwrite existing hook",
+	})
+}
+
+func TestGitInstallDriversRequiresGit(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "git-install-drivers"), RunExpected{
+		Status:      1,
+		StderrRegex: "requires a git repository",
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	runcmd "github.com/terramate-io/terramate/commands/run"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGitMetadataNamespace(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:terramate.tm:terramate {
+  config {
+    experiments = ["git-metadata"]
+  }
+}`,
+		`f:stack/gen.tm:generate_file "provenance.txt" {
+  content = "${terramate.git.commit} ${terramate.git.short_commit} ${terramate.git.branch} ${terramate.git.dirty}"
+}`,
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+	commit := git.RevParse("HEAD")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t,
+		fmt.Sprintf("%s %s %s false", commit, commit[:8], git.CurrentBranch()),
+		string(s.RootEntry().ReadFile("stack/provenance.txt")))
+
+	git.CommitAll("generate")
+
+	// the generated file now embeds the previous commit, so it's outdated
+	// like any other file with a different content.
+	tm.PrependToPath(filepath.Dir(HelperPath))
+	AssertRunResult(t, tm.Run("run", "--quiet", "--", filepath.Base(HelperPath), "echo", "ok"),
+		RunExpected{
+			Status:      defaultErrExitStatus,
+			StderrRegex: string(runcmd.ErrOutdatedGenCodeDetected),
+		})
+
+	s.RootEntry().CreateFile("untracked.txt", "")
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t,
+		fmt.Sprintf("%s %s %s true", git.RevParse("HEAD"), git.RevParse("HEAD")[:8], git.CurrentBranch()),
+		string(s.RootEntry().ReadFile("stack/provenance.txt")))
+}
+
+func TestGitMetadataNamespaceRequiresExperiment(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/gen.tm:generate_file "provenance.txt" {
+  content = terramate.git.commit
+}`,
+	})
+	s.Git().CommitAll("first commit")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:       1,
+		IgnoreStdout: true,
+		IgnoreStderr: true,
+	})
+}
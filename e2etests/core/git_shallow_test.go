@@ -0,0 +1,128 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestChangeDetectionFetchesMissingBaseRefInShallowClone(t *testing.T) {
+	t.Parallel()
+
+	for _, fetch := range []bool{false, true} {
+		fetch := fetch
+		name := "without fetch_missing_base_ref"
+		if fetch {
+			name = "with fetch_missing_base_ref"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := sandbox.New(t)
+			s.BuildTree([]string{
+				"s:stacks/a",
+				"s:stacks/b",
+				"f:stacks/a/main.tf:# a",
+				"f:stacks/b/main.tf:# b",
+			})
+			if fetch {
+				s.RootEntry().CreateFile("terramate.tm.hcl", `
+terramate {
+  config {
+    git {
+      fetch_missing_base_ref = true
+    }
+  }
+}
+`)
+			}
+			git := s.Git()
+			git.CommitAll("first commit")
+			git.Push("main")
+			git.CheckoutNew("change-b")
+			s.DirEntry("stacks/b").CreateFile("main.tf", "# b changed")
+			git.CommitAll("stack b changed")
+			git.Push("change-b")
+
+			clonedir := filepath.Join(t.TempDir(), "clone")
+			_, err := git.Unwrap().Exec("clone", "--depth=1", "--branch", "change-b",
+				"file://"+git.BareRepoAbsPath(), clonedir)
+			assert.NoError(t, err)
+
+			cli := NewCLI(t, clonedir)
+			if !fetch {
+				AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+					Status:      1,
+					StderrRegex: `requires a repository with at least two commits`,
+				})
+				return
+			}
+			AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+				Stdout:      nljoin("stacks/b"),
+				StderrRegex: `base ref "origin/main" is missing, fetching 1 commit\(s\) of branch "main" from remote "origin"`,
+			})
+		})
+	}
+}
+
+func TestChangeDetectionDeepensShallowCloneOfDefaultBranch(t *testing.T) {
+	t.Parallel()
+
+	for _, fetch := range []bool{false, true} {
+		fetch := fetch
+		name := "without fetch_missing_base_ref"
+		if fetch {
+			name = "with fetch_missing_base_ref"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := sandbox.New(t)
+			s.BuildTree([]string{
+				"s:stacks/a",
+				"s:stacks/b",
+				"f:stacks/a/main.tf:# a",
+				"f:stacks/b/main.tf:# b",
+			})
+			if fetch {
+				s.RootEntry().CreateFile("terramate.tm.hcl", `
+terramate {
+  config {
+    git {
+      fetch_missing_base_ref = true
+    }
+  }
+}
+`)
+			}
+			git := s.Git()
+			git.CommitAll("first commit")
+			s.DirEntry("stacks/a").CreateFile("main.tf", "# a changed")
+			git.CommitAll("stack a changed")
+			git.Push("main")
+
+			clonedir := filepath.Join(t.TempDir(), "clone")
+			_, err := git.Unwrap().Exec("clone", "--depth=1", "file://"+git.BareRepoAbsPath(), clonedir)
+			assert.NoError(t, err)
+
+			cli := NewCLI(t, clonedir)
+			if !fetch {
+				AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+					Status:      1,
+					StderrRegex: `requires a repository with at least two commits`,
+				})
+				return
+			}
+			AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+				Stdout:      nljoin("stacks/a"),
+				StderrRegex: `base ref "HEAD\^" is missing in the shallow clone, fetching 1 more commit\(s\) from remote "origin"`,
+			})
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestChangeDetectionInGitWorktree(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		"f:stacks/a/main.tf:# a",
+		"f:stacks/b/main.tf:# b",
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+	git.CheckoutNew("change-b")
+	s.DirEntry("stacks/b").CreateFile("main.tf", "# b changed")
+	git.CommitAll("stack b changed")
+	git.Checkout("main")
+
+	worktree := filepath.Join(t.TempDir(), "worktree")
+	_, err := git.Unwrap().Exec("worktree", "add", worktree, "change-b")
+	assert.NoError(t, err)
+
+	cli := NewCLI(t, worktree)
+	AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+		Stdout: nljoin("stacks/b"),
+	})
+	AssertRunResult(t, NewCLI(t, filepath.Join(worktree, "stacks")).Run("list"), RunExpected{
+		Stdout: nljoin("a", "b"),
+	})
+}
+
+func TestChangeDetectionInSparseCheckout(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		"f:stacks/b/main.tf:# b",
+		"f:modules/m/main.tf:# m",
+	})
+	s.RootEntry().CreateFile("stacks/a/main.tf", `module "m" {
+  source = "../../modules/m"
+}
+`)
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+	git.CheckoutNew("change-b-and-module")
+	s.DirEntry("stacks/b").CreateFile("main.tf", "# b changed")
+	s.DirEntry("modules/m").CreateFile("main.tf", "# m changed")
+	git.CommitAll("stack b and module changed")
+
+	_, err := git.Unwrap().Exec("sparse-checkout", "set", "--no-cone", "/*.tm.hcl", "/stacks/a/")
+	assert.NoError(t, err)
+	test.DoesNotExist(t, s.RootDir(), "modules/m/main.tf")
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+		Stdout:      nljoin("stacks/a"),
+		StderrRegex: "2 changed files are excluded by the sparse checkout",
+	})
+	AssertRunResult(t, cli.Run("list"), RunExpected{
+		Stdout: nljoin("stacks/a"),
+	})
+}
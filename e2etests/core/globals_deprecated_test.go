@@ -0,0 +1,54 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestDeprecatedGlobalReferenceWarns(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals {
+  old_name = tm_deprecated("value", "use global.new_name")
+  new_name = "value"
+}
+`,
+		`f:stack/gen.tm:generate_hcl "file.hcl" {
+  content {
+    a = global.new_name
+  }
+}
+`,
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	cli.LogLevel = "warn"
+	AssertRunResult(t, cli.Run("generate"), RunExpected{
+		IgnoreStdout:  true,
+		NoStderrRegex: "deprecated variable referenced",
+	})
+
+	s.RootEntry().CreateFile("stack/gen.tm", `generate_hcl "file.hcl" {
+  content {
+    a = global.old_name
+  }
+}
+`)
+
+	AssertRunResult(t, cli.Run("generate"), RunExpected{
+		IgnoreStdout: true,
+		StderrRegexes: []string{
+			"deprecated variable referenced",
+			"global.old_name",
+			"use global.new_name",
+		},
+	})
+}
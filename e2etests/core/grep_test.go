@@ -0,0 +1,51 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGrep(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:globals.tm:globals {
+  amis = {
+    old = "ami-0123"
+    new = "ami-4567"
+  }
+}`,
+		`f:stacks/a/globals.tm:globals {
+  ami = global.amis.old
+}`,
+		`f:stacks/b/globals.tm:globals {
+  ami = global.amis.new
+}`,
+		`f:stacks/gen.tm:generate_file "ami.txt" {
+  content = "image = ${global.ami}\n"
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "grep", "ami-01"), RunExpected{
+		Stdout: `/stacks/a: global.ami = "ami-0123"` + "\n" +
+			`/stacks/a: global.amis.old = "ami-0123"` + "\n" +
+			"/stacks/a: /stacks/a/ami.txt:1: image = ami-0123\n" +
+			`/stacks/b: global.amis.old = "ami-0123"` + "\n",
+	})
+
+	AssertRunResult(t, tm.Run("experimental", "grep", "no-match"), RunExpected{})
+
+	AssertRunResult(t, tm.Run("experimental", "grep", "("), RunExpected{
+		Status:      1,
+		StderrRegex: "invalid pattern",
+	})
+}
@@ -5,6 +5,7 @@ package core_test
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	. "github.com/terramate-io/terramate/e2etests/internal/runner"
@@ -75,3 +76,51 @@ stack "/":
 		})
 	})
 }
+
+func TestImportsCondition(t *testing.T) {
+	t.Parallel()
+	testcase := func(t *testing.T, env []string, want RunExpected) {
+		t.Parallel()
+		s := sandbox.New(t)
+		s.BuildTree([]string{
+			`s:.`,
+			`f:imports/prod/globals.tm:globals {
+				env = "prod"
+			}`,
+			`f:imports/dev/globals.tm:globals {
+				env = "dev"
+			}`,
+			`f:imports.tm.hcl:import {
+				source    = "/imports/prod/globals.tm"
+				condition = tm_try(env.TM_TEST_WORKSPACE, "dev") == "prod"
+			}
+			import {
+				source    = "/imports/dev/globals.tm"
+				condition = tm_try(env.TM_TEST_WORKSPACE, "dev") == "dev"
+			}`,
+		})
+
+		tmcli := NewCLI(t, s.RootDir(), env...)
+		AssertRunResult(t,
+			tmcli.Run("debug", "show", "globals"),
+			want,
+		)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		testcase(t, os.Environ(), RunExpected{
+			Stdout: `
+stack "/":
+	env = "dev"
+`,
+		})
+	})
+	t.Run("selected by env", func(t *testing.T) {
+		testcase(t, append(os.Environ(), "TM_TEST_WORKSPACE=prod"), RunExpected{
+			Stdout: `
+stack "/":
+	env = "prod"
+`,
+		})
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestImportsVerify(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		"f:shared/a.tm:globals {\n  a = 1\n}\n",
+		"f:shared/b.tm:globals {\n  b = 1\n}\n",
+		`f:stack/imports.tm:import {
+  source = "/shared/a.tm"
+  sha256 = "767be49450ef58456fe88fcb1e388c8bd8c483440653893a47535d3c89810aa4"
+}
+
+import {
+  source = "/shared/b.tm"
+}
+`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "imports", "verify"), RunExpected{
+		Stdout: "/shared/a.tm: ok (imported at /stack/imports.tm:2,3-26)\n" +
+			`/shared/b.tm: not pinned, sha256 = "4f77c970a0208e29e48f4c7e8b906341a6608b322630394575f24f73b531f2dc"` +
+			" (imported at /stack/imports.tm:7,3-26)\n",
+	})
+
+	AssertRunResult(t, tm.Run("experimental", "imports", "verify", "--strict"), RunExpected{
+		Status:       1,
+		IgnoreStdout: true,
+		StderrRegex:  "1 imported files are not pinned",
+	})
+
+	// tampering with a pinned import fails the parsing of the configuration.
+	s.RootEntry().CreateFile("shared/a.tm", "globals {\n  a = 2\n}\n")
+
+	AssertRunResult(t, tm.Run("experimental", "imports", "verify"), RunExpected{
+		Status:      1,
+		StderrRegex: "import integrity check failed",
+	})
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:      1,
+		StderrRegex: "import integrity check failed",
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+)
+
+func TestInitProject(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		profile   string
+		ci        string
+		provider  string
+		wantFiles []string
+	}{
+		{
+			profile:   "aws",
+			ci:        "github",
+			provider:  `provider "aws"`,
+			wantFiles: []string{".github/workflows/terramate-preview.yml", ".github/workflows/terramate-deploy.yml"},
+		},
+		{
+			profile:   "gcp",
+			ci:        "gitlab",
+			provider:  `provider "google"`,
+			wantFiles: []string{".gitlab-ci.yml"},
+		},
+		{
+			profile:  "azure",
+			ci:       "none",
+			provider: `provider "azurerm"`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.profile, func(t *testing.T) {
+			t.Parallel()
+
+			rootdir := t.TempDir()
+			tmcli := NewCLI(t, rootdir)
+			AssertRunResult(t,
+				tmcli.Run("init-project", "--profile", tc.profile, "--ci", tc.ci),
+				RunExpected{StdoutRegex: `\[\+\] stacks/dev/stack.tm.hcl`},
+			)
+			wantFiles := append([]string{
+				"terramate.tm.hcl",
+				"globals.tm.hcl",
+				"stacks/config.tm.hcl",
+				"stacks/prod/stack.tm.hcl",
+				".gitignore",
+			}, tc.wantFiles...)
+			for _, f := range wantFiles {
+				_, err := os.Stat(filepath.Join(rootdir, f))
+				assert.NoError(t, err, "file %s not created", f)
+			}
+
+			AssertRunResult(t, tmcli.Run("generate"), RunExpected{IgnoreStdout: true})
+			AssertRunResult(t, tmcli.Run("list"), RunExpected{
+				Stdout: "stacks/dev\nstacks/prod\n",
+			})
+			AssertRunResult(t, tmcli.Run("fmt", "--check"), RunExpected{})
+
+			provider, err := os.ReadFile(filepath.Join(rootdir, "stacks", "dev", "_terramate_generated_provider.tf"))
+			assert.NoError(t, err)
+			assert.IsTrue(t, strings.Contains(string(provider), tc.provider),
+				"generated provider %q does not contain %q", provider, tc.provider)
+		})
+	}
+
+	t.Run("subdirectory", func(t *testing.T) {
+		t.Parallel()
+		rootdir := t.TempDir()
+		tmcli := NewCLI(t, rootdir)
+		AssertRunResult(t,
+			tmcli.Run("init-project", "--profile", "aws", "--ci", "none", "infra"),
+			RunExpected{IgnoreStdout: true},
+		)
+		_, err := os.Stat(filepath.Join(rootdir, "infra", "terramate.tm.hcl"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("existing files are not overwritten", func(t *testing.T) {
+		t.Parallel()
+		rootdir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(rootdir, "globals.tm.hcl"), []byte("# keep\n"), 0644))
+		tmcli := NewCLI(t, rootdir)
+		AssertRunResult(t,
+			tmcli.Run("init-project", "--profile", "aws"),
+			RunExpected{
+				StderrRegex: "files already exist, use --force to overwrite them: globals.tm.hcl",
+				Status:      1,
+			},
+		)
+		_, err := os.Stat(filepath.Join(rootdir, "terramate.tm.hcl"))
+		assert.IsTrue(t, os.IsNotExist(err), "no file must be created when failing")
+
+		AssertRunResult(t,
+			tmcli.Run("init-project", "--profile", "aws", "--force"),
+			RunExpected{IgnoreStdout: true},
+		)
+		globals, err := os.ReadFile(filepath.Join(rootdir, "globals.tm.hcl"))
+		assert.NoError(t, err)
+		assert.IsTrue(t, string(globals) != "# keep\n", "globals.tm.hcl not overwritten")
+	})
+
+	t.Run("profile is required when not interactive", func(t *testing.T) {
+		t.Parallel()
+		tmcli := NewCLI(t, t.TempDir())
+		AssertRunResult(t,
+			tmcli.Run("init-project"),
+			RunExpected{
+				StderrRegex: "--profile is required when the input is not a terminal",
+				Status:      1,
+			},
+		)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		t.Parallel()
+		tmcli := NewCLI(t, t.TempDir())
+		AssertRunResult(t,
+			tmcli.Run("init-project", "--profile", "oracle"),
+			RunExpected{
+				StderrRegex: `unknown profile "oracle"`,
+				Status:      1,
+			},
+		)
+	})
+}
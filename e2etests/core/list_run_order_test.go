@@ -57,3 +57,35 @@ func TestListRunOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestListRunOrderTree(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:stack1:after=["/stack2"]`,
+		"s:stack2",
+		"s:stack2/child",
+		"s:stack3",
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("list", "--run-order", "--tree"), RunExpected{
+		Stdout: `Level 0
+├── stack2
+└── stack3
+Level 1
+└── stack2/child
+    └── after stack2: parent stack
+Level 2
+└── stack1
+    ├── after stack2: after "/stack2" of /stack1
+    └── after stack2/child: after "/stack2" of /stack1
+`,
+	})
+
+	AssertRunResult(t, cli.Run("list", "--tree"), RunExpected{
+		Status:      1,
+		StderrRegex: "--tree flag must be used together with --run-order",
+	})
+}
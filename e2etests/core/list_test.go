@@ -469,7 +469,9 @@ func TestListLogsWarningIfConfigHasSchemaIssues(t *testing.T) {
 				"s:stack",
 				`f:stack/terramate.tm:` + Terramate(
 					Config(
-						Block("generate"),
+						Block("generate",
+							Bool("fsync", true),
+						),
 					),
 				).String(),
 			},
@@ -477,10 +479,26 @@ func TestListLogsWarningIfConfigHasSchemaIssues(t *testing.T) {
 				Stdout: nljoin("stack"),
 				StderrRegexes: []string{
 					string(hcl.ErrTerramateSchema),
-					"block terramate\\.config\\.generate can only be declared at the project root directory",
+					"attribute terramate\\.config\\.generate\\.fsync can only be declared at the project root directory",
 				},
 			},
 		},
+		{
+			name: "terramate.config.generate.hcl_magic_header_comment_style in child dirs is allowed",
+			layout: []string{
+				"s:stack",
+				`f:stack/terramate.tm:` + Terramate(
+					Config(
+						Block("generate",
+							Str("hcl_magic_header_comment_style", "#"),
+						),
+					),
+				).String(),
+			},
+			want: RunExpected{
+				Stdout: nljoin("stack"),
+			},
+		},
 		{
 			name: "terramate.config.change_detection in child dirs do WARN",
 			layout: []string{
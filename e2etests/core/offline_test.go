@@ -0,0 +1,88 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestOffline(t *testing.T) {
+	t.Parallel()
+
+	const offlineErr = "network access disabled in offline mode"
+
+	// the vendoring failures are shown in the vendor report.
+	t.Run("remote modules are not vendored", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t,
+			tmcli.Run("--offline", "experimental", "vendor", "download", "github.com/terramate-io/example", "main"),
+			RunExpected{
+				StdoutRegex: offlineErr,
+			},
+		)
+	})
+
+	t.Run("local modules are vendored", func(t *testing.T) {
+		t.Parallel()
+		gitSource := newGitSource(t, "test.txt", "test")
+		s := sandbox.NoGit(t, true)
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t,
+			tmcli.Run("--offline", "experimental", "vendor", "download", gitSource, "main"),
+			RunExpected{IgnoreStdout: true},
+		)
+	})
+
+	t.Run("cloud sync fails", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree([]string{"s:stack:id=stack"})
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t,
+			tmcli.Run("--offline", "run", "--sync-deployment", "--", HelperPath, "true"),
+			RunExpected{
+				StderrRegex: offlineErr,
+				Status:      1,
+			},
+		)
+	})
+
+	t.Run("git remote safeguard fails", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.New(t)
+		s.BuildTree([]string{"s:stack"})
+		s.Git().CommitAll("all")
+		s.Git().Push("main")
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t,
+			tmcli.Run("--offline", "run", "--quiet", "--", HelperPath, "true"),
+			RunExpected{
+				StderrRegex: offlineErr,
+				Status:      1,
+			},
+		)
+		AssertRunResult(t,
+			tmcli.Run("--offline", "run", "--quiet", "--disable-safeguards=git-out-of-sync", "--", HelperPath, "echo", "ok"),
+			RunExpected{Stdout: "ok\n"},
+		)
+	})
+
+	t.Run("offline from the environment", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		tmcli := NewCLI(t, s.RootDir())
+		tmcli.AppendEnv = append(tmcli.AppendEnv, "TM_ARG_OFFLINE=true")
+		AssertRunResult(t,
+			tmcli.Run("experimental", "vendor", "download", "github.com/terramate-io/example", "main"),
+			RunExpected{
+				StdoutRegex: offlineErr,
+			},
+		)
+	})
+}
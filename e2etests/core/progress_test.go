@@ -0,0 +1,57 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestProgressNDJSON(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack-a",
+		"s:stack-b",
+		`f:gen.tm:generate_file "file.txt" {
+  content = terramate.stack.name
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+
+	t.Run("generate", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("generate", "--progress", "ndjson"), RunExpected{
+			IgnoreStdout: true,
+			StderrRegexes: []string{
+				`{"time":"[^"]+","phase":"evaluate","stack":"/stack-a","percent":\d+,"message":"evaluated"}`,
+				`{"time":"[^"]+","phase":"save","stack":"/stack-b","percent":\d+,"message":"saved"}`,
+				`{"time":"[^"]+","phase":"save","stack":"/stack-[ab]","percent":100,"message":"saved"}`,
+				`{"time":"[^"]+","phase":"generate","percent":100,"message":"finished"}`,
+			},
+		})
+	})
+
+	t.Run("run", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("run", "--quiet", "--progress", "ndjson", "--", HelperPath, "true"), RunExpected{
+			StderrRegexes: []string{
+				`{"time":"[^"]+","phase":"run","percent":0,"message":"running 2 stacks"}\n`,
+				`{"time":"[^"]+","phase":"run","stack":"/stack-a","percent":0,"message":"running"}`,
+				`{"time":"[^"]+","phase":"run","stack":"/stack-a","percent":50,"message":"succeeded"}`,
+				`{"time":"[^"]+","phase":"run","stack":"/stack-b","percent":100,"message":"succeeded"}`,
+				`{"time":"[^"]+","phase":"run","percent":100,"message":"finished"}\n`,
+			},
+		})
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("generate", "--progress", "xml"), RunExpected{
+			Status:      1,
+			StderrRegex: `must be one of "none","ndjson"`,
+		})
+	})
+}
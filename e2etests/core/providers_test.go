@@ -0,0 +1,222 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestProvidersGenerate(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm:terramate {
+  config {
+    experiments = ["providers"]
+  }
+}`,
+		`f:providers.tm:providers {
+  environment = global.env
+
+  provider "aws" {
+    source  = "hashicorp/aws"
+    version = "~> 5.0"
+    environments = {
+      prod = "= 5.31.0"
+    }
+  }
+
+  provider "null" {
+    source  = "hashicorp/null"
+    version = ">= 3.0"
+  }
+}`,
+		`f:globals.tm:globals {
+  env = "dev"
+}`,
+		"s:dev",
+		"s:prod",
+		`f:prod/globals.tm:globals {
+  env = "prod"
+}`,
+		"s:legacy",
+		`f:legacy/providers.tm:providers {
+  filename = "providers.tf"
+
+  provider "aws" {
+    version = "~> 4.0"
+  }
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+
+	header := genhcl.DefaultHeader()
+	test.AssertFileContentEquals(t, filepath.Join(s.RootDir(), "dev", "versions.tf"), header+`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = ">= 3.0"
+    }
+  }
+}
+`)
+	test.AssertFileContentEquals(t, filepath.Join(s.RootDir(), "prod", "versions.tf"), header+`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "= 5.31.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = ">= 3.0"
+    }
+  }
+}
+`)
+	test.AssertFileContentEquals(t, filepath.Join(s.RootDir(), "legacy", "providers.tf"), header+`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = ">= 3.0"
+    }
+  }
+}
+`)
+}
+
+func TestProvidersGenerateTerraformStacks(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm:terramate {
+  config {
+    experiments = ["providers"]
+  }
+}`,
+		`f:providers.tm:providers {
+  filename = "providers.tfstack.hcl"
+
+  provider "aws" {
+    source  = "hashicorp/aws"
+    version = "~> 5.0"
+  }
+}`,
+		"s:stack",
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	test.AssertFileContentEquals(t, filepath.Join(s.RootDir(), "stack", "providers.tfstack.hcl"), genhcl.DefaultHeader()+`required_providers {
+  aws = {
+    source  = "hashicorp/aws"
+    version = "~> 5.0"
+  }
+}
+`)
+
+	s.RootEntry().CreateFile("stack/providers.tm", `providers {
+  filename = "providers.tfdeploy.hcl"
+}`)
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:      1,
+		StdoutRegex: `must be in a \.tfstack\.hcl file`,
+	})
+}
+
+func TestProvidersBlockRequiresExperiment(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:providers.tm:providers {
+  provider "aws" {
+    source = "hashicorp/aws"
+  }
+}`,
+	})
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{
+		Status:      1,
+		StderrRegex: `block "providers" is an experimental feature`,
+	})
+}
+
+func TestProvidersCheck(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm:terramate {
+  config {
+    experiments = ["providers"]
+  }
+}`,
+		`f:providers.tm:providers {
+  provider "aws" {
+    source  = "hashicorp/aws"
+    version = "~> 5.0"
+  }
+}`,
+		"s:aligned",
+		`f:aligned/main.tf:terraform {
+  required_providers {
+    aws = {
+      source  = "registry.terraform.io/hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}`,
+		"s:drifted",
+		`f:drifted/main.tf:terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}`,
+		"s:tfstacks",
+		`f:tfstacks/components.tfstack.hcl:required_providers {
+  aws = {
+    source  = "hashicorp/aws"
+    version = "~> 3.0"
+  }
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("generate"), RunExpected{IgnoreStdout: true})
+	AssertRunResult(t, tm.Run("experimental", "providers", "check"), RunExpected{
+		Status: 1,
+		Stdout: "stack \"/drifted\": main.tf requires provider aws version \"~> 4.0\" but the central constraint is \"~> 5.0\" (defined at /providers.tm:2)\n" +
+			"stack \"/tfstacks\": components.tfstack.hcl requires provider aws version \"~> 3.0\" but the central constraint is \"~> 5.0\" (defined at /providers.tm:2)\n",
+		StderrRegex: "2 provider requirements drift from the central constraints",
+	})
+
+	s.RootEntry().CreateFile("drifted/main.tf", "")
+	s.RootEntry().CreateFile("tfstacks/components.tfstack.hcl", "")
+	AssertRunResult(t, tm.Run("experimental", "providers", "check"), RunExpected{})
+}
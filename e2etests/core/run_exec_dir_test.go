@@ -0,0 +1,47 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	"github.com/terramate-io/terramate/config"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRunStackExecDir(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/app:exec_dir=infra",
+		"d:stacks/app/infra",
+		"s:stacks/other",
+	})
+	cli := NewCLI(t, s.RootDir())
+
+	AssertRunResult(t, cli.Run("list"), RunExpected{
+		Stdout: nljoin("stacks/app", "stacks/other"),
+	})
+	AssertRunResult(t, cli.Run("run", "--quiet", "--", HelperPath, "stack-abs-path", s.RootDir()), RunExpected{
+		Stdout: nljoin("/stacks/app/infra", "/stacks/other"),
+	})
+}
+
+func TestRunStackExecDirOutsideStackFails(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/app:exec_dir=../other",
+		"s:stacks/other",
+	})
+	cli := NewCLI(t, s.RootDir())
+
+	AssertRunResult(t, cli.Run("run", "--quiet", "--", HelperPath, "stack-abs-path", s.RootDir()), RunExpected{
+		Status:      1,
+		StderrRegex: string(config.ErrStackInvalidExecDir),
+	})
+}
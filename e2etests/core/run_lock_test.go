@@ -0,0 +1,77 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRunStackLock(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:terramate.tm:terramate {
+  config {
+    run {
+      lock {
+        backend = "file"
+      }
+    }
+  }
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	tm.PrependToPath(filepath.Dir(HelperPath))
+	echo := []string{"run", "--quiet", "--", filepath.Base(HelperPath), "echo", "hello"}
+
+	// the lock is released after the execution.
+	AssertRunResult(t, tm.Run(echo...), RunExpected{Stdout: "hello\n"})
+	AssertRunResult(t, tm.Run(echo...), RunExpected{Stdout: "hello\n"})
+
+	// simulates a lock left behind by an aborted execution on another machine.
+	s.RootEntry().CreateDir(".terramate/locks").CreateFile("%2Fstack.lock", `{
+  "id": "0123456789abcdef",
+  "stack": "/stack",
+  "who": "ci@runner-1",
+  "when": "2026-01-02T03:04:05Z",
+  "purpose": "terramate run: terraform apply"
+}`)
+
+	AssertRunResult(t, tm.Run(echo...), RunExpected{
+		Status:      1,
+		StderrRegex: `stack is locked.*locked by ci@runner-1 at 2026-01-02T03:04:05Z \(purpose: terramate run: terraform apply`,
+	})
+
+	AssertRunResult(t, tm.Run("experimental", "unlock", "stack"), RunExpected{
+		Stdout: "Released lock of stack /stack: locked by ci@runner-1 at 2026-01-02T03:04:05Z " +
+			"(purpose: terramate run: terraform apply, lock ID: 0123456789abcdef)\n",
+	})
+
+	AssertRunResult(t, tm.Run("experimental", "unlock", "stack"), RunExpected{
+		Status:      1,
+		StderrRegex: "stack is not locked",
+	})
+
+	AssertRunResult(t, tm.Run(echo...), RunExpected{Stdout: "hello\n"})
+}
+
+func TestUnlockRequiresLockConfig(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("experimental", "unlock", "stack"), RunExpected{
+		Status:      1,
+		StderrRegex: "stack locking is not enabled",
+	})
+}
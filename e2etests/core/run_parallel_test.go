@@ -108,6 +108,21 @@ func TestParalleCmdNotFoundContinueOnError(t *testing.T) {
 	)
 }
 
+func TestParallelTUIRequiresTerminal(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+	tmcli := NewCLI(t, s.RootDir())
+	AssertRunResult(t,
+		tmcli.Run("run", "--parallel=2", "--tui", "--", HelperPath, "true"),
+		RunExpected{
+			Status:      1,
+			StderrRegex: "--tui requires the output to be a terminal",
+		},
+	)
+}
+
 func TestParallelSharingOutputFailure(t *testing.T) {
 	s := sandbox.NoGit(t, true)
 	layout := []string{
@@ -0,0 +1,85 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRunPolicy(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name string
+		ci   bool
+		args []string
+		want RunExpected
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "allowed command",
+			args: []string{"echo", "hello"},
+			want: RunExpected{
+				Stdout: "hello\n",
+			},
+		},
+		{
+			name: "command not allowed",
+			args: []string{"cat", "file.txt"},
+			want: RunExpected{
+				Status:      1,
+				StderrRegex: "does not match any of the allowed_commands patterns",
+			},
+		},
+		{
+			name: "denied flag outside CI",
+			args: []string{"echo", "-auto-approve"},
+			want: RunExpected{
+				Status:      1,
+				StderrRegex: "flag -auto-approve .* is only allowed in CI/CD",
+			},
+		},
+		{
+			name: "denied flag in CI",
+			ci:   true,
+			args: []string{"echo", "-auto-approve"},
+			want: RunExpected{
+				Stdout: "-auto-approve\n",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree([]string{
+				"s:stack",
+				`f:terramate.tm:terramate {
+  config {
+    run {
+      policy {
+        allowed_commands = ["* echo *"]
+        denied_flags     = ["-auto-approve"]
+      }
+    }
+  }
+}`,
+			})
+			var env []string
+			if tc.ci {
+				env = append(os.Environ(), "CI=true")
+			}
+			tm := NewCLI(t, s.RootDir(), env...)
+			tm.PrependToPath(filepath.Dir(HelperPath))
+			cmd := []string{"run", "--quiet", "--", filepath.Base(HelperPath)}
+			AssertRunResult(t, tm.Run(append(cmd, tc.args...)...), tc.want)
+		})
+	}
+}
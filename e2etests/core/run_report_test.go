@@ -0,0 +1,159 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	runcmd "github.com/terramate-io/terramate/commands/run"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRunReport(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack1",
+		"s:stack2",
+	})
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run(
+		"run", "--quiet", "--report", reportFile, "--",
+		HelperPath, "exit", "0",
+	), RunExpected{})
+
+	data, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+
+	var report runcmd.Report
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.EqualInts(t, 2, len(report.Stacks))
+	assert.EqualStrings(t, "exit", report.Command[1])
+
+	for i, want := range []string{"/stack1", "/stack2"} {
+		got := report.Stacks[i]
+		assert.EqualStrings(t, want, got.Stack)
+		assert.EqualStrings(t, "ok", got.Status)
+		assert.EqualInts(t, 0, got.ExitCode)
+		if got.StartedAt == nil || got.FinishedAt == nil {
+			t.Fatalf("stack %s: missing start and finish times", want)
+		}
+		if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+			if got.UserCPUMS == nil || got.SystemCPUMS == nil {
+				t.Fatalf("stack %s: missing CPU times", want)
+			}
+			if got.MaxRSSBytes == nil || *got.MaxRSSBytes <= 0 {
+				t.Fatalf("stack %s: missing peak memory", want)
+			}
+		}
+	}
+}
+
+func TestRunReportFailedStack(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run(
+		"run", "--quiet", "--report", reportFile, "--", HelperPath, "exit", "3",
+	), RunExpected{
+		Status:      1,
+		StderrRegex: "one or more commands failed",
+	})
+
+	data, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+
+	var report runcmd.Report
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.EqualInts(t, 1, len(report.Stacks))
+	assert.EqualStrings(t, "failed", report.Stacks[0].Status)
+	assert.EqualInts(t, 3, report.Stacks[0].ExitCode)
+}
+
+func TestRunReportGroupsIdenticalFailures(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack1:id=8f14e45f-ceea-467f-a0e6-1f5b0b3b4b1a",
+		"s:stack2:id=c9f0f895-fb98-4b91-9f5c-4b5a2c3d4e5f",
+		"s:stack3:id=45c48cce-2e2d-4fbd-8a7c-0d9e8f7a6b5c",
+		"s:other",
+	})
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run(
+		"run", "--quiet", "--continue-on-error", "--report", reportFile, "--eval", "--",
+		HelperPathAsHCL, "fail",
+		`${terramate.stack.path.absolute == "/other" ? "permission denied" : "ExpiredToken: request ${terramate.stack.id} failed at ${terramate.stack.path.absolute}"}`,
+	), RunExpected{
+		Status:      1,
+		StderrRegex: `3 stacks failed with the same error \(fingerprint [0-9a-f]{12}\): /stack1, /stack2, /stack3`,
+	})
+
+	data, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+
+	var report runcmd.Report
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.EqualInts(t, 2, len(report.Failures))
+
+	group := report.Failures[0]
+	assert.EqualInts(t, 3, group.Count)
+	assert.EqualStrings(t, "/stack1 /stack2 /stack3", strings.Join(group.Stacks, " "))
+	assert.EqualStrings(t, "ExpiredToken: request 8f14e45f-ceea-467f-a0e6-1f5b0b3b4b1a failed at /stack1", group.Log)
+
+	other := report.Failures[1]
+	assert.EqualInts(t, 1, other.Count)
+	assert.EqualStrings(t, "permission denied", other.Log)
+
+	for _, st := range report.Stacks {
+		want := group.Fingerprint
+		if st.Stack == "/other" {
+			want = other.Fingerprint
+		}
+		assert.EqualStrings(t, want, st.ErrorFingerprint, "stack %s", st.Stack)
+	}
+}
+
+func TestRunDedupErrorsPrintsIdenticalFailuresOnce(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack1",
+		"s:stack2",
+		"s:stack3",
+		"s:other",
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	res := tm.Run(
+		"run", "--quiet", "--continue-on-error", "--dedup-errors", "--eval", "--",
+		HelperPathAsHCL, "fail",
+		`${terramate.stack.path.absolute == "/other" ? "permission denied" : "ExpiredToken: request failed"}`,
+	)
+	AssertRunResult(t, res, RunExpected{
+		Status:      1,
+		StderrRegex: `3 stacks failed with the same error \(fingerprint [0-9a-f]{12}\): /stack1, /stack2, /stack3`,
+	})
+	// the errors of the commands also show up in the summary of the failures.
+	assert.EqualInts(t, 1, strings.Count(res.Stderr, "\nExpiredToken: request failed\n"), "stderr: %s", res.Stderr)
+	assert.EqualInts(t, 1, strings.Count(res.Stderr, "\npermission denied\n"), "stderr: %s", res.Stderr)
+}
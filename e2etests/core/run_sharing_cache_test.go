@@ -0,0 +1,116 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestRunSharingOutputsCache(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:exp.tm:` + Terramate(
+			Config(
+				Experiments("outputs-sharing"),
+			),
+		).String(),
+		`f:sharing.tm:` + Block("sharing_backend",
+			Labels("cat"),
+			Command(HelperPath, "cat", "outputs.json"),
+			Str("filename", "_sharing.tf"),
+			Expr("type", "terraform"),
+		).String(),
+		`s:s1:id=s1`,
+		`f:s1/outputs.tm:` + Output(
+			Labels("name"),
+			Str("backend", "cat"),
+			Expr("value", "module.something")).String(),
+		`s:s2:id=s2;after=["/s1"]`,
+		`f:s2/inputs.tm:` + Input(
+			Labels("name"),
+			Str("backend", "cat"),
+			Str("from_stack_id", "s1"),
+			Expr("value", "outputs.name.value")).String(),
+	})
+	s1 := s.DirEntry("s1")
+	setOutput := func(value string) {
+		s1.CreateFile("outputs.json", `{"name": {"value": "`+value+`"}}`)
+	}
+	setState := func(serial string) {
+		s1.CreateFile("terraform.tfstate", `{"version": 4, "serial": `+serial+`, "lineage": "s1-lineage"}`)
+	}
+	setOutput("first")
+	setState("1")
+
+	tmcli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tmcli.Run("generate"), RunExpected{
+		StdoutRegex: regexp.QuoteMeta("[+] _sharing.tf"),
+	})
+
+	run := func(want string, extraArgs ...string) {
+		t.Helper()
+		args := []string{"run", "--enable-sharing", "--quiet"}
+		args = append(args, extraArgs...)
+		args = append(args, "--", HelperPath, "env", s.RootDir(), "TF_VAR_name")
+		AssertRunResult(t, tmcli.Run(args...), RunExpected{
+			Stdout: `/s2: "` + want + `"` + "\n",
+		})
+	}
+
+	run("first")
+
+	// the state didn't change, so the cached outputs are used.
+	setOutput("second")
+	run("first")
+
+	run("second", "--refresh-outputs")
+
+	setOutput("third")
+	setState("2")
+	run("third")
+}
+
+func TestDebugShowRunEnvEnableSharing(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:exp.tm:` + Terramate(
+			Config(
+				Experiments("outputs-sharing"),
+			),
+		).String(),
+		`f:sharing.tm:` + Block("sharing_backend",
+			Labels("cat"),
+			Command(HelperPath, "cat", "outputs.json"),
+			Str("filename", "_sharing.tf"),
+			Expr("type", "terraform"),
+		).String(),
+		`s:s1:id=s1`,
+		`f:s1/outputs.json:{"name": {"value": "first"}}`,
+		`s:s2:id=s2;after=["/s1"]`,
+		`f:s2/inputs.tm:` + Input(
+			Labels("name"),
+			Str("backend", "cat"),
+			Str("from_stack_id", "s1"),
+			Expr("value", "outputs.name.value")).String(),
+	})
+
+	tmcli := NewCLI(t, s.DirEntry("s2").Path())
+	AssertRunResult(t, tmcli.Run("debug", "show", "run-env", "--enable-sharing"), RunExpected{
+		StdoutRegex: regexp.QuoteMeta(`TF_VAR_name="first"`),
+	})
+	res := tmcli.Run("debug", "show", "run-env")
+	AssertRunResult(t, res, RunExpected{IgnoreStdout: true})
+	assert.IsTrue(t, !strings.Contains(res.Stdout, "TF_VAR_name"), "unexpected input variable: %s", res.Stdout)
+}
@@ -693,7 +693,7 @@ func TestRunOutputDependencies(t *testing.T) {
 
 		expected := RunExpected{
 			Status:      1,
-			StderrRegex: regexp.QuoteMeta("--include-output-dependencies requires the 'outputs-sharing' experiment enabled"),
+			StderrRegex: regexp.QuoteMeta("--include-output-dependencies is an experimental feature, it must be enabled before usage with `terramate.config.experiments = [\"outputs-sharing\"]`"),
 		}
 		tmcli := NewCLI(t, s.RootDir())
 		AssertRunResult(t, tmcli.Run("run", "-X", "--include-output-dependencies", "--", HelperPath, "stack-abs-path", s.RootDir()), expected)
@@ -4,6 +4,7 @@
 package core_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/madlambda/spells/assert"
 	runcmd "github.com/terramate-io/terramate/commands/run"
 	"github.com/terramate-io/terramate/ui/tui/cliconfig"
 
@@ -1751,6 +1753,31 @@ func TestRunReverseExecution(t *testing.T) {
 	assertRunOrder("stack-3", "stack-2", "stack-1")
 }
 
+func TestRunReverseFailsWithAmbiguousWants(t *testing.T) {
+	t.Parallel()
+
+	const testfile = "testfile"
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`s:app:wants=["/network"]`,
+		`s:network`,
+		fmt.Sprintf("f:app/%s:app\n", testfile),
+		fmt.Sprintf("f:network/%s:network\n", testfile),
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("run", "--quiet", "--reverse", HelperPath, "cat", testfile), RunExpected{
+		Status:      1,
+		StderrRegex: `stack /app wants /network but their run order is not defined`,
+	})
+
+	s.BuildTree([]string{`s:app:wants=["/network"];after=["/network"]`})
+	AssertRunResult(t, cli.Run("run", "--quiet", "--reverse", HelperPath, "cat", testfile), RunExpected{
+		Stdout: "app\nnetwork\n",
+	})
+}
+
 func TestRunIgnoresAfterBeforeStackRefsOutsideWorkingDirAndTagFilter(t *testing.T) {
 	t.Parallel()
 
@@ -3325,6 +3352,33 @@ stack "/stack":
 			IgnoreStderr: true,
 			Stdout:       want})
 	})
+
+	t.Run("RunEnvJSON", func(t *testing.T) {
+		res := tm.Run("debug", "show", "run-env", "--format", "json")
+		AssertRunResult(t, res, RunExpected{
+			IgnoreStdout: true,
+			IgnoreStderr: true,
+		})
+
+		var got struct {
+			Stacks []struct {
+				Dir string            `json:"dir"`
+				Env map[string]string `json:"env"`
+			} `json:"stacks"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(res.Stdout), &got))
+		assert.EqualInts(t, 1, len(got.Stacks))
+		assert.EqualStrings(t, "/stack", got.Stacks[0].Dir)
+
+		var resolved []string
+		for name, value := range got.Stacks[0].Env {
+			if name != "TM_CLI_CONFIG_FILE" {
+				resolved = append(resolved, name+"="+value)
+			}
+		}
+		sort.Strings(resolved)
+		test.AssertDiff(t, resolved, gotenv)
+	})
 }
 
 func nljoin(stacks ...string) string {
@@ -3345,3 +3399,53 @@ func testEnviron(t *testing.T) []string {
 	}
 	return env
 }
+
+func TestRunCheckSelectedGenCode(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:outdated",
+		"s:updated",
+		`f:outdated/generate.tm.hcl:generate_hcl "backend.tf" {
+  content {
+    terraform {
+      backend "local" {}
+    }
+  }
+}`,
+	})
+
+	tmcli := NewCLI(t, s.RootDir())
+
+	t.Run("whole project is checked by the outdated-code safeguard", func(t *testing.T) {
+		AssertRunResult(t, tmcli.Run("run", "--check-selected-gen-code", "--", HelperPath, "true"), RunExpected{
+			Status:      defaultErrExitStatus,
+			StderrRegex: string(runcmd.ErrOutdatedGenCodeDetected),
+		})
+	})
+
+	t.Run("selected stacks without outdated code run", func(t *testing.T) {
+		AssertRunResult(t, tmcli.Run("run", "--quiet",
+			"--disable-safeguards=outdated-code", "--check-selected-gen-code",
+			"--stack", "/updated", "--", HelperPath, "echo", "ok"), RunExpected{
+			Stdout: "ok\n",
+		})
+	})
+
+	t.Run("selected stacks with outdated code fail listing the files", func(t *testing.T) {
+		AssertRunResult(t, tmcli.Run("run", "--quiet",
+			"--disable-safeguards=outdated-code", "--check-selected-gen-code",
+			"--", HelperPath, "echo", "ok"), RunExpected{
+			Status:      defaultErrExitStatus,
+			StderrRegex: string(runcmd.ErrOutdatedGenCodeDetected) + `: please run[^\n]+:\s+outdated/backend.tf`,
+		})
+	})
+
+	t.Run("check is disabled by default", func(t *testing.T) {
+		AssertRunResult(t, tmcli.Run("run", "--quiet",
+			"--disable-safeguards=outdated-code", "--", HelperPath, "echo", "ok"), RunExpected{
+			Stdout: "ok\nok\n",
+		})
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestScriptRunApproval(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		layout []string
+		stdin  string
+		env    []string
+		want   RunExpected
+	}
+
+	layout := []string{
+		`f:terramate.tm:
+		  terramate {
+			config {
+			  experiments = ["scripts"]
+			}
+		  }`,
+		"s:stack",
+		`f:stack/script.tm:
+		  script "deploy" {
+			job {
+			  command = ["echo", "planned"]
+			}
+			job {
+			  approval {
+				message = "apply the plan?"
+				file    = "approved.txt"
+			  }
+			}
+			job {
+			  command = ["echo", "applied"]
+			}
+		  }`,
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:   "approved interactively",
+			layout: layout,
+			stdin:  "yes\n",
+			want: RunExpected{
+				Stdout: "planned\napplied\n",
+				StderrRegexes: []string{
+					`/stack \(script:0 job:1.0\)> apply the plan\? \[y/N\]`,
+					`approved by `,
+				},
+			},
+		},
+		{
+			name:   "denied interactively",
+			layout: layout,
+			stdin:  "no\n",
+			want: RunExpected{
+				Status:      1,
+				Stdout:      "planned\n",
+				StderrRegex: "approval denied",
+			},
+		},
+		{
+			name:   "no answer is a denial",
+			layout: layout,
+			want: RunExpected{
+				Status:      1,
+				Stdout:      "planned\n",
+				StderrRegex: "approval denied",
+			},
+		},
+		{
+			name: "approved by file",
+			layout: append(layout[:len(layout):len(layout)],
+				"f:stack/approved.txt:release-managers\n",
+			),
+			want: RunExpected{
+				Stdout:      "planned\napplied\n",
+				StderrRegex: "approved by release-managers",
+			},
+		},
+		{
+			name:   "CI requires an approval file",
+			layout: layout,
+			stdin:  "yes\n",
+			env:    []string{"CIRCLECI=true"},
+			want: RunExpected{
+				Status:      1,
+				Stdout:      "planned\n",
+				StderrRegex: "interactive approval is not possible in CI/CD",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.New(t)
+			s.BuildTree(tc.layout)
+			s.Git().CommitAll("everything")
+
+			env := RemoveEnv(os.Environ(), "CI", "GITHUB_ACTIONS", "GITHUB_TOKEN")
+			env = append(env, tc.env...)
+
+			tm := NewCLI(t, s.RootDir(), env...)
+			AssertRunResult(t, tm.RunWithStdin(tc.stdin, "script", "run", "deploy"), tc.want)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestScriptRunSkipNoOpPlans(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake terraform is a shell script")
+	}
+
+	const (
+		changedPlan = `{"format_version": "1.2", "resource_changes": [{"address": "null_resource.a", "change": {"actions": ["create"]}}]}`
+		noOpPlan    = `{"format_version": "1.2", "resource_changes": [{"address": "null_resource.a", "change": {"actions": ["no-op"]}}]}`
+	)
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm:
+		  terramate {
+			config {
+			  experiments = ["scripts"]
+			}
+		  }`,
+		"s:changed",
+		"s:noop",
+		"f:changed/plan.json:" + changedPlan,
+		"f:noop/plan.json:" + noOpPlan,
+		`f:script.tm:
+		  script "deploy" {
+			job {
+			  commands = [
+			    ["echo", "plan", { terraform_plan_file = "plan.json" }],
+			    ["echo", "apply"],
+			  ]
+			}
+		  }`,
+	})
+
+	// the fake terraform shows the plan file, which is already the JSON plan.
+	bindir := test.TempDir(t)
+	test.WriteFile(t, bindir, "terraform", "#!/bin/sh\nfor last; do :; done\ncat \"$last\"\n")
+	if err := os.Chmod(filepath.Join(bindir, "terraform"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewCLI(t, s.RootDir())
+	tm.PrependToPath(bindir)
+
+	t.Run("without the flag all commands run", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("script", "run", "--quiet", "deploy"), RunExpected{
+			Stdout: "plan\napply\nplan\napply\n",
+		})
+	})
+
+	t.Run("commands after a no-op plan are skipped", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("script", "run", "--skip-no-op-plans", "deploy"), RunExpected{
+			Stdout:      "plan\napply\nplan\n",
+			StderrRegex: `Plan of stack /noop has no changes, skipping the remaining commands`,
+		})
+	})
+}
+
+func TestRunSkipNoOpPlansRequiresPlanFile(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.Run("run", "--skip-no-op-plans", "--", "echo", "apply"), RunExpected{
+		Status:      1,
+		StderrRegex: "--skip-no-op-plans requires --terraform-plan-file or --tofu-plan-file",
+	})
+}
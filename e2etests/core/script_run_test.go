@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/madlambda/spells/assert"
 	. "github.com/terramate-io/terramate/e2etests/internal/runner"
 
 	"github.com/terramate-io/terramate/test/sandbox"
@@ -252,6 +254,29 @@ func TestScriptRun(t *testing.T) {
 					"hello1" + "\n",
 			},
 		},
+		{
+			name: "script jobs hand off artifacts",
+			layout: []string{
+				terramateConfig,
+				"s:stack-a",
+				`f:stack-a/script.tm:
+				script "deploy" {
+				  description = "plan and apply"
+				  job {
+					artifacts = ["plan"]
+					command   = ["` + HelperPath + `", "write", artifact.plan, "planned"]
+				  }
+				  job {
+					command = ["` + HelperPath + `", "cat", artifact.plan]
+				  }
+				}`,
+			},
+			runScript: []string{"deploy"},
+			want: RunExpected{
+				Stdout:       "planned",
+				IgnoreStderr: true,
+			},
+		},
 		{
 			name: "unknown script should return exit code",
 			layout: []string{
@@ -462,6 +487,73 @@ func TestScriptRun(t *testing.T) {
 				FlattenStdout: true,
 			},
 		},
+		{
+			name: "script arguments are available in the job commands",
+			layout: []string{
+				terramateConfig,
+				"s:stack-a",
+				`f:script.tm:
+				script "deploy" {
+				  arg "env" {
+				    description = "environment to deploy"
+				  }
+				  arg "replicas" {
+				    type    = number
+				    default = 1
+				  }
+				  job {
+				    command = ["echo", "${script.args.env}:${script.args.replicas}"]
+				  }
+				}`,
+			},
+			runScript: []string{"deploy", "--", "--env=prod", "--replicas", "3"},
+			want: RunExpected{
+				Stdout:      "prod:3\n",
+				StderrRegex: "/stack-a \\(script:0 job:0.0\\)> echo prod:3",
+			},
+		},
+		{
+			name: "script arguments are validated",
+			layout: []string{
+				terramateConfig,
+				"s:stack-a",
+				`f:script.tm:
+				script "deploy" {
+				  arg "env" {
+				    validation {
+				      condition     = tm_contains(["dev", "prod"], script.args.env)
+				      error_message = "env must be dev or prod"
+				    }
+				  }
+				  job {
+				    command = ["echo", script.args.env]
+				  }
+				}`,
+			},
+			runScript: []string{"deploy", "--", "--env=qa"},
+			want: RunExpected{
+				StderrRegex: "env must be dev or prod",
+				Status:      1,
+			},
+		},
+		{
+			name: "unknown script arguments fail",
+			layout: []string{
+				terramateConfig,
+				"s:stack-a",
+				`f:script.tm:
+				script "deploy" {
+				  job {
+				    command = ["echo", "hello"]
+				  }
+				}`,
+			},
+			runScript: []string{"deploy", "--", "--env=qa"},
+			want: RunExpected{
+				StderrRegex: "script does not declare the argument \"env\"",
+				Status:      1,
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -564,3 +656,94 @@ func TestRunScriptOnChangedStacks(t *testing.T) {
 		})
 
 }
+
+func TestScriptRunDependsOnFiles(t *testing.T) {
+	t.Parallel()
+
+	layout := []string{
+		`f:terramate.tm:
+		  terramate {
+			config {
+			  experiments = ["scripts"]
+			}
+		  }`,
+		"s:app",
+		"s:network",
+		"f:network/main.tf:# network",
+		`f:app/script.tm:
+		  script "deploy" {
+			job {
+			  depends_on_files = ["../network/outputs.json"]
+			  command          = ["` + HelperPath + `", "cat", "../network/outputs.json"]
+			}
+		  }`,
+		`f:network/script.tm:
+		  script "deploy" {
+			job {
+			  command = ["` + HelperPath + `", "write", "outputs.json", "{}"]
+			}
+		  }`,
+	}
+
+	t.Run("producing stack runs first", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		cli := NewCLI(t, s.RootDir())
+		AssertRunResult(t, cli.RunScript("--quiet", "deploy"), RunExpected{Stdout: "{}"})
+	})
+
+	t.Run("producing stack is added to the selection", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		cli := NewCLI(t, filepath.Join(s.RootDir(), "app"))
+		AssertRunResult(t, cli.RunScript("--quiet", "deploy"), RunExpected{Stdout: "{}"})
+		assert.EqualStrings(t, "{}", string(s.RootEntry().ReadFile("network/outputs.json")))
+	})
+
+	t.Run("missing file fails", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		cli := NewCLI(t, filepath.Join(s.RootDir(), "app"))
+		AssertRunResult(t, cli.RunScript("--quiet", "--no-recursive", "deploy"), RunExpected{
+			StderrRegex: `stack /app depends on file /network/outputs.json`,
+			Status:      1,
+		})
+	})
+
+	t.Run("outdated file fails", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		outputs := filepath.Join(s.RootDir(), "network", "outputs.json")
+		assert.NoError(t, os.WriteFile(outputs, []byte("{}"), 0644))
+		old := time.Now().Add(-time.Hour)
+		assert.NoError(t, os.Chtimes(outputs, old, old))
+		cli := NewCLI(t, filepath.Join(s.RootDir(), "app"))
+		AssertRunResult(t, cli.RunScript("--quiet", "--no-recursive", "deploy"), RunExpected{
+			StderrRegex: `depends on file /network/outputs.json which is older than /network/`,
+			Status:      1,
+		})
+	})
+
+	t.Run("file older than the inputs in subdirectories fails", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		outputs := filepath.Join(s.RootDir(), "network", "outputs.json")
+		assert.NoError(t, os.WriteFile(outputs, []byte("{}"), 0644))
+		old := time.Now().Add(-time.Hour)
+		for _, file := range []string{"main.tf", "script.tm", "stack.tm.hcl", "outputs.json"} {
+			path := filepath.Join(s.RootDir(), "network", file)
+			assert.NoError(t, os.Chtimes(path, old, old))
+		}
+		s.RootEntry().CreateFile("network/modules/vpc/main.tf", "# vpc")
+		cli := NewCLI(t, filepath.Join(s.RootDir(), "app"))
+		AssertRunResult(t, cli.RunScript("--quiet", "--no-recursive", "deploy"), RunExpected{
+			StderrRegex: `depends on file /network/outputs.json which is older than /network/modules/vpc/main.tf`,
+			Status:      1,
+		})
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestSensitiveGlobalsAreMasked(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals {
+  user = "admin"
+  dsn  = "${global.user}:${global.password}"
+
+  global "password" {
+    value     = "s3cr3t"
+    sensitive = true
+  }
+}`,
+		`f:terramate.tm:terramate {
+  config {
+    run {
+      env {
+        DB_USER     = global.user
+        DB_PASSWORD = global.password
+      }
+    }
+  }
+}`,
+	})
+
+	tm := NewCLI(t, s.RootDir())
+	stacktm := NewCLI(t, s.RootDir()+"/stack")
+
+	t.Run("debug show globals", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("debug", "show", "globals"), RunExpected{
+			Stdout: `
+stack "/stack":
+	dsn      = "(sensitive value)"
+	password = "(sensitive value)"
+	user     = "admin"
+`,
+		})
+	})
+
+	t.Run("debug show run-env", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("debug", "show", "run-env", "--format", "json"), RunExpected{
+			StdoutRegexes: []string{
+				`"DB_PASSWORD": "\(sensitive value\)"`,
+				`"DB_USER": "admin"`,
+			},
+		})
+	})
+
+	t.Run("debug show runtime-env", func(t *testing.T) {
+		AssertRunResult(t, tm.Run("debug", "show", "runtime-env"), RunExpected{
+			Stdout: `
+stack "/stack":
+	DB_PASSWORD=(sensitive value)
+	DB_USER=admin
+`,
+		})
+	})
+
+	t.Run("experimental eval", func(t *testing.T) {
+		AssertRunResult(t, stacktm.Run("experimental", "eval", "global.dsn", "global.user"), RunExpected{
+			Stdout: "(sensitive value)\nadmin\n",
+		})
+	})
+
+	t.Run("experimental get-config-value", func(t *testing.T) {
+		AssertRunResult(t, stacktm.Run("experimental", "get-config-value", "global.password"), RunExpected{
+			Stdout: "(sensitive value)\n",
+		})
+	})
+
+	t.Run("command shown by run is redacted", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("uses the sh shell")
+		}
+		AssertRunResult(t, tm.Run("run", "--quiet", "--eval", "--", "sh", "-c", "echo ${global.password} | wc -c"), RunExpected{
+			Stdout: "7\n",
+		})
+		AssertRunResult(t, tm.Run("run", "--eval", "--", "sh", "-c", "echo ${global.password} > /dev/null"), RunExpected{
+			StderrRegex: `Executing command "sh -c \(sensitive value\)"`,
+		})
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !darwin
+
+package core_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/commands/experimental/serve"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestServeAnswersQueriesAboutTheLoadedProject(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`s:stacks/a:id=a`,
+		`s:stacks/b:id=b;after=["/stacks/a"]`,
+		`f:terramate.tm:terramate {
+  config {
+    change_detection {
+      git {
+        untracked   = "off"
+        uncommitted = "off"
+      }
+    }
+  }
+}`,
+		`f:stacks/a/globals.tm:globals {
+  region = "eu-west-1"
+  names  = ["a", tm_upper("b")]
+}`,
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+	git.CheckoutNew("change-b")
+	s.RootEntry().CreateFile("stacks/b/main.tf", "# changed")
+	git.CommitAll("change stack b")
+
+	tm := NewCLI(t, s.RootDir())
+	cmd := tm.NewCmd("experimental", "serve")
+	cmd.Setpgid()
+	cmd.Start()
+
+	errs := make(chan error)
+	go func() {
+		errs <- cmd.Wait()
+		close(errs)
+	}()
+
+	listening := pollServeAddress(t, cmd)
+	url := strings.TrimPrefix(listening, "Listening on ")
+
+	call := func(method string, params any) serve.Response {
+		t.Helper()
+		req := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+		}
+		if params != nil {
+			req["params"] = params
+		}
+		body, err := json.Marshal(req)
+		assert.NoError(t, err)
+		resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+		assert.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		var got serve.Response
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		return got
+	}
+
+	assertStacks := func(got serve.Response, want []string) {
+		t.Helper()
+		if got.Error != nil {
+			t.Fatalf("unexpected error: %+v", got.Error)
+		}
+		data, err := json.Marshal(got.Result)
+		assert.NoError(t, err)
+		var res serve.StacksResult
+		assert.NoError(t, json.Unmarshal(data, &res))
+		var dirs []string
+		for _, st := range res.Stacks {
+			dirs = append(dirs, st.Dir)
+		}
+		if diff := cmp.Diff(want, dirs); diff != "" {
+			t.Fatalf("unexpected stacks: %s", diff)
+		}
+	}
+
+	assertStacks(call(serve.MethodStacksList, nil), []string{"/stacks/a", "/stacks/b"})
+	assertStacks(call(serve.MethodStacksChanged, nil), []string{"/stacks/b"})
+	assertStacks(call(serve.MethodStacksRunOrder, nil), []string{"/stacks/a", "/stacks/b"})
+	assertStacks(call(serve.MethodStacksRunOrder, map[string]any{"changed": true}), []string{"/stacks/b"})
+
+	globals := call(serve.MethodStackGlobals, map[string]any{"stack": "/stacks/a"})
+	if globals.Error != nil {
+		t.Fatalf("unexpected error: %+v", globals.Error)
+	}
+	data, err := json.Marshal(globals.Result)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, `{"globals":{"names":["a","B"],"region":"eu-west-1"},"stack":"/stacks/a"}`, string(data))
+
+	got := call(serve.MethodStackGlobals, map[string]any{"stack": "/stacks"})
+	if got.Error == nil {
+		t.Fatalf("want error for a directory that is not a stack, got: %+v", got.Result)
+	}
+	got = call("stacks.unknown", nil)
+	if got.Error == nil || got.Error.Code != -32601 {
+		t.Fatalf("want method not found error, got: %+v", got.Error)
+	}
+
+	// the project is reloaded when its files or the git HEAD change.
+	s.BuildTree([]string{`s:stacks/c`})
+	assertStacks(call(serve.MethodStacksList, nil), []string{"/stacks/a", "/stacks/b", "/stacks/c"})
+	assertStacks(call(serve.MethodStacksChanged, nil), []string{"/stacks/b"})
+
+	// committing changes only the HEAD.
+	git.CommitAll("add stack c")
+	assertStacks(call(serve.MethodStacksChanged, nil), []string{"/stacks/b", "/stacks/c"})
+	assertStacks(call(serve.MethodProjectReload, nil), []string{"/stacks/a", "/stacks/b", "/stacks/c"})
+
+	resp, err := http.Post(url, "text/plain", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"stacks.list"}`))
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.EqualInts(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	SendUntilMsgIsReceived(t, cmd, os.Interrupt, listening, "Server stopped")
+	assert.NoError(t, <-errs)
+}
+
+func pollServeAddress(t *testing.T, cmd *Cmd) string {
+	t.Helper()
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		for _, line := range strings.Split(cmd.Stdout.String(), "\n") {
+			if strings.HasPrefix(line, "Listening on http://") {
+				return line
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+	t.Fatalf("server not listening: stdout: %s stderr: %s", cmd.Stdout.String(), cmd.Stderr.String())
+	return ""
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestStackExtendsTemplateChangeDetection(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`f:templates/base/template.tm.hcl:terramate {
+  config {
+    stack_defaults {
+      tags = ["base"]
+    }
+  }
+}
+
+generate_hcl "backend.tf" {
+  content {
+    backend = "local"
+  }
+}
+`,
+		"s:stacks/a:extends=/templates/base",
+		"s:stacks/b:extends=/templates/base",
+		"s:stacks/c",
+	})
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("generate"), RunExpected{IgnoreStdout: true})
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+
+	AssertRunResult(t, cli.Run("list", "--tags", "base"), RunExpected{
+		Stdout: nljoin("stacks/a", "stacks/b"),
+	})
+
+	git.CheckoutNew("change-template")
+	s.RootEntry().CreateFile("templates/base/README.md", "# base stack template")
+	git.CommitAll("template changed")
+
+	AssertRunResult(t, cli.ListChangedStacks(), RunExpected{
+		Stdout: nljoin("stacks/a", "stacks/b"),
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestStackSelectionFlag(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		"s:stacks/c",
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("list", "--stack", "/stacks/a", "--stack=/stacks/c"), RunExpected{
+		Stdout: nljoin("stacks/a", "stacks/c"),
+	})
+	AssertRunResult(t, cli.Run("run", "--quiet", "--stack", "/stacks/b", "--", HelperPath, "echo", "hello"), RunExpected{
+		Stdout: nljoin("hello"),
+	})
+
+	cli = NewCLI(t, s.DirEntry("stacks").Path())
+	AssertRunResult(t, cli.Run("list", "--stack", "b"), RunExpected{
+		Stdout: nljoin("b"),
+	})
+	AssertRunResult(t, cli.Run("list", "--stack", "/stacks/d"), RunExpected{
+		Status:      1,
+		StderrRegex: "--stack /stacks/d is not one of the selected stacks",
+	})
+}
+
+func TestGenerateStackSelection(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:gen.tm:generate_file "file.txt" {
+  content = terramate.stack.name
+}
+
+generate_file "/root.txt" {
+  context = root
+  content = "root"
+}`,
+	})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("generate", "--stack", "/stacks/b"), RunExpected{
+		Stdout: `Code generation report
+
+Successes:
+
+- /stacks/b
+	[+] file.txt
+
+Hint: '+', '~' and '-' mean the file was created, changed and deleted, respectively.
+`,
+	})
+	AssertRunResult(t, cli.Run("generate", "--stack", "/stacks/c"), RunExpected{
+		Status:      1,
+		StderrRegex: "--stack /stacks/c is not one of the selected stacks",
+	})
+	AssertRunResult(t, cli.Run("generate", "--stack", "/stacks/a", "--check"), RunExpected{
+		Status:      1,
+		StderrRegex: "--pick and --stack conflict with --check",
+	})
+	test.DoesNotExist(t, s.DirEntry("stacks/a").Path(), "file.txt")
+	test.DoesNotExist(t, s.RootDir(), "root.txt")
+
+	AssertRunResult(t, cli.Run("generate"), RunExpected{IgnoreStdout: true})
+	assert.EqualStrings(t, "a", string(s.DirEntry("stacks/a").ReadFile("file.txt")))
+	assert.EqualStrings(t, "b", string(s.DirEntry("stacks/b").ReadFile("file.txt")))
+	assert.EqualStrings(t, "root", string(s.RootEntry().ReadFile("root.txt")))
+}
+
+func TestStackPickerRequiresTerminal(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{"s:stack"})
+
+	cli := NewCLI(t, s.RootDir())
+	AssertRunResult(t, cli.Run("list", "--pick"), RunExpected{
+		Status:      1,
+		StderrRegex: "the stack picker requires a terminal",
+	})
+	AssertRunResult(t, cli.Run("generate", "--pick"), RunExpected{
+		Status:      1,
+		StderrRegex: "the stack picker requires a terminal",
+	})
+	AssertRunResult(t, cli.Run("run", "--pick", "--", HelperPath, "true"), RunExpected{
+		Status:      1,
+		StderrRegex: "the stack picker requires a terminal",
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestUnrecognizedConfigSuggestions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "top-level block",
+			config: `generate_hcll "file.hcl" {}`,
+			want:   `unrecognized block "generate_hcll", did you mean "generate_hcl"\?`,
+		},
+		{
+			name: "generate_file attribute",
+			config: `generate_file "file.txt" {
+				condtion = true
+				content = "test"
+			}`,
+			want: `An argument named "condtion" is not expected here, did you mean "condition"\?`,
+		},
+		{
+			name: "stack attribute",
+			config: `stack {
+				tag = ["test"]
+			}`,
+			want: `unrecognized attribute stack."tag", did you mean "tags"\?`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree([]string{"s:stack", "f:stack/config.tm:" + tc.config})
+			tmcli := NewCLI(t, s.RootDir())
+			AssertRunResult(t, tmcli.Run("list"), RunExpected{
+				StderrRegex: tc.want,
+				Status:      1,
+			})
+		})
+	}
+}
+
+func TestStrictConfig(t *testing.T) {
+	t.Parallel()
+
+	layout := []string{
+		"s:stack",
+		`f:stack/terramate.tm:terramate {
+			config {
+				git {
+					default_branch = "trunk"
+				}
+			}
+		}`,
+	}
+
+	t.Run("root config outside of the root is ignored", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(layout)
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t, tmcli.Run("list"), RunExpected{Stdout: "stack\n"})
+	})
+
+	t.Run("root config outside of the root fails in strict mode", func(t *testing.T) {
+		t.Parallel()
+		s := sandbox.NoGit(t, true)
+		s.BuildTree(append(layout, `f:strict.tm:terramate {
+			config {
+				strict = true
+			}
+		}`))
+		tmcli := NewCLI(t, s.RootDir())
+		AssertRunResult(t, tmcli.Run("list"), RunExpected{
+			StderrRegex: `block terramate.config.git can only be declared at the project root directory`,
+			Status:      1,
+		})
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestSubprojectsAreIsolated(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`f:project.tm:terramate {
+  config {
+    config_file_suffixes = [".terramate.hcl"]
+    subprojects          = ["/embedded"]
+  }
+}`,
+		`f:stack/stack.terramate.hcl:stack {
+  name = "custom"
+}`,
+		`f:embedded/terramate.tm:terramate {
+  required_version = "> 0.0.1"
+  config {
+  }
+}`,
+		"s:embedded/stack-a",
+		"s:embedded/stack-b",
+	})
+	s.Git().CommitAll("first commit")
+
+	tm := NewCLI(t, s.RootDir())
+	AssertRunResult(t, tm.ListStacks(), RunExpected{
+		Stdout: nljoin("stack"),
+	})
+
+	subtm := NewCLI(t, filepath.Join(s.RootDir(), "embedded"))
+	AssertRunResult(t, subtm.ListStacks(), RunExpected{
+		Stdout: nljoin("stack-a", "stack-b"),
+	})
+
+	subtm = NewCLI(t, filepath.Join(s.RootDir(), "embedded", "stack-a"))
+	AssertRunResult(t, subtm.Run("list", "--run-order"), RunExpected{
+		Stdout: nljoin("."),
+	})
+}
+
+func TestSubprojectsChangeDetection(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.BuildTree([]string{
+		`f:project.tm:terramate {
+  config {
+    subprojects = ["/embedded"]
+  }
+}`,
+		"s:stack",
+		`f:embedded/terramate.tm:terramate {
+  config {
+  }
+}`,
+		"s:embedded/stack-a",
+		"s:embedded/stack-b",
+	})
+	git := s.Git()
+	git.CommitAll("first commit")
+	git.Push("main")
+	git.CheckoutNew("change-stacks")
+
+	s.RootEntry().CreateFile("stack/main.tf", "# changed")
+	s.RootEntry().CreateFile("embedded/stack-b/main.tf", "# changed")
+	git.CommitAll("stacks changed")
+
+	subtm := NewCLI(t, filepath.Join(s.RootDir(), "embedded"))
+	AssertRunResult(t, subtm.ListChangedStacks(), RunExpected{
+		Stdout: nljoin("stack-b"),
+	})
+
+	// untracked files outside of the subproject are not part of it.
+	s.RootEntry().CreateFile("stack/untracked.tf", "# untracked")
+	AssertRunResult(t, subtm.Run("run", "--quiet", "--", HelperPath, "true"), RunExpected{})
+}
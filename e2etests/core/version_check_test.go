@@ -32,6 +32,7 @@ func TestVersionCheck(t *testing.T) {
 		"help":            "--help",
 		"version flag":    "--version",
 		"version command": "version",
+		"version json":    "version --format json",
 	}
 
 	run := func(t *testing.T, cmd string, version string) RunResult {
@@ -0,0 +1,51 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	tm "github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/commands/version"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestVersionJSON(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.RootEntry().CreateFile("terramate.tm", `terramate {
+  config {
+    experiments = ["scripts", "k8s"]
+  }
+}`)
+
+	cli := NewCLI(t, s.RootDir())
+	res := cli.Run("version", "--format", "json")
+	AssertRunResult(t, res, RunExpected{IgnoreStdout: true})
+
+	var report version.Report
+	assert.NoError(t, json.Unmarshal([]byte(res.Stdout), &report))
+	assert.EqualStrings(t, tm.Version(), report.Version)
+	assert.IsTrue(t, report.Build.GoVersion != "")
+	assert.EqualInts(t, 2, len(report.Experiments))
+	assert.EqualStrings(t, "scripts", report.Experiments[0])
+	assert.EqualStrings(t, "k8s", report.Experiments[1])
+
+	for _, block := range []string{"generate_file", "generate_hcl", "globals", "import", "stack", "terramate"} {
+		assert.IsTrue(t, slices.Contains(report.Capabilities.Blocks, block), "missing block %s", block)
+	}
+	for _, fn := range []string{"tm_concat", "tm_hclencode", "tm_tomlencode", "tm_k8s_patch"} {
+		assert.IsTrue(t, slices.Contains(report.Capabilities.Functions, fn), "missing function %s", fn)
+	}
+
+	AssertRunResult(t, cli.Run("version", "--format", "yaml"), RunExpected{
+		Status:      1,
+		StderrRegex: "--format",
+	})
+}
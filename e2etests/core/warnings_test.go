@@ -0,0 +1,62 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package core_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	. "github.com/terramate-io/terramate/e2etests/internal/runner"
+	"github.com/terramate-io/terramate/test"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/terramate-io/terramate/test/sandbox"
+	"github.com/terramate-io/terramate/warnings"
+)
+
+func TestWarningsReportAndPromotion(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/terramate.tm:` + Terramate(
+			Config(
+				Block("git"),
+			),
+		).String(),
+	})
+	cli := NewCLI(t, s.RootDir())
+	cli.LogLevel = "warn"
+
+	reportFile := filepath.Join(t.TempDir(), "warnings.json")
+	AssertRunResult(t, cli.Run("list", "--warnings-report", reportFile), RunExpected{
+		Stdout:      nljoin("stack"),
+		StderrRegex: "block terramate\\.config\\.git can only be declared at the project root directory",
+	})
+
+	var report struct {
+		Warnings []warnings.Warning `json:"warnings"`
+	}
+	assert.NoError(t, json.Unmarshal(test.ReadFile(t, filepath.Dir(reportFile), "warnings.json"), &report))
+	assert.EqualInts(t, 1, len(report.Warnings), "unexpected warnings: %v", report.Warnings)
+	assert.EqualStrings(t, string(warnings.ConfigSchema), string(report.Warnings[0].Code))
+
+	s.RootEntry().CreateFile("terramate.tm", Terramate(
+		Config(
+			Block("warnings",
+				Expr("as_errors", `["config-schema"]`),
+			),
+		),
+	).String())
+
+	AssertRunResult(t, cli.Run("list"), RunExpected{
+		Status: 1,
+		StderrRegexes: []string{
+			"warnings promoted to errors",
+			string(warnings.ErrPromoted),
+		},
+	})
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "runner" {
-  content = <<-EOT
+  content = <<-EOF
 package runner // import "github.com/terramate-io/terramate/e2etests/internal/runner"
 
 Package runner provides helpers for compiling and running the Terramate binary
@@ -26,7 +26,7 @@ type CLI struct{ ... }
 type Cmd struct{ ... }
 type RunExpected struct{ ... }
 type RunResult struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-runner.ignore"
 }
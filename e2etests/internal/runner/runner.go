@@ -5,6 +5,7 @@ package runner
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -25,6 +26,7 @@ import (
 
 const testCliConfigFormat = `
 user_terramate_dir = "%s"
+credential_storage = "file"
 `
 
 type (
@@ -89,6 +91,7 @@ func NewCLI(t *testing.T, chdir string, env ...string) CLI {
 	env = RemoveEnv(env, "TMC_API_HOST", "TMC_API_IDP_KEY")
 	// this needs to be deleted from environment otherwise CLI GHA tests will try to issue JWT tokens.
 	env = RemoveEnv(env, "ACTIONS_ID_TOKEN_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	env = RemoveEnv(env, "TM_CLOUD_OIDC_PROVIDER", "TM_CLOUD_OIDC_TOKEN", "TM_CLOUD_OIDC_TOKEN_FILE")
 	// this must be always disabled otherwise we contaminate the telemetry endpoint.
 	env = append(env, "CHECKPOINT_DISABLE=1")
 	// sanity check for cases where user has this configured in their environment.
@@ -232,7 +235,21 @@ func (tm CLI) NewCmd(args ...string) *Cmd {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	fakeJwt, err := token.SignedString([]byte("test"))
 	assert.NoError(t, err)
-	test.WriteFile(t, tm.userDir, "credentials.tmrc.json", fmt.Sprintf(`{"id_token": "%s", "refresh_token": "abcd", "provider": "Google"}`, fakeJwt))
+	cred := map[string]string{
+		"id_token":      fakeJwt,
+		"refresh_token": "abcd",
+		"provider":      "Google",
+	}
+	// keep the organization selected by a previous `terramate cloud org switch`.
+	if data, err := os.ReadFile(filepath.Join(tm.userDir, "credentials.tmrc.json")); err == nil {
+		var previous map[string]string
+		if json.Unmarshal(data, &previous) == nil && previous["organization"] != "" {
+			cred["organization"] = previous["organization"]
+		}
+	}
+	data, err := json.Marshal(cred)
+	assert.NoError(t, err)
+	test.WriteFile(t, tm.userDir, "credentials.tmrc.json", string(data))
 
 	cmd := exec.Command(tm.terramatePath(), allargs...)
 	cmd.Stdout = stdout
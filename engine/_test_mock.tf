@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "engine" {
-  content = <<-EOT
+  content = <<-EOF
 package engine // import "github.com/terramate-io/terramate/engine"
 
 Package engine provides the core functionality of Terramate.
@@ -35,7 +35,7 @@ type StackRun struct{ ... }
 type StackRunTask struct{ ... }
 type UIMode int
     const HumanMode UIMode = iota ...
-EOT
+EOF
 
   filename = "${path.module}/mock-engine.ignore"
 }
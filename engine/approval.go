@@ -0,0 +1,164 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/ci"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+)
+
+// ErrApprovalDenied indicates that the approval of a task was denied or could
+// not be obtained.
+const ErrApprovalDenied errors.Kind = "approval denied"
+
+const defaultApprovalMessage = "Approve the execution of the next jobs?"
+
+// Approval is a gate which pauses the execution of the tasks of a stack until
+// the next tasks are approved.
+type Approval struct {
+	// Message is shown when asking for the approval.
+	Message string
+
+	// File is the path, relative to the stack directory, of a file providing
+	// an external approval. Its content identifies who approved.
+	// An external approval is required when running in CI/CD.
+	File string
+}
+
+// approvalMu serializes the approvals of stacks executed in parallel, as
+// they share the same stdin.
+var approvalMu sync.Mutex
+
+// approve waits for the approval of the task and returns the result
+// recording who approved.
+func (e *Engine) approve(stack *config.Stack, task StackRunTask, opts RunAllOptions) (RunResult, error) {
+	if opts.DryRun {
+		if !opts.Quiet {
+			e.printers.Stderr.Println(approvalPrefix(stack, task) + "approval (skipped in dry run)")
+		}
+		return RunResult{}, nil
+	}
+
+	approvalMu.Lock()
+	defer approvalMu.Unlock()
+
+	startTime := time.Now().UTC()
+	approvedBy, err := e.askApproval(stack, task, opts)
+	endTime := time.Now().UTC()
+
+	res := RunResult{
+		StartedAt:  &startTime,
+		FinishedAt: &endTime,
+		ApprovedBy: approvedBy,
+	}
+	if err != nil {
+		res.ExitCode = 1
+		return res, err
+	}
+
+	log.Info().
+		Stringer("stack", stack).
+		Str("approved_by", approvedBy).
+		Msg("approval granted")
+
+	if !opts.Quiet {
+		e.printers.Stderr.Println(approvalPrefix(stack, task) + "approved by " + approvedBy)
+	}
+	return res, nil
+}
+
+func (e *Engine) askApproval(stack *config.Stack, task StackRunTask, opts RunAllOptions) (string, error) {
+	approval := task.Approval
+	if approval.File != "" {
+		path := approval.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(stack.HostDir(e.Config()), path)
+		}
+		data, err := os.ReadFile(path)
+		if err == nil {
+			approvedBy := strings.TrimSpace(string(data))
+			if approvedBy == "" {
+				approvedBy = "approval file " + approval.File
+			}
+			return approvedBy, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", errors.E(ErrApprovalDenied, err, "reading approval file of stack %s", stack.Dir)
+		}
+	}
+
+	if ci.DetectPlatformFromEnv() != ci.PlatformLocal {
+		return "", errors.E(ErrApprovalDenied,
+			"stack %s: interactive approval is not possible in CI/CD, an approval file must be provided", stack.Dir)
+	}
+
+	message := approval.Message
+	if message == "" {
+		message = defaultApprovalMessage
+	}
+	e.printers.Stderr.Printf("%s%s [y/N]: ", approvalPrefix(stack, task), printer.Sprint(printer.RoleHighlight, message))
+
+	answer, err := readLine(opts.Stdin)
+	if err != nil && answer == "" {
+		return "", errors.E(ErrApprovalDenied, err, "stack %s: reading the approval answer", stack.Dir)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return currentUser(), nil
+	default:
+		return "", errors.E(ErrApprovalDenied, "stack %s", stack.Dir)
+	}
+}
+
+// approvalPrefix returns the same "prompt" style prefix used for printing
+// the script commands.
+func approvalPrefix(stack *config.Stack, task StackRunTask) string {
+	return printer.Sprint(printer.RolePrompt, fmt.Sprintf("%s (script:%d job:%d.%d)> ",
+		stack.Dir.String(),
+		task.ScriptIdx, task.ScriptJobIdx, task.ScriptCmdIdx))
+}
+
+// readLine reads a single line from r. It reads a byte at a time so no input
+// is consumed beyond the line, as r is shared with the executed commands.
+func readLine(r io.Reader) (string, error) {
+	if r == nil {
+		return "", io.EOF
+	}
+	var line strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return line.String(), nil
+			}
+			_ = line.WriteByte(buf[0])
+		}
+		if err != nil {
+			return line.String(), err
+		}
+	}
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/terramate/cloud"
 	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/cloud/queue"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/errors/verbosity"
+	"github.com/terramate-io/terramate/http"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/ui/tui/cliauth"
 	"github.com/terramate-io/terramate/ui/tui/clitest"
@@ -32,6 +34,7 @@ const (
 // CloudState represents the state of the cloud.
 type CloudState struct {
 	disabled bool
+	queued   bool
 	client   *cloud.Client
 
 	Org CloudOrgState
@@ -130,12 +133,38 @@ func (e *Engine) CloudState() CloudState {
 
 // SetupCloudConfig sets up the cloud configuration.
 func (e *Engine) SetupCloudConfig(requestedFeatures []string) error {
-	if e.state.cloud.Org.UUID != "" {
+	return e.setupCloudConfig(requestedFeatures, false)
+}
+
+// SetupCloudSyncConfig sets up the cloud configuration for features which
+// only send payloads to the Terramate Cloud, like the deployment and drift
+// sync. Unlike [Engine.SetupCloudConfig], if the Terramate Cloud is
+// unreachable and the organization is configured, the cloud is set in queue
+// mode, where the payloads are saved in the project cloud queue to be sent
+// later with `terramate cloud flush`.
+func (e *Engine) SetupCloudSyncConfig(requestedFeatures []string) error {
+	return e.setupCloudConfig(requestedFeatures, true)
+}
+
+func (e *Engine) setupCloudConfig(requestedFeatures []string, queueable bool) error {
+	if e.state.cloud.Org.UUID != "" || e.state.cloud.queued {
 		// already setup
 		return nil
 	}
+	if err := http.CheckOnline("Terramate Cloud"); err != nil {
+		return errors.E(err, "%s", strings.Join(requestedFeatures, ", "))
+	}
 	err := e.LoadCredential()
 	if err != nil {
+		if orgName := e.CloudOrgName(); queueable && orgName != "" && queue.IsUnreachable(err) {
+			printer.Stderr.WarnWithDetails(
+				"Terramate Cloud is unreachable: payloads will be queued, run `terramate cloud flush` to send them",
+				err,
+			)
+			e.state.cloud.Org.Name = orgName
+			e.state.cloud.queued = true
+			return nil
+		}
 		if errors.IsKind(err, cliauth.ErrLoginRequired) {
 			e.printers.Stderr.Warn(err)
 			return newCloudLoginRequiredError(requestedFeatures).WithCause(err)
@@ -200,6 +229,7 @@ func (e *Engine) SetupCloudConfig(requestedFeatures []string) error {
 		}
 
 		e.state.cloud.Org.UUID = useOrgUUID
+		e.state.cloud.client.SetOrg(useOrgUUID)
 	} else {
 		if len(activeOrgs) == 0 {
 			printer.Stderr.Error(clitest.CloudNoMembershipMessage)
@@ -237,7 +267,8 @@ func (e *Engine) SetupCloudConfig(requestedFeatures []string) error {
 		printer.Stderr.ErrorWithDetails(
 			"Missing cloud configuration",
 			errors.E("Please set TM_CLOUD_ORGANIZATION environment variable or "+
-				"terramate.config.cloud.organization configuration attribute to a specific organization",
+				"terramate.config.cloud.organization configuration attribute to a specific organization, "+
+				"use the --org flag or select one with `terramate cloud org switch`",
 			),
 		)
 		return cloudError()
@@ -250,8 +281,15 @@ func (e *Engine) SetupCloudConfig(requestedFeatures []string) error {
 	return nil
 }
 
-// CloudOrgName returns the name of the cloud organization.
+// CloudOrgName returns the name of the cloud organization. The organization
+// set with the --org flag takes precedence over the TM_CLOUD_ORGANIZATION
+// environment variable, which takes precedence over the project
+// configuration. If none is set, the organization selected with
+// `terramate cloud org switch` is used.
 func (e *Engine) CloudOrgName() string {
+	if e.usercfg.CloudOrganization != "" {
+		return e.usercfg.CloudOrganization
+	}
 	orgName := os.Getenv("TM_CLOUD_ORGANIZATION")
 	if orgName != "" {
 		return orgName
@@ -259,10 +297,11 @@ func (e *Engine) CloudOrgName() string {
 	cfg := e.RootNode()
 	if cfg.Terramate != nil &&
 		cfg.Terramate.Config != nil &&
-		cfg.Terramate.Config.Cloud != nil {
+		cfg.Terramate.Config.Cloud != nil &&
+		cfg.Terramate.Config.Cloud.Organization != "" {
 		return cfg.Terramate.Config.Cloud.Organization
 	}
-	return ""
+	return cliauth.SelectedOrganization(e.usercfg)
 }
 
 // IsCloudEnabled returns true if cloud features are enabled.
@@ -270,6 +309,13 @@ func (e *Engine) IsCloudEnabled() bool {
 	return !e.state.cloud.disabled
 }
 
+// IsCloudQueued returns true if the Terramate Cloud was unreachable when
+// setting up the cloud configuration, in which case the payloads must be
+// queued instead of sent. See [Engine.SetupCloudSyncConfig].
+func (e *Engine) IsCloudQueued() bool {
+	return e.state.cloud.queued
+}
+
 // IsCloudDisabled returns true if cloud features are disabled.
 func (e *Engine) IsCloudDisabled() bool {
 	return !e.IsCloudEnabled()
@@ -0,0 +1,148 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/tf"
+)
+
+// ErrDependencyFile indicates that a file required by a task is missing or
+// older than its inputs.
+const ErrDependencyFile errors.Kind = "file dependency not satisfied"
+
+// inputSuffixes are the suffixes of the configuration files of a stack,
+// which are the inputs of the files produced by the stack.
+var inputSuffixes = []string{
+	".tm", ".tm.hcl", ".tf", ".tf.json", ".tofu", ".tofu.json",
+	tf.StackFileExt, tf.DeploymentFileExt,
+}
+
+// DependencyFile is a file which must be up to date before a task runs.
+type DependencyFile struct {
+	// Path of the file.
+	Path project.Path
+
+	// Producer is the stack containing the file, if any. The file must be
+	// newer than the configuration files of the producer.
+	Producer *config.Stack
+}
+
+// NewDependencyFiles returns the dependency files of the paths relative to
+// the stack, or relative to the project root if absolute. The producer of
+// each file is the innermost stack containing it.
+func NewDependencyFiles(root *config.Root, st *config.Stack, paths []string) []DependencyFile {
+	var files []DependencyFile
+	for _, p := range paths {
+		file := DependencyFile{Path: st.Dir.Join(p)}
+		if path.IsAbs(p) {
+			file.Path = project.NewPath(p)
+		}
+		for dir := file.Path.Dir(); ; dir = dir.Dir() {
+			if node, ok := root.Lookup(dir); ok && node.IsStack() {
+				if producer, err := node.Stack(); err == nil {
+					file.Producer = producer
+				}
+				break
+			}
+			if dir.String() == "/" {
+				break
+			}
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// checkDependencyFiles checks that the files exist and are newer than the
+// configuration files of the stacks producing them, including the ones in
+// their subdirectories.
+func (e *Engine) checkDependencyFiles(stack *config.Stack, files []DependencyFile) error {
+	rootdir := e.rootdir()
+	deps := map[string]struct{}{}
+	for _, file := range files {
+		deps[file.Path.HostPath(rootdir)] = struct{}{}
+	}
+
+	errs := errors.L()
+	for _, file := range files {
+		info, err := os.Stat(file.Path.HostPath(rootdir))
+		if err != nil {
+			errs.Append(errors.E(ErrDependencyFile, err,
+				"stack %s depends on file %s", stack.Dir, file.Path))
+			continue
+		}
+		if file.Producer == nil {
+			continue
+		}
+		input, err := e.newerInput(file.Producer, info.ModTime(), deps)
+		if err != nil {
+			errs.Append(errors.E(ErrDependencyFile, err,
+				"reading the inputs of file %s", file.Path))
+			continue
+		}
+		if input != "" {
+			errs.Append(errors.E(ErrDependencyFile,
+				"stack %s depends on file %s which is older than %s: run the stack %s first",
+				stack.Dir, file.Path, project.PrjAbsPath(rootdir, input), file.Producer.Dir))
+		}
+	}
+	return errs.AsError()
+}
+
+// newerInput returns the first configuration file of the producer, searched
+// in its directory and subdirectories, except the child stacks and the
+// hidden directories, which is newer than modtime. The deps are ignored.
+func (e *Engine) newerInput(producer *config.Stack, modtime time.Time, deps map[string]struct{}) (string, error) {
+	producerDir := producer.HostDir(e.Config())
+	var newer string
+	err := filepath.WalkDir(producerDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path == producerDir {
+				return nil
+			}
+			if strings.HasPrefix(entry.Name(), ".") || config.IsStack(e.Config(), path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !hasInputSuffix(entry.Name()) {
+			return nil
+		}
+		if _, isDep := deps[path]; isDep {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(modtime) {
+			newer = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return newer, err
+}
+
+func hasInputSuffix(name string) bool {
+	for _, suffix := range inputSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
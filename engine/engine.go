@@ -23,6 +23,7 @@ import (
 	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/config/tag"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/globals"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/hcl/ast"
@@ -101,6 +102,9 @@ type (
 	OutputsSharingOptions struct {
 		IncludeOutputDependencies bool
 		OnlyOutputDependencies    bool
+
+		// RefreshOutputs ignores the cached outputs of the dependencies.
+		RefreshOutputs bool
 	}
 )
 
@@ -121,6 +125,10 @@ func Load(wd string, changeDetectionEnabled bool, clicfg cliconfig.Config, uimod
 	if err != nil {
 		return nil, true, errors.E(err, "setting configuration")
 	}
+	err = prj.setGitMetadata()
+	if err != nil {
+		return nil, true, errors.E(err, "loading git metadata")
+	}
 	return &Engine{
 		project:                prj,
 		printers:               printers,
@@ -163,14 +171,21 @@ func (e *Engine) HCLOptions() []hcl.Option {
 	return e.hclOpts
 }
 
-// ReloadConfig reloads the root configuration of the project.
+// ReloadConfig reloads the root configuration of the project and drops the
+// cached change detection, affected stacks and git commits.
 func (e *Engine) ReloadConfig() error {
 	rootcfg, err := config.LoadRoot(e.rootdir(), e.changeDetectionEnabled, e.hclOpts...)
 	if err != nil {
 		return err
 	}
 	e.project.root = rootcfg
-	return nil
+	if err := e.project.setDefaults(); err != nil {
+		return errors.E(err, "setting configuration")
+	}
+	e.project.resetStackManager()
+	e.project.resetGitCache()
+	e.state.affectedStacks = nil
+	return e.project.setGitMetadata()
 }
 
 // CLIConfig returns the CLI configuration.
@@ -290,7 +305,7 @@ func (e *Engine) addOutputDependencies(outputFlags OutputsSharingOptions, stacks
 		return nil, errors.E("--include-output-dependencies and --only-output-dependencies cannot be used together")
 	}
 	if (outputFlags.IncludeOutputDependencies || outputFlags.OnlyOutputDependencies) && !e.Config().HasExperiment(hcl.SharingIsCaringExperimentName) {
-		return nil, errors.E("--include-output-dependencies requires the '%s' experiment enabled", hcl.SharingIsCaringExperimentName)
+		return nil, experiments.NotEnabled(hcl.SharingIsCaringExperimentName, "--include-output-dependencies")
 	}
 
 	stacksMap := map[string]*config.SortableStack{}
@@ -412,6 +427,40 @@ func (e *Engine) filterStacksByTags(entries []stack.Entry, tags filter.TagClause
 	return filtered
 }
 
+// SelectStackDirs keeps only the stacks in the given directories, which are
+// project absolute paths or paths relative to the working directory. It fails
+// if any of the directories is not one of the stacks.
+func (e *Engine) SelectStackDirs(stacks config.List[*config.SortableStack], dirs []string) (config.List[*config.SortableStack], error) {
+	if len(dirs) == 0 {
+		return stacks, nil
+	}
+	rootdir := e.Config().HostDir()
+	wanted := make([]project.Path, len(dirs))
+	for i, dir := range dirs {
+		if strings.HasPrefix(dir, "/") {
+			wanted[i] = project.NewPath(dir)
+		} else {
+			wanted[i] = project.PrjAbsPath(rootdir, filepath.Join(e.wd(), dir))
+		}
+	}
+	var selected config.List[*config.SortableStack]
+	for _, st := range stacks {
+		if slices.Contains(wanted, st.Dir()) {
+			selected = append(selected, st)
+		}
+	}
+	errs := errors.L()
+	for _, dir := range wanted {
+		if !slices.ContainsFunc(selected, func(st *config.SortableStack) bool { return st.Dir() == dir }) {
+			errs.Append(errors.E("--stack %s is not one of the selected stacks", dir))
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
 // FriendlyFmtDir formats the directory path in a friendly way.
 func (e *Engine) FriendlyFmtDir(dir string) (string, bool) {
 	return project.FriendlyFmtDir(e.Config().HostDir(), e.project.wd, dir)
@@ -424,10 +473,17 @@ func (e *Engine) setupGit(gitfilter GitFilter) error {
 
 	remoteCheckFailed := false
 	if err := e.project.checkDefaultRemote(); err != nil {
-		if e.project.Git.RemoteConfigured {
+		fetched, ferr := e.project.fetchMissingRev(e.project.DefaultBranchRef())
+		if ferr != nil {
+			return ferr
+		}
+		if fetched {
+			err = e.project.checkDefaultRemote()
+		}
+		if err != nil && e.project.Git.RemoteConfigured {
 			return errors.E(err, "checking git default remote")
 		}
-		remoteCheckFailed = true
+		remoteCheckFailed = err != nil
 	}
 
 	var err error
@@ -441,7 +497,8 @@ func (e *Engine) setupGit(gitfilter GitFilter) error {
 	if err != nil {
 		return errors.E(err, "setting up git")
 	}
-	return nil
+	_, err = e.project.fetchMissingRev(e.project.baseRef)
+	return err
 }
 
 // SetupEvalContext sets up the evaluation context for a stack.
@@ -460,7 +517,8 @@ func (e *Engine) SetupEvalContext(wd string, st *config.Stack, target string, ov
 		tdir = wd
 	}
 
-	ctx := eval.NewContext(stdlib.NoFS(tdir, e.RootNode().Experiments()))
+	ctx := e.Config().NewEvalContext(tdir)
+	stdlib.NoFS(ctx)
 	ctx.SetNamespace("terramate", runtime)
 
 	wdPath := project.PrjAbsPath(e.rootdir(), tdir)
@@ -571,7 +629,7 @@ func ParseFilterTags(tags, notags []string) (filter.TagClause, error) {
 func (e *Engine) CheckTargetsConfiguration(targetArg, fromTargetArg string, cloudCheckFn func(bool) error) error {
 	isTargetSet := targetArg != ""
 	isFromTargetSet := fromTargetArg != ""
-	isTargetsEnabled := e.Config().HasExperiment("targets") && e.Config().IsTargetsEnabled()
+	isTargetsEnabled := e.Config().HasExperiment(hcl.TargetsExperimentName) && e.Config().IsTargetsEnabled()
 
 	if isTargetSet {
 		if !isTargetsEnabled {
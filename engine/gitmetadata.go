@@ -0,0 +1,57 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// shortCommitLen is the length of the abbreviated commit SHA.
+const shortCommitLen = 8
+
+// setGitMetadata exposes the git metadata of the repository in the
+// terramate.git namespace, if the git-metadata experiment is enabled.
+func (p *Project) setGitMetadata() error {
+	if !p.root.HasExperiment(config.GitMetadataExperimentName) || !p.isRepo {
+		return nil
+	}
+
+	md := &config.GitMetadata{}
+	if p.HasCommit() {
+		commit, err := p.Git.Wrapper.RevParse("HEAD")
+		if err != nil {
+			return errors.E(err, "reading the HEAD commit")
+		}
+		md.Commit = commit
+		md.ShortCommit = commit
+		if len(commit) > shortCommitLen {
+			md.ShortCommit = commit[:shortCommitLen]
+		}
+	}
+
+	// WHY: the branch is not available when HEAD is detached, which is common
+	// in CI environments.
+	if branch, err := p.Git.Wrapper.CurrentBranch(); err == nil {
+		md.Branch = branch
+	}
+
+	remote := defaultRemote
+	if tm := p.root.Tree().Node.Terramate; tm != nil && tm.Config != nil &&
+		tm.Config.Git != nil && tm.Config.Git.DefaultRemote != "" {
+		remote = tm.Config.Git.DefaultRemote
+	}
+	if url, err := p.Git.Wrapper.URL(remote); err == nil {
+		md.RemoteURL = url
+	}
+
+	untracked, uncommitted, err := p.Git.Wrapper.ListDirtyFiles()
+	if err != nil {
+		return errors.E(err, "checking the repository for dirty files")
+	}
+	md.Dirty = len(untracked) > 0 || len(uncommitted) > 0
+
+	p.root.SetGitMetadata(md)
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/ast"
+	runutil "github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/run/outputcache"
+	"github.com/terramate-io/terramate/warnings"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/json"
+)
+
+// outputsLoader loads the outputs of the stacks with the sharing backends.
+// The outputs of each stack and backend are loaded once, and cached by the
+// state of the stack unless refresh is set.
+type outputsLoader struct {
+	// map of stackName -> map of backendName -> outputs
+	outputs *runutil.OnceMap[string, *runutil.OnceMap[string, cty.Value]]
+	cache   *outputcache.Cache
+	refresh bool
+}
+
+func newOutputsLoader(rootdir string, refresh bool) *outputsLoader {
+	return &outputsLoader{
+		outputs: runutil.NewOnceMap[string, *runutil.OnceMap[string, cty.Value]](),
+		cache:   outputcache.New(rootdir),
+		refresh: refresh,
+	}
+}
+
+// StackInputsEnv returns the TF_VAR_<name> variables of the inputs of the
+// stack, as set by the commands executed with the outputs sharing enabled.
+// The outputs of the stacks are loaded with their sharing backends, using the
+// outputs cache.
+func (e *Engine) StackInputsEnv(stack *config.Stack) ([]string, error) {
+	return e.inputsEnv(stack, newOutputsLoader(e.rootdir(), false), false)
+}
+
+// inputsEnv returns the TF_VAR_<name> variables of the inputs of the stack,
+// evaluated with the outputs of the stacks they depend on. If mockOnFail is
+// set, the inputs whose outputs fail to load get the value of their mocks.
+func (e *Engine) inputsEnv(stack *config.Stack, loader *outputsLoader, mockOnFail bool) ([]string, error) {
+	logger := log.With().
+		Stringer("stack", stack).
+		Bool("mock_on_fail", mockOnFail).
+		Logger()
+
+	cfg, _ := e.Config().Lookup(stack.Dir)
+
+	var environ []string
+	for _, in := range cfg.Node.Inputs {
+		evalctx, err := e.SetupEvalContext(stack.HostDir(e.Config()), stack, "", map[string]string{})
+		if err != nil {
+			return nil, errors.E(err, "failed to setup evaluation context")
+		}
+		input, err := config.EvalInput(evalctx, in)
+		if err != nil {
+			return nil, errors.E(err, "failed to evaluate input block")
+		}
+		otherStack, found, err := e.stackManager().StackByID(input.FromStackID)
+		if err != nil {
+			return nil, errors.E(err, "populating stack inputs from stack.id %s", input.FromStackID)
+		}
+		if !found {
+			return nil, errors.E(
+				"Stack %s needs output from stack ID %q but it cannot be found",
+				stack.Dir,
+				input.FromStackID)
+		}
+
+		logger.Debug().Msgf("Stack depends on outputs from stack %s", otherStack.Dir)
+
+		backend, ok := cfg.SharingBackend(input.Backend)
+		if !ok {
+			return nil, errors.E("backend %s not found", input.Backend)
+		}
+
+		outputsVal, err := loader.load(e, otherStack, backend, mockOnFail)
+		if err != nil {
+			return nil, err
+		}
+
+		evalctx.SetNamespaceRaw("outputs", outputsVal)
+		inputVal, inputErr := input.Value(evalctx)
+		mockVal, mockFound, mockErr := input.Mock(evalctx)
+
+		if inputErr != nil {
+			if !mockOnFail || !mockFound || mockErr != nil {
+				errs := errors.L(errors.E(inputErr, "evaluating input value"))
+				if mockErr != nil {
+					errs.Append(errors.E(mockErr, "failed to evaluate input mock"))
+				}
+				return nil, errs.AsError()
+			}
+
+			inputVal = mockVal
+		}
+		environ = append(environ, fmt.Sprintf("TF_VAR_%s=%s", input.Name, string(ast.TokensForValue(inputVal).Bytes())))
+	}
+	return environ, nil
+}
+
+// load returns the outputs of the stack read with the sharing backend.
+func (l *outputsLoader) load(e *Engine, stack *config.Stack, backend hcl.SharingBackend, mockOnFail bool) (cty.Value, error) {
+	logger := log.With().
+		Stringer("stack", stack).
+		Str("backend", backend.Name).
+		Logger()
+
+	stackOutputs, _ := l.outputs.GetOrInit(stack.Dir.String(), func() (*runutil.OnceMap[string, cty.Value], error) {
+		return runutil.NewOnceMap[string, cty.Value](), nil
+	})
+
+	return stackOutputs.GetOrInit(backend.Name, func() (cty.Value, error) {
+		stackDir := stack.ExecHostDir(e.Config())
+		state, cacheable, err := outputsCacheState(stackDir, backend.Command)
+		if err != nil {
+			return cty.Value{}, err
+		}
+		if cacheable && !l.refresh {
+			cached, found, err := l.cache.Get(stack.Dir.String(), backend.Command, state)
+			if err != nil {
+				if err := warnings.Emit(warnings.OutputsCache, "failed to load cached outputs", err); err != nil {
+					return cty.Value{}, err
+				}
+			} else if found {
+				logger.Debug().Msg("using cached outputs")
+				val, err := unmarshalOutputs(cached)
+				if err == nil {
+					return val, nil
+				}
+				if err := warnings.Emit(warnings.OutputsCache, "ignoring invalid cached outputs", err); err != nil {
+					return cty.Value{}, err
+				}
+			}
+		}
+
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		cmd := exec.Command(backend.Command[0], backend.Command[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		cmd.Dir = stackDir
+		var inputVal cty.Value
+		err = cmd.Run()
+		if err != nil {
+			if !mockOnFail {
+				return cty.Value{}, errors.E(err, "failed to execute: (cmd: %s) (stdout: %s) (stderr: %s)", cmd.String(), stdout.String(), stderr.String())
+			}
+
+			err := warnings.Emit(warnings.OutputsSharing,
+				"failed to execute `sharing_backend` command",
+				errors.E(err, "(cmd: %s) (stdout: %s) (stderr: %s)", cmd.String(), stdout.String(), stderr.String()),
+			)
+			if err != nil {
+				return cty.Value{}, err
+			}
+			return inputVal, nil
+		}
+
+		stdoutBytes := stdout.Bytes()
+		typ, err := json.ImpliedType(stdoutBytes)
+		if err != nil {
+			return cty.Value{}, errors.E(err, "unmashaling sharing_backend output")
+		}
+		inputVal, err = json.Unmarshal(stdoutBytes, typ)
+		if err != nil {
+			return cty.Value{}, errors.E(err, "unmashaling sharing_backend output")
+		}
+		if cacheable {
+			if err := l.cache.Put(stack.Dir.String(), backend.Command, state, stdoutBytes); err != nil {
+				if err := warnings.Emit(warnings.OutputsCache, "failed to cache outputs", err); err != nil {
+					return cty.Value{}, err
+				}
+			}
+		}
+		return inputVal, nil
+	})
+}
+
+// outputsCacheState returns the state of the stack used as the key of its
+// cached outputs. The outputs are not cached if the stack has no state.
+// It only fails if the warning about an unreadable state is promoted to error.
+func outputsCacheState(stackdir string, command []string) (outputcache.State, bool, error) {
+	state, found, err := outputcache.StackState(stackdir, stateCommand(command))
+	if err != nil {
+		return outputcache.State{}, false, warnings.Emit(warnings.OutputsCache, "failed to read the state for caching outputs", err)
+	}
+	return state, found, nil
+}
+
+// stateCommand returns the command reading the state of the stacks whose
+// outputs are read with the given sharing backend command, which is tofu if
+// the outputs are read with tofu and terraform otherwise.
+func stateCommand(command []string) string {
+	if len(command) > 0 && strings.TrimSuffix(filepath.Base(command[0]), ".exe") == "tofu" {
+		return "tofu"
+	}
+	return "terraform"
+}
+
+func unmarshalOutputs(data []byte) (cty.Value, error) {
+	typ, err := json.ImpliedType(data)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return json.Unmarshal(data, typ)
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	runutil "github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/tf"
+)
+
+// planShowTimeout is the maximum duration of the command showing a plan.
+const planShowTimeout = 300 * time.Second
+
+// provisionerOpenTofu is the provisioner of the plan files created by OpenTofu.
+const provisionerOpenTofu = "opentofu"
+
+// planHasChanges tells if the plan file created by the task in the stack has
+// changes, inspecting the JSON plan shown by the provisioner of the task.
+func (e *Engine) planHasChanges(stack *config.Stack, task StackRunTask, environ []string) (bool, error) {
+	if filepath.IsAbs(task.CloudPlanFile) {
+		return false, errors.E("plan file path must be relative to the running stack")
+	}
+
+	cmdName := "terraform"
+	if task.UseTerragrunt {
+		cmdName = "terragrunt"
+	} else if task.CloudPlanProvisioner == provisionerOpenTofu {
+		cmdName = "tofu"
+	}
+	cmdPath, err := runutil.LookPath(cmdName, environ)
+	if err != nil {
+		return false, errors.E(err, "looking up executable for %s", cmdName)
+	}
+
+	env := environ
+	args := []string{"show", "-no-color", "-json"}
+	if task.UseTerragrunt {
+		args = append(args, "--terragrunt-non-interactive")
+		env = append(append([]string{}, environ...),
+			"TERRAGRUNT_FORWARD_TF_STDOUT=true",
+			"TERRAGRUNT_LOG_FORMAT=bare",
+		)
+	}
+	args = append(args, task.CloudPlanFile)
+
+	ctx, cancel := context.WithTimeout(context.Background(), planShowTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cmdPath, args...)
+	cmd.Dir = stack.ExecHostDir(e.Config())
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return false, errors.E(err, "executing %s (stderr: %s)", cmd, stderr.String())
+	}
+	return tf.PlanHasChanges(stdout.Bytes())
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/terramate/ci"
@@ -14,7 +15,10 @@ import (
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/git"
 	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/http"
+	"github.com/terramate-io/terramate/safeguard"
 	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 const (
@@ -80,6 +84,18 @@ func NewProject(wd string, changeDetectionEnabled bool, parserOpts ...hcl.Option
 			return nil, false, err
 		}
 
+		if subdir, ok := subprojectDir(cfg, wd); ok {
+			// WHY: a subproject shares the repository of its parent project.
+			// The git wrapper works from the subproject directory, so the
+			// changed files are relative to the subproject root.
+			log.Debug().Str("subproject", subdir).Msg("working directory is inside a subproject")
+			cfg, err = config.LoadRoot(subdir, changeDetectionEnabled, parserOpts...)
+			if err != nil {
+				return nil, false, err
+			}
+			rootdir = subdir
+		}
+
 		gw = gw.With().WorkingDir(rootdir).Wrapper()
 
 		prj.isRepo = true
@@ -106,6 +122,38 @@ func NewProject(wd string, changeDetectionEnabled bool, parserOpts ...hcl.Option
 	return prj, true, nil
 }
 
+// resetStackManager replaces the stack manager, dropping its cached change
+// detection, so it uses the current root configuration.
+func (p *Project) resetStackManager() {
+	if p.isRepo {
+		p.stackManager = stack.NewGitAwareManager(p.root, p.Git.Wrapper)
+		return
+	}
+	p.stackManager = stack.NewManager(p.root)
+}
+
+// resetGitCache drops the cached commits and base refs, so they are computed
+// again for the current state of the repository.
+func (p *Project) resetGitCache() {
+	p.Git.headCommit = ""
+	p.Git.localDefaultBranchCommit = ""
+	p.Git.remoteDefaultBranchCommit = ""
+	p.Git.defaultBaseRef = ""
+	p.Git.defaultLocalBaseRef = ""
+}
+
+// subprojectDir returns the directory of the subproject, declared with
+// terramate.config.subprojects, containing the working directory.
+func subprojectDir(root *config.Root, wd string) (string, bool) {
+	for _, sub := range root.Tree().Node.Subprojects() {
+		dir := sub.HostPath(root.HostDir())
+		if wd == dir || strings.HasPrefix(wd, dir+string(filepath.Separator)) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
 // IsRepo returns true if the project is a git repository.
 func (p *Project) IsRepo() bool { return p.isRepo }
 
@@ -203,6 +251,11 @@ func (p *Project) RemoteDefaultCommit() (string, error) {
 	}
 
 	gitcfg := p.GitConfig()
+	err := http.CheckOnline(fmt.Sprintf("fetching the remote commit of %s/%s (safeguard %s)",
+		gitcfg.DefaultRemote, gitcfg.DefaultBranch, safeguard.GitOutOfSync))
+	if err != nil {
+		return "", err
+	}
 	remoteRef, err := p.Git.Wrapper.FetchRemoteRev(gitcfg.DefaultRemote, gitcfg.DefaultBranch)
 	if err != nil {
 		return "", errors.E(
@@ -228,31 +281,48 @@ func (p *Project) HasCommit() bool {
 }
 
 // HasCommits returns true if the project has commits.
+// The truncated history of a shallow clone only has commits when
+// terramate.config.git.fetch_missing_base_ref is set, as the missing
+// commits are fetched then.
 func (p *Project) HasCommits() bool {
 	_, err := p.Git.Wrapper.RevParse("HEAD^")
-	return err == nil
+	if err == nil {
+		return true
+	}
+	if !p.GitConfig().FetchMissingBaseRef {
+		return false
+	}
+	shallow, _ := p.Git.Wrapper.IsShallow()
+	return shallow
 }
 
 func (p *Project) isDefaultBranch() (bool, error) {
 	git := p.GitConfig()
 	branch, err := p.Git.Wrapper.CurrentBranch()
-	if err != nil {
-		// WHY?
-		// The current branch name (the symbolic-ref of the HEAD) is not always
-		// available, in this case we naively check if HEAD == local origin/main.
-		// This case usually happens in the git setup of CIs.
-		errs := errors.L()
-		headcommit, err := p.HeadCommit()
-		errs.Append(err)
-		localdefault, err := p.LocalDefaultBranchCommit()
-		errs.Append(err)
-		if errs.AsError() != nil {
-			return false, errs
-		}
+	if err == nil {
+		return branch == git.DefaultBranch, nil
+	}
+
+	// WHY?
+	// The current branch name (the symbolic-ref of the HEAD) is not always
+	// available, in this case we naively check if HEAD == local origin/main.
+	errs := errors.L()
+	headcommit, err := p.HeadCommit()
+	errs.Append(err)
+	localdefault, err := p.LocalDefaultBranchCommit()
+	errs.Append(err)
+	if errs.AsError() == nil {
 		return localdefault == headcommit, nil
 	}
 
-	return branch == git.DefaultBranch, nil
+	// This usually happens in the git setup of CIs, which then provide the
+	// branch being built in the environment.
+	if md := ci.DetectMetadataFromEnv(p.CIPlatform()); md.IsReviewRequest() {
+		return false, nil
+	} else if md.Branch != "" {
+		return md.Branch == git.DefaultBranch, nil
+	}
+	return false, errs
 }
 
 // DefaultBaseRef returns the baseRef for the current git environment.
@@ -272,8 +342,11 @@ func (p *Project) DefaultBaseRef() (string, error) {
 			return "", err
 		}
 		if remoteDefault == headCommit {
-			_, err := p.Git.Wrapper.RevParse(defaultBranchBaseRef)
-			if err == nil {
+			fetched, err := p.fetchMissingRev(defaultBranchBaseRef)
+			if err != nil {
+				return "", err
+			}
+			if fetched {
 				p.Git.defaultBaseRef = defaultBranchBaseRef
 				return p.Git.defaultBaseRef, nil
 			}
@@ -295,8 +368,11 @@ func (p *Project) DefaultLocalBaseRef() (string, error) {
 	}
 
 	if isDefault {
-		_, err := p.Git.Wrapper.RevParse(defaultBranchBaseRef)
-		if err == nil {
+		fetched, err := p.fetchMissingRev(defaultBranchBaseRef)
+		if err != nil {
+			return "", err
+		}
+		if fetched {
 			p.Git.defaultLocalBaseRef = defaultBranchBaseRef
 			return p.Git.defaultLocalBaseRef, nil
 		}
@@ -305,6 +381,58 @@ func (p *Project) DefaultLocalBaseRef() (string, error) {
 	return p.Git.defaultLocalBaseRef, nil
 }
 
+// fetchMissingRev fetches the rev from the default remote if it's missing in the
+// local repository, which is common in the shallow clones made by CI systems.
+// Nothing is fetched unless terramate.config.git.fetch_missing_base_ref is set.
+// It returns true if the rev is available. It only fails if a warning about
+// the fetch is promoted to error.
+func (p *Project) fetchMissingRev(rev string) (bool, error) {
+	if _, err := p.Git.Wrapper.RevParse(rev); err == nil {
+		return true, nil
+	}
+	gitcfg := p.GitConfig()
+	if !gitcfg.FetchMissingBaseRef {
+		return false, nil
+	}
+	remote := gitcfg.DefaultRemote
+	if _, err := p.Git.Wrapper.URL(remote); err != nil {
+		log.Debug().Err(err).Msgf("remote %q is not configured, not fetching %q", remote, rev)
+		return false, nil
+	}
+	if err := http.CheckOnline(fmt.Sprintf("fetching the missing base ref %q", rev)); err != nil {
+		return false, warnings.Emit(warnings.BaseRefFetch, fmt.Sprintf("unable to fetch the missing base ref %q", rev), err)
+	}
+	depth := gitcfg.FetchDepth
+	if depth == 0 {
+		depth = 1
+	}
+	var err error
+	if branch, ok := strings.CutPrefix(rev, remote+"/"); ok {
+		err = warnings.Emit(warnings.BaseRefFetch,
+			fmt.Sprintf("base ref %q is missing, fetching %d commit(s) of branch %q from remote %q",
+				rev, depth, branch, remote), nil)
+		if err != nil {
+			return false, err
+		}
+		err = p.Git.Wrapper.FetchRef(remote, branch, depth)
+	} else if shallow, _ := p.Git.Wrapper.IsShallow(); shallow {
+		err = warnings.Emit(warnings.BaseRefFetch,
+			fmt.Sprintf("base ref %q is missing in the shallow clone, fetching %d more commit(s) from remote %q",
+				rev, depth, remote), nil)
+		if err != nil {
+			return false, err
+		}
+		err = p.Git.Wrapper.Deepen(remote, depth)
+	} else {
+		return false, nil
+	}
+	if err != nil {
+		return false, warnings.Emit(warnings.BaseRefFetch, fmt.Sprintf("failed to fetch the missing base ref %q", rev), err)
+	}
+	_, err = p.Git.Wrapper.RevParse(rev)
+	return err == nil, nil
+}
+
 // DefaultBranchRef returns the default branch ref.
 // Usually it's "origin/main".
 func (p Project) DefaultBranchRef() string {
@@ -4,7 +4,6 @@
 package engine
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -15,24 +14,23 @@ import (
 	"strings"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/ci"
 	"github.com/terramate-io/terramate/cloud"
 	"github.com/terramate-io/terramate/cloud/api/preview"
 	"github.com/terramate-io/terramate/cloud/api/resources"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
-	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
-	"github.com/terramate-io/terramate/run"
 	runutil "github.com/terramate-io/terramate/run"
 	"github.com/terramate-io/terramate/run/dag"
+	"github.com/terramate-io/terramate/run/lock"
 	"github.com/terramate-io/terramate/scheduler"
 	"github.com/terramate-io/terramate/scheduler/resource"
-	"github.com/zclconf/go-cty/cty"
-	"github.com/zclconf/go-cty/cty/json"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 const (
@@ -72,9 +70,39 @@ type StackRunTask struct {
 	CloudPlanFile        string
 	CloudPlanProvisioner string
 
+	// SkipNoOpPlan skips the task when its plan file exists before it runs
+	// and has no changes.
+	SkipNoOpPlan bool
+
 	UseTerragrunt bool
 	EnableSharing bool
 	MockOnFail    bool
+
+	// Approval is set for tasks which pause the execution until approved.
+	// Approval tasks have no command.
+	Approval *Approval
+
+	// DependsOnFiles are the files which must be up to date before the
+	// task runs.
+	DependsOnFiles []DependencyFile
+
+	// Sensitive are the values of the sensitive globals of the stack, which
+	// are redacted from the command when it's shown, logged or synchronized
+	// with Terramate Cloud.
+	Sensitive []string
+}
+
+// RedactedCmd returns the command of the task with the sensitive values
+// redacted.
+func (t StackRunTask) RedactedCmd() []string {
+	if len(t.Sensitive) == 0 {
+		return t.Cmd
+	}
+	cmd := make([]string, len(t.Cmd))
+	for i, arg := range t.Cmd {
+		cmd[i] = eval.Redact(arg, t.Sensitive)
+	}
+	return cmd
 }
 
 // RunAllOptions contains options for the RunAll method.
@@ -86,10 +114,28 @@ type RunAllOptions struct {
 	ContinueOnError bool
 	Parallel        int
 
+	// Unordered ignores the ordering between the stacks and executes the
+	// runs in the given order, or concurrently if Parallel is set. It must
+	// only be used when the commands do not depend on each other, eg.: drift
+	// detection.
+	Unordered bool
+
+	// SkipNoOpPlans skips the remaining tasks of a stack when the plan file
+	// created by a task has no changes.
+	SkipNoOpPlans bool
+
+	// RefreshOutputs ignores the cached outputs of the stacks sharing outputs
+	// and executes the sharing_backend command again.
+	RefreshOutputs bool
+
 	Stdout io.Writer
 	Stderr io.Writer
 	Stdin  io.Reader
 
+	// Outputs, if set, returns the writers of the output of the commands
+	// executed in the stack, instead of Stdout and Stderr.
+	Outputs func(stack *config.Stack) (stdout, stderr io.Writer)
+
 	Hooks *Hooks
 }
 
@@ -127,6 +173,17 @@ type RunResult struct {
 	ExitCode   int
 	StartedAt  *time.Time
 	FinishedAt *time.Time
+
+	// ApprovedBy identifies who approved an approval task.
+	ApprovedBy string
+
+	// Skipped tells if the command was not executed because its plan, or the
+	// plan of a previous command of the stack, has no changes.
+	Skipped bool
+
+	// Usage is the resource usage of the command, if it exited and the
+	// accounting is supported by the operating system.
+	Usage *ResourceUsage
 }
 
 // RunAll will execute the list of RunStack definitions. A RunStack defines the
@@ -153,8 +210,24 @@ func (e *Engine) RunAll(
 	}
 	// Construct a DAG from the list of stackRuns, based on the implicit and
 	// explicit dependencies between stacks.
-	d, reason, err := runutil.BuildDAGFromStacks(e.Config(), runs,
-		func(run StackRun) *config.Stack { return run.Stack })
+	var (
+		d      *dag.DAG[StackRun]
+		reason string
+		err    error
+	)
+	if opts.Reverse && !opts.Unordered {
+		err := runutil.CheckReverseOrder(e.Config(), runs,
+			func(run StackRun) *config.Stack { return run.Stack })
+		if err != nil {
+			return errors.E(err, "refusing to run in reverse order")
+		}
+	}
+	if opts.Unordered {
+		d, err = unorderedDAG(runs)
+	} else {
+		d, reason, err = runutil.BuildDAGFromStacks(e.Config(), runs,
+			func(run StackRun) *config.Stack { return run.Stack })
+	}
 	if err != nil {
 		if errors.IsKind(err, dag.ErrCycleDetected) {
 			return errors.E(err, "cycle detected: %s", reason)
@@ -194,6 +267,17 @@ func (e *Engine) RunAll(
 		return err
 	}
 
+	// same for the run policy, no stack is executed if any of the commands
+	// is not allowed.
+	if err := checkRunPolicy(e.Config(), runs); err != nil {
+		return err
+	}
+
+	locks, err := stackLocks(e.Config())
+	if err != nil {
+		return err
+	}
+
 	const signalsBufferSize = 10
 	signals := make(chan os.Signal, signalsBufferSize)
 	signal.Notify(signals, os.Interrupt)
@@ -236,12 +320,37 @@ func (e *Engine) RunAll(
 		}
 	}()
 
-	// map of stackName -> map of backendName -> outputs
-	allOutputs := runutil.NewOnceMap[string, *run.OnceMap[string, cty.Value]]()
+	outputs := newOutputsLoader(e.rootdir(), opts.RefreshOutputs)
 
-	err = sched.Run(func(run StackRun) error {
+	err = sched.Run(func(run StackRun) (runErr error) {
 		errs := errors.L()
 
+		if locks != nil && !opts.DryRun {
+			stackLock, err := locks.Acquire(run.Stack.Dir, lockPurpose(run, opts))
+			if err != nil {
+				if run.SyncTaskIndex != -1 && run.SyncTaskIndex < len(run.Tasks) {
+					cloudRun := StackCloudRun{
+						Stack: run.Stack,
+						Task:  run.Tasks[run.SyncTaskIndex],
+					}
+					opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
+				}
+				if !continueOnError {
+					cancel()
+				}
+				return errors.E(err, "locking stack %s", run.Stack.Dir)
+			}
+			defer func() {
+				if err := locks.Release(stackLock); err != nil {
+					err := warnings.Emit(warnings.StackLock,
+						fmt.Sprintf("failed to release the lock of stack %s", run.Stack.Dir), err)
+					if err != nil {
+						runErr = errors.L(runErr, err).AsError()
+					}
+				}
+			}()
+		}
+
 		failedTaskIndex := -1
 
 	tasksLoop:
@@ -259,6 +368,21 @@ func (e *Engine) RunAll(
 			default:
 			}
 
+			if task.Approval != nil {
+				res, err := e.approve(run.Stack, task, opts)
+				opts.Hooks.After(e, cloudRun, res, err)
+				releaseResource()
+				if err != nil {
+					errs.Append(err)
+					failedTaskIndex = taskIndex
+					if !continueOnError {
+						cancel()
+					}
+					break tasksLoop
+				}
+				continue tasksLoop
+			}
+
 			if !opts.Quiet && !opts.ScriptRun {
 				printer.Stderr.Println(printPrefix + " Entering stack in " + run.Stack.String())
 			}
@@ -273,68 +397,46 @@ func (e *Engine) RunAll(
 				Bool("mock_on_fail", task.MockOnFail).
 				Logger()
 
-			cfg, _ := e.Config().Lookup(run.Stack.Dir)
 			environ := newEnvironFrom(stackEnvs[run.Stack.Dir])
 
 			if task.EnableSharing {
-				for _, in := range cfg.Node.Inputs {
-					evalctx, err := e.SetupEvalContext(run.Stack.HostDir(e.Config()), run.Stack, "", map[string]string{})
-					if err != nil {
-						errs.Append(errors.E(err, "failed to setup evaluation context"))
-						opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-						releaseResource()
-						failedTaskIndex = taskIndex
-						if !continueOnError {
-							cancel()
-						}
-						break tasksLoop
-					}
-					input, err := config.EvalInput(evalctx, in)
-					if err != nil {
-						errs.Append(errors.E(err, "failed to evaluate input block"))
-						opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-						releaseResource()
-						failedTaskIndex = taskIndex
-						if !continueOnError {
-							cancel()
-						}
-						break tasksLoop
-					}
-					otherStack, found, err := e.stackManager().StackByID(input.FromStackID)
-					if err != nil {
-						errs.Append(errors.E(err, "populating stack inputs from stack.id %s", input.FromStackID))
-						opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-						releaseResource()
-						failedTaskIndex = taskIndex
-						if !continueOnError {
-							cancel()
-						}
-						break tasksLoop
+				inputsEnv, err := e.inputsEnv(run.Stack, outputs, task.MockOnFail)
+				if err != nil {
+					errs.Append(err)
+					opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
+					releaseResource()
+					failedTaskIndex = taskIndex
+					if !continueOnError {
+						cancel()
 					}
-					if !found {
-						err := errors.E(
-							"Stack %s needs output from stack ID %q but it cannot be found",
-							run.Stack.Dir,
-							input.FromStackID)
+					break tasksLoop
+				}
+				environ = append(environ, inputsEnv...)
+			}
 
-						errs.Append(err)
+			cloudRun.Env = environ
 
-						opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-						releaseResource()
-						failedTaskIndex = taskIndex
-						if !continueOnError {
-							cancel()
-						}
-						break tasksLoop
+			if len(task.DependsOnFiles) > 0 && !opts.DryRun {
+				if err := e.checkDependencyFiles(run.Stack, task.DependsOnFiles); err != nil {
+					errs.Append(err)
+					opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
+					releaseResource()
+					failedTaskIndex = taskIndex
+					if !continueOnError {
+						cancel()
 					}
+					break tasksLoop
+				}
+			}
 
-					logger.Debug().Msgf("Stack depends on outputs from stack %s", otherStack.Dir)
-
-					backend, ok := cfg.SharingBackend(input.Backend)
-					if !ok {
-						err := errors.E("backend %s not found", input.Backend)
-						errs.Append(err)
+			if task.SkipNoOpPlan && !opts.DryRun {
+				changed, err := e.planHasChanges(run.Stack, task, environ)
+				if err != nil {
+					err := warnings.Emit(warnings.PlanCheck,
+						fmt.Sprintf("failed to check the plan of stack %s, running the command", run.Stack.Dir), err)
+					if err != nil {
 						opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
+						errs.Append(err)
 						releaseResource()
 						failedTaskIndex = taskIndex
 						if !continueOnError {
@@ -342,100 +444,18 @@ func (e *Engine) RunAll(
 						}
 						break tasksLoop
 					}
-
-					stackOutputs, _ := allOutputs.GetOrInit(otherStack.Dir.String(), func() (*runutil.OnceMap[string, cty.Value], error) {
-						return runutil.NewOnceMap[string, cty.Value](), nil
-					})
-
-					outputsVal, err := stackOutputs.GetOrInit(backend.Name, func() (cty.Value, error) {
-						var stdout bytes.Buffer
-						var stderr bytes.Buffer
-						cmd := exec.Command(backend.Command[0], backend.Command[1:]...)
-						cmd.Stdout = &stdout
-						cmd.Stderr = &stderr
-						cmd.Dir = otherStack.HostDir(e.Config())
-						var inputVal cty.Value
-						err := cmd.Run()
-						if err != nil {
-							if !task.MockOnFail {
-								err := errors.E(err, "failed to execute: (cmd: %s) (stdout: %s) (stderr: %s)", cmd.String(), stdout.String(), stderr.String())
-								errs.Append(err)
-								opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-								releaseResource()
-								failedTaskIndex = taskIndex
-								if !continueOnError {
-									cancel()
-								}
-								return cty.Value{}, err
-							}
-
-							printer.Stderr.WarnWithDetails(
-								"failed to execute `sharing_backend` command",
-								errors.E(err, "(cmd: %s) (stdout: %s) (stderr: %s)", cmd.String(), stdout.String(), stderr.String()),
-							)
-						} else {
-							stdoutBytes := stdout.Bytes()
-							typ, err := json.ImpliedType(stdoutBytes)
-							if err != nil {
-								err := errors.E(err, "unmashaling sharing_backend output")
-								errs.Append(err)
-								opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-								releaseResource()
-								failedTaskIndex = taskIndex
-								if !continueOnError {
-									cancel()
-								}
-								return cty.Value{}, err
-
-							}
-							inputVal, err = json.Unmarshal(stdoutBytes, typ)
-							if err != nil {
-								err := errors.E(err, "unmashaling sharing_backend output")
-								errs.Append(err)
-								opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-								releaseResource()
-								failedTaskIndex = taskIndex
-								if !continueOnError {
-									cancel()
-								}
-								return cty.Value{}, err
-							}
-						}
-						return inputVal, nil
-					})
-					if err != nil {
-						break tasksLoop
-					}
-
-					evalctx.SetNamespaceRaw("outputs", outputsVal)
-					inputVal, inputErr := input.Value(evalctx)
-					mockVal, mockFound, mockErr := input.Mock(evalctx)
-
-					if inputErr != nil {
-						if !task.MockOnFail || !mockFound || mockErr != nil {
-							err := errors.E(inputErr, "evaluating input value")
-							errs.Append(err)
-							if mockErr != nil {
-								errs.Append(errors.E(mockErr, "failed to evaluate input mock"))
-							}
-							opts.Hooks.After(e, cloudRun, RunResult{ExitCode: -1}, errors.E(ErrRunCommandNotExecuted, err))
-							releaseResource()
-							failedTaskIndex = taskIndex
-							if !continueOnError {
-								cancel()
-							}
-							break tasksLoop
-						}
-
-						inputVal = mockVal
+				} else if !changed {
+					if !opts.Quiet {
+						printer.Stderr.Println(printPrefix + " Plan of stack " + run.Stack.String() +
+							" has no changes, skipping the command")
 					}
-					environ = append(environ, fmt.Sprintf("TF_VAR_%s=%s", input.Name, string(ast.TokensForValue(inputVal).Bytes())))
+					opts.Hooks.After(e, cloudRun, RunResult{Skipped: true}, nil)
+					releaseResource()
+					continue tasksLoop
 				}
 			}
 
-			cloudRun.Env = environ
-
-			cmdStr := strings.Join(task.Cmd, " ")
+			cmdStr := strings.Join(task.RedactedCmd(), " ")
 			logger = logger.With().
 				Str("cmd", cmdStr).
 				Logger()
@@ -453,15 +473,18 @@ func (e *Engine) RunAll(
 			}
 
 			cmd := exec.Command(cmdPath, task.Cmd[1:]...)
-			cmd.Dir = run.Stack.HostDir(e.Config())
+			cmd.Dir = run.Stack.ExecHostDir(e.Config())
 			cmd.Env = environ
 
 			stdin := opts.Stdin
 			stdout := opts.Stdout
 			stderr := opts.Stderr
+			if opts.Outputs != nil {
+				stdout, stderr = opts.Outputs(run.Stack)
+			}
 
 			logSyncWait := func() {}
-			if e.IsCloudEnabled() && opts.Hooks.LogSyncCondition(task, run) {
+			if e.IsCloudEnabled() && !e.IsCloudQueued() && opts.Hooks.LogSyncCondition(task, run) {
 				logSyncer := cloud.NewLogSyncer(func(logs resources.CommandLogs) {
 					opts.Hooks.LogSyncer(&logger, e, run, logs)
 				})
@@ -548,6 +571,7 @@ func (e *Engine) RunAll(
 					ExitCode:   result.cmd.ProcessState.ExitCode(),
 					StartedAt:  &startTime,
 					FinishedAt: result.finishedAt,
+					Usage:      processUsage(result.cmd.ProcessState),
 				}
 
 				logMsg := logger.Debug().Int("exit_code", res.ExitCode)
@@ -568,6 +592,37 @@ func (e *Engine) RunAll(
 					}
 					break tasksLoop
 				}
+
+				if opts.SkipNoOpPlans && task.CloudPlanFile != "" && taskIndex < len(run.Tasks)-1 {
+					changed, err := e.planHasChanges(run.Stack, task, environ)
+					if err != nil {
+						err := warnings.Emit(warnings.PlanCheck,
+							fmt.Sprintf("failed to check the plan of stack %s, running the remaining commands", run.Stack.Dir), err)
+						if err != nil {
+							errs.Append(err)
+							failedTaskIndex = taskIndex
+							if !continueOnError {
+								cancel()
+							}
+							break tasksLoop
+						}
+						continue tasksLoop
+					}
+					if !changed {
+						if !opts.Quiet {
+							printer.Stderr.Println(printPrefix + " Plan of stack " + run.Stack.String() +
+								" has no changes, skipping the remaining commands")
+						}
+						if run.SyncTaskIndex > taskIndex {
+							cloudRun := StackCloudRun{
+								Stack: run.Stack,
+								Task:  run.Tasks[run.SyncTaskIndex],
+							}
+							opts.Hooks.After(e, cloudRun, RunResult{Skipped: true}, nil)
+						}
+						break tasksLoop
+					}
+				}
 			}
 		}
 
@@ -585,6 +640,19 @@ func (e *Engine) RunAll(
 	return err
 }
 
+// unorderedDAG builds a DAG without edges whose order is the order of the runs.
+func unorderedDAG(runs []StackRun) (*dag.DAG[StackRun], error) {
+	d := dag.New[StackRun]()
+	width := len(strconv.Itoa(len(runs)))
+	for i, run := range runs {
+		id := dag.ID(fmt.Sprintf("%0*d:%s", width, i, run.Stack.Dir))
+		if err := d.AddNode(id, run, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
 func (t StackRunTask) isSuccessExit(exitCode int) bool {
 	if exitCode == 0 {
 		return true
@@ -600,11 +668,11 @@ func (t StackRunTask) isSuccessExit(exitCode int) bool {
 // /somestack (script:0 job:0.0)> echo hello
 func printScriptCommand(p *printer.Printer, stack *config.Stack, run StackRunTask) {
 	p.Printf("%s",
-		color.GreenString(fmt.Sprintf("%s (script:%d job:%d.%d)> ",
+		printer.Sprint(printer.RolePrompt, fmt.Sprintf("%s (script:%d job:%d.%d)> ",
 			stack.Dir.String(),
 			run.ScriptIdx, run.ScriptJobIdx, run.ScriptCmdIdx)),
 	)
-	p.Println(color.YellowString(strings.Join(run.Cmd, " ")))
+	p.Println(printer.Sprint(printer.RoleHighlight, strings.Join(run.RedactedCmd(), " ")))
 }
 
 type cmdResult struct {
@@ -636,6 +704,54 @@ func newEnvironFrom(stackEnviron []string) []string {
 	return environ
 }
 
+func checkRunPolicy(root *config.Root, runs []StackRun) error {
+	cfg := root.Tree().Node
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil ||
+		cfg.Terramate.Config.Run == nil || cfg.Terramate.Config.Run.Policy == nil {
+		return nil
+	}
+	policy := cfg.Terramate.Config.Run.Policy
+	isCI := ci.DetectPlatformFromEnv() != ci.PlatformLocal
+	for _, run := range runs {
+		for _, task := range run.Tasks {
+			if task.Approval != nil {
+				continue
+			}
+			if err := runutil.CheckPolicy(policy, task.Cmd, isCI); err != nil {
+				return errors.E(err, "stack %s", run.Stack.Dir)
+			}
+		}
+	}
+	return nil
+}
+
+// stackLocks returns the stack locking backend configured in
+// terramate.config.run.lock, or nil if locking is not enabled.
+func stackLocks(root *config.Root) (lock.Backend, error) {
+	cfg := root.Tree().Node
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil ||
+		cfg.Terramate.Config.Run == nil || cfg.Terramate.Config.Run.Lock == nil {
+		return nil, nil
+	}
+	return lock.New(root.HostDir(), cfg.Terramate.Config.Run.Lock)
+}
+
+// lockPurpose describes the commands executed in the stack while locked.
+func lockPurpose(run StackRun, opts RunAllOptions) string {
+	var cmds []string
+	for _, task := range run.Tasks {
+		if task.Approval != nil {
+			continue
+		}
+		cmds = append(cmds, strings.Join(task.RedactedCmd(), " "))
+	}
+	cmd := "terramate run"
+	if opts.ScriptRun {
+		cmd = "terramate script run"
+	}
+	return cmd + ": " + strings.Join(cmds, "; ")
+}
+
 func loadAllStackEnvs(root *config.Root, runs []StackRun) (map[project.Path]runutil.EnvVars, error) {
 	errs := errors.L()
 	stackEnvs := map[project.Path]runutil.EnvVars{}
@@ -0,0 +1,35 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"os"
+	"time"
+)
+
+// ResourceUsage is the resources used by the child process of a command, as
+// accounted by the operating system.
+type ResourceUsage struct {
+	// UserCPU is the CPU time spent in user mode.
+	UserCPU time.Duration
+	// SystemCPU is the CPU time spent in kernel mode.
+	SystemCPU time.Duration
+	// MaxRSS is the peak resident set size in bytes. It is zero when not
+	// supported by the operating system.
+	MaxRSS int64
+}
+
+// CPU returns the total CPU time.
+func (u ResourceUsage) CPU() time.Duration {
+	return u.UserCPU + u.SystemCPU
+}
+
+// processUsage returns the resource usage of the exited process, if supported
+// by the operating system.
+func processUsage(ps *os.ProcessState) *ResourceUsage {
+	if ps == nil {
+		return nil
+	}
+	return sysProcessUsage(ps)
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !(aix || android || darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris || windows)
+
+package engine
+
+import "os"
+
+func sysProcessUsage(_ *os.ProcessState) *ResourceUsage {
+	return nil
+}
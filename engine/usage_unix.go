@@ -0,0 +1,31 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build aix || android || darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+
+package engine
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+func sysProcessUsage(ps *os.ProcessState) *ResourceUsage {
+	ru, ok := ps.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return nil
+	}
+	// WHY: ru_maxrss is reported in bytes on Apple systems and in kilobytes
+	// on the others.
+	maxRSS := int64(ru.Maxrss)
+	if runtime.GOOS != "darwin" && runtime.GOOS != "ios" {
+		maxRSS *= 1024
+	}
+	return &ResourceUsage{
+		UserCPU:   time.Duration(ru.Utime.Nano()),
+		SystemCPU: time.Duration(ru.Stime.Nano()),
+		MaxRSS:    maxRSS,
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package engine
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+func sysProcessUsage(ps *os.ProcessState) *ResourceUsage {
+	ru, ok := ps.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return nil
+	}
+	// the peak memory of the process is not available after it exits.
+	return &ResourceUsage{
+		UserCPU:   filetimeDuration(ru.UserTime),
+		SystemCPU: filetimeDuration(ru.KernelTime),
+	}
+}
+
+// filetimeDuration converts a FILETIME holding a duration, in 100-nanosecond
+// intervals, to a time.Duration.
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
+}
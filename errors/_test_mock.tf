@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "errors" {
-  content = <<-EOT
+  content = <<-EOF
 package errors // import "github.com/terramate-io/terramate/errors"
 
 Package errors implements the Terramate standard error type. It's heavily
@@ -23,7 +23,7 @@ type Kind string
     const ErrInternal Kind = "terramate internal error"
 type List struct{ ... }
     func L(errs ...error) *List
-EOT
+EOF
 
   filename = "${path.module}/mock-errors.ignore"
 }
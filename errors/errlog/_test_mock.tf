@@ -1,14 +1,14 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "errlog" {
-  content = <<-EOT
+  content = <<-EOF
 package errlog // import "github.com/terramate-io/terramate/errors/errlog"
 
 Package errlog provides functions to log Terramate errors nicely and in a
 consistent manner.
 
 func Warn(logger zerolog.Logger, err error, args ...any)
-EOT
+EOF
 
   filename = "${path.module}/mock-errlog.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "event" {
-  content = <<-EOT
+  content = <<-EOF
 package event // import "github.com/terramate-io/terramate/event"
 
 Package event implements a simple event stream and defines all events generated
@@ -11,7 +11,7 @@ type Stream[T any] chan T
     func NewStream[T any](buffsize int) Stream[T]
 type VendorProgress struct{ ... }
 type VendorRequest struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-event.ignore"
 }
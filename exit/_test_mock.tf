@@ -1,14 +1,14 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "exit" {
-  content = <<-EOT
+  content = <<-EOF
 package exit // import "github.com/terramate-io/terramate/exit"
 
 Package exit provides standard exit codes for Terramate.
 
 type Status int
     const OK Status = iota ...
-EOT
+EOF
 
   filename = "${path.module}/mock-exit.ignore"
 }
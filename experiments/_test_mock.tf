@@ -0,0 +1,21 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "experiments" {
+  content = <<-EOF
+package experiments // import "github.com/terramate-io/terramate/experiments"
+
+Package experiments implements the registry of the experimental features.
+Each feature registers its experiment, which must be enabled in the project with
+the terramate.config.experiments attribute before usage.
+
+const ErrUnknown errors.Kind = "unknown experiment" ...
+func NotEnabled(name, feature string) *errors.Error
+func Register(exp Experiment)
+func Validate(names []string) (warnings []string, err error)
+type Experiment struct{ ... }
+    func All() []Experiment
+    func Lookup(name string) (Experiment, bool)
+EOF
+
+  filename = "${path.module}/mock-experiments.ignore"
+}
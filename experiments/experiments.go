@@ -0,0 +1,152 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package experiments declares the experimental features. Each experiment
+// must be enabled in the project with the terramate.config.experiments
+// attribute before usage.
+package experiments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/eval"
+)
+
+const (
+	// ErrUnknown indicates the experiment is not declared.
+	ErrUnknown errors.Kind = "unknown experiment"
+
+	// ErrNotEnabled indicates an experimental feature is used without
+	// enabling its experiment.
+	ErrNotEnabled errors.Kind = "experiment not enabled"
+)
+
+// Experiment is an experimental feature.
+type Experiment struct {
+	// Name enabling the experiment in terramate.config.experiments.
+	Name string
+
+	// Description of the feature.
+	Description string
+
+	// Stable tells the feature is not experimental anymore and it's always
+	// enabled, then enabling it is accepted with a warning.
+	Stable bool
+}
+
+// Names of the experiments.
+const (
+	GitMetadata    = "git-metadata"
+	K8s            = "k8s"
+	OutputsSharing = "outputs-sharing"
+	Providers      = "providers"
+	Scripts        = "scripts"
+	Targets        = "targets"
+	Terragrunt     = "terragrunt"
+	Tmgen          = "tmgen"
+	TomlFunctions  = "toml-functions"
+)
+
+// declared are the experiments, sorted by name.
+var declared = []Experiment{
+	{
+		Name:        GitMetadata,
+		Description: "The terramate.git namespace, with the commit, branch, remote URL and dirty state of the repository.",
+	},
+	{
+		Name:        K8s,
+		Description: "The generate_yaml block and the tm_k8s_patch function for Kubernetes manifests.",
+	},
+	{
+		Name:        OutputsSharing,
+		Description: "The sharing_backend, input and output blocks for sharing outputs between stacks.",
+	},
+	{
+		Name:        Providers,
+		Description: "The providers block for generating the Terraform provider requirements of the stacks.",
+	},
+	{
+		Name:        Scripts,
+		Description: "The script block and the script run command.",
+	},
+	{
+		Name:        Targets,
+		Description: "The deployment targets of Terramate Cloud, configured in terramate.config.cloud.targets.",
+	},
+	{
+		Name:        Terragrunt,
+		Description: "The Terragrunt integration, enabled by default.",
+		Stable:      true,
+	},
+	{
+		Name:        Tmgen,
+		Description: "The .tmgen files, generated as HCL with the Terramate functions and variables evaluated.",
+	},
+	{
+		Name:        TomlFunctions,
+		Description: "The tm_tomlencode and tm_tomldecode functions.",
+	},
+}
+
+// Lookup returns the experiment with the given name.
+func Lookup(name string) (Experiment, bool) {
+	for _, exp := range declared {
+		if exp.Name == name {
+			return exp, true
+		}
+	}
+	return Experiment{}, false
+}
+
+// All returns the experiments sorted by name.
+func All() []Experiment {
+	return append([]Experiment{}, declared...)
+}
+
+// Validate checks the names are declared experiments. The unknown names
+// are reported in an error of kind [ErrUnknown], with the similarly named
+// experiments, and the stable ones are reported as warnings.
+func Validate(names []string) (warnings []string, err error) {
+	var known []string
+	for _, exp := range All() {
+		if !exp.Stable {
+			known = append(known, exp.Name)
+		}
+	}
+	errs := errors.L()
+	for _, name := range names {
+		exp, ok := Lookup(name)
+		switch {
+		case !ok:
+			msg := fmt.Sprintf("%q", name)
+			if suggestions := eval.Suggest(name, known); len(suggestions) > 0 {
+				msg += fmt.Sprintf(", did you mean %s?", quoteList(suggestions))
+			} else {
+				msg += fmt.Sprintf(" (available: %s)", quoteList(known))
+			}
+			errs.Append(errors.E(ErrUnknown, msg))
+		case exp.Stable:
+			warnings = append(warnings, fmt.Sprintf(
+				"experiment %q is stable and always enabled, it can be removed from terramate.config.experiments", name))
+		}
+	}
+	return warnings, errs.AsError()
+}
+
+// NotEnabled returns an error of kind [ErrNotEnabled] for the usage of the
+// feature without enabling the experiment, showing how to enable it.
+func NotEnabled(name, feature string) *errors.Error {
+	return errors.E(ErrNotEnabled,
+		"%s is an experimental feature, it must be enabled before usage with `terramate.config.experiments = [%q]`",
+		feature, name)
+}
+
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package experiments_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
+	errtest "github.com/terramate-io/terramate/test/errors"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	warnings, err := experiments.Validate([]string{"scripts"})
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0, len(warnings))
+
+	warnings, err = experiments.Validate([]string{"terragrunt", "scripts"})
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(warnings))
+	assertContains(t, warnings[0], `"terragrunt" is stable`)
+}
+
+func TestValidateUnknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := experiments.Validate([]string{"script"})
+	errtest.Assert(t, err, errors.E(experiments.ErrUnknown))
+	assertContains(t, err.Error(), `did you mean "scripts"?`)
+
+	_, err = experiments.Validate([]string{"xyz"})
+	errtest.Assert(t, err, errors.E(experiments.ErrUnknown))
+	assertContains(t, err.Error(), `available: `)
+	if strings.Contains(err.Error(), `"terragrunt"`) {
+		t.Fatalf("stable experiments must not be listed: %v", err)
+	}
+}
+
+func TestAllSortedAndUnique(t *testing.T) {
+	t.Parallel()
+
+	all := experiments.All()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name >= all[i].Name {
+			t.Fatalf("experiments must be sorted and unique: %q before %q", all[i-1].Name, all[i].Name)
+		}
+	}
+}
+
+func TestNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	err := experiments.NotEnabled(experiments.Scripts, `block "test"`)
+	errtest.Assert(t, err, errors.E(experiments.ErrNotEnabled))
+	assertContains(t, err.Error(), "block \"test\" is an experimental feature, it must be enabled "+
+		"before usage with `terramate.config.experiments = [\"scripts\"]`")
+}
+
+func assertContains(t *testing.T, s, substr string) {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		t.Fatalf("%q does not contain %q", s, substr)
+	}
+}
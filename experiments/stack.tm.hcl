@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package experiments // import \"github.com/terramate-io/terramate/experiments\""
+  description = "package experiments // import \"github.com/terramate-io/terramate/experiments\"\n\nPackage experiments implements the registry of the experimental features.\nEach feature registers its experiment, which must be enabled in the project with\nthe terramate.config.experiments attribute before usage.\n\nconst ErrUnknown errors.Kind = \"unknown experiment\" ...\nfunc NotEnabled(name, feature string) *errors.Error\nfunc Register(exp Experiment)\nfunc Validate(names []string) (warnings []string, err error)\ntype Experiment struct{ ... }\n    func All() []Experiment\n    func Lookup(name string) (Experiment, bool)"
+  tags        = ["experiments", "golang"]
+  id          = "1b293123-e0df-4d00-a644-3579127ef297"
+}
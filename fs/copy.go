@@ -79,14 +79,23 @@ func CopyAll(dstdir, srcdir string) error {
 	})
 }
 
-// CopyFile copies a file from srcfile to destfile.
+// CopyFile copies a file from srcfile to destfile. The destfile is executable
+// if the srcfile is executable.
 func CopyFile(destfile, srcfile string) error {
 	src, err := os.Open(srcfile)
 	if err != nil {
 		return errors.E(err, "opening source file")
 	}
 	defer closeFile(src)
-	dest, err := os.Create(destfile)
+	st, err := src.Stat()
+	if err != nil {
+		return errors.E(err, "stating source file")
+	}
+	perm := os.FileMode(0666)
+	if st.Mode()&0111 != 0 {
+		perm = 0777
+	}
+	dest, err := os.OpenFile(destfile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, perm)
 	if err != nil {
 		return errors.E(err, "creating dest file")
 	}
@@ -5,6 +5,7 @@ package fs
 
 import (
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
@@ -14,7 +15,10 @@ import (
 	"github.com/terramate-io/terramate/errors"
 )
 
-const tmgenExt = ".tmgen"
+const (
+	tmgenExt  = ".tmgen"
+	tmJSONExt = ".tm.json"
+)
 
 // ListResult contains the result of listing a directory.
 type ListResult struct {
@@ -24,6 +28,13 @@ type ListResult struct {
 	TgRootFile string
 	Dirs       []string
 	Skipped    []string
+
+	// SymlinkDirs are the symbolic links to directories.
+	SymlinkDirs []string
+
+	// ConfigSuffixes are the additional suffixes of the files classified as
+	// Terramate files.
+	ConfigSuffixes []string
 }
 
 // AddFile adds a file to the ListResult. It classifies the file accordingly.
@@ -31,7 +42,7 @@ func (r *ListResult) AddFile(name string) {
 	switch {
 	case name[0] == '.':
 		r.Skipped = append(r.Skipped, name)
-	case isTerramateFile(name):
+	case isTerramateFile(name) || hasConfigSuffix(name, r.ConfigSuffixes):
 		r.TmFiles = append(r.TmFiles, name)
 	case strings.HasSuffix(name, tmgenExt) && len(name) > len(tmgenExt):
 		r.TmGenFiles = append(r.TmGenFiles, name)
@@ -54,9 +65,19 @@ func (r *ListResult) AddDir(name string) {
 	}
 }
 
+// AddSymlinkDir adds a symbolic link to a directory to the ListResult.
+func (r *ListResult) AddSymlinkDir(name string) {
+	if name[0] == '.' {
+		r.Skipped = append(r.Skipped, name)
+	} else {
+		r.SymlinkDirs = append(r.SymlinkDirs, name)
+	}
+}
+
 // ListTerramateFiles returns the entries of directory separated (terramate files, others  and
-// directories)
-func ListTerramateFiles(dir string) (ListResult, error) {
+// directories). The files with any of the given configSuffixes are also
+// classified as Terramate files.
+func ListTerramateFiles(dir string, configSuffixes ...string) (ListResult, error) {
 	f, err := os.Open(dir)
 	if err != nil {
 		return ListResult{}, errors.E(err, "opening directory %s for reading file entries", dir)
@@ -71,16 +92,20 @@ func ListTerramateFiles(dir string) (ListResult, error) {
 		return ListResult{}, errors.E(err, "reading dir to list Terramate files")
 	}
 
-	res := ListResult{}
+	res := ListResult{ConfigSuffixes: configSuffixes}
 	for _, entry := range dirEntries {
 		fname := entry.Name()
-		if entry.IsDir() {
+		switch {
+		case entry.IsDir():
 			res.AddDir(fname)
-		} else {
+		case entry.Type()&os.ModeSymlink != 0 && isDir(filepath.Join(dir, fname)):
+			res.AddSymlinkDir(fname)
+		default:
 			res.AddFile(fname)
 		}
 	}
 	sort.Strings(res.Dirs)
+	sort.Strings(res.SymlinkDirs)
 	sort.Strings(res.TmFiles)
 	sort.Strings(res.TmGenFiles)
 	sort.Strings(res.OtherFiles)
@@ -88,6 +113,12 @@ func ListTerramateFiles(dir string) (ListResult, error) {
 	return res, nil
 }
 
+// isDir tells if path is a directory, following symbolic links.
+func isDir(path string) bool {
+	st, err := os.Stat(path)
+	return err == nil && st.IsDir()
+}
+
 func isTerramateFile(filename string) bool {
 	if len(filename) <= 3 || filename[0] == '.' {
 		return false
@@ -99,7 +130,24 @@ func isTerramateFile(filename string) bool {
 		return strings.HasSuffix(filename, ".tm.hcl")
 	case 'm':
 		return strings.HasSuffix(filename, ".tm")
+	case 'n':
+		return IsTerramateJSONFile(filename)
+	}
+}
+
+func hasConfigSuffix(filename string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(filename, suffix) && len(filename) > len(suffix) {
+			return true
+		}
 	}
+	return false
+}
+
+// IsTerramateJSONFile tells if the file is a Terramate configuration file
+// written in the HCL JSON syntax (*.tm.json).
+func IsTerramateJSONFile(filename string) bool {
+	return strings.HasSuffix(filename, tmJSONExt) && len(filename) > len(tmJSONExt)
 }
 
 func isTerragruntRootConfig(filename string) bool {
@@ -16,8 +16,9 @@ func TestListTerramateFiles(t *testing.T) {
 	t.Parallel()
 
 	type testcase struct {
-		layout []string
-		want   fs.ListResult
+		layout   []string
+		suffixes []string
+		want     fs.ListResult
 	}
 
 	for _, tc := range []testcase{
@@ -59,6 +60,18 @@ func TestListTerramateFiles(t *testing.T) {
 				Skipped: []string{".test.tm.hcl", ".tmskip"},
 			},
 		},
+		{
+			layout: []string{
+				"f:test.tm.json",
+				"f:test.json",
+				"f:.tm.json",
+			},
+			want: fs.ListResult{
+				TmFiles:    []string{"test.tm.json"},
+				OtherFiles: []string{"test.json"},
+				Skipped:    []string{".tm.json"},
+			},
+		},
 		{
 			layout: []string{
 				"f:test.tm.hcl",
@@ -79,11 +92,41 @@ func TestListTerramateFiles(t *testing.T) {
 				Skipped:    []string{".test.txt", ".tmskip"},
 			},
 		},
+		{
+			layout: []string{
+				"d:dir",
+				"f:file.txt",
+				"l:dir:linkdir",
+				"l:file.txt:linkfile",
+				"l:dir:.linkdir",
+			},
+			want: fs.ListResult{
+				OtherFiles:  []string{"file.txt", "linkfile"},
+				Dirs:        []string{"dir"},
+				Skipped:     []string{".linkdir"},
+				SymlinkDirs: []string{"linkdir"},
+			},
+		},
+		{
+			layout: []string{
+				"f:stack.terramate.hcl",
+				"f:globals.tm",
+				"f:main.hcl",
+				"f:.terramate.hcl",
+			},
+			suffixes: []string{".terramate.hcl"},
+			want: fs.ListResult{
+				TmFiles:        []string{"globals.tm", "stack.terramate.hcl"},
+				OtherFiles:     []string{"main.hcl"},
+				Skipped:        []string{".terramate.hcl"},
+				ConfigSuffixes: []string{".terramate.hcl"},
+			},
+		},
 	} {
 		tc := tc
 		s := sandbox.NoGit(t, false)
 		s.BuildTree(tc.layout)
-		got, err := fs.ListTerramateFiles(s.RootDir())
+		got, err := fs.ListTerramateFiles(s.RootDir(), tc.suffixes...)
 		assert.NoError(t, err)
 		if diff := cmp.Diff(tc.want, got); diff != "" {
 			t.Errorf("ListTerramateFiles() result mismatch (-want +got):\n%s", diff)
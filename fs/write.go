@@ -0,0 +1,83 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package fs
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+// WriteFileAtomic writes data to the named file atomically.
+// The data is written to a temporary file in the same directory which is
+// then renamed to filename, so readers never observe a partially written file,
+// even if the process is killed in the middle of the write.
+// If the file already exists its permissions are preserved, otherwise it's
+// created with perm (before umask).
+// If fsync is true, the file content and the parent directory entry are
+// flushed to stable storage before returning.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode, fsync bool) (err error) {
+	dir := filepath.Dir(filename)
+	tmp, err := createTempFile(dir, filepath.Base(filename), perm)
+	if err != nil {
+		return errors.E(err, "creating temporary file")
+	}
+
+	tmpname := tmp.Name()
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmpname)
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return errors.E(err, "writing temporary file")
+	}
+
+	if fsync {
+		if err = tmp.Sync(); err != nil {
+			return errors.E(err, "syncing temporary file")
+		}
+	}
+
+	if err = tmp.Close(); err != nil {
+		return errors.E(err, "closing temporary file")
+	}
+
+	if st, statErr := os.Stat(filename); statErr == nil {
+		if err = os.Chmod(tmpname, st.Mode().Perm()); err != nil {
+			return errors.E(err, "setting permissions of temporary file")
+		}
+	}
+
+	if err = os.Rename(tmpname, filename); err != nil {
+		return errors.E(err, "renaming temporary file")
+	}
+
+	if fsync {
+		if err = syncDir(dir); err != nil {
+			return errors.E(err, "syncing directory %s", dir)
+		}
+	}
+	return nil
+}
+
+const tempFileMarker = ".tmtmp"
+
+func createTempFile(dir, base string, perm os.FileMode) (*os.File, error) {
+	const maxTries = 10000
+	for i := 0; i < maxTries; i++ {
+		name := filepath.Join(dir, "."+base+tempFileMarker+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) {
+			continue
+		}
+		return f, err
+	}
+	return nil, errors.E("unable to create temporary file for %s in %s", base, dir)
+}
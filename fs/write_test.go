@@ -0,0 +1,68 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package fs_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/fs"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	for _, fsync := range []bool{false, true} {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "file.txt")
+
+		assert.NoError(t, fs.WriteFileAtomic(filename, []byte("first"), 0644, fsync))
+		assertFileContent(t, filename, "first")
+
+		assert.NoError(t, fs.WriteFileAtomic(filename, []byte("second"), 0644, fsync))
+		assertFileContent(t, filename, "second")
+
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.EqualInts(t, 1, len(entries), "temporary files left behind: %v", entries)
+	}
+}
+
+func TestWriteFileAtomicPreservesPermissions(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "script.sh")
+
+	assert.NoError(t, os.WriteFile(filename, []byte("old"), 0644))
+	assert.NoError(t, os.Chmod(filename, 0755))
+
+	assert.NoError(t, fs.WriteFileAtomic(filename, []byte("new"), 0644, false))
+	assertFileContent(t, filename, "new")
+
+	st, err := os.Stat(filename)
+	assert.NoError(t, err)
+	assert.IsTrue(t, st.Mode().Perm() == 0755, "permissions not preserved: %s", st.Mode())
+}
+
+func TestWriteFileAtomicFailsOnMissingDir(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "non-existent", "file.txt")
+	assert.Error(t, fs.WriteFileAtomic(filename, []byte("data"), 0644, false))
+}
+
+func assertFileContent(t *testing.T, filename, want string) {
+	t.Helper()
+	got, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, want, string(got))
+}
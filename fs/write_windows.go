@@ -0,0 +1,10 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package fs
+
+// syncDir is a no-op on Windows because directories cannot be opened for
+// syncing and renames are already persisted by NTFS metadata journaling.
+func syncDir(_ string) error { return nil }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "generate" {
-  content = <<-EOT
+  content = <<-EOF
 package generate // import "github.com/terramate-io/terramate/generate"
 
 Package generate implements code generation. It includes all available code
@@ -18,7 +18,7 @@ type LoadResult struct{ ... }
 type Report struct{ ... }
     func Do(root *config.Root, dir project.Path, vendorDir project.Path, ...) Report
 type Result struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-generate.ignore"
 }
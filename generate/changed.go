@@ -0,0 +1,73 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/project"
+)
+
+// withoutChangedDependent removes from the outdated files the ones whose
+// content depends on the terramate.changed namespace. Their content depends on
+// the changed stacks when they were generated with --changed, so it can't be
+// checked against the configuration.
+//
+// The files are loaded by load without changed stacks and again with all the
+// stacks changed, the files with a different content depend on the namespace.
+// It changes the changed stacks of the root temporarily, so it must not run
+// concurrently with other evaluations of the root.
+func withoutChangedDependent(root *config.Root, outdated []string, load func() ([]GenFile, error)) ([]string, error) {
+	if len(outdated) == 0 {
+		return outdated, nil
+	}
+
+	prev, _ := root.ChangedStacks()
+	defer root.SetChangedStacks(prev)
+
+	root.SetChangedStacks(nil)
+	unchanged, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	all := project.Paths{}
+	for _, st := range root.Tree().Stacks() {
+		all = append(all, st.Dir())
+	}
+	root.SetChangedStacks(all)
+	changed, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	unchangedContent := genFilesContent(root, unchanged)
+	changedContent := genFilesContent(root, changed)
+
+	var res []string
+	for _, filename := range outdated {
+		content, ok := unchangedContent[filename]
+		if ok && content != changedContent[filename] {
+			continue
+		}
+		res = append(res, filename)
+	}
+	return res, nil
+}
+
+// genFilesContent returns the content of the generated files with a true
+// condition, by their filename relative to the directory of the check.
+func genFilesContent(root *config.Root, generated []GenFile) map[string]string {
+	contents := map[string]string{}
+	for _, genfile := range generated {
+		if !genfile.Condition() {
+			continue
+		}
+		filename := genfile.Label()
+		if genfile.Context() == "root" {
+			filename = filename[1:]
+		}
+		contents[filename] = fileContent(root, genfile)
+	}
+	return contents
+}
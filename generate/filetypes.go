@@ -0,0 +1,115 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	genreport "github.com/terramate-io/terramate/generate/report"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+	"gopkg.in/yaml.v3"
+)
+
+// checkFileTypes checks the generated files match the types configured in
+// terramate.config.generate.file_types for their labels. A file must match
+// the types of all the patterns matching its label.
+func checkFileTypes(root *config.Root, generated []GenFile) error {
+	fileTypes := configuredFileTypes(root)
+	if len(fileTypes) == 0 {
+		return nil
+	}
+	errs := errors.L()
+	for _, file := range generated {
+		if file.Builtin() || !file.Condition() {
+			continue
+		}
+		for _, ft := range fileTypes {
+			if !matchFileType(ft.Pattern, file.Label()) {
+				continue
+			}
+			if err := checkFileType(ft.Type, file); err != nil {
+				errs.Append(errors.E(ErrInvalidFileType, file.Range(), err,
+					"%s: files matching %q must be of type %q", file.Label(), ft.Pattern, ft.Type))
+			}
+		}
+	}
+	return errs.AsError()
+}
+
+// checkRootFileTypes checks the types of the files generated in the root
+// context, adding the failures to the report by target directory.
+func checkRootFileTypes(root *config.Root, files []GenFile, report *genreport.Report) {
+	for _, file := range files {
+		if err := checkFileTypes(root, []GenFile{file}); err != nil {
+			report.AddFailure(project.NewPath(path.Clean("/"+path.Dir(file.Label()))), err)
+		}
+	}
+}
+
+func configuredFileTypes(root *config.Root) []hcl.GenerateFileType {
+	tmConfig := root.Tree().Node.Terramate
+	if tmConfig == nil || tmConfig.Config == nil || tmConfig.Config.Generate == nil {
+		return nil
+	}
+	return tmConfig.Config.Generate.FileTypes
+}
+
+// matchFileType tells if the label matches the pattern. Patterns without
+// a slash match the base name of the label.
+func matchFileType(pattern, label string) bool {
+	if !strings.Contains(pattern, "/") {
+		label = path.Base(label)
+	} else {
+		// labels of files generated in the root context are absolute.
+		label = strings.TrimPrefix(label, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	// the patterns are validated when loading the config.
+	matched, _ := path.Match(pattern, label)
+	return matched
+}
+
+func checkFileType(typ string, file GenFile) error {
+	switch typ {
+	case hcl.FileTypeGenerateHCL:
+		if _, ok := file.(genhcl.HCL); !ok {
+			return errors.E("generated by a block other than generate_hcl")
+		}
+	case hcl.FileTypeHCL:
+		if _, ok := file.(genhcl.HCL); ok {
+			return nil
+		}
+		_, diags := hclsyntax.ParseConfig([]byte(file.Body()), file.Label(), hhcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return errors.E(diags)
+		}
+	case hcl.FileTypeYAML:
+		dec := yaml.NewDecoder(strings.NewReader(file.Body()))
+		for {
+			var doc yaml.Node
+			err := dec.Decode(&doc)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.E(err, "invalid YAML")
+			}
+		}
+	case hcl.FileTypeJSON:
+		var v any
+		if err := json.Unmarshal([]byte(file.Body()), &v); err != nil {
+			return errors.E(err, "invalid JSON")
+		}
+	}
+	return nil
+}
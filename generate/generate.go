@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -21,17 +22,19 @@ import (
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/event"
+	tmfs "github.com/terramate-io/terramate/fs"
 	"github.com/terramate-io/terramate/generate/genfile"
 	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/generate/providers"
 	genreport "github.com/terramate-io/terramate/generate/report"
 	"github.com/terramate-io/terramate/generate/sharing"
 	"github.com/terramate-io/terramate/globals"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/lets"
 	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/stack"
-	"github.com/terramate-io/terramate/stdlib"
 	tel "github.com/terramate-io/terramate/ui/tui/telemetry"
 )
 
@@ -43,6 +46,10 @@ const (
 	// was not previously generated by Terramate.
 	ErrManualCodeExists errors.Kind = "manually defined code found"
 
+	// ErrManualCodeCollision indicates generated files would replace
+	// manually written files tracked by git.
+	ErrManualCodeCollision errors.Kind = "generated files collide with manually written files tracked by git"
+
 	// ErrConflictingConfig indicates that two code generation configurations
 	// are conflicting, like both generates a file with the same name
 	// and would overwrite each other.
@@ -54,7 +61,19 @@ const (
 
 	// ErrAssertion indicates that code generation configuration
 	// has a failed assertion.
-	ErrAssertion errors.Kind = "assertion failed"
+	ErrAssertion = config.ErrAssertion
+
+	// ErrSharedStackConflict indicates that stacks sharing the same directory,
+	// through symbolic links, generate conflicting files.
+	ErrSharedStackConflict errors.Kind = "conflicting generation of shared stack directory"
+
+	// ErrInvalidFileType indicates that a generated file doesn't match the
+	// type configured for its label in terramate.config.generate.file_types.
+	ErrInvalidFileType errors.Kind = "generated file does not match its file type"
+
+	// ErrGeneratedFileModified indicates that a file tracked by the generate
+	// state file was modified after generated, so it's not deleted.
+	ErrGeneratedFileModified errors.Kind = "generated file modified manually"
 )
 
 // GenFile represents a generated file loaded from a Terramate configuration.
@@ -75,6 +94,10 @@ type GenFile interface {
 	Condition() bool
 	// Asserts is the origin generate block assert blocks.
 	Asserts() []config.Assert
+	// EvalDuration is the time spent evaluating the origin generate block.
+	EvalDuration() time.Duration
+	// FormatDuration is the time spent formatting the generated code.
+	FormatDuration() time.Duration
 }
 
 // LoadResult represents all generated files of a specific directory.
@@ -92,44 +115,48 @@ type LoadResult struct {
 // Each directory will be represented by a single [LoadResult] inside the returned slice.
 // Errors generating code for specific dirs will be found inside each [LoadResult].
 //
+// The stacks are loaded in parallel, using at most parallel goroutines or
+// the number of CPUs if parallel is zero.
+//
 // The given vendorDir is used when calculating the vendor path using tm_vendor
 // on the generate blocks.
 //
 // If a critical error that fails the loading of all results happens it returns
 // a non-nil error. In this case the error is not specific to generating code
 // for a specific dir.
-func Load(root *config.Root, vendorDir project.Path) ([]LoadResult, error) {
+func Load(root *config.Root, parallel int, vendorDir project.Path) ([]LoadResult, error) {
 	stacks, err := config.LoadAllStacks(root, root.Tree())
 	if err != nil {
 		return nil, err
 	}
 	results := make([]LoadResult, len(stacks))
 
-	for i, st := range stacks {
+	forEachStack(parallelism(parallel), len(stacks), func(i int) {
+		st := stacks[i]
 		res := LoadResult{Dir: st.Dir()}
 		loadres := globals.ForStack(root, st.Stack)
 		if err := loadres.AsError(); err != nil {
 			res.Err = err
 			results[i] = res
-			continue
+			return
 		}
 		cfg, _ := root.Lookup(st.Dir())
 		generated, err := loadStackCodeCfgs(root, cfg, vendorDir, nil)
 		if err != nil {
 			res.Err = errors.E(err, "while loading configs of stack %s", st.Dir())
 			results[i] = res
-			continue
+			return
 		}
 		res.Files = generated
 		results[i] = res
-	}
+	})
 
 	for _, dircfg := range root.Tree().AsList() {
 		if dircfg.IsEmptyConfig() || dircfg.IsStack() {
 			continue
 		}
 		res := LoadResult{Dir: dircfg.Dir()}
-		evalctx := eval.NewContext(stdlib.Functions(dircfg.HostDir(), root.Tree().Node.Experiments()))
+		evalctx := root.NewEvalContext(dircfg.HostDir())
 
 		var generated []GenFile
 		for _, block := range dircfg.Node.Generate.Files {
@@ -137,6 +164,23 @@ func Load(root *config.Root, vendorDir project.Path) ([]LoadResult, error) {
 				continue
 			}
 
+			forEachStack, err := genfile.ForEachStack(block, evalctx)
+			if err == nil && forEachStack {
+				var files []genfile.File
+				files, err = evalRootBlockForEachStack(root, dircfg, block)
+				for _, file := range files {
+					generated = append(generated, file)
+				}
+			}
+			if err != nil {
+				res.Err = errors.L(res.Err, err).AsError()
+				results = append(results, res)
+				continue
+			}
+			if forEachStack {
+				continue
+			}
+
 			file, skip, err := genfile.Eval(block, dircfg, evalctx)
 			if err != nil {
 				res.Err = errors.L(res.Err, err).AsError()
@@ -158,6 +202,52 @@ func Load(root *config.Root, vendorDir project.Path) ([]LoadResult, error) {
 	return results, nil
 }
 
+// Option is an option of the code generation.
+type Option func(*options)
+
+type options struct {
+	isTracked func(path string) bool
+	progress  func(phase string, stack project.Path, done, total int)
+	stacks    project.Paths
+}
+
+// Phases of the code generation reported with [WithProgress].
+const (
+	PhaseEvaluate = "evaluate"
+	PhaseSave     = "save"
+)
+
+// WithTrackedFiles enables the detection of generated files replacing
+// manually written files tracked by git. The isTracked function tells if
+// the file at the given host path is tracked. If any collision is found then
+// no file is saved and all the collisions are reported in an error of kind
+// [ErrManualCodeCollision].
+func WithTrackedFiles(isTracked func(path string) bool) Option {
+	return func(opts *options) {
+		opts.isTracked = isTracked
+	}
+}
+
+// WithProgress sets the function called each time a stack finishes a phase
+// of the code generation, with the number of stacks done and the total of
+// stacks of the phase. The stacks are evaluated ([PhaseEvaluate]) and then
+// saved ([PhaseSave]). The function is called concurrently.
+func WithProgress(fn func(phase string, stack project.Path, done, total int)) Option {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
+// WithStacks restricts the code generation to the stacks in the given
+// directories. The files of the root context are not generated and the
+// orphaned generated files are not removed, as they don't belong to any of
+// the stacks.
+func WithStacks(dirs project.Paths) Option {
+	return func(opts *options) {
+		opts.stacks = dirs
+	}
+}
+
 // Do will generate code for the entire configuration.
 //
 // There generation mechanism depend on the generate_* block context attribute:
@@ -191,11 +281,17 @@ func Do(
 	parallel int,
 	vendorDir project.Path,
 	vendorRequests chan<- event.VendorRequest,
+	opts ...Option,
 ) *genreport.Report {
 	logger := log.With().
 		Stringer("target_dir", targetDir).
 		Logger()
 
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	startTime := time.Now()
 	defer func() {
 		endTime := time.Now()
@@ -212,84 +308,210 @@ func Do(
 			BootstrapErr: errors.E("directory %s not found", targetDir),
 		}
 	}
-	if parallel == 0 {
-		parallel = runtime.NumCPU()
-	}
+	parallel = parallelism(parallel)
 
 	logger = logger.With().Int("parallel", parallel).Logger()
 
-	workchan := make(chan *config.Tree)
-	reportchan := make(chan *genreport.Report)
-	var wg sync.WaitGroup
-	for i := 0; i < parallel; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for cfg := range workchan {
-				reportchan <- stackGenerate(root, cfg, vendorDir, vendorRequests)
-			}
-		}()
+	// the code of all the stacks is loaded before saving any file, so the
+	// collisions with manually written files are reported all at once.
+	var (
+		stacks []*config.Tree
+		shared [][2]*config.Tree
+	)
+	// stacks discovered through symlinks may share the same directory, which
+	// must be generated only once.
+	owners := map[string]*config.Tree{}
+	for _, cfg := range tree.Stacks() {
+		if options.stacks != nil && !slices.Contains(options.stacks, cfg.Dir()) {
+			continue
+		}
+		realdir, err := filepath.EvalSymlinks(cfg.HostDir())
+		if err != nil {
+			realdir = cfg.HostDir()
+		}
+		if owner, ok := owners[realdir]; ok {
+			shared = append(shared, [2]*config.Tree{owner, cfg})
+			continue
+		}
+		owners[realdir] = cfg
+		stacks = append(stacks, cfg)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		reportchan <- rootGenerate(root, targetDir)
-	}()
+	gens := make([]*stackGeneration, len(stacks))
+	reports := make([]*genreport.Report, len(stacks))
+	evaluated := options.phaseProgress(PhaseEvaluate, len(stacks))
+	forEachStack(parallel, len(stacks), func(i int) {
+		reports[i] = &genreport.Report{}
+		gens[i] = loadStackGeneration(root, stacks[i], vendorDir, vendorRequests, reports[i])
+		evaluated(stacks[i].Dir())
+	})
+
+	if options.isTracked != nil {
+		if err := checkManualCodeCollisions(root, gens, options.isTracked); err != nil {
+			return &genreport.Report{BootstrapErr: err}
+		}
+	}
 
-	var report *genreport.Report
-	mergedReports := make(chan struct{})
+	rootReport := make(chan *genreport.Report, 1)
 	go func() {
-		report = genreport.Merge(reportchan)
-		mergedReports <- struct{}{}
+		if options.stacks != nil {
+			rootReport <- &genreport.Report{}
+			return
+		}
+		rootReport <- rootGenerate(root, targetDir)
 	}()
 
-	for _, cfg := range tree.Stacks() {
-		workchan <- cfg
-	}
+	saved := options.phaseProgress(PhaseSave, len(stacks))
+	forEachStack(parallel, len(stacks), func(i int) {
+		if gens[i] != nil {
+			saveStackGeneration(root, gens[i], reports[i])
+		}
+		saved(stacks[i].Dir())
+	})
 
-	close(workchan)
-	wg.Wait()
-	close(reportchan)
+	sharedReports := make([]*genreport.Report, len(shared))
+	forEachStack(parallel, len(shared), func(i int) {
+		sharedReports[i] = sharedStackGenerate(root, shared[i][0], shared[i][1], vendorDir)
+	})
 
-	<-mergedReports
+	// the reports are merged in the stacks order, independent of the order
+	// the workers finished, so the merged errors are deterministic.
+	reportchan := make(chan *genreport.Report, 1+len(reports)+len(sharedReports))
+	reportchan <- <-rootReport
+	for _, r := range reports {
+		reportchan <- r
+	}
+	for _, r := range sharedReports {
+		reportchan <- r
+	}
+	close(reportchan)
 
+	report := genreport.Merge(reportchan)
+	if options.stacks != nil {
+		report.Sort()
+		return report
+	}
 	return cleanupOrphaned(root, tree, report)
 }
 
-// stackGenerate assumes cfg is a stack.
-func stackGenerate(
+// phaseProgress returns the function reporting that a stack finished the
+// phase, counting the stacks done.
+func (opts options) phaseProgress(phase string, total int) func(stack project.Path) {
+	if opts.progress == nil {
+		return func(project.Path) {}
+	}
+	var (
+		mu   sync.Mutex
+		done int
+	)
+	return func(stack project.Path) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		opts.progress(phase, stack, done, total)
+	}
+}
+
+// sharedStackGenerate checks that the stack cfg, which shares its directory
+// with the owner stack, generates the same files as the owner.
+// Both are assumed to be stacks.
+func sharedStackGenerate(
 	root *config.Root,
+	owner *config.Tree,
 	cfg *config.Tree,
 	vendorDir project.Path,
-	vendorRequests chan<- event.VendorRequest,
 ) *genreport.Report {
-	logger := log.With().
-		Str("action", "stackGenerate()").
-		Stringer("stack", cfg.Dir()).
-		Logger()
-
-	startTime := time.Now()
-	defer func() {
-		endTime := time.Now()
-		logger.Debug().
-			Time("started_at", startTime).
-			Time("finished_at", endTime).
-			Dur("elapsed_time_ms", endTime.Sub(startTime)).
-			Msg("stack generation finished")
-	}()
 	report := &genreport.Report{}
 
+	ownerFiles, err := loadStackCodeCfgs(root, owner, vendorDir, nil)
+	if err != nil {
+		// already reported by the owner generation.
+		return report
+	}
+	files, err := loadStackCodeCfgs(root, cfg, vendorDir, nil)
+	if err != nil {
+		report.AddFailure(cfg.Dir(), err)
+		return report
+	}
+
+	contents := func(files []GenFile) map[string]string {
+		res := map[string]string{}
+		for _, file := range files {
+			if file.Condition() {
+				res[file.Label()] = fileContent(root, file)
+			}
+		}
+		return res
+	}
+
+	want := contents(ownerFiles)
+	got := contents(files)
+	errs := errors.L()
+	for label, content := range got {
+		wantContent, ok := want[label]
+		if !ok {
+			errs.Append(errors.E(ErrSharedStackConflict,
+				"%s: not generated in stack %s sharing the same directory", label, owner.Dir()))
+			continue
+		}
+		if content != wantContent {
+			errs.Append(errors.E(ErrSharedStackConflict,
+				"%s: generated differently than in stack %s sharing the same directory", label, owner.Dir()))
+		}
+	}
+	for label := range want {
+		if _, ok := got[label]; !ok {
+			errs.Append(errors.E(ErrSharedStackConflict,
+				"%s: only generated in stack %s sharing the same directory", label, owner.Dir()))
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		report.AddFailure(cfg.Dir(), err)
+	}
+	return report
+}
+
+// stackGeneration is the code generated for a stack, not saved yet.
+type stackGeneration struct {
+	cfg       *config.Tree
+	generated []GenFile
+
+	// allFiles are the contents of the files currently generated in the
+	// stack, by filename.
+	allFiles map[string]string
+
+	// state are the files tracked by the generate state file of the stack,
+	// by filename.
+	state map[string]stateEntry
+}
+
+// loadStackGeneration loads the code generated for the stack cfg, checking
+// it can be saved. It returns nil if the stack failed, adding the failure
+// to the report. It assumes cfg is a stack.
+func loadStackGeneration(
+	root *config.Root,
+	cfg *config.Tree,
+	vendorDir project.Path,
+	vendorRequests chan<- event.VendorRequest,
+	report *genreport.Report,
+) *stackGeneration {
 	_, err := cfg.Stack()
 	if err != nil {
 		report.BootstrapErr = err
-		return report
+		return nil
 	}
 
 	generated, err := loadStackCodeCfgs(root, cfg, vendorDir, vendorRequests)
 	if err != nil {
 		report.AddFailure(cfg.Dir(), err)
-		return report
+		return nil
+	}
+
+	for _, file := range generated {
+		if file.Builtin() {
+			continue
+		}
+		report.AddTiming(cfg.Dir(), file.Label(), file.EvalDuration(), file.FormatDuration())
 	}
 
 	errsmap := checkFileConflict(generated)
@@ -299,24 +521,68 @@ func stackGenerate(
 			errs.Append(err)
 		}
 		report.AddFailure(cfg.Dir(), errs.AsError())
-		return report
+		return nil
 	}
 
 	err = validateStackGeneratedFiles(root, cfg.HostDir(), generated)
 	if err != nil {
 		report.AddFailure(cfg.Dir(), err)
-		return report
+		return nil
+	}
+
+	err = checkFileTypes(root, generated)
+	if err != nil {
+		report.AddFailure(cfg.Dir(), err)
+		return nil
 	}
 
 	allFiles, err := allStackGeneratedFiles(root, cfg.HostDir(), generated)
 	if err != nil {
 		report.AddFailure(cfg.Dir(), errors.E(err, "listing all generated files"))
-		return report
+		return nil
+	}
+
+	entries, err := loadState(cfg.HostDir())
+	if err != nil {
+		report.AddFailure(cfg.Dir(), err)
+		return nil
+	}
+	state := map[string]stateEntry{}
+	for _, entry := range entries {
+		state[entry.Path] = entry
+	}
+
+	return &stackGeneration{
+		cfg:       cfg,
+		generated: generated,
+		allFiles:  allFiles,
+		state:     state,
 	}
+}
+
+// saveStackGeneration saves the generated code of the stack and deletes the
+// files not generated anymore.
+func saveStackGeneration(root *config.Root, gen *stackGeneration, report *genreport.Report) {
+	cfg, generated, allFiles := gen.cfg, gen.generated, gen.allFiles
+	logger := log.With().
+		Str("action", "saveStackGeneration()").
+		Stringer("stack", cfg.Dir()).
+		Logger()
+
+	startTime := time.Now()
+	defer func() {
+		endTime := time.Now()
+		logger.Debug().
+			Time("started_at", startTime).
+			Time("finished_at", endTime).
+			Dur("elapsed_time_ms", endTime.Sub(startTime)).
+			Msg("stack generation finished")
+	}()
 
 	logger.Trace().Msg("saving generated files")
 
 	stackReport := genreport.Dir{}
+	saveFailed := false
 
 	for _, file := range generated {
 		filename := file.Label()
@@ -330,7 +596,15 @@ func stackGenerate(
 			continue
 		}
 
-		body := file.Header() + file.Body()
+		if _, ok := file.(stateFile); ok && saveFailed {
+			// WHY: the state would track the files that failed to be
+			// saved, like manually written files.
+			logger.Trace().Msg("saving files failed, keeping the state file")
+			delete(allFiles, filename)
+			continue
+		}
+
+		body := fileContent(root, file)
 
 		// Change detection + remove entries that got re-generated
 		oldFileBody, oldExists := allFiles[filename]
@@ -339,6 +613,9 @@ func stackGenerate(
 			err := writeGeneratedCode(root, path, file)
 			if err != nil {
 				report.AddFailure(cfg.Dir(), errors.E(err, "saving file %q", filename))
+				// the existing file must not be deleted below.
+				delete(allFiles, filename)
+				saveFailed = true
 				continue
 			}
 		}
@@ -363,7 +640,14 @@ func stackGenerate(
 		}
 	}
 
-	for filename := range allFiles {
+	for filename, body := range allFiles {
+		if entry, ok := gen.state[filename]; ok {
+			if err := checkStateCanDelete(entry, body); err != nil {
+				report.AddFailure(cfg.Dir(), errors.E(err, "removing file %s", filename))
+				continue
+			}
+		}
+
 		log.Info().
 			Stringer("stack", cfg.Dir()).
 			Str("file", filename).
@@ -372,7 +656,7 @@ func stackGenerate(
 		stackReport.AddDeletedFile(filename)
 
 		path := filepath.Join(cfg.HostDir(), filename)
-		err = os.Remove(path)
+		err := os.Remove(path)
 		if err != nil {
 			report.AddFailure(cfg.Dir(), errors.E("removing file %s", filename))
 			continue
@@ -382,7 +666,66 @@ func stackGenerate(
 	}
 
 	report.AddDirReport(cfg.Dir(), stackReport)
-	return report
+}
+
+// parallelism returns the number of goroutines used to process the stacks,
+// which is the number of CPUs if parallel is not positive.
+func parallelism(parallel int) int {
+	if parallel <= 0 {
+		return runtime.NumCPU()
+	}
+	return parallel
+}
+
+// forEachStack calls fn for each index of the n stacks, in parallel, using at
+// most parallel workers. The config tree is shared by all workers, so fn must
+// only read from it.
+func forEachStack(parallel, n int, fn func(i int)) {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
+
+// checkManualCodeCollisions checks that the generated files with a header
+// don't replace files without the Terramate header tracked by git, which
+// are manually written files. All the collisions are reported in the error.
+func checkManualCodeCollisions(root *config.Root, gens []*stackGeneration, isTracked func(path string) bool) error {
+	var collisions []string
+	for _, gen := range gens {
+		if gen == nil {
+			continue
+		}
+		for _, file := range gen.generated {
+			if !file.Condition() || file.Header() == "" {
+				continue
+			}
+			path := filepath.Join(gen.cfg.HostDir(), file.Label())
+			if !isTracked(path) {
+				continue
+			}
+			if _, _, err := readGeneratedFile(root, path); errors.IsKind(err, ErrManualCodeExists) {
+				collisions = append(collisions, project.PrjAbsPath(root.HostDir(), path).String())
+			}
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+	return errors.E(ErrManualCodeCollision, "%s", strings.Join(collisions, ", "))
 }
 
 func rootGenerate(root *config.Root, target project.Path) *genreport.Report {
@@ -402,8 +745,8 @@ func rootGenerate(root *config.Root, target project.Path) *genreport.Report {
 	}()
 
 	report := &genreport.Report{}
-	evalctx := eval.NewContext(stdlib.Functions(root.HostDir(), root.Tree().Node.Experiments()))
-	evalctx.SetNamespace("terramate", root.Runtime())
+	evalctx := root.NewEvalContext(root.HostDir())
+	evalctx.SetNamespace("terramate", root.RootContextRuntime())
 
 	var files []GenFile
 
@@ -427,11 +770,32 @@ func rootGenerate(root *config.Root, target project.Path) *genreport.Report {
 				continue
 			}
 
+			forEachStack, err := genfile.ForEachStack(block, evalctx)
+			if err == nil && forEachStack {
+				var stackFiles []genfile.File
+				stackFiles, err = evalRootBlockForEachStack(root, cfg, block)
+				for _, file := range stackFiles {
+					targetDir := project.NewPath(path.Clean("/" + path.Dir(file.Label())))
+					if !targetDir.HasPrefix(target.String()) {
+						continue
+					}
+					report.AddTiming(targetDir, path.Base(file.Label()), file.EvalDuration(), file.FormatDuration())
+					files = append(files, file)
+				}
+			}
+			if err != nil {
+				report.AddFailure(project.NewPath(path.Clean("/"+path.Dir(block.Label))), err)
+				return report
+			}
+			if forEachStack {
+				continue
+			}
+
 			// TODO(i4k): generate report must be redesigned for context=root
 			// Here we use path.Clean("/"+path.Dir(label)) to ensure the
 			// report.Dir is always absolute.
 			targetDir := project.NewPath(path.Clean("/" + path.Dir(block.Label)))
-			err := validateRootGenerateBlock(root, block)
+			err = validateRootGenerateBlock(root, block)
 			if err != nil {
 				report.AddFailure(targetDir, err)
 				return report
@@ -456,6 +820,7 @@ func rootGenerate(root *config.Root, target project.Path) *genreport.Report {
 
 			logger.Trace().Msg("block evaluated successfully")
 
+			report.AddTiming(targetDir, path.Base(block.Label), file.EvalDuration(), file.FormatDuration())
 			files = append(files, file)
 		}
 	}
@@ -475,6 +840,28 @@ func rootGenerate(root *config.Root, target project.Path) *genreport.Report {
 
 	logger.Trace().Msg("no conflicts found")
 
+	checkRootFileTypes(root, files, report)
+	if report.HasFailures() {
+		return report
+	}
+
+	generateRootFiles(root, files, report)
+	return report
+}
+
+// RootFiles writes the files in the root context, like the files of the
+// generate_file blocks with context = root, so files generated by commands
+// are created, changed and deleted the same way. The files with a false
+// condition are deleted.
+func RootFiles(root *config.Root, files []GenFile) *genreport.Report {
+	report := &genreport.Report{}
+	for file, err := range checkFileConflict(files) {
+		report.AddFailure(project.NewPath(path.Dir(file)), err)
+	}
+	checkRootFileTypes(root, files, report)
+	if report.HasFailures() {
+		return report
+	}
 	generateRootFiles(root, files, report)
 	return report
 }
@@ -493,15 +880,21 @@ func handleAsserts(rootdir string, dir string, asserts []config.Assert) error {
 				log.Warn().
 					Stringer("origin", assertRange).
 					Str("msg", assert.Message).
+					Str("code", assert.Code).
+					Str("docs_url", assert.DocsURL).
 					Str("dir", dir).
 					Msg("assertion failed")
 			} else {
-				msg := fmt.Sprintf("%s: %s", assertRange, assert.Message)
+				assertErr := &config.AssertionError{
+					Range:   assertRange,
+					Message: assert.Message,
+					Code:    assert.Code,
+					DocsURL: assert.DocsURL,
+				}
 
-				logger.Debug().Msgf("assertion failure detected: %s", msg)
+				logger.Debug().Msgf("assertion failure detected: %s", assertErr)
 
-				err := errors.E(ErrAssertion, msg)
-				errs.Append(err)
+				errs.Append(assertErr)
 			}
 		}
 	}
@@ -530,6 +923,13 @@ func handleAsserts(rootdir string, dir string, asserts []config.Assert) error {
 func ListStackGenFiles(root *config.Root, dir string) ([]string, error) {
 	pendingSubDirs := []string{""}
 	genfiles := []string{}
+	found := map[string]bool{}
+	addGenFile := func(file string) {
+		if !found[file] {
+			found[file] = true
+			genfiles = append(genfiles, file)
+		}
+	}
 
 processSubdirs:
 	for len(pendingSubDirs) > 0 {
@@ -548,6 +948,33 @@ processSubdirs:
 			}
 		}
 
+		// The files generated without header are detected by the state file.
+		state, err := loadState(absSubdir)
+		if err != nil {
+			return nil, err
+		}
+		var tracked []string
+		for _, entry := range state {
+			tracked = append(tracked, entry.Path)
+		}
+		if len(tracked) > 0 {
+			tracked = append(tracked, StateFilename)
+		}
+		for _, file := range tracked {
+			info, err := os.Lstat(filepath.Join(absSubdir, file))
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, errors.E(err, "checking generated file %q", file)
+			}
+			if info.Mode().IsRegular() {
+				addGenFile(filepath.ToSlash(filepath.Join(relSubdir, file)))
+			}
+		}
+
+		commentStyle := commentStyleAt(root, absSubdir)
+
 		for _, entry := range entries {
 			if entry.IsDir() {
 				// only dotdirs are ignored.
@@ -580,9 +1007,8 @@ processSubdirs:
 				return nil, errors.E(err, "checking if file is generated %q", file)
 			}
 
-			if hasGenHCLHeader(genhcl.CommentStyleFromConfig(root.Tree()), string(data)) {
-				genfiles = append(genfiles, filepath.ToSlash(
-					filepath.Join(relSubdir, entry.Name())))
+			if hasGenHCLHeader(commentStyle, string(data)) {
+				addGenFile(filepath.ToSlash(filepath.Join(relSubdir, entry.Name())))
 			}
 		}
 	}
@@ -590,9 +1016,57 @@ processSubdirs:
 	return genfiles, nil
 }
 
+// DetectOutdatedStacks will verify if the given stacks have outdated code
+// generated in the stack context and return a list of filenames, relative to
+// the project root, that are outdated, ordered lexicographically.
+// Unlike [DetectOutdated], the code generated by root context blocks and the
+// orphaned files outside of the stacks are not checked.
+// The stacks are checked in parallel, like in [Load].
+func DetectOutdatedStacks(root *config.Root, stacks []*config.Tree, parallel int, vendorDir project.Path) ([]string, error) {
+	outdated, err := detectOutdatedStacks(root, stacks, parallel, vendorDir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(outdated)
+	return outdated, nil
+}
+
+func detectOutdatedStacks(root *config.Root, stacks []*config.Tree, parallel int, vendorDir project.Path) ([]string, error) {
+	stacksOutdated := make([][]string, len(stacks))
+	stacksErrs := make([]error, len(stacks))
+	forEachStack(parallelism(parallel), len(stacks), func(i int) {
+		stacksOutdated[i], stacksErrs[i] = stackContextOutdated(root, stacks[i], vendorDir)
+	})
+	for i, cfg := range stacks {
+		if stacksErrs[i] != nil {
+			continue
+		}
+		stacksOutdated[i], stacksErrs[i] = withoutChangedDependent(root, stacksOutdated[i], func() ([]GenFile, error) {
+			return loadStackCodeCfgs(root, cfg, vendorDir, nil)
+		})
+	}
+
+	var outdatedFiles []string
+	errs := errors.L()
+	for i, cfg := range stacks {
+		if err := stacksErrs[i]; err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		// We want results relative to root
+		dirRelPath := cfg.Dir().String()[1:]
+		for _, file := range stacksOutdated[i] {
+			outdatedFiles = append(outdatedFiles, path.Join(dirRelPath, file))
+		}
+	}
+	return outdatedFiles, errs.AsError()
+}
+
 // DetectOutdated will verify if the given config has outdated code in the target tree
 // and return a list of filenames that are outdated, ordered lexicographically.
-func DetectOutdated(root *config.Root, target *config.Tree, vendorDir project.Path) ([]string, error) {
+// The stacks are checked in parallel, like in [Load].
+func DetectOutdated(root *config.Root, target *config.Tree, parallel int, vendorDir project.Path) ([]string, error) {
 	logger := log.With().
 		Str("action", "generate.DetectOutdated()").
 		Stringer("dir", target.Dir()).
@@ -603,22 +1077,19 @@ func DetectOutdated(root *config.Root, target *config.Tree, vendorDir project.Pa
 
 	logger.Debug().Msg("checking outdated code inside stacks")
 
-	for _, cfg := range target.Stacks() {
-		outdated, err := stackContextOutdated(root, cfg, vendorDir)
-		if err != nil {
-			errs.Append(err)
-			continue
-		}
-
-		// We want results relative to root
-		dirRelPath := cfg.Dir().String()[1:]
-		for _, file := range outdated {
-			outdatedFiles.add(path.Join(dirRelPath, file))
-		}
+	stacksOutdated, err := detectOutdatedStacks(root, target.Stacks(), parallel, vendorDir)
+	errs.Append(err)
+	for _, file := range stacksOutdated {
+		outdatedFiles.add(file)
 	}
 
 	for _, cfg := range target.AsList() {
 		outdated, err := rootContextOutdated(root, cfg)
+		if err == nil {
+			outdated, err = withoutChangedDependent(root, outdated, func() ([]GenFile, error) {
+				return loadRootCodeCfgs(root, cfg)
+			})
+		}
 		if err != nil {
 			errs.Append(err)
 			continue
@@ -783,7 +1254,7 @@ func updateOutdatedFiles(root *config.Root, cfgpath string, generated []GenFile,
 			continue
 		}
 
-		generatedCode := genfile.Header() + genfile.Body()
+		generatedCode := fileContent(root, genfile)
 		if generatedCode != currentCode {
 			logger.Debug().Msg("outdated: code on fs differs from generated from config")
 
@@ -799,12 +1270,13 @@ func updateOutdatedFiles(root *config.Root, cfgpath string, generated []GenFile,
 }
 
 func writeGeneratedCode(root *config.Root, target string, genfile GenFile) error {
-	body := genfile.Header() + genfile.Body()
+	body := fileContent(root, genfile)
 
-	if genfile.Header() != "" {
+	if genfile.Header() != "" || isStateTracked(root, genfile) {
 		// WHY: some file generation strategies don't provide
 		// headers, like generate_file, so we can't detect
-		// if we are overwriting a Terramate generated file.
+		// if we are overwriting a Terramate generated file,
+		// unless it's tracked by the generate state file.
 		if err := checkFileCanBeOverwritten(root, target); err != nil {
 			return err
 		}
@@ -814,7 +1286,22 @@ func writeGeneratedCode(root *config.Root, target string, genfile GenFile) error
 		return err
 	}
 
-	return os.WriteFile(target, []byte(body), 0666)
+	// WHY: the file is written to a temporary file and then renamed, so a
+	// generate killed in the middle of the write never leaves partial files.
+	return tmfs.WriteFileAtomic(target, []byte(body), 0666, fsyncEnabled(root))
+}
+
+// fsyncEnabled tells if the generated files must be flushed to stable storage.
+// It's configured by terramate.config.generate.fsync and defaults to false.
+func fsyncEnabled(root *config.Root) bool {
+	tmConfig := root.Tree().Node.Terramate
+	if tmConfig == nil ||
+		tmConfig.Config == nil ||
+		tmConfig.Config.Generate == nil ||
+		tmConfig.Config.Generate.Fsync == nil {
+		return false
+	}
+	return *tmConfig.Config.Generate.Fsync
 }
 
 func checkFileCanBeOverwritten(root *config.Root, path string) error {
@@ -839,13 +1326,34 @@ func readGeneratedFile(root *config.Root, path string) (string, bool, error) {
 		return "", false, nil
 	}
 
-	if hasGenHCLHeader(genhcl.CommentStyleFromConfig(root.Tree()), data) {
+	if hasGenHCLHeader(commentStyleAt(root, filepath.Dir(path)), data) {
+		return data, true, nil
+	}
+
+	_, tracked, err := lookupState(root, path)
+	if err != nil {
+		return "", false, err
+	}
+	if tracked {
 		return data, true, nil
 	}
 
 	return "", false, errors.E(ErrManualCodeExists, "check file %q", path)
 }
 
+// commentStyleAt returns the comment style of the header of the files
+// generated in the host directory dir.
+func commentStyleAt(root *config.Root, dir string) genhcl.CommentStyle {
+	for p := project.PrjAbsPath(root.HostDir(), dir); ; p = p.Dir() {
+		if cfg, ok := root.Lookup(p); ok {
+			return genhcl.CommentStyleFromConfig(cfg)
+		}
+		if p.String() == "/" {
+			return genhcl.DefaultComment
+		}
+	}
+}
+
 // readFile will load the file at the given path.
 // It returns an error if it can't read the file.
 //
@@ -997,7 +1505,7 @@ func generateRootFiles(root *config.Root, genfiles []GenFile, report *genreport.
 		abspath := filepath.Join(root.HostDir(), label)
 		filename := path.Base(label)
 		dir := project.NewPath(path.Dir(label))
-		body := genfile.Header() + genfile.Body()
+		body := fileContent(root, genfile)
 
 		dirReport := genreport.Dir{}
 		diskContent, existOnDisk := diskFiles[label]
@@ -1047,8 +1555,11 @@ func genBlockLogger(logger zerolog.Logger, blockname, label, context string) zer
 func hasGenHCLHeader(commentStyle genhcl.CommentStyle, code string) bool {
 	// When changing headers we need to support old ones (or break).
 	// For now keeping them here, to avoid breaks.
+	// Generated files may have a BOM and CRLF line endings.
+	code = strings.TrimPrefix(code, utf8BOM)
 	for _, header := range []string{genhcl.Header(commentStyle), genhcl.HeaderV0} {
-		if strings.HasPrefix(code, header) {
+		if strings.HasPrefix(code, header) ||
+			strings.HasPrefix(code, strings.ReplaceAll(header, "\n", "\r\n")) {
 			return true
 		}
 	}
@@ -1136,6 +1647,12 @@ func validateStackGeneratedFiles(root *config.Root, stackpath string, generated
 
 func validateRootGenerateBlock(root *config.Root, block hcl.GenFileBlock) error {
 	target := block.Label
+	if lets.IsLabelTemplate(target) {
+		return errors.E(
+			ErrInvalidGenBlockLabel, block.Range,
+			"%s: label templates of generate_file.context=root require for_each_stack = true", target,
+		)
+	}
 	if !path.IsAbs(target) {
 		return errors.E(
 			ErrInvalidGenBlockLabel, block.Range,
@@ -1293,13 +1810,29 @@ func loadRootCodeCfgs(root *config.Root, cfg *config.Tree) ([]GenFile, error) {
 		if block.Context != "root" {
 			continue
 		}
-		err := validateRootGenerateBlock(root, block)
+
+		evalctx := root.NewEvalContext(cfg.RootDir())
+		evalctx.SetNamespace("terramate", root.RootContextRuntime())
+
+		forEachStack, err := genfile.ForEachStack(block, evalctx)
 		if err != nil {
 			return nil, err
 		}
+		if forEachStack {
+			files, err := evalRootBlockForEachStack(root, cfg, block)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				genfiles = append(genfiles, file)
+			}
+			continue
+		}
 
-		evalctx := eval.NewContext(stdlib.Functions(cfg.RootDir(), root.Tree().Node.Experiments()))
-		evalctx.SetNamespace("terramate", root.Runtime())
+		err = validateRootGenerateBlock(root, block)
+		if err != nil {
+			return nil, err
+		}
 
 		file, skip, err := genfile.Eval(block, cfg, evalctx)
 		if err != nil {
@@ -1313,6 +1846,44 @@ func loadRootCodeCfgs(root *config.Root, cfg *config.Tree) ([]GenFile, error) {
 	return genfiles, nil
 }
 
+// evalRootBlockForEachStack evaluates the context=root block with
+// for_each_stack = true once for each stack of the project. The label template
+// and the block attributes are evaluated using the stack evaluation context,
+// which contains the stack metadata and globals.
+func evalRootBlockForEachStack(root *config.Root, cfg *config.Tree, block hcl.GenFileBlock) ([]genfile.File, error) {
+	stacks, err := config.LoadAllStacks(root, root.Tree())
+	if err != nil {
+		return nil, err
+	}
+
+	var files []genfile.File
+	for _, st := range stacks {
+		report := globals.ForStack(root, st.Stack)
+		if err := report.AsError(); err != nil {
+			return nil, errors.E(ErrLoadingGlobals, err)
+		}
+		evalctx := stack.NewEvalCtx(root, st.Stack, report.Globals)
+
+		stackBlock, err := genfile.EvalLabel(block, evalctx.Context)
+		if err != nil {
+			return nil, errors.E(err, "evaluating label of %s for stack %s", block.Label, st.Dir())
+		}
+		err = validateRootGenerateBlock(root, stackBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		file, skip, err := genfile.Eval(stackBlock, cfg, evalctx.Context)
+		if err != nil {
+			return nil, errors.E(err, "evaluating %s for stack %s", stackBlock.Label, st.Dir())
+		}
+		if !skip {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
 func loadStackCodeCfgs(
 	root *config.Root,
 	cfg *config.Tree,
@@ -1405,15 +1976,46 @@ func loadStackCodeCfgs(
 		if !ok {
 			return nil, errors.E("backend %s not found", backendName)
 		}
-		sharingFile, err := sharing.PrepareFile(root, backend.Filename, file.inputs, file.outputs)
+		sharingFile, err := sharing.PrepareFile(cfg, backend.Filename, file.inputs, file.outputs)
 		if err != nil {
 			return nil, err
 		}
 		genfilesConfigs = append(genfilesConfigs, sharingFile)
 	}
+
+	reqs, found, err := providers.Resolve(cfg, evalctx.Context)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		genfilesConfigs = append(genfilesConfigs, providers.PrepareFile(cfg, reqs))
+	}
+	// the state file is saved after the files it tracks.
+	if state := prepareStateFile(root, genfilesConfigs); state.Condition() {
+		genfilesConfigs = append(genfilesConfigs, state)
+	}
 	return genfilesConfigs, nil
 }
 
+// removeOrphaned removes the orphaned generated file at the given host path,
+// unless it's tracked by the generate state file and was modified.
+func removeOrphaned(root *config.Root, path string) error {
+	entry, tracked, err := lookupState(root, path)
+	if err != nil {
+		return err
+	}
+	if tracked {
+		content, _, err := readFile(path)
+		if err != nil {
+			return err
+		}
+		if err := checkStateCanDelete(entry, content); err != nil {
+			return err
+		}
+	}
+	return os.Remove(path)
+}
+
 func cleanupOrphaned(root *config.Root, target *config.Tree, report *genreport.Report) *genreport.Report {
 	logger := log.With().
 		Str("action", "generate.cleanupOrphaned()").
@@ -1442,7 +2044,7 @@ func cleanupOrphaned(root *config.Root, target *config.Tree, report *genreport.R
 	for _, genfile := range orphanedGenFiles {
 		genfileAbspath := filepath.Join(target.HostDir(), genfile)
 		dir := project.PrjAbsPath(root.HostDir(), filepath.Dir(genfileAbspath))
-		if err := os.Remove(genfileAbspath); err != nil {
+		if err := removeOrphaned(root, genfileAbspath); err != nil {
 			if deleteFailures[dir] == nil {
 				deleteFailures[dir] = errors.L()
 			}
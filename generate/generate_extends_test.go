@@ -0,0 +1,88 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate_test
+
+import (
+	"fmt"
+	"testing"
+
+	genreport "github.com/terramate-io/terramate/generate/report"
+	"github.com/terramate-io/terramate/project"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+)
+
+func TestGenerateStackExtendsTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCodeGeneration(t, []testcase{
+		{
+			name: "template blocks are generated in the extending stacks",
+			layout: []string{
+				"d:templates/base",
+				"s:stacks/a:extends=/templates/base",
+				"s:stacks/b:extends=/templates/base",
+				"s:stacks/c",
+			},
+			configs: []hclconfig{
+				{
+					path: "/templates/base",
+					add: Doc(
+						GenerateHCL(
+							Labels("backend.tf"),
+							Content(
+								Expr("stack", "terramate.stack.name"),
+							),
+						),
+						GenerateFile(
+							Labels("template.txt"),
+							Bool("inherit", false),
+							Str("content", "from template"),
+						),
+					),
+				},
+				{
+					path: "/stacks/b",
+					add: GenerateHCL(
+						Labels("backend.tf"),
+						Content(
+							Str("stack", "overridden"),
+						),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/stacks/a",
+					files: map[string]fmt.Stringer{
+						"backend.tf": Doc(
+							Str("stack", "a"),
+						),
+						"template.txt": stringer("from template"),
+					},
+				},
+				{
+					dir: "/stacks/b",
+					files: map[string]fmt.Stringer{
+						"backend.tf": Doc(
+							Str("stack", "overridden"),
+						),
+						"template.txt": stringer("from template"),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []genreport.Result{
+					{
+						Dir:     project.NewPath("/stacks/a"),
+						Created: []string{"backend.tf", "template.txt"},
+					},
+					{
+						Dir:     project.NewPath("/stacks/b"),
+						Created: []string{"backend.tf", "template.txt"},
+					},
+				},
+			},
+		},
+	})
+}
@@ -1651,6 +1651,59 @@ EOT
 				},
 			},
 		},
+		{
+			name: "generate_hcl with visibility=directory generates only in stacks directly in the directory",
+			layout: []string{
+				"s:/stacks/a",
+				"s:/stacks/b",
+				"s:/stacks/b/c",
+				"s:/other",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stacks",
+					add: Doc(
+						GenerateHCL(
+							Labels("dir.hcl"),
+							Str("visibility", "directory"),
+							Content(
+								Str("hello", "world"),
+							),
+						),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/stacks/a",
+					files: map[string]fmt.Stringer{
+						"dir.hcl": Doc(
+							Str("hello", "world"),
+						),
+					},
+				},
+				{
+					dir: "/stacks/b",
+					files: map[string]fmt.Stringer{
+						"dir.hcl": Doc(
+							Str("hello", "world"),
+						),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []report.Result{
+					{
+						Dir:     project.NewPath("/stacks/a"),
+						Created: []string{"dir.hcl"},
+					},
+					{
+						Dir:     project.NewPath("/stacks/b"),
+						Created: []string{"dir.hcl"},
+					},
+				},
+			},
+		},
 		{
 			name: "generate with inherit=false from imported file on intermediate stack with child stack",
 			layout: []string{
@@ -2121,6 +2174,58 @@ func TestWontOverwriteManuallyDefinedTerraform(t *testing.T) {
 	assert.EqualStrings(t, manualTfCode, actualTfCode, "tf code altered by generate")
 }
 
+func TestGenerateDetectsCollisionsWithTrackedManualCode(t *testing.T) {
+	t.Parallel()
+
+	const (
+		genFilename  = "test.tf"
+		manualTfCode = "some manual stuff, doesn't matter"
+	)
+
+	generateHCLConfig := GenerateHCL(
+		Labels(genFilename),
+		Content(
+			Terraform(
+				Str("required_version", "1.11"),
+			),
+		),
+	)
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		fmt.Sprintf("f:%s:%s", terramate.DefaultFilename, generateHCLConfig.String()),
+		"s:stack-1",
+		"s:stack-2",
+		"s:stack-3",
+		"s:stack-4",
+		fmt.Sprintf("f:stack-1/%s:%s", genFilename, manualTfCode),
+		fmt.Sprintf("f:stack-2/%s:%s", genFilename, manualTfCode),
+		fmt.Sprintf("f:stack-4/%s:%s", genFilename, manualTfCode),
+	})
+
+	tracked := map[string]bool{
+		filepath.Join(s.RootDir(), "stack-1", genFilename): true,
+		filepath.Join(s.RootDir(), "stack-2", genFilename): true,
+	}
+	report := generate.Do(s.Config(), project.NewPath("/"), 0, project.NewPath("/modules"), nil,
+		generate.WithTrackedFiles(func(path string) bool {
+			return tracked[path]
+		}))
+
+	assert.EqualInts(t, 0, len(report.Successes), "want no success")
+	assert.EqualInts(t, 0, len(report.Failures), "want no stack failure")
+	test.AssertReportHasError(t, report, errors.E(generate.ErrManualCodeCollision))
+	for _, stack := range []string{"stack-1", "stack-2"} {
+		assert.IsTrue(t, strings.Contains(report.BootstrapErr.Error(), "/"+stack+"/"+genFilename),
+			"missing collision of %s: %v", stack, report.BootstrapErr)
+	}
+
+	assert.EqualStrings(t, manualTfCode, s.StackEntry("stack-1").ReadFile(genFilename))
+	assert.EqualStrings(t, manualTfCode, s.StackEntry("stack-4").ReadFile(genFilename))
+	_, err := os.Stat(filepath.Join(s.RootDir(), "stack-3", genFilename))
+	assert.IsTrue(t, os.IsNotExist(err), "no file must be generated on collisions")
+}
+
 func TestGenerateHCLStackFilters(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,150 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	genreport "github.com/terramate-io/terramate/generate/report"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateCommentStyleOverriddenByDirectory(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/hash/b",
+		"f:stacks/hash/terramate.tm:" + Terramate(
+			Config(
+				Block("generate",
+					Str("hcl_magic_header_comment_style", "#"),
+				),
+			),
+		).String(),
+		"f:generate.tm:" + GenerateHCL(
+			Labels("file.hcl"),
+			Content(
+				Str("a", "a"),
+			),
+		).String(),
+	})
+
+	s.Generate()
+
+	body := "a = \"a\"\n"
+	assert.EqualStrings(t, genhcl.Header(genhcl.SlashComment)+body,
+		string(s.DirEntry("stacks/a").ReadFile("file.hcl")))
+	assert.EqualStrings(t, genhcl.Header(genhcl.HashComment)+body,
+		string(s.DirEntry("stacks/hash/b").ReadFile("file.hcl")))
+
+	assertEqualStringList(t, s.DirEntry("stacks/hash/b").ListGenFiles(s.Config()), []string{"file.hcl"})
+
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{})
+	outdated, err := generate.DetectOutdated(s.Config(), s.Config().Tree(), 0, project.NewPath("/modules"))
+	assert.NoError(t, err)
+	assertEqualStringList(t, outdated, []string{})
+}
+
+func TestGenerateHCLNoHeader(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	stack := s.CreateStack("stack")
+
+	createConfig := func(noHeader bool) {
+		stack.CreateConfig(Doc(
+			GenerateHCL(
+				Labels("dir/file.json"),
+				Bool("no_header", noHeader),
+				Content(
+					Str("a", "a"),
+				),
+			),
+			GenerateHCL(
+				Labels("file.hcl"),
+				Content(
+					Str("b", "b"),
+				),
+			),
+		).String())
+		s.ReloadConfig()
+	}
+
+	createConfig(true)
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Created: []string{generate.StateFilename, "dir/file.json", "file.hcl"},
+			},
+		},
+	})
+
+	assert.EqualStrings(t, "a = \"a\"\n", string(stack.ReadFile("dir/file.json")))
+	assert.EqualStrings(t, `{
+  "files": [
+    {
+      "path": "dir/file.json",
+      "sha256": "84690ecf2bf32d819d7e5f6212e1c06a67520304faed433ad58198112c979b4c"
+    }
+  ]
+}
+`, string(stack.ReadFile(generate.StateFilename)))
+	assertEqualStringList(t, stack.ListGenFiles(s.Config()),
+		[]string{"dir/file.json", generate.StateFilename, "file.hcl"})
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{})
+
+	// the files tracked by the state can have the header back.
+	createConfig(false)
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Changed: []string{"dir/file.json"},
+				Deleted: []string{generate.StateFilename},
+			},
+		},
+	})
+	assert.EqualStrings(t, genhcl.DefaultHeader()+"a = \"a\"\n", string(stack.ReadFile("dir/file.json")))
+
+	createConfig(true)
+	s.Generate()
+
+	// the files tracked by the state are deleted when not generated anymore.
+	stack.CreateConfig(GenerateHCL(
+		Labels("file.hcl"),
+		Content(
+			Str("b", "b"),
+		),
+	).String())
+	s.ReloadConfig()
+
+	outdated, err := generate.DetectOutdated(s.Config(), s.Config().Tree(), 0, project.NewPath("/modules"))
+	assert.NoError(t, err)
+	assertEqualStringList(t, outdated, []string{"stack/" + generate.StateFilename, "stack/dir/file.json"})
+
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Deleted: []string{generate.StateFilename, "dir/file.json"},
+			},
+		},
+	})
+	for _, file := range []string{generate.StateFilename, "dir/file.json"} {
+		_, err := os.Stat(filepath.Join(stack.Path(), file))
+		assert.IsTrue(t, errors.Is(err, os.ErrNotExist), "want %s deleted", file)
+	}
+}
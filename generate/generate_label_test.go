@@ -0,0 +1,219 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	genreport "github.com/terramate-io/terramate/generate/report"
+	"github.com/terramate-io/terramate/lets"
+	"github.com/terramate-io/terramate/project"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+)
+
+func TestGenerateLabelInterpolatesLets(t *testing.T) {
+	t.Parallel()
+
+	testCodeGeneration(t, []testcase{
+		{
+			name: "generate_file label interpolating lets",
+			layout: []string{
+				"s:stacks/stack-1",
+				"s:stacks/stack-2",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stacks",
+					add: GenerateFile(
+						Labels("$${let.name}.tfvars"),
+						Lets(
+							Expr("name", "terramate.stack.name"),
+						),
+						Expr("content", "let.name"),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/stacks/stack-1",
+					files: map[string]fmt.Stringer{
+						"stack-1.tfvars": stringer("stack-1"),
+					},
+				},
+				{
+					dir: "/stacks/stack-2",
+					files: map[string]fmt.Stringer{
+						"stack-2.tfvars": stringer("stack-2"),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []genreport.Result{
+					{
+						Dir:     project.NewPath("/stacks/stack-1"),
+						Created: []string{"stack-1.tfvars"},
+					},
+					{
+						Dir:     project.NewPath("/stacks/stack-2"),
+						Created: []string{"stack-2.tfvars"},
+					},
+				},
+			},
+		},
+		{
+			name: "generate_hcl label interpolating lets",
+			layout: []string{
+				"s:stack",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stack",
+					add: GenerateHCL(
+						Labels("$${let.region}/main.tf"),
+						Lets(
+							Str("region", "eu"),
+						),
+						Content(
+							Expr("region", "let.region"),
+						),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/stack",
+					files: map[string]fmt.Stringer{
+						"eu/main.tf": Doc(
+							Str("region", "eu"),
+						),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []genreport.Result{
+					{
+						Dir:     project.NewPath("/stack"),
+						Created: []string{"eu/main.tf"},
+					},
+				},
+			},
+		},
+		{
+			name: "interpolated labels colliding",
+			layout: []string{
+				"s:stack",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stack",
+					add: Doc(
+						GenerateFile(
+							Labels("$${let.region}.tfvars"),
+							Lets(
+								Str("region", "eu"),
+							),
+							Str("content", "a"),
+						),
+						GenerateFile(
+							Labels("eu.tfvars"),
+							Str("content", "b"),
+						),
+					),
+				},
+			},
+			wantReport: genreport.Report{
+				Failures: []genreport.FailureResult{
+					{
+						Result: genreport.Result{
+							Dir: project.NewPath("/stack"),
+						},
+						Error: errors.E(generate.ErrConflictingConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "label interpolating other namespaces fails",
+			layout: []string{
+				"s:stack",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stack",
+					add: GenerateFile(
+						Labels("$${terramate.stack.name}.tfvars"),
+						Str("content", "a"),
+					),
+				},
+			},
+			wantReport: genreport.Report{
+				Failures: []genreport.FailureResult{
+					{
+						Result: genreport.Result{
+							Dir: project.NewPath("/stack"),
+						},
+						Error: errors.E(lets.ErrLabel),
+					},
+				},
+			},
+		},
+		{
+			name: "label interpolating empty string fails",
+			layout: []string{
+				"s:stack",
+			},
+			configs: []hclconfig{
+				{
+					path: "/stack",
+					add: GenerateHCL(
+						Labels("$${let.name}"),
+						Lets(
+							Str("name", ""),
+						),
+						Content(),
+					),
+				},
+			},
+			wantReport: genreport.Report{
+				Failures: []genreport.FailureResult{
+					{
+						Result: genreport.Result{
+							Dir: project.NewPath("/stack"),
+						},
+						Error: errors.E(lets.ErrLabel),
+					},
+				},
+			},
+		},
+		{
+			name: "context=root label template without for_each_stack fails",
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: GenerateFile(
+						Labels("/$${let.name}.txt"),
+						Expr("context", "root"),
+						Lets(
+							Str("name", "file"),
+						),
+						Str("content", "a"),
+					),
+				},
+			},
+			wantReport: genreport.Report{
+				Failures: []genreport.FailureResult{
+					{
+						Result: genreport.Result{
+							Dir: project.NewPath("/"),
+						},
+						Error: errors.E(generate.ErrInvalidGenBlockLabel),
+					},
+				},
+			},
+		},
+	})
+}
@@ -101,6 +101,49 @@ func TestGenerateRootContext(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "generate.context=root with for_each_stack generates one file per stack",
+			layout: []string{
+				"s:stacks/stack-1",
+				"s:stacks/stack-2",
+			},
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Globals(
+						Str("env", "prod"),
+					),
+				},
+				{
+					path: "/source",
+					add: Doc(
+						GenerateFile(
+							Labels(`/ci/$${tm_replace(terramate.stack.path.relative, "/", "-")}.yml`),
+							Expr("context", "root"),
+							Bool("for_each_stack", true),
+							Expr("content", `"${terramate.stack.path.absolute}: ${global.env}"`),
+						),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/ci",
+					files: map[string]fmt.Stringer{
+						"stacks-stack-1.yml": stringer("/stacks/stack-1: prod"),
+						"stacks-stack-2.yml": stringer("/stacks/stack-2: prod"),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []report.Result{
+					{
+						Dir:     project.NewPath("/ci"),
+						Created: []string{"stacks-stack-1.yml", "stacks-stack-2.yml"},
+					},
+				},
+			},
+		},
 		{
 			name: "generate.context=root fails when generating outside rootdir",
 			configs: []hclconfig{
@@ -356,6 +356,52 @@ func TestGenerateFile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "generate_file with visibility=directory on stack generates only in the stack and its direct child stacks",
+			layout: []string{
+				"s:/s1",
+				"s:/s1/s2",
+				"s:/s1/s2/s3",
+			},
+			configs: []hclconfig{
+				{
+					path: "/s1",
+					add: Doc(
+						GenerateFile(
+							Labels("dir.txt"),
+							Str("visibility", "directory"),
+							Expr("content", `"okay"`),
+						),
+					),
+				},
+			},
+			want: []generatedFile{
+				{
+					dir: "/s1",
+					files: map[string]fmt.Stringer{
+						"dir.txt": stringer(`okay`),
+					},
+				},
+				{
+					dir: "/s1/s2",
+					files: map[string]fmt.Stringer{
+						"dir.txt": stringer(`okay`),
+					},
+				},
+			},
+			wantReport: genreport.Report{
+				Successes: []genreport.Result{
+					{
+						Dir:     project.NewPath("/s1"),
+						Created: []string{"dir.txt"},
+					},
+					{
+						Dir:     project.NewPath("/s1/s2"),
+						Created: []string{"dir.txt"},
+					},
+				},
+			},
+		},
 		{
 			name: "generate_file at root with inherit=global.inherit generating only in parent",
 			layout: []string{
@@ -1243,3 +1289,57 @@ func TestGenerateFileStackFilters(t *testing.T) {
 		},
 	})
 }
+
+func TestGenerateFileOutputEncoding(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	stack := s.CreateStack("stack")
+	s.RootEntry().CreateFile("gen.tm", `
+terramate {
+  config {
+    generate {
+      line_endings = "crlf"
+    }
+  }
+}
+
+generate_file "run.bat" {
+  content = "echo one\necho two\n"
+  bom     = true
+}
+
+generate_file "run.sh" {
+  content          = "echo one\necho two"
+  line_endings     = "lf"
+  trailing_newline = true
+}
+
+generate_hcl "main.tf" {
+  trailing_newline = false
+  content {
+    locals {
+      a = 1
+    }
+  }
+}
+`)
+
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Created: []string{"main.tf", "run.bat", "run.sh"},
+			},
+		},
+	})
+
+	assert.EqualStrings(t, "\ufeffecho one\r\necho two\r\n", stack.ReadFile("run.bat"))
+	assert.EqualStrings(t, "echo one\necho two\n", stack.ReadFile("run.sh"))
+	assert.EqualStrings(t,
+		"// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT\r\n\r\nlocals {\r\n  a = 1\r\n}",
+		stack.ReadFile("main.tf"))
+
+	// the encoded files must not be detected as outdated.
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{})
+}
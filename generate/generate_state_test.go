@@ -0,0 +1,164 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	genreport "github.com/terramate-io/terramate/generate/report"
+	"github.com/terramate-io/terramate/project"
+	stackpkg "github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/test"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateStateTracksHeaderlessFiles(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.RootEntry().CreateFile("root.tm", Terramate(
+		Config(
+			Block("generate",
+				Bool("track_headerless_files", true),
+			),
+		),
+	).String())
+	stack := s.CreateStack("stack")
+
+	createConfig := func(files ...string) {
+		doc := Doc()
+		for _, file := range files {
+			doc.AddBlock(GenerateFile(
+				Labels(file),
+				Str("content", file),
+			))
+		}
+		stack.CreateConfig(doc.String())
+		s.ReloadConfig()
+	}
+
+	createConfig("a.txt", "b.txt")
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Created: []string{generate.StateFilename, "a.txt", "b.txt"},
+			},
+		},
+	})
+	assertEqualStringList(t, stack.ListGenFiles(s.Config()),
+		[]string{"a.txt", "b.txt", generate.StateFilename})
+
+	createConfig("a.txt")
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Changed: []string{generate.StateFilename},
+				Deleted: []string{"b.txt"},
+			},
+		},
+	})
+	assertFileDoesNotExist(t, filepath.Join(stack.Path(), "b.txt"))
+
+	// the files modified after generated are not deleted.
+	stack.CreateFile("a.txt", "modified")
+	createConfig()
+	report := generate.Do(s.Config(), project.NewPath("/"), 0, project.NewPath("/modules"), nil)
+	assert.EqualInts(t, 1, len(report.Failures), "unexpected report: %s", report.Full())
+	assert.IsTrue(t, errors.IsKind(report.Failures[0].Error, generate.ErrGeneratedFileModified),
+		"unexpected error: %v", report.Failures[0].Error)
+	assert.EqualStrings(t, "modified", string(stack.ReadFile("a.txt")))
+	assertFileDoesNotExist(t, filepath.Join(stack.Path(), generate.StateFilename))
+}
+
+func TestGenerateStateDetectsManualFiles(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.RootEntry().CreateFile("root.tm", Terramate(
+		Config(
+			Block("generate",
+				Bool("track_headerless_files", true),
+			),
+		),
+	).String())
+	stack := s.CreateStack("stack")
+	stack.CreateConfig(Doc(
+		GenerateFile(
+			Labels("manual.txt"),
+			Str("content", "generated"),
+		),
+		GenerateFile(
+			Labels("same.txt"),
+			Str("content", "generated"),
+		),
+	).String())
+	stack.CreateFile("manual.txt", "manual")
+	stack.CreateFile("same.txt", "generated")
+
+	report := generate.Do(s.Config(), project.NewPath("/"), 0, project.NewPath("/modules"), nil)
+	assert.EqualInts(t, 1, len(report.Failures), "unexpected report: %s", report.Full())
+	assert.IsTrue(t, errors.IsKind(report.Failures[0].Error, generate.ErrManualCodeExists),
+		"unexpected error: %v", report.Failures[0].Error)
+	assert.EqualStrings(t, "manual", string(stack.ReadFile("manual.txt")))
+
+	// the existing files with the generated content are adopted.
+	stack.RemoveFile("manual.txt")
+	test.AssertEqualReports(t, s.Generate(), genreport.Report{
+		Successes: []genreport.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Created: []string{generate.StateFilename, "manual.txt"},
+			},
+		},
+	})
+}
+
+func TestGenerateStateCleanupOrphanedFiles(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	stack := s.CreateStack("stack")
+	stack.CreateConfig(Doc(
+		GenerateHCL(
+			Labels("a.json"),
+			Bool("no_header", true),
+			Content(
+				Str("a", "a"),
+			),
+		),
+		GenerateHCL(
+			Labels("b.json"),
+			Bool("no_header", true),
+			Content(
+				Str("b", "b"),
+			),
+		),
+	).String())
+	s.Generate()
+
+	stack.CreateFile("b.json", "modified")
+	stack.RemoveFile(stackpkg.DefaultFilename)
+	stack.DeleteConfig()
+
+	report := generate.Do(s.ReloadConfig(), project.NewPath("/"), 0, project.NewPath("/modules"), nil)
+	assert.EqualInts(t, 1, len(report.Failures), "unexpected report: %s", report.Full())
+	assert.IsTrue(t, errors.IsKind(report.Failures[0].Error, generate.ErrGeneratedFileModified),
+		"unexpected error: %v", report.Failures[0].Error)
+	assertEqualStringList(t, report.Failures[0].Deleted, []string{generate.StateFilename, "a.json"})
+	assert.EqualStrings(t, "modified", string(stack.ReadFile("b.json")))
+}
+
+func assertFileDoesNotExist(t *testing.T, path string) {
+	t.Helper()
+	_, err := os.Stat(path)
+	assert.IsTrue(t, errors.Is(err, os.ErrNotExist), "want %s to not exist: %v", path, err)
+}
@@ -1300,3 +1300,67 @@ func assertEqualStringList(t *testing.T, got []string, want []string) {
 func init() {
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
+
+func TestGenerateParallelIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	const nstacks = 40
+
+	build := func(t *testing.T) sandbox.S {
+		s := sandbox.NoGit(t, true)
+		layout := []string{
+			`f:globals.tm:globals {
+  env = "prod"
+}`,
+			`f:gen.tm:generate_hcl "gen.hcl" {
+  content {
+    name = "${global.env}-${terramate.stack.name}"
+    path = terramate.stack.path.absolute
+  }
+}`,
+		}
+		for i := 0; i < nstacks; i++ {
+			layout = append(layout, fmt.Sprintf("s:group-%d/stack-%d", i%4, i))
+			if i%7 == 0 {
+				layout = append(layout, fmt.Sprintf(`f:group-%d/stack-%d/fail.tm:generate_hcl "fail.hcl" {
+  content {
+    a = global.undefined
+  }
+}`, i%4, i))
+			}
+		}
+		s.BuildTree(layout)
+		return s
+	}
+
+	serial := build(t)
+	parallel := build(t)
+
+	want := generate.Do(serial.Config(), project.NewPath("/"), 1, project.NewPath("/modules"), nil)
+	got := generate.Do(parallel.Config(), project.NewPath("/"), 16, project.NewPath("/modules"), nil)
+
+	assert.EqualInts(t, nstacks-nstacks/7-1, len(want.Successes))
+	assert.EqualStrings(t,
+		strings.ReplaceAll(want.Full(), serial.RootDir(), ""),
+		strings.ReplaceAll(got.Full(), parallel.RootDir(), ""))
+
+	for i := 0; i < nstacks; i++ {
+		stackdir := fmt.Sprintf("group-%d/stack-%d", i%4, i)
+		if i%7 == 0 {
+			continue
+		}
+		assert.EqualStrings(t,
+			serial.StackEntry(stackdir).ReadFile("gen.hcl"),
+			parallel.StackEntry(stackdir).ReadFile("gen.hcl"))
+	}
+
+	serialLoad, err := generate.Load(serial.Config(), 1, project.NewPath("/modules"))
+	assert.NoError(t, err)
+	parallelLoad, err := generate.Load(parallel.Config(), 16, project.NewPath("/modules"))
+	assert.NoError(t, err)
+	assert.EqualInts(t, len(serialLoad), len(parallelLoad))
+	for i := range serialLoad {
+		assert.EqualStrings(t, serialLoad[i].Dir.String(), parallelLoad[i].Dir.String())
+		assert.EqualInts(t, len(serialLoad[i].Files), len(parallelLoad[i].Files))
+	}
+}
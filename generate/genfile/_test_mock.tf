@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "genfile" {
-  content = <<-EOT
+  content = <<-EOF
 package genfile // import "github.com/terramate-io/terramate/generate/genfile"
 
 Package genfile implements generate_file code generation.
@@ -11,7 +11,7 @@ const StackContext = "stack" ...
 type File struct{ ... }
     func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (file File, skip bool, err error)
     func Load(root *config.Root, st *config.Stack, parentctx *eval.Context, ...) ([]File, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-genfile.ignore"
 }
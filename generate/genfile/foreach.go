@@ -0,0 +1,72 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package genfile
+
+import (
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// ErrForEachStackEval indicates the failure to evaluate the for_each_stack
+	// attribute.
+	ErrForEachStackEval errors.Kind = "evaluating for_each_stack attribute"
+
+	// ErrInvalidForEachStackType indicates the for_each_stack attribute has an
+	// invalid type.
+	ErrInvalidForEachStackType errors.Kind = "invalid for_each_stack type"
+
+	// ErrLabelEval indicates the failure to evaluate the label template of
+	// a block with for_each_stack = true.
+	ErrLabelEval errors.Kind = "evaluating label template"
+)
+
+// ForEachStack tells if the context=root block must be generated once for
+// each stack of the project.
+func ForEachStack(block hcl.GenFileBlock, evalctx *eval.Context) (bool, error) {
+	if block.ForEachStack == nil {
+		return false, nil
+	}
+	value, err := evalctx.Eval(block.ForEachStack.Expr)
+	if err != nil {
+		return false, errors.E(ErrForEachStackEval, err)
+	}
+	if value.Type() != cty.Bool {
+		return false, errors.E(
+			ErrInvalidForEachStackType,
+			block.ForEachStack.Range(),
+			`"for_each_stack" has type %s but must be boolean`,
+			value.Type().FriendlyName(),
+		)
+	}
+	return value.True(), nil
+}
+
+// EvalLabel evaluates the label of the block as a template using the given
+// evaluation context and returns a copy of the block with the evaluated label.
+// Eg.: /.github/workflows/${tm_replace(terramate.stack.path.relative, "/", "-")}.yml
+func EvalLabel(block hcl.GenFileBlock, evalctx *eval.Context) (hcl.GenFileBlock, error) {
+	start := block.Range.ToHCLRange().Start
+	expr, diags := hclsyntax.ParseTemplate([]byte(block.Label), block.Range.HostPath(), start)
+	if diags.HasErrors() {
+		return hcl.GenFileBlock{}, errors.E(ErrLabelEval, diags)
+	}
+	value, err := evalctx.Eval(expr)
+	if err != nil {
+		return hcl.GenFileBlock{}, errors.E(ErrLabelEval, err)
+	}
+	if value.Type() != cty.String {
+		return hcl.GenFileBlock{}, errors.E(
+			ErrLabelEval,
+			block.Range,
+			"label has type %s but must be string",
+			value.Type().FriendlyName(),
+		)
+	}
+	block.Label = value.AsString()
+	return block, nil
+}
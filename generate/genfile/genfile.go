@@ -7,10 +7,15 @@ package genfile
 import (
 	"fmt"
 	"path"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/event"
 	"github.com/terramate-io/terramate/hcl"
@@ -37,6 +42,13 @@ const (
 	// ErrContentEval indicates an error when evaluating the content attribute.
 	ErrContentEval errors.Kind = "evaluating content"
 
+	// ErrInvalidOutputs indicates the outputs attribute has an invalid type
+	// or an invalid filename.
+	ErrInvalidOutputs errors.Kind = "invalid outputs"
+
+	// ErrOutputsEval indicates an error when evaluating the outputs attribute.
+	ErrOutputsEval errors.Kind = "evaluating outputs"
+
 	// ErrConditionEval indicates an error when evaluating the condition attribute.
 	ErrConditionEval errors.Kind = "evaluating condition"
 
@@ -67,6 +79,8 @@ type File struct {
 	body      string
 	condition bool
 	asserts   []config.Assert
+	output    hcl.GenerateOutputConfig
+	evalTime  time.Duration
 }
 
 // Builtin returns false for generate_file blocks.
@@ -98,6 +112,16 @@ func (f File) Context() string {
 	return f.context
 }
 
+// EvalDuration returns the time spent evaluating the generate_file block.
+func (f File) EvalDuration() time.Duration {
+	return f.evalTime
+}
+
+// FormatDuration returns zero since generate_file content is not formatted.
+func (f File) FormatDuration() time.Duration {
+	return 0
+}
+
 // Asserts returns all (if any) of the evaluated assert configs of the
 // generate_file block. If [File.Condition] returns false then assert configs
 // will always be empty since they are not evaluated at all in that case.
@@ -105,6 +129,11 @@ func (f File) Asserts() []config.Assert {
 	return f.asserts
 }
 
+// Output returns the output encoding configured in the generate_file block.
+func (f File) Output() hcl.GenerateOutputConfig {
+	return f.output
+}
+
 // Header returns the header of this file.
 func (f File) Header() string {
 	// For now we don't support headers for arbitrary files
@@ -139,6 +168,7 @@ func Load(
 	if err != nil {
 		return nil, errors.E("loading generate_file", err)
 	}
+	genFileBlocks = appendTemplateGenFileBlocks(root, st, genFileBlocks)
 
 	var files []File
 
@@ -154,6 +184,11 @@ func Load(
 
 		name := genFileBlock.Label
 
+		if genFileBlock.Visibility == hcl.VisibilityDirectory && !st.IsDirectlyIn(genFileBlock.Dir) {
+			log.Logger.Trace().Msgf("Skipping %q, not directly inside %s", st.Dir, genFileBlock.Dir)
+			continue
+		}
+
 		matchedAnyStackFilter := len(genFileBlock.StackFilters) == 0
 		for _, cond := range genFileBlock.StackFilters {
 			matched := true
@@ -169,10 +204,19 @@ func Load(
 				}
 			}
 
+			if matched && !cond.Tags.IsEmpty() && !filter.MatchTags(cond.Tags, st.Tags) {
+				log.Logger.Trace().Msgf("Skipping %q, tags %v don't match the tags filter", st.Dir, st.Tags)
+				matched = false
+			}
+
 			matchedAnyStackFilter = matchedAnyStackFilter || matched
 		}
 
 		if !matchedAnyStackFilter {
+			if lets.IsLabelTemplate(name) {
+				// the filename is unknown without evaluating the lets.
+				continue
+			}
 			files = append(files, File{
 				label:     name,
 				origin:    genFileBlock.Range,
@@ -187,15 +231,15 @@ func Load(
 
 		evalctx := parentctx.Copy()
 
-		evalctx.SetFunction(stdlib.Name("vendor"), stdlib.VendorFunc(vendorTargetDir, vendorDir, vendorRequests))
+		evalctx.SetFunction(stdlib.Name("vendor"), root.VendorFunc(vendorTargetDir, vendorDir, vendorRequests))
 
 		dircfg, _ := root.Lookup(st.Dir)
-		file, skip, err := Eval(genFileBlock, dircfg, evalctx)
+		blockFiles, skip, err := EvalFiles(genFileBlock, dircfg, evalctx)
 		if err != nil {
 			return nil, err
 		}
 		if !skip {
-			files = append(files, file)
+			files = append(files, blockFiles...)
 		}
 	}
 
@@ -211,22 +255,52 @@ func Load(
 	return files, nil
 }
 
-// Eval the generate_file block.
-func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (file File, skip bool, err error) {
-	name := block.Label
+// Eval the generate_file block, which must have a content attribute.
+func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (File, bool, error) {
+	if block.Content == nil {
+		return File{}, false, errors.E(ErrInvalidOutputs, block.Range,
+			"outputs is only supported with context = %q", StackContext)
+	}
+	files, skip, err := EvalFiles(block, cfg, evalctx)
+	if err != nil || skip {
+		return File{}, skip, err
+	}
+	return files[0], false, nil
+}
+
+// EvalFiles evaluates the generate_file block into the files it generates:
+// a single file for the content attribute or one file for each entry of the
+// outputs attribute, all of them sharing the lets, condition and asserts
+// of the block.
+func EvalFiles(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (files []File, skip bool, err error) {
+	defer func(start time.Time) {
+		for i := range files {
+			files[i].evalTime = time.Since(start)
+		}
+	}(time.Now())
+
 	err = lets.Load(block.Lets, evalctx)
 	if err != nil {
-		return File{}, false, err
+		return nil, false, err
+	}
+
+	// the labels of context=root blocks are evaluated with for_each_stack.
+	label := block.Label
+	if block.Context == StackContext {
+		label, err = lets.EvalLabel(block.Label, block.Range, evalctx)
+		if err != nil {
+			return nil, false, err
+		}
 	}
 
 	condition := true
 	if block.Condition != nil {
 		value, err := evalctx.Eval(block.Condition.Expr)
 		if err != nil {
-			return File{}, false, errors.E(ErrConditionEval, err)
+			return nil, false, errors.E(ErrConditionEval, err)
 		}
 		if value.Type() != cty.Bool {
-			return File{}, false, errors.E(
+			return nil, false, errors.E(
 				ErrInvalidConditionType,
 				"condition has type %s but must be boolean",
 				value.Type().FriendlyName(),
@@ -236,23 +310,26 @@ func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (file
 	}
 
 	if !condition {
-		return File{
-			label:     name,
-			origin:    block.Range,
-			condition: condition,
-			context:   block.Context,
-		}, false, nil
+		for _, name := range fileNames(block, label, evalctx) {
+			files = append(files, File{
+				label:     name,
+				origin:    block.Range,
+				condition: condition,
+				context:   block.Context,
+			})
+		}
+		return files, false, nil
 	}
 
 	inherit := true
 	if block.Inherit != nil {
 		value, err := evalctx.Eval(block.Inherit.Expr)
 		if err != nil {
-			return File{}, false, errors.E(ErrInheritEval, err)
+			return nil, false, errors.E(ErrInheritEval, err)
 		}
 
 		if value.Type() != cty.Bool {
-			return File{}, false, errors.E(
+			return nil, false, errors.E(
 				ErrInvalidInheritType,
 				`"inherit" has type %s but must be boolean`,
 				value.Type().FriendlyName(),
@@ -262,9 +339,9 @@ func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (file
 		inherit = value.True()
 	}
 
-	if !inherit && block.Dir != cfg.Dir() {
+	if !inherit && block.Dir != cfg.Dir() && !isStackTemplate(cfg, block.Dir) {
 		// ignore non-inheritable block
-		return File{}, true, nil
+		return nil, true, nil
 	}
 
 	asserts := make([]config.Assert, len(block.Asserts))
@@ -284,46 +361,189 @@ func Eval(block hcl.GenFileBlock, cfg *config.Tree, evalctx *eval.Context) (file
 	}
 
 	if err := assertsErrs.AsError(); err != nil {
-		return File{}, false, err
+		return nil, false, err
 	}
 
 	if assertFailed {
-		return File{
-			label:     name,
+		return []File{{
+			label:     label,
 			origin:    block.Range,
 			condition: condition,
 			context:   block.Context,
 			asserts:   asserts,
-		}, false, nil
+		}}, false, nil
 	}
 
-	value, err := evalctx.Eval(block.Content.Expr)
+	bodies, err := evalBodies(block, label, evalctx)
 	if err != nil {
-		return File{}, false, errors.E(ErrContentEval, err)
+		return nil, false, err
+	}
+
+	for i, body := range bodies {
+		file := File{
+			label:     body.name,
+			origin:    block.Range,
+			body:      body.content,
+			condition: condition,
+			context:   block.Context,
+			output:    block.Output,
+		}
+		// the asserts are reported only once for the block.
+		if i == 0 {
+			file.asserts = asserts
+		}
+		files = append(files, file)
+	}
+	return files, false, nil
+}
+
+type fileBody struct {
+	name    string
+	content string
+}
+
+// evalBodies evaluates the content or the outputs of the block, whose
+// label is already interpolated.
+func evalBodies(block hcl.GenFileBlock, label string, evalctx *eval.Context) ([]fileBody, error) {
+	if block.Outputs == nil {
+		value, err := evalctx.Eval(block.Content.Expr)
+		if err != nil {
+			return nil, errors.E(ErrContentEval, err)
+		}
+
+		if block.Format == hcl.GenerateYAMLFormat {
+			content, err := encodeYAML(value)
+			if err != nil {
+				return nil, errors.E(block.Content.Expr.Range(), err)
+			}
+			return []fileBody{{name: label, content: content}}, nil
+		}
+
+		if value.Type() != cty.String {
+			return nil, errors.E(
+				ErrInvalidContentType,
+				"content has type %s but must be string",
+				value.Type().FriendlyName(),
+			)
+		}
+		return []fileBody{{name: label, content: value.AsString()}}, nil
 	}
 
-	if value.Type() != cty.String {
-		return File{}, false, errors.E(
-			ErrInvalidContentType,
-			"content has type %s but must be string",
+	value, err := evalctx.Eval(block.Outputs.Expr)
+	if err != nil {
+		return nil, errors.E(ErrOutputsEval, err)
+	}
+	if !value.Type().IsObjectType() && !value.Type().IsMapType() {
+		return nil, errors.E(
+			ErrInvalidOutputs,
+			block.Outputs.Expr.Range(),
+			"outputs has type %s but must be a map of filename to content",
 			value.Type().FriendlyName(),
 		)
 	}
+	if value.IsNull() || value.LengthInt() == 0 {
+		return nil, errors.E(ErrInvalidOutputs, block.Outputs.Expr.Range(),
+			"outputs must have at least one entry")
+	}
 
-	return File{
-		label:     name,
-		origin:    block.Range,
-		body:      value.AsString(),
-		condition: condition,
-		context:   block.Context,
-		asserts:   asserts,
-	}, false, nil
+	values := value.AsValueMap()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bodies := make([]fileBody, 0, len(names))
+	errs := errors.L()
+	for _, name := range names {
+		content := values[name]
+		if !validOutputName(name) {
+			errs.Append(errors.E(ErrInvalidOutputs, block.Outputs.Expr.Range(),
+				"outputs filename %q must be a clean relative path", name))
+			continue
+		}
+		if content.Type() != cty.String || content.IsNull() {
+			errs.Append(errors.E(ErrInvalidOutputs, block.Outputs.Expr.Range(),
+				"outputs[%q] has type %s but must be string", name, content.Type().FriendlyName()))
+			continue
+		}
+		bodies = append(bodies, fileBody{
+			name:    path.Join(label, name),
+			content: content.AsString(),
+		})
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	return bodies, nil
+}
+
+// fileNames returns the names of the files generated by the block without
+// evaluating their content, so the files of a block whose condition is false
+// can be removed. The filenames of the outputs attribute are only known if
+// they are given by the keys of an object constructor expression.
+func fileNames(block hcl.GenFileBlock, label string, evalctx *eval.Context) []string {
+	if block.Outputs == nil {
+		return []string{label}
+	}
+	objexpr, ok := block.Outputs.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, item := range objexpr.Items {
+		key, err := evalctx.Eval(item.KeyExpr)
+		if err != nil || key.Type() != cty.String || key.IsNull() || !validOutputName(key.AsString()) {
+			continue
+		}
+		names = append(names, path.Join(label, key.AsString()))
+	}
+	return names
+}
+
+func validOutputName(name string) bool {
+	return name != "" && !path.IsAbs(name) && path.Clean(name) == name &&
+		name != ".." && !strings.HasPrefix(name, "../")
 }
 
 // loadGenFileBlocks will load all generate_file blocks.
 // The returned map maps the name of the block (its label)
 // to the original block and the path (relative to project root) of the config
 // from where it was parsed.
+// appendTemplateGenFileBlocks appends the stack context generate_file blocks
+// of the template extended by the stack to blocks. The template blocks are
+// overridden by the blocks with the same label declared in the stack or its
+// parent directories.
+func appendTemplateGenFileBlocks(root *config.Root, st *config.Stack, blocks []hcl.GenFileBlock) []hcl.GenFileBlock {
+	if st.Extends == (project.Path{}) {
+		return blocks
+	}
+	template, ok := root.Lookup(st.Extends)
+	if !ok || template.IsEmptyConfig() {
+		return blocks
+	}
+	res := blocks
+	for _, block := range template.Node.Generate.Files {
+		if block.Context != StackContext {
+			continue
+		}
+		overridden := slices.ContainsFunc(blocks, func(other hcl.GenFileBlock) bool {
+			return other.Label == block.Label
+		})
+		if !overridden {
+			res = append(res, block)
+		}
+	}
+	return res
+}
+
+// isStackTemplate tells if dir is the template extended by the stack at cfg,
+// whose blocks are generated as if declared in the stack directory.
+func isStackTemplate(cfg *config.Tree, dir project.Path) bool {
+	template, ok, _ := cfg.StackTemplate()
+	return ok && template.Dir() == dir
+}
+
 func loadGenFileBlocks(tree *config.Root, cfgdir project.Path) ([]hcl.GenFileBlock, error) {
 	res := []hcl.GenFileBlock{}
 	cfg, ok := tree.Lookup(cfgdir)
@@ -0,0 +1,192 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package genfile_test
+
+import (
+	"testing"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genfile"
+	"github.com/terramate-io/terramate/hcl"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+)
+
+func TestGenFileFileScopedLets(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []testcase{
+		{
+			name:  "file lets are visible to all blocks of the file",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/genfile.tm",
+					add: Doc(
+						Lets(
+							Str("env", "prod"),
+							Expr("prefix", `"app-${let.env}"`),
+						),
+						GenerateFile(
+							Labels("a.txt"),
+							Str("content", "${let.prefix}-a"),
+						),
+						GenerateFile(
+							Labels("b.txt"),
+							Str("content", "${let.prefix}-b"),
+						),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "a.txt",
+					file: genFile{
+						condition: true,
+						body:      "app-prod-a",
+					},
+				},
+				{
+					name: "b.txt",
+					file: genFile{
+						condition: true,
+						body:      "app-prod-b",
+					},
+				},
+			},
+		},
+		{
+			name:  "block lets take precedence and can reference file lets",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/genfile.tm",
+					add: Doc(
+						GenerateFile(
+							Labels("a.txt"),
+							Lets(
+								Str("env", "dev"),
+								Expr("name", `"${let.region}-${let.env}"`),
+							),
+							Expr("content", "let.name"),
+						),
+						Lets(
+							Str("env", "prod"),
+							Str("region", "eu"),
+						),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "a.txt",
+					file: genFile{
+						condition: true,
+						body:      "eu-dev",
+					},
+				},
+			},
+		},
+		{
+			name:  "file lets with map block and globals",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/globals.tm",
+					add: Globals(
+						Expr("names", `["a", "b"]`),
+					),
+				},
+				{
+					path: "/stack/genfile.tm",
+					add: Doc(
+						Lets(
+							Map(
+								Labels("upper"),
+								Expr("for_each", "global.names"),
+								Expr("key", "element.new"),
+								Expr("value", "tm_upper(element.new)"),
+							),
+						),
+						GenerateFile(
+							Labels("a.txt"),
+							Str("content", "${let.upper.a}${let.upper.b}"),
+						),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "a.txt",
+					file: genFile{
+						condition: true,
+						body:      "AB",
+					},
+				},
+			},
+		},
+		{
+			name:  "file lets are not visible to blocks of other files",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/lets.tm",
+					add: Lets(
+						Str("name", "value"),
+					),
+				},
+				{
+					path: "/stack/genfile.tm",
+					add: GenerateFile(
+						Labels("a.txt"),
+						Expr("content", "let.name"),
+					),
+				},
+			},
+			wantErr: errors.E(genfile.ErrContentEval),
+		},
+		{
+			name:  "file lets redeclared in the same file",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/genfile.tm",
+					add: Doc(
+						Lets(
+							Str("name", "a"),
+						),
+						Lets(
+							Str("name", "b"),
+						),
+						GenerateFile(
+							Labels("a.txt"),
+							Expr("content", "let.name"),
+						),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+		{
+			name:  "file lets with labels",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/genfile.tm",
+					add: Doc(
+						Lets(
+							Labels("invalid"),
+							Str("name", "a"),
+						),
+						GenerateFile(
+							Labels("a.txt"),
+							Expr("content", "let.name"),
+						),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+	} {
+		testGenfile(t, tc)
+	}
+}
@@ -923,6 +923,166 @@ stack_id=stack-id
 			},
 			wantErr: errors.E(genfile.ErrContentEval),
 		},
+		{
+			name:  "outputs generates a file for each entry sharing lets",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Lets(
+							Str("env", "prod"),
+						),
+						Expr("outputs", `{
+							"a.txt"       = "a-${let.env}"
+							"nested/b.txt" = "b-${let.env}"
+						}`),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "config/a.txt",
+					file: genFile{
+						body:      "a-prod",
+						condition: true,
+					},
+				},
+				{
+					name: "config/nested/b.txt",
+					file: genFile{
+						body:      "b-prod",
+						condition: true,
+					},
+				},
+			},
+		},
+		{
+			name:  "outputs built with for expression",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("."),
+						Expr("outputs", `{ for name in ["x", "y"] : "${name}.txt" => name }`),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "x.txt",
+					file: genFile{
+						body:      "x",
+						condition: true,
+					},
+				},
+				{
+					name: "y.txt",
+					file: genFile{
+						body:      "y",
+						condition: true,
+					},
+				},
+			},
+		},
+		{
+			name:  "outputs with false condition",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Bool("condition", false),
+						Expr("outputs", `{
+							"a.txt" = global.undefined
+						}`),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "config/a.txt",
+					file: genFile{
+						condition: false,
+					},
+				},
+			},
+		},
+		{
+			name:  "outputs and content are mutually exclusive",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Str("content", "data"),
+						Expr("outputs", `{ "a.txt" = "a" }`),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+		{
+			name:  "outputs with context=root fails",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("/config"),
+						Str("context", "root"),
+						Expr("outputs", `{ "a.txt" = "a" }`),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+		{
+			name:  "outputs with non-string content fails",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Expr("outputs", `{ "a.txt" = 1 }`),
+					),
+				},
+			},
+			wantErr: errors.E(genfile.ErrInvalidOutputs),
+		},
+		{
+			name:  "outputs with filename escaping the label dir fails",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Expr("outputs", `{ "../a.txt" = "a" }`),
+					),
+				},
+			},
+			wantErr: errors.E(genfile.ErrInvalidOutputs),
+		},
+		{
+			name:  "outputs with wrong type fails",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/test.tm",
+					add: GenerateFile(
+						Labels("config"),
+						Str("outputs", "a.txt"),
+					),
+				},
+			},
+			wantErr: errors.E(genfile.ErrInvalidOutputs),
+		},
 	}
 
 	for _, tcase := range tcases {
@@ -0,0 +1,194 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package genfile_test
+
+import (
+	"testing"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genfile"
+	"github.com/terramate-io/terramate/hcl"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+)
+
+func TestLoadGenerateYAML(t *testing.T) {
+	t.Parallel()
+
+	k8sExperiment := hclconfig{
+		path: "/terramate.tm",
+		add:  Terramate(Config(Expr("experiments", `["k8s"]`))),
+	}
+
+	tcases := []testcase{
+		{
+			name:  "generate_yaml requires the k8s experiment",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack/k8s.tm",
+					add: GenerateYAML(
+						Labels("app.yaml"),
+						Expr("content", `{a = 1}`),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+		{
+			name:  "generate_yaml does not support outputs",
+			stack: "/stack",
+			configs: []hclconfig{
+				k8sExperiment,
+				{
+					path: "/stack/k8s.tm",
+					add: GenerateYAML(
+						Labels("manifests"),
+						Expr("outputs", `{"a.yaml" = {}}`),
+					),
+				},
+			},
+			wantErr: errors.E(hcl.ErrTerramateSchema),
+		},
+		{
+			name:  "manifest keys are generated first",
+			stack: "/stack",
+			configs: []hclconfig{
+				k8sExperiment,
+				{
+					path: "/stack/k8s.tm",
+					add: GenerateYAML(
+						Labels("app.yaml"),
+						Expr("content", `{
+							spec = {
+							  replicas = 2
+							  paused   = false
+							}
+							metadata = {
+							  name   = "app"
+							  labels = { app = "app", env = terramate.stack.name }
+							}
+							kind       = "Deployment"
+							apiVersion = "apps/v1"
+						}`),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "app.yaml",
+					file: genFile{
+						condition: true,
+						body: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: app
+    env: stack
+  name: app
+spec:
+  paused: false
+  replicas: 2
+`,
+					},
+				},
+			},
+		},
+		{
+			name:  "list content generates multiple documents patched by tm_k8s_patch",
+			stack: "/stack",
+			configs: []hclconfig{
+				k8sExperiment,
+				{
+					path: "/stack/k8s.tm",
+					add: GenerateYAML(
+						Labels("app.yaml"),
+						Lets(
+							Expr("base", `[
+							  {
+							    apiVersion = "v1"
+							    kind       = "ConfigMap"
+							    metadata   = { name = "config" }
+							    data       = { "app.conf" = "a = 1\nb = 2\n", debug = "true" }
+							  },
+							  null,
+							  {
+							    apiVersion = "apps/v1"
+							    kind       = "Deployment"
+							    metadata   = { name = "app" }
+							    spec = {
+							      containers = [
+							        { name = "app", image = "app:1.0" },
+							        { name = "sidecar", image = "proxy:1.0" },
+							      ]
+							    }
+							  },
+							]`),
+						),
+						Expr("content", `tm_k8s_patch(let.base,
+						  {
+						    kind     = "ConfigMap"
+						    metadata = { name = "config" }
+						    data     = { debug = null }
+						  },
+						  {
+						    kind     = "Deployment"
+						    metadata = { name = "app" }
+						    spec = {
+						      containers = [{ name = "app", image = "app:2.0" }]
+						    }
+						  },
+						)`),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "app.yaml",
+					file: genFile{
+						condition: true,
+						body: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  app.conf: |
+    a = 1
+    b = 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  containers:
+    - image: app:2.0
+      name: app
+    - image: proxy:1.0
+      name: sidecar
+`,
+					},
+				},
+			},
+		},
+		{
+			name:  "content with unsupported type fails",
+			stack: "/stack",
+			configs: []hclconfig{
+				k8sExperiment,
+				{
+					path: "/stack/k8s.tm",
+					add: GenerateYAML(
+						Labels("app.yaml"),
+						Expr("content", `null`),
+					),
+				},
+			},
+			wantErr: errors.E(genfile.ErrInvalidContentType),
+		},
+	}
+
+	for _, tcase := range tcases {
+		testGenfile(t, tcase)
+	}
+}
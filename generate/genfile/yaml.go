@@ -0,0 +1,140 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package genfile
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestKeys are the keys of the Kubernetes manifests which are encoded
+// first in the YAML documents, in this order.
+var manifestKeys = []string{"apiVersion", "kind", "metadata"}
+
+// encodeYAML encodes the value as YAML. A list is encoded as multiple
+// documents, one for each non-null element.
+func encodeYAML(val cty.Value) (string, error) {
+	if !val.IsWhollyKnown() {
+		return "", errors.E(ErrInvalidContentType, "content must be wholly known")
+	}
+	if val.IsNull() {
+		return "", errors.E(ErrInvalidContentType, "content must not be null")
+	}
+
+	docs := []cty.Value{val}
+	if ty := val.Type(); ty.IsListType() || ty.IsTupleType() || ty.IsSetType() {
+		docs = nil
+		for _, doc := range val.AsValueSlice() {
+			if !doc.IsNull() {
+				docs = append(docs, doc)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		node, err := yamlNode(doc, true)
+		if err != nil {
+			return "", err
+		}
+		if err := enc.Encode(node); err != nil {
+			return "", errors.E(ErrInvalidContentType, err, "encoding content as YAML")
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.E(ErrInvalidContentType, err, "encoding content as YAML")
+	}
+	return buf.String(), nil
+}
+
+func yamlNode(val cty.Value, root bool) (*yaml.Node, error) {
+	if val.IsNull() {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: val.AsString()}
+		if strings.Contains(node.Value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node, nil
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		text := bf.Text('f', -1)
+		tag := "!!float"
+		if bf.IsInt() {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: text}, nil
+	case ty == cty.Bool:
+		text := "false"
+		if val.True() {
+			text = "true"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: text}, nil
+	case ty.IsListType() || ty.IsTupleType() || ty.IsSetType():
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, elem := range val.AsValueSlice() {
+			child, err := yamlNode(elem, false)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+	case ty.IsObjectType() || ty.IsMapType():
+		attrs := val.AsValueMap()
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if root {
+			keys = manifestOrder(keys)
+		}
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range keys {
+			child, err := yamlNode(attrs[key], false)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+		return node, nil
+	default:
+		return nil, errors.E(ErrInvalidContentType,
+			"type %s cannot be encoded as YAML", ty.FriendlyName())
+	}
+}
+
+// manifestOrder moves the manifestKeys to the beginning of the sorted keys.
+func manifestOrder(keys []string) []string {
+	ordered := make([]string, 0, len(keys))
+	for _, mkey := range manifestKeys {
+		for _, key := range keys {
+			if key == mkey {
+				ordered = append(ordered, key)
+			}
+		}
+	}
+	for _, key := range keys {
+		isManifestKey := false
+		for _, mkey := range manifestKeys {
+			isManifestKey = isManifestKey || key == mkey
+		}
+		if !isManifestKey {
+			ordered = append(ordered, key)
+		}
+	}
+	return ordered
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "genhcl" {
-  content = <<-EOT
+  content = <<-EOF
 package genhcl // import "github.com/terramate-io/terramate/generate/genhcl"
 
 Package genhcl implements generate_hcl code generation.
@@ -15,7 +15,7 @@ type CommentStyle int
     func CommentStyleFromConfig(tree *config.Tree) CommentStyle
 type HCL struct{ ... }
     func Load(root *config.Root, st *config.Stack, evalctx *eval.Context, ...) ([]HCL, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-genhcl.ignore"
 }
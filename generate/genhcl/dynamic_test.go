@@ -1861,6 +1861,113 @@ func TestGenerateHCLDynamic(t *testing.T) {
 			},
 			wantErr: errors.E(genhcl.ErrDynamicAttrsConflict),
 		},
+		{
+			name:  "elide_nulls omits null attributes of attributes and content",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path:     "/stack",
+					filename: "globals.tm",
+					add: Globals(
+						Expr("obj", `{
+						  a = "a"
+						  b = null
+						}`),
+						Expr("nothing", "null"),
+					),
+				},
+				{
+					path:     "/stack",
+					filename: "gen.tm",
+					add: GenerateHCL(
+						Labels("test.tf"),
+						Content(
+							TmDynamic(
+								Labels("from_object"),
+								Bool("elide_nulls", true),
+								Expr("attributes", "global.obj"),
+							),
+							TmDynamic(
+								Labels("from_cons"),
+								Bool("elide_nulls", true),
+								Expr("attributes", `{
+								  a = global.nothing
+								  b = "b"
+								  c = null
+								  d = var.unknown
+								}`),
+							),
+							TmDynamic(
+								Labels("from_content"),
+								Bool("elide_nulls", true),
+								Content(
+									Expr("a", "global.nothing"),
+									Str("b", "b"),
+									Block("nested",
+										Expr("c", "null"),
+										Str("d", "d"),
+									),
+								),
+							),
+							TmDynamic(
+								Labels("not_elided"),
+								Content(
+									Expr("a", "global.nothing"),
+								),
+							),
+						),
+					),
+				},
+			},
+			want: []result{
+				{
+					name: "test.tf",
+					hcl: genHCL{
+						condition: true,
+						body: Doc(
+							Block("from_object",
+								Str("a", "a"),
+							),
+							Block("from_cons",
+								Str("b", "b"),
+								Expr("d", "var.unknown"),
+							),
+							Block("from_content",
+								Str("b", "b"),
+								Block("nested",
+									Str("d", "d"),
+								),
+							),
+							Block("not_elided",
+								Expr("a", "null"),
+							),
+						),
+					},
+				},
+			},
+		},
+		{
+			name:  "elide_nulls must be a boolean",
+			stack: "/stack",
+			configs: []hclconfig{
+				{
+					path: "/stack",
+					add: GenerateHCL(
+						Labels("tm_dynamic_test.tf"),
+						Content(
+							TmDynamic(
+								Labels("my_block"),
+								Str("elide_nulls", "yes"),
+								Content(
+									Str("a", "val"),
+								),
+							),
+						),
+					),
+				},
+			},
+			wantErr: errors.E(genhcl.ErrInvalidDynamicElideNulls),
+		},
 	}
 
 	for _, tcase := range tcases {
@@ -0,0 +1,100 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package genhcl_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGenerateHCLWithoutFormat(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/generate.tm:generate_hcl "policy.sentinel" {
+  format = false
+  content {
+    block "a" {
+      list = [1,2,
+        3]
+      ref  = data.x.y
+    }
+    short = 1
+    a_long_name = "x"
+    name = [terramate.stack.name,   "b"]
+    upper = tm_upper("a")
+    text = <<-EOT
+      line
+    EOT
+  }
+}
+
+generate_hcl "formatted.hcl" {
+  content {
+    short = 1
+    a_long_name = "x"
+  }
+}
+`,
+	})
+
+	cfg, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	st, err := config.LoadStack(cfg, project.NewPath("/stack"))
+	assert.NoError(t, err)
+
+	evalctx := stack.NewEvalCtx(cfg, st, s.LoadStackGlobals(cfg, st))
+	got, err := genhcl.Load(cfg, st, evalctx.Context, project.NewPath("/modules"), nil)
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(got))
+
+	assert.EqualStrings(t, "formatted.hcl", got[0].Label())
+	assert.EqualStrings(t, `a_long_name = "x"
+short       = 1
+`, got[0].Body())
+
+	assert.EqualStrings(t, "policy.sentinel", got[1].Label())
+	assert.EqualStrings(t, `a_long_name = "x"
+name = ["stack", "b"]
+short = 1
+text = <<-EOT
+      line
+    EOT
+upper = "A"
+block "a" {
+  list = [1,2,
+        3]
+  ref = data.x.y
+}
+`, got[1].Body())
+}
+
+func TestGenerateHCLFormatMustBeBool(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:stack/generate.tm:generate_hcl "file.hcl" {
+  format = "no"
+  content {
+    a = 1
+  }
+}
+`,
+	})
+
+	_, err := config.LoadRoot(s.RootDir(), false)
+	assert.IsTrue(t, errors.IsKind(err, hcl.ErrTerramateSchema), "unexpected error: %v", err)
+}
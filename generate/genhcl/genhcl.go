@@ -6,8 +6,12 @@ package genhcl
 
 import (
 	stdfmt "fmt"
+	"io"
 	"path"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
 	"github.com/rs/zerolog/log"
@@ -15,6 +19,7 @@ import (
 	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/hcl/v2/hclwrite"
 	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/event"
 	"github.com/terramate-io/terramate/hcl"
@@ -35,11 +40,15 @@ import (
 // about the origin of the generated code.
 type HCL struct {
 	magicCommentStyle CommentStyle
+	noHeader          bool
 	label             string
 	origin            info.Range
 	body              string
 	condition         bool
 	asserts           []config.Assert
+	output            hcl.GenerateOutputConfig
+	evalTime          time.Duration
+	formatTime        time.Duration
 }
 
 // CommentStyle is the configured comment style that must be generated.
@@ -69,6 +78,9 @@ const (
 	// ErrContentEval indicates the failure to evaluate the content block.
 	ErrContentEval errors.Kind = "evaluating content"
 
+	// ErrFormat indicates the failure to format the generated code.
+	ErrFormat errors.Kind = "formatting generated code"
+
 	// ErrConditionEval indicates the failure to evaluate the condition attribute.
 	ErrConditionEval errors.Kind = "evaluating condition attribute"
 
@@ -95,6 +107,10 @@ const (
 	// ErrDynamicConditionEval indicates that the condition of a tm_dynamic cant be evaluated.
 	ErrDynamicConditionEval errors.Kind = "evaluating tm_dynamic.condition"
 
+	// ErrInvalidDynamicElideNulls indicates that the elide_nulls attribute of
+	// a tm_dynamic block is invalid.
+	ErrInvalidDynamicElideNulls errors.Kind = "invalid tm_dynamic.elide_nulls"
+
 	// ErrDynamicAttrsConflict indicates fields of tm_dynamic conflicts.
 	ErrDynamicAttrsConflict errors.Kind = "tm_dynamic.attributes and tm_dynamic.content have conflicting fields"
 )
@@ -116,15 +132,29 @@ func (h HCL) Asserts() []config.Assert {
 
 // Header returns the header of the generated HCL file.
 func (h HCL) Header() string {
+	if h.noHeader {
+		return ""
+	}
 	return Header(h.magicCommentStyle)
 }
 
+// NoHeader tells if the code is generated without the header, as set by the
+// no_header attribute of the generate_hcl block.
+func (h HCL) NoHeader() bool {
+	return h.noHeader
+}
+
 // Body returns a string representation of the HCL code
 // or an empty string if the config itself is empty.
 func (h HCL) Body() string {
 	return string(h.body)
 }
 
+// Output returns the output encoding configured in the generate_hcl block.
+func (h HCL) Output() hcl.GenerateOutputConfig {
+	return h.output
+}
+
 // Range returns the range information of the generate_file block.
 func (h HCL) Range() info.Range {
 	return h.origin
@@ -140,6 +170,16 @@ func (h HCL) Context() string {
 	return "stack"
 }
 
+// EvalDuration returns the time spent evaluating the generate_hcl block.
+func (h HCL) EvalDuration() time.Duration {
+	return h.evalTime
+}
+
+// FormatDuration returns the time spent formatting the generated code.
+func (h HCL) FormatDuration() time.Duration {
+	return h.formatTime
+}
+
 func (h HCL) String() string {
 	return stdfmt.Sprintf("Generating file %q (condition %t) (body %q) (origin %q)",
 		h.Label(), h.Condition(), h.Body(), h.Range().HostPath())
@@ -167,17 +207,54 @@ func commentStyleFromString(str string) CommentStyle {
 	}
 }
 
-// CommentStyleFromConfig returns the CommentStyle from the configuration or the
-// default if not defined.
+// CommentStyleFromConfig returns the CommentStyle configured for the tree
+// directory, which is the one set by the closest directory up to the project
+// root, or the default if not defined.
 func CommentStyleFromConfig(tree *config.Tree) CommentStyle {
+	for node := tree; node != nil; node = node.Parent {
+		tmConfig := node.Node.Terramate
+		if tmConfig == nil ||
+			tmConfig.Config == nil ||
+			tmConfig.Config.Generate == nil ||
+			tmConfig.Config.Generate.HCLMagicHeaderCommentStyle == nil {
+			continue
+		}
+		return commentStyleFromString(*tmConfig.Config.Generate.HCLMagicHeaderCommentStyle)
+	}
+	return DefaultComment
+}
+
+// FormatOptionsFromConfig returns the formatting options for the generated
+// code from the configuration.
+func FormatOptionsFromConfig(tree *config.Tree) []fmt.Option {
+	tmConfig := tree.Node.Terramate
+	if tmConfig == nil ||
+		tmConfig.Config == nil ||
+		tmConfig.Config.Generate == nil {
+		return nil
+	}
+	var opts []fmt.Option
+	if size := tmConfig.Config.Generate.IndentSize; size != nil {
+		opts = append(opts, fmt.WithIndentSize(*size))
+	}
+	if width := tmConfig.Config.Generate.MaxLineWidth; width != nil {
+		opts = append(opts, fmt.WithMaxLineWidth(*width))
+	}
+	return opts
+}
+
+// SimplifyFromConfig tells if the partially evaluated expressions must be
+// simplified. It's configured by terramate.config.generate.simplify_expressions
+// and defaults to false.
+func SimplifyFromConfig(tree *config.Tree) bool {
 	tmConfig := tree.Node.Terramate
 	if tmConfig == nil ||
 		tmConfig.Config == nil ||
 		tmConfig.Config.Generate == nil ||
-		tmConfig.Config.Generate.HCLMagicHeaderCommentStyle == nil {
-		return DefaultComment
+		tmConfig.Config.Generate.SimplifyExpressions == nil {
+		return false
 	}
-	return commentStyleFromString(*tmConfig.Config.Generate.HCLMagicHeaderCommentStyle)
+	return *tmConfig.Config.Generate.SimplifyExpressions
 }
 
 // Load loads from the file system all generate_hcl for
@@ -202,17 +279,29 @@ func Load(
 	if err != nil {
 		return nil, errors.E("loading generate_hcl", err)
 	}
+	hclBlocks = appendTemplateGenHCLBlocks(root, st, hclBlocks)
 
 	tel.DefaultRecord.Set(
 		tel.BoolFlag("hcl", len(hclBlocks) != 0, "generate"),
 	)
 
-	commentStyle := CommentStyleFromConfig(root.Tree())
+	commentStyle := DefaultComment
+	if cfg, ok := root.Lookup(st.Dir); ok {
+		commentStyle = CommentStyleFromConfig(cfg)
+	}
+	formatOpts := FormatOptionsFromConfig(root.Tree())
+	simplify := SimplifyFromConfig(root.Tree())
+	source := ast.NewFileSourceReader()
 
 	var hcls []HCL
 	for _, hclBlock := range hclBlocks {
 		name := hclBlock.Label
 
+		if hclBlock.Visibility == hcl.VisibilityDirectory && !st.IsDirectlyIn(hclBlock.Dir) {
+			log.Logger.Trace().Msgf("Skipping %q, not directly inside %s", st.Dir, hclBlock.Dir)
+			continue
+		}
+
 		matchedAnyStackFilter := len(hclBlock.StackFilters) == 0
 		for _, cond := range hclBlock.StackFilters {
 			matched := true
@@ -228,12 +317,22 @@ func Load(
 				}
 			}
 
+			if matched && !cond.Tags.IsEmpty() && !filter.MatchTags(cond.Tags, st.Tags) {
+				log.Logger.Trace().Msgf("Skipping %q, tags %v don't match the tags filter", st.Dir, st.Tags)
+				matched = false
+			}
+
 			matchedAnyStackFilter = matchedAnyStackFilter || matched
 		}
 
 		if !matchedAnyStackFilter {
+			if lets.IsLabelTemplate(name) {
+				// the filename is unknown without evaluating the lets.
+				continue
+			}
 			hcls = append(hcls, HCL{
 				magicCommentStyle: commentStyle,
+				noHeader:          hclBlock.NoHeader,
 				label:             name,
 				origin:            hclBlock.Range,
 				condition:         false,
@@ -241,7 +340,9 @@ func Load(
 			continue
 		}
 
+		evalStart := time.Now()
 		evalctx := evalctx.Copy()
+		evalctx.SetSimplify(simplify)
 
 		vendorTargetDir := project.NewPath(path.Join(
 			st.Dir.String(),
@@ -249,7 +350,7 @@ func Load(
 
 		evalctx.SetFunction(
 			stdlib.Name("vendor"),
-			stdlib.VendorFunc(vendorTargetDir, vendorDir, vendorRequests),
+			root.VendorFunc(vendorTargetDir, vendorDir, vendorRequests),
 		)
 
 		err := lets.Load(hclBlock.Lets, evalctx)
@@ -257,6 +358,11 @@ func Load(
 			return nil, err
 		}
 
+		name, err = lets.EvalLabel(name, hclBlock.Range, evalctx)
+		if err != nil {
+			return nil, err
+		}
+
 		condition := true
 		if hclBlock.Condition != nil {
 			value, err := evalctx.Eval(hclBlock.Condition.Expr)
@@ -276,9 +382,11 @@ func Load(
 		if !condition {
 			hcls = append(hcls, HCL{
 				magicCommentStyle: commentStyle,
+				noHeader:          hclBlock.NoHeader,
 				label:             name,
 				origin:            hclBlock.Range,
 				condition:         condition,
+				evalTime:          time.Since(evalStart),
 			})
 			continue
 		}
@@ -301,7 +409,7 @@ func Load(
 			inherit = value.True()
 		}
 
-		if !inherit && hclBlock.Dir != st.Dir {
+		if !inherit && hclBlock.Dir != st.Dir && hclBlock.Dir != st.Extends {
 			// ignore non-inheritable block
 			continue
 		}
@@ -329,10 +437,12 @@ func Load(
 		if assertFailed {
 			hcls = append(hcls, HCL{
 				magicCommentStyle: commentStyle,
+				noHeader:          hclBlock.NoHeader,
 				label:             name,
 				origin:            hclBlock.Range,
 				condition:         condition,
 				asserts:           asserts,
+				evalTime:          time.Since(evalStart),
 			})
 			continue
 		}
@@ -344,23 +454,32 @@ func Load(
 		if !ok {
 			panic(errors.E(errors.ErrInternal, "unexpected block body type"))
 		}
-		if err := copyBody(gen.Body(), blockBody, evalctx); err != nil {
+		if err := copyBody(gen.Body(), blockBody, evalctx, source, false, hclBlock.SkipFormat); err != nil {
 			return nil, evalErr(root.Tree().RootDir(), ErrContentEval, hclBlock, err)
 		}
-
-		formatted, err := fmt.FormatMultiline(string(gen.Bytes()), hclBlock.Range.HostPath())
-		if err != nil {
-			panic(errors.E(err,
-				"internal error: formatting generated code for generate_hcl %q:%s", name, string(gen.Bytes()),
-			))
+		evalTime := time.Since(evalStart)
+
+		formatStart := time.Now()
+		var formatted string
+		if hclBlock.SkipFormat {
+			formatted = verbatim(gen)
+		} else {
+			formatted, err = fmt.FormatMultiline(string(gen.Bytes()), hclBlock.Range.HostPath(), formatOpts...)
+			if err != nil {
+				return nil, evalErr(root.Tree().RootDir(), ErrFormat, hclBlock, err)
+			}
 		}
 		hcls = append(hcls, HCL{
 			magicCommentStyle: commentStyle,
+			noHeader:          hclBlock.NoHeader,
 			label:             name,
 			origin:            hclBlock.Range,
 			body:              formatted,
 			condition:         condition,
 			asserts:           asserts,
+			output:            hclBlock.Output,
+			evalTime:          evalTime,
+			formatTime:        time.Since(formatStart),
 		})
 	}
 
@@ -371,6 +490,89 @@ func Load(
 	return hcls, nil
 }
 
+// verbatim returns the generated code without the canonical formatting,
+// which aligns, reflows and expands the code. The expressions copied from
+// the source are kept as written and the other tokens are only indented by
+// their nesting level and separated by a single space where the generated
+// code has spaces.
+func verbatim(gen *hclwrite.File) string {
+	// writing the file sets the spacing of its tokens.
+	_, _ = gen.WriteTo(io.Discard)
+	tokens := gen.BuildTokens(nil)
+
+	var b strings.Builder
+	level := 0
+	lineStart := true
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenNewline:
+			b.Write(tok.Bytes)
+			lineStart = true
+			continue
+		case hclsyntax.TokenEOF:
+			continue
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen:
+			if level > 0 {
+				level--
+			}
+		}
+		if lineStart {
+			b.WriteString(strings.Repeat("  ", level))
+		} else if tok.SpacesBefore > 0 {
+			b.WriteByte(' ')
+		}
+		b.Write(tok.Bytes)
+		lineStart = false
+		switch tok.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen:
+			level++
+		}
+	}
+	return b.String()
+}
+
+// sourceTokens returns the expression exactly as written in its source file.
+// It returns false if the expression is changed by the partial evaluation,
+// which is the case when it references a known namespace or calls a
+// Terramate function, or if its source is not available.
+func sourceTokens(expr hhcl.Expression, eval hcl.Evaluator, source ast.SourceReader) (hclwrite.Tokens, bool) {
+	for _, traversal := range expr.Variables() {
+		if eval.HasNamespace(traversal.RootName()) {
+			return nil, false
+		}
+	}
+	syntaxExpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return nil, false
+	}
+	callsTmFunc := false
+	_ = hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hhcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && strings.HasPrefix(call.Name, "tm_") {
+			callsTmFunc = true
+		}
+		return nil
+	})
+	if callsTmFunc {
+		return nil, false
+	}
+	rng := expr.Range()
+	if strings.HasSuffix(rng.Filename, ".json") {
+		// the expressions of JSON files have no HCL source.
+		return nil, false
+	}
+	src := source(rng.Filename)
+	if rng.End.Byte > len(src) {
+		return nil, false
+	}
+	// the expression is a single opaque token, spaced like an identifier.
+	return hclwrite.Tokens{
+		{
+			Type:  hclsyntax.TokenIdent,
+			Bytes: rng.SliceBytes(src),
+		},
+	}, true
+}
+
 func evalErr(rootdir string, kind errors.Kind, block hcl.GenHCLBlock, err error) error {
 	if block.IsImplicitBlock {
 		return errors.E(kind, err, `tmgen file "%s"`, project.PrjAbsPath(rootdir, block.Range.HostPath()))
@@ -384,6 +586,7 @@ type dynBlockAttributes struct {
 	foreach    *hclsyntax.Attribute
 	labels     *hclsyntax.Attribute
 	condition  *hclsyntax.Attribute
+	elideNulls *hclsyntax.Attribute
 }
 
 // loadGenHCLBlocks will load all generate_hcl blocks.
@@ -412,14 +615,43 @@ func loadGenHCLBlocks(root *config.Root, st *config.Stack, cfgdir project.Path)
 	return res, nil
 }
 
+// appendTemplateGenHCLBlocks appends the generate_hcl blocks of the template
+// extended by the stack to blocks. The template blocks are overridden by the
+// blocks with the same label declared in the stack or its parent directories.
+func appendTemplateGenHCLBlocks(root *config.Root, st *config.Stack, blocks []hcl.GenHCLBlock) []hcl.GenHCLBlock {
+	if st.Extends == (project.Path{}) {
+		return blocks
+	}
+	template, ok := root.Lookup(st.Extends)
+	if !ok || template.IsEmptyConfig() {
+		return blocks
+	}
+	res := blocks
+	for _, block := range template.Node.Generate.HCLs {
+		overridden := slices.ContainsFunc(blocks, func(other hcl.GenHCLBlock) bool {
+			return other.Label == block.Label
+		})
+		if !overridden {
+			res = append(res, block)
+		}
+	}
+	return res
+}
+
 // copyBody will copy the src body to the given target, evaluating attributes
 // using the given evaluation context.
 //
 // Scoped traversals, like name.traverse, for unknown namespaces will be copied
 // as is (original expression form, no evaluation).
 //
+// If elideNulls is true, the attributes evaluated to null are omitted, also
+// in the nested blocks.
+//
+// If keepSource is true, the attributes not changed by the evaluation are
+// copied exactly as written in the source, also in the nested blocks.
+//
 // Returns an error if the evaluation fails.
-func copyBody(dest *hclwrite.Body, src *hclsyntax.Body, eval hcl.Evaluator) error {
+func copyBody(dest *hclwrite.Body, src *hclsyntax.Body, eval hcl.Evaluator, source ast.SourceReader, elideNulls, keepSource bool) error {
 	attrs := ast.SortRawAttributes(ast.AsHCLAttributes(src.Attributes))
 	for _, attr := range attrs {
 		newexpr, _, err := eval.PartialEval(attr.Expr)
@@ -427,11 +659,21 @@ func copyBody(dest *hclwrite.Body, src *hclsyntax.Body, eval hcl.Evaluator) erro
 			return errors.E(err, attr.Expr.Range())
 		}
 
-		dest.SetAttributeRaw(attr.Name, ast.TokensForExpression(newexpr))
+		if elideNulls && isNullExpr(newexpr) {
+			continue
+		}
+
+		if keepSource {
+			if tokens, ok := sourceTokens(attr.Expr, eval, source); ok {
+				dest.SetAttributeRaw(attr.Name, tokens)
+				continue
+			}
+		}
+		dest.SetAttributeRaw(attr.Name, ast.TokensForExpressionWithSource(newexpr, source))
 	}
 
 	for _, block := range src.Blocks {
-		err := appendBlock(dest, block, eval)
+		err := appendBlock(dest, block, eval, source, elideNulls, keepSource)
 		if err != nil {
 			return err
 		}
@@ -440,14 +682,15 @@ func copyBody(dest *hclwrite.Body, src *hclsyntax.Body, eval hcl.Evaluator) erro
 	return nil
 }
 
-func appendBlock(target *hclwrite.Body, block *hclsyntax.Block, eval hcl.Evaluator) error {
+func appendBlock(target *hclwrite.Body, block *hclsyntax.Block, eval hcl.Evaluator, source ast.SourceReader, elideNulls, keepSource bool) error {
 	if block.Type == "tm_dynamic" {
-		return appendDynamicBlocks(target, block, eval)
+		// tm_dynamic blocks have their own elide_nulls attribute.
+		return appendDynamicBlocks(target, block, eval, source, keepSource)
 	}
 
 	targetBlock := target.AppendNewBlock(block.Type, block.Labels)
 	if block.Body != nil {
-		err := copyBody(targetBlock.Body(), block.Body, eval)
+		err := copyBody(targetBlock.Body(), block.Body, eval, source, elideNulls, keepSource)
 		if err != nil {
 			return err
 		}
@@ -455,12 +698,21 @@ func appendBlock(target *hclwrite.Body, block *hclsyntax.Block, eval hcl.Evaluat
 	return nil
 }
 
+// isNullExpr tells if the partially evaluated expression is the null value.
+func isNullExpr(expr hhcl.Expression) bool {
+	lit, ok := expr.(*hclsyntax.LiteralValueExpr)
+	return ok && lit.Val.IsNull()
+}
+
 func appendDynamicBlock(
 	destination *hclwrite.Body,
 	evaluator hcl.Evaluator,
 	genBlockType string,
 	attrs dynBlockAttributes,
+	elideNulls bool,
 	contentBlock *hclsyntax.Block,
+	source ast.SourceReader,
+	keepSource bool,
 ) error {
 	var labels []string
 	if attrs.labels != nil {
@@ -505,6 +757,9 @@ func appendDynamicBlock(
 				if key.Type() != cty.String {
 					panic("unreachable")
 				}
+				if elideNulls && val.IsNull() {
+					continue
+				}
 				tmAttrs = append(tmAttrs, tmAttribute{
 					name:   key.AsString(),
 					tokens: ast.TokensForValue(val),
@@ -536,9 +791,12 @@ func appendDynamicBlock(
 						ast.TokensForExpression(item.ValueExpr),
 					)
 				}
+				if elideNulls && isNullExpr(valExpr) {
+					continue
+				}
 				tmAttrs = append(tmAttrs, tmAttribute{
 					name:   keyVal.AsString(),
-					tokens: ast.TokensForExpression(valExpr),
+					tokens: ast.TokensForExpressionWithSource(valExpr, source),
 					info:   item.ValueExpr.Range(),
 				})
 			}
@@ -569,7 +827,7 @@ func appendDynamicBlock(
 				)
 			}
 		}
-		err := copyBody(newblock.Body(), contentBlock.Body, evaluator)
+		err := copyBody(newblock.Body(), contentBlock.Body, evaluator, source, elideNulls, keepSource)
 		if err != nil {
 			return err
 		}
@@ -596,7 +854,7 @@ func setBodyAttributes(body *hclwrite.Body, attrs []tmAttribute) error {
 	return nil
 }
 
-func appendDynamicBlocks(target *hclwrite.Body, dynblock *hclsyntax.Block, evaluator hcl.Evaluator) error {
+func appendDynamicBlocks(target *hclwrite.Body, dynblock *hclsyntax.Block, evaluator hcl.Evaluator, source ast.SourceReader, keepSource bool) error {
 	errs := errors.L()
 	if len(dynblock.Labels) != 1 {
 		errs.Append(errors.E(ErrParsing,
@@ -620,6 +878,19 @@ func appendDynamicBlocks(target *hclwrite.Body, dynblock *hclsyntax.Block, evalu
 
 	genBlockType := dynblock.Labels[0]
 
+	elideNulls := false
+	if attrs.elideNulls != nil {
+		value, err := evaluator.Eval(attrs.elideNulls.Expr)
+		if err != nil {
+			return errors.E(ErrInvalidDynamicElideNulls, err, attrs.elideNulls.Range())
+		}
+		if value.Type() != cty.Bool || value.IsNull() {
+			return errors.E(ErrInvalidDynamicElideNulls, attrs.elideNulls.Range(),
+				"want boolean got %s", value.Type().FriendlyName())
+		}
+		elideNulls = value.True()
+	}
+
 	if attrs.condition != nil {
 		condition, err := evaluator.Eval(attrs.condition.Expr)
 		if err != nil {
@@ -658,7 +929,7 @@ func appendDynamicBlocks(target *hclwrite.Body, dynblock *hclsyntax.Block, evalu
 		}
 
 		return appendDynamicBlock(target, evaluator,
-			genBlockType, attrs, contentBlock)
+			genBlockType, attrs, elideNulls, contentBlock, source, keepSource)
 	}
 
 	iterator := genBlockType
@@ -687,7 +958,7 @@ func appendDynamicBlocks(target *hclwrite.Body, dynblock *hclsyntax.Block, evalu
 		})
 
 		if err := appendDynamicBlock(target, evaluator,
-			genBlockType, attrs, contentBlock); err != nil {
+			genBlockType, attrs, elideNulls, contentBlock, source, keepSource); err != nil {
 			tmDynamicErr = err
 			return true
 		}
@@ -716,6 +987,8 @@ func getDynamicBlockAttrs(block *hclsyntax.Block) (dynBlockAttributes, error) {
 			dynAttrs.iterator = attr
 		case "condition":
 			dynAttrs.condition = attr
+		case "elide_nulls":
+			dynAttrs.elideNulls = attr
 		default:
 			errs.Append(attrErr(
 				attr, "tm_dynamic unsupported attribute %q", name))
@@ -1307,6 +1307,37 @@ func TestGenerateHCL(t *testing.T) {
 			},
 			wantErr: errors.E(genhcl.ErrContentEval),
 		},
+		{
+			name:  "tm_vendor is not available in sandboxed mode",
+			stack: "/stacks/stack",
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Terramate(
+						Config(
+							Block("sandbox",
+								Bool("enabled", true),
+							),
+						),
+					),
+				},
+				{
+					path: "/stacks/stack",
+					add: Doc(
+						GenerateHCL(
+							Labels("test"),
+							Content(
+								Module(
+									Labels("test"),
+									Expr("source", `tm_vendor("github.com/terramate-io/example?ref=v1")`),
+								),
+							),
+						),
+					),
+				},
+			},
+			wantErr: errors.E(genhcl.ErrContentEval),
+		},
 		{
 			name:  "global evaluation failure on condition",
 			stack: "/stacks/stack",
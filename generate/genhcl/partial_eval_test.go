@@ -1492,6 +1492,41 @@ EOT
 				Expr("test", `<<-EOT
 ${[]}
 EOT
+`),
+			),
+		},
+		{
+			name: "HEREDOCs with partials left preserve delimiter and indent marker",
+			config: Doc(
+				Expr("test", `<<EOF
+  BEFORE ${local.myvar} AFTER
+EOF
+`),
+			),
+			want: Doc(
+				Expr("test", `<<EOF
+  BEFORE ${local.myvar} AFTER
+EOF
+`),
+			),
+		},
+		{
+			name: "HEREDOCs with delimiter conflicting with evaluated content",
+			globals: Globals(
+				Str("value", "EOF"),
+			),
+			config: Doc(
+				Expr("test", `<<EOF
+${global.value}
+${local.myvar}
+EOF
+`),
+			),
+			want: Doc(
+				Expr("test", `<<-EOT
+EOF
+${local.myvar}
+EOT
 `),
 			),
 		},
@@ -1914,3 +1949,46 @@ EOT
 		})
 	}
 }
+
+func TestPartialEvalSimplifyExpressions(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	stackEntry := s.CreateStack("stack")
+	s.RootEntry().CreateFile("terramate.tm", `
+terramate {
+  config {
+    generate {
+      simplify_expressions = true
+    }
+  }
+}
+`)
+	stackEntry.CreateFile(terramate.DefaultFilename, Doc(
+		Globals(
+			Number("num", 10),
+		),
+		GenerateHCL(
+			Labels("test"),
+			Content(
+				Expr("a", `1 + global.num + var.x`),
+				Expr("b", `1 >= global.num ? local.x : [for x in local.a : x]`),
+			),
+		),
+	).String())
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+
+	st := stackEntry.Load(root)
+	globals := s.LoadStackGlobals(root, st)
+	evalctx := stack.NewEvalCtx(root, st, globals)
+	got, err := genhcl.Load(root, st, evalctx.Context, project.NewPath("/modules"), nil)
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(got), "want single generated HCL")
+
+	assertHCLEquals(t, got[0].Body(), Doc(
+		Expr("a", `11 + var.x`),
+		Expr("b", `[for x in local.a : x]`),
+	).String())
+}
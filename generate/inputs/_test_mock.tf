@@ -0,0 +1,22 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "inputs" {
+  content = <<-EOF
+package inputs // import "github.com/terramate-io/terramate/generate/inputs"
+
+Package inputs implements the snapshot of the resolved inputs of generated
+files, which allows auditing which inputs changed since the last code
+generation.
+
+const ErrNotFound errors.Kind = "generation state not found" ...
+const File = ".terramate/generate-state.json"
+func Compute(root *config.Root, st *config.Stack, files []generate.GenFile) (map[string]Inputs, error)
+func Drift(recorded, current Inputs) []string
+func Save(rootdir string, s State) error
+type Inputs struct{ ... }
+type State struct{ ... }
+    func Load(rootdir string) (State, error)
+EOF
+
+  filename = "${path.module}/mock-inputs.ignore"
+}
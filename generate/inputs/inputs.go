@@ -0,0 +1,287 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package inputs implements the snapshot of the resolved inputs of generated
+// files, which allows auditing which inputs changed since the last
+// code generation.
+package inputs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl/diff"
+	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// File is the path, relative to the project root, of the generation state.
+const File = ".terramate/generate-state.json"
+
+const (
+	// ErrNotFound indicates that the generation state does not exist.
+	ErrNotFound errors.Kind = "generation state not found"
+
+	// ErrSave indicates that the generation state could not be saved.
+	ErrSave errors.Kind = "saving generation state"
+
+	// ErrLoad indicates that the generation state could not be loaded.
+	ErrLoad errors.Kind = "loading generation state"
+
+	// ErrCompute indicates that the inputs of the generated files could not
+	// be computed.
+	ErrCompute errors.Kind = "computing generation inputs"
+)
+
+// State is the snapshot of the inputs of all generated files.
+type State struct {
+	// Files maps the generated file path, relative to the project root, to
+	// its inputs.
+	Files map[string]Inputs `json:"files"`
+}
+
+// Inputs are the resolved inputs of a single generated file.
+type Inputs struct {
+	// TerramateVersion is the version of Terramate which generated the file.
+	TerramateVersion string `json:"terramate_version"`
+	// Globals maps the name of the globals referenced by the generate block
+	// to the hash of their values.
+	Globals map[string]string `json:"globals,omitempty"`
+	// GlobalValues maps the name of the globals referenced by the generate
+	// block to their values, so the drifts can show what changed.
+	GlobalValues map[string]json.RawMessage `json:"global_values,omitempty"`
+	// Metadata is the hash of the stack metadata.
+	Metadata string `json:"metadata"`
+}
+
+// Compute computes the inputs of the files generated for the given stack.
+// Files with a false condition are not included.
+func Compute(root *config.Root, st *config.Stack, files []generate.GenFile) (map[string]Inputs, error) {
+	report := globals.ForStack(root, st)
+	if err := report.AsError(); err != nil {
+		return nil, errors.E(ErrCompute, err, "evaluating globals of stack %s", st.Dir)
+	}
+	globalVals := report.Globals.AsValueMap()
+
+	metadata, err := hash(cty.ObjectVal(st.RuntimeValues(root)))
+	if err != nil {
+		return nil, errors.E(ErrCompute, err, "hashing metadata of stack %s", st.Dir)
+	}
+
+	tree, _ := root.Lookup(st.Dir)
+	refs := globalRefs(tree)
+
+	res := map[string]Inputs{}
+	for _, file := range files {
+		if !file.Condition() {
+			continue
+		}
+		inputs := Inputs{
+			TerramateVersion: terramate.Version(),
+			Metadata:         metadata,
+		}
+		for name := range refs[file.Label()] {
+			val, ok := globalVals[name]
+			if !ok {
+				continue
+			}
+			h, err := hash(val)
+			if err != nil {
+				return nil, errors.E(ErrCompute, err, "hashing global.%s of stack %s", name, st.Dir)
+			}
+			data, err := json.Marshal(ctyjson.SimpleJSONValue{Value: val})
+			if err != nil {
+				return nil, errors.E(ErrCompute, err, "encoding global.%s of stack %s", name, st.Dir)
+			}
+			if inputs.Globals == nil {
+				inputs.Globals = map[string]string{}
+				inputs.GlobalValues = map[string]json.RawMessage{}
+			}
+			inputs.Globals[name] = h
+			inputs.GlobalValues[name] = data
+		}
+		res[st.Dir.Join(file.Label()).String()] = inputs
+	}
+	return res, nil
+}
+
+// Drift returns a description of each input which differs between the
+// recorded and the current inputs. The changes of the globals are described
+// by the paths of their changed values, if both inputs have the values.
+func Drift(recorded, current Inputs) []string {
+	var drifts []string
+	if recorded.TerramateVersion != current.TerramateVersion {
+		drifts = append(drifts, fmt.Sprintf("terramate version changed from %s to %s",
+			recorded.TerramateVersion, current.TerramateVersion))
+	}
+	if recorded.Metadata != current.Metadata {
+		drifts = append(drifts, "stack metadata changed")
+	}
+
+	names := map[string]struct{}{}
+	for name := range recorded.Globals {
+		names[name] = struct{}{}
+	}
+	for name := range current.Globals {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		old, hadOld := recorded.Globals[name]
+		cur, hasCur := current.Globals[name]
+		switch {
+		case !hadOld:
+			drifts = append(drifts, fmt.Sprintf("global.%s was added", name))
+		case !hasCur:
+			drifts = append(drifts, fmt.Sprintf("global.%s was removed", name))
+		case old != cur:
+			drifts = append(drifts, globalDrifts(name, recorded, current)...)
+		}
+	}
+	return drifts
+}
+
+// globalDrifts describes the changes of the value of the global.
+func globalDrifts(name string, recorded, current Inputs) []string {
+	changed := []string{fmt.Sprintf("global.%s changed", name)}
+	old, ok := globalValue(recorded, name)
+	if !ok {
+		return changed
+	}
+	cur, ok := globalValue(current, name)
+	if !ok {
+		return changed
+	}
+	changes := diff.Values(old, cur)
+	if len(changes) == 0 {
+		return changed
+	}
+	prefix := cty.GetAttrPath("global").GetAttr(name)
+	drifts := make([]string, len(changes))
+	for i, change := range changes {
+		change.Path = append(prefix.Copy(), change.Path...)
+		drifts[i] = change.String()
+	}
+	return drifts
+}
+
+func globalValue(in Inputs, name string) (cty.Value, bool) {
+	data, ok := in.GlobalValues[name]
+	if !ok {
+		return cty.NilVal, false
+	}
+	var val ctyjson.SimpleJSONValue
+	if err := json.Unmarshal(data, &val); err != nil {
+		return cty.NilVal, false
+	}
+	return val.Value, true
+}
+
+// Save saves the state in the File of the given project root.
+func Save(rootdir string, s State) error {
+	path := filepath.Join(rootdir, filepath.FromSlash(File))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.E(ErrSave, err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.E(ErrSave, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.E(ErrSave, err)
+	}
+	return nil
+}
+
+// Load loads the state from the File of the given project root.
+func Load(rootdir string) (State, error) {
+	path := filepath.Join(rootdir, filepath.FromSlash(File))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return State{}, errors.E(ErrNotFound, "%s", File)
+		}
+		return State{}, errors.E(ErrLoad, err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, errors.E(ErrLoad, err, "decoding %s", path)
+	}
+	if s.Files == nil {
+		s.Files = map[string]Inputs{}
+	}
+	return s, nil
+}
+
+// Merge replaces the inputs of files inside the given directory with the
+// given files.
+func (s *State) Merge(dir project.Path, files map[string]Inputs) {
+	if s.Files == nil {
+		s.Files = map[string]Inputs{}
+	}
+	for path := range s.Files {
+		if project.NewPath(path).HasDirPrefix(dir.String()) {
+			delete(s.Files, path)
+		}
+	}
+	for path, inputs := range files {
+		s.Files[path] = inputs
+	}
+}
+
+// globalRefs maps the label of each stack context generate block of the
+// stack to the root names of the globals it references. The closest block
+// defining a label takes precedence.
+func globalRefs(tree *config.Tree) map[string]map[string]struct{} {
+	refs := map[string]map[string]struct{}{}
+	add := func(label string, traversals []hhcl.Traversal) {
+		if _, ok := refs[label]; ok {
+			return
+		}
+		names := map[string]struct{}{}
+		for _, traversal := range traversals {
+			if path, ok := generate.GlobalRef(traversal); ok {
+				names[path[0]] = struct{}{}
+			}
+		}
+		refs[label] = names
+	}
+	for node := tree; node != nil; node = node.Parent {
+		for _, block := range node.Node.Generate.HCLs {
+			add(block.Label, generate.HCLBlockTraversals(block))
+		}
+		for _, block := range node.Node.Generate.Files {
+			if block.Context == "root" {
+				continue
+			}
+			add(block.Label, generate.FileBlockTraversals(block))
+		}
+	}
+	return refs
+}
+
+func hash(val cty.Value) (string, error) {
+	data, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
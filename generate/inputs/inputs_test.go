@@ -0,0 +1,127 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package inputs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/inputs"
+	"github.com/terramate-io/terramate/project"
+)
+
+func TestInputsSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+
+	_, err := inputs.Load(rootdir)
+	assert.IsError(t, err, errors.E(inputs.ErrNotFound))
+
+	want := inputs.State{
+		Files: map[string]inputs.Inputs{
+			"/stack/main.tf": {
+				TerramateVersion: "0.1.0",
+				Globals:          map[string]string{"a": "hash"},
+				Metadata:         "metadata",
+			},
+		},
+	}
+	assert.NoError(t, inputs.Save(rootdir, want))
+
+	got, err := inputs.Load(rootdir)
+	assert.NoError(t, err)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
+
+func TestInputsMerge(t *testing.T) {
+	t.Parallel()
+
+	state := inputs.State{
+		Files: map[string]inputs.Inputs{
+			"/stacks/a/main.tf":   {Metadata: "a"},
+			"/stacks/a/old.tf":    {Metadata: "a"},
+			"/stacks/abc/main.tf": {Metadata: "abc"},
+		},
+	}
+	state.Merge(project.NewPath("/stacks/a"), map[string]inputs.Inputs{
+		"/stacks/a/main.tf": {Metadata: "new"},
+	})
+
+	want := map[string]inputs.Inputs{
+		"/stacks/a/main.tf":   {Metadata: "new"},
+		"/stacks/abc/main.tf": {Metadata: "abc"},
+	}
+	if diff := cmp.Diff(want, state.Files); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
+
+func TestInputsDrift(t *testing.T) {
+	t.Parallel()
+
+	recorded := inputs.Inputs{
+		TerramateVersion: "0.1.0",
+		Globals: map[string]string{
+			"changed":   "1",
+			"removed":   "1",
+			"unchanged": "1",
+		},
+		Metadata: "m1",
+	}
+	current := inputs.Inputs{
+		TerramateVersion: "0.2.0",
+		Globals: map[string]string{
+			"added":     "1",
+			"changed":   "2",
+			"unchanged": "1",
+		},
+		Metadata: "m2",
+	}
+
+	want := []string{
+		"terramate version changed from 0.1.0 to 0.2.0",
+		"stack metadata changed",
+		"global.added was added",
+		"global.changed changed",
+		"global.removed was removed",
+	}
+	if diff := cmp.Diff(want, inputs.Drift(recorded, current)); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+	assert.EqualInts(t, 0, len(inputs.Drift(recorded, recorded)))
+}
+
+func TestInputsDriftGlobalValues(t *testing.T) {
+	t.Parallel()
+
+	recorded := inputs.Inputs{
+		Globals: map[string]string{"cfg": "1", "name": "1"},
+		GlobalValues: map[string]json.RawMessage{
+			"cfg":  json.RawMessage(`{"region":"us-east-1","zones":["a"]}`),
+			"name": json.RawMessage(`"a"`),
+		},
+	}
+	current := inputs.Inputs{
+		Globals: map[string]string{"cfg": "2", "name": "2"},
+		GlobalValues: map[string]json.RawMessage{
+			"cfg": json.RawMessage(`{"region":"eu-west-1","zones":["a","b"]}`),
+		},
+	}
+
+	want := []string{
+		`~ global.cfg.region: "us-east-1" => "eu-west-1"`,
+		`+ global.cfg.zones[1] = "b"`,
+		// without the current value only the change is reported.
+		"global.name changed",
+	}
+	if diff := cmp.Diff(want, inputs.Drift(recorded, current)); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
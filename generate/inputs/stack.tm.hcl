@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package inputs // import \"github.com/terramate-io/terramate/generate/inputs\""
+  description = "package inputs // import \"github.com/terramate-io/terramate/generate/inputs\"\n\nPackage inputs implements the snapshot of the resolved inputs of generated\nfiles, which allows auditing which inputs changed since the last code\ngeneration.\n\nconst ErrNotFound errors.Kind = \"generation state not found\" ...\nconst File = \".terramate/generate-state.json\"\nfunc Compute(root *config.Root, st *config.Stack, files []generate.GenFile) (map[string]Inputs, error)\nfunc Drift(recorded, current Inputs) []string\nfunc Save(rootdir string, s State) error\ntype Inputs struct{ ... }\ntype State struct{ ... }\n    func Load(rootdir string) (State, error)"
+  tags        = ["generate", "golang", "inputs"]
+  id          = "4c61eecf-0a45-443a-9cbc-518d016dc993"
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/madlambda/spells/assert"
 	"github.com/terramate-io/terramate"
+	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/generate"
 	"github.com/terramate-io/terramate/project"
@@ -1560,7 +1561,7 @@ func TestOutdatedDetection(t *testing.T) {
 					}
 				}
 
-				got, err := generate.DetectOutdated(s.Config(), target, vendorDir)
+				got, err := generate.DetectOutdated(s.Config(), target, 0, vendorDir)
 				assert.IsError(t, err, step.wantErr)
 				if err != nil {
 					continue
@@ -1571,7 +1572,7 @@ func TestOutdatedDetection(t *testing.T) {
 				t.Log("checking that after generate outdated detection should always return empty")
 
 				s.GenerateWith(s.Config(), vendorDir)
-				got, err = generate.DetectOutdated(s.Config(), s.Config().Tree(), vendorDir)
+				got, err = generate.DetectOutdated(s.Config(), s.Config().Tree(), 0, vendorDir)
 				assert.NoError(t, err)
 
 				assertEqualStringList(t, got, []string{})
@@ -1579,3 +1580,99 @@ func TestOutdatedDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestOutdatedDetectionComparesGitMetadata(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals {
+  branch = terramate.git.branch
+}`,
+		`f:stack/gen.tm:generate_file "commit.txt" {
+  lets {
+    sha = terramate.git.short_commit
+  }
+  content = let.sha
+}
+
+generate_file "branch.txt" {
+  content = global.branch
+}
+
+generate_hcl "static.hcl" {
+  content {
+    a = "static"
+  }
+}`,
+	})
+
+	vendorDir := project.NewPath("/modules")
+	root := s.Config()
+	root.SetGitMetadata(&config.GitMetadata{ShortCommit: "aaaaaaaa", Branch: "main"})
+	s.GenerateWith(root, vendorDir)
+	assert.EqualStrings(t, "aaaaaaaa", string(s.RootEntry().ReadFile("stack/commit.txt")))
+	assert.EqualStrings(t, "main", string(s.RootEntry().ReadFile("stack/branch.txt")))
+
+	got, err := generate.DetectOutdated(root, root.Tree(), 0, vendorDir)
+	assert.NoError(t, err)
+	assertEqualStringList(t, got, []string{})
+
+	root.SetGitMetadata(&config.GitMetadata{ShortCommit: "aaaaaaaa", Branch: "feature"})
+	got, err = generate.DetectOutdated(root, root.Tree(), 0, vendorDir)
+	assert.NoError(t, err)
+	assertEqualStringList(t, got, []string{"stack/branch.txt"})
+
+	root.SetGitMetadata(&config.GitMetadata{ShortCommit: "bbbbbbbb", Branch: "feature"})
+	got, err = generate.DetectOutdated(root, root.Tree(), 0, vendorDir)
+	assert.NoError(t, err)
+	assertEqualStringList(t, got, []string{"stack/branch.txt", "stack/commit.txt"})
+}
+
+func TestOutdatedDetectionIgnoresChangedDependent(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:globals.tm:globals {
+  changed = terramate.changed.stack
+}`,
+		`f:gen.tm:generate_file "changed.txt" {
+  content = global.changed ? "true" : "false"
+}
+
+generate_file "/ci/changed.txt" {
+  context = root
+  content = tm_join(",", terramate.changed.stacks)
+}
+
+generate_hcl "static.hcl" {
+  content {
+    a = "static"
+  }
+}`,
+	})
+
+	vendorDir := project.NewPath("/modules")
+	root := s.Config()
+	root.SetChangedStacks(project.Paths{project.NewPath("/stacks/a")})
+	s.GenerateWith(root, vendorDir)
+	assert.EqualStrings(t, "true", string(s.RootEntry().ReadFile("stacks/a/changed.txt")))
+	assert.EqualStrings(t, "/stacks/a", string(s.RootEntry().ReadFile("ci/changed.txt")))
+
+	root.SetChangedStacks(nil)
+	got, err := generate.DetectOutdated(root, root.Tree(), 0, vendorDir)
+	assert.NoError(t, err)
+	assertEqualStringList(t, got, []string{})
+
+	s.RootEntry().CreateFile("stacks/b/static.hcl", "changed")
+	got, err = generate.DetectOutdated(root, root.Tree(), 0, vendorDir)
+	assert.NoError(t, err)
+	assertEqualStringList(t, got, []string{"stacks/b/static.hcl"})
+
+	_, isSet := root.ChangedStacks()
+	assert.IsTrue(t, !isSet, "changed stacks must be restored")
+}
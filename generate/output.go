@@ -0,0 +1,78 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"strings"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/hcl"
+)
+
+const utf8BOM = "\ufeff"
+
+// outputConfigurer is implemented by the generated files supporting the
+// output encoding attributes.
+type outputConfigurer interface {
+	Output() hcl.GenerateOutputConfig
+}
+
+// fileContent returns the content of the generated file as written to disk.
+// The output encoding configured in the generate block takes precedence over
+// the project defaults set in terramate.config.generate.
+func fileContent(root *config.Root, genfile GenFile) string {
+	content := genfile.Header() + genfile.Body()
+	if content == "" {
+		return ""
+	}
+
+	output := projectOutputConfig(root)
+	if configurer, ok := genfile.(outputConfigurer); ok {
+		blockOutput := configurer.Output()
+		if blockOutput.LineEndings != nil {
+			output.LineEndings = blockOutput.LineEndings
+		}
+		if blockOutput.TrailingNewline != nil {
+			output.TrailingNewline = blockOutput.TrailingNewline
+		}
+		if blockOutput.BOM != nil {
+			output.BOM = blockOutput.BOM
+		}
+	}
+	return encodeOutput(output, content)
+}
+
+func projectOutputConfig(root *config.Root) hcl.GenerateOutputConfig {
+	tmConfig := root.Tree().Node.Terramate
+	if tmConfig == nil ||
+		tmConfig.Config == nil ||
+		tmConfig.Config.Generate == nil {
+		return hcl.GenerateOutputConfig{}
+	}
+	return tmConfig.Config.Generate.Output
+}
+
+// encodeOutput applies the output encoding to the content.
+// Unset attributes leave the content unchanged.
+func encodeOutput(output hcl.GenerateOutputConfig, content string) string {
+	if output.TrailingNewline != nil {
+		content = strings.TrimRight(content, "\r\n")
+		if *output.TrailingNewline {
+			content += "\n"
+		}
+	}
+	if output.LineEndings != nil {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		if *output.LineEndings == hcl.LineEndingsCRLF {
+			content = strings.ReplaceAll(content, "\n", "\r\n")
+		}
+	}
+	if output.BOM != nil {
+		content = strings.TrimPrefix(content, utf8BOM)
+		if *output.BOM {
+			content = utf8BOM + content
+		}
+	}
+	return content
+}
@@ -0,0 +1,20 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "providers" {
+  content = <<-EOF
+package providers // import "github.com/terramate-io/terramate/generate/providers"
+
+Package providers implements the generation of the Terraform provider
+requirements of the stacks from the providers blocks.
+
+const DefaultFilename = "versions.tf"
+const ErrInvalid errors.Kind = "invalid providers configuration"
+type File struct{ ... }
+    func PrepareFile(root *config.Root, reqs Requirements) File
+type Provider struct{ ... }
+type Requirements struct{ ... }
+    func Resolve(tree *config.Tree, evalctx *eval.Context) (Requirements, bool, error)
+EOF
+
+  filename = "${path.module}/mock-providers.ignore"
+}
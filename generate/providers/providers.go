@@ -0,0 +1,282 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providers implements the generation of the Terraform provider
+// requirements of the stacks from the providers blocks.
+package providers
+
+import (
+	stdfmt "fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/tf"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrInvalid indicates that the providers configuration of a stack is invalid.
+const ErrInvalid errors.Kind = "invalid providers configuration"
+
+// DefaultFilename is the name of the generated file if no providers block
+// sets the filename.
+const DefaultFilename = "versions.tf"
+
+// Provider is the resolved requirement of a provider of a stack.
+type Provider struct {
+	Name    string
+	Source  string
+	Version string
+
+	// Origin is the provider block where the version was defined.
+	Origin info.Range
+}
+
+// Requirements are the resolved provider requirements of a stack.
+type Requirements struct {
+	// Filename is the name of the generated file.
+	Filename string
+
+	// Providers are the required providers, sorted by name.
+	Providers []Provider
+
+	// Origin is the closest providers block of the stack.
+	Origin info.Range
+}
+
+// Resolve resolves the provider requirements of the stack at tree, evaluating
+// the providers blocks of the stack directory and its parent directories.
+// The attributes of the closest directories override the ones of the
+// farthest directories and, at each directory, the version constraint of the
+// stack environment overrides the default version of the provider.
+// It returns false if no providers block applies to the stack.
+func Resolve(tree *config.Tree, evalctx *eval.Context) (Requirements, bool, error) {
+	var nodes []*config.Tree
+	for node := tree; node != nil; node = node.Parent {
+		nodes = append([]*config.Tree{node}, nodes...)
+	}
+
+	reqs := Requirements{Filename: DefaultFilename}
+	var envExpr hhcl.Expression
+	found := false
+	for _, node := range nodes {
+		for _, block := range node.Node.Providers {
+			found = true
+			reqs.Origin = block.Range
+			if block.Filename != nil {
+				filename, err := evalString(evalctx, block.Filename, "providers.filename")
+				if err != nil {
+					return Requirements{}, false, err
+				}
+				reqs.Filename = filename
+			}
+			if block.Environment != nil {
+				envExpr = block.Environment
+			}
+		}
+	}
+	if !found {
+		return Requirements{}, false, nil
+	}
+	if reqs.Filename == "" {
+		return Requirements{}, false, errors.E(ErrInvalid, "providers.filename must not be empty")
+	}
+	if strings.HasSuffix(reqs.Filename, tf.DeploymentFileExt) {
+		return Requirements{}, false, errors.E(ErrInvalid, reqs.Origin,
+			"providers.filename %q: the provider requirements of Terraform Stacks must be in a %s file",
+			reqs.Filename, tf.StackFileExt)
+	}
+
+	var env string
+	if envExpr != nil {
+		var err error
+		env, err = evalString(evalctx, envExpr, "providers.environment")
+		if err != nil {
+			return Requirements{}, false, err
+		}
+	}
+
+	providers := map[string]*Provider{}
+	for _, node := range nodes {
+		for _, block := range node.Node.Providers {
+			for _, cfg := range block.Providers {
+				provider, ok := providers[cfg.Name]
+				if !ok {
+					provider = &Provider{Name: cfg.Name}
+					providers[cfg.Name] = provider
+				}
+				if cfg.Source != nil {
+					source, err := evalString(evalctx, cfg.Source, "provider.source")
+					if err != nil {
+						return Requirements{}, false, err
+					}
+					provider.Source = source
+				}
+				version, ok, err := providerVersion(evalctx, cfg.Version, cfg.Environments, env)
+				if err != nil {
+					return Requirements{}, false, errors.E(err, "evaluating version of provider %s", cfg.Name)
+				}
+				if ok {
+					provider.Version = version
+					provider.Origin = cfg.Range
+				}
+			}
+		}
+	}
+
+	for _, provider := range providers {
+		if provider.Source == "" {
+			return Requirements{}, false, errors.E(ErrInvalid,
+				"provider %s of stack %s has no source", provider.Name, tree.Dir())
+		}
+		reqs.Providers = append(reqs.Providers, *provider)
+	}
+	sort.Slice(reqs.Providers, func(i, j int) bool {
+		return reqs.Providers[i].Name < reqs.Providers[j].Name
+	})
+	return reqs, true, nil
+}
+
+// providerVersion returns the version constraint of the environment, if
+// defined, or the default version otherwise.
+func providerVersion(evalctx *eval.Context, versionExpr, envsExpr hhcl.Expression, env string) (string, bool, error) {
+	if envsExpr != nil && env != "" {
+		envs, err := evalctx.Eval(envsExpr)
+		if err != nil {
+			return "", false, err
+		}
+		if !envs.Type().IsObjectType() && !envs.Type().IsMapType() {
+			return "", false, errors.E(ErrInvalid, envsExpr.Range(),
+				"provider.environments must be an object but %s given", envs.Type().FriendlyName())
+		}
+		for it := envs.ElementIterator(); it.Next(); {
+			key, val := it.Element()
+			if key.AsString() != env {
+				continue
+			}
+			if val.Type() != cty.String {
+				return "", false, errors.E(ErrInvalid, envsExpr.Range(),
+					"provider.environments.%s must be a string but %s given", env, val.Type().FriendlyName())
+			}
+			return val.AsString(), true, nil
+		}
+	}
+	if versionExpr == nil {
+		return "", false, nil
+	}
+	version, err := evalString(evalctx, versionExpr, "provider.version")
+	if err != nil {
+		return "", false, err
+	}
+	return version, true, nil
+}
+
+func evalString(evalctx *eval.Context, expr hhcl.Expression, name string) (string, error) {
+	val, err := evalctx.Eval(expr)
+	if err != nil {
+		return "", errors.E(err, "evaluating %s", name)
+	}
+	if val.Type() != cty.String {
+		return "", errors.E(ErrInvalid, expr.Range(),
+			"%s must be a string but %s given", name, val.Type().FriendlyName())
+	}
+	return val.AsString(), nil
+}
+
+// File is a generated file with the provider requirements of a stack.
+type File struct {
+	magicCommentStyle genhcl.CommentStyle
+	filename          string
+	origin            info.Range
+	body              string
+	condition         bool
+}
+
+// PrepareFile prepares the generated file with the given provider requirements
+// for the stack at the cfg tree. The requirements are generated in the
+// terraform block, except for Terraform Stacks files, which declare them in
+// a top-level required_providers block.
+func PrepareFile(cfg *config.Tree, reqs Requirements) File {
+	gen := hclwrite.NewEmptyFile()
+	body := gen.Body()
+	if !strings.HasSuffix(reqs.Filename, tf.StackFileExt) {
+		body = body.AppendNewBlock("terraform", nil).Body()
+	}
+	reqBlock := body.AppendNewBlock("required_providers", nil)
+	for _, provider := range reqs.Providers {
+		attrs := map[string]cty.Value{
+			"source": cty.StringVal(provider.Source),
+		}
+		if provider.Version != "" {
+			attrs["version"] = cty.StringVal(provider.Version)
+		}
+		reqBlock.Body().SetAttributeValue(provider.Name, cty.ObjectVal(attrs))
+	}
+	return File{
+		magicCommentStyle: genhcl.CommentStyleFromConfig(cfg),
+		filename:          reqs.Filename,
+		origin:            reqs.Origin,
+		condition:         len(reqs.Providers) > 0,
+		body:              string(hclwrite.Format(gen.Bytes())),
+	}
+}
+
+// Builtin returns true for the providers generated file.
+func (f File) Builtin() bool { return true }
+
+// Label is the name of the generated file.
+func (f File) Label() string {
+	return f.filename
+}
+
+// Asserts returns nil.
+func (f File) Asserts() []config.Assert {
+	return nil
+}
+
+// Header returns the header of the generated HCL file.
+func (f File) Header() string {
+	return genhcl.Header(f.magicCommentStyle)
+}
+
+// Body returns the HCL code of the provider requirements.
+func (f File) Body() string {
+	return f.body
+}
+
+// Range returns the range of the closest providers block.
+func (f File) Range() info.Range {
+	return f.origin
+}
+
+// Condition is true if there's any provider to be generated.
+func (f File) Condition() bool {
+	return f.condition
+}
+
+// Context of the generated file.
+func (f File) Context() string {
+	return "stack"
+}
+
+// EvalDuration returns zero since the providers file is not timed.
+func (f File) EvalDuration() time.Duration {
+	return 0
+}
+
+// FormatDuration returns zero since the providers file is not timed.
+func (f File) FormatDuration() time.Duration {
+	return 0
+}
+
+func (f File) String() string {
+	return stdfmt.Sprintf("Generating file %q (condition %t) (body %q) (origin %q)",
+		f.Label(), f.Condition(), f.Body(), f.Range().HostPath())
+}
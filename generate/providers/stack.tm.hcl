@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package providers // import \"github.com/terramate-io/terramate/generate/providers\""
+  description = "package providers // import \"github.com/terramate-io/terramate/generate/providers\"\n\nPackage providers implements the generation of the Terraform provider\nrequirements of the stacks from the providers blocks.\n\nconst DefaultFilename = \"versions.tf\"\nconst ErrInvalid errors.Kind = \"invalid providers configuration\"\ntype File struct{ ... }\n    func PrepareFile(root *config.Root, reqs Requirements) File\ntype Provider struct{ ... }\ntype Requirements struct{ ... }\n    func Resolve(tree *config.Tree, evalctx *eval.Context) (Requirements, bool, error)"
+  tags        = ["generate", "golang", "providers"]
+  id          = "a076cd8f-080a-42bc-a55a-052a0aeace1d"
+}
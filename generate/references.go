@@ -0,0 +1,85 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// HCLBlockTraversals returns the variable traversals of all the expressions
+// of the generate_hcl block (lets, condition, asserts and content).
+func HCLBlockTraversals(block hcl.GenHCLBlock) []hhcl.Traversal {
+	traversals := commonTraversals(block.Lets, block.Condition, block.Asserts)
+	if block.Content != nil {
+		if body, ok := block.Content.Body.(*hclsyntax.Body); ok {
+			_ = hclsyntax.VisitAll(body, func(n hclsyntax.Node) hhcl.Diagnostics {
+				if expr, ok := n.(*hclsyntax.ScopeTraversalExpr); ok {
+					traversals = append(traversals, expr.Traversal)
+				}
+				return nil
+			})
+		}
+	}
+	return traversals
+}
+
+// FileBlockTraversals returns the variable traversals of all the expressions
+// of the generate_file block (lets, condition, asserts and content or outputs).
+func FileBlockTraversals(block hcl.GenFileBlock) []hhcl.Traversal {
+	traversals := commonTraversals(block.Lets, block.Condition, block.Asserts)
+	if block.Content != nil {
+		traversals = append(traversals, block.Content.Expr.Variables()...)
+	}
+	if block.Outputs != nil {
+		traversals = append(traversals, block.Outputs.Expr.Variables()...)
+	}
+	return traversals
+}
+
+// GlobalRef returns the accessor path of a global.* traversal.
+// Eg.: global.a["b"].c returns [a, b, c].
+func GlobalRef(traversal hhcl.Traversal) ([]string, bool) {
+	if traversal.RootName() != "global" {
+		return nil, false
+	}
+	var path []string
+	for _, step := range traversal[1:] {
+		switch t := step.(type) {
+		case hhcl.TraverseAttr:
+			path = append(path, t.Name)
+		case hhcl.TraverseIndex:
+			if !t.Key.IsKnown() || !t.Key.Type().Equals(cty.String) {
+				return path, len(path) > 0
+			}
+			path = append(path, t.Key.AsString())
+		default:
+			return path, len(path) > 0
+		}
+	}
+	return path, len(path) > 0
+}
+
+func commonTraversals(lets *ast.MergedBlock, condition *hclsyntax.Attribute, asserts []hcl.AssertConfig) []hhcl.Traversal {
+	var traversals []hhcl.Traversal
+	if lets != nil {
+		for _, attr := range lets.Attributes {
+			traversals = append(traversals, attr.Expr.Variables()...)
+		}
+	}
+	if condition != nil {
+		traversals = append(traversals, condition.Expr.Variables()...)
+	}
+	for _, assert := range asserts {
+		for _, expr := range []hhcl.Expression{assert.Assertion, assert.Message, assert.Warning} {
+			if expr != nil {
+				traversals = append(traversals, expr.Variables()...)
+			}
+		}
+	}
+	return traversals
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "report" {
-  content = <<-EOT
+  content = <<-EOF
 package report // import "github.com/terramate-io/terramate/generate/report"
 
 Package report provides a report of the code generation process.
@@ -11,7 +11,7 @@ type FailureResult struct{ ... }
 type Report struct{ ... }
     func Merge(reportChan chan *Report) *Report
 type Result struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-report.ignore"
 }
@@ -0,0 +1,125 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package report
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+)
+
+type jsonReport struct {
+	BootstrapError *jsonError    `json:"bootstrap_error,omitempty"`
+	Successes      []jsonResult  `json:"successes"`
+	Failures       []jsonFailure `json:"failures"`
+	CleanupError   *jsonError    `json:"cleanup_error,omitempty"`
+	Timings        []jsonTiming  `json:"timings,omitempty"`
+	Slowest        []jsonTiming  `json:"slowest,omitempty"`
+}
+
+type jsonResult struct {
+	Dir     string   `json:"dir"`
+	Created []string `json:"created,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+type jsonFailure struct {
+	jsonResult
+	Errors []jsonError `json:"errors"`
+}
+
+type jsonTiming struct {
+	Dir      string  `json:"dir"`
+	Label    string  `json:"label"`
+	EvalMS   float64 `json:"eval_ms"`
+	FormatMS float64 `json:"format_ms"`
+	TotalMS  float64 `json:"total_ms"`
+}
+
+type jsonError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	DocsURL string `json:"docs_url,omitempty"`
+}
+
+// MarshalJSON returns the JSON representation of the report.
+// Failed assertions include their code and documentation URL, if any.
+// The timings of each generate block are reported in milliseconds, together
+// with the DefaultSlowestBlocks slowest ones.
+func (r Report) MarshalJSON() ([]byte, error) {
+	out := jsonReport{
+		Successes: []jsonResult{},
+		Failures:  []jsonFailure{},
+	}
+	if r.BootstrapErr != nil {
+		jerr := newJSONError(r.BootstrapErr)
+		out.BootstrapError = &jerr
+	}
+	if r.CleanupErr != nil {
+		jerr := newJSONError(r.CleanupErr)
+		out.CleanupError = &jerr
+	}
+	for _, success := range r.Successes {
+		out.Successes = append(out.Successes, newJSONResult(success))
+	}
+	for _, failure := range r.Failures {
+		jfailure := jsonFailure{
+			jsonResult: newJSONResult(failure.Result),
+			Errors:     []jsonError{},
+		}
+		if list, ok := failure.Error.(*errors.List); ok {
+			for _, err := range list.Errors() {
+				jfailure.Errors = append(jfailure.Errors, newJSONError(err))
+			}
+		} else if failure.Error != nil {
+			jfailure.Errors = append(jfailure.Errors, newJSONError(failure.Error))
+		}
+		out.Failures = append(out.Failures, jfailure)
+	}
+	for _, timing := range r.Timings {
+		out.Timings = append(out.Timings, newJSONTiming(timing))
+	}
+	for _, timing := range r.Slowest(DefaultSlowestBlocks) {
+		out.Slowest = append(out.Slowest, newJSONTiming(timing))
+	}
+	return json.Marshal(out)
+}
+
+func newJSONResult(res Result) jsonResult {
+	return jsonResult{
+		Dir:     res.Dir.String(),
+		Created: res.Created,
+		Changed: res.Changed,
+		Deleted: res.Deleted,
+	}
+}
+
+func newJSONTiming(t BlockTiming) jsonTiming {
+	return jsonTiming{
+		Dir:      t.Dir.String(),
+		Label:    t.Label,
+		EvalMS:   milliseconds(t.Eval),
+		FormatMS: milliseconds(t.Format),
+		TotalMS:  milliseconds(t.Total()),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func newJSONError(err error) jsonError {
+	jerr := jsonError{
+		Message: err.Error(),
+	}
+	var assertErr *config.AssertionError
+	if errors.As(err, &assertErr) {
+		jerr.Code = assertErr.Code
+		jerr.DocsURL = assertErr.DocsURL
+	}
+	return jerr
+}
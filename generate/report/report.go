@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
 )
 
@@ -46,6 +47,10 @@ type Report struct {
 	// CleanupErr is an error that happened after code generation
 	// was done while trying to cleanup files outside stacks.
 	CleanupErr error
+
+	// Timings are the evaluation and formatting durations of each
+	// generate block.
+	Timings []BlockTiming
 }
 
 // HasFailures returns true if this report includes any failures.
@@ -79,19 +84,19 @@ func (r Report) Full() string {
 	}
 	addResultChangeset := func(res Result) {
 		for _, created := range res.Created {
-			addLine("\t[+] %s", created)
+			addLine("\t%s %s", printer.Sprint(printer.RoleSuccess, "[+]"), created)
 		}
 		for _, changed := range res.Changed {
-			addLine("\t[~] %s", changed)
+			addLine("\t%s %s", printer.Sprint(printer.RoleWarning, "[~]"), changed)
 		}
 		for _, deleted := range res.Deleted {
-			addLine("\t[-] %s", deleted)
+			addLine("\t%s %s", printer.Sprint(printer.RoleError, "[-]"), deleted)
 		}
 	}
 	needsHint := false
 
 	if len(r.Successes) > 0 {
-		addLine("Successes:")
+		addLine("%s", printer.SprintBold(printer.RoleSuccess, "Successes:"))
 		newline()
 		for _, success := range r.Successes {
 			addStack(success.Dir)
@@ -102,7 +107,7 @@ func (r Report) Full() string {
 	}
 
 	if len(r.Failures) > 0 {
-		addLine("Failures:")
+		addLine("%s", printer.SprintBold(printer.RoleError, "Failures:"))
 		newline()
 		for _, failure := range r.Failures {
 			addStack(failure.Dir)
@@ -192,6 +197,7 @@ func (r Report) empty() bool {
 func (r *Report) Sort() {
 	r.sortDirs()
 	r.sortFilenames()
+	r.sortTimings()
 }
 
 func (r *Report) sortDirs() {
@@ -327,6 +333,7 @@ func Merge(reportChan chan *Report) *Report {
 
 		merged.Successes = joinResults(merged.Successes, r.Successes)
 		merged.Failures = joinResults(merged.Failures, r.Failures)
+		merged.Timings = joinResults(merged.Timings, r.Timings)
 	}
 	return merged
 }
@@ -4,9 +4,12 @@
 package report_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/generate/report"
 	"github.com/terramate-io/terramate/project"
@@ -331,3 +334,155 @@ Fatal failure while cleaning up generated code outside stacks:
 		})
 	}
 }
+
+func TestReportJSON(t *testing.T) {
+	t.Parallel()
+
+	r := report.Report{
+		Successes: []report.Result{
+			{
+				Dir:     project.NewPath("/stack"),
+				Created: []string{"main.tf"},
+			},
+		},
+		Failures: []report.FailureResult{
+			{
+				Result: report.Result{
+					Dir: project.NewPath("/failed"),
+				},
+				Error: errors.L(
+					errors.E("some error"),
+					&config.AssertionError{
+						Message: "missing owner tag",
+						Code:    "TAG001",
+						DocsURL: "https://wiki.example.com/tags",
+					},
+				),
+			},
+		},
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"successes": []any{
+			map[string]any{
+				"dir":     "/stack",
+				"created": []any{"main.tf"},
+			},
+		},
+		"failures": []any{
+			map[string]any{
+				"dir": "/failed",
+				"errors": []any{
+					map[string]any{
+						"message": "some error",
+					},
+					map[string]any{
+						"message":  "assertion failed: :0,0-0: [TAG001] missing owner tag (see https://wiki.example.com/tags)",
+						"code":     "TAG001",
+						"docs_url": "https://wiki.example.com/tags",
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
+
+func TestReportSlowest(t *testing.T) {
+	t.Parallel()
+
+	r := report.Report{}
+	r.AddTiming(project.NewPath("/stack"), "fast.tf", time.Millisecond, time.Millisecond)
+	r.AddTiming(project.NewPath("/stack"), "slow.tf", 3*time.Second, 500*time.Millisecond)
+	r.AddTiming(project.NewPath("/other"), "medium.tf", time.Second, 0)
+
+	got := r.Slowest(2)
+	want := []report.BlockTiming{
+		{
+			Dir:    project.NewPath("/stack"),
+			Label:  "slow.tf",
+			Eval:   3 * time.Second,
+			Format: 500 * time.Millisecond,
+		},
+		{
+			Dir:   project.NewPath("/other"),
+			Label: "medium.tf",
+			Eval:  time.Second,
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(project.Path{})); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+
+	if len(r.Slowest(10)) != 3 {
+		t.Fatalf("want all timings when n is bigger than the number of blocks")
+	}
+
+	wantReport := `Slowest generate blocks:
+
+- /stack/slow.tf
+	total: 3.5s, eval: 3s, format: 500ms`
+	if gotReport := r.SlowestReport(1); gotReport != wantReport {
+		t.Fatalf("want:\n%s\ngot:\n%s", wantReport, gotReport)
+	}
+
+	if got := (report.Report{}).SlowestReport(5); got != "" {
+		t.Fatalf("want empty report for no timings, got %q", got)
+	}
+}
+
+func TestReportJSONTimings(t *testing.T) {
+	t.Parallel()
+
+	r := report.Report{}
+	r.AddTiming(project.NewPath("/stack"), "a.tf", 2*time.Millisecond, time.Millisecond)
+	r.AddTiming(project.NewPath("/stack"), "b.tf", 10*time.Millisecond, 0)
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	timingA := map[string]any{
+		"dir":       "/stack",
+		"label":     "a.tf",
+		"eval_ms":   2.0,
+		"format_ms": 1.0,
+		"total_ms":  3.0,
+	}
+	timingB := map[string]any{
+		"dir":       "/stack",
+		"label":     "b.tf",
+		"eval_ms":   10.0,
+		"format_ms": 0.0,
+		"total_ms":  10.0,
+	}
+	want := map[string]any{
+		"successes": []any{},
+		"failures":  []any{},
+		"timings":   []any{timingA, timingB},
+		"slowest":   []any{timingB, timingA},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
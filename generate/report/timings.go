@@ -0,0 +1,79 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/project"
+)
+
+// DefaultSlowestBlocks is the number of slowest blocks included in the
+// JSON representation of the report.
+const DefaultSlowestBlocks = 10
+
+// BlockTiming is the time spent generating the code of a single generate block.
+type BlockTiming struct {
+	// Dir is the directory where the code was generated.
+	Dir project.Path
+	// Label is the label of the generate block.
+	Label string
+	// Eval is the time spent evaluating the block.
+	Eval time.Duration
+	// Format is the time spent formatting the generated code.
+	Format time.Duration
+}
+
+// Total is the total time spent generating the code of the block.
+func (t BlockTiming) Total() time.Duration {
+	return t.Eval + t.Format
+}
+
+// AddTiming adds the timing of a generate block to the report.
+func (r *Report) AddTiming(dir project.Path, label string, eval, format time.Duration) {
+	r.Timings = append(r.Timings, BlockTiming{
+		Dir:    dir,
+		Label:  label,
+		Eval:   eval,
+		Format: format,
+	})
+}
+
+// Slowest returns the n blocks which took longer to be generated, slowest first.
+func (r Report) Slowest(n int) []BlockTiming {
+	timings := append([]BlockTiming{}, r.Timings...)
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Total() > timings[j].Total()
+	})
+	if n < len(timings) {
+		timings = timings[:n]
+	}
+	return timings
+}
+
+// SlowestReport provides a report of the n slowest generate blocks.
+func (r Report) SlowestReport(n int) string {
+	slowest := r.Slowest(n)
+	if len(slowest) == 0 {
+		return ""
+	}
+	report := []string{"Slowest generate blocks:", ""}
+	for _, t := range slowest {
+		report = append(report, fmt.Sprintf("- %s\n\ttotal: %s, eval: %s, format: %s",
+			t.Dir.Join(t.Label), t.Total(), t.Eval, t.Format))
+	}
+	return strings.Join(report, "\n")
+}
+
+func (r *Report) sortTimings() {
+	sort.Slice(r.Timings, func(i, j int) bool {
+		if r.Timings[i].Dir != r.Timings[j].Dir {
+			return r.Timings[i].Dir.String() < r.Timings[j].Dir.String()
+		}
+		return r.Timings[i].Label < r.Timings[j].Label
+	})
+}
@@ -1,14 +1,14 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "sharing" {
-  content = <<-EOT
+  content = <<-EOF
 package sharing // import "github.com/terramate-io/terramate/generate/sharing"
 
 Package sharing implements the loading of sharing related blocks.
 
 type File struct{ ... }
     func PrepareFile(root *config.Root, filename string, inputs config.Inputs, ...) (File, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-sharing.ignore"
 }
@@ -6,6 +6,7 @@ package sharing
 
 import (
 	stdfmt "fmt"
+	"time"
 
 	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/hcl/v2/hclwrite"
@@ -25,9 +26,10 @@ type File struct {
 	condition         bool
 }
 
-// PrepareFile prepares a sharing backend generated file.
-func PrepareFile(root *config.Root, filename string, inputs config.Inputs, outputs config.Outputs) (File, error) {
-	commentStyle := genhcl.CommentStyleFromConfig(root.Tree())
+// PrepareFile prepares a sharing backend generated file for the stack at the
+// cfg tree.
+func PrepareFile(cfg *config.Tree, filename string, inputs config.Inputs, outputs config.Outputs) (File, error) {
+	commentStyle := genhcl.CommentStyleFromConfig(cfg)
 	gen := hclwrite.NewEmptyFile()
 	body := gen.Body()
 	var info info.Range
@@ -109,6 +111,16 @@ func (f File) Context() string {
 	return "stack" // always the case for sharing backend.
 }
 
+// EvalDuration returns zero since sharing_backend files are not timed.
+func (f File) EvalDuration() time.Duration {
+	return 0
+}
+
+// FormatDuration returns zero since sharing_backend files are not timed.
+func (f File) FormatDuration() time.Duration {
+	return 0
+}
+
 func (f File) String() string {
 	return stdfmt.Sprintf("Generating file %q (condition %t) (body %q) (origin %q)",
 		f.Label(), f.Condition(), f.Body(), f.Range().HostPath())
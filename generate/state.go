@@ -0,0 +1,189 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/hcl/info"
+)
+
+// StateFilename is the name of the file generated in the stacks to track the
+// files generated without the Terramate header, which can't be detected by
+// their content.
+const StateFilename = ".terramate-generated.json"
+
+// generateState is the content of the state file.
+type generateState struct {
+	Files []stateEntry `json:"files"`
+}
+
+// stateEntry is a file tracked by the state file.
+type stateEntry struct {
+	// Path of the file relative to the stack directory.
+	Path string `json:"path"`
+
+	// SHA256 is the hex encoded SHA-256 of the generated content, used to
+	// detect files modified after generation.
+	SHA256 string `json:"sha256"`
+}
+
+// stateFile is the builtin generated state file of a stack.
+type stateFile struct {
+	origin    info.Range
+	body      string
+	condition bool
+}
+
+// prepareStateFile prepares the state file tracking the generated files
+// without header. The state file is only generated if there are such files.
+func prepareStateFile(root *config.Root, generated []GenFile) stateFile {
+	var (
+		state  generateState
+		origin info.Range
+	)
+	for _, file := range generated {
+		if !isStateTracked(root, file) {
+			continue
+		}
+		if len(state.Files) == 0 {
+			origin = file.Range()
+		}
+		state.Files = append(state.Files, stateEntry{
+			Path:   file.Label(),
+			SHA256: contentHash(fileContent(root, file)),
+		})
+	}
+	if len(state.Files) == 0 {
+		return stateFile{}
+	}
+	sort.Slice(state.Files, func(i, j int) bool {
+		return state.Files[i].Path < state.Files[j].Path
+	})
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		panic(errors.E(errors.ErrInternal, err, "encoding generate state"))
+	}
+	return stateFile{
+		origin:    origin,
+		body:      string(data) + "\n",
+		condition: true,
+	}
+}
+
+// isStateTracked tells if the generated file must be tracked by the state
+// file. The generate_hcl blocks with no_header = true are always tracked and
+// the generate_file blocks only if terramate.config.generate.track_headerless_files
+// is set.
+func isStateTracked(root *config.Root, file GenFile) bool {
+	if file.Builtin() || !file.Condition() || file.Header() != "" || file.Context() != "stack" {
+		return false
+	}
+	if _, ok := file.(genhcl.HCL); ok {
+		return true
+	}
+	return trackHeaderlessFiles(root)
+}
+
+// trackHeaderlessFiles tells if all the files generated without header in
+// the stacks are tracked by the state file. It's configured by
+// terramate.config.generate.track_headerless_files and defaults to false.
+func trackHeaderlessFiles(root *config.Root) bool {
+	tmConfig := root.Tree().Node.Terramate
+	if tmConfig == nil ||
+		tmConfig.Config == nil ||
+		tmConfig.Config.Generate == nil ||
+		tmConfig.Config.Generate.TrackHeaderlessFiles == nil {
+		return false
+	}
+	return *tmConfig.Config.Generate.TrackHeaderlessFiles
+}
+
+// loadState loads the files tracked by the state file of the stack dir, if
+// any. The dir must be an absolute host path.
+func loadState(dir string) ([]stateEntry, error) {
+	data, found, err := readFile(filepath.Join(dir, StateFilename))
+	if err != nil || !found {
+		return nil, err
+	}
+	var state generateState
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(data, utf8BOM)), &state); err != nil {
+		return nil, errors.E(err, "parsing %s", filepath.Join(dir, StateFilename))
+	}
+	return state.Files, nil
+}
+
+// lookupState returns the state entry of the file at the given host path,
+// from the state file of a stack containing it.
+func lookupState(root *config.Root, path string) (stateEntry, bool, error) {
+	for dir := filepath.Dir(path); dir != root.HostDir() && strings.HasPrefix(dir, root.HostDir()); dir = filepath.Dir(dir) {
+		entries, err := loadState(dir)
+		if err != nil {
+			return stateEntry{}, false, err
+		}
+		relpath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return stateEntry{}, false, errors.E(errors.ErrInternal, err)
+		}
+		for _, entry := range entries {
+			if entry.Path == filepath.ToSlash(relpath) {
+				return entry, true, nil
+			}
+		}
+	}
+	return stateEntry{}, false, nil
+}
+
+// checkStateCanDelete checks that the file tracked by the state entry can be
+// deleted, ie.: it was not modified since generated.
+func checkStateCanDelete(entry stateEntry, content string) error {
+	if contentHash(content) != entry.SHA256 {
+		return errors.E(ErrGeneratedFileModified, "%s", entry.Path)
+	}
+	return nil
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Builtin returns true for the state file.
+func (f stateFile) Builtin() bool { return true }
+
+// Label is the name of the state file.
+func (f stateFile) Label() string { return StateFilename }
+
+// Asserts returns nil.
+func (f stateFile) Asserts() []config.Assert { return nil }
+
+// Header returns an empty string as JSON doesn't support comments.
+func (f stateFile) Header() string { return "" }
+
+// Body returns the JSON encoded state.
+func (f stateFile) Body() string { return f.body }
+
+// Range returns the range of the first generate block tracked by the state.
+func (f stateFile) Range() info.Range { return f.origin }
+
+// Condition is true if there's any file to be tracked.
+func (f stateFile) Condition() bool { return f.condition }
+
+// Context of the state file.
+func (f stateFile) Context() string { return "stack" }
+
+// EvalDuration returns zero since the state file is not timed.
+func (f stateFile) EvalDuration() time.Duration { return 0 }
+
+// FormatDuration returns zero since the state file is not timed.
+func (f stateFile) FormatDuration() time.Duration { return 0 }
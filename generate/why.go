@@ -0,0 +1,130 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/hcl/diff"
+	"github.com/terramate-io/terramate/project"
+)
+
+// WhyOutdated returns, for each of the given outdated files, relative to the
+// project root, the lines describing why it's outdated. The files generated by
+// generate_hcl blocks are compared structurally with [diff.Bodies], the other
+// files line by line.
+//
+// The files must be the ones returned by [DetectOutdated] for the same target.
+func WhyOutdated(root *config.Root, target *config.Tree, vendorDir project.Path, outdated []string) (map[string][]string, error) {
+	generated := map[string]GenFile{}
+	add := func(filename string, genfile GenFile) {
+		if genfile.Condition() {
+			generated[filename] = genfile
+		}
+	}
+
+	errs := errors.L()
+	for _, cfg := range target.Stacks() {
+		genfiles, err := loadStackCodeCfgs(root, cfg, vendorDir, nil)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		dirRelPath := cfg.Dir().String()[1:]
+		for _, genfile := range genfiles {
+			add(path.Join(dirRelPath, genfile.Label()), genfile)
+		}
+	}
+	for _, cfg := range target.AsList() {
+		genfiles, err := loadRootCodeCfgs(root, cfg)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		for _, genfile := range genfiles {
+			add(genfile.Label()[1:], genfile)
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+
+	res := map[string][]string{}
+	for _, filename := range outdated {
+		current, found, err := readFile(filepath.Join(root.HostDir(), filepath.FromSlash(filename)))
+		if err != nil {
+			return nil, errors.E(err, "reading generated file %s", filename)
+		}
+		genfile, isGenerated := generated[filename]
+		switch {
+		case !isGenerated:
+			res[filename] = []string{"file is no longer generated"}
+		case !found:
+			res[filename] = []string{"file is not generated yet"}
+		default:
+			res[filename] = contentChanges(current, fileContent(root, genfile), genfile)
+		}
+	}
+	return res, nil
+}
+
+// contentChanges describes the changes from the current to the generated
+// content of a file.
+func contentChanges(current, generated string, genfile GenFile) []string {
+	if _, ok := genfile.(genhcl.HCL); ok {
+		oldBody, oldOK := parseBody(current)
+		newBody, newOK := parseBody(generated)
+		if oldOK && newOK {
+			changes := diff.Bodies(oldBody, newBody)
+			if len(changes) == 0 {
+				return []string{"only the formatting or the comments changed"}
+			}
+			lines := make([]string, len(changes))
+			for i, change := range changes {
+				lines[i] = change.String()
+			}
+			return lines
+		}
+	}
+	return lineChanges(current, generated)
+}
+
+func parseBody(code string) (*hclsyntax.Body, bool) {
+	file, diags := hclsyntax.ParseConfig([]byte(strings.TrimPrefix(code, "\ufeff")), "", hhcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	return file.Body.(*hclsyntax.Body), true
+}
+
+// lineChanges describes the lines removed and added between the common
+// leading and trailing lines of the current and generated contents.
+func lineChanges(current, generated string) []string {
+	oldLines := strings.Split(strings.ReplaceAll(current, "\r\n", "\n"), "\n")
+	newLines := strings.Split(strings.ReplaceAll(generated, "\r\n", "\n"), "\n")
+	for len(oldLines) > 0 && len(newLines) > 0 && oldLines[0] == newLines[0] {
+		oldLines, newLines = oldLines[1:], newLines[1:]
+	}
+	for len(oldLines) > 0 && len(newLines) > 0 && oldLines[len(oldLines)-1] == newLines[len(newLines)-1] {
+		oldLines, newLines = oldLines[:len(oldLines)-1], newLines[:len(newLines)-1]
+	}
+	if len(oldLines) == 0 && len(newLines) == 0 {
+		return []string{"only the line endings or the encoding changed"}
+	}
+	var lines []string
+	for _, line := range oldLines {
+		lines = append(lines, "- "+line)
+	}
+	for _, line := range newLines {
+		lines = append(lines, "+ "+line)
+	}
+	return lines
+}
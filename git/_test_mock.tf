@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "git" {
-  content = <<-EOT
+  content = <<-EOF
 package git // import "github.com/terramate-io/terramate/git"
 
 Package git provides a wrapper for the git command line program. The helper
@@ -29,7 +29,7 @@ type Ref struct{ ... }
 type Remote struct{ ... }
 type Repository struct{ ... }
     func NormalizeGitURI(raw string) (Repository, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-git.ignore"
 }
@@ -446,6 +446,36 @@ func (git *Git) FetchRemoteRev(remote, ref string) (Ref, error) {
 	}, nil
 }
 
+// IsShallow tells if the repository is a shallow clone.
+func (git *Git) IsShallow() (bool, error) {
+	out, err := git.exec("rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, err
+	}
+	return out == "true", nil
+}
+
+// FetchRef fetches the branch from the remote into its remote-tracking
+// reference (eg.: refs/remotes/origin/main). A depth greater than zero limits
+// the fetched history to the given number of commits.
+func (git *Git) FetchRef(remote, branch string, depth int) error {
+	args := []string{"--no-tags"}
+	if depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", depth))
+	}
+	args = append(args, remote,
+		fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, remote, branch))
+	_, err := git.exec("fetch", args...)
+	return err
+}
+
+// Deepen fetches depth more commits of history from the remote into the
+// current shallow clone.
+func (git *Git) Deepen(remote string, depth int) error {
+	_, err := git.exec("fetch", "--no-tags", fmt.Sprintf("--deepen=%d", depth), remote)
+	return err
+}
+
 // MergeBase finds the common commit ancestor of commit1 and commit2.
 func (git *Git) MergeBase(commit1, commit2 string) (string, error) {
 	return git.exec("merge-base", commit1, commit2)
@@ -495,6 +525,28 @@ func (git *Git) DiffNames(from, to string) ([]string, error) {
 	return removeEmptyLines(strings.Split(diff, "\n")), nil
 }
 
+// SparseExcludedFiles returns the tracked files of the given paths which are
+// not materialized in the working tree because they are excluded by a sparse
+// checkout. The paths are relative to the working directory.
+func (git *Git) SparseExcludedFiles(paths ...string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"-t", "--"}, paths...)
+	out, err := git.exec("ls-files", args...)
+	if err != nil {
+		return nil, fmt.Errorf("ls-files: %w", err)
+	}
+	var excluded []string
+	for _, line := range strings.Split(out, "\n") {
+		// skip-worktree entries are tagged with "S".
+		if file, ok := strings.CutPrefix(line, "S "); ok {
+			excluded = append(excluded, file)
+		}
+	}
+	return excluded, nil
+}
+
 // NewBranch creates a new branch reference pointing to current HEAD.
 func (git *Git) NewBranch(name string) error {
 	_, err := git.RevParse(name)
@@ -586,13 +638,34 @@ func (git *Git) Pull(remote, branch string) error {
 	return err
 }
 
-// ListDirtyFiles lists untracked and uncommitted files in the repository.
+// ListTrackedFiles lists the files tracked by git in the working directory
+// and its subdirectories, relative to the working directory.
+func (git *Git) ListTrackedFiles() ([]string, error) {
+	out, err := git.exec("ls-files", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files: %w", err)
+	}
+	out = strings.TrimRight(out, "\x00")
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\x00"), nil
+}
+
+// ListDirtyFiles lists untracked and uncommitted files in the working
+// directory and its subdirectories, relative to the working directory.
 func (git *Git) ListDirtyFiles() ([]string, []string, error) {
 	logger := log.With().
 		Str("action", "ListDirtyFiles()").
 		Str("workingDir", git.cfg().WorkingDir).
 		Logger()
 
+	// the porcelain paths are relative to the git root directory.
+	prefix, err := git.exec("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, nil, fmt.Errorf("git rev-parse --show-prefix: %w", err)
+	}
+
 	out, err := git.exec("status", "--porcelain")
 	if err != nil {
 		return nil, nil, fmt.Errorf("git status --porcelain: %w", err)
@@ -607,9 +680,12 @@ func (git *Git) ListDirtyFiles() ([]string, []string, error) {
 		if len(line) < 4 {
 			continue
 		}
+		if !strings.HasPrefix(line[3:], prefix) {
+			continue
+		}
 		switch line[0:2] {
 		case "??":
-			file := line[3:]
+			file := line[3:][len(prefix):]
 			if len(file) > 1 && file[len(file)-1] == os.PathSeparator {
 				file = file[:len(file)-1]
 			}
@@ -639,7 +715,7 @@ func (git *Git) ListDirtyFiles() ([]string, []string, error) {
 			}
 			untracked = append(untracked, file)
 		case " M":
-			file := line[3:]
+			file := line[3:][len(prefix):]
 			uncommitted = append(uncommitted, file)
 		}
 	}
@@ -755,6 +831,20 @@ func (git *Git) GetConfigValue(key string) (string, error) {
 	return strings.TrimSpace(s), nil
 }
 
+// SetConfigValue sets the given config key to value in the repository
+// local configuration.
+func (git *Git) SetConfigValue(key, value string) error {
+	_, err := git.exec("config", "--local", key, value)
+	return err
+}
+
+// GitPath resolves the given path inside the git directory, eg.: "hooks".
+// The returned path is relative to the working directory unless the git
+// directory is outside of it.
+func (git *Git) GitPath(path string) (string, error) {
+	return git.exec("rev-parse", "--git-path", path)
+}
+
 func (git *Git) exec(command string, args ...string) (string, error) {
 	cfg := git.cfg()
 	cmd := exec.Cmd{
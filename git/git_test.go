@@ -220,6 +220,49 @@ func TestFetchRemoteRevErrorHandling(t *testing.T) {
 	assert.Error(t, err, "unexpected result: %v", remoteRef)
 }
 
+func TestShallowCloneFetch(t *testing.T) {
+	t.Parallel()
+	repodir := mkOneCommitRepo(t)
+	g := test.NewGitWrapper(t, repodir, []string{})
+
+	test.WriteFile(t, repodir, "other.txt", "other")
+	assert.NoError(t, g.Add("other.txt"))
+	assert.NoError(t, g.Commit("second commit"))
+
+	remote, revision := addDefaultRemoteRev(t, g)
+	remoteDir, err := g.URL(remote)
+	assert.NoError(t, err)
+
+	assert.NoError(t, g.Checkout("feature", true))
+	assert.NoError(t, g.Push(remote, "feature"))
+
+	shallowdir := test.TempDir(t)
+	_, err = g.Exec("clone", "--depth=1", "--single-branch", "--branch", revision,
+		"file://"+remoteDir, shallowdir)
+	assert.NoError(t, err)
+
+	shallow := test.NewGitWrapper(t, shallowdir, []string{})
+	isShallow, err := shallow.IsShallow()
+	assert.NoError(t, err)
+	assert.IsTrue(t, isShallow)
+
+	isShallow, err = g.IsShallow()
+	assert.NoError(t, err)
+	assert.IsTrue(t, !isShallow)
+
+	_, err = shallow.RevParse("HEAD^")
+	assert.Error(t, err)
+	assert.NoError(t, shallow.Deepen(remote, 1))
+	_, err = shallow.RevParse("HEAD^")
+	assert.NoError(t, err)
+
+	_, err = shallow.RevParse("origin/feature")
+	assert.Error(t, err)
+	assert.NoError(t, shallow.FetchRef(remote, "feature", 1))
+	_, err = shallow.RevParse("origin/feature")
+	assert.NoError(t, err)
+}
+
 func TestListingAvailableRemotes(t *testing.T) {
 	t.Parallel()
 	type testcase struct {
@@ -505,6 +548,59 @@ func TestListDirtyFiles(t *testing.T) {
 	assert.EqualStrings(t, "deep/nested/path/test.txt", untracked[1])
 	assert.EqualStrings(t, "README.md", uncommitted[0])
 
+	subg := g.With().WorkingDir(filepath.Join(repodir, "deep")).Wrapper()
+	untracked, uncommitted, err = subg.ListDirtyFiles()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(untracked))
+	assert.EqualInts(t, 0, len(uncommitted))
+	assert.EqualStrings(t, "nested/path/test.txt", untracked[0])
+}
+
+func TestListTrackedFiles(t *testing.T) {
+	t.Parallel()
+
+	repodir := mkOneCommitRepo(t)
+	g := test.NewGitWrapper(t, repodir, []string{})
+
+	tracked, err := g.ListTrackedFiles()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(tracked))
+	assert.EqualStrings(t, "README.md", tracked[0])
+
+	test.WriteFile(t, filepath.Join(repodir, "deep/nested/path"), "test.txt", "some content")
+	tracked, err = g.ListTrackedFiles()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(tracked))
+
+	assert.NoError(t, g.Add("deep/nested/path/test.txt"))
+	tracked, err = g.ListTrackedFiles()
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(tracked))
+	assert.EqualStrings(t, "README.md", tracked[0])
+	assert.EqualStrings(t, "deep/nested/path/test.txt", tracked[1])
+}
+
+func TestSparseExcludedFiles(t *testing.T) {
+	t.Parallel()
+
+	repodir := mkOneCommitRepo(t)
+	g := test.NewGitWrapper(t, repodir, []string{})
+
+	test.WriteFile(t, filepath.Join(repodir, "deep/nested/path"), "test.txt", "some content")
+	assert.NoError(t, g.Add("deep/nested/path/test.txt"))
+	assert.NoError(t, g.Commit("add nested file"))
+
+	excluded, err := g.SparseExcludedFiles("deep", "README.md")
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0, len(excluded))
+
+	_, err = g.Exec("sparse-checkout", "set", "--no-cone", "/README.md")
+	assert.NoError(t, err)
+
+	excluded, err = g.SparseExcludedFiles("deep", "README.md")
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(excluded))
+	assert.EqualStrings(t, "deep/nested/path/test.txt", excluded[0])
 }
 
 const defaultBranch = "main"
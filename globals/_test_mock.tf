@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "globals" {
-  content = <<-EOT
+  content = <<-EOF
 package globals // import "github.com/terramate-io/terramate/globals"
 
 Package globals provides functions for loading globals.
@@ -19,7 +19,7 @@ type GlobalPathKey struct{ ... }
     func NewGlobalExtendPath(path []string) GlobalPathKey
 type HierarchicalExprs map[project.Path]*ExprSet
     func LoadExprs(tree *config.Tree) (HierarchicalExprs, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-globals.ignore"
 }
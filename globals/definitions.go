@@ -0,0 +1,136 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package globals
+
+import (
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/project"
+)
+
+// Definition is the provenance of a global.
+type Definition struct {
+	// Path is the global accessor path (labels + attribute name).
+	Path []string
+
+	// Origin is where the effective definition of the global is.
+	Origin info.Range
+
+	// Expr is the expression of the effective definition.
+	Expr hhcl.Expression
+
+	// Overrides are the definitions of the same global in parent directories
+	// which were overridden by the effective definition, from the closest to
+	// the farthest directory.
+	Overrides []info.Range
+}
+
+// Definitions returns the provenance of all the globals attributes, sorted by
+// the global path. Object extensions from labeled globals blocks without
+// attributes are not included.
+func (dirExprs HierarchicalExprs) Definitions() []Definition {
+	defs := map[GlobalPathKey]*Definition{}
+	sets := dirExprs.sort()
+	for i := len(sets) - 1; i >= 0; i-- {
+		for key, expr := range sets[i].expressions {
+			if !key.isattr {
+				continue
+			}
+			def, ok := defs[key]
+			if !ok {
+				defs[key] = &Definition{
+					Path:   key.Path(),
+					Origin: expr.Origin,
+					Expr:   expr.Expression,
+				}
+				continue
+			}
+			def.Overrides = append(def.Overrides, expr.Origin)
+		}
+	}
+
+	res := make([]Definition, 0, len(defs))
+	for _, def := range defs {
+		res = append(res, *def)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return strings.Join(res[i].Path, ".") < strings.Join(res[j].Path, ".")
+	})
+	return res
+}
+
+// Trace is a definition in the override chain of a global.
+type Trace struct {
+	// Dir is the configuration directory of the definition.
+	Dir project.Path
+
+	// Path is the accessor path of the definition, which is the traced
+	// global itself, one of its parent objects or one of its nested values.
+	Path []string
+
+	// Labels are the labels of the globals block of the definition.
+	Labels []string
+
+	// Extend tells if the definition is a labeled globals block without
+	// attributes, which only extends the object at Path.
+	Extend bool
+
+	// Origin is where the definition is.
+	Origin info.Range
+}
+
+// Trace returns all the definitions setting or extending the global at the
+// given accessor path, its parent objects or its nested values, from the
+// root directory to the most specific directory. The definitions of the same
+// directory are sorted by their origin.
+func (dirExprs HierarchicalExprs) Trace(path []string) []Trace {
+	var res []Trace
+	for _, set := range dirExprs.sort() {
+		var traces []Trace
+		for key, expr := range set.expressions {
+			keyPath := key.Path()
+			if !hasPathPrefix(path, keyPath) && !hasPathPrefix(keyPath, path) {
+				continue
+			}
+			labels := keyPath
+			if key.isattr {
+				labels = keyPath[:len(keyPath)-1]
+			}
+			traces = append(traces, Trace{
+				Dir:    set.origin,
+				Path:   keyPath,
+				Labels: labels,
+				Extend: !key.isattr,
+				Origin: expr.Origin,
+			})
+		}
+		sort.Slice(traces, func(i, j int) bool {
+			a, b := traces[i].Origin, traces[j].Origin
+			if a.Path() != b.Path() {
+				return a.Path().String() < b.Path().String()
+			}
+			if a.Start().Line() != b.Start().Line() {
+				return a.Start().Line() < b.Start().Line()
+			}
+			return a.Start().Column() < b.Start().Column()
+		})
+		res = append(res, traces...)
+	}
+	return res
+}
+
+func hasPathPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
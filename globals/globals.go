@@ -14,6 +14,7 @@ import (
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl"
 	"github.com/terramate-io/terramate/mapexpr"
+	"github.com/terramate-io/terramate/stdlib"
 
 	"github.com/terramate-io/terramate/hcl/eval"
 	"github.com/terramate-io/terramate/hcl/info"
@@ -23,8 +24,9 @@ import (
 
 // Errors returned when parsing and evaluating globals.
 const (
-	ErrEval      errors.Kind = "global eval"
-	ErrRedefined errors.Kind = "global redefined"
+	ErrEval       errors.Kind = "global eval"
+	ErrRedefined  errors.Kind = "global redefined"
+	ErrDeprecated errors.Kind = "invalid global deprecation"
 )
 
 type (
@@ -40,6 +42,10 @@ type (
 		// be assigned into.
 		LabelPath eval.ObjectPath
 
+		// Sensitive is the expression of the sensitive attribute of the
+		// global block, if defined.
+		Sensitive hhcl.Expression
+
 		hhcl.Expression
 	}
 
@@ -86,6 +92,7 @@ func ForDir(root *config.Root, cfgdir project.Path, ctx *eval.Context) EvalRepor
 		return report
 	}
 
+	ctx.SetDefinitionDir("global", cfgdir)
 	return exprs.Eval(ctx)
 }
 
@@ -145,10 +152,24 @@ func LoadExprs(tree *config.Tree) (HierarchicalExprs, error) {
 			if _, ok := block.Attributes[varName]; ok {
 				return HierarchicalExprs{}, errors.E(
 					ErrRedefined,
-					"map label %s conflicts with global.%s attribute", varName, varName)
+					"%s label %s conflicts with global.%s attribute", varsBlock.Type, varName, varName)
 			}
 
 			key := NewGlobalAttrPath(block.Labels, varName)
+			if varsBlock.Type == "global" {
+				var sensitive hhcl.Expression
+				if attr, ok := varsBlock.Attributes["sensitive"]; ok {
+					sensitive = attr.Expr
+				}
+				exprs.expressions[key] = Expr{
+					Origin:     varsBlock.RawOrigins[0].Range,
+					ConfigDir:  tree.Dir(),
+					LabelPath:  key.Path(),
+					Sensitive:  sensitive,
+					Expression: varsBlock.Attributes["value"].Expr,
+				}
+				continue
+			}
 			expr, err := mapexpr.NewMapExpr(varsBlock)
 			if err != nil {
 				return HierarchicalExprs{}, errors.E(err, "failed to interpret map block")
@@ -442,6 +463,16 @@ func (dirExprs HierarchicalExprs) Eval(ctx *eval.Context) EvalReport {
 						ErrEval, err, "global.%s (%t)", accessor.rootname(), accessor.isattr))
 					continue
 				}
+				deprecation, err := deprecationMessage(ctx, expr)
+				if err != nil {
+					pendingExprsErrs[accessor].Append(err)
+					continue
+				}
+				sensitive, err := isSensitive(ctx, expr)
+				if err != nil {
+					pendingExprsErrs[accessor].Append(err)
+					continue
+				}
 				if hasOldValue && oldValue.IsObject() && !accessor.isattr {
 					// all the `attr = expr` inside global blocks become an entry
 					// in the globalExprs map but we have the special case that
@@ -460,8 +491,10 @@ func (dirExprs HierarchicalExprs) Eval(ctx *eval.Context) EvalReport {
 
 					err := setGlobal(globals, accessor, eval.NewValue(val,
 						eval.Info{
-							DefinedAt: expr.Origin.Path(),
-							Dir:       sortedGlobals.origin,
+							DefinedAt:  expr.Origin.Path(),
+							Dir:        sortedGlobals.origin,
+							Deprecated: deprecation,
+							Sensitive:  sensitive,
 						},
 					))
 
@@ -469,6 +502,12 @@ func (dirExprs HierarchicalExprs) Eval(ctx *eval.Context) EvalReport {
 						pendingExprsErrs[accessor].Append(errors.E(err, "setting global"))
 						continue
 					}
+					if deprecation != "" {
+						ctx.Deprecate("global", accessor.Path(), deprecation)
+					}
+					if sensitive {
+						ctx.MarkSensitive("global", accessor.Path())
+					}
 				}
 
 				amountEvaluated++
@@ -499,6 +538,44 @@ func (dirExprs HierarchicalExprs) Eval(ctx *eval.Context) EvalReport {
 	return report
 }
 
+// deprecationMessage returns the deprecation message of the global if its
+// expression is a tm_deprecated(value, message) call.
+func deprecationMessage(ctx *eval.Context, expr Expr) (string, error) {
+	call, ok := expr.Expression.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != stdlib.Name("deprecated") || len(call.Args) != 2 {
+		return "", nil
+	}
+	msg, err := ctx.Eval(call.Args[1])
+	if err != nil {
+		return "", errors.E(ErrDeprecated, err)
+	}
+	if msg.Type() != cty.String || msg.IsNull() || !msg.IsKnown() || msg.AsString() == "" {
+		return "", errors.E(ErrDeprecated, call.Args[1].Range(),
+			"deprecation message must be a non-empty string")
+	}
+	return msg.AsString(), nil
+}
+
+// isSensitive tells if the global is sensitive, which is the case if it's
+// marked with sensitive = true or if it references any sensitive global.
+func isSensitive(ctx *eval.Context, expr Expr) (bool, error) {
+	if ctx.IsSensitive(expr.Expression) {
+		return true, nil
+	}
+	if expr.Sensitive == nil {
+		return false, nil
+	}
+	val, err := ctx.Eval(expr.Sensitive)
+	if err != nil {
+		return false, errors.E(ErrEval, err, "evaluating global.sensitive")
+	}
+	if val.Type() != cty.Bool || val.IsNull() || !val.IsKnown() {
+		return false, errors.E(ErrEval, expr.Sensitive.Range(),
+			"global.sensitive must be a bool but %s given", val.Type().FriendlyName())
+	}
+	return val.True(), nil
+}
+
 func (dirExprs HierarchicalExprs) merge(other HierarchicalExprs) {
 	for k, v := range other {
 		if _, ok := dirExprs[k]; !ok {
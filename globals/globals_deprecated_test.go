@@ -0,0 +1,88 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package globals_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/hclwrite"
+	. "github.com/terramate-io/terramate/test/hclwrite/hclutils"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGlobalsDeprecated(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []testcase{
+		{
+			name:   "deprecated global evaluates to its value",
+			layout: []string{"s:stack"},
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Globals(
+						Expr("old", `tm_deprecated("value", "use global.new")`),
+						Str("new", "value"),
+						Expr("ref", `"${global.old}-ref"`),
+					),
+				},
+			},
+			want: map[string]*hclwrite.Block{
+				"/stack": Globals(
+					Str("old", "value"),
+					Str("new", "value"),
+					Str("ref", "value-ref"),
+				),
+			},
+		},
+		{
+			name:   "deprecation message must be non-empty",
+			layout: []string{"s:stack"},
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Globals(
+						Expr("old", `tm_deprecated("value", "")`),
+					),
+				},
+			},
+			wantErr: errors.E(globals.ErrDeprecated),
+		},
+	} {
+		testGlobals(t, tc)
+	}
+}
+
+func TestGlobalsDeprecatedInfo(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals "obj" {
+  old = tm_deprecated(1, "use global.obj.new")
+  new = 1
+}
+`,
+	})
+
+	st, err := config.LoadStack(s.Config(), project.NewPath("/stack"))
+	assert.NoError(t, err)
+
+	report := globals.ForStack(s.Config(), st)
+	assert.NoError(t, report.AsError())
+
+	oldGlobal, ok := report.Globals.GetKeyPath([]string{"obj", "old"})
+	assert.IsTrue(t, ok)
+	assert.EqualStrings(t, "use global.obj.new", oldGlobal.Info().Deprecated)
+
+	newGlobal, ok := report.Globals.GetKeyPath([]string{"obj", "new"})
+	assert.IsTrue(t, ok)
+	assert.EqualStrings(t, "", newGlobal.Info().Deprecated)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package globals_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/globals"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGlobalsSensitive(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals "db" {
+  user = "admin"
+
+  global "password" {
+    value     = "s3cr3t"
+    sensitive = true
+  }
+
+  global "host" {
+    value = "localhost"
+  }
+}
+
+globals {
+  dsn = "${global.db.user}:${global.db.password}@${global.db.host}"
+  all = global.db
+}
+`,
+	})
+
+	st, err := config.LoadStack(s.Config(), project.NewPath("/stack"))
+	assert.NoError(t, err)
+
+	report := globals.ForStack(s.Config(), st)
+	assert.NoError(t, report.AsError())
+
+	for _, tc := range []struct {
+		path      []string
+		sensitive bool
+	}{
+		{path: []string{"db", "user"}},
+		{path: []string{"db", "host"}},
+		{path: []string{"db", "password"}, sensitive: true},
+		{path: []string{"dsn"}, sensitive: true},
+		{path: []string{"all"}, sensitive: true},
+	} {
+		val, ok := report.Globals.GetKeyPath(tc.path)
+		assert.IsTrue(t, ok, "global %v not found", tc.path)
+		if val.Info().Sensitive != tc.sensitive {
+			t.Errorf("global %v: want sensitive=%t but got %t", tc.path, tc.sensitive, val.Info().Sensitive)
+		}
+	}
+
+	password, _ := report.Globals.GetKeyPath([]string{"db", "password"})
+	assert.EqualStrings(t, "s3cr3t", password.(eval.CtyValue).Raw().AsString())
+
+	want := []string{"localhost", "s3cr3t", "admin", "s3cr3t", "admin:s3cr3t@localhost"}
+	got := report.Globals.SensitiveStrings()
+	assert.EqualInts(t, len(want), len(got), "sensitive strings: %v", got)
+	for i := range want {
+		assert.EqualStrings(t, want[i], got[i])
+	}
+}
+
+func TestGlobalsSensitiveMustBeBool(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stack",
+		`f:globals.tm:globals {
+  global "password" {
+    value     = "s3cr3t"
+    sensitive = "yes"
+  }
+}
+`,
+	})
+
+	st, err := config.LoadStack(s.Config(), project.NewPath("/stack"))
+	assert.NoError(t, err)
+
+	report := globals.ForStack(s.Config(), st)
+	assert.IsTrue(t, errors.IsKind(report.AsError(), globals.ErrEval))
+}
+
+func TestGlobalsGlobalBlockSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []string{
+		`global "password" {
+  sensitive = true
+}`,
+		`global "password" {
+  value = 1
+  other = true
+}`,
+		`global {
+  value = 1
+}`,
+	} {
+		s := sandbox.NoGit(t, true)
+		s.BuildTree([]string{"s:stack"})
+		s.RootEntry().CreateFile("globals.tm", "globals {\n"+body+"\n}\n")
+		_, err := config.LoadRoot(s.RootDir(), false)
+		assert.IsTrue(t, errors.IsKind(err, hcl.ErrTerramateSchema), "unexpected error: %v", err)
+	}
+}
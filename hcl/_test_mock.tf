@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "hcl" {
-  content = <<-EOT
+  content = <<-EOF
 package hcl // import "github.com/terramate-io/terramate/hcl"
 
 Package hcl provides parsing functionality for Terramate HCL configuration.
@@ -66,7 +66,7 @@ type TerramateParser struct{ ... }
     func NewStrictTerramateParser(rootdir string, dir string, experiments ...string) (*TerramateParser, error)
     func NewTerramateParser(rootdir string, dir string, experiments ...string) (*TerramateParser, error)
 type VendorConfig struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-hcl.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "ast" {
-  content = <<-EOT
+  content = <<-EOF
 package ast // import "github.com/terramate-io/terramate/hcl/ast"
 
 Package ast provides low level parsing facilities for HCL configuration.
@@ -31,7 +31,7 @@ type MergedBlock struct{ ... }
     func NewMergedBlock(typ string, labels []string) *MergedBlock
 type MergedBlocks map[string]*MergedBlock
 type MergedLabelBlocks map[LabelBlockType]*MergedBlock
-EOT
+EOF
 
   filename = "${path.module}/mock-ast.ignore"
 }
@@ -4,8 +4,10 @@
 package ast
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"os"
 
 	"github.com/terramate-io/hcl/v2"
 	"github.com/terramate-io/hcl/v2/hclsyntax"
@@ -22,22 +24,61 @@ func ParseExpression(str string, filename string) (hcl.Expression, error) {
 	return expr, nil
 }
 
+// SourceReader returns the original source code of the given file, or nil if
+// it is not available.
+type SourceReader func(filename string) []byte
+
 // TokensForExpression generates valid tokens for the given expression.
 func TokensForExpression(expr hcl.Expression) hclwrite.Tokens {
-	tokens := tokensForExpression(expr)
+	return TokensForExpressionWithSource(expr, nil)
+}
+
+// TokensForExpressionWithSource generates valid tokens for the given expression.
+// The source reader is used to recover syntax details not retained in the
+// expression, like the delimiters and indent marker of heredocs, from the
+// original source code.
+func TokensForExpressionWithSource(expr hcl.Expression, source SourceReader) hclwrite.Tokens {
+	builder := tokenBuilder{source: source}
+	tokens := builder.tokensFor(expr)
 	tokens[0].SpacesBefore = 0
 	tokens = append(tokens, eof())
 	return tokens
 }
 
+// NewFileSourceReader returns a SourceReader which reads the files from the
+// file system, caching their contents.
+func NewFileSourceReader() SourceReader {
+	files := map[string][]byte{}
+	return func(filename string) []byte {
+		if src, ok := files[filename]; ok {
+			return src
+		}
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			// not a file on disk, the defaults are used.
+			src = nil
+		}
+		files[filename] = src
+		return src
+	}
+}
+
 func tokensForExpression(expr hcl.Expression) hclwrite.Tokens {
 	builder := tokenBuilder{}
-	builder.fromExpr(expr)
-	return builder.tokens
+	return builder.tokensFor(expr)
 }
 
 type tokenBuilder struct {
 	tokens hclwrite.Tokens
+	source SourceReader
+}
+
+// tokensFor generates the tokens for the given expression using a new builder
+// sharing the same source reader.
+func (builder *tokenBuilder) tokensFor(expr hcl.Expression) hclwrite.Tokens {
+	sub := tokenBuilder{source: builder.source}
+	sub.fromExpr(expr)
+	return sub.tokens
 }
 
 func (builder *tokenBuilder) add(tokens ...*hclwrite.Token) {
@@ -109,7 +150,7 @@ func (builder *tokenBuilder) templateTokens(tmpl *hclsyntax.TemplateExpr) {
 	begin := len(builder.tokens)
 	builder.add(oquote())
 	for _, part := range tmpl.Parts {
-		tokens := tokensForExpression(part)
+		tokens := builder.tokensFor(part)
 		if tokens[0].Type != hclsyntax.TokenOQuote {
 			addInterp := !canMergeTemplate(tokens)
 			if addInterp {
@@ -171,13 +212,14 @@ func (builder *tokenBuilder) templateTokens(tmpl *hclsyntax.TemplateExpr) {
 	last := builder.tokens[len(builder.tokens)-1]
 	if last.Type == hclsyntax.TokenStringLit &&
 		isHeredoc(last.Bytes) {
-		builder.tokens[begin] = oheredoc()
 		for _, tok := range builder.tokens[begin+1:] {
 			if tok.Type == hclsyntax.TokenStringLit {
 				tok.Bytes = renderString(tok.Bytes)
 			}
 		}
-		builder.add(cheredoc())
+		marker, flush := builder.heredocDelimiter(tmpl.SrcRange, builder.tokens[begin+1:])
+		builder.tokens[begin] = oheredocWith(marker, flush)
+		builder.add(cheredocWith(marker))
 	} else {
 		builder.add(cquote())
 	}
@@ -322,7 +364,7 @@ func (builder *tokenBuilder) forExprTokens(forExpr *hclsyntax.ForExpr) {
 		builder.add(ident(forExpr.ValVar, 1))
 	}
 	builder.add(ident("in", 1))
-	in := tokensForExpression(forExpr.CollExpr)
+	in := builder.tokensFor(forExpr.CollExpr)
 	in[0].SpacesBefore = 1
 	builder.add(in...)
 	builder.add(colon())
@@ -394,6 +436,41 @@ func (builder *tokenBuilder) anonSplatTokens(_ *hclsyntax.AnonSymbolExpr) {
 	// and should generate nothing?
 }
 
+// heredocDelimiter returns the heredoc delimiter and if it is an indented
+// heredoc (<<-) for the template at the given range. The original delimiter is
+// used if the template was a heredoc in the source code and its delimiter does
+// not conflict with the given content.
+func (builder *tokenBuilder) heredocDelimiter(rng hcl.Range, content hclwrite.Tokens) (string, bool) {
+	const (
+		defaultMarker = "EOT"
+		defaultFlush  = true
+	)
+	if builder.source == nil {
+		return defaultMarker, defaultFlush
+	}
+	src := builder.source(rng.Filename)
+	if rng.Start.Byte < 0 || rng.Start.Byte >= len(src) ||
+		!bytes.HasPrefix(src[rng.Start.Byte:], []byte("<<")) {
+		return defaultMarker, defaultFlush
+	}
+	opener := src[rng.Start.Byte+2:]
+	if nl := bytes.IndexByte(opener, '\n'); nl >= 0 {
+		opener = opener[:nl]
+	}
+	opener = bytes.TrimSuffix(opener, []byte("\r"))
+	flush := bytes.HasPrefix(opener, []byte("-"))
+	marker := string(bytes.TrimPrefix(opener, []byte("-")))
+	if !hclsyntax.ValidIdentifier(marker) {
+		return defaultMarker, defaultFlush
+	}
+	for _, line := range bytes.Split(content.Bytes(), []byte("\n")) {
+		if string(bytes.TrimSpace(line)) == marker {
+			return defaultMarker, defaultFlush
+		}
+	}
+	return marker, flush
+}
+
 // isHeredoc checks if the bytes can be represented as a heredoc string.
 // A valid heredoc must end with a newline and should only have printable
 // characters. (\r and \u sequences from the non-printable range).
@@ -715,17 +792,21 @@ func cquote() *hclwrite.Token {
 	}
 }
 
-func oheredoc() *hclwrite.Token {
+func oheredocWith(marker string, flush bool) *hclwrite.Token {
+	opener := "<<"
+	if flush {
+		opener += "-"
+	}
 	return &hclwrite.Token{
 		Type:  hclsyntax.TokenOHeredoc,
-		Bytes: []byte("<<-EOT\n"),
+		Bytes: []byte(opener + marker + "\n"),
 	}
 }
 
-func cheredoc() *hclwrite.Token {
+func cheredocWith(marker string) *hclwrite.Token {
 	return &hclwrite.Token{
 		Type:  hclsyntax.TokenCHeredoc,
-		Bytes: []byte("EOT\n"),
+		Bytes: []byte(marker + "\n"),
 	}
 }
 
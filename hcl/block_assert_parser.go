@@ -51,6 +51,10 @@ func (a *AssertBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 			cfg.Message = attr.Expr
 		case "warning":
 			cfg.Warning = attr.Expr
+		case "code":
+			cfg.Code = attr.Expr
+		case "docs_url":
+			cfg.DocsURL = attr.Expr
 		default:
 			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
 				"unrecognized attribute %s.%s", block.Type, attr.Name,
@@ -23,6 +23,11 @@ func (*GenerateFileBlockParser) Name() string {
 	return "generate_file"
 }
 
+// schema returns the schema of the generate_file block.
+func (*GenerateFileBlockParser) schema() *blockSchema {
+	return generateFileBlockSchema
+}
+
 // Parse parses the "generate_file" block.
 func (*GenerateFileBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 	err := validateGenerateFileBlock(block)
@@ -92,6 +97,40 @@ func (*GenerateFileBlockParser) Parse(p *TerramateParser, block *ast.Block) erro
 		))
 	}
 
+	visibility, err := parseGenerateVisibility(block)
+	errs.Append(err)
+	if attr, ok := block.Body.Attributes["visibility"]; ok && context == "root" {
+		errs.Append(errors.E(ErrTerramateSchema,
+			attr.Range(),
+			`visibility attribute cannot be used with context=root`,
+		))
+	}
+
+	content := block.Body.Attributes["content"]
+	outputs := block.Body.Attributes["outputs"]
+	switch {
+	case content == nil && outputs == nil:
+		errs.Append(errors.E(ErrTerramateSchema, block.Range,
+			"generate_file must have either a content or an outputs attribute"))
+	case content != nil && outputs != nil:
+		errs.Append(errors.E(ErrTerramateSchema, outputs.Range(),
+			"generate_file content and outputs attributes are mutually exclusive"))
+	case outputs != nil && context != "stack":
+		errs.Append(errors.E(ErrTerramateSchema, outputs.Range(),
+			"outputs is only supported with context = \"stack\""))
+	}
+
+	forEachStack := block.Body.Attributes["for_each_stack"]
+	if forEachStack != nil && context != "root" {
+		errs.Append(errors.E(ErrTerramateSchema,
+			forEachStack.Range(),
+			`for_each_stack attribute can only be used with context=root`,
+		))
+	}
+
+	output, err := parseGenerateOutputBlockAttrs(block)
+	errs.Append(err)
+
 	if err := errs.AsError(); err != nil {
 		return err
 	}
@@ -108,10 +147,14 @@ func (*GenerateFileBlockParser) Parse(p *TerramateParser, block *ast.Block) erro
 		Lets:         lets,
 		Asserts:      asserts,
 		StackFilters: stackFilters,
-		Content:      block.Body.Attributes["content"],
+		Content:      content,
+		Outputs:      outputs,
 		Condition:    block.Body.Attributes["condition"],
 		Inherit:      inherit,
+		Visibility:   visibility,
+		ForEachStack: forEachStack,
 		Context:      context,
+		Output:       output,
 	})
 	return nil
 }
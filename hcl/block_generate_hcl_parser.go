@@ -8,6 +8,7 @@ import (
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // GenerateHCLBlockParser is the parser for the "generate_hcl" block.
@@ -23,6 +24,11 @@ func (*GenerateHCLBlockParser) Name() string {
 	return "generate_hcl"
 }
 
+// schema returns the schema of the generate_hcl block.
+func (*GenerateHCLBlockParser) schema() *blockSchema {
+	return generateHCLBlockSchema
+}
+
 // Parse parses the "generate_hcl" block.
 func (*GenerateHCLBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 	var (
@@ -84,6 +90,42 @@ func (*GenerateHCLBlockParser) Parse(p *TerramateParser, block *ast.Block) error
 		}
 	}
 
+	output, err := parseGenerateOutputBlockAttrs(block)
+	errs.Append(err)
+
+	visibility, err := parseGenerateVisibility(block)
+	errs.Append(err)
+
+	skipFormat := false
+	if attr, ok := block.Body.Attributes["format"]; ok {
+		value, diags := attr.Expr.Value(nil)
+		switch {
+		case diags.HasErrors():
+			errs.Append(errors.E(ErrTerramateSchema, diags,
+				"failed to evaluate generate_hcl.format attribute"))
+		case value.Type() != cty.Bool || value.IsNull():
+			errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+				"generate_hcl.format is not a bool but %q", value.Type().FriendlyName()))
+		default:
+			skipFormat = value.False()
+		}
+	}
+
+	noHeader := false
+	if attr, ok := block.Body.Attributes["no_header"]; ok {
+		value, diags := attr.Expr.Value(nil)
+		switch {
+		case diags.HasErrors():
+			errs.Append(errors.E(ErrTerramateSchema, diags,
+				"failed to evaluate generate_hcl.no_header attribute"))
+		case value.Type() != cty.Bool || value.IsNull():
+			errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+				"generate_hcl.no_header is not a bool but %q", value.Type().FriendlyName()))
+		default:
+			noHeader = value.True()
+		}
+	}
+
 	if err := errs.AsError(); err != nil {
 		return err
 	}
@@ -102,7 +144,11 @@ func (*GenerateHCLBlockParser) Parse(p *TerramateParser, block *ast.Block) error
 		Content:      content.AsHCLBlock(),
 		Condition:    block.Body.Attributes["condition"],
 		Inherit:      block.Body.Attributes["inherit"],
+		Visibility:   visibility,
 		StackFilters: stackFilters,
+		Output:       output,
+		SkipFormat:   skipFormat,
+		NoHeader:     noHeader,
 	}
 	p.ParsedConfig.Generate.HCLs = append(p.ParsedConfig.Generate.HCLs, genblock)
 	return nil
@@ -0,0 +1,71 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/stdlib"
+)
+
+// GenerateYAMLFormat is the format of the files generated by the
+// "generate_yaml" blocks.
+const GenerateYAMLFormat = "yaml"
+
+// GenerateYAMLBlockParser is the parser for the "generate_yaml" block.
+type GenerateYAMLBlockParser struct{}
+
+// NewGenerateYAMLBlockParser returns a new parser specification for the "generate_yaml" block.
+func NewGenerateYAMLBlockParser() *GenerateYAMLBlockParser {
+	return &GenerateYAMLBlockParser{}
+}
+
+// Name returns the type of the block.
+func (*GenerateYAMLBlockParser) Name() string {
+	return "generate_yaml"
+}
+
+// schema returns the schema of the generate_yaml block.
+func (*GenerateYAMLBlockParser) schema() *blockSchema {
+	return generateFileBlockSchema
+}
+
+// Parse parses the "generate_yaml" block. The block has the same schema of
+// the "generate_file" block with context = "stack", but its content is a
+// value encoded as YAML, or a list of values encoded as multiple documents.
+func (*GenerateYAMLBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
+	if !p.hasExperimentalFeature(stdlib.K8sExperimentName) {
+		return errors.E(ErrTerramateSchema, block.DefRange(),
+			experiments.NotEnabled(stdlib.K8sExperimentName, fmt.Sprintf("block %q", block.Type)))
+	}
+
+	errs := errors.L()
+	if len(block.Labels) != 1 || block.Labels[0] == "" {
+		errs.Append(errors.E(ErrTerramateSchema, block.OpenBraceRange,
+			"generate_yaml must have a single non-empty label instead got %v", block.Labels))
+	}
+	if block.Body.Attributes["content"] == nil {
+		errs.Append(errors.E(ErrTerramateSchema, block.Range,
+			"generate_yaml must have a content attribute"))
+	}
+	for _, name := range []string{"outputs", "context", "for_each_stack"} {
+		if attr, ok := block.Body.Attributes[name]; ok {
+			errs.Append(errors.E(ErrTerramateSchema, attr.Range(),
+				"generate_yaml does not support the %s attribute", name))
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		return err
+	}
+
+	if err := NewGenerateFileBlockParser().Parse(p, block); err != nil {
+		return err
+	}
+	files := p.ParsedConfig.Generate.Files
+	files[len(files)-1].Format = GenerateYAMLFormat
+	return nil
+}
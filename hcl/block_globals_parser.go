@@ -4,6 +4,7 @@
 package hcl
 
 import (
+	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl/ast"
 )
@@ -21,6 +22,11 @@ func (g *GlobalsBlockParser) Name() string {
 	return "globals"
 }
 
+// schema returns the schema of the globals block.
+func (*GlobalsBlockParser) schema() *blockSchema {
+	return globalsBlockSchema
+}
+
 // Parse parses the globals block.
 func (g *GlobalsBlockParser) Parse(p *TerramateParser, label ast.LabelBlockType, block *ast.MergedBlock) error {
 	if p.ParsedConfig.Globals == nil {
@@ -41,11 +47,47 @@ func validateGlobals(block *ast.MergedBlock) error {
 		return errors.E(ErrTerramateSchema,
 			block.RawOrigins[0].TypeRange, "unexpected block type %q", block.Type)
 	}
-	errs.Append(block.ValidateSubBlocks("map"))
+	errs.Append(block.ValidateSubBlocks(globalsBlockSchema.blockTypes()...))
 	for _, raw := range block.RawOrigins {
 		for _, subBlock := range raw.Blocks {
-			errs.Append(validateMap(subBlock))
+			switch subBlock.Type {
+			case "map":
+				errs.Append(validateMap(subBlock))
+			case "global":
+				errs.Append(validateGlobal(subBlock))
+			}
 		}
 	}
 	return errs.AsError()
 }
+
+// validateGlobal validates the global block, which defines a single global
+// with the given value and options, eg.:
+//
+//	global "password" {
+//	  value     = env.PASSWORD
+//	  sensitive = true
+//	}
+func validateGlobal(block *ast.Block) error {
+	errs := errors.L()
+	if len(block.Labels) != 1 || !hclsyntax.ValidIdentifier(block.Labels[0]) {
+		errs.Append(errors.E(block.DefRange(),
+			"global block requires a single label with a valid identifier"))
+	}
+	if _, ok := block.Attributes["value"]; !ok {
+		errs.Append(errors.E(block.TypeRange, "global.value attribute is required"))
+	}
+	for _, attr := range block.Attributes.SortedList() {
+		switch attr.Name {
+		case "value", "sensitive":
+		default:
+			errs.Append(errors.E(attr.NameRange,
+				"unrecognized attribute global.%s", attr.Name))
+		}
+	}
+	for _, subBlock := range block.Blocks {
+		errs.Append(errors.E(subBlock.TypeRange,
+			"unrecognized block %s inside global block", subBlock.Type))
+	}
+	return errs.AsError()
+}
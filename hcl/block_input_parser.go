@@ -4,7 +4,10 @@
 package hcl
 
 import (
+	"fmt"
+
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/hcl/ast"
 )
 
@@ -21,11 +24,16 @@ func (i *InputBlockParser) Name() string {
 	return "input"
 }
 
+// schema returns the schema of the input block.
+func (*InputBlockParser) schema() *blockSchema {
+	return labeledBlockSchema
+}
+
 // Parse parses the "input" block.
 func (i *InputBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 	if !p.hasExperimentalFeature(SharingIsCaringExperimentName) {
 		return errors.E(ErrTerramateSchema, block.DefRange(),
-			"unrecognized block %q (sharing-is-caring is an experimental feature, it must be enabled before usage with `terramate.config.experiments = [%q]`)", block.Type, SharingIsCaringExperimentName)
+			experiments.NotEnabled(SharingIsCaringExperimentName, fmt.Sprintf("block %q", block.Type)))
 	}
 	input := Input{
 		Range: block.Range,
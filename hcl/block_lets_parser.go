@@ -0,0 +1,131 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"sort"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/ast"
+)
+
+// FileLetsBlockParser is the parser for the top-level "lets" block.
+// The lets declared at the file scope are visible to all the generate blocks
+// declared in the same file.
+type FileLetsBlockParser struct{}
+
+// NewFileLetsBlockParser returns a new parser specification for the
+// top-level "lets" block.
+func NewFileLetsBlockParser() UnmergedBlockHandler {
+	return &FileLetsBlockParser{}
+}
+
+// Name returns the type of the block.
+func (*FileLetsBlockParser) Name() string {
+	return "lets"
+}
+
+// schema returns the schema of the lets block.
+func (*FileLetsBlockParser) schema() *blockSchema {
+	return letsBlockSchema()
+}
+
+// Parse parses the top-level "lets" block.
+func (*FileLetsBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
+	if err := checkNoLabels(block); err != nil {
+		return err
+	}
+
+	cfg := &p.ParsedConfig
+	if cfg.fileLets == nil {
+		cfg.fileLets = map[string]*ast.MergedBlock{}
+	}
+	file := block.Range.HostPath()
+	lets, ok := cfg.fileLets[file]
+	if !ok {
+		lets = ast.NewMergedBlock("lets", []string{})
+		cfg.fileLets[file] = lets
+	}
+	if err := lets.MergeBlock(block, true); err != nil {
+		return errors.E(ErrTerramateSchema, err)
+	}
+	return nil
+}
+
+// applyFileLets adds the lets declared at the file scope to the lets of the
+// generate blocks declared in the same file. The lets of the generate block
+// take precedence over the ones of the file.
+func (p *TerramateParser) applyFileLets() error {
+	cfg := &p.ParsedConfig
+	if len(cfg.fileLets) == 0 {
+		return nil
+	}
+
+	files := make([]string, 0, len(cfg.fileLets))
+	for file := range cfg.fileLets {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	errs := errors.L()
+	for _, file := range files {
+		errs.AppendWrap(ErrTerramateSchema, validateLets(cfg.fileLets[file]))
+	}
+	if err := errs.AsError(); err != nil {
+		return err
+	}
+
+	for i := range cfg.Generate.HCLs {
+		block := &cfg.Generate.HCLs[i]
+		block.Lets = withFileLets(cfg.fileLets[block.Range.HostPath()], block.Lets)
+	}
+	for i := range cfg.Generate.Files {
+		block := &cfg.Generate.Files[i]
+		block.Lets = withFileLets(cfg.fileLets[block.Range.HostPath()], block.Lets)
+	}
+	return nil
+}
+
+// withFileLets returns the lets of a generate block merged with the lets of
+// its file, if any.
+func withFileLets(fileLets, blockLets *ast.MergedBlock) *ast.MergedBlock {
+	if fileLets == nil {
+		return blockLets
+	}
+
+	merged := ast.NewMergedBlock("lets", []string{})
+	add := func(lets *ast.MergedBlock, shadowed func(name string) bool) {
+		merged.RawOrigins = append(merged.RawOrigins, lets.RawOrigins...)
+		for name, attr := range lets.Attributes {
+			if !shadowed(name) {
+				merged.Attributes[name] = attr
+			}
+		}
+		for lb, mapBlock := range lets.Blocks {
+			if !shadowed(mapBlock.Labels[0]) {
+				merged.Blocks[lb] = mapBlock
+				merged.RawBlocks[string(mapBlock.Type)] = append(
+					merged.RawBlocks[string(mapBlock.Type)], mapBlock.RawOrigins...)
+			}
+		}
+	}
+
+	add(fileLets, func(name string) bool { return declaresLet(blockLets, name) })
+	add(blockLets, func(string) bool { return false })
+	return merged
+}
+
+// declaresLet tells if the lets block declares the named let, either as an
+// attribute or as a map block.
+func declaresLet(lets *ast.MergedBlock, name string) bool {
+	if _, ok := lets.Attributes[name]; ok {
+		return true
+	}
+	for _, mapBlock := range lets.Blocks {
+		if mapBlock.Labels[0] == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/info"
+)
+
+// ProvidersExperimentName is the name of the providers experiment.
+const ProvidersExperimentName = experiments.Providers
+
+// ProvidersConfig represents a "providers" block, which declares the
+// provider version constraints generated into the stacks of its directory
+// and sub directories.
+type ProvidersConfig struct {
+	Range info.Range
+
+	// Filename is the name of the generated file, if set.
+	Filename hcl.Expression
+
+	// Environment is the environment of the stack, which selects the
+	// version constraints of the providers, if set.
+	Environment hcl.Expression
+
+	Providers []ProviderConfig
+}
+
+// ProviderConfig represents a "provider" block of a "providers" block.
+type ProviderConfig struct {
+	Range info.Range
+	Name  string
+
+	Source  hcl.Expression
+	Version hcl.Expression
+
+	// Environments is a map of environment to version constraints,
+	// overriding the version of the provider.
+	Environments hcl.Expression
+}
+
+// ProvidersBlockParser is the parser for the "providers" block.
+type ProvidersBlockParser struct{}
+
+// NewProvidersBlockParser returns a new parser specification for the "providers" block.
+func NewProvidersBlockParser() *ProvidersBlockParser {
+	return &ProvidersBlockParser{}
+}
+
+// Name returns the type of the block.
+func (*ProvidersBlockParser) Name() string {
+	return "providers"
+}
+
+// schema returns the schema of the providers block.
+func (*ProvidersBlockParser) schema() *blockSchema {
+	return providersBlockSchema
+}
+
+// Parse parses the "providers" block.
+func (*ProvidersBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
+	if !p.hasExperimentalFeature(ProvidersExperimentName) {
+		return errors.E(ErrTerramateSchema, block.DefRange(),
+			experiments.NotEnabled(ProvidersExperimentName, fmt.Sprintf("block %q", block.Type)))
+	}
+
+	errs := errors.L()
+	if len(block.Labels) != 0 {
+		errs.Append(errors.E(ErrTerramateSchema, block.DefRange(),
+			"providers block must have no labels but %d given", len(block.Labels)))
+	}
+
+	providers := ProvidersConfig{Range: block.Range}
+	for _, attr := range block.Attributes.SortedList() {
+		switch attr.Name {
+		case "filename":
+			providers.Filename = attr.Expr
+		case "environment":
+			providers.Environment = attr.Expr
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange, "unrecognized attribute %s", attr.Name))
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, subblock := range block.Blocks {
+		if subblock.Type != "provider" {
+			errs.Append(errors.E(ErrTerramateSchema, subblock.DefRange(),
+				"unrecognized block %s", subblock.Type))
+			continue
+		}
+		provider, err := parseProviderBlock(subblock)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		if seen[provider.Name] {
+			errs.Append(errors.E(ErrTerramateSchema, subblock.DefRange(),
+				"provider %q is already declared in the same providers block", provider.Name))
+			continue
+		}
+		seen[provider.Name] = true
+		providers.Providers = append(providers.Providers, provider)
+	}
+
+	if err := errs.AsError(); err != nil {
+		return err
+	}
+	p.ParsedConfig.Providers = append(p.ParsedConfig.Providers, providers)
+	return nil
+}
+
+func parseProviderBlock(block *ast.Block) (ProviderConfig, error) {
+	errs := errors.L()
+	provider := ProviderConfig{Range: block.Range}
+	if len(block.Labels) != 1 || block.Labels[0] == "" {
+		errs.Append(errors.E(ErrTerramateSchema, block.DefRange(),
+			"provider block must have a single non-empty label but %d given", len(block.Labels)))
+	} else {
+		provider.Name = block.Labels[0]
+	}
+	for _, attr := range block.Attributes.SortedList() {
+		switch attr.Name {
+		case "source":
+			provider.Source = attr.Expr
+		case "version":
+			provider.Version = attr.Expr
+		case "environments":
+			provider.Environments = attr.Expr
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange, "unrecognized attribute %s", attr.Name))
+		}
+	}
+	for _, subblock := range block.Blocks {
+		errs.Append(errors.E(ErrTerramateSchema, subblock.DefRange(),
+			"unrecognized block %s", subblock.Type))
+	}
+	if err := errs.AsError(); err != nil {
+		return ProviderConfig{}, err
+	}
+	return provider, nil
+}
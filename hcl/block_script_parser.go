@@ -4,14 +4,22 @@
 package hcl
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/terramate-io/hcl/v2/ext/typeexpr"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/zclconf/go-cty/cty"
 	"golang.org/x/exp/slices"
 )
 
+// ScriptsExperimentName is the name of the scripts experiment.
+const ScriptsExperimentName = experiments.Scripts
+
 // Errors returned during the HCL parsing of script block
 const (
 	ErrScriptNoLabels            errors.Kind = "terramate schema error: (script): must provide at least one label"
@@ -24,6 +32,13 @@ const (
 	ErrScriptCmdConflict         errors.Kind = "terramate schema error: (script): conflicting attribute already set"
 )
 
+// ErrScriptApprovalUnrecognizedAttr indicates an unrecognized attribute in the
+// script.job.approval block.
+const ErrScriptApprovalUnrecognizedAttr errors.Kind = "terramate schema error: (script.job.approval): unrecognized attribute"
+
+// ErrScriptInvalidArg indicates an invalid script.arg block.
+const ErrScriptInvalidArg errors.Kind = "terramate schema error: (script.arg): invalid argument"
+
 // ScriptBlockParser is a parser for the "script" block
 type ScriptBlockParser struct{}
 
@@ -37,8 +52,38 @@ type Commands ast.Attribute
 type ScriptJob struct {
 	Name        *ast.Attribute
 	Description *ast.Attribute
-	Command     *Command  // Command is a single executable command
-	Commands    *Commands // Commands is a list of executable commands
+	Command     *Command        // Command is a single executable command
+	Commands    *Commands       // Commands is a list of executable commands
+	Artifacts   *ast.Attribute  // Artifacts are the names of the files produced for later jobs
+	Approval    *ScriptApproval // Approval pauses the script until the next jobs are approved
+
+	// DependsOnFiles are the files, possibly of other stacks, which must be
+	// up to date before the job runs.
+	DependsOnFiles *ast.Attribute
+}
+
+// ScriptApproval represents an approval gate between script jobs.
+type ScriptApproval struct {
+	Range   info.Range
+	Message *ast.Attribute // Message is shown when asking for the approval
+	File    *ast.Attribute // File is the path of an externally provided approval
+}
+
+// ScriptArg represents an argument declared by a script.
+type ScriptArg struct {
+	Range       info.Range
+	Name        string                 // Name of the argument, used as script.args.<name>
+	Type        cty.Type               // Type of the argument, string if not declared
+	Default     *ast.Attribute         // Default makes the argument optional
+	Description *ast.Attribute         // Description is a human readable description of the argument
+	Validations []*ScriptArgValidation // Validations are checked after the arguments are evaluated
+}
+
+// ScriptArgValidation represents a validation rule of a script argument.
+type ScriptArgValidation struct {
+	Range        info.Range
+	Condition    *ast.Attribute // Condition must evaluate to true for valid arguments
+	ErrorMessage *ast.Attribute // ErrorMessage is shown when the condition is false
 }
 
 // Script represents a parsed script block
@@ -47,6 +92,7 @@ type Script struct {
 	Labels      []string         // Labels of the script block used for grouping scripts
 	Name        *ast.Attribute   // Name of the script
 	Description *ast.Attribute   // Description is a human readable description of a script
+	Args        []*ScriptArg     // Args are the arguments accepted by the script
 	Jobs        []*ScriptJob     // Job represents the command(s) part of this script
 	Lets        *ast.MergedBlock // Lets are script local variables.
 }
@@ -91,13 +137,16 @@ func (*ScriptBlockParser) Name() string {
 	return "script"
 }
 
+// schema returns the schema of the script block.
+func (*ScriptBlockParser) schema() *blockSchema {
+	return scriptBlockSchema
+}
+
 // Parse parses the "script" block.
 func (*ScriptBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
-	if !p.hasExperimentalFeature("scripts") {
-		return errors.E(
-			ErrTerramateSchema, block.DefRange(),
-			"unrecognized block %q (script is an experimental feature, it must be enabled before usage with `terramate.config.experiments = [\"scripts\"]`)", block.Type,
-		)
+	if !p.hasExperimentalFeature(ScriptsExperimentName) {
+		return errors.E(ErrTerramateSchema, block.DefRange(),
+			experiments.NotEnabled(ScriptsExperimentName, fmt.Sprintf("block %q", block.Type)))
 	}
 
 	if other, found := findScript(p.ParsedConfig.Scripts, block.Labels); found {
@@ -141,6 +190,18 @@ func (*ScriptBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 			parsedScript.Jobs = append(parsedScript.Jobs, parsedJobBlock)
 		case "lets":
 			errs.AppendWrap(ErrTerramateSchema, letsConfig.mergeBlocks(ast.Blocks{nestedBlock}))
+		case "arg":
+			arg, err := parseScriptArgBlock(nestedBlock)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			if slices.ContainsFunc(parsedScript.Args, func(other *ScriptArg) bool { return other.Name == arg.Name }) {
+				errs.Append(errors.E(ErrScriptInvalidArg, nestedBlock.LabelRanges(),
+					"argument %q declared more than once", arg.Name))
+				continue
+			}
+			parsedScript.Args = append(parsedScript.Args, arg)
 		default:
 			errs.Append(errors.E(ErrScriptUnrecognizedBlock, nestedBlock.TypeRange, nestedBlock.Type))
 		}
@@ -200,13 +261,32 @@ func parseScriptJobBlock(block *ast.Block) (*ScriptJob, error) {
 			parsedScriptJob.Command = NewScriptCommand(attr)
 		case "commands":
 			parsedScriptJob.Commands = NewScriptCommands(attr)
+		case "artifacts":
+			parsedScriptJob.Artifacts = &attr
+		case "depends_on_files":
+			parsedScriptJob.DependsOnFiles = &attr
 		default:
 			errs.Append(errors.E(ErrScriptJobUnrecognizedAttr, attr.NameRange, attr.Name))
 		}
 	}
 
+	hasApproval := false
 	for _, childBlock := range block.Blocks {
-		errs.Append(errors.E(ErrScriptUnrecognizedBlock, childBlock.TypeRange, childBlock.Type))
+		switch {
+		case childBlock.Type == "approval" && !hasApproval:
+			hasApproval = true
+			approval, err := parseScriptApprovalBlock(childBlock)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			parsedScriptJob.Approval = approval
+		case childBlock.Type == "approval":
+			errs.Append(errors.E(ErrScriptCmdConflict, childBlock.TypeRange,
+				"multiple approval blocks in the same job"))
+		default:
+			errs.Append(errors.E(ErrScriptUnrecognizedBlock, childBlock.TypeRange, childBlock.Type))
+		}
 	}
 
 	// job.command and job.commands are mutually exclusive
@@ -215,7 +295,21 @@ func parseScriptJobBlock(block *ast.Block) (*ScriptJob, error) {
 		errs.Append(errors.E(ErrScriptCmdConflict, parsedScriptJob.Commands.NameRange))
 	}
 
-	if parsedScriptJob.Command == nil && parsedScriptJob.Commands == nil {
+	// approval jobs have no commands.
+	if hasApproval {
+		if parsedScriptJob.Command != nil {
+			errs.Append(errors.E(ErrScriptCmdConflict, parsedScriptJob.Command.NameRange,
+				"approval jobs cannot have commands"))
+		}
+		if parsedScriptJob.Commands != nil {
+			errs.Append(errors.E(ErrScriptCmdConflict, parsedScriptJob.Commands.NameRange,
+				"approval jobs cannot have commands"))
+		}
+		if parsedScriptJob.DependsOnFiles != nil {
+			errs.Append(errors.E(ErrScriptCmdConflict, parsedScriptJob.DependsOnFiles.NameRange,
+				"approval jobs cannot depend on files"))
+		}
+	} else if parsedScriptJob.Command == nil && parsedScriptJob.Commands == nil {
 		errs.Append(errors.E(ErrScriptNoCmds, block.Range))
 	}
 
@@ -225,3 +319,116 @@ func parseScriptJobBlock(block *ast.Block) (*ScriptJob, error) {
 
 	return parsedScriptJob, nil
 }
+
+func parseScriptArgBlock(block *ast.Block) (*ScriptArg, error) {
+	if len(block.Labels) != 1 {
+		return nil, errors.E(ErrScriptInvalidArg, block.TypeRange,
+			"arg block must have a single label but got %v", block.Labels)
+	}
+	if !hclsyntax.ValidIdentifier(block.Labels[0]) {
+		return nil, errors.E(ErrScriptInvalidArg, block.LabelRanges(),
+			"arg name %q is not a valid identifier", block.Labels[0])
+	}
+
+	errs := errors.L()
+	arg := &ScriptArg{
+		Range: block.Range,
+		Name:  block.Labels[0],
+		Type:  cty.String,
+	}
+	for _, attr := range block.Attributes {
+		attr := attr
+		switch attr.Name {
+		case "type":
+			typ, diags := typeexpr.TypeConstraint(attr.Expr)
+			if diags.HasErrors() {
+				errs.Append(errors.E(ErrScriptInvalidArg, diags))
+				continue
+			}
+			arg.Type = typ
+		case "default":
+			arg.Default = &attr
+		case "description":
+			arg.Description = &attr
+		default:
+			errs.Append(errors.E(ErrScriptInvalidArg, attr.NameRange,
+				"unrecognized attribute %q", attr.Name))
+		}
+	}
+
+	for _, childBlock := range block.Blocks {
+		if childBlock.Type != "validation" {
+			errs.Append(errors.E(ErrScriptUnrecognizedBlock, childBlock.TypeRange, childBlock.Type))
+			continue
+		}
+		validation, err := parseScriptArgValidationBlock(childBlock)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		arg.Validations = append(arg.Validations, validation)
+	}
+
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	return arg, nil
+}
+
+func parseScriptArgValidationBlock(block *ast.Block) (*ScriptArgValidation, error) {
+	errs := errors.L()
+	validation := &ScriptArgValidation{
+		Range: block.Range,
+	}
+	for _, attr := range block.Attributes {
+		attr := attr
+		switch attr.Name {
+		case "condition":
+			validation.Condition = &attr
+		case "error_message":
+			validation.ErrorMessage = &attr
+		default:
+			errs.Append(errors.E(ErrScriptInvalidArg, attr.NameRange,
+				"unrecognized attribute %q", attr.Name))
+		}
+	}
+	for _, childBlock := range block.Blocks {
+		errs.Append(errors.E(ErrScriptUnrecognizedBlock, childBlock.TypeRange, childBlock.Type))
+	}
+	if validation.Condition == nil {
+		errs.Append(errors.E(ErrScriptInvalidArg, block.Range,
+			"validation block requires a condition attribute"))
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	return validation, nil
+}
+
+func parseScriptApprovalBlock(block *ast.Block) (*ScriptApproval, error) {
+	errs := errors.L()
+
+	approval := &ScriptApproval{
+		Range: block.Range,
+	}
+	for _, attr := range block.Attributes {
+		attr := attr
+		switch attr.Name {
+		case "message":
+			approval.Message = &attr
+		case "file":
+			approval.File = &attr
+		default:
+			errs.Append(errors.E(ErrScriptApprovalUnrecognizedAttr, attr.NameRange, attr.Name))
+		}
+	}
+
+	for _, childBlock := range block.Blocks {
+		errs.Append(errors.E(ErrScriptUnrecognizedBlock, childBlock.TypeRange, childBlock.Type))
+	}
+
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
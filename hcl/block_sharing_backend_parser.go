@@ -4,14 +4,17 @@
 package hcl
 
 import (
+	"fmt"
+
 	"github.com/terramate-io/hcl/v2"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // SharingIsCaringExperimentName is the name of the outputs-sharing experiment.
-const SharingIsCaringExperimentName = "outputs-sharing"
+const SharingIsCaringExperimentName = experiments.OutputsSharing
 
 // SharingBackendBlockParser is the parser for the "sharing_backend" block.
 type SharingBackendBlockParser struct{}
@@ -26,17 +29,16 @@ func (*SharingBackendBlockParser) Name() string {
 	return "sharing_backend"
 }
 
+// schema returns the schema of the sharing_backend block.
+func (*SharingBackendBlockParser) schema() *blockSchema {
+	return labeledBlockSchema
+}
+
 // Parse parses the "outputs_sharing" block.
 func (*SharingBackendBlockParser) Parse(p *TerramateParser, block *ast.Block) error {
 	if !p.hasExperimentalFeature(SharingIsCaringExperimentName) {
-		return errors.E(
-			ErrTerramateSchema,
-			block.DefRange(),
-			"unrecognized block %q (%s is an experimental feature, it must be enabled before usage with `terramate.config.experiments = [%q]`)",
-			block.Type,
-			SharingIsCaringExperimentName,
-			SharingIsCaringExperimentName,
-		)
+		return errors.E(ErrTerramateSchema, block.DefRange(),
+			experiments.NotEnabled(SharingIsCaringExperimentName, fmt.Sprintf("block %q", block.Type)))
 	}
 	shr := SharingBackend{}
 	errs := errors.L()
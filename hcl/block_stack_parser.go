@@ -13,6 +13,11 @@ import (
 type StackBlockSpec struct {
 }
 
+// stackAttributes are the attributes of the stack block.
+var stackAttributes = []string{
+	"id", "name", "description", "tags", "after", "before", "wants", "wanted_by", "watch", "extends", "exec_dir",
+}
+
 // NewStackBlockParser returns a new parser specification for the "stack" block.
 func NewStackBlockParser() *StackBlockSpec {
 	return &StackBlockSpec{}
@@ -107,9 +112,29 @@ func (*StackBlockSpec) Parse(p *TerramateParser, block *ast.Block) error {
 		case "watch":
 			errs.Append(assignSet(attr, &stack.Watch, attrVal))
 
+		case "extends":
+			if attrVal.Type() != cty.String {
+				errs.Append(hclAttrErr(attr,
+					"field stack.extends must be a string but given %q",
+					attrVal.Type().FriendlyName()),
+				)
+				continue
+			}
+			stack.Extends = attrVal.AsString()
+
+		case "exec_dir":
+			if attrVal.Type() != cty.String {
+				errs.Append(hclAttrErr(attr,
+					"field stack.exec_dir must be a string but given %q",
+					attrVal.Type().FriendlyName()),
+				)
+				continue
+			}
+			stack.ExecDir = attrVal.AsString()
+
 		default:
 			errs.Append(
-				errors.E(ErrTerramateSchema, attr.NameRange, "unrecognized attribute stack.%q", attr.Name),
+				errors.E(ErrTerramateSchema, attr.NameRange, "unrecognized attribute stack.%q%s", attr.Name, didYouMean(attr.Name, stackAttributes)),
 			)
 		}
 	}
@@ -191,9 +216,29 @@ func (*StackBlockSpec) Parse(p *TerramateParser, block *ast.Block) error {
 		case "watch":
 			errs.Append(assignSet(attr, &stack.Watch, attrVal))
 
+		case "extends":
+			if attrVal.Type() != cty.String {
+				errs.Append(hclAttrErr(attr,
+					"field stack.extends must be a string but given %q",
+					attrVal.Type().FriendlyName()),
+				)
+				continue
+			}
+			stack.Extends = attrVal.AsString()
+
+		case "exec_dir":
+			if attrVal.Type() != cty.String {
+				errs.Append(hclAttrErr(attr,
+					"field stack.exec_dir must be a string but given %q",
+					attrVal.Type().FriendlyName()),
+				)
+				continue
+			}
+			stack.ExecDir = attrVal.AsString()
+
 		default:
 			errs.Append(errors.E(
-				attr.NameRange, "unrecognized attribute stack.%q", attr.Name,
+				attr.NameRange, "unrecognized attribute stack.%q%s", attr.Name, didYouMean(attr.Name, stackAttributes),
 			))
 		}
 	}
@@ -22,6 +22,11 @@ func (*TerramateBlockParser) Name() string {
 	return "terramate"
 }
 
+// schema returns the schema of the terramate block.
+func (*TerramateBlockParser) schema() *blockSchema {
+	return terramateBlockSchema
+}
+
 // Parse parses the "terramate" block.
 func (*TerramateBlockParser) Parse(p *TerramateParser, block *ast.MergedBlock) error {
 	tm := Terramate{}
@@ -71,7 +76,7 @@ func (*TerramateBlockParser) Parse(p *TerramateParser, block *ast.MergedBlock) e
 		}
 	}
 
-	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks("config"))
+	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks(terramateBlockSchema.blockTypes()...))
 
 	configBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("config")]
 	if ok {
@@ -22,6 +22,11 @@ func (*VendorBlockParser) Name() string {
 	return "vendor"
 }
 
+// schema returns the schema of the vendor block.
+func (*VendorBlockParser) schema() *blockSchema {
+	return vendorBlockSchema
+}
+
 // Parse parses the "vendor" block.
 func (*VendorBlockParser) Parse(p *TerramateParser, block *ast.Block) (err error) {
 	errs := errors.L()
@@ -57,7 +62,7 @@ func (*VendorBlockParser) Parse(p *TerramateParser, block *ast.Block) (err error
 		}
 	}
 	errs.Append(checkNoLabels(block))
-	errs.Append(checkHasSubBlocks(block, "manifest"))
+	errs.Append(checkHasSubBlocks(block, vendorBlockSchema.blockTypes()...))
 
 	if err := errs.AsError(); err != nil {
 		return err
@@ -71,7 +76,7 @@ func (*VendorBlockParser) Parse(p *TerramateParser, block *ast.Block) (err error
 
 	errs.Append(checkNoAttributes(manifestBlock))
 	errs.Append(checkNoLabels(manifestBlock))
-	errs.Append(checkHasSubBlocks(manifestBlock, "default"))
+	errs.Append(checkHasSubBlocks(manifestBlock, vendorBlockSchema.block("manifest").blockTypes()...))
 
 	if err := errs.AsError(); err != nil {
 		return err
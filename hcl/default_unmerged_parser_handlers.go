@@ -13,10 +13,13 @@ func DefaultUnmergedBlockParsers() []UnmergedBlockHandlerConstructor {
 		newTopLevelAssertBlockConstructor,
 		newGenerateHCLBlockConstructor,
 		newGenerateFileBlockConstructor,
+		newGenerateYAMLBlockConstructor,
 		newSharingBackendBlockConstructor,
 		newInputBlockConstructor,
 		newOutputBlockConstructor,
 		newScriptBlockConstructor,
+		newProvidersBlockConstructor,
+		newFileLetsBlockConstructor,
 	}
 }
 
@@ -36,6 +39,10 @@ func newGenerateFileBlockConstructor() UnmergedBlockHandler {
 	return NewGenerateFileBlockParser()
 }
 
+func newGenerateYAMLBlockConstructor() UnmergedBlockHandler {
+	return NewGenerateYAMLBlockParser()
+}
+
 func newSharingBackendBlockConstructor() UnmergedBlockHandler {
 	return NewSharingBackendBlockParser()
 }
@@ -51,3 +58,11 @@ func newOutputBlockConstructor() UnmergedBlockHandler {
 func newScriptBlockConstructor() UnmergedBlockHandler {
 	return NewScriptBlockParser()
 }
+
+func newProvidersBlockConstructor() UnmergedBlockHandler {
+	return NewProvidersBlockParser()
+}
+
+func newFileLetsBlockConstructor() UnmergedBlockHandler {
+	return NewFileLetsBlockParser()
+}
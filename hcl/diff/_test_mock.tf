@@ -0,0 +1,20 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "diff" {
+  content = <<-EOF
+package diff // import "github.com/terramate-io/terramate/hcl/diff"
+
+Package diff computes structural differences between cty values and between HCL
+bodies.
+
+func PathString(path cty.Path) string
+type Change struct{ ... }
+type Changes []Change
+    func Bodies(old, new *hclsyntax.Body) Changes
+    func Values(old, new cty.Value) Changes
+type Kind int
+    const Added Kind = iota + 1 ...
+EOF
+
+  filename = "${path.module}/mock-diff.ignore"
+}
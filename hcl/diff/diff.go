@@ -0,0 +1,367 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package diff computes structural differences between cty values and
+// between HCL bodies.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kind is the kind of a change.
+type Kind int
+
+// Kinds of changes.
+const (
+	Added Kind = iota + 1
+	Removed
+	Changed
+)
+
+// Change is a difference found at a path.
+type Change struct {
+	Kind Kind
+
+	// Path of the added, removed or changed value.
+	Path cty.Path
+
+	// Old is the removed or changed value. It's cty.NilVal for added values.
+	Old cty.Value
+
+	// New is the added or changed value. It's cty.NilVal for removed values.
+	New cty.Value
+
+	// Source tells if Old and New are strings with HCL source code, as is the
+	// case for the changes returned by Bodies.
+	Source bool
+}
+
+// Changes is a list of changes in a deterministic order.
+type Changes []Change
+
+// Values returns the changes between the old and new values.
+// Objects and maps are compared by key, lists and tuples by index and sets by
+// element. A cty.NilVal old (or new) value reports the new (or old) value as
+// added (or removed).
+func Values(old, new cty.Value) Changes {
+	var changes Changes
+	diffValues(cty.Path{}, old, new, &changes)
+	return changes
+}
+
+// Bodies returns the changes between the old and new bodies.
+// Attributes are compared by their formatted expression, which is set as a
+// string in the Old and New fields of the changes. Blocks are identified by
+// their type and labels, and added or removed blocks are reported with their
+// formatted source.
+func Bodies(old, new *hclsyntax.Body) Changes {
+	var changes Changes
+	diffBodies(cty.Path{}, old, new, &changes)
+	for i := range changes {
+		changes[i].Source = true
+	}
+	return changes
+}
+
+// String returns the string representation of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// String returns a one line representation of the change,
+// eg.: ~ a.b[0]: "old" => "new".
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s = %s", PathString(c.Path), c.valueString(c.New))
+	case Removed:
+		return fmt.Sprintf("- %s = %s", PathString(c.Path), c.valueString(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s => %s", PathString(c.Path), c.valueString(c.Old), c.valueString(c.New))
+	}
+}
+
+func (c Change) valueString(val cty.Value) string {
+	if c.Source && val != cty.NilVal {
+		return val.AsString()
+	}
+	return valueString(val)
+}
+
+// String returns the changes, one per line.
+func (changes Changes) String() string {
+	lines := make([]string, len(changes))
+	for i, c := range changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PathString returns the path in the HCL traversal syntax, eg.: a.b[0]["c d"].
+// The empty path is represented as ".".
+func PathString(path cty.Path) string {
+	if len(path) == 0 {
+		return "."
+	}
+	var b strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s.Name)
+		case cty.IndexStep:
+			b.WriteByte('[')
+			b.WriteString(valueString(s.Key))
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
+
+func diffValues(path cty.Path, old, new cty.Value, changes *Changes) {
+	switch {
+	case old == cty.NilVal && new == cty.NilVal:
+		return
+	case old == cty.NilVal:
+		*changes = append(*changes, Change{Kind: Added, Path: path.Copy(), New: new})
+		return
+	case new == cty.NilVal:
+		*changes = append(*changes, Change{Kind: Removed, Path: path.Copy(), Old: old})
+		return
+	case old.RawEquals(new):
+		return
+	}
+
+	if !sameCollectionKind(old, new) {
+		*changes = append(*changes, Change{Kind: Changed, Path: path.Copy(), Old: old, New: new})
+		return
+	}
+
+	oldType := old.Type()
+	switch {
+	case oldType.IsObjectType() || oldType.IsMapType():
+		oldElems := old.AsValueMap()
+		newElems := new.AsValueMap()
+		keys := make([]string, 0, len(oldElems)+len(newElems))
+		for key := range oldElems {
+			keys = append(keys, key)
+		}
+		for key := range newElems {
+			if _, ok := oldElems[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			var step cty.PathStep = cty.IndexStep{Key: cty.StringVal(key)}
+			if oldType.IsObjectType() && hclsyntax.ValidIdentifier(key) {
+				step = cty.GetAttrStep{Name: key}
+			}
+			diffValues(append(path, step), oldElems[key], newElems[key], changes)
+		}
+	case oldType.IsSetType():
+		for _, elem := range old.AsValueSlice() {
+			if !new.HasElement(elem).True() {
+				*changes = append(*changes, Change{
+					Kind: Removed,
+					Path: append(path.Copy(), cty.IndexStep{Key: elem}),
+					Old:  elem,
+				})
+			}
+		}
+		for _, elem := range new.AsValueSlice() {
+			if !old.HasElement(elem).True() {
+				*changes = append(*changes, Change{
+					Kind: Added,
+					Path: append(path.Copy(), cty.IndexStep{Key: elem}),
+					New:  elem,
+				})
+			}
+		}
+	default:
+		oldElems := old.AsValueSlice()
+		newElems := new.AsValueSlice()
+		for i := 0; i < max(len(oldElems), len(newElems)); i++ {
+			var oldElem, newElem cty.Value
+			if i < len(oldElems) {
+				oldElem = oldElems[i]
+			}
+			if i < len(newElems) {
+				newElem = newElems[i]
+			}
+			diffValues(append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))}), oldElem, newElem, changes)
+		}
+	}
+}
+
+// sameCollectionKind tells if the old and new values are collections of the
+// same kind, which can be compared element by element.
+func sameCollectionKind(old, new cty.Value) bool {
+	if old.IsNull() || new.IsNull() || !old.IsKnown() || !new.IsKnown() {
+		return false
+	}
+	oldType, newType := old.Type(), new.Type()
+	switch {
+	case oldType.IsObjectType() || oldType.IsMapType():
+		return newType.IsObjectType() || newType.IsMapType()
+	case oldType.IsListType() || oldType.IsTupleType():
+		return newType.IsListType() || newType.IsTupleType()
+	case oldType.IsSetType():
+		return newType.IsSetType() && oldType.Equals(newType) && old.IsWhollyKnown() && new.IsWhollyKnown()
+	}
+	return false
+}
+
+func diffBodies(path cty.Path, old, new *hclsyntax.Body, changes *Changes) {
+	oldAttrs := old.Attributes
+	newAttrs := new.Attributes
+	names := make([]string, 0, len(oldAttrs)+len(newAttrs))
+	for name := range oldAttrs {
+		names = append(names, name)
+	}
+	for name := range newAttrs {
+		if _, ok := oldAttrs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var oldExpr, newExpr cty.Value
+		if attr, ok := oldAttrs[name]; ok {
+			oldExpr = cty.StringVal(exprString(attr.Expr))
+		}
+		if attr, ok := newAttrs[name]; ok {
+			newExpr = cty.StringVal(exprString(attr.Expr))
+		}
+		diffValues(append(path, cty.GetAttrStep{Name: name}), oldExpr, newExpr, changes)
+	}
+
+	oldBlocks := blocksByID(old.Blocks)
+	newBlocks := blocksByID(new.Blocks)
+	ids := make([]blockID, 0, len(oldBlocks)+len(newBlocks))
+	for id := range oldBlocks {
+		ids = append(ids, id)
+	}
+	for id := range newBlocks {
+		if _, ok := oldBlocks[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].less(ids[j]) })
+
+	for _, id := range ids {
+		blockPath := append(path.Copy(), id.steps()...)
+		oldBlock, inOld := oldBlocks[id]
+		newBlock, inNew := newBlocks[id]
+		switch {
+		case inOld && inNew:
+			diffBodies(blockPath, oldBlock.Body, newBlock.Body, changes)
+		case inOld:
+			*changes = append(*changes, Change{
+				Kind: Removed,
+				Path: blockPath,
+				Old:  cty.StringVal(blockString(oldBlock)),
+			})
+		default:
+			*changes = append(*changes, Change{
+				Kind: Added,
+				Path: blockPath,
+				New:  cty.StringVal(blockString(newBlock)),
+			})
+		}
+	}
+}
+
+// blockID identifies a block by its type, labels and the occurrence of
+// blocks with the same type and labels.
+type blockID struct {
+	typ        string
+	labels     string
+	occurrence int
+}
+
+func blocksByID(blocks hclsyntax.Blocks) map[blockID]*hclsyntax.Block {
+	res := map[blockID]*hclsyntax.Block{}
+	for _, block := range blocks {
+		id := blockID{typ: block.Type, labels: strings.Join(block.Labels, "\x00")}
+		for {
+			if _, ok := res[id]; !ok {
+				break
+			}
+			id.occurrence++
+		}
+		res[id] = block
+	}
+	return res
+}
+
+func (id blockID) less(other blockID) bool {
+	if id.typ != other.typ {
+		return id.typ < other.typ
+	}
+	if id.labels != other.labels {
+		return id.labels < other.labels
+	}
+	return id.occurrence < other.occurrence
+}
+
+func (id blockID) steps() []cty.PathStep {
+	steps := []cty.PathStep{cty.GetAttrStep{Name: id.typ}}
+	if id.labels != "" {
+		for _, label := range strings.Split(id.labels, "\x00") {
+			steps = append(steps, cty.IndexStep{Key: cty.StringVal(label)})
+		}
+	}
+	if id.occurrence > 0 {
+		steps = append(steps, cty.IndexStep{Key: cty.NumberIntVal(int64(id.occurrence))})
+	}
+	return steps
+}
+
+func exprString(expr hclsyntax.Expression) string {
+	return string(hclwrite.Format(ast.TokensForExpression(expr).Bytes()))
+}
+
+func blockString(block *hclsyntax.Block) string {
+	f := hclwrite.NewEmptyFile()
+	dest := f.Body().AppendNewBlock(block.Type, block.Labels).Body()
+	appendBody(dest, block.Body)
+	return strings.TrimSpace(string(hclwrite.Format(f.Bytes())))
+}
+
+func appendBody(dest *hclwrite.Body, src *hclsyntax.Body) {
+	for _, attr := range ast.SortRawAttributes(ast.AsHCLAttributes(src.Attributes)) {
+		dest.SetAttributeRaw(attr.Name, ast.TokensForExpression(attr.Expr))
+	}
+	for _, block := range src.Blocks {
+		appendBody(dest.AppendNewBlock(block.Type, block.Labels).Body(), block.Body)
+	}
+}
+
+func valueString(val cty.Value) string {
+	if val == cty.NilVal {
+		return "<nil>"
+	}
+	if !val.IsWhollyKnown() {
+		return "<unknown>"
+	}
+	return strings.TrimSpace(string(hclwrite.TokensForValue(val).Bytes()))
+}
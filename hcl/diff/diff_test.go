@@ -0,0 +1,209 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/hcl/diff"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name string
+		old  cty.Value
+		new  cty.Value
+		want string
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "equal values",
+			old:  cty.ObjectVal(map[string]cty.Value{"a": cty.StringVal("a")}),
+			new:  cty.ObjectVal(map[string]cty.Value{"a": cty.StringVal("a")}),
+		},
+		{
+			name: "added value",
+			old:  cty.NilVal,
+			new:  cty.NumberIntVal(1),
+			want: `+ . = 1`,
+		},
+		{
+			name: "changed primitive",
+			old:  cty.StringVal("a"),
+			new:  cty.StringVal("b"),
+			want: `~ .: "a" => "b"`,
+		},
+		{
+			name: "changed type",
+			old:  cty.StringVal("1"),
+			new:  cty.NumberIntVal(1),
+			want: `~ .: "1" => 1`,
+		},
+		{
+			name: "nested object attributes",
+			old: cty.ObjectVal(map[string]cty.Value{
+				"kept":    cty.StringVal("kept"),
+				"removed": cty.True,
+				"nested": cty.ObjectVal(map[string]cty.Value{
+					"value":     cty.NumberIntVal(1),
+					"with-dash": cty.NumberIntVal(1),
+				}),
+			}),
+			new: cty.ObjectVal(map[string]cty.Value{
+				"kept":  cty.StringVal("kept"),
+				"added": cty.False,
+				"nested": cty.ObjectVal(map[string]cty.Value{
+					"value":     cty.NumberIntVal(2),
+					"with-dash": cty.NumberIntVal(1),
+				}),
+			}),
+			want: `+ added = false
+~ nested.value: 1 => 2
+- removed = true`,
+		},
+		{
+			name: "map keys",
+			old:  cty.MapVal(map[string]cty.Value{"a": cty.StringVal("a")}),
+			new:  cty.MapVal(map[string]cty.Value{"a": cty.StringVal("b"), "c d": cty.StringVal("c")}),
+			want: `~ ["a"]: "a" => "b"
++ ["c d"] = "c"`,
+		},
+		{
+			name: "list elements",
+			old:  cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			new:  cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("c"), cty.StringVal("d")}),
+			want: `~ [1]: "b" => "c"
++ [2] = "d"`,
+		},
+		{
+			name: "set elements",
+			old:  cty.SetVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			new:  cty.SetVal([]cty.Value{cty.StringVal("b"), cty.StringVal("c")}),
+			want: `- ["a"] = "a"
++ ["c"] = "c"`,
+		},
+		{
+			name: "null and unknown values",
+			old: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.NullVal(cty.String),
+				"b": cty.StringVal("b"),
+			}),
+			new: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("a"),
+				"b": cty.UnknownVal(cty.String),
+			}),
+			want: `~ a: null => "a"
+~ b: "b" => <unknown>`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.EqualStrings(t, tc.want, diff.Values(tc.old, tc.new).String())
+		})
+	}
+}
+
+func TestValuesChangeFields(t *testing.T) {
+	t.Parallel()
+
+	changes := diff.Values(
+		cty.ObjectVal(map[string]cty.Value{"a": cty.ListVal([]cty.Value{cty.StringVal("a")})}),
+		cty.ObjectVal(map[string]cty.Value{"a": cty.ListValEmpty(cty.String)}),
+	)
+	assert.EqualInts(t, 1, len(changes))
+	assert.EqualInts(t, int(diff.Removed), int(changes[0].Kind))
+	assert.EqualStrings(t, "removed", changes[0].Kind.String())
+	assert.EqualStrings(t, `a[0]`, diff.PathString(changes[0].Path))
+	assert.IsTrue(t, changes[0].Old.RawEquals(cty.StringVal("a")))
+	assert.IsTrue(t, changes[0].New == cty.NilVal)
+}
+
+func TestBodies(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name string
+		old  string
+		new  string
+		want string
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "equal bodies with different formatting",
+			old:  "a = 1\nb = [1,2]\n",
+			new:  "b = [ 1, 2 ]\na   = 1\n",
+		},
+		{
+			name: "attributes",
+			old:  "a = 1\nb = global.b\n",
+			new:  "b = global.c\nc = \"c\"\n",
+			want: `- a = 1
+~ b: global.b => global.c
++ c = "c"`,
+		},
+		{
+			name: "nested blocks",
+			old: `
+resource "a" "b" {
+  name = "old"
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+locals {
+  removed = 1
+}
+`,
+			new: `
+resource "a" "b" {
+  name = "new"
+}
+resource "a" "c" {
+  name = "c"
+}
+`,
+			want: `- locals = locals {
+  removed = 1
+}
+~ resource["a"]["b"].name: "old" => "new"
+- resource["a"]["b"].lifecycle = lifecycle {
+  prevent_destroy = true
+}
++ resource["a"]["c"] = resource "a" "c" {
+  name = "c"
+}`,
+		},
+		{
+			name: "repeated blocks",
+			old:  "dynamic {\n  a = 1\n}\ndynamic {\n  a = 2\n}\n",
+			new:  "dynamic {\n  a = 1\n}\ndynamic {\n  a = 3\n}\n",
+			want: `~ dynamic[1].a: 2 => 3`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := diff.Bodies(parseBody(t, tc.old), parseBody(t, tc.new))
+			assert.EqualStrings(t, tc.want, got.String())
+		})
+	}
+}
+
+func parseBody(t *testing.T, src string) *hclsyntax.Body {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(src), "test.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parsing %q: %v", src, diags)
+	}
+	return file.Body.(*hclsyntax.Body)
+}
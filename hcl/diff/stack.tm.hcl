@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package diff // import \"github.com/terramate-io/terramate/hcl/diff\""
+  description = "package diff // import \"github.com/terramate-io/terramate/hcl/diff\"\n\nPackage diff computes structural differences between cty values and between HCL\nbodies.\n\nfunc PathString(path cty.Path) string\ntype Change struct{ ... }\ntype Changes []Change\n    func Bodies(old, new *hclsyntax.Body) Changes\n    func Values(old, new cty.Value) Changes\ntype Kind int\n    const Added Kind = iota + 1 ..."
+  tags        = ["diff", "golang", "hcl"]
+  id          = "0038b686-9fee-4525-9a76-95f42a5d60fb"
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "eval" {
-  content = <<-EOT
+  content = <<-EOF
 package eval // import "github.com/terramate-io/terramate/hcl/eval"
 
 Package eval provides both full and partial evaluation of HCL.
@@ -20,7 +20,7 @@ type Object struct{ ... }
 type ObjectPath []string
 type Value interface{ ... }
     func NewValue(val cty.Value, origin Info) Value
-EOT
+EOF
 
   filename = "${path.module}/mock-eval.ignore"
 }
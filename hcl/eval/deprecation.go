@@ -0,0 +1,91 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/warnings"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Deprecate marks the variable at the given path of the namespace as
+// deprecated. Evaluating any expression referencing the variable, or any of
+// its nested values, emits a warning with the given message.
+func (c *Context) Deprecate(namespace string, path ObjectPath, message string) {
+	if c.deprecated == nil {
+		c.deprecated = map[string]string{}
+	}
+	c.deprecated[deprecationKey(namespace, path)] = message
+}
+
+// DeprecateFrom marks as deprecated all the deprecated values of the given
+// object, which is expected to be set as the given namespace.
+func (c *Context) DeprecateFrom(namespace string, obj *Object) {
+	var walk func(path ObjectPath, obj *Object)
+	walk = func(path ObjectPath, obj *Object) {
+		for key, val := range obj.Keys {
+			valpath := append(append(ObjectPath{}, path...), key)
+			if msg := val.Info().Deprecated; msg != "" {
+				c.Deprecate(namespace, valpath, msg)
+			}
+			if val.IsObject() {
+				walk(valpath, val.(*Object))
+			}
+		}
+	}
+	walk(nil, obj)
+}
+
+func (c *Context) copyDeprecations(from *Context) {
+	for k, v := range from.deprecated {
+		if c.deprecated == nil {
+			c.deprecated = map[string]string{}
+		}
+		c.deprecated[k] = v
+	}
+}
+
+// warnDeprecated emits a warning for each reference to a deprecated variable
+// in the given expression. It fails if the warning is promoted to error.
+func (c *Context) warnDeprecated(expr hhcl.Expression) error {
+	if len(c.deprecated) == 0 {
+		return nil
+	}
+	for _, traversal := range expr.Variables() {
+		key := traversal.RootName()
+	steps:
+		for _, step := range traversal[1:] {
+			switch attr := step.(type) {
+			case hhcl.TraverseAttr:
+				key += "." + attr.Name
+			case hhcl.TraverseIndex:
+				if !attr.Key.IsKnown() || !attr.Key.Type().Equals(cty.String) {
+					break steps
+				}
+				key += "." + attr.Key.AsString()
+			default:
+				break steps
+			}
+			msg, ok := c.deprecated[key]
+			if !ok {
+				continue
+			}
+			err := warnings.Log(warnings.DeprecatedVariable, "deprecated variable referenced",
+				traversal.SourceRange(),
+				errors.E("%s: %s", key, msg))
+			if err != nil {
+				return err
+			}
+			break steps
+		}
+	}
+	return nil
+}
+
+func deprecationKey(namespace string, path ObjectPath) string {
+	return namespace + "." + strings.Join(path, ".")
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 
@@ -19,7 +20,12 @@ const ErrEval errors.Kind = "eval expression"
 
 // Context is used to evaluate HCL code.
 type Context struct {
-	hclctx *hhcl.EvalContext
+	hclctx     *hhcl.EvalContext
+	deprecated map[string]string
+	sensitive  map[string]struct{}
+	defdirs    map[string]project.Path
+	limiter    *Limiter
+	simplify   bool
 }
 
 // NewContext creates a new HCL evaluation context.
@@ -42,9 +48,31 @@ func (c *Context) ChildContext() *Context {
 	for k, v := range c.hclctx.Variables {
 		child.hclctx.Variables[k] = v
 	}
+	child.copyDeprecations(c)
+	child.copySensitive(c)
+	child.copyDefinitionDirs(c)
+	child.limiter = c.limiter
+	child.simplify = c.simplify
 	return child
 }
 
+// SetLimiter sets the limiter bounding the evaluations of the context. The
+// functions of the context should be limited by the same limiter.
+func (c *Context) SetLimiter(l *Limiter) {
+	c.limiter = l
+}
+
+// SetSimplify sets if the partial evaluation simplifies the expressions with
+// unknowns, folding their operations on known values.
+func (c *Context) SetSimplify(enabled bool) {
+	c.simplify = enabled
+}
+
+// DeleteFunction deletes the function from the context.
+func (c *Context) DeleteFunction(name string) {
+	delete(c.hclctx.Functions, name)
+}
+
 // SetNamespace will set the given values inside the given namespace on the
 // evaluation context.
 func (c *Context) SetNamespace(name string, vals map[string]cty.Value) {
@@ -93,8 +121,22 @@ func (c *Context) HasNamespace(name string) bool {
 
 // Eval will evaluate an expression given its context.
 func (c *Context) Eval(expr hhcl.Expression) (cty.Value, error) {
+	if err := c.warnDeprecated(expr); err != nil {
+		return cty.NilVal, err
+	}
+	if c.limiter != nil {
+		defer c.limiter.Begin()()
+	}
+	return c.eval(expr)
+}
+
+func (c *Context) eval(expr hhcl.Expression) (cty.Value, error) {
+	if c.limiter != nil {
+		expr = c.limiter.limit(expr)
+	}
 	val, diag := expr.Value(c.hclctx)
 	if diag.HasErrors() {
+		c.explainUndefined(expr, diag)
 		return cty.NilVal, errors.E(ErrEval, diag)
 	}
 	return val, nil
@@ -108,6 +150,12 @@ func (c *Context) Eval(expr hhcl.Expression) (cty.Value, error) {
 // It try to reduce the expression to its simplest form, which means expressions
 // with no unknowns are evaluated down to literals.
 func (c *Context) PartialEval(expr hhcl.Expression) (hhcl.Expression, bool, error) {
+	if err := c.warnDeprecated(expr); err != nil {
+		return nil, false, err
+	}
+	if c.limiter != nil {
+		defer c.limiter.Begin()()
+	}
 	newexpr, hasUnknowns, err := c.partialEval(expr)
 	if err != nil {
 		return nil, false, errors.E(ErrPartial, err)
@@ -124,7 +172,7 @@ func (c *Context) PartialEval(expr hhcl.Expression) (hhcl.Expression, bool, erro
 		return newexpr, hasUnknowns, nil
 	}
 	var evaluated cty.Value
-	evaluated, err = c.Eval(newexpr)
+	evaluated, err = c.eval(newexpr)
 	if err != nil {
 		return nil, false, errors.E(ErrPartial, err)
 	}
@@ -144,7 +192,13 @@ func (c *Context) Copy() *Context {
 	for k, v := range c.hclctx.Variables {
 		newctx.Variables[k] = v
 	}
-	return NewContextFrom(newctx)
+	copied := NewContextFrom(newctx)
+	copied.copyDeprecations(c)
+	copied.copySensitive(c)
+	copied.copyDefinitionDirs(c)
+	copied.limiter = c.limiter
+	copied.simplify = c.simplify
+	return copied
 }
 
 // Unwrap returns the internal hhcl.EvalContext.
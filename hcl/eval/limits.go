@@ -0,0 +1,286 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrLimit indicates the evaluation exceeded a limit of its [Limiter].
+const ErrLimit errors.Kind = "sandbox limit exceeded"
+
+// Limiter bounds the duration of the evaluations and the size of the values
+// created by them. It's checked by the evaluation of the for expressions and
+// templates of a [Context] using it, and by the functions wrapped with it
+// before they allocate their results.
+//
+// An evaluation starts with the outermost [Context.Eval] or
+// [Context.PartialEval] call, or with a call of a limited function made out
+// of them. It's safe for concurrent use, but the concurrent evaluations
+// share their limits.
+type Limiter struct {
+	timeout time.Duration
+	maxSize int
+
+	mu       sync.Mutex
+	depth    int
+	deadline time.Time
+	size     int
+}
+
+// NewLimiter creates a limiter for evaluations lasting up to timeout and
+// creating values whose sizes, as returned by [ValueSize], sum up to maxSize.
+// A zero timeout or maxSize disables the respective limit.
+func NewLimiter(timeout time.Duration, maxSize int) *Limiter {
+	return &Limiter{
+		timeout: timeout,
+		maxSize: maxSize,
+	}
+}
+
+// Begin begins an evaluation, if none is running, and returns the function
+// ending it.
+func (l *Limiter) Begin() (end func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.depth == 0 {
+		l.size = 0
+		l.deadline = time.Time{}
+		if l.timeout > 0 {
+			l.deadline = time.Now().Add(l.timeout)
+		}
+	}
+	l.depth++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.depth--
+	}
+}
+
+// Check fails if the running evaluation exceeded its duration.
+func (l *Limiter) Check(what string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.deadline.IsZero() && time.Now().After(l.deadline) {
+		return errors.E(ErrLimit, "evaluation timed out evaluating %s", what)
+	}
+	return nil
+}
+
+// Fits fails if creating a value of the given size exceeds the maximum size
+// of the running evaluation, without accounting the value.
+func (l *Limiter) Fits(what string, size int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.fits(what, size)
+}
+
+// Alloc accounts the size of a value created by the running evaluation,
+// failing if it exceeds its maximum size.
+func (l *Limiter) Alloc(what string, size int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.fits(what, size); err != nil {
+		return err
+	}
+	l.size += size
+	return nil
+}
+
+// Limit returns the size still available to the running evaluation, or
+// [math.MaxInt] if the size is unlimited.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxSize <= 0 {
+		return math.MaxInt
+	}
+	return l.maxSize - l.size
+}
+
+func (l *Limiter) fits(what string, size int) error {
+	if l.maxSize > 0 && (size > l.maxSize || l.size+size > l.maxSize) {
+		return errors.E(ErrLimit, "evaluation exceeds the maximum size of %d creating %s", l.maxSize, what)
+	}
+	return nil
+}
+
+// ValueSize returns the size of the value, which is the length of the
+// strings, object keys and map keys plus the number of the other primitive
+// values, summed over all the nested values. It stops once the size exceeds
+// the limit.
+func ValueSize(val cty.Value, limit int) int {
+	val, _ = val.UnmarkDeep()
+	if val.IsNull() || !val.IsKnown() {
+		return 0
+	}
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return len(val.AsString())
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType() ||
+		ty.IsMapType() || ty.IsObjectType():
+		size := 0
+		for it := val.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			if key.Type() == cty.String {
+				size += len(key.AsString())
+			}
+			size += ValueSize(elem, limit-size)
+			if size > limit {
+				break
+			}
+		}
+		return size
+	default:
+		return 1
+	}
+}
+
+// limit returns a copy of the expression whose for expressions and
+// templates are checked by the limiter on each element and part.
+// The expression is copied because it can be evaluated concurrently by
+// contexts with other limiters.
+func (l *Limiter) limit(expr hhcl.Expression) hhcl.Expression {
+	synexpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return expr
+	}
+	return l.limitSyntax(synexpr)
+}
+
+func (l *Limiter) limitSyntax(expr hclsyntax.Expression) hclsyntax.Expression {
+	switch e := expr.(type) {
+	case *hclsyntax.ForExpr:
+		c := *e
+		c.CollExpr = l.limitSyntax(e.CollExpr)
+		c.KeyExpr = l.checked(l.limitSyntax(e.KeyExpr), "for expression key")
+		c.ValExpr = l.checked(l.limitSyntax(e.ValExpr), "for expression element")
+		c.CondExpr = l.checked(l.limitSyntax(e.CondExpr), "for expression condition")
+		return &c
+	case *hclsyntax.TemplateExpr:
+		c := *e
+		c.Parts = make([]hclsyntax.Expression, len(e.Parts))
+		for i, part := range e.Parts {
+			c.Parts[i] = l.checked(l.limitSyntax(part), "template part")
+		}
+		return &c
+	case *hclsyntax.TemplateWrapExpr:
+		c := *e
+		c.Wrapped = l.limitSyntax(e.Wrapped)
+		return &c
+	case *hclsyntax.TemplateJoinExpr:
+		c := *e
+		c.Tuple = l.limitSyntax(e.Tuple)
+		return &c
+	case *hclsyntax.BinaryOpExpr:
+		c := *e
+		c.LHS = l.limitSyntax(e.LHS)
+		c.RHS = l.limitSyntax(e.RHS)
+		return &c
+	case *hclsyntax.UnaryOpExpr:
+		c := *e
+		c.Val = l.limitSyntax(e.Val)
+		return &c
+	case *hclsyntax.ParenthesesExpr:
+		c := *e
+		c.Expression = l.limitSyntax(e.Expression)
+		return &c
+	case *hclsyntax.ConditionalExpr:
+		c := *e
+		c.Condition = l.limitSyntax(e.Condition)
+		c.TrueResult = l.limitSyntax(e.TrueResult)
+		c.FalseResult = l.limitSyntax(e.FalseResult)
+		return &c
+	case *hclsyntax.TupleConsExpr:
+		c := *e
+		c.Exprs = make([]hclsyntax.Expression, len(e.Exprs))
+		for i, elem := range e.Exprs {
+			c.Exprs[i] = l.limitSyntax(elem)
+		}
+		return &c
+	case *hclsyntax.ObjectConsExpr:
+		c := *e
+		c.Items = make([]hclsyntax.ObjectConsItem, len(e.Items))
+		for i, item := range e.Items {
+			c.Items[i] = hclsyntax.ObjectConsItem{
+				KeyExpr:   item.KeyExpr,
+				ValueExpr: l.limitSyntax(item.ValueExpr),
+			}
+		}
+		return &c
+	case *hclsyntax.FunctionCallExpr:
+		c := *e
+		c.Args = make([]hclsyntax.Expression, len(e.Args))
+		for i, arg := range e.Args {
+			c.Args[i] = l.limitSyntax(arg)
+		}
+		return &c
+	case *hclsyntax.IndexExpr:
+		c := *e
+		c.Collection = l.limitSyntax(e.Collection)
+		c.Key = l.limitSyntax(e.Key)
+		return &c
+	case *hclsyntax.SplatExpr:
+		c := *e
+		c.Source = l.limitSyntax(e.Source)
+		return &c
+	case *hclsyntax.RelativeTraversalExpr:
+		c := *e
+		c.Source = l.limitSyntax(e.Source)
+		return &c
+	default:
+		return expr
+	}
+}
+
+func (l *Limiter) checked(expr hclsyntax.Expression, what string) hclsyntax.Expression {
+	if expr == nil {
+		return nil
+	}
+	return &limitedExpr{Expression: expr, limiter: l, what: what}
+}
+
+// limitedExpr is an expression checked by the limiter before and after its
+// evaluation.
+type limitedExpr struct {
+	hclsyntax.Expression
+
+	limiter *Limiter
+	what    string
+}
+
+func (e *limitedExpr) Value(ctx *hhcl.EvalContext) (cty.Value, hhcl.Diagnostics) {
+	if err := e.limiter.Check(e.what); err != nil {
+		return cty.DynamicVal, limitDiags(e.Expression, err)
+	}
+	val, diags := e.Expression.Value(ctx)
+	if diags.HasErrors() {
+		return val, diags
+	}
+	if err := e.limiter.Alloc(e.what, ValueSize(val, e.limiter.Limit())); err != nil {
+		return cty.DynamicVal, limitDiags(e.Expression, err)
+	}
+	return val, diags
+}
+
+func limitDiags(expr hclsyntax.Expression, err error) hhcl.Diagnostics {
+	rng := expr.Range()
+	return hhcl.Diagnostics{
+		&hhcl.Diagnostic{
+			Severity: hhcl.DiagError,
+			Summary:  string(ErrLimit),
+			Detail:   err.Error(),
+			Subject:  &rng,
+		},
+	}
+}
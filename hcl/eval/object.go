@@ -68,6 +68,12 @@ type (
 
 		// DefinedAt provides the source file where the value is defined.
 		DefinedAt project.Path
+
+		// Deprecated is the deprecation message of the value, if any.
+		Deprecated string
+
+		// Sensitive tells if the value must be masked in any output.
+		Sensitive bool
 	}
 
 	// ObjectPath represents a path inside the object.
@@ -246,6 +252,10 @@ func (obj *Object) MergeNewKeys(path ObjectPath, value Value) error {
 }
 
 func computeTargetFrom(obj *Object, path ObjectPath, info Info) (*Object, string, error) {
+	// the implicitly created parent objects are not deprecated nor sensitive
+	// because of the value being set.
+	info.Deprecated = ""
+	info.Sensitive = false
 	for len(path) > 1 {
 		key := path[0]
 		subobj, ok := obj.Keys[key]
@@ -303,8 +313,9 @@ func (obj *Object) AsValueMap() map[string]cty.Value {
 }
 
 // String representation of the object.
+// Sensitive values are masked.
 func (obj *Object) String() string {
-	return fmt.FormatAttributes(obj.AsValueMap())
+	return fmt.FormatAttributes(obj.AsMaskedValueMap())
 }
 
 // NewCtyValue creates a new cty.Value wrapper.
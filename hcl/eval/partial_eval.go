@@ -56,7 +56,11 @@ func (c *Context) partialEval(expr hhcl.Expression) (newexpr hhcl.Expression, ha
 	case *hclsyntax.RelativeTraversalExpr:
 		return c.partialEvalRelTrav(e)
 	case *hclsyntax.ParenthesesExpr:
-		return c.partialEvalParenExpr(e)
+		newexpr, hasUnknowns, err := c.partialEvalParenExpr(e)
+		if err != nil || !c.simplify {
+			return newexpr, hasUnknowns, err
+		}
+		return foldParen(newexpr.(*hclsyntax.ParenthesesExpr)), hasUnknowns, nil
 	case *hclsyntax.AnonSymbolExpr:
 		return expr, false, nil
 	default:
@@ -94,7 +98,10 @@ func (c *Context) partialEvalTmplWrap(old *hclsyntax.TemplateWrapExpr) (hhcl.Exp
 		Wrapped:  asSyntax(newwrap),
 		SrcRange: old.SrcRange,
 	}
-	return newtpl, hasUnknowns, nil
+	if !c.simplify {
+		return newtpl, hasUnknowns, nil
+	}
+	return foldTmplWrap(newtpl), hasUnknowns, nil
 }
 
 func (c *Context) partialEvalTuple(old *hclsyntax.TupleConsExpr) (hclsyntax.Expression, bool, error) {
@@ -144,7 +151,7 @@ func (c *Context) partialEvalObject(old *hclsyntax.ObjectConsExpr) (hclsyntax.Ex
 
 func (c *Context) partialEvalFunc(old *hclsyntax.FunctionCallExpr) (hhcl.Expression, bool, error) {
 	if strings.HasPrefix(old.Name, "tm_") {
-		val, err := c.Eval(old)
+		val, err := c.eval(old)
 		if err != nil {
 			return nil, false, err
 		}
@@ -194,7 +201,7 @@ func (c *Context) partialEvalIndex(old *hclsyntax.IndexExpr) (hhcl.Expression, b
 			BracketRange: old.BracketRange,
 		}, hasUnknowns, nil
 	}
-	val, err := c.Eval(old)
+	val, err := c.eval(old)
 	if err != nil {
 		return nil, false, err
 	}
@@ -227,7 +234,7 @@ func (c *Context) partialEvalSplat(old *hclsyntax.SplatExpr) (hhcl.Expression, b
 	if hasUnknowns {
 		return newsplat, hasUnknowns, nil
 	}
-	val, err := c.Eval(newsplat)
+	val, err := c.eval(newsplat)
 	if err != nil {
 		return newsplat, false, err // TODO(i4k): why return new and err??
 	}
@@ -334,7 +341,7 @@ func (c *Context) evalForLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bool, er
 
 func (c *Context) evalForObjectLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bool, error) {
 	res := make(map[string]cty.Value)
-	newcoll, err := c.Eval(old.CollExpr)
+	newcoll, err := c.eval(old.CollExpr)
 	if err != nil {
 		return nil, false, err
 	}
@@ -368,7 +375,7 @@ func (c *Context) evalForObjectLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bo
 			if hasUnknowns {
 				return newfor, hasUnknowns, nil
 			}
-			condVal, err := childCtx.Eval(newCondExpr)
+			condVal, err := childCtx.eval(newCondExpr)
 			if err != nil {
 				return nil, false, err
 			}
@@ -388,7 +395,7 @@ func (c *Context) evalForObjectLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bo
 			return newfor, hasUnknowns, nil
 		}
 
-		resKeyVal, err := childCtx.Eval(resKeyExpr)
+		resKeyVal, err := childCtx.eval(resKeyExpr)
 		if err != nil {
 			return nil, false, err
 		}
@@ -402,7 +409,7 @@ func (c *Context) evalForObjectLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bo
 		if hasUnknowns {
 			return newfor, hasUnknowns, nil
 		}
-		resVal, err := childCtx.Eval(resValExpr)
+		resVal, err := childCtx.eval(resValExpr)
 		if err != nil {
 			return nil, false, err
 		}
@@ -416,7 +423,7 @@ func (c *Context) evalForObjectLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bo
 
 func (c *Context) evalForListLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bool, error) {
 	var res []cty.Value
-	newcoll, err := c.Eval(old.CollExpr)
+	newcoll, err := c.eval(old.CollExpr)
 	if err != nil {
 		return nil, false, err
 	}
@@ -450,7 +457,7 @@ func (c *Context) evalForListLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bool
 			if hasUnknowns {
 				return newfor, hasUnknowns, nil
 			}
-			condVal, err := childCtx.Eval(condExpr)
+			condVal, err := childCtx.eval(condExpr)
 			if err != nil {
 				return nil, false, err
 			}
@@ -469,7 +476,7 @@ func (c *Context) evalForListLoop(old *hclsyntax.ForExpr) (hhcl.Expression, bool
 		if hasUnknowns {
 			return newfor, hasUnknowns, nil
 		}
-		resVal, err := childCtx.Eval(resValExpr)
+		resVal, err := childCtx.eval(resValExpr)
 		if err != nil {
 			return nil, false, err
 		}
@@ -490,12 +497,16 @@ func (c *Context) partialEvalBinOp(old *hclsyntax.BinaryOpExpr) (hhcl.Expression
 	if err != nil {
 		return nil, false, err
 	}
-	return &hclsyntax.BinaryOpExpr{
+	newbinop := &hclsyntax.BinaryOpExpr{
 		LHS:      asSyntax(lhs),
 		RHS:      asSyntax(rhs),
 		Op:       old.Op, // not copied but this is never modified.
 		SrcRange: old.SrcRange,
-	}, h1 || h2, nil
+	}
+	if !c.simplify {
+		return newbinop, h1 || h2, nil
+	}
+	return foldOp(newbinop, newbinop.LHS, newbinop.RHS), h1 || h2, nil
 }
 
 func (c *Context) partialEvalUnaryOp(old *hclsyntax.UnaryOpExpr) (hhcl.Expression, bool, error) {
@@ -506,7 +517,10 @@ func (c *Context) partialEvalUnaryOp(old *hclsyntax.UnaryOpExpr) (hhcl.Expressio
 	newfor := &hclsyntax.UnaryOpExpr{SrcRange: old.SrcRange, SymbolRange: old.SymbolRange}
 	newfor.Val = asSyntax(val)
 	newfor.Op = old.Op
-	return newfor, hasUnknowns, nil
+	if !c.simplify {
+		return newfor, hasUnknowns, nil
+	}
+	return foldOp(newfor, newfor.Val), hasUnknowns, nil
 }
 
 func (c *Context) partialEvalCondExpr(old *hclsyntax.ConditionalExpr) (hhcl.Expression, bool, error) {
@@ -522,12 +536,27 @@ func (c *Context) partialEvalCondExpr(old *hclsyntax.ConditionalExpr) (hhcl.Expr
 	if err != nil {
 		return nil, false, err
 	}
-	return &hclsyntax.ConditionalExpr{
+	newcondexpr := &hclsyntax.ConditionalExpr{
 		SrcRange:    old.SrcRange,
 		Condition:   asSyntax(newcond),
 		TrueResult:  asSyntax(newtrue),
 		FalseResult: asSyntax(newfalse),
-	}, h1 || h2 || h3, nil
+	}
+	if !c.simplify {
+		return newcondexpr, h1 || h2 || h3, nil
+	}
+	if !h2 && !h3 {
+		// both branches must be evaluated for the type unification.
+		return foldOp(newcondexpr, newcondexpr.Condition, newcondexpr.TrueResult, newcondexpr.FalseResult), h1, nil
+	}
+	switch folded := foldCond(newcondexpr); folded {
+	case newcondexpr.TrueResult:
+		return folded, h2, nil
+	case newcondexpr.FalseResult:
+		return folded, h3, nil
+	default:
+		return folded, h1 || h2 || h3, nil
+	}
 }
 
 type partialEvalOption struct {
@@ -557,7 +586,7 @@ func (c *Context) partialEvalScopeTrav(old *hclsyntax.ScopeTraversalExpr, opts .
 	if !c.HasNamespace(ns.Name) {
 		return newtrav, true, nil
 	}
-	val, err := c.Eval(newtrav)
+	val, err := c.eval(newtrav)
 	if err != nil {
 		return nil, false, err
 	}
@@ -607,7 +636,7 @@ func (c *Context) partialEvalRelTrav(old *hclsyntax.RelativeTraversalExpr) (hhcl
 	if hasUnknowns {
 		return newRelTrav, hasUnknowns, nil
 	}
-	val, err := c.Eval(old)
+	val, err := c.eval(old)
 	if err != nil {
 		return nil, false, err
 	}
@@ -26,6 +26,7 @@ func TestPartialEval(t *testing.T) {
 		expr        string
 		want        string
 		hasUnknowns bool
+		simplify    bool
 		wantErr     error
 	}
 
@@ -481,11 +482,95 @@ EOT
 			expr: `tm_upper(global.string)`,
 			want: `"TERRAMATE"`,
 		},
+		{
+			simplify:    true,
+			expr:        `1+global.number+var.x`,
+			want:        `11 + var.x`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `var.x*(global.number - 4)`,
+			want:        `var.x * 6`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `var.x+1+2`,
+			want:        `var.x + 1 + 2`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `[!global.falsy, -(global.number), var.x]`,
+			want:        `[true, -10, var.x]`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `"${"foo"}-${global.string}-${var.x}"`,
+			want:        `"foo-terramate-${var.x}"`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `[var.x, "${"foo"}"]`,
+			want:        `[var.x, "foo"]`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `global.truer ? var.x : var.y`,
+			want:        `var.x`,
+			hasUnknowns: true,
+		},
+		{
+			simplify: true,
+			expr:     `global.falsy ? var.x : global.string`,
+			want:     `"terramate"`,
+		},
+		{
+			simplify:    true,
+			expr:        `[var.x, global.truer ? 1 : "a"]`,
+			want:        `[var.x, "1"]`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `[var.x, global.truer ? [] : {}]`,
+			want:        `[var.x, true ? [] : {}]`,
+			hasUnknowns: true,
+		},
+		{
+			simplify:    true,
+			expr:        `var.x ? 1+1 : 2`,
+			want:        `var.x ? 2 : 2`,
+			hasUnknowns: true,
+		},
+		{
+			// errors are left for the tool consuming the generated code.
+			simplify:    true,
+			expr:        `[var.x, global.string+1]`,
+			want:        `[var.x, "terramate" + 1]`,
+			hasUnknowns: true,
+		},
+		{
+			// the expressions are simplified only if enabled.
+			expr:        `1+global.number+var.x`,
+			want:        `1 + 10 + var.x`,
+			hasUnknowns: true,
+		},
+		{
+			expr:        `global.truer ? var.x : var.y`,
+			want:        `true ? var.x : var.y`,
+			hasUnknowns: true,
+		},
 	} {
 		tc := tc
 		t.Run(tc.expr, func(t *testing.T) {
 			t.Parallel()
 			ctx := eval.NewContext(stdlib.Functions(os.TempDir(), []string{}))
+			ctx.SetSimplify(tc.simplify)
 			ctx.SetNamespace("global", map[string]cty.Value{
 				"number": cty.NumberIntVal(10),
 				"string": cty.StringVal("terramate"),
@@ -0,0 +1,171 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SensitiveMask is shown in place of sensitive values.
+const SensitiveMask = "(sensitive value)"
+
+// MarkSensitive marks the variable at the given path of the namespace as
+// sensitive. Any expression referencing the variable, any of its nested values
+// or any of its parent objects is considered sensitive.
+func (c *Context) MarkSensitive(namespace string, path ObjectPath) {
+	if c.sensitive == nil {
+		c.sensitive = map[string]struct{}{}
+	}
+	c.sensitive[deprecationKey(namespace, path)] = struct{}{}
+}
+
+// SensitiveFrom marks as sensitive all the sensitive values of the given
+// object, which is expected to be set as the given namespace.
+func (c *Context) SensitiveFrom(namespace string, obj *Object) {
+	for _, path := range obj.SensitivePaths() {
+		c.MarkSensitive(namespace, path)
+	}
+}
+
+// IsSensitive tells if the expression references any sensitive variable.
+func (c *Context) IsSensitive(expr hhcl.Expression) bool {
+	if len(c.sensitive) == 0 {
+		return false
+	}
+	for _, traversal := range expr.Variables() {
+		key := traversal.RootName()
+	steps:
+		for _, step := range traversal[1:] {
+			switch attr := step.(type) {
+			case hhcl.TraverseAttr:
+				key += "." + attr.Name
+			case hhcl.TraverseIndex:
+				if !attr.Key.IsKnown() || !attr.Key.Type().Equals(cty.String) {
+					break steps
+				}
+				key += "." + attr.Key.AsString()
+			default:
+				break steps
+			}
+			if _, ok := c.sensitive[key]; ok {
+				return true
+			}
+		}
+		// the traversal references the whole object containing some
+		// sensitive value.
+		for sensitiveKey := range c.sensitive {
+			if strings.HasPrefix(sensitiveKey, key+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Context) copySensitive(from *Context) {
+	for k := range from.sensitive {
+		if c.sensitive == nil {
+			c.sensitive = map[string]struct{}{}
+		}
+		c.sensitive[k] = struct{}{}
+	}
+}
+
+// SensitivePaths returns the paths of the sensitive values of the object.
+// The nested values of a sensitive value are not returned.
+func (obj *Object) SensitivePaths() []ObjectPath {
+	var paths []ObjectPath
+	var walk func(path ObjectPath, obj *Object)
+	walk = func(path ObjectPath, obj *Object) {
+		for key, val := range obj.Keys {
+			valpath := append(append(ObjectPath{}, path...), key)
+			if val.Info().Sensitive {
+				paths = append(paths, valpath)
+				continue
+			}
+			if val.IsObject() {
+				walk(valpath, val.(*Object))
+			}
+		}
+	}
+	walk(nil, obj)
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Join(paths[i], ".") < strings.Join(paths[j], ".")
+	})
+	return paths
+}
+
+// SensitiveStrings returns all the strings nested in the sensitive values of
+// the object, which must be redacted from any output of the commands using
+// them.
+func (obj *Object) SensitiveStrings() []string {
+	var strs []string
+	for _, path := range obj.SensitivePaths() {
+		val, _ := obj.GetKeyPath(path)
+		strs = append(strs, nestedStrings(valueOf(val))...)
+	}
+	return strs
+}
+
+// AsMaskedValueMap is like [Object.AsValueMap] but with the sensitive values
+// replaced by [SensitiveMask].
+func (obj *Object) AsMaskedValueMap() map[string]cty.Value {
+	vmap := map[string]cty.Value{}
+	for k, v := range obj.Keys {
+		switch {
+		case v.Info().Sensitive:
+			vmap[k] = cty.StringVal(SensitiveMask)
+		case v.IsObject():
+			vmap[k] = cty.ObjectVal(v.(*Object).AsMaskedValueMap())
+		default:
+			vmap[k] = v.(CtyValue).Raw()
+		}
+	}
+	return vmap
+}
+
+func valueOf(val Value) cty.Value {
+	if val.IsObject() {
+		return cty.ObjectVal(val.(*Object).AsValueMap())
+	}
+	return val.(CtyValue).Raw()
+}
+
+func nestedStrings(val cty.Value) []string {
+	if val.IsNull() || !val.IsKnown() {
+		return nil
+	}
+	if val.CanIterateElements() {
+		var strs []string
+		for it := val.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			strs = append(strs, nestedStrings(elem)...)
+		}
+		return strs
+	}
+	if val.Type() != cty.String || val.AsString() == "" {
+		return nil
+	}
+	return []string{val.AsString()}
+}
+
+// Redact replaces all the occurrences of the given sensitive strings in str
+// by [SensitiveMask]. The longest strings are replaced first.
+func Redact(str string, sensitive []string) string {
+	sorted := append([]string{}, sensitive...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	for _, s := range sorted {
+		if s == "" {
+			continue
+		}
+		str = strings.ReplaceAll(str, s, SensitiveMask)
+	}
+	return str
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The functions below simplify the partially evaluated expressions which
+// still have unknowns, so the generated code doesn't keep the operations
+// which can be computed by Terramate, like the `1 + 2` in `1 + 2 + var.x`.
+// They are used only if enabled by [Context.SetSimplify].
+// The simplification is best effort: if the folded expression fails to
+// evaluate then it's kept as-is and the error is reported by the tool
+// consuming the generated code.
+
+// foldOp folds the operation if all its operands are literals.
+func foldOp(expr hclsyntax.Expression, operands ...hclsyntax.Expression) hclsyntax.Expression {
+	for _, operand := range operands {
+		if !isLiteral(operand) {
+			return expr
+		}
+	}
+	return foldLiteral(expr)
+}
+
+// foldCond returns the branch of the conditional selected by its literal
+// condition. It must only be used if the branches have unknowns, otherwise
+// their types must be unified by evaluating the whole conditional.
+func foldCond(cond *hclsyntax.ConditionalExpr) hclsyntax.Expression {
+	lit, ok := cond.Condition.(*hclsyntax.LiteralValueExpr)
+	if !ok || lit.Val.IsNull() || !lit.Val.IsKnown() || lit.Val.Type() != cty.Bool {
+		return cond
+	}
+	if lit.Val.True() {
+		return cond.TrueResult
+	}
+	return cond.FalseResult
+}
+
+// foldParen removes the parentheses around literals.
+func foldParen(paren *hclsyntax.ParenthesesExpr) hclsyntax.Expression {
+	if lit, ok := paren.Expression.(*hclsyntax.LiteralValueExpr); ok {
+		return lit
+	}
+	return paren
+}
+
+// foldTmplWrap unwraps the interpolation of a template with only literal
+// parts, then `"${"a"}-${var.x}"` is generated as `"a-${var.x}"`.
+func foldTmplWrap(wrap *hclsyntax.TemplateWrapExpr) hclsyntax.Expression {
+	if tmpl, ok := wrap.Wrapped.(*hclsyntax.TemplateExpr); ok && isLiteral(tmpl) {
+		return tmpl
+	}
+	return wrap
+}
+
+// isLiteral tells if the expression is a literal or a string template with
+// only literal parts.
+func isLiteral(expr hclsyntax.Expression) bool {
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		return true
+	case *hclsyntax.TemplateExpr:
+		for _, part := range e.Parts {
+			if _, ok := part.(*hclsyntax.LiteralValueExpr); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func foldLiteral(expr hclsyntax.Expression) hclsyntax.Expression {
+	val, diags := expr.Value(&hhcl.EvalContext{})
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return expr
+	}
+	return &hclsyntax.LiteralValueExpr{
+		Val:      val,
+		SrcRange: expr.Range(),
+	}
+}
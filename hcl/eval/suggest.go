@@ -0,0 +1,197 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/project"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxSuggestions is the maximum number of suggestions given for an undefined
+// variable.
+const maxSuggestions = 3
+
+// SetDefinitionDir sets the directory whose configuration defines the
+// variables of the namespace. The variables are assumed to be inherited from
+// the parent directories, so an undefined variable of the namespace is
+// reported together with all the directories where it could be defined.
+func (c *Context) SetDefinitionDir(namespace string, dir project.Path) {
+	if c.defdirs == nil {
+		c.defdirs = map[string]project.Path{}
+	}
+	c.defdirs[namespace] = dir
+}
+
+func (c *Context) copyDefinitionDirs(from *Context) {
+	for k, v := range from.defdirs {
+		c.SetDefinitionDir(k, v)
+	}
+}
+
+// explainUndefined improves the diagnostics of references to undefined
+// global and let variables with suggestions of similarly named variables
+// and the directories where the variable could be defined.
+func (c *Context) explainUndefined(expr hhcl.Expression, diags hhcl.Diagnostics) {
+	for _, diag := range diags {
+		if diag.Severity != hhcl.DiagError || diag.Subject == nil {
+			continue
+		}
+		for _, traversal := range expr.Variables() {
+			rng := traversal.SourceRange()
+			if rng.Filename != diag.Subject.Filename ||
+				!rng.ContainsOffset(diag.Subject.Start.Byte) {
+				continue
+			}
+			if explanation, ok := c.undefinedVariable(traversal); ok {
+				diag.Detail = strings.TrimSpace(diag.Detail + " " + explanation)
+			}
+			break
+		}
+	}
+}
+
+// undefinedVariable explains the first undefined key referenced by the
+// traversal, if any.
+func (c *Context) undefinedVariable(traversal hhcl.Traversal) (string, bool) {
+	namespace := traversal.RootName()
+	if namespace != "global" && namespace != "let" {
+		return "", false
+	}
+	val, ok := c.hclctx.Variables[namespace]
+	if !ok {
+		return "", false
+	}
+
+	path := namespace
+	for _, step := range traversal[1:] {
+		var name string
+		switch attr := step.(type) {
+		case hhcl.TraverseAttr:
+			name = attr.Name
+		case hhcl.TraverseIndex:
+			if !attr.Key.IsKnown() || attr.Key.IsNull() || !attr.Key.Type().Equals(cty.String) {
+				return "", false
+			}
+			name = attr.Key.AsString()
+		default:
+			return "", false
+		}
+
+		if !val.IsKnown() || val.IsNull() {
+			return "", false
+		}
+
+		var keys []string
+		typ := val.Type()
+		switch {
+		case typ.IsObjectType():
+			if typ.HasAttribute(name) {
+				val = val.GetAttr(name)
+				path += "." + name
+				continue
+			}
+			for key := range typ.AttributeTypes() {
+				keys = append(keys, key)
+			}
+		case typ.IsMapType():
+			if val.HasIndex(cty.StringVal(name)).True() {
+				val = val.Index(cty.StringVal(name))
+				path += "." + name
+				continue
+			}
+			for it := val.ElementIterator(); it.Next(); {
+				key, _ := it.Element()
+				keys = append(keys, key.AsString())
+			}
+		default:
+			return "", false
+		}
+
+		explanation := fmt.Sprintf("%s.%s is undefined.", path, name)
+		if suggestions := Suggest(name, keys); len(suggestions) > 0 {
+			for i, s := range suggestions {
+				suggestions[i] = path + "." + s
+			}
+			if len(suggestions) == 1 {
+				explanation += fmt.Sprintf(" Did you mean %s?", suggestions[0])
+			} else {
+				explanation += fmt.Sprintf(" Did you mean one of %s?", strings.Join(suggestions, ", "))
+			}
+		}
+		if dir, ok := c.defdirs[namespace]; ok {
+			explanation += fmt.Sprintf(" It can be defined in any of the directories: %s.",
+				strings.Join(parentDirs(dir), ", "))
+		}
+		return explanation, true
+	}
+	return "", false
+}
+
+// Suggest returns the keys similar to name, the most similar first.
+func Suggest(name string, keys []string) []string {
+	type candidate struct {
+		key  string
+		dist int
+	}
+	maxdist := 2
+	if len(name) < 4 {
+		maxdist = 1
+	}
+	var candidates []candidate
+	for _, key := range keys {
+		dist := editDistance(strings.ToLower(name), strings.ToLower(key))
+		if dist <= maxdist {
+			candidates = append(candidates, candidate{key: key, dist: dist})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].key < candidates[j].key
+	})
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].key)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// parentDirs returns the dir and all its parent directories, up to the
+// project root.
+func parentDirs(dir project.Path) []string {
+	dirs := []string{dir.String()}
+	for dir.String() != "/" && dir.String() != "." {
+		dir = dir.Dir()
+		dirs = append(dirs, dir.String())
+	}
+	return dirs
+}
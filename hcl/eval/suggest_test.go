@@ -0,0 +1,100 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package eval_test
+
+import (
+	"strings"
+	"testing"
+
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEvalUndefinedVariableSuggestions(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		expr    string
+		defdir  string
+		wantErr string
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:    "typo in global",
+			expr:    `global.environmnet`,
+			wantErr: "global.environmnet is undefined. Did you mean global.environment?",
+		},
+		{
+			name:    "typo in nested global",
+			expr:    `global.obj.nmae`,
+			wantErr: "global.obj.nmae is undefined. Did you mean global.obj.name?",
+		},
+		{
+			name:    "typo in global with index",
+			expr:    `global["obj"]["nam"]`,
+			wantErr: "global.obj.nam is undefined. Did you mean global.obj.name?",
+		},
+		{
+			name:    "multiple suggestions",
+			expr:    `global.regio`,
+			wantErr: "global.regio is undefined. Did you mean one of global.region, global.regions?",
+		},
+		{
+			name:    "no similar global",
+			expr:    `global.unrelated`,
+			wantErr: "global.unrelated is undefined.",
+		},
+		{
+			name:    "global with definition dir",
+			expr:    `global.environmnet`,
+			defdir:  "/stacks/prod",
+			wantErr: "It can be defined in any of the directories: /stacks/prod, /stacks, /.",
+		},
+		{
+			name:    "typo in let",
+			expr:    `"${let.valeu}-suffix"`,
+			wantErr: "let.valeu is undefined. Did you mean let.value?",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := eval.NewContext(stdlib.Functions(root(t), []string{}))
+			ctx.SetNamespace("global", map[string]cty.Value{
+				"environment": cty.StringVal("prod"),
+				"region":      cty.StringVal("eu-west-1"),
+				"regions":     cty.ListVal([]cty.Value{cty.StringVal("eu-west-1")}),
+				"obj": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("name"),
+				}),
+			})
+			ctx.SetNamespace("let", map[string]cty.Value{
+				"value": cty.StringVal("value"),
+			})
+			if tc.defdir != "" {
+				ctx.SetDefinitionDir("global", project.NewPath(tc.defdir))
+			}
+
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.expr), "test.hcl", hhcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("expr %q is not valid: %v", tc.expr, diags)
+			}
+
+			_, err := ctx.Eval(expr)
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
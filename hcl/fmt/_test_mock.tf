@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "fmt" {
-  content = <<-EOT
+  content = <<-EOF
 package fmt // import "github.com/terramate-io/terramate/hcl/fmt"
 
 Package fmt contains functions for formatting hcl config.
@@ -14,7 +14,7 @@ func FormatMultiline(src, filename string) (string, error)
 type FormatResult struct{ ... }
     func FormatFiles(basedir string, files []string) ([]FormatResult, error)
     func FormatTree(dir string) ([]FormatResult, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-fmt.ignore"
 }
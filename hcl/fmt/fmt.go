@@ -34,14 +34,21 @@ type FormatResult struct {
 // It enforces lists to be formatted as multiline, where each
 // element on the list resides on its own line followed by a comma.
 //
+// The indentation and maximum line width can be customized with the
+// WithIndentSize and WithMaxLineWidth options.
+//
 // It returns an error if the given source is invalid HCL.
-func FormatMultiline(src, filename string) (string, error) {
+func FormatMultiline(src, filename string, opts ...Option) (string, error) {
 	parsed, diags := hclwrite.ParseConfig([]byte(src), filename, hcl.InitialPos)
 	if diags.HasErrors() {
 		return "", errors.E(ErrHCLSyntax, diags)
 	}
 	fmtBody(parsed.Body())
-	return string(hclwrite.Format(parsed.Bytes())), nil
+	formatted, err := layout(hclwrite.Format(parsed.Bytes()), filename, newOptions(opts))
+	if err != nil {
+		return "", err
+	}
+	return string(formatted), nil
 }
 
 // Format will format the given source code using hcl.Format.
@@ -58,21 +65,22 @@ func Format(src, filename string) (string, error) {
 // in the given tree starting at the given dir. It will recursively
 // navigate on sub directories. Directories starting with "." are ignored.
 //
-// Only Terramate configuration files will be formatted.
+// Only Terramate configuration files will be formatted, including the files
+// with any of the given configSuffixes (see fs.ListTerramateFiles).
 //
 // Files that are already formatted are ignored. If all files are formatted
 // this function returns an empty result.
 //
 // All files will be left untouched. To save the formatted result on disk you
 // can use FormatResult.Save for each FormatResult.
-func FormatTree(dir string) ([]FormatResult, error) {
+func FormatTree(dir string, configSuffixes ...string) ([]FormatResult, error) {
 	logger := log.With().
 		Str("action", "FormatTree").
 		Str("dir", dir).
 		Logger()
 
 	// TODO(i4k): use files from the config tree.
-	res, err := fs.ListTerramateFiles(dir)
+	res, err := fs.ListTerramateFiles(dir, configSuffixes...)
 	if err != nil {
 		return nil, errors.E(errFormatTree, err)
 	}
@@ -83,7 +91,13 @@ func FormatTree(dir string) ([]FormatResult, error) {
 		}
 	}
 
-	files := append([]string{}, res.TmFiles...)
+	files := []string{}
+	for _, fname := range res.TmFiles {
+		// the JSON syntax is not formatted.
+		if !fs.IsTerramateJSONFile(fname) {
+			files = append(files, fname)
+		}
+	}
 	files = append(files, res.TmGenFiles...)
 
 	sort.Strings(files)
@@ -94,7 +108,7 @@ func FormatTree(dir string) ([]FormatResult, error) {
 	errs.Append(err)
 
 	for _, d := range res.Dirs {
-		subres, err := FormatTree(filepath.Join(dir, d))
+		subres, err := FormatTree(filepath.Join(dir, d), configSuffixes...)
 		if err != nil {
 			errs.Append(err)
 			continue
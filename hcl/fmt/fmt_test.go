@@ -1275,6 +1275,142 @@ var = [
 	}
 }
 
+func TestFormatMultilineOptions(t *testing.T) {
+	t.Parallel()
+	type testcase struct {
+		name  string
+		input string
+		opts  []fmt.Option
+		want  string
+	}
+
+	tcases := []testcase{
+		{
+			name: "indent size",
+			input: `
+a {
+  b {
+    c = [1, 2]
+  }
+}
+`,
+			opts: []fmt.Option{fmt.WithIndentSize(4)},
+			want: `
+a {
+    b {
+        c = [
+            1,
+            2,
+        ]
+    }
+}
+`,
+		},
+		{
+			name: "indent size preserves heredocs",
+			input: `
+a {
+  b = <<-EOT
+    text
+  EOT
+}
+`,
+			opts: []fmt.Option{fmt.WithIndentSize(4)},
+			want: `
+a {
+    b = <<-EOT
+    text
+  EOT
+}
+`,
+		},
+		{
+			name: "long function call is wrapped",
+			input: `
+a = tm_format("%s-%s-%s", global.first, global.second, global.third)
+b = tm_upper("short")
+`,
+			opts: []fmt.Option{fmt.WithMaxLineWidth(40)},
+			want: `
+a = tm_format(
+  "%s-%s-%s",
+  global.first,
+  global.second,
+  global.third,
+)
+b = tm_upper("short")
+`,
+		},
+		{
+			name: "nested long function calls are wrapped",
+			input: `
+a = tm_upper(tm_format("%s-%s", global.first, global.second))
+`,
+			opts: []fmt.Option{fmt.WithMaxLineWidth(30)},
+			want: `
+a = tm_upper(
+  tm_format(
+    "%s-%s",
+    global.first,
+    global.second,
+  ),
+)
+`,
+		},
+		{
+			name: "function calls inside string templates are not wrapped",
+			input: `
+a = "${tm_upper(global.aaaaaaaaaaaaaaaa)}"
+`,
+			opts: []fmt.Option{fmt.WithMaxLineWidth(10)},
+			want: `
+a = "${tm_upper(global.aaaaaaaaaaaaaaaa)}"
+`,
+		},
+		{
+			name: "line width considers the indent size",
+			input: `
+a {
+  b = tm_concat(global.a, global.b)
+}
+`,
+			opts: []fmt.Option{
+				fmt.WithIndentSize(8),
+				fmt.WithMaxLineWidth(40),
+			},
+			want: `
+a {
+        b = tm_concat(
+                global.a,
+                global.b,
+        )
+}
+`,
+		},
+	}
+
+	for _, tcase := range tcases {
+		tcase := tcase
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := fmt.FormatMultiline(tcase.input, "test-input.hcl", tcase.opts...)
+			assert.NoError(t, err)
+
+			if diff := cmp.Diff(got, tcase.want); diff != "" {
+				t.Errorf("got:\n%s", got)
+				t.Errorf("want:\n%s", tcase.want)
+				t.Error("diff:")
+				t.Fatal(diff)
+			}
+
+			got2, err := fmt.FormatMultiline(got, "formatted.hcl", tcase.opts...)
+			assert.NoError(t, err)
+			assert.EqualStrings(t, got, got2, "reformatting should produce identical results")
+		})
+	}
+}
+
 func TestFormatHCL(t *testing.T) {
 	type testcase struct {
 		name     string
@@ -1507,6 +1643,25 @@ a = 1
 	assert.EqualInts(t, 0, len(got), "want no results, got: %v", got)
 }
 
+func TestFormatTreeConfigSuffixes(t *testing.T) {
+	t.Parallel()
+
+	const unformattedCode = `
+a = 1
+ b = "la"
+`
+
+	tmpdir := test.TempDir(t)
+	test.WriteFile(t, tmpdir, "file.hcl", unformattedCode)
+	test.Mkdir(t, tmpdir, "sub")
+	test.WriteFile(t, filepath.Join(tmpdir, "sub"), "stack.terramate.hcl", unformattedCode)
+
+	got, err := fmt.FormatTree(tmpdir, ".terramate.hcl")
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(got), "want one result, got: %v", got)
+	assert.EqualStrings(t, filepath.Join(tmpdir, "sub", "stack.terramate.hcl"), got[0].Path())
+}
+
 func TestFormatTreeSupportsTmSkip(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,233 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package fmt
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// DefaultIndentSize is the number of spaces per indentation level used by
+// hclwrite when formatting code.
+const DefaultIndentSize = 2
+
+// Option is a formatting option for FormatMultiline.
+type Option func(*options)
+
+type options struct {
+	indentSize   int
+	maxLineWidth int
+}
+
+type edit struct {
+	pos  int
+	text string
+}
+
+// WithIndentSize is an option to set the number of spaces used per
+// indentation level. The default is DefaultIndentSize.
+func WithIndentSize(size int) Option {
+	return func(o *options) {
+		o.indentSize = size
+	}
+}
+
+// WithMaxLineWidth is an option to set the maximum line width. Function calls
+// on lines exceeding it are wrapped with one argument per line.
+// Zero, the default, means no limit.
+func WithMaxLineWidth(width int) Option {
+	return func(o *options) {
+		o.maxLineWidth = width
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		indentSize: DefaultIndentSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// layout applies the indentation and line width options on the given code
+// already formatted by hclwrite.
+func layout(code []byte, filename string, o options) ([]byte, error) {
+	if o.maxLineWidth > 0 {
+		for {
+			tokens, diags := hclsyntax.LexConfig(code, filename, hcl.InitialPos)
+			if diags.HasErrors() {
+				return nil, errors.E(ErrHCLSyntax, diags)
+			}
+			verbatim := verbatimLines(tokens)
+			edits, ok := wrapLongLine(code, tokens, verbatim, o)
+			if !ok {
+				break
+			}
+			code = hclwrite.Format(applyEdits(code, edits))
+		}
+	}
+
+	if o.indentSize == DefaultIndentSize {
+		return code, nil
+	}
+
+	tokens, diags := hclsyntax.LexConfig(code, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, errors.E(ErrHCLSyntax, diags)
+	}
+	verbatim := verbatimLines(tokens)
+	lines := bytes.Split(code, []byte("\n"))
+	for i, line := range lines {
+		if verbatim[i+1] {
+			continue
+		}
+		lines[i] = reindent(line, o.indentSize)
+	}
+	return bytes.Join(lines, []byte("\n")), nil
+}
+
+// wrapLongLine finds the first line exceeding the maximum width which has a
+// single line function call and returns the edits needed to wrap its arguments.
+func wrapLongLine(code []byte, tokens hclsyntax.Tokens, verbatim map[int]bool, o options) ([]edit, bool) {
+	for i, line := range bytes.Split(code, []byte("\n")) {
+		lineno := i + 1
+		if verbatim[lineno] || lineWidth(line, o.indentSize) <= o.maxLineWidth {
+			continue
+		}
+		if edits, ok := wrapFuncCall(tokens, lineno); ok {
+			return edits, true
+		}
+	}
+	return nil, false
+}
+
+// wrapFuncCall returns the edits needed to wrap the arguments of the
+// outermost function call fully contained in the given line.
+func wrapFuncCall(tokens hclsyntax.Tokens, lineno int) ([]edit, bool) {
+	templateDepth := 0
+	for i := 0; i+1 < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.Type {
+		case hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			templateDepth++
+			continue
+		case hclsyntax.TokenTemplateSeqEnd:
+			templateDepth--
+			continue
+		}
+		if templateDepth > 0 || tok.Type != hclsyntax.TokenIdent ||
+			tok.Range.Start.Line != lineno ||
+			tokens[i+1].Type != hclsyntax.TokenOParen {
+			continue
+		}
+
+		closeParen, commas := matchParen(tokens, i+1)
+		if closeParen == -1 ||
+			tokens[closeParen].Range.Start.Line != lineno ||
+			closeParen == i+2 {
+			continue
+		}
+
+		edits := []edit{{pos: tokens[i+1].Range.End.Byte, text: "\n"}}
+		for _, comma := range commas {
+			edits = append(edits, edit{pos: tokens[comma].Range.End.Byte, text: "\n"})
+		}
+		switch tokens[closeParen-1].Type {
+		case hclsyntax.TokenComma:
+			// already wrapped by the trailing comma.
+		case hclsyntax.TokenEllipsis:
+			edits = append(edits, edit{pos: tokens[closeParen].Range.Start.Byte, text: "\n"})
+		default:
+			edits = append(edits, edit{pos: tokens[closeParen].Range.Start.Byte, text: ",\n"})
+		}
+		return edits, true
+	}
+	return nil, false
+}
+
+// matchParen returns the position of the parenthesis closing the one at the
+// given position and the position of its top level commas.
+func matchParen(tokens hclsyntax.Tokens, open int) (int, []int) {
+	var commas []int
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].Type {
+		case hclsyntax.TokenOParen, hclsyntax.TokenOBrack, hclsyntax.TokenOBrace,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			depth++
+		case hclsyntax.TokenCParen, hclsyntax.TokenCBrack, hclsyntax.TokenCBrace,
+			hclsyntax.TokenTemplateSeqEnd:
+			depth--
+			if depth == 0 {
+				return i, commas
+			}
+		case hclsyntax.TokenComma:
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		}
+	}
+	return -1, nil
+}
+
+// verbatimLines returns the lines that must be kept untouched, ie.: the
+// contents of heredocs and multiline comments.
+func verbatimLines(tokens hclsyntax.Tokens) map[int]bool {
+	verbatim := map[int]bool{}
+	heredocStart := 0
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenOHeredoc:
+			heredocStart = tok.Range.Start.Line
+		case hclsyntax.TokenCHeredoc:
+			for line := heredocStart + 1; line <= tok.Range.Start.Line; line++ {
+				verbatim[line] = true
+			}
+		case hclsyntax.TokenComment:
+			if !bytes.HasPrefix(tok.Bytes, []byte("/*")) {
+				continue
+			}
+			for line := tok.Range.Start.Line + 1; line <= tok.Range.End.Line; line++ {
+				verbatim[line] = true
+			}
+		}
+	}
+	return verbatim
+}
+
+func applyEdits(code []byte, edits []edit) []byte {
+	var b bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		b.Write(code[last:e.pos])
+		b.WriteString(e.text)
+		last = e.pos
+	}
+	b.Write(code[last:])
+	return b.Bytes()
+}
+
+// reindent converts the indentation of the given line, formatted with
+// DefaultIndentSize spaces per level, to the given size.
+func reindent(line []byte, size int) []byte {
+	trimmed := bytes.TrimLeft(line, " ")
+	if len(trimmed) == 0 {
+		return line
+	}
+	spaces := len(line) - len(trimmed)
+	indent := (spaces/DefaultIndentSize)*size + spaces%DefaultIndentSize
+	return append([]byte(strings.Repeat(" ", indent)), trimmed...)
+}
+
+func lineWidth(line []byte, size int) int {
+	return utf8.RuneCount(reindent(line, size))
+}
@@ -4,20 +4,27 @@
 package hcl
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
-	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/hcl/v2"
 	"github.com/terramate-io/hcl/v2/hclparse"
 	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/config/filter"
+	"github.com/terramate-io/terramate/config/tag"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/fs"
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/terramate-io/terramate/hcl/eval"
@@ -25,6 +32,7 @@ import (
 	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/safeguard"
 	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty"
 	"golang.org/x/exp/slices"
 )
@@ -37,6 +45,10 @@ const (
 	ErrImport            errors.Kind = "import error"
 )
 
+// ErrImportIntegrity indicates that an imported file does not match the
+// integrity hash pinned in the import block.
+const ErrImportIntegrity errors.Kind = "import integrity check failed"
+
 // OptionalCheck is a bool that can also have no configured value.
 type OptionalCheck int
 
@@ -77,6 +89,7 @@ type Config struct {
 	SharingBackends SharingBackends
 	Inputs          Inputs
 	Outputs         Outputs
+	Providers       []ProvidersConfig
 
 	Imported RawConfig
 
@@ -85,6 +98,16 @@ type Config struct {
 
 	// absdir is the absolute path to the configuration directory.
 	absdir string
+
+	// sandbox is the sandbox set with the [WithSandbox] parser option.
+	sandbox *stdlib.Sandbox
+
+	// functions are the custom functions set with the [WithFunctions] parser
+	// option.
+	functions *stdlib.Registry
+
+	// fileLets are the top-level lets blocks merged by file host path.
+	fileLets map[string]*ast.MergedBlock
 }
 
 // GenerateConfig includes code generation related configurations, like
@@ -100,12 +123,17 @@ type AssertConfig struct {
 	Warning   hcl.Expression
 	Assertion hcl.Expression
 	Message   hcl.Expression
+	Code      hcl.Expression
+	DocsURL   hcl.Expression
 }
 
 // StackFilterConfig represents Terramate stack_filter configuration block.
 type StackFilterConfig struct {
 	ProjectPaths    []glob.Glob
 	RepositoryPaths []glob.Glob
+
+	// Tags is the tag filter expression the stack tags must match, if any.
+	Tags filter.TagClause
 }
 
 // SharingBackendType is the type of the sharing backend.
@@ -179,6 +207,58 @@ type RunConfig struct {
 
 	// Env contains environment definitions for run.
 	Env *RunEnv
+
+	// Policy restricts the commands which can be executed, if any.
+	Policy *RunPolicyConfig
+
+	// Lock configures the locking of stacks during execution, if any.
+	Lock *RunLockConfig
+
+	// ExportGlobalsAsTFVars are the patterns of the globals exported as
+	// TF_VAR_* environment variables when running commands in the stacks.
+	ExportGlobalsAsTFVars []string
+
+	// ExportGlobalsAsTFVarsRange is the range of the
+	// terramate.config.run.export_globals_as_tfvars attribute.
+	ExportGlobalsAsTFVarsRange info.Range
+}
+
+// RunLockConfig represents the terramate.config.run.lock block.
+type RunLockConfig struct {
+	// Backend is the locking backend, "file", "gcs" or "dynamodb".
+	Backend string
+
+	// Path is the directory where the file backend keeps the locks. If
+	// relative, it is relative to the project root.
+	Path string
+
+	// Bucket is the Google Cloud Storage bucket where the gcs backend keeps
+	// the locks.
+	Bucket string
+
+	// Prefix is prepended to the names of the lock objects of the gcs
+	// backend and to the keys of the lock items of the dynamodb backend.
+	Prefix string
+
+	// Table is the DynamoDB table where the dynamodb backend keeps the
+	// locks.
+	Table string
+
+	// Region is the AWS region of the DynamoDB table, the region of the AWS
+	// configuration if empty.
+	Region string
+}
+
+// RunPolicyConfig represents the terramate.config.run.policy block.
+type RunPolicyConfig struct {
+	// AllowedCommands are glob patterns matching the commands, with their
+	// arguments separated by a single space, which are allowed to run.
+	// If empty, any command is allowed.
+	AllowedCommands []string
+
+	// DeniedFlags are command flags which are not allowed to be used
+	// outside of CI/CD.
+	DeniedFlags []string
 }
 
 // RunEnv represents Terramate run environment.
@@ -203,6 +283,14 @@ type GitConfig struct {
 
 	// CheckRemote enables checking if local default branch is updated with remote.
 	CheckRemote OptionalCheck
+
+	// FetchMissingBaseRef enables fetching the change detection base ref from
+	// the default remote when it's missing, eg.: in shallow clones.
+	FetchMissingBaseRef bool
+
+	// FetchDepth is the number of commits fetched when FetchMissingBaseRef
+	// is enabled. Zero means a single commit.
+	FetchDepth int
 }
 
 // ChangeDetectionConfig is the `terramate.config.change_detection` config.
@@ -235,6 +323,95 @@ const (
 // GenerateRootConfig represents the AST node for the `terramate.config.generate` block.
 type GenerateRootConfig struct {
 	HCLMagicHeaderCommentStyle *string
+
+	// Fsync tells if generated files must be flushed to stable storage
+	// before the generation finishes.
+	Fsync *bool
+
+	// TrackHeaderlessFiles tells if the files generated by generate_file
+	// blocks in stacks must be tracked by the generate state file, as the
+	// generate_hcl blocks with no_header = true are.
+	TrackHeaderlessFiles *bool
+
+	// SimplifyExpressions tells if the partially evaluated expressions of
+	// the generate_hcl blocks must be simplified, folding their operations
+	// on known values.
+	SimplifyExpressions *bool
+
+	// IndentSize is the number of spaces used per indentation level
+	// of the generated HCL code.
+	IndentSize *int
+
+	// MaxLineWidth is the maximum line width of the generated HCL code.
+	// Function calls exceeding it are wrapped with one argument per line.
+	// Zero means no limit.
+	MaxLineWidth *int
+
+	// Output is the project default output encoding of generated files.
+	Output GenerateOutputConfig
+
+	// Timestamp is the RFC3339 timestamp pinned for tm_timestamp.
+	Timestamp string
+
+	// RandomSeed is the seed pinned for tm_random.
+	RandomSeed string
+
+	// FileTypes are the types required for the generated files, sorted by
+	// pattern.
+	FileTypes []GenerateFileType
+}
+
+// GenerateFileType is the type required for the generated files with a label
+// matching Pattern, set by `terramate.config.generate.file_types`.
+type GenerateFileType struct {
+	// Pattern is matched against the base name of the label or, if it has
+	// a slash, against the whole label.
+	Pattern string
+
+	// Type is one of the FileType constants.
+	Type string
+}
+
+// Supported types of the generated files.
+const (
+	// FileTypeGenerateHCL requires the file to be generated by generate_hcl.
+	FileTypeGenerateHCL = "generate_hcl"
+	// FileTypeHCL requires the file to be valid HCL.
+	FileTypeHCL = "hcl"
+	// FileTypeYAML requires the file to be valid YAML.
+	FileTypeYAML = "yaml"
+	// FileTypeJSON requires the file to be valid JSON.
+	FileTypeJSON = "json"
+)
+
+// Supported visibilities of the generate blocks.
+const (
+	// VisibilitySubtree applies the block to all the stacks in the subtree
+	// of its directory. It's the default.
+	VisibilitySubtree = "subtree"
+	// VisibilityDirectory applies the block only to the stack of its
+	// directory and to the stacks directly inside it.
+	VisibilityDirectory = "directory"
+)
+
+// Supported line endings of generated files.
+const (
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+)
+
+// GenerateOutputConfig represents the output encoding attributes of the
+// generated files. It's used by the generate_hcl and generate_file blocks and by
+// the `terramate.config.generate` block. Unset attributes are nil.
+type GenerateOutputConfig struct {
+	// LineEndings is either LineEndingsLF or LineEndingsCRLF.
+	LineEndings *string
+
+	// TrailingNewline tells if the generated file must end with a newline.
+	TrailingNewline *bool
+
+	// BOM tells if the generated file starts with a UTF-8 byte order mark.
+	BOM *bool
 }
 
 // CloudConfig represents Terramate cloud configuration.
@@ -245,6 +422,19 @@ type CloudConfig struct {
 	Targets *TargetsConfig
 
 	Location cloud.Region
+
+	Queue *CloudQueueConfig
+}
+
+// CloudQueueConfig represents the configuration of the queue of the cloud
+// payloads which could not be sent because Terramate Cloud was unreachable.
+type CloudQueueConfig struct {
+	// TTL is the time a payload is kept in the queue. Zero means the default.
+	TTL time.Duration
+
+	// MaxSize is the maximum size, in bytes, of the queue. Zero means the
+	// default.
+	MaxSize int64
 }
 
 // TargetsConfig represents Terramate targets configuration.
@@ -252,11 +442,85 @@ type TargetsConfig struct {
 	Enabled bool
 }
 
+// TargetsExperimentName is the name of the deployment targets experiment.
+const TargetsExperimentName = experiments.Targets
+
+// StackDefaultsConfig represents the `terramate.config.stack_defaults` block.
+// It can be declared in any directory and applies to all stacks in the
+// directory and its subdirectories.
+type StackDefaultsConfig struct {
+	// Description is the description of the stacks not declaring one.
+	Description string
+
+	// Tags are the tags added to the stacks.
+	Tags []string
+
+	// RemoveTags are the tags inherited from parent directories that are
+	// removed from the stacks.
+	RemoveTags []string
+
+	// Watch is the list of files added to the stacks watch list.
+	// Relative paths are relative to the directory declaring the block.
+	Watch []string
+
+	// After is the list of stacks, or tag filters, added to the stacks
+	// after list. Relative paths are relative to the directory declaring
+	// the block.
+	After []string
+
+	// Before is the list of stacks, or tag filters, added to the stacks
+	// before list. Relative paths are relative to the directory declaring
+	// the block.
+	Before []string
+}
+
+// Notification formats supported by the `terramate.config.notifications` block.
+const (
+	NotificationsFormatJSON  = "json"
+	NotificationsFormatSlack = "slack"
+)
+
+// Notification events supported by the `terramate.config.notifications` block.
+const (
+	NotificationsEventFailure = "failure"
+	NotificationsEventDrift   = "drift"
+)
+
+// NotificationsConfig represents the `terramate.config.notifications` block.
+type NotificationsConfig struct {
+	// URL is the webhook URL template. It's only evaluated when a
+	// notification is sent, so it can reference secrets from the `env`
+	// namespace.
+	URL hcl.Expression
+
+	// Format is the payload format, either NotificationsFormatJSON or
+	// NotificationsFormatSlack.
+	Format string
+
+	// Events are the events triggering a notification.
+	Events []string
+}
+
 // TelemetryConfig represents Terramate telemetry configuration.
 type TelemetryConfig struct {
 	Enabled *bool
 }
 
+// SandboxConfig represents the `terramate.config.sandbox` block.
+type SandboxConfig struct {
+	// Enabled tells if the configuration is evaluated in sandboxed mode.
+	Enabled bool
+
+	// Limits of the sandbox. The defaults are used for the unset limits.
+	Limits stdlib.Sandbox
+}
+
+// WarningsConfig represents the `terramate.config.warnings` block.
+type WarningsConfig struct {
+	// AsErrors are the codes of the warnings promoted to errors.
+	AsErrors []warnings.Code
+}
+
 // RootConfig represents the root config block of a Terramate configuration.
 type RootConfig struct {
 	Git               *GitConfig
@@ -267,6 +531,26 @@ type RootConfig struct {
 	Experiments       []string
 	DisableSafeguards safeguard.Keywords
 	Telemetry         *TelemetryConfig
+	StackDefaults     *StackDefaultsConfig
+	Notifications     *NotificationsConfig
+	Sandbox           *SandboxConfig
+	Warnings          *WarningsConfig
+
+	// FollowSymlinks enables the discovery of stacks inside symlinked
+	// directories.
+	FollowSymlinks bool
+
+	// ConfigFileSuffixes are additional suffixes of the files recognized as
+	// Terramate configuration files, eg.: ".terramate.hcl".
+	ConfigFileSuffixes []string
+
+	// Subprojects are the directories of embedded projects, with their own
+	// root configuration, which are not loaded as part of this project.
+	Subprojects project.Paths
+
+	// Strict makes the configuration that would be ignored, like the root
+	// configuration declared in other directories, an error.
+	Strict bool
 }
 
 // ManifestDesc represents a parsed manifest description.
@@ -336,6 +620,13 @@ type Stack struct {
 
 	// Watch is a list of files to be watched for changes.
 	Watch []string
+
+	// Extends is the template directory the stack inherits from.
+	Extends string
+
+	// ExecDir is the directory, relative to the stack, where the commands of
+	// the stack are executed.
+	ExecDir string
 }
 
 // GenHCLBlock represents a parsed generate_hcl block.
@@ -361,6 +652,21 @@ type GenHCLBlock struct {
 	// Inherit tells if the block is inherited in child directories.
 	Inherit *hclsyntax.Attribute
 
+	// Visibility is either VisibilitySubtree or VisibilityDirectory, as set
+	// by the visibility attribute.
+	Visibility string
+
+	// Output is the output encoding of the generated file.
+	Output GenerateOutputConfig
+
+	// SkipFormat tells if the generated code is written verbatim, without
+	// the canonical formatting, as set by the format attribute.
+	SkipFormat bool
+
+	// NoHeader tells if the generated code is written without the Terramate
+	// header, as set by the no_header attribute.
+	NoHeader bool
+
 	// IsImplicitBlock tells if the block is implicit (does not have a real generate_hcl block).
 	// This is the case for the "tmgen" feature.
 	IsImplicitBlock bool
@@ -383,6 +689,9 @@ type GenFileBlock struct {
 	StackFilters []StackFilterConfig
 	// Content attribute of the block
 	Content *hclsyntax.Attribute
+	// Outputs attribute of the block, a map of filename to content which
+	// generates one file for each entry. It is mutually exclusive with Content.
+	Outputs *hclsyntax.Attribute
 	// Context of the generation (stack by default).
 	Context string
 	// Asserts represents all assert blocks
@@ -390,6 +699,22 @@ type GenFileBlock struct {
 
 	// Inherit tells if the block is inherited in child directories.
 	Inherit *hclsyntax.Attribute
+
+	// Visibility is either VisibilitySubtree or VisibilityDirectory, as set
+	// by the visibility attribute.
+	Visibility string
+
+	// ForEachStack tells if the context=root block is generated once for each
+	// stack, with the label evaluated as a template for each stack.
+	ForEachStack *hclsyntax.Attribute
+
+	// Format is the encoding of the content. If empty, the content must be
+	// a string generated as-is, otherwise it's a value encoded in the format
+	// (only "yaml" is supported, by the generate_yaml block).
+	Format string
+
+	// Output is the output encoding of the generated file.
+	Output GenerateOutputConfig
 }
 
 // Evaluator represents a Terramate evaluator
@@ -409,6 +734,9 @@ type Evaluator interface {
 
 	// DeleteNamespace deletes a namespace.
 	DeleteNamespace(name string)
+
+	// HasNamespace tells if the namespace is defined.
+	HasNamespace(name string) bool
 }
 
 type parserState int
@@ -430,11 +758,18 @@ type TerramateParser struct {
 	Config   RawConfig
 	Imported RawConfig
 
+	// ImportedFiles are the files imported by the parsed configuration,
+	// including the ones imported indirectly.
+	ImportedFiles []ImportedFile
+
 	rootdir string
 	dir     string
 
 	// options
 	experiments               []string
+	sandbox                   *stdlib.Sandbox
+	functions                 *stdlib.Registry
+	configSuffixes            []string
 	strict                    bool
 	unmergedBlockHandlers     map[string]UnmergedBlockHandler
 	mergedBlockHandlers       map[string]MergedBlockHandler
@@ -453,6 +788,21 @@ type TerramateParser struct {
 	state parserState
 }
 
+// ImportedFile is a file imported by an import block.
+type ImportedFile struct {
+	// Origin is the range of the import.source attribute importing the file.
+	Origin info.Range
+
+	// HostPath is the absolute path of the imported file.
+	HostPath string
+
+	// SHA256 is the hex encoded SHA-256 digest of the imported file.
+	SHA256 string
+
+	// Pinned tells if the import block pins the SHA-256 digest of the file.
+	Pinned bool
+}
+
 // Option is a function that can be used to configure a TerramateParser.
 type Option func(*TerramateParser)
 
@@ -561,7 +911,12 @@ func NewTerramateParser(rootdir string, dir string, opts ...Option) (*TerramateP
 		opt(p)
 	}
 
-	p.evalctx = eval.NewContext(stdlib.Functions(dir, p.experiments))
+	funcs := p.functions.Functions(dir, p.experiments)
+	if p.sandbox != nil {
+		p.evalctx = p.sandbox.NewContext(funcs)
+	} else {
+		p.evalctx = eval.NewContext(funcs)
+	}
 
 	if len(p.unmergedBlockHandlers) == 0 {
 		for _, spec := range DefaultUnmergedBlockParsers() {
@@ -619,10 +974,11 @@ func (p *TerramateParser) addParsedFile(origin string, kind parsedKind, files ..
 	}
 }
 
-// AddDir walks over all the files in the directory dir and add all .tm and
-// .tm.hcl files to the parser.
+// AddDir walks over all the files in the directory dir and add all .tm,
+// .tm.hcl and .tm.json files, and the files with the suffixes set with the
+// [WithConfigFileSuffixes] option, to the parser.
 func (p *TerramateParser) AddDir(dir string) error {
-	res, err := fs.ListTerramateFiles(dir)
+	res, err := fs.ListTerramateFiles(dir, p.configSuffixes...)
 	if err != nil {
 		return errors.E(err, "adding directory to terramate parser")
 	}
@@ -729,8 +1085,12 @@ func (p *TerramateParser) ParsedBodies() map[string]*hclsyntax.Body {
 	return parsed
 }
 
-// Imports returns all import blocks.
+// Imports returns all import blocks of the parsed files. The files are only
+// syntax parsed if needed, so the imports are not applied.
 func (p *TerramateParser) Imports() (ast.Blocks, error) {
+	if err := p.parseSyntax(); err != nil {
+		return nil, err
+	}
 	errs := errors.L()
 
 	var imports ast.Blocks
@@ -791,12 +1151,18 @@ func (p *TerramateParser) parseSyntax() error {
 		p.state = syntaxParsedState
 	}()
 	errs := errors.L()
+	schema := p.topLevelSchema()
 	for _, name := range p.sortedFilenames() {
 		if _, ok := p.parsedFiles[name]; ok {
 			continue
 		}
 		data := p.files[name]
-		_, diags := p.hclparser.ParseHCL(data, name)
+		var diags hcl.Diagnostics
+		if fs.IsTerramateJSONFile(name) {
+			diags = parseJSON(p.hclparser, name, data, schema)
+		} else {
+			_, diags = p.hclparser.ParseHCL(data, name)
+		}
 		if diags.HasErrors() {
 			errs.Append(errors.E(ErrHCLSyntax, diags))
 			continue
@@ -826,6 +1192,16 @@ func (p *TerramateParser) applyImports() error {
 }
 
 func (p *TerramateParser) handleImport(importBlock *ast.Block) error {
+	if condAttr, ok := importBlock.Attributes["condition"]; ok {
+		enabled, err := p.evalImportCondition(condAttr)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return nil
+		}
+	}
+
 	srcAttr := importBlock.Attributes["source"]
 	srcVal, diags := srcAttr.Expr.Value(nil)
 	if diags.HasErrors() {
@@ -837,6 +1213,22 @@ func (p *TerramateParser) handleImport(importBlock *ast.Block) error {
 		return attrErr(srcAttr, "import.source must be a string")
 	}
 
+	var pinned string
+	if hashAttr, ok := importBlock.Attributes["sha256"]; ok {
+		hashVal, diags := hashAttr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return errors.E(ErrTerramateSchema, hashAttr.Expr.Range(),
+				"failed to evaluate import.sha256")
+		}
+		if hashVal.Type() != cty.String {
+			return attrErr(hashAttr, "import.sha256 must be a string")
+		}
+		pinned = strings.ToLower(hashVal.AsString())
+		if _, err := hex.DecodeString(pinned); err != nil || len(pinned) != sha256.Size*2 {
+			return attrErr(hashAttr, "import.sha256 must be a hex encoded SHA-256 digest")
+		}
+	}
+
 	src := srcVal.AsString()
 	srcBase := path.Base(src)
 	srcDir := path.Dir(src)
@@ -866,6 +1258,11 @@ func (p *TerramateParser) handleImport(importBlock *ast.Block) error {
 		return errors.E(ErrImport, srcAttr.Expr.Range(),
 			"import path %q returned no matches", srcVal.AsString())
 	}
+	if pinned != "" && len(matches) > 1 {
+		return errors.E(ErrImport, srcAttr.Expr.Range(),
+			"import.sha256 requires import.source to match a single file but %q matches %d files",
+			srcVal.AsString(), len(matches))
+	}
 	for _, file := range matches {
 		if _, ok := p.parsedFiles[file]; ok {
 			return errors.E(ErrImport, srcAttr.Expr.Range(),
@@ -891,6 +1288,17 @@ func (p *TerramateParser) handleImport(importBlock *ast.Block) error {
 			)
 		}
 
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.E(ErrImport, srcAttr.Expr.Range(), err)
+		}
+		sum := sha256.Sum256(content)
+		digest := hex.EncodeToString(sum[:])
+		if pinned != "" && digest != pinned {
+			return errors.E(ErrImportIntegrity, importBlock.Attributes["sha256"].Expr.Range(),
+				"file %q has sha256 %s but %s is pinned", file, digest, pinned)
+		}
+
 		fileDir := filepath.Dir(file)
 		importParser, err := NewTerramateParser(p.rootdir, fileDir)
 		if err != nil {
@@ -924,10 +1332,39 @@ func (p *TerramateParser) handleImport(importBlock *ast.Block) error {
 		}
 
 		p.addParsedFile(p.dir, external, file)
+		p.ImportedFiles = append(p.ImportedFiles, ImportedFile{
+			Origin:   srcAttr.Range,
+			HostPath: file,
+			SHA256:   digest,
+			Pinned:   pinned != "",
+		})
+		p.ImportedFiles = append(p.ImportedFiles, importParser.ImportedFiles...)
 	}
 	return nil
 }
 
+// evalImportCondition evaluates the import.condition attribute. The condition
+// has access to the functions and to the environment variables in the env
+// namespace, which is empty in sandboxed mode.
+func (p *TerramateParser) evalImportCondition(attr ast.Attribute) (bool, error) {
+	evalctx := p.evalctx.Copy()
+	if p.sandbox == nil {
+		evalctx.SetEnv(os.Environ())
+	} else {
+		evalctx.SetEnv(nil)
+	}
+	val, err := evalctx.Eval(attr.Expr)
+	if err != nil {
+		return false, errors.E(ErrImport, err, attr.Expr.Range(),
+			"failed to evaluate import.condition")
+	}
+	if val.Type() != cty.Bool || val.IsNull() {
+		return false, attrErr(attr, "import.condition must be a bool but %q given",
+			val.Type().FriendlyName())
+	}
+	return val.True(), nil
+}
+
 func (p *TerramateParser) sortedFilenames() []string {
 	filenames := []string{}
 	for fname := range p.files {
@@ -984,6 +1421,24 @@ func (c Config) HasRunEnv() bool {
 		c.Terramate.Config.Run.Env != nil
 }
 
+// StackDefaults returns the terramate.config.stack_defaults block of the
+// config or nil if not defined.
+func (c Config) StackDefaults() *StackDefaultsConfig {
+	if c.Terramate == nil || c.Terramate.Config == nil {
+		return nil
+	}
+	return c.Terramate.Config.StackDefaults
+}
+
+// Notifications returns the terramate.config.notifications block of the
+// configuration, if any.
+func (c Config) Notifications() *NotificationsConfig {
+	if c.Terramate == nil || c.Terramate.Config == nil {
+		return nil
+	}
+	return c.Terramate.Config.Notifications
+}
+
 // Experiments returns the config enabled experiments, if any.
 func (c Config) Experiments() []string {
 	if c.Terramate != nil &&
@@ -993,6 +1448,60 @@ func (c Config) Experiments() []string {
 	return []string{}
 }
 
+// Sandbox returns the sandbox limiting the evaluation of the configuration,
+// if the sandboxed mode is enabled with terramate.config.sandbox or with
+// the [WithSandbox] parser option.
+func (c Config) Sandbox() (stdlib.Sandbox, bool) {
+	if c.Terramate != nil &&
+		c.Terramate.Config != nil &&
+		c.Terramate.Config.Sandbox != nil &&
+		c.Terramate.Config.Sandbox.Enabled {
+		return c.Terramate.Config.Sandbox.Limits, true
+	}
+	if c.sandbox != nil {
+		return *c.sandbox, true
+	}
+	return stdlib.Sandbox{}, false
+}
+
+// Functions returns the registry of the custom functions set with the
+// [WithFunctions] parser option, which is nil if none was set.
+func (c Config) Functions() *stdlib.Registry {
+	return c.functions
+}
+
+// FollowSymlinks tells if terramate.config.follow_symlinks is enabled.
+func (c Config) FollowSymlinks() bool {
+	return c.Terramate != nil &&
+		c.Terramate.Config != nil &&
+		c.Terramate.Config.FollowSymlinks
+}
+
+// Strict tells if terramate.config.strict is enabled.
+func (c Config) Strict() bool {
+	return c.Terramate != nil &&
+		c.Terramate.Config != nil &&
+		c.Terramate.Config.Strict
+}
+
+// ConfigFileSuffixes returns the additional configuration file suffixes set
+// with terramate.config.config_file_suffixes.
+func (c Config) ConfigFileSuffixes() []string {
+	if c.Terramate == nil || c.Terramate.Config == nil {
+		return nil
+	}
+	return c.Terramate.Config.ConfigFileSuffixes
+}
+
+// Subprojects returns the embedded projects set with
+// terramate.config.subprojects.
+func (c Config) Subprojects() project.Paths {
+	if c.Terramate == nil || c.Terramate.Config == nil {
+		return nil
+	}
+	return c.Terramate.Config.Subprojects
+}
+
 // AbsDir returns the absolute path of the configuration directory.
 func (c Config) AbsDir() string { return c.absdir }
 
@@ -1084,10 +1593,18 @@ func validateImportBlock(block *ast.Block) error {
 				Name:     "source",
 				Required: true,
 			},
+			{
+				Name:     "sha256",
+				Required: false,
+			},
+			{
+				Name:     "condition",
+				Required: false,
+			},
 		},
 	}
 
-	_, diags := block.Body.Content(schema)
+	diags := bodyContent(block.Body, schema)
 	if diags.HasErrors() {
 		errs.Append(errors.E(ErrTerramateSchema, diags))
 	}
@@ -1124,28 +1641,17 @@ func validateGenerateHCLBlock(block *ast.Block) error {
 				Name:     "inherit",
 				Required: false,
 			},
+			{Name: "visibility"},
+			{Name: "format"},
+			{Name: "no_header"},
+			{Name: "line_endings"},
+			{Name: "trailing_newline"},
+			{Name: "bom"},
 		},
-		Blocks: []hcl.BlockHeaderSchema{
-			{
-				Type:       "content",
-				LabelNames: []string{},
-			},
-			{
-				Type:       "lets",
-				LabelNames: []string{},
-			},
-			{
-				Type:       "assert",
-				LabelNames: []string{},
-			},
-			{
-				Type:       "stack_filter",
-				LabelNames: []string{},
-			},
-		},
+		Blocks: generateHCLBlockSchema.headers(),
 	}
 
-	_, diags := block.Body.Content(schema)
+	diags := bodyContent(block.Body, schema)
 	if diags.HasErrors() {
 		errs.Append(errors.E(ErrTerramateSchema, diags))
 	}
@@ -1185,7 +1691,11 @@ func validateGenerateFileBlock(block *ast.Block) error {
 		Attributes: []hcl.AttributeSchema{
 			{
 				Name:     "content",
-				Required: true,
+				Required: false,
+			},
+			{
+				Name:     "outputs",
+				Required: false,
 			},
 			{
 				Name:     "condition",
@@ -1195,28 +1705,23 @@ func validateGenerateFileBlock(block *ast.Block) error {
 				Name:     "inherit",
 				Required: false,
 			},
+			{Name: "visibility"},
 			{
 				Name:     "context",
 				Required: false,
 			},
-		},
-		Blocks: []hcl.BlockHeaderSchema{
-			{
-				Type:       "lets",
-				LabelNames: []string{},
-			},
 			{
-				Type:       "assert",
-				LabelNames: []string{},
-			},
-			{
-				Type:       "stack_filter",
-				LabelNames: []string{},
+				Name:     "for_each_stack",
+				Required: false,
 			},
+			{Name: "line_endings"},
+			{Name: "trailing_newline"},
+			{Name: "bom"},
 		},
+		Blocks: generateFileBlockSchema.headers(),
 	}
 
-	_, diags := block.Body.Content(schema)
+	diags := bodyContent(block.Body, schema)
 	if diags.HasErrors() {
 		errs.Append(errors.E(ErrTerramateSchema, diags))
 	}
@@ -1405,6 +1910,11 @@ func parseStackFilterConfig(block *ast.Block) (StackFilterConfig, error) {
 			cfg.RepositoryPaths, err = parseStackFilterAttr(attr)
 			errs.Append(err)
 
+		case "tags":
+			var err error
+			cfg.Tags, err = parseStackFilterTagsAttr(attr)
+			errs.Append(err)
+
 		default:
 			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
 				"unrecognized attribute %s.%s", block.Type, attr.Name,
@@ -1419,6 +1929,27 @@ func parseStackFilterConfig(block *ast.Block) (StackFilterConfig, error) {
 	return cfg, nil
 }
 
+func parseStackFilterTagsAttr(attr ast.Attribute) (filter.TagClause, error) {
+	attrVal, hclerr := attr.Expr.Value(nil)
+	if hclerr != nil {
+		return filter.TagClause{}, errors.E(ErrTerramateSchema, hclerr, attr.NameRange, "evaluating %s", attr.Name)
+	}
+
+	exprs, err := ValueAsStringList(attrVal)
+	if err != nil {
+		return filter.TagClause{}, errors.E(ErrTerramateSchema, err, attr.NameRange)
+	}
+
+	clause, found, err := filter.ParseTagClauses(exprs...)
+	if err != nil {
+		return filter.TagClause{}, errors.E(ErrTerramateSchema, err, attr.Expr.Range())
+	}
+	if !found {
+		return filter.TagClause{}, errors.E(ErrTerramateSchema, attr.NameRange, "%s must not be empty", attr.Name)
+	}
+	return clause, nil
+}
+
 func parseStackFilterAttr(attr ast.Attribute) ([]glob.Glob, error) {
 	attrVal, hclerr := attr.Expr.Value(nil)
 	if hclerr != nil {
@@ -1464,6 +1995,11 @@ func (p *TerramateParser) hasExperimentalFeature(feature string) bool {
 	return slices.Contains(p.experiments, feature)
 }
 
+// rootConfigAttributes are the attributes of the terramate.config block.
+var rootConfigAttributes = []string{
+	"experiments", "follow_symlinks", "strict", "config_file_suffixes", "subprojects", "disable_safeguards",
+}
+
 func (p *TerramateParser) parseRootConfig(cfg *RootConfig, block *ast.MergedBlock) error {
 	errs := errors.L()
 
@@ -1471,7 +2007,8 @@ func (p *TerramateParser) parseRootConfig(cfg *RootConfig, block *ast.MergedBloc
 		switch attr.Name {
 		default:
 			errs.Append(errors.E(attr.NameRange,
-				"unrecognized attribute terramate.config.%s", attr.Name,
+				"unrecognized attribute terramate.config.%s%s", attr.Name,
+				didYouMean(attr.Name, rootConfigAttributes),
 			))
 			continue
 		case "experiments":
@@ -1486,7 +2023,83 @@ func (p *TerramateParser) parseRootConfig(cfg *RootConfig, block *ast.MergedBloc
 				errs.Append(err)
 				continue
 			}
+			warns, err := experiments.Validate(cfg.Experiments)
+			for _, warning := range warns {
+				errs.Append(warnings.Log(warnings.Experiment, warning, attr.Range, nil))
+			}
+			if err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(), err))
+				continue
+			}
 			p.experiments = cfg.Experiments
+		case "follow_symlinks":
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				errs.Append(errors.E(diags, attr.Expr.Range(),
+					"evaluating terramate.config.follow_symlinks attribute"))
+				continue
+			}
+			if val.Type() != cty.Bool {
+				errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+					"terramate.config.follow_symlinks must be a bool but has type %s",
+					val.Type().FriendlyName()))
+				continue
+			}
+			cfg.FollowSymlinks = val.True()
+		case "strict":
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				errs.Append(errors.E(diags, attr.Expr.Range(),
+					"evaluating terramate.config.strict attribute"))
+				continue
+			}
+			if val.Type() != cty.Bool {
+				errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+					"terramate.config.strict must be a bool but has type %s",
+					val.Type().FriendlyName()))
+				continue
+			}
+			cfg.Strict = val.True()
+		case "config_file_suffixes":
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				errs.Append(errors.E(diags, attr.Expr.Range(),
+					"evaluating terramate.config.config_file_suffixes attribute"))
+				continue
+			}
+			var suffixes []string
+			if err := assignSet(attr.Attribute, &suffixes, val); err != nil {
+				errs.Append(err)
+				continue
+			}
+			for _, suffix := range suffixes {
+				if len(suffix) < 2 || suffix[0] != '.' {
+					errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+						"terramate.config.config_file_suffixes: suffix %q must start with a dot", suffix))
+				}
+			}
+			cfg.ConfigFileSuffixes = suffixes
+		case "subprojects":
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				errs.Append(errors.E(diags, attr.Expr.Range(),
+					"evaluating terramate.config.subprojects attribute"))
+				continue
+			}
+			var dirs []string
+			if err := assignSet(attr.Attribute, &dirs, val); err != nil {
+				errs.Append(err)
+				continue
+			}
+			cfg.Subprojects = nil
+			for _, dir := range dirs {
+				if !path.IsAbs(dir) || path.Clean(dir) == "/" {
+					errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+						"terramate.config.subprojects: %q must be an absolute project path of a subdirectory", dir))
+					continue
+				}
+				cfg.Subprojects = append(cfg.Subprojects, project.NewPath(path.Clean(dir)))
+			}
 		case "disable_safeguards":
 			val, diags := attr.Expr.Value(nil)
 			if diags.HasErrors() {
@@ -1508,7 +2121,7 @@ func (p *TerramateParser) parseRootConfig(cfg *RootConfig, block *ast.MergedBloc
 		}
 	}
 
-	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks("git", "generate", "change_detection", "run", "cloud", "targets", "telemetry"))
+	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks(terramateBlockSchema.block("config").blockTypes()...))
 
 	gitBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("git")]
 	if ok {
@@ -1546,14 +2159,143 @@ func (p *TerramateParser) parseRootConfig(cfg *RootConfig, block *ast.MergedBloc
 		errs.Append(parseTelemetryConfigBlock(cfg.Telemetry, telemetryBlock))
 	}
 
-	return errs.AsError()
-}
+	stackDefaultsBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("stack_defaults")]
+	if ok {
+		cfg.StackDefaults = &StackDefaultsConfig{}
+		errs.Append(parseStackDefaultsConfig(cfg.StackDefaults, stackDefaultsBlock))
+	}
 
-func parseRunConfig(cfg *RootConfig, runBlock *ast.MergedBlock) error {
-	cfg.Run = &RunConfig{
-		CheckGenCode: true,
+	notificationsBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("notifications")]
+	if ok {
+		cfg.Notifications = &NotificationsConfig{}
+		errs.Append(parseNotificationsConfig(cfg.Notifications, notificationsBlock))
 	}
-	runCfg := cfg.Run
+
+	sandboxBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("sandbox")]
+	if ok {
+		cfg.Sandbox = &SandboxConfig{Limits: stdlib.DefaultSandbox}
+		errs.Append(parseSandboxConfig(cfg.Sandbox, sandboxBlock))
+	}
+
+	warningsBlock, ok := block.Blocks[ast.NewEmptyLabelBlockType("warnings")]
+	if ok {
+		cfg.Warnings = &WarningsConfig{}
+		errs.Append(parseWarningsConfig(cfg.Warnings, warningsBlock))
+	}
+
+	return errs.AsError()
+}
+
+func parseWarningsConfig(cfg *WarningsConfig, warningsBlock *ast.MergedBlock) error {
+	errs := errors.L()
+
+	errs.AppendWrap(ErrTerramateSchema, warningsBlock.ValidateSubBlocks())
+
+	for _, attr := range warningsBlock.Attributes.SortedList() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(ErrTerramateSchema, diags,
+				"failed to evaluate terramate.config.warnings.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "as_errors":
+			list, err := ValueAsStringList(value)
+			if err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, err, attr.NameRange,
+					"terramate.config.warnings.as_errors must be a list of strings"))
+				continue
+			}
+			for _, str := range list {
+				code := warnings.Code(str)
+				if !code.IsValid() {
+					errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+						"unknown warning code %q in terramate.config.warnings.as_errors", str))
+					continue
+				}
+				cfg.AsErrors = append(cfg.AsErrors, code)
+			}
+
+		default:
+			errs.Append(errors.E(ErrTerramateSchema,
+				attr.NameRange,
+				"unrecognized attribute terramate.config.warnings.%s",
+				attr.Name,
+			))
+		}
+	}
+	return errs.AsError()
+}
+
+func parseSandboxConfig(cfg *SandboxConfig, sandboxBlock *ast.MergedBlock) error {
+	errs := errors.L()
+
+	errs.AppendWrap(ErrTerramateSchema, sandboxBlock.ValidateSubBlocks())
+
+	for _, attr := range sandboxBlock.Attributes.SortedList() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(diags,
+				"failed to evaluate terramate.config.sandbox.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "enabled":
+			if value.Type() != cty.Bool {
+				errs.Append(attrErr(attr,
+					"terramate.config.sandbox.enabled is not a bool but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			cfg.Enabled = value.True()
+
+		case "timeout":
+			if value.Type() != cty.String {
+				errs.Append(attrErr(attr,
+					"terramate.config.sandbox.timeout is not a string but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			timeout, err := time.ParseDuration(value.AsString())
+			if err != nil || timeout <= 0 {
+				errs.Append(attrErr(attr,
+					"terramate.config.sandbox.timeout must be a positive duration (eg.: \"30s\") but %q was given",
+					value.AsString(),
+				))
+				continue
+			}
+			cfg.Limits.Timeout = timeout
+
+		case "max_value_size":
+			size, err := parseIntAttr("terramate.config.sandbox", attr, value, 1)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			cfg.Limits.MaxValueSize = size
+
+		default:
+			errs.Append(errors.E(
+				attr.NameRange,
+				"unrecognized attribute terramate.config.sandbox.%s",
+				attr.Name,
+			))
+		}
+	}
+	return errs.AsError()
+}
+
+func parseRunConfig(cfg *RootConfig, runBlock *ast.MergedBlock) error {
+	cfg.Run = &RunConfig{
+		CheckGenCode: true,
+	}
+	runCfg := cfg.Run
 	errs := errors.L()
 	for _, attr := range runBlock.Attributes.SortedList() {
 		value, diags := attr.Expr.Value(nil)
@@ -1582,13 +2324,28 @@ func parseRunConfig(cfg *RootConfig, runBlock *ast.MergedBlock) error {
 				continue
 			}
 			runCfg.CheckGenCode = value.True()
+		case "export_globals_as_tfvars":
+			patterns, err := ValueAsStringList(value)
+			if err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, err, attr.NameRange,
+					"terramate.config.run.export_globals_as_tfvars must be a list of strings"))
+				continue
+			}
+			for _, pattern := range patterns {
+				if err := ValidateGlobalPattern(pattern); err != nil {
+					errs.Append(errors.E(ErrTerramateSchema, err, attr.Expr.Range(),
+						"invalid pattern in terramate.config.run.export_globals_as_tfvars"))
+				}
+			}
+			runCfg.ExportGlobalsAsTFVars = patterns
+			runCfg.ExportGlobalsAsTFVarsRange = attr.Range
 		default:
 			errs.Append(errors.E("unrecognized attribute terramate.config.run.env.%s",
 				attr.Name))
 		}
 	}
 
-	errs.AppendWrap(ErrTerramateSchema, runBlock.ValidateSubBlocks("env"))
+	errs.AppendWrap(ErrTerramateSchema, runBlock.ValidateSubBlocks(terramateBlockSchema.block("config", "run").blockTypes()...))
 
 	block, ok := runBlock.Blocks[ast.NewEmptyLabelBlockType("env")]
 	if ok {
@@ -1596,9 +2353,39 @@ func parseRunConfig(cfg *RootConfig, runBlock *ast.MergedBlock) error {
 		errs.Append(parseRunEnv(runCfg.Env, block))
 	}
 
+	block, ok = runBlock.Blocks[ast.NewEmptyLabelBlockType("policy")]
+	if ok {
+		runCfg.Policy = &RunPolicyConfig{}
+		errs.Append(parseRunPolicy(runCfg.Policy, block))
+	}
+
+	block, ok = runBlock.Blocks[ast.NewEmptyLabelBlockType("lock")]
+	if ok {
+		runCfg.Lock = &RunLockConfig{}
+		errs.Append(parseRunLock(runCfg.Lock, block))
+	}
+
 	return errs.AsError()
 }
 
+// ValidateGlobalPattern validates a pattern matching global paths, which is a
+// dot separated list of global keys where each key can be a glob pattern,
+// eg.: "tfvars.*" matches all the keys of the global.tfvars object.
+func ValidateGlobalPattern(pattern string) error {
+	if pattern == "" {
+		return errors.E("empty global pattern")
+	}
+	for _, key := range strings.Split(pattern, ".") {
+		if key == "" {
+			return errors.E("pattern %q has an empty key", pattern)
+		}
+		if _, err := path.Match(key, ""); err != nil {
+			return errors.E(err, "pattern %q has an invalid key %q", pattern, key)
+		}
+	}
+	return nil
+}
+
 func parseGenerateRootConfig(cfg *GenerateRootConfig, generateBlock *ast.MergedBlock) error {
 	errs := errors.L()
 
@@ -1635,6 +2422,100 @@ func parseGenerateRootConfig(cfg *GenerateRootConfig, generateBlock *ast.MergedB
 
 			cfg.HCLMagicHeaderCommentStyle = &str
 
+		case "fsync":
+			if value.Type() != cty.Bool {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.fsync is not a bool but %q",
+					value.Type().FriendlyName(),
+				))
+
+				continue
+			}
+
+			fsync := value.True()
+			cfg.Fsync = &fsync
+
+		case "track_headerless_files":
+			if value.Type() != cty.Bool {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.track_headerless_files is not a bool but %q",
+					value.Type().FriendlyName(),
+				))
+
+				continue
+			}
+
+			track := value.True()
+			cfg.TrackHeaderlessFiles = &track
+
+		case "simplify_expressions":
+			if value.Type() != cty.Bool {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.simplify_expressions is not a bool but %q",
+					value.Type().FriendlyName(),
+				))
+
+				continue
+			}
+
+			simplify := value.True()
+			cfg.SimplifyExpressions = &simplify
+
+		case "indent_size":
+			size, err := parseIntAttr("terramate.config.generate", attr, value, 1)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+
+			cfg.IndentSize = &size
+
+		case "max_line_width":
+			width, err := parseIntAttr("terramate.config.generate", attr, value, 0)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+
+			cfg.MaxLineWidth = &width
+
+		case "line_endings", "trailing_newline", "bom":
+			errs.Append(parseGenerateOutputAttr(&cfg.Output, "terramate.config.generate", attr.Name, value, attr.Expr.Range()))
+
+		case "timestamp":
+			if value.Type() != cty.String {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.timestamp is not a string but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			if _, err := time.Parse(time.RFC3339, value.AsString()); err != nil {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.timestamp is not a RFC3339 timestamp: %v", err,
+				))
+				continue
+			}
+			cfg.Timestamp = value.AsString()
+
+		case "random_seed":
+			if value.Type() != cty.String {
+				errs.Append(attrErr(attr,
+					"terramate.config.generate.random_seed is not a string but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			cfg.RandomSeed = value.AsString()
+
+		case "file_types":
+			fileTypes, err := parseGenerateFileTypes(attr, value)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			cfg.FileTypes = fileTypes
+
 		default:
 			errs.Append(errors.E(
 				attr.NameRange,
@@ -1646,8 +2527,147 @@ func parseGenerateRootConfig(cfg *GenerateRootConfig, generateBlock *ast.MergedB
 	return errs.AsError()
 }
 
+func parseGenerateFileTypes(attr ast.Attribute, value cty.Value) ([]GenerateFileType, error) {
+	if !value.Type().IsObjectType() && !value.Type().IsMapType() {
+		return nil, attrErr(attr,
+			"terramate.config.generate.file_types is not an object but %q",
+			value.Type().FriendlyName(),
+		)
+	}
+	errs := errors.L()
+	var fileTypes []GenerateFileType
+	for it := value.ElementIterator(); it.Next(); {
+		k, v := it.Element()
+		pattern := k.AsString()
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs.Append(attrErr(attr,
+				"terramate.config.generate.file_types: invalid pattern %q: %v", pattern, err,
+			))
+			continue
+		}
+		if v.Type() != cty.String || v.IsNull() {
+			errs.Append(attrErr(attr,
+				"terramate.config.generate.file_types[%q] is not a string but %q",
+				pattern, v.Type().FriendlyName(),
+			))
+			continue
+		}
+		switch typ := v.AsString(); typ {
+		case FileTypeGenerateHCL, FileTypeHCL, FileTypeYAML, FileTypeJSON:
+			fileTypes = append(fileTypes, GenerateFileType{Pattern: pattern, Type: typ})
+		default:
+			errs.Append(attrErr(attr,
+				"terramate.config.generate.file_types[%q] must be one of %q, %q, %q or %q but %q was given",
+				pattern, FileTypeGenerateHCL, FileTypeHCL, FileTypeYAML, FileTypeJSON, typ,
+			))
+		}
+	}
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	sort.Slice(fileTypes, func(i, j int) bool {
+		return fileTypes[i].Pattern < fileTypes[j].Pattern
+	})
+	return fileTypes, nil
+}
+
+// parseGenerateOutputBlockAttrs parses the output encoding attributes of a
+// generate_hcl or generate_file block.
+func parseGenerateOutputBlockAttrs(block *ast.Block) (GenerateOutputConfig, error) {
+	var cfg GenerateOutputConfig
+	errs := errors.L()
+	for _, name := range []string{"line_endings", "trailing_newline", "bom"} {
+		attr, ok := block.Body.Attributes[name]
+		if !ok {
+			continue
+		}
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(ErrTerramateSchema, diags,
+				"failed to evaluate %s.%s attribute", block.Type, name))
+			continue
+		}
+		errs.Append(parseGenerateOutputAttr(&cfg, block.Type, name, value, attr.Expr.Range()))
+	}
+	return cfg, errs.AsError()
+}
+
+// parseGenerateVisibility parses the visibility attribute of the generate
+// blocks, which defaults to VisibilitySubtree.
+func parseGenerateVisibility(block *ast.Block) (string, error) {
+	attr, ok := block.Body.Attributes["visibility"]
+	if !ok {
+		return VisibilitySubtree, nil
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return "", errors.E(ErrTerramateSchema, diags,
+			"failed to evaluate %s.visibility attribute", block.Type)
+	}
+	if value.Type() != cty.String || value.IsNull() {
+		return "", errors.E(ErrTerramateSchema, attr.Expr.Range(),
+			"%s.visibility is not a string but %q", block.Type, value.Type().FriendlyName())
+	}
+	str := value.AsString()
+	if str != VisibilitySubtree && str != VisibilityDirectory {
+		return "", errors.E(ErrTerramateSchema, attr.Expr.Range(),
+			"%s.visibility must be either %q or %q but %q was given",
+			block.Type, VisibilitySubtree, VisibilityDirectory, str)
+	}
+	return str, nil
+}
+
+func parseGenerateOutputAttr(cfg *GenerateOutputConfig, blockname, name string, value cty.Value, rng hcl.Range) error {
+	switch name {
+	case "line_endings":
+		if value.Type() != cty.String {
+			return errors.E(ErrTerramateSchema, rng,
+				"%s.line_endings is not a string but %q", blockname, value.Type().FriendlyName())
+		}
+		str := value.AsString()
+		if str != LineEndingsLF && str != LineEndingsCRLF {
+			return errors.E(ErrTerramateSchema, rng,
+				"%s.line_endings must be either %q or %q but %q was given",
+				blockname, LineEndingsLF, LineEndingsCRLF, str)
+		}
+		cfg.LineEndings = &str
+	case "trailing_newline", "bom":
+		if value.Type() != cty.Bool {
+			return errors.E(ErrTerramateSchema, rng,
+				"%s.%s is not a bool but %q", blockname, name, value.Type().FriendlyName())
+		}
+		b := value.True()
+		if name == "bom" {
+			cfg.BOM = &b
+		} else {
+			cfg.TrailingNewline = &b
+		}
+	default:
+		panic(errors.E(errors.ErrInternal, "unexpected output attribute %s", name))
+	}
+	return nil
+}
+
+func parseIntAttr(blockname string, attr ast.Attribute, value cty.Value, minval int) (int, error) {
+	if value.Type() != cty.Number {
+		return 0, attrErr(attr,
+			"%s.%s is not a number but %q",
+			blockname, attr.Name, value.Type().FriendlyName(),
+		)
+	}
+	bf := value.AsBigFloat()
+	num, acc := bf.Int64()
+	if !bf.IsInt() || acc != big.Exact || num < int64(minval) || num > math.MaxInt32 {
+		return 0, attrErr(attr,
+			"%s.%s must be an integer greater than or equal to %d but %s was given",
+			blockname, attr.Name, minval, bf.String(),
+		)
+	}
+	return int(num), nil
+}
+
 func parseChangeDetectionConfig(cfg *ChangeDetectionConfig, changeDetectionBlock *ast.MergedBlock) error {
-	err := changeDetectionBlock.ValidateSubBlocks("terragrunt", "git")
+	err := changeDetectionBlock.ValidateSubBlocks(terramateBlockSchema.block("config", "change_detection").blockTypes()...)
 	if err != nil {
 		return err
 	}
@@ -1721,6 +2741,71 @@ func parseTerragruntChangeDetectionConfig(cfg *TerragruntChangeDetectionConfig,
 	return nil
 }
 
+func parseStackDefaultsConfig(cfg *StackDefaultsConfig, block *ast.MergedBlock) error {
+	errs := errors.L()
+	for _, attr := range block.Attributes.SortedList() {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(ErrTerramateSchema, diags, attr.Expr.Range(),
+				"evaluating terramate.config.stack_defaults.%s attribute", attr.Name))
+			continue
+		}
+
+		var (
+			target  *[]string
+			hasTags bool
+		)
+		switch attr.Name {
+		case "description":
+			if val.Type() != cty.String {
+				errs.Append(errors.E(ErrTerramateSchema, attr.Expr.Range(),
+					"terramate.config.stack_defaults.description must be a string but given %q",
+					val.Type().FriendlyName()))
+				continue
+			}
+			cfg.Description = val.AsString()
+			continue
+		case "tags":
+			target, hasTags = &cfg.Tags, true
+		case "remove_tags":
+			target, hasTags = &cfg.RemoveTags, true
+		case "watch":
+			target = &cfg.Watch
+		case "after":
+			target = &cfg.After
+		case "before":
+			target = &cfg.Before
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+				"unrecognized attribute terramate.config.stack_defaults.%s", attr.Name))
+			continue
+		}
+
+		if err := assignSet(attr.Attribute, target, val); err != nil {
+			errs.Append(err)
+			continue
+		}
+		if !hasTags {
+			continue
+		}
+		for _, tagname := range *target {
+			if err := tag.Validate(tagname); err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, err, attr.Expr.Range()))
+			}
+		}
+	}
+
+	for _, tagname := range cfg.Tags {
+		if slices.Contains(cfg.RemoveTags, tagname) {
+			errs.Append(errors.E(ErrTerramateSchema,
+				"tag %q is both added and removed in terramate.config.stack_defaults", tagname))
+		}
+	}
+
+	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks())
+	return errs.AsError()
+}
+
 func parseTelemetryConfigBlock(cfg *TelemetryConfig, telemetryBlock *ast.MergedBlock) error {
 	errs := errors.L()
 
@@ -1765,6 +2850,72 @@ func parseTelemetryConfigBlock(cfg *TelemetryConfig, telemetryBlock *ast.MergedB
 	return nil
 }
 
+func parseNotificationsConfig(cfg *NotificationsConfig, block *ast.MergedBlock) error {
+	errs := errors.L()
+	errs.AppendWrap(ErrTerramateSchema, block.ValidateSubBlocks())
+
+	for _, attr := range block.Attributes.SortedList() {
+		if attr.Name == "url" {
+			cfg.URL = attr.Expr
+			continue
+		}
+
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(diags,
+				"failed to evaluate terramate.config.notifications.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "format":
+			if value.Type() != cty.String {
+				errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+					"terramate.config.notifications.format must be a string but has type %s",
+					value.Type().FriendlyName()))
+				continue
+			}
+			cfg.Format = value.AsString()
+			if cfg.Format != NotificationsFormatJSON && cfg.Format != NotificationsFormatSlack {
+				errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+					"unsupported terramate.config.notifications.format %q (supported: %q, %q)",
+					cfg.Format, NotificationsFormatJSON, NotificationsFormatSlack))
+			}
+		case "events":
+			list, err := ValueAsStringList(value)
+			if err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, err, attr.NameRange,
+					"terramate.config.notifications.events must be a list of strings"))
+				continue
+			}
+			for _, event := range list {
+				if event != NotificationsEventFailure && event != NotificationsEventDrift {
+					errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+						"unsupported event %q in terramate.config.notifications.events (supported: %q, %q)",
+						event, NotificationsEventFailure, NotificationsEventDrift))
+				}
+			}
+			cfg.Events = list
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+				"unrecognized attribute terramate.config.notifications.%s", attr.Name))
+		}
+	}
+
+	if cfg.URL == nil {
+		errs.Append(errors.E(ErrTerramateSchema, block.RawOrigins[0].Range,
+			"terramate.config.notifications.url is required"))
+	}
+	if cfg.Format == "" {
+		cfg.Format = NotificationsFormatJSON
+	}
+	if cfg.Events == nil {
+		cfg.Events = []string{NotificationsEventFailure}
+	}
+	return errs.AsError()
+}
+
 func parseGitChangeDetectionConfig(cfg *GitChangeDetectionConfig, gitBlock *ast.MergedBlock) error {
 	errs := errors.L()
 	errs.Append(gitBlock.ValidateSubBlocks())
@@ -1825,6 +2976,126 @@ func parseRunEnv(runEnv *RunEnv, envBlock *ast.MergedBlock) error {
 	return errs.AsError()
 }
 
+func parseRunPolicy(policy *RunPolicyConfig, policyBlock *ast.MergedBlock) error {
+	errs := errors.L()
+	errs.AppendWrap(ErrTerramateSchema, policyBlock.ValidateSubBlocks())
+
+	for _, attr := range policyBlock.Attributes.SortedList() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(diags,
+				"failed to evaluate terramate.config.run.policy.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "allowed_commands", "denied_flags":
+			list, err := ValueAsStringList(value)
+			if err != nil {
+				errs.Append(errors.E(ErrTerramateSchema, err, attr.NameRange,
+					"terramate.config.run.policy.%s must be a list of strings", attr.Name))
+				continue
+			}
+			if attr.Name == "denied_flags" {
+				policy.DeniedFlags = list
+				continue
+			}
+			for _, pattern := range list {
+				if _, err := glob.Compile(pattern); err != nil {
+					errs.Append(errors.E(ErrTerramateSchema, err, attr.NameRange,
+						"invalid pattern %q in terramate.config.run.policy.allowed_commands", pattern))
+				}
+			}
+			policy.AllowedCommands = list
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+				"unrecognized attribute terramate.config.run.policy.%s", attr.Name))
+		}
+	}
+	return errs.AsError()
+}
+
+func parseRunLock(lock *RunLockConfig, lockBlock *ast.MergedBlock) error {
+	errs := errors.L()
+	errs.AppendWrap(ErrTerramateSchema, lockBlock.ValidateSubBlocks())
+
+	for _, attr := range lockBlock.Attributes.SortedList() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(diags,
+				"failed to evaluate terramate.config.run.lock.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "backend", "path", "bucket", "prefix", "table", "region":
+			if value.Type() != cty.String {
+				errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+					"terramate.config.run.lock.%s must be a string but has type %s",
+					attr.Name, value.Type().FriendlyName()))
+				continue
+			}
+			switch attr.Name {
+			case "path":
+				lock.Path = value.AsString()
+			case "bucket":
+				lock.Bucket = value.AsString()
+			case "prefix":
+				lock.Prefix = value.AsString()
+			case "table":
+				lock.Table = value.AsString()
+			case "region":
+				lock.Region = value.AsString()
+			default:
+				lock.Backend = value.AsString()
+				if lock.Backend != "file" && lock.Backend != "gcs" && lock.Backend != "dynamodb" {
+					errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+						"unsupported terramate.config.run.lock.backend %q (supported: \"file\", \"gcs\" and \"dynamodb\")", lock.Backend))
+				}
+			}
+		default:
+			errs.Append(errors.E(ErrTerramateSchema, attr.NameRange,
+				"unrecognized attribute terramate.config.run.lock.%s", attr.Name))
+		}
+	}
+
+	if lock.Backend == "" {
+		lock.Backend = "file"
+	}
+	switch lock.Backend {
+	case "file":
+		if lock.Bucket != "" || lock.Prefix != "" {
+			errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+				"terramate.config.run.lock.bucket and prefix are not supported by the \"file\" backend"))
+		}
+	case "gcs":
+		if lock.Bucket == "" {
+			errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+				"terramate.config.run.lock.bucket is required by the \"gcs\" backend"))
+		}
+	case "dynamodb":
+		if lock.Table == "" {
+			errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+				"terramate.config.run.lock.table is required by the \"dynamodb\" backend"))
+		}
+		if lock.Bucket != "" {
+			errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+				"terramate.config.run.lock.bucket is only supported by the \"gcs\" backend"))
+		}
+	}
+	if lock.Backend != "file" && lock.Path != "" {
+		errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+			"terramate.config.run.lock.path is only supported by the \"file\" backend"))
+	}
+	if lock.Backend != "dynamodb" && (lock.Table != "" || lock.Region != "") {
+		errs.Append(errors.E(ErrTerramateSchema, lockBlock.RawOrigins[0].Range,
+			"terramate.config.run.lock.table and region are only supported by the \"dynamodb\" backend"))
+	}
+	return errs.AsError()
+}
+
 func parseGitConfig(cfg *RootConfig, gitBlock *ast.MergedBlock) error {
 	errs := errors.L()
 
@@ -1906,6 +3177,22 @@ func parseGitConfig(cfg *RootConfig, gitBlock *ast.MergedBlock) error {
 				continue
 			}
 			git.CheckRemote = ToOptionalCheck(value.True())
+		case "fetch_missing_base_ref":
+			if value.Type() != cty.Bool {
+				errs.Append(attrErr(attr,
+					"terramate.config.git.fetch_missing_base_ref is not a boolean but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			git.FetchMissingBaseRef = value.True()
+		case "fetch_depth":
+			depth, err := parseIntAttr("terramate.config.git", attr, value, 1)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			git.FetchDepth = depth
 
 		default:
 			errs.Append(errors.E(
@@ -1985,7 +3272,7 @@ func parseCloudConfig(cloudcfg *CloudConfig, cloudBlock *ast.MergedBlock) error
 		}
 	}
 
-	errs.AppendWrap(ErrTerramateSchema, cloudBlock.ValidateSubBlocks("targets"))
+	errs.AppendWrap(ErrTerramateSchema, cloudBlock.ValidateSubBlocks(terramateBlockSchema.block("config", "cloud").blockTypes()...))
 
 	targetsBlock, ok := cloudBlock.Blocks[ast.NewEmptyLabelBlockType("targets")]
 	if ok {
@@ -1994,6 +3281,65 @@ func parseCloudConfig(cloudcfg *CloudConfig, cloudBlock *ast.MergedBlock) error
 		errs.Append(parseTargetsConfig(cloudcfg.Targets, targetsBlock))
 	}
 
+	queueBlock, ok := cloudBlock.Blocks[ast.NewEmptyLabelBlockType("queue")]
+	if ok {
+		cloudcfg.Queue = &CloudQueueConfig{}
+
+		errs.Append(parseCloudQueueConfig(cloudcfg.Queue, queueBlock))
+	}
+
+	return errs.AsError()
+}
+
+func parseCloudQueueConfig(cfg *CloudQueueConfig, queueBlock *ast.MergedBlock) error {
+	errs := errors.L()
+
+	errs.AppendWrap(ErrTerramateSchema, queueBlock.ValidateSubBlocks())
+
+	for _, attr := range queueBlock.Attributes.SortedList() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			errs.Append(errors.E(diags,
+				"failed to evaluate terramate.config.cloud.queue.%s attribute", attr.Name,
+			))
+			continue
+		}
+
+		switch attr.Name {
+		case "ttl":
+			if value.Type() != cty.String {
+				errs.Append(attrErr(attr,
+					"terramate.config.cloud.queue.ttl is not a string but %q",
+					value.Type().FriendlyName(),
+				))
+				continue
+			}
+			ttl, err := time.ParseDuration(value.AsString())
+			if err != nil || ttl <= 0 {
+				errs.Append(attrErr(attr,
+					"terramate.config.cloud.queue.ttl must be a positive duration (eg.: \"72h\") but %q was given",
+					value.AsString(),
+				))
+				continue
+			}
+			cfg.TTL = ttl
+
+		case "max_size":
+			size, err := parseIntAttr("terramate.config.cloud.queue", attr, value, 1)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			cfg.MaxSize = int64(size)
+
+		default:
+			errs.Append(errors.E(
+				attr.NameRange,
+				"unrecognized attribute terramate.config.cloud.queue.%s",
+				attr.Name,
+			))
+		}
+	}
 	return errs.AsError()
 }
 
@@ -2038,7 +3384,9 @@ func parseTargetsConfig(targets *TargetsConfig, targetsBlock *ast.MergedBlock) e
 func (p *TerramateParser) parseTerramateSchema() (*Config, error) {
 	defer func() { p.state = schemaParsedState }()
 	p.ParsedConfig = Config{
-		absdir: p.dir,
+		absdir:    p.dir,
+		sandbox:   p.sandbox,
+		functions: p.functions,
 	}
 
 	config := &p.ParsedConfig
@@ -2074,6 +3422,7 @@ func (p *TerramateParser) parseTerramateSchema() (*Config, error) {
 			continue
 		}
 	}
+	errs.Append(p.applyFileLets())
 
 	config.Imported = p.Imported
 
@@ -2099,9 +3448,6 @@ func (p *TerramateParser) parseTerramateSchema() (*Config, error) {
 
 func (p *TerramateParser) checkConfigSanity() error {
 	defer func() { p.state = schemaValidatedState }()
-	logger := log.With().
-		Str("action", "TerramateParser.checkConfigSanity()").
-		Logger()
 
 	rawconfig := p.Imported.Copy()
 	_ = rawconfig.Merge(p.Config)
@@ -2125,10 +3471,11 @@ func (p *TerramateParser) checkConfigSanity() error {
 	if p.strict {
 		return errs.AsError()
 	}
+	promoted := errors.L()
 	for _, err := range errs.Errors() {
-		logger.Warn().Err(err).Send()
+		promoted.Append(warnings.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, err))
 	}
-	return nil
+	return promoted.AsError()
 }
 
 func terramateConfigRunSanityCheck(parsingDir string, runblock *ast.Block) error {
@@ -2145,6 +3492,23 @@ func terramateConfigRunSanityCheck(parsingDir string, runblock *ast.Block) error
 	return errs.AsError()
 }
 
+// terramateConfigGenerateSanityCheck checks the terramate.config.generate
+// block of a non-root directory, where only the comment style of the header
+// can be overridden.
+func terramateConfigGenerateSanityCheck(parsingDir string, genblock *ast.Block) error {
+	errs := errors.L()
+	for _, attr := range genblock.Attributes.SortedList() {
+		if attr.Name == "hcl_magic_header_comment_style" {
+			continue
+		}
+		errs.Append(attributeSanityCheckErr(parsingDir, "terramate.config.generate", attr))
+	}
+	for _, block := range genblock.Blocks {
+		errs.Append(blockSanityCheckErr(parsingDir, "terramate.config.generate", block))
+	}
+	return errs.AsError()
+}
+
 func terramateConfigBlockSanityCheck(parsingDir string, cfgblock *ast.Block) error {
 	errs := errors.L()
 	for _, attr := range cfgblock.Attributes.SortedList() {
@@ -2153,6 +3517,10 @@ func terramateConfigBlockSanityCheck(parsingDir string, cfgblock *ast.Block) err
 	for _, block := range cfgblock.Blocks {
 		if block.Type == "run" {
 			errs.Append(terramateConfigRunSanityCheck(parsingDir, block))
+		} else if block.Type == "generate" {
+			errs.Append(terramateConfigGenerateSanityCheck(parsingDir, block))
+		} else if block.Type == "stack_defaults" {
+			continue
 		} else {
 			errs.Append(blockSanityCheckErr(parsingDir, "terramate.config", block))
 		}
@@ -2297,3 +3665,23 @@ func hclAttrErr(attr *hcl.Attribute, msg string, args ...interface{}) error {
 func attrErr(attr ast.Attribute, msg string, args ...interface{}) error {
 	return errors.E(ErrTerramateSchema, attr.Expr.Range(), fmt.Sprintf(msg, args...))
 }
+
+// BlockTypes returns the sorted types of the top-level blocks supported by
+// the default parser, including the ones enabled by experiments.
+func BlockTypes() []string {
+	types := []string{"import"}
+	for _, spec := range DefaultUnmergedBlockParsers() {
+		types = append(types, spec().Name())
+	}
+	for _, spec := range DefaultMergedBlockHandlers() {
+		types = append(types, spec().Name())
+	}
+	for _, spec := range DefaultMergedLabelsBlockHandlers() {
+		types = append(types, spec().Name())
+	}
+	for _, spec := range DefaultUniqueBlockHandlers() {
+		types = append(types, spec().Name())
+	}
+	sort.Strings(types)
+	return types
+}
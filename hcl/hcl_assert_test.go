@@ -63,6 +63,32 @@ func TestHCLParserAssert(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "assert with code and docs_url",
+			input: []cfgfile{
+				{
+					filename: "assert.tm",
+					body: Assert(
+						Expr("assertion", "1 == 1"),
+						Expr("message", "global.message"),
+						Str("code", "TAG001"),
+						Str("docs_url", "https://wiki.example.com/tags"),
+					).String(),
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Asserts: []hcl.AssertConfig{
+						{
+							Assertion: expr(t, "1 == 1"),
+							Message:   expr(t, "global.message"),
+							Code:      expr(t, `"TAG001"`),
+							DocsURL:   expr(t, `"https://wiki.example.com/tags"`),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "multiple asserts on same file",
 			input: []cfgfile{
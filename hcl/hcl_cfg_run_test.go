@@ -657,6 +657,350 @@ func TestHCLParserConfigRun(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "run.policy block",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      policy {
+					        allowed_commands = ["terraform *", "tofu *"]
+					        denied_flags     = ["-auto-approve"]
+					      }
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Policy: &hcl.RunPolicyConfig{
+									AllowedCommands: []string{"terraform *", "tofu *"},
+									DeniedFlags:     []string{"-auto-approve"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unrecognized attribute on run.policy",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    run {
+						      policy {
+						        something = true
+						      }
+						    }
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(6, 15, 87), End(6, 24, 96)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.policy.allowed_commands must be a list of strings",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    run {
+						      policy {
+						        allowed_commands = "terraform *"
+						      }
+						    }
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(6, 15, 87), End(6, 31, 103)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.export_globals_as_tfvars with invalid pattern",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      export_globals_as_tfvars = ["tfvars..a", "tfvars.[a"]
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 39, 81), End(4, 65, 107)),
+					),
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 39, 81), End(4, 65, 107)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.lock block",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      lock {
+					        backend = "file"
+					        path    = "/mnt/shared/locks"
+					      }
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Lock: &hcl.RunLockConfig{
+									Backend: "file",
+									Path:    "/mnt/shared/locks",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "run.lock defaults to file backend",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      lock {}
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Lock: &hcl.RunLockConfig{
+									Backend: "file",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "run.lock with gcs backend",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      lock {
+					        backend = "gcs"
+					        bucket  = "locks"
+					        prefix  = "terramate/"
+					      }
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Lock: &hcl.RunLockConfig{
+									Backend: "gcs",
+									Bucket:  "locks",
+									Prefix:  "terramate/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "run.lock with gcs backend requires bucket",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+  config {
+    run {
+      lock {
+        backend = "gcs"
+      }
+    }
+  }
+}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 7, 39), End(6, 8, 77)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.lock with dynamodb backend",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+					  config {
+					    run {
+					      lock {
+					        backend = "dynamodb"
+					        table   = "locks"
+					        region  = "eu-west-1"
+					        prefix  = "terramate/"
+					      }
+					    }
+					  }
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Lock: &hcl.RunLockConfig{
+									Backend: "dynamodb",
+									Table:   "locks",
+									Region:  "eu-west-1",
+									Prefix:  "terramate/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "run.lock with dynamodb backend requires table",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+  config {
+    run {
+      lock {
+        backend = "dynamodb"
+      }
+    }
+  }
+}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 7, 39), End(6, 8, 82)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.lock table with gcs backend",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `terramate {
+  config {
+    run {
+      lock {
+        backend = "gcs"
+        bucket  = "locks"
+        table   = "locks"
+      }
+    }
+  }
+}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 7, 39), End(8, 8, 129)),
+					),
+				},
+			},
+		},
+		{
+			name: "run.lock with unsupported backend",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    run {
+						      lock {
+						        backend = "consul"
+						      }
+						    }
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(6, 15, 85), End(6, 22, 92)),
+					),
+				},
+			},
+		},
 	} {
 		testParser(t, tc)
 	}
@@ -368,6 +368,180 @@ func TestHCLImport(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "import with matching sha256",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source = "/other/cfg.tm"
+  sha256 = "18b438cae1b4da596bcf9923c73aa159b50b8271e343a4f6cd4e3722375f217d"
+}`,
+				},
+				{
+					filename: "other/cfg.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{},
+			},
+		},
+		{
+			name:     "import with mismatching sha256 - fails",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source = "/other/cfg.tm"
+  sha256 = "0000000000000000000000000000000000000000000000000000000000000000"
+}`,
+				},
+				{
+					filename: "other/cfg.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrImportIntegrity,
+						Mkrange("stack/cfg.tm", Start(3, 12, 47), End(3, 78, 113))),
+				},
+			},
+		},
+		{
+			name:     "import with invalid sha256 - fails",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source = "/other/cfg.tm"
+  sha256 = "abc"
+}`,
+				},
+				{
+					filename: "other/cfg.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("stack/cfg.tm", Start(3, 12, 47), End(3, 17, 52))),
+				},
+			},
+		},
+		{
+			name:     "import glob matching multiple files with sha256 - fails",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source = "/other/*.tm"
+  sha256 = "18b438cae1b4da596bcf9923c73aa159b50b8271e343a4f6cd4e3722375f217d"
+}`,
+				},
+				{
+					filename: "other/a.tm",
+					body:     `globals {}`,
+				},
+				{
+					filename: "other/b.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrImport,
+						Mkrange("stack/cfg.tm", Start(2, 12, 20), End(2, 25, 33))),
+				},
+			},
+		},
+		{
+			name:     "import with false condition is skipped",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source    = "/other/non-existent-file"
+  condition = tm_contains(["a", "b"], "c")
+}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{},
+			},
+		},
+		{
+			name:     "import with true condition is imported",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source    = "/other/non-existent-file"
+  condition = tm_contains(["a", "b"], "a")
+}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrImport,
+						Mkrange("stack/cfg.tm", Start(2, 15, 23), End(2, 41, 49))),
+				},
+			},
+		},
+		{
+			name:     "import with non-boolean condition - fails",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source    = "/other/cfg.tm"
+  condition = "yes"
+}`,
+				},
+				{
+					filename: "other/cfg.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("stack/cfg.tm", Start(3, 15, 53), End(3, 20, 58))),
+				},
+			},
+		},
+		{
+			name:     "import with condition referencing unknown namespace - fails",
+			parsedir: "stack",
+			input: []cfgfile{
+				{
+					filename: "stack/cfg.tm",
+					body: `import {
+  source    = "/other/cfg.tm"
+  condition = global.enabled
+}`,
+				},
+				{
+					filename: "other/cfg.tm",
+					body:     `globals {}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrImport,
+						Mkrange("stack/cfg.tm", Start(3, 15, 53), End(3, 29, 67))),
+				},
+			},
+		},
 	} {
 		testParser(t, tc)
 	}
@@ -0,0 +1,438 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/test"
+	. "github.com/terramate-io/terramate/test/hclutils"
+	"github.com/terramate-io/terramate/warnings"
+)
+
+func TestHCLParserJSONSyntax(t *testing.T) {
+	for _, tc := range []testcase{
+		{
+			name: "terramate and stack blocks",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"//": "comments are ignored",
+						"terramate": {
+							"required_version": "> 0.0.0"
+						}
+					}`,
+				},
+				{
+					filename: "stack.tm.json",
+					body: `{
+						"stack": {
+							"name": "${tm_upper(\"stack-name\")}",
+							"description": "a \"quoted\" description",
+							"after": ["/other"]
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						RequiredVersion: "> 0.0.0",
+					},
+					Stack: &hcl.Stack{
+						Name:        "STACK-NAME",
+						Description: `a "quoted" description`,
+						After:       []string{"/other"},
+					},
+				},
+			},
+		},
+		{
+			name: "mixed native and JSON syntax",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm",
+					body: `
+						terramate {
+							required_version = "> 0.0.0"
+						}
+					`,
+				},
+				{
+					filename: "stack.tm.json",
+					body:     `{"stack": {"name": "stack"}}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						RequiredVersion: "> 0.0.0",
+					},
+					Stack: &hcl.Stack{
+						Name: "stack",
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.run.lock block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body:     `{"terramate":{"config":{"run":{"lock":{"backend":"file"}}}}}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Lock: &hcl.RunLockConfig{
+									Backend: "file",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.run.policy block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"terramate": {
+							"config": {
+								"run": {
+									"policy": {
+										"allowed_commands": ["terraform *"],
+										"denied_flags": ["-auto-approve"]
+									}
+								}
+							}
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Run: &hcl.RunConfig{
+								CheckGenCode: true,
+								Policy: &hcl.RunPolicyConfig{
+									AllowedCommands: []string{"terraform *"},
+									DeniedFlags:     []string{"-auto-approve"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.cloud.queue block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"terramate": {
+							"config": {
+								"cloud": {
+									"queue": {
+										"ttl": "72h",
+										"max_size": 1048576
+									}
+								}
+							}
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Cloud: &hcl.CloudConfig{
+								Queue: &hcl.CloudQueueConfig{
+									TTL:     72 * time.Hour,
+									MaxSize: 1048576,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.notifications block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"terramate": {
+							"config": {
+								"notifications": {
+									"url": "https://hooks.example.com"
+								}
+							}
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Notifications: &hcl.NotificationsConfig{
+								Format: hcl.NotificationsFormatJSON,
+								Events: []string{hcl.NotificationsEventFailure},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.sandbox block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"terramate": {
+							"config": {
+								"sandbox": {
+									"enabled": true,
+									"timeout": "10s",
+									"max_value_size": 1000
+								}
+							}
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Sandbox: &hcl.SandboxConfig{
+								Enabled: true,
+								Limits: stdlib.Sandbox{
+									Timeout:      10 * time.Second,
+									MaxValueSize: 1000,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.warnings block",
+			input: []cfgfile{
+				{
+					filename: "terramate.tm.json",
+					body: `{
+						"terramate": {
+							"config": {
+								"warnings": {
+									"as_errors": ["deprecated-variable"]
+								}
+							}
+						}
+					}`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Warnings: &hcl.WarningsConfig{
+								AsErrors: []warnings.Code{warnings.DeprecatedVariable},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid JSON",
+			input: []cfgfile{
+				{
+					filename: "stack.tm.json",
+					body:     `{"stack": {"name": "stack",}}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrHCLSyntax,
+						Mkrange("stack.tm.json", Start(1, 27, 26), End(1, 28, 27))),
+				},
+			},
+		},
+		{
+			name: "invalid template",
+			input: []cfgfile{
+				{
+					filename: "stack.tm.json",
+					body:     `{"stack": {"name": "${"}}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrHCLSyntax,
+						Mkrange("stack.tm.json", Start(1, 23, 22), End(1, 23, 22))),
+				},
+			},
+		},
+		{
+			name: "top-level value is not an object",
+			input: []cfgfile{
+				{
+					filename: "stack.tm.json",
+					body:     `["stack"]`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrHCLSyntax,
+						Mkrange("stack.tm.json", Start(1, 2, 1), End(1, 9, 8))),
+				},
+			},
+		},
+		{
+			name: "invalid attribute name",
+			input: []cfgfile{
+				{
+					filename: "stack.tm.json",
+					body: `{
+						"stack": {
+							"invalid name": "stack"
+						}
+					}`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrHCLSyntax,
+						Mkrange("stack.tm.json", Start(3, 15, 26), End(3, 29, 40))),
+				},
+			},
+		},
+	} {
+		testParser(t, tc)
+	}
+}
+
+func TestHCLParserJSONSyntaxNestedBlocks(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name        string
+		experiments []string
+		body        string
+		check       func(t *testing.T, cfg *hcl.Config)
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:        "providers block",
+			experiments: []string{hcl.ProvidersExperimentName},
+			body: `{
+				"providers": {
+					"provider": {
+						"aws": {
+							"source": "hashicorp/aws",
+							"version": "~> 5.0"
+						}
+					}
+				}
+			}`,
+			check: func(t *testing.T, cfg *hcl.Config) {
+				assert.EqualInts(t, 1, len(cfg.Providers), "providers blocks")
+				providers := cfg.Providers[0].Providers
+				assert.EqualInts(t, 1, len(providers), "provider blocks")
+				assert.EqualStrings(t, "aws", providers[0].Name)
+			},
+		},
+		{
+			name:        "script arg block",
+			experiments: []string{hcl.ScriptsExperimentName},
+			body: `{
+				"script": {
+					"deploy": {
+						"description": "deploy",
+						"arg": {
+							"env": {
+								"description": "environment",
+								"validation": {
+									"condition": "${length(script.args.env) > 0}"
+								}
+							}
+						},
+						"job": {
+							"command": ["echo", "${script.args.env}"]
+						}
+					}
+				}
+			}`,
+			check: func(t *testing.T, cfg *hcl.Config) {
+				assert.EqualInts(t, 1, len(cfg.Scripts), "script blocks")
+				args := cfg.Scripts[0].Args
+				assert.EqualInts(t, 1, len(args), "script arg blocks")
+				assert.EqualStrings(t, "env", args[0].Name)
+				assert.EqualInts(t, 1, len(args[0].Validations), "arg validation blocks")
+			},
+		},
+		{
+			name: "tm_dynamic inside generate_hcl content",
+			body: `{
+				"generate_hcl": {
+					"main.tf": {
+						"content": {
+							"tm_dynamic": {
+								"resource": {
+									"labels": ["null_resource", "test"],
+									"content": {
+										"triggers": {}
+									}
+								}
+							}
+						}
+					}
+				}
+			}`,
+			check: func(t *testing.T, cfg *hcl.Config) {
+				assert.EqualInts(t, 1, len(cfg.Generate.HCLs), "generate_hcl blocks")
+				body := cfg.Generate.HCLs[0].Content.Body.(*hclsyntax.Body)
+				assert.EqualInts(t, 1, len(body.Blocks), "content blocks")
+				dynamic := body.Blocks[0]
+				assert.EqualStrings(t, "tm_dynamic", dynamic.Type)
+				assert.EqualInts(t, 1, len(dynamic.Body.Blocks), "tm_dynamic blocks")
+				assert.EqualStrings(t, "content", dynamic.Body.Blocks[0].Type)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := test.TempDir(t)
+			test.WriteFile(t, dir, "config.tm.json", tc.body)
+
+			cfg, err := hcl.ParseDir(dir, dir, hcl.WithExperiments(tc.experiments...))
+			assert.NoError(t, err)
+			tc.check(t, cfg)
+		})
+	}
+}
@@ -3,6 +3,8 @@
 
 package hcl
 
+import "github.com/terramate-io/terramate/stdlib"
+
 // WithExperiments is an option to set the experiments to be enabled in the parser.
 func WithExperiments(experiments ...string) Option {
 	return func(p *TerramateParser) {
@@ -10,7 +12,33 @@ func WithExperiments(experiments ...string) Option {
 	}
 }
 
-// WithStrictMode is an option to enable strict mode in the parser.
+// WithSandbox is an option to evaluate the configuration in sandboxed mode,
+// even if it's not enabled with terramate.config.sandbox.
+func WithSandbox(sandbox stdlib.Sandbox) Option {
+	return func(p *TerramateParser) {
+		p.sandbox = &sandbox
+	}
+}
+
+// WithFunctions is an option to make the custom functions of the registry
+// available to the expressions of the configuration.
+func WithFunctions(registry *stdlib.Registry) Option {
+	return func(p *TerramateParser) {
+		p.functions = registry
+	}
+}
+
+// WithConfigFileSuffixes is an option to recognize the files with the given
+// suffixes as Terramate configuration files.
+func WithConfigFileSuffixes(suffixes ...string) Option {
+	return func(p *TerramateParser) {
+		p.configSuffixes = suffixes
+	}
+}
+
+// WithStrictMode is an option to enable strict mode in the parser, in which
+// the root configuration declared outside of the root directory is an error
+// instead of a warning.
 func WithStrictMode() Option {
 	return func(p *TerramateParser) {
 		p.strict = true
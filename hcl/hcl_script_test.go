@@ -747,6 +747,137 @@ func TestHCLScript(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "script with approval job",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+					  terramate {
+						  config {
+							  experiments = ["scripts"]
+						  }
+					  }
+					`,
+				},
+				{
+					filename: "script.tm",
+					body: `
+					  script "deploy" {
+						job {
+						  command = ["terraform", "plan"]
+						}
+						job {
+						  approval {
+							message = "apply the plan?"
+							file    = "approved.txt"
+						  }
+						}
+						job {
+						  command = ["terraform", "apply"]
+						}
+					  }
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Experiments: []string{"scripts"},
+						},
+					},
+					Scripts: []*hcl.Script{
+						{
+							Labels: []string{"deploy"},
+							Jobs: []*hcl.ScriptJob{
+								{
+									Command: makeCommand(t, `["terraform", "plan"]`),
+								},
+								{
+									Approval: &hcl.ScriptApproval{
+										Message: makeAttribute(t, "message", `"apply the plan?"`),
+										File:    makeAttribute(t, "file", `"approved.txt"`),
+									},
+								},
+								{
+									Command: makeCommand(t, `["terraform", "apply"]`),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "script with approval job having commands",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+					  terramate {
+						  config {
+							  experiments = ["scripts"]
+						  }
+					  }
+					`,
+				},
+				{
+					filename: "script.tm",
+					body: `
+					  script "deploy" {
+						job {
+						  approval {}
+						  command = ["terraform", "apply"]
+						}
+					  }
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrScriptCmdConflict,
+						Mkrange("script.tm", Start(5, 9, 66), End(5, 16, 73))),
+					errors.E(hcl.ErrScriptMissingOrInvalidJob,
+						Mkrange("script.tm", Start(2, 8, 8), End(7, 9, 115))),
+				},
+			},
+		},
+		{
+			name: "script with approval job having unrecognized attribute",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+					  terramate {
+						  config {
+							  experiments = ["scripts"]
+						  }
+					  }
+					`,
+				},
+				{
+					filename: "script.tm",
+					body: `
+					  script "deploy" {
+						job {
+						  approval {
+							approvers = ["admin"]
+						  }
+						}
+					  }
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrScriptApprovalUnrecognizedAttr,
+						Mkrange("script.tm", Start(5, 8, 64), End(5, 17, 73))),
+					errors.E(hcl.ErrScriptMissingOrInvalidJob,
+						Mkrange("script.tm", Start(2, 8, 8), End(8, 9, 112))),
+				},
+			},
+		},
 	} {
 		testParser(t, tc)
 	}
@@ -553,6 +553,86 @@ func TestHCLParserStack(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "stack with extends",
+			input: []cfgfile{
+				{
+					filename: "stack.tm",
+					body: `
+						stack {
+							extends = "/templates/base"
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Stack: &hcl.Stack{
+						Extends: "/templates/base",
+					},
+				},
+			},
+		},
+		{
+			name: "stack with invalid extends",
+			input: []cfgfile{
+				{
+					filename: "stack.tm",
+					body: `
+						stack {
+							extends = ["/templates/base"]
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("stack.tm", Start(3, 18, 32), End(3, 37, 51)),
+					),
+				},
+			},
+		},
+		{
+			name: "stack with exec_dir",
+			input: []cfgfile{
+				{
+					filename: "stack.tm",
+					body: `
+						stack {
+							exec_dir = "infra"
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Stack: &hcl.Stack{
+						ExecDir: "infra",
+					},
+				},
+			},
+		},
+		{
+			name: "stack with invalid exec_dir",
+			input: []cfgfile{
+				{
+					filename: "stack.tm",
+					body: `
+						stack {
+							exec_dir = true
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("stack.tm", Start(3, 19, 33), End(3, 23, 37)),
+					),
+				},
+			},
+		},
 		{
 			name:      "'before' and 'after'",
 			nonStrict: true,
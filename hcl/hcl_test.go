@@ -7,17 +7,21 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/madlambda/spells/assert"
 	"github.com/rs/zerolog"
 	"github.com/terramate-io/terramate/cloud"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/safeguard"
+	"github.com/terramate-io/terramate/stdlib"
 	"github.com/terramate-io/terramate/test"
 	errtest "github.com/terramate-io/terramate/test/errors"
 	. "github.com/terramate-io/terramate/test/hclutils"
 	"github.com/terramate-io/terramate/test/hclutils/info"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 type (
@@ -419,7 +423,7 @@ func TestHCLParserTerramateBlock(t *testing.T) {
 					body: `
 						terramate {
 						    config {
-								experiments = ["scripts", "awesome-feature"]
+								experiments = ["scripts", "targets"]
 							}
 						}
 					`,
@@ -429,12 +433,173 @@ func TestHCLParserTerramateBlock(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							Experiments: []string{"scripts", "awesome-feature"},
+							Experiments: []string{"scripts", "targets"},
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "terramate.config.experiments with unknown experiment",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								experiments = ["scripts", "script"]
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 23, 60), End(4, 44, 81))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.follow_symlinks",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								follow_symlinks = true
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							FollowSymlinks: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.strict",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								strict = true
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Strict: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.follow_symlinks with wrong type",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								follow_symlinks = 1
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 27, 64), End(4, 28, 65))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.config_file_suffixes and subprojects",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								config_file_suffixes = [".terramate.hcl"]
+								subprojects          = ["/embedded/", "/other"]
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							ConfigFileSuffixes: []string{".terramate.hcl"},
+							Subprojects: project.Paths{
+								project.NewPath("/embedded"),
+								project.NewPath("/other"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.config_file_suffixes without dot",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								config_file_suffixes = ["terramate.hcl"]
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 32, 69), End(4, 49, 86))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.subprojects with relative path",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						    config {
+								subprojects = ["embedded"]
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(4, 23, 60), End(4, 35, 72))),
+				},
+			},
+		},
 		{
 			name: "terramate.config.disable_safeguards with wrong type",
 			input: []cfgfile{
@@ -776,6 +941,8 @@ func TestHCLParserRootConfig(t *testing.T) {
 									check_untracked         = false
 									check_uncommitted       = false
 									check_remote            = false
+									fetch_missing_base_ref  = true
+									fetch_depth             = 50
 								}
 							}
 						}
@@ -787,11 +954,13 @@ func TestHCLParserRootConfig(t *testing.T) {
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
 							Git: &hcl.GitConfig{
-								DefaultBranch:    "trunk",
-								DefaultRemote:    "upstream",
-								CheckUntracked:   false,
-								CheckUncommitted: false,
-								CheckRemote:      hcl.CheckIsFalse,
+								DefaultBranch:       "trunk",
+								DefaultRemote:       "upstream",
+								CheckUntracked:      false,
+								CheckUncommitted:    false,
+								CheckRemote:         hcl.CheckIsFalse,
+								FetchMissingBaseRef: true,
+								FetchDepth:          50,
 							},
 						},
 					},
@@ -855,6 +1024,32 @@ func TestHCLParserRootConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "git.fetch fields with invalid values",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								git {
+									fetch_missing_base_ref = "yes"
+									fetch_depth            = 0
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(5, 35, 83), End(5, 40, 88))),
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("cfg.tm", Start(6, 35, 123), End(6, 36, 124))),
+				},
+			},
+		},
 		{
 			name: "empty config.cloud block",
 			input: []cfgfile{
@@ -938,15 +1133,18 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "terramate.config.generate.hcl_magic_header_comment_style = //",
+			name: "terramate.config.cloud.queue",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
 							config {
-								generate {
-									hcl_magic_header_comment_style = "//"
+								cloud {
+									queue {
+										ttl      = "72h"
+										max_size = 1048576
+									}
 								}
 							}
 						}
@@ -957,8 +1155,11 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							Generate: &hcl.GenerateRootConfig{
-								HCLMagicHeaderCommentStyle: ptr("//"),
+							Cloud: &hcl.CloudConfig{
+								Queue: &hcl.CloudQueueConfig{
+									TTL:     72 * time.Hour,
+									MaxSize: 1048576,
+								},
 							},
 						},
 					},
@@ -966,47 +1167,42 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "terramate.config.generate.hcl_magic_header_comment_style = #",
+			name: "terramate.config.cloud.queue.ttl is not a duration -- fail",
 			input: []cfgfile{
 				{
-					filename: "cfg.tm",
+					filename: "tm.tm",
 					body: `
-						terramate {
-							config {
-								generate {
-									hcl_magic_header_comment_style = "#"
+					terramate {
+						config {
+							cloud {
+								queue {
+									ttl = "forever"
 								}
 							}
 						}
+					}
 					`,
 				},
 			},
 			want: want{
-				config: hcl.Config{
-					Terramate: &hcl.Terramate{
-						Config: &hcl.RootConfig{
-							Generate: &hcl.GenerateRootConfig{
-								HCLMagicHeaderCommentStyle: ptr("#"),
-							},
-						},
-					},
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("tm.tm", Start(6, 16, 79), End(6, 25, 88))),
 				},
 			},
 		},
 		{
-			name: "terramate.config.change_detection.terragrunt.enabled = auto",
+			name: "terramate.config.generate.hcl_magic_header_comment_style = //",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
-						  config {
-						    change_detection {
-							  terragrunt {
-							    enabled = "auto"
-							  }
+							config {
+								generate {
+									hcl_magic_header_comment_style = "//"
+								}
 							}
-						  }
 						}
 					`,
 				},
@@ -1015,10 +1211,8 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							ChangeDetection: &hcl.ChangeDetectionConfig{
-								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
-									Enabled: hcl.TerragruntAutoOption,
-								},
+							Generate: &hcl.GenerateRootConfig{
+								HCLMagicHeaderCommentStyle: ptr("//"),
 							},
 						},
 					},
@@ -1026,19 +1220,17 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "terramate.config.change_detection.terragrunt.enabled = off",
+			name: "terramate.config.generate.hcl_magic_header_comment_style = #",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
-						  config {
-						    change_detection {
-							  terragrunt {
-							    enabled = "off"
-							  }
+							config {
+								generate {
+									hcl_magic_header_comment_style = "#"
+								}
 							}
-						  }
 						}
 					`,
 				},
@@ -1047,10 +1239,8 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							ChangeDetection: &hcl.ChangeDetectionConfig{
-								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
-									Enabled: hcl.TerragruntOffOption,
-								},
+							Generate: &hcl.GenerateRootConfig{
+								HCLMagicHeaderCommentStyle: ptr("#"),
 							},
 						},
 					},
@@ -1058,19 +1248,17 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "terramate.config.change_detection.terragrunt.enabled = force",
+			name: "terramate.config.generate.fsync = true",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
-						  config {
-						    change_detection {
-							  terragrunt {
-							    enabled = "force"
-							  }
+							config {
+								generate {
+									fsync = true
+								}
 							}
-						  }
 						}
 					`,
 				},
@@ -1079,10 +1267,8 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							ChangeDetection: &hcl.ChangeDetectionConfig{
-								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
-									Enabled: hcl.TerragruntForceOption,
-								},
+							Generate: &hcl.GenerateRootConfig{
+								Fsync: func() *bool { b := true; return &b }(),
 							},
 						},
 					},
@@ -1090,20 +1276,17 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "disabling terramate.config.change_detection.git",
+			name: "terramate.config.generate.track_headerless_files = true",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
-						  config {
-						    change_detection {
-							  git {
-							    untracked = "off"
-								uncommitted = false
-							  }
+							config {
+								generate {
+									track_headerless_files = true
+								}
 							}
-						  }
 						}
 					`,
 				},
@@ -1112,11 +1295,8 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							ChangeDetection: &hcl.ChangeDetectionConfig{
-								Git: &hcl.GitChangeDetectionConfig{
-									Untracked:   &off,
-									Uncommitted: &off,
-								},
+							Generate: &hcl.GenerateRootConfig{
+								TrackHeaderlessFiles: func() *bool { b := true; return &b }(),
 							},
 						},
 					},
@@ -1124,13 +1304,424 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "enabling terramate.config.change_detection.git",
+			name: "terramate.config.generate.simplify_expressions = true",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
-						  config {
+							config {
+								generate {
+									simplify_expressions = true
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Generate: &hcl.GenerateRootConfig{
+								SimplifyExpressions: func() *bool { b := true; return &b }(),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate indent_size and max_line_width",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									indent_size    = 4
+									max_line_width = 100
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Generate: &hcl.GenerateRootConfig{
+								IndentSize:   func() *int { i := 4; return &i }(),
+								MaxLineWidth: func() *int { i := 100; return &i }(),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate timestamp and random_seed",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									timestamp   = "2026-01-02T03:04:05Z"
+									random_seed = "ci"
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Generate: &hcl.GenerateRootConfig{
+								Timestamp:  "2026-01-02T03:04:05Z",
+								RandomSeed: "ci",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.file_types",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									file_types = {
+										"*.tf"   = "generate_hcl"
+										"*.yml"  = "yaml"
+										"*.json" = "json"
+									}
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Generate: &hcl.GenerateRootConfig{
+								FileTypes: []hcl.GenerateFileType{
+									{Pattern: "*.json", Type: "json"},
+									{Pattern: "*.tf", Type: "generate_hcl"},
+									{Pattern: "*.yml", Type: "yaml"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.file_types with unknown type -- fail",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									file_types = {
+										"*.tf" = "terraform"
+									}
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.file_types with invalid pattern -- fail",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									file_types = {
+										"[.tf" = "hcl"
+									}
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.timestamp is not RFC3339",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								generate {
+									timestamp = "yesterday"
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "terramate.config.sandbox",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								sandbox {
+									enabled        = true
+									timeout        = "10s"
+									max_value_size = 1000
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Sandbox: &hcl.SandboxConfig{
+								Enabled: true,
+								Limits: stdlib.Sandbox{
+									Timeout:      10 * time.Second,
+									MaxValueSize: 1000,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.warnings",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								warnings {
+									as_errors = ["deprecated-variable", "config-schema"]
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Warnings: &hcl.WarningsConfig{
+								AsErrors: []warnings.Code{
+									warnings.DeprecatedVariable,
+									warnings.ConfigSchema,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.warnings.as_errors with unknown code",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								warnings {
+									as_errors = ["deprecated"]
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "terramate.config.change_detection.terragrunt.enabled = auto",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    change_detection {
+							  terragrunt {
+							    enabled = "auto"
+							  }
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							ChangeDetection: &hcl.ChangeDetectionConfig{
+								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
+									Enabled: hcl.TerragruntAutoOption,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.change_detection.terragrunt.enabled = off",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    change_detection {
+							  terragrunt {
+							    enabled = "off"
+							  }
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							ChangeDetection: &hcl.ChangeDetectionConfig{
+								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
+									Enabled: hcl.TerragruntOffOption,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.change_detection.terragrunt.enabled = force",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    change_detection {
+							  terragrunt {
+							    enabled = "force"
+							  }
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							ChangeDetection: &hcl.ChangeDetectionConfig{
+								Terragrunt: &hcl.TerragruntChangeDetectionConfig{
+									Enabled: hcl.TerragruntForceOption,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "disabling terramate.config.change_detection.git",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    change_detection {
+							  git {
+							    untracked = "off"
+								uncommitted = false
+							  }
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							ChangeDetection: &hcl.ChangeDetectionConfig{
+								Git: &hcl.GitChangeDetectionConfig{
+									Untracked:   &off,
+									Uncommitted: &off,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "enabling terramate.config.change_detection.git",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
 						    change_detection {
 							  git {
 							    untracked = "on"
@@ -1158,15 +1749,71 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "disabling terramate.config.telemetry with string",
+			name: "disabling terramate.config.telemetry with string",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    telemetry {
+							  enabled = "off"
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Telemetry: &hcl.TelemetryConfig{
+								Enabled: &off,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "disabling terramate.config.telemetry with bool",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+						  config {
+						    telemetry {
+							  enabled = false
+							}
+						  }
+						}
+					`,
+				},
+			},
+			want: want{
+				config: hcl.Config{
+					Terramate: &hcl.Terramate{
+						Config: &hcl.RootConfig{
+							Telemetry: &hcl.TelemetryConfig{
+								Enabled: &off,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "terramate.config.notifications with defaults",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
 						  config {
-						    telemetry {
-							  enabled = "off"
+						    notifications {
+							  url = "https://hooks.example.com/${env.TOKEN}"
 							}
 						  }
 						}
@@ -1177,8 +1824,9 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							Telemetry: &hcl.TelemetryConfig{
-								Enabled: &off,
+							Notifications: &hcl.NotificationsConfig{
+								Format: hcl.NotificationsFormatJSON,
+								Events: []string{hcl.NotificationsEventFailure},
 							},
 						},
 					},
@@ -1186,15 +1834,17 @@ func TestHCLParserRootConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "disabling terramate.config.telemetry with bool",
+			name: "terramate.config.notifications with slack format and drift events",
 			input: []cfgfile{
 				{
 					filename: "cfg.tm",
 					body: `
 						terramate {
 						  config {
-						    telemetry {
-							  enabled = false
+						    notifications {
+							  url    = "https://hooks.slack.com/services/${env.SLACK_TOKEN}"
+							  format = "slack"
+							  events = ["failure", "drift"]
 							}
 						  }
 						}
@@ -1205,8 +1855,12 @@ func TestHCLParserRootConfig(t *testing.T) {
 				config: hcl.Config{
 					Terramate: &hcl.Terramate{
 						Config: &hcl.RootConfig{
-							Telemetry: &hcl.TelemetryConfig{
-								Enabled: &off,
+							Notifications: &hcl.NotificationsConfig{
+								Format: hcl.NotificationsFormatSlack,
+								Events: []string{
+									hcl.NotificationsEventFailure,
+									hcl.NotificationsEventDrift,
+								},
 							},
 						},
 					},
@@ -1432,6 +2086,98 @@ func TestHCLParserMultipleErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "terramate.config.generate.indent_size is zero -- fail",
+			input: []cfgfile{
+				{
+					filename: "tm.tm",
+					body: `
+					terramate {
+						config {
+							generate {
+								indent_size = 0
+							}
+						}
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("tm.tm", Start(5, 23, 73), End(5, 24, 74))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.max_line_width is not a number -- fail",
+			input: []cfgfile{
+				{
+					filename: "tm.tm",
+					body: `
+					terramate {
+						config {
+							generate {
+								max_line_width = "100"
+							}
+						}
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("tm.tm", Start(5, 26, 76), End(5, 31, 81))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.generate.fsync is not bool -- fail",
+			input: []cfgfile{
+				{
+					filename: "tm.tm",
+					body: `
+					terramate {
+						config {
+							generate {
+								fsync = "yes"
+							}
+						}
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("tm.tm", Start(5, 17, 67), End(5, 22, 72))),
+				},
+			},
+		},
+		{
+			name: "terramate.config.sandbox.timeout is not a duration -- fail",
+			input: []cfgfile{
+				{
+					filename: "tm.tm",
+					body: `
+					terramate {
+						config {
+							sandbox {
+								timeout = "forever"
+							}
+						}
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema,
+						Mkrange("tm.tm", Start(5, 19, 68), End(5, 28, 77))),
+				},
+			},
+		},
 		{
 			name: "generate_file with inherit and context=root -- fails",
 			input: []cfgfile{
@@ -1452,6 +2198,66 @@ func TestHCLParserMultipleErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "generate_file with visibility and context=root -- fails",
+			input: []cfgfile{
+				{
+					filename: "gen.tm",
+					body: `
+					generate_file "test.tf" {
+						content    = "fail"
+						context    = root
+						visibility = "directory"
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "generate_hcl with invalid visibility -- fails",
+			input: []cfgfile{
+				{
+					filename: "gen.tm",
+					body: `
+					generate_hcl "test.tf" {
+						visibility = "subdirs"
+						content {
+							a = 1
+						}
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "generate_file with for_each_stack and context=stack -- fails",
+			input: []cfgfile{
+				{
+					filename: "gen.tm",
+					body: `
+					generate_file "test.tf" {
+						content = "fail"
+						for_each_stack = true
+					}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
 	} {
 		testParser(t, tc)
 	}
@@ -1535,6 +2341,136 @@ func TestHCLParserGenerateStackFilters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "generate_hcl - invalid tags filter",
+			input: []cfgfile{
+				{
+					filename: "gen.tm",
+					body: `
+						generate_hcl "test.tf" {
+							stack_filter { tags = ["Invalid:tag"] }
+							content { foo = "bar" }
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "generate_file - invalid line_endings",
+			input: []cfgfile{
+				{
+					filename: "gen.tm",
+					body: `
+						generate_file "test.bat" {
+							line_endings = "cr"
+							content      = "foo"
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "notifications - missing url",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								notifications {
+									format = "json"
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "notifications - unsupported format and event",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								notifications {
+									url    = "https://example.com"
+									format = "xml"
+									events = ["success"]
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "stack_defaults - invalid tag",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								stack_defaults {
+									tags = ["_invalid"]
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
+		{
+			name: "stack_defaults - tag added and removed",
+			input: []cfgfile{
+				{
+					filename: "cfg.tm",
+					body: `
+						terramate {
+							config {
+								stack_defaults {
+									tags        = ["a"]
+									remove_tags = ["a"]
+								}
+							}
+						}
+					`,
+				},
+			},
+			want: want{
+				errs: []error{
+					errors.E(hcl.ErrTerramateSchema),
+				},
+			},
+		},
 		{
 			name: "generate_file - invalid context",
 			input: []cfgfile{
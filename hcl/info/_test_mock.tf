@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "info" {
-  content = <<-EOT
+  content = <<-EOF
 package info // import "github.com/terramate-io/terramate/hcl/info"
 
 Package info provides informational types related to hcl.
@@ -11,7 +11,7 @@ type Pos struct{ ... }
 type Range struct{ ... }
     func NewRange(rootdir string, r hcl.Range) Range
 type Ranges []Range
-EOT
+EOF
 
   filename = "${path.module}/mock-info.ignore"
 }
@@ -0,0 +1,142 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclparse"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// parseJSON parses the configuration file in the HCL JSON syntax with the
+// JSON parser of hclparse and replaces the parsed file by its native syntax
+// tree, as the configuration is decoded from the native syntax tree.
+// The JSON syntax has no way to tell blocks and attributes apart, then the
+// properties are blocks only if they are in the given schema.
+func parseJSON(parser *hclparse.Parser, filename string, src []byte, schema *blockSchema) hcl.Diagnostics {
+	file, diags := parser.ParseJSON(src, filename)
+	if diags.HasErrors() {
+		return diags
+	}
+	body, diags := jsonBody(file.Body, schema)
+	if diags.HasErrors() {
+		return diags
+	}
+	parser.AddFile(filename, &hcl.File{Body: body, Bytes: src})
+	return nil
+}
+
+// jsonBody converts the body of a JSON file into a native syntax body, with
+// the blocks of the given schema.
+func jsonBody(body hcl.Body, schema *blockSchema) (*hclsyntax.Body, hcl.Diagnostics) {
+	bodySchema := &hcl.BodySchema{Blocks: schema.headers()}
+	content, remain, diags := body.PartialContent(bodySchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attrs, diags := remain.JustAttributes()
+
+	rng := body.MissingItemRange()
+	native := &hclsyntax.Body{
+		Attributes: hclsyntax.Attributes{},
+		SrcRange:   rng,
+		EndRange:   rng,
+	}
+	for name, attr := range attrs {
+		if !hclsyntax.ValidIdentifier(name) {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid attribute name",
+				Detail:   fmt.Sprintf("The property %q is not a valid attribute name.", name),
+				Subject:  attr.NameRange.Ptr(),
+			})
+			continue
+		}
+		expr, exprDiags := jsonExpr(attr.Expr)
+		diags = diags.Extend(exprDiags)
+		native.Attributes[name] = &hclsyntax.Attribute{
+			Name:        name,
+			Expr:        expr,
+			SrcRange:    attr.Range,
+			NameRange:   attr.NameRange,
+			EqualsRange: attr.NameRange,
+		}
+	}
+	for _, block := range content.Blocks {
+		blockBody, blockDiags := jsonBody(block.Body, schema.blocks[block.Type])
+		diags = diags.Extend(blockDiags)
+		if blockBody == nil {
+			continue
+		}
+		native.Blocks = append(native.Blocks, &hclsyntax.Block{
+			Type:            block.Type,
+			Labels:          block.Labels,
+			Body:            blockBody,
+			TypeRange:       block.TypeRange,
+			LabelRanges:     block.LabelRanges,
+			OpenBraceRange:  blockBody.SrcRange,
+			CloseBraceRange: blockBody.EndRange,
+		})
+	}
+	return native, diags
+}
+
+// jsonExpr converts the expression of a JSON file into a native syntax
+// expression. The strings are templates, as defined by the HCL JSON
+// specification, so a string with a single interpolation evaluates to the
+// value of the interpolated expression.
+func jsonExpr(expr hcl.Expression) (hclsyntax.Expression, hcl.Diagnostics) {
+	// without an evaluation context, the strings are not parsed as templates.
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	rng := expr.Range()
+	typ := val.Type()
+	switch {
+	case typ == cty.String && val.IsKnown() && !val.IsNull():
+		start := rng.Start
+		// skips the opening quote.
+		start.Column++
+		start.Byte++
+		return hclsyntax.ParseTemplate([]byte(val.AsString()), rng.Filename, start)
+	case typ.IsTupleType():
+		elems, diags := hcl.ExprList(expr)
+		tuple := &hclsyntax.TupleConsExpr{
+			SrcRange:  rng,
+			OpenRange: rng,
+		}
+		for _, elem := range elems {
+			native, elemDiags := jsonExpr(elem)
+			diags = diags.Extend(elemDiags)
+			tuple.Exprs = append(tuple.Exprs, native)
+		}
+		return tuple, diags
+	case typ.IsObjectType():
+		pairs, diags := hcl.ExprMap(expr)
+		object := &hclsyntax.ObjectConsExpr{
+			SrcRange:  rng,
+			OpenRange: rng,
+		}
+		for _, pair := range pairs {
+			key, keyDiags := jsonExpr(pair.Key)
+			diags = diags.Extend(keyDiags)
+			value, valueDiags := jsonExpr(pair.Value)
+			diags = diags.Extend(valueDiags)
+			object.Items = append(object.Items, hclsyntax.ObjectConsItem{
+				KeyExpr:   &hclsyntax.ObjectConsKeyExpr{Wrapped: key},
+				ValueExpr: value,
+			})
+		}
+		return object, diags
+	default:
+		return &hclsyntax.LiteralValueExpr{
+			Val:      val,
+			SrcRange: rng,
+		}, nil
+	}
+}
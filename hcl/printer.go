@@ -85,6 +85,14 @@ func PrintConfig(w io.Writer, cfg Config) error {
 			stackBody.SetAttributeValue("watch", cty.SetVal(listToValue(stack.Watch)))
 		}
 
+		if stack.Extends != "" {
+			stackBody.SetAttributeValue("extends", cty.StringVal(stack.Extends))
+		}
+
+		if stack.ExecDir != "" {
+			stackBody.SetAttributeValue("exec_dir", cty.StringVal(stack.ExecDir))
+		}
+
 		if stack.ID != "" {
 			stackBody.SetAttributeValue("id", cty.StringVal(stack.ID))
 		}
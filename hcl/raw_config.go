@@ -39,6 +39,11 @@ type RawConfig struct {
 
 type dupeHandler func(r *RawConfig, block *ast.Block) error
 
+// knownBlocks returns the types of the blocks recognized by the config.
+func (cfg *RawConfig) knownBlocks() []string {
+	return slices.Collect(maps.Keys(cfg.dupeHandlers))
+}
+
 // NewTopLevelRawConfig returns a new RawConfig object tailored for the
 // Terramate top-level attributes and blocks.
 func NewTopLevelRawConfig() RawConfig {
@@ -89,7 +94,7 @@ func (cfg *RawConfig) mergeBlocks(blocks ast.Blocks) error {
 		if !ok {
 			errs.Append(
 				errors.E(ErrTerramateSchema, block.DefRange(),
-					"unrecognized block %q", block.Type),
+					"unrecognized block %q%s", block.Type, didYouMean(block.Type, cfg.knownBlocks())),
 			)
 
 			continue
@@ -0,0 +1,237 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/terramate-io/hcl/v2"
+)
+
+// blockSchema describes the labels and the nested blocks of a configuration
+// block. The block parsers validate the nested blocks of the native syntax
+// with it and the same schema decodes the blocks of the JSON syntax, which
+// has no other way to tell blocks and attributes apart.
+// Blocks with a variable number of labels (globals and script) are limited to
+// the given number of labels in the JSON syntax.
+type blockSchema struct {
+	labels int
+	blocks map[string]*blockSchema
+}
+
+// blockSchemaProvider is implemented by the block handlers of the blocks with
+// labels or nested blocks.
+type blockSchemaProvider interface {
+	schema() *blockSchema
+}
+
+// mapBlockSchema is the schema of the map blocks of globals and lets.
+var mapBlockSchema = newMapBlockSchema()
+
+// genhclContentSchema is the schema of the content block of generate_hcl.
+// The content is arbitrary code, then only the tm_dynamic blocks are known.
+var genhclContentSchema = newGenHCLContentSchema()
+
+// terramateBlockSchema is the schema of the terramate block.
+var terramateBlockSchema = &blockSchema{
+	blocks: map[string]*blockSchema{
+		"config": {
+			blocks: map[string]*blockSchema{
+				"git":      {},
+				"generate": {},
+				"change_detection": {
+					blocks: map[string]*blockSchema{
+						"terragrunt": {},
+						"git":        {},
+					},
+				},
+				"run": {
+					blocks: map[string]*blockSchema{
+						"env":    {},
+						"policy": {},
+						"lock":   {},
+					},
+				},
+				"cloud": {
+					blocks: map[string]*blockSchema{
+						"targets": {},
+						"queue":   {},
+					},
+				},
+				"targets":        {},
+				"telemetry":      {},
+				"stack_defaults": {},
+				"notifications":  {},
+				"sandbox":        {},
+				"warnings":       {},
+			},
+		},
+	},
+}
+
+// generateHCLBlockSchema is the schema of the generate_hcl block.
+var generateHCLBlockSchema = generateBlockSchema(map[string]*blockSchema{
+	"content": genhclContentSchema,
+})
+
+// generateFileBlockSchema is the schema of the generate_file and
+// generate_yaml blocks.
+var generateFileBlockSchema = generateBlockSchema(nil)
+
+// scriptBlockSchema is the schema of the script block.
+var scriptBlockSchema = &blockSchema{
+	labels: 1,
+	blocks: map[string]*blockSchema{
+		"job": {
+			blocks: map[string]*blockSchema{"approval": {}},
+		},
+		"lets": letsBlockSchema(),
+		"arg": {
+			labels: 1,
+			blocks: map[string]*blockSchema{"validation": {}},
+		},
+	},
+}
+
+// providersBlockSchema is the schema of the providers block.
+var providersBlockSchema = &blockSchema{
+	blocks: map[string]*blockSchema{
+		"provider": {labels: 1},
+	},
+}
+
+// globalsBlockSchema is the schema of the globals block.
+var globalsBlockSchema = &blockSchema{
+	blocks: map[string]*blockSchema{
+		"map":    mapBlockSchema,
+		"global": {labels: 1},
+	},
+}
+
+// vendorBlockSchema is the schema of the vendor block.
+var vendorBlockSchema = &blockSchema{
+	blocks: map[string]*blockSchema{
+		"manifest": {
+			blocks: map[string]*blockSchema{"default": {}},
+		},
+	},
+}
+
+// labeledBlockSchema is the schema of the blocks with a single label and no
+// nested blocks.
+var labeledBlockSchema = &blockSchema{labels: 1}
+
+func newMapBlockSchema() *blockSchema {
+	mapSchema := &blockSchema{labels: 1}
+	mapSchema.blocks = map[string]*blockSchema{
+		"value": {blocks: map[string]*blockSchema{"map": mapSchema}},
+	}
+	return mapSchema
+}
+
+func newGenHCLContentSchema() *blockSchema {
+	content := &blockSchema{}
+	content.blocks = map[string]*blockSchema{
+		"tm_dynamic": {
+			labels: 1,
+			blocks: map[string]*blockSchema{"content": content},
+		},
+	}
+	return content
+}
+
+// generateBlockSchema returns the schema of the generate blocks, with the
+// given extra nested blocks.
+func generateBlockSchema(extra map[string]*blockSchema) *blockSchema {
+	schema := &blockSchema{
+		labels: 1,
+		blocks: map[string]*blockSchema{
+			"lets":         letsBlockSchema(),
+			"assert":       {},
+			"stack_filter": {},
+		},
+	}
+	for typ, block := range extra {
+		schema.blocks[typ] = block
+	}
+	return schema
+}
+
+// letsBlockSchema returns the schema of the lets blocks.
+func letsBlockSchema() *blockSchema {
+	return &blockSchema{
+		blocks: map[string]*blockSchema{"map": mapBlockSchema},
+	}
+}
+
+// block returns the schema of the nested block of the given path. It panics
+// if the path is not in the schema, which is a programming error.
+func (s *blockSchema) block(path ...string) *blockSchema {
+	schema := s
+	for _, typ := range path {
+		next, ok := schema.blocks[typ]
+		if !ok {
+			panic("unknown block " + typ + " in schema")
+		}
+		schema = next
+	}
+	return schema
+}
+
+// blockTypes returns the sorted types of the nested blocks.
+func (s *blockSchema) blockTypes() []string {
+	types := make([]string, 0, len(s.blocks))
+	for typ := range s.blocks {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// headers returns the header schemas of the nested blocks.
+func (s *blockSchema) headers() []hcl.BlockHeaderSchema {
+	headers := make([]hcl.BlockHeaderSchema, 0, len(s.blocks))
+	for _, typ := range s.blockTypes() {
+		labels := make([]string, s.blocks[typ].labels)
+		for i := range labels {
+			labels[i] = fmt.Sprintf("label%d", i)
+		}
+		headers = append(headers, hcl.BlockHeaderSchema{
+			Type:       typ,
+			LabelNames: labels,
+		})
+	}
+	return headers
+}
+
+// topLevelSchema returns the schema of the top-level blocks handled by the
+// parser.
+func (p *TerramateParser) topLevelSchema() *blockSchema {
+	schema := &blockSchema{
+		blocks: map[string]*blockSchema{
+			"import": {},
+		},
+	}
+	add := func(name string, handler any) {
+		if provider, ok := handler.(blockSchemaProvider); ok {
+			schema.blocks[name] = provider.schema()
+		} else {
+			schema.blocks[name] = &blockSchema{}
+		}
+	}
+	for name, handler := range p.unmergedBlockHandlers {
+		add(name, handler)
+	}
+	for name, handler := range p.mergedBlockHandlers {
+		add(name, handler)
+	}
+	for name, handler := range p.mergedLabelsBlockHandlers {
+		add(name, handler)
+	}
+	for name, handler := range p.uniqueBlockHandlers {
+		add(name, handler)
+	}
+	return schema
+}
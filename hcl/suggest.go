@@ -0,0 +1,66 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/hcl/eval"
+)
+
+// didYouMean returns a hint with the known names similar to name, to be
+// appended to the error of an unrecognized name, or an empty string if
+// there's no similar name.
+func didYouMean(name string, known []string) string {
+	suggestions := eval.Suggest(name, known)
+	if len(suggestions) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf(", did you mean %s?", quoted[0])
+	}
+	return fmt.Sprintf(", did you mean one of %s?", strings.Join(quoted, ", "))
+}
+
+// bodyContent validates the body against the schema, like body.Content(),
+// adding suggestions to the diagnostics of unsupported arguments and blocks.
+func bodyContent(body *hclsyntax.Body, schema *hcl.BodySchema) hcl.Diagnostics {
+	_, diags := body.Content(schema)
+
+	var attrNames, blockTypes []string
+	for _, attr := range schema.Attributes {
+		attrNames = append(attrNames, attr.Name)
+	}
+	for _, block := range schema.Blocks {
+		blockTypes = append(blockTypes, block.Type)
+	}
+
+	for _, diag := range diags {
+		if diag.Subject == nil {
+			continue
+		}
+		switch diag.Summary {
+		case "Unsupported argument":
+			for name, attr := range body.Attributes {
+				if attr.NameRange == *diag.Subject {
+					diag.Detail += didYouMean(name, attrNames)
+				}
+			}
+		case "Unsupported block type":
+			for _, block := range body.Blocks {
+				if block.TypeRange == *diag.Subject {
+					diag.Detail += didYouMean(block.Type, blockTypes)
+				}
+			}
+		}
+	}
+	return diags
+}
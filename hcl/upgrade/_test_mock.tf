@@ -0,0 +1,19 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "upgrade" {
+  content = <<-EOF
+package upgrade // import "github.com/terramate-io/terramate/hcl/upgrade"
+
+Package upgrade contains functions for rewriting deprecated Terramate
+configuration into its up-to-date form.
+
+const ErrHCLSyntax errors.Kind = "HCL syntax error"
+const ErrReadFile errors.Kind = "failed to read file"
+func Config(src, filename string) (string, []string, error)
+func Header(code string) (string, bool)
+type Result struct{ ... }
+    func Tree(dir string) ([]Result, error)
+EOF
+
+  filename = "${path.module}/mock-upgrade.ignore"
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Diff returns an unified diff between the original and the upgraded
+// contents of the file.
+func (r Result) Diff() string {
+	ops := diffLines(splitLines(r.original), splitLines(r.upgraded))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", r.path, r.path)
+
+	oldline, newline := 1, 1
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			oldline++
+			newline++
+			start++
+			continue
+		}
+
+		// hunk begins with (up to) diffContext lines before the first change
+		// and ends when more than 2*diffContext unchanged lines are found.
+		begin := max(start-diffContext, 0)
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			unchanged := 0
+			for end+unchanged < len(ops) && ops[end+unchanged].kind == ' ' {
+				unchanged++
+			}
+			if end+unchanged == len(ops) || unchanged > 2*diffContext {
+				end += min(unchanged, diffContext)
+				break
+			}
+			end += unchanged
+		}
+
+		hunkOld, hunkNew := oldline-(start-begin), newline-(start-begin)
+		var oldcount, newcount int
+		for _, op := range ops[begin:end] {
+			if op.kind != '+' {
+				oldcount++
+			}
+			if op.kind != '-' {
+				newcount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", hunkOld, oldcount, hunkNew, newcount)
+		for _, op := range ops[begin:end] {
+			fmt.Fprintf(&b, "%c%s\n", op.kind, op.line)
+		}
+
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				oldline++
+			}
+			if op.kind != '-' {
+				newline++
+			}
+		}
+		start = end
+	}
+	return b.String()
+}
+
+// diffLines computes the line operations needed to transform a into b using
+// the longest common subsequence of lines.
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
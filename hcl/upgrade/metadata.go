@@ -0,0 +1,106 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package upgrade
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/errors"
+)
+
+// deprecatedMetadata maps the deprecated metadata of the old terramate
+// namespace to the metadata replacing them.
+var deprecatedMetadata = []struct {
+	old []string
+	new []string
+}{
+	{[]string{"terramate", "name"}, []string{"terramate", "stack", "name"}},
+	{[]string{"terramate", "path"}, []string{"terramate", "stack", "path", "absolute"}},
+	{[]string{"terramate", "description"}, []string{"terramate", "stack", "description"}},
+}
+
+// MetadataTree rewrites the references to deprecated metadata in all the
+// Terramate files, including the files with any of the given configSuffixes,
+// in the given tree starting at the given dir. It will recursively navigate
+// on sub directories.
+//
+// Files that need no rewrite are ignored. All files will be left untouched.
+// To save the rewritten result on disk you can use Result.Save for each
+// Result.
+func MetadataTree(dir string, configSuffixes ...string) ([]Result, error) {
+	return upgradeTree(dir, Metadata, false, configSuffixes)
+}
+
+// Metadata rewrites the references to deprecated metadata of the given
+// Terramate configuration source code, eg.: terramate.path is rewritten as
+// terramate.stack.path.absolute. Only the references are changed, the rest
+// of the source (formatting and comments) is preserved.
+// It returns the rewritten code and a description of each applied change.
+// If no change is needed the returned code is the same as the given one.
+//
+// It returns an error if the given source is invalid HCL.
+func Metadata(src, filename string) (string, []string, error) {
+	parsed, diags := hclwrite.ParseConfig([]byte(src), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", nil, errors.E(ErrHCLSyntax, diags)
+	}
+
+	var changes []string
+	var walk func(body *hclwrite.Body)
+	walk = func(body *hclwrite.Body) {
+		attrs := body.Attributes()
+		attrNames := make([]string, 0, len(attrs))
+		for attrName := range attrs {
+			attrNames = append(attrNames, attrName)
+		}
+		sort.Strings(attrNames)
+		for _, attrName := range attrNames {
+			for _, traversal := range attrs[attrName].Expr().Variables() {
+				changes = append(changes, rewriteMetadata(traversal)...)
+			}
+		}
+		for _, block := range body.Blocks() {
+			walk(block.Body())
+		}
+	}
+	walk(parsed.Body())
+
+	if len(changes) == 0 {
+		return src, nil, nil
+	}
+	return string(parsed.Bytes()), changes, nil
+}
+
+// rewriteMetadata rewrites the traversal if it references deprecated
+// metadata. The tokens are changed in place, keeping the surrounding source.
+func rewriteMetadata(traversal *hclwrite.Traversal) []string {
+	var names []*hclwrite.Token
+	for _, tok := range traversal.BuildTokens(nil) {
+		if tok.Type == hclsyntax.TokenIdent {
+			names = append(names, tok)
+		}
+	}
+	for _, deprecated := range deprecatedMetadata {
+		if len(names) != len(deprecated.old) {
+			continue
+		}
+		match := true
+		for i, name := range names {
+			match = match && string(name.Bytes) == deprecated.old[i]
+		}
+		if !match {
+			continue
+		}
+		// the old namespace has only attributes of the root object.
+		last := names[len(names)-1]
+		last.Bytes = []byte(strings.Join(deprecated.new[1:], "."))
+		return []string{"replaced deprecated " + strings.Join(deprecated.old, ".") +
+			" with " + strings.Join(deprecated.new, ".")}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package upgrade // import \"github.com/terramate-io/terramate/hcl/upgrade\""
+  description = "package upgrade // import \"github.com/terramate-io/terramate/hcl/upgrade\"\n\nPackage upgrade contains functions for rewriting deprecated Terramate\nconfiguration into its up-to-date form.\n\nconst ErrHCLSyntax errors.Kind = \"HCL syntax error\"\nconst ErrReadFile errors.Kind = \"failed to read file\"\nfunc Config(src, filename string) (string, []string, error)\nfunc Header(code string) (string, bool)\ntype Result struct{ ... }\n    func Tree(dir string) ([]Result, error)"
+  tags        = ["golang", "hcl", "upgrade"]
+  id          = "097ee5d3-52f4-44e1-964a-8dc0ba84ac27"
+}
@@ -0,0 +1,349 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package upgrade contains functions for rewriting deprecated Terramate
+// configuration into its up-to-date form.
+package upgrade
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/hcl/v2/hclwrite"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/fs"
+	"github.com/terramate-io/terramate/generate/genhcl"
+	"github.com/terramate-io/terramate/safeguard"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrHCLSyntax is the error kind for syntax errors.
+const ErrHCLSyntax errors.Kind = "HCL syntax error"
+
+// ErrReadFile is the error kind for any error related to reading the file content.
+const ErrReadFile errors.Kind = "failed to read file"
+
+// Result represents the result of an upgrade operation on a single file.
+type Result struct {
+	path     string
+	original string
+	upgraded string
+	changes  []string
+}
+
+// deprecatedSafeguards maps the deprecated terramate.config.<block>.<attr>
+// attributes to the terramate.config.disable_safeguards keyword replacing them.
+var deprecatedSafeguards = []struct {
+	block   string
+	attr    string
+	keyword safeguard.Keyword
+}{
+	{"git", "check_untracked", safeguard.GitUntracked},
+	{"git", "check_uncommitted", safeguard.GitUncommitted},
+	{"git", "check_remote", safeguard.GitOutOfSync},
+	{"run", "check_gen_code", safeguard.Outdated},
+}
+
+// Tree will upgrade all the files in the given tree starting at the given
+// dir. It will recursively navigate on sub directories.
+//
+// Terramate configuration files, including the files with any of the given
+// configSuffixes, have their deprecated syntax rewritten and
+// generated files using the deprecated header have it replaced by the
+// current one.
+//
+// Files that need no upgrade are ignored. If no file needs to be upgraded
+// this function returns an empty result.
+//
+// All files will be left untouched. To save the upgraded result on disk you
+// can use Result.Save for each Result.
+func Tree(dir string, configSuffixes ...string) ([]Result, error) {
+	return upgradeTree(dir, Config, true, configSuffixes)
+}
+
+// upgradeTree upgrades the Terramate files in the tree with the given config
+// function and, if headers is true, the headers of the generated files.
+func upgradeTree(dir string, config func(src, filename string) (string, []string, error), headers bool, configSuffixes []string) ([]Result, error) {
+	logger := log.With().
+		Str("action", "upgrade.Tree").
+		Str("dir", dir).
+		Logger()
+
+	res, err := fs.ListTerramateFiles(dir, configSuffixes...)
+	if err != nil {
+		return nil, errors.E(errUpgradeTree, err)
+	}
+	for _, fname := range res.Skipped {
+		if fname == ".tmskip" {
+			logger.Debug().Msg("skip file found: skipping whole subtree")
+			return nil, nil
+		}
+	}
+
+	tmfiles := []string{}
+	for _, fname := range res.TmFiles {
+		// only the native syntax is upgraded.
+		if !fs.IsTerramateJSONFile(fname) {
+			tmfiles = append(tmfiles, fname)
+		}
+	}
+	sort.Strings(tmfiles)
+
+	errs := errors.L()
+	results := []Result{}
+
+	for _, fname := range tmfiles {
+		path := filepath.Join(dir, fname)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs.Append(errors.E(ErrReadFile, err))
+			continue
+		}
+		original := string(content)
+		upgraded, changes, err := config(original, path)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		results = append(results, Result{
+			path:     path,
+			original: original,
+			upgraded: upgraded,
+			changes:  changes,
+		})
+	}
+
+	otherFiles := res.OtherFiles
+	if !headers {
+		otherFiles = nil
+	}
+	for _, fname := range otherFiles {
+		path := filepath.Join(dir, fname)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs.Append(errors.E(ErrReadFile, err))
+			continue
+		}
+		original := string(content)
+		upgraded, changed := Header(original)
+		if !changed {
+			continue
+		}
+		results = append(results, Result{
+			path:     path,
+			original: original,
+			upgraded: upgraded,
+			changes:  []string{"replaced deprecated generated code header"},
+		})
+	}
+
+	for _, d := range res.Dirs {
+		subres, err := upgradeTree(filepath.Join(dir, d), config, headers, configSuffixes)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		results = append(results, subres...)
+	}
+
+	if err := errs.AsError(); err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].path < results[j].path
+	})
+	return results, nil
+}
+
+// Config will upgrade the given Terramate configuration source code.
+// It returns the upgraded code and a description of each applied change.
+// If no change is needed the returned code is the same as the given one.
+//
+// It returns an error if the given source is invalid HCL.
+func Config(src, filename string) (string, []string, error) {
+	parsed, diags := hclwrite.ParseConfig([]byte(src), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", nil, errors.E(ErrHCLSyntax, diags)
+	}
+
+	var changes []string
+	changes = append(changes, upgradeSafeguards(parsed.Body())...)
+	changes = append(changes, upgradeFuncNames(parsed.Body())...)
+
+	if len(changes) == 0 {
+		return src, nil, nil
+	}
+	return string(hclwrite.Format(parsed.Bytes())), changes, nil
+}
+
+// Header replaces the deprecated header of generated code by the current one.
+// It returns the upgraded code and true if the header was replaced.
+func Header(code string) (string, bool) {
+	if !strings.HasPrefix(code, genhcl.HeaderV0) {
+		return code, false
+	}
+	return "// " + genhcl.HeaderMagic + strings.TrimPrefix(code, genhcl.HeaderV0), true
+}
+
+// Save will save the upgraded result on the original file, replacing
+// its original contents. The permissions of the file are preserved.
+func (r Result) Save() error {
+	st, err := os.Stat(r.path)
+	if err != nil {
+		return errors.E(ErrReadFile, err)
+	}
+	return os.WriteFile(r.path, []byte(r.upgraded), st.Mode().Perm())
+}
+
+// Path is the absolute path of the original file.
+func (r Result) Path() string {
+	return r.path
+}
+
+// Original is the contents of the original file.
+func (r Result) Original() string {
+	return r.original
+}
+
+// Upgraded is the contents of the original file after the upgrade.
+func (r Result) Upgraded() string {
+	return r.upgraded
+}
+
+// Changes is the description of each change applied to the file.
+func (r Result) Changes() []string {
+	return r.changes
+}
+
+func upgradeSafeguards(body *hclwrite.Body) []string {
+	var changes []string
+	for _, tmblock := range body.Blocks() {
+		if tmblock.Type() != "terramate" {
+			continue
+		}
+		for _, cfgblock := range tmblock.Body().Blocks() {
+			if cfgblock.Type() != "config" {
+				continue
+			}
+			changes = append(changes, upgradeConfigSafeguards(cfgblock.Body())...)
+		}
+	}
+	return changes
+}
+
+func upgradeConfigSafeguards(cfg *hclwrite.Body) []string {
+	if cfg.GetAttribute("disable_safeguards") != nil {
+		// mixing both is a config error which must be solved by the user.
+		return nil
+	}
+
+	var (
+		changes  []string
+		keywords []cty.Value
+	)
+	for _, deprecated := range deprecatedSafeguards {
+		for _, block := range cfg.Blocks() {
+			if block.Type() != deprecated.block {
+				continue
+			}
+			attr := block.Body().GetAttribute(deprecated.attr)
+			if attr == nil {
+				continue
+			}
+			switch boolLiteral(attr.Expr().BuildTokens(nil)) {
+			case "true":
+				// enabled is the default.
+			case "false":
+				keywords = append(keywords, cty.StringVal(string(deprecated.keyword)))
+			default:
+				continue
+			}
+			block.Body().RemoveAttribute(deprecated.attr)
+			changes = append(changes, "replaced deprecated terramate.config."+
+				deprecated.block+"."+deprecated.attr+" with terramate.config.disable_safeguards")
+
+			if len(block.Body().Attributes()) == 0 && len(block.Body().Blocks()) == 0 {
+				cfg.RemoveBlock(block)
+			}
+		}
+	}
+	if len(keywords) > 0 {
+		cfg.SetAttributeValue("disable_safeguards", cty.ListVal(keywords))
+	}
+	return changes
+}
+
+// upgradeFuncNames renames the function calls missing the tm_ prefix on
+// blocks fully evaluated by Terramate.
+func upgradeFuncNames(body *hclwrite.Body) []string {
+	var changes []string
+	var walk func(body *hclwrite.Body)
+	walk = func(body *hclwrite.Body) {
+		attrs := body.Attributes()
+		attrNames := make([]string, 0, len(attrs))
+		for attrName := range attrs {
+			attrNames = append(attrNames, attrName)
+		}
+		sort.Strings(attrNames)
+		for _, attrName := range attrNames {
+			attr := attrs[attrName]
+			tokens := attr.Expr().BuildTokens(nil)
+			for i, tok := range tokens {
+				if tok.Type != hclsyntax.TokenIdent || i+1 >= len(tokens) ||
+					tokens[i+1].Type != hclsyntax.TokenOParen {
+					continue
+				}
+				name := string(tok.Bytes)
+				if strings.HasPrefix(name, "tm_") {
+					continue
+				}
+				if !stdlib.IsFunction(stdlib.Name(name)) {
+					continue
+				}
+				tok.Bytes = []byte(stdlib.Name(name))
+				changes = append(changes, "renamed function "+name+" to "+stdlib.Name(name))
+			}
+		}
+		for _, block := range body.Blocks() {
+			walk(block.Body())
+		}
+	}
+
+	for _, block := range body.Blocks() {
+		switch block.Type() {
+		case "globals", "assert":
+			walk(block.Body())
+		}
+	}
+	return changes
+}
+
+func boolLiteral(tokens hclwrite.Tokens) string {
+	var lit string
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenNewline, hclsyntax.TokenComment:
+			continue
+		case hclsyntax.TokenIdent:
+			if lit != "" {
+				return ""
+			}
+			lit = string(bytes.TrimSpace(tok.Bytes))
+		default:
+			return ""
+		}
+	}
+	return lit
+}
+
+const errUpgradeTree errors.Kind = "upgrading tree"
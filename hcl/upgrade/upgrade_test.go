@@ -0,0 +1,376 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package upgrade_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/upgrade"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestUpgradeConfig(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name        string
+		input       string
+		wantChanges []string
+		contains    []string
+		notContains []string
+		wantErr     error
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "up-to-date config is untouched",
+			input: `terramate {
+  config {
+    disable_safeguards = ["git-untracked"]
+  }
+}
+
+globals {
+  a = tm_upper("a")
+}
+`,
+		},
+		{
+			name: "deprecated git safeguards",
+			input: `terramate {
+  config {
+    git {
+      default_branch    = "main"
+      check_untracked   = false
+      check_uncommitted = true
+      check_remote      = false
+    }
+  }
+}
+`,
+			wantChanges: []string{
+				"replaced deprecated terramate.config.git.check_untracked with terramate.config.disable_safeguards",
+				"replaced deprecated terramate.config.git.check_uncommitted with terramate.config.disable_safeguards",
+				"replaced deprecated terramate.config.git.check_remote with terramate.config.disable_safeguards",
+			},
+			contains: []string{
+				`default_branch = "main"`,
+				`disable_safeguards = ["git-untracked", "git-out-of-sync"]`,
+			},
+			notContains: []string{"check_untracked", "check_uncommitted", "check_remote"},
+		},
+		{
+			name: "deprecated run safeguard removes empty block",
+			input: `terramate {
+  config {
+    run {
+      check_gen_code = false
+    }
+  }
+}
+`,
+			wantChanges: []string{
+				"replaced deprecated terramate.config.run.check_gen_code with terramate.config.disable_safeguards",
+			},
+			contains: []string{
+				`disable_safeguards = ["outdated-code"]`,
+			},
+			notContains: []string{"run", "check_gen_code"},
+		},
+		{
+			name: "non literal deprecated safeguards are kept",
+			input: `terramate {
+  config {
+    git {
+      check_untracked = global.check
+    }
+  }
+}
+`,
+		},
+		{
+			name: "functions without prefix are renamed",
+			input: `globals {
+  a = upper("a")
+  b = "${lower("B")}"
+  c = tm_upper("c")
+  d = unknown("d")
+}
+`,
+			wantChanges: []string{
+				"renamed function upper to tm_upper",
+				"renamed function lower to tm_lower",
+			},
+			contains: []string{
+				`a = tm_upper("a")`,
+				`b = "${tm_lower("B")}"`,
+				`c = tm_upper("c")`,
+				`d = unknown("d")`,
+			},
+		},
+		{
+			name: "functions on generate_hcl are not renamed",
+			input: `generate_hcl "file.tf" {
+  content {
+    a = upper("a")
+  }
+}
+`,
+		},
+		{
+			name:    "invalid HCL",
+			input:   `globals {`,
+			wantErr: errors.E(upgrade.ErrHCLSyntax),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changes, err := upgrade.Config(tc.input, "upgrade.tm")
+			assert.IsError(t, err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantChanges, changes); diff != "" {
+				t.Fatalf("-(want) +(got):\n%s", diff)
+			}
+			if len(tc.wantChanges) == 0 {
+				assert.EqualStrings(t, tc.input, got)
+			}
+			for _, want := range tc.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("upgraded code does not contain %q:\n%s", want, got)
+				}
+			}
+			for _, notwant := range tc.notContains {
+				if strings.Contains(got, notwant) {
+					t.Errorf("upgraded code contains %q:\n%s", notwant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradeHeader(t *testing.T) {
+	t.Parallel()
+
+	got, changed := upgrade.Header("// GENERATED BY TERRAMATE: DO NOT EDIT\n\nresource \"a\" \"b\" {}\n")
+	assert.IsTrue(t, changed)
+	assert.EqualStrings(t,
+		"// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT\n\nresource \"a\" \"b\" {}\n", got)
+
+	code := "// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT\n"
+	got, changed = upgrade.Header(code)
+	assert.IsTrue(t, !changed)
+	assert.EqualStrings(t, code, got)
+}
+
+func TestUpgradeTree(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm:terramate {
+  config {
+    run {
+      check_gen_code = false
+    }
+  }
+}
+`,
+		`s:stack`,
+		`f:stack/globals.tm:globals {
+  a = upper("a")
+}
+`,
+		"f:stack/old.tf:// GENERATED BY TERRAMATE: DO NOT EDIT\n",
+		"f:stack/new.tf:// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT\n",
+	})
+
+	results, err := upgrade.Tree(s.RootDir())
+	assert.NoError(t, err)
+
+	var got []string
+	for _, res := range results {
+		got = append(got, strings.TrimPrefix(res.Path(), s.RootDir()))
+	}
+	want := []string{
+		"/stack/globals.tm",
+		"/stack/old.tf",
+		"/terramate.tm",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+
+	for _, res := range results {
+		assert.NoError(t, res.Save())
+	}
+
+	results, err = upgrade.Tree(s.RootDir())
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0, len(results), "tree must be up-to-date after saving")
+}
+
+func TestUpgradeResultDiff(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:globals.tm:globals {
+  a = 1
+  b = 2
+  c = 3
+  d = upper("d")
+  e = 5
+  f = 6
+  g = 7
+  h = 8
+  i = 9
+  j = 10
+  k = 11
+  l = lower("L")
+}
+`,
+	})
+
+	results, err := upgrade.Tree(s.RootDir())
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(results))
+
+	path := filepath.Join(s.RootDir(), "globals.tm")
+	want := `--- ` + path + `
++++ ` + path + `
+@@ -2,7 +2,7 @@
+   a = 1
+   b = 2
+   c = 3
+-  d = upper("d")
++  d = tm_upper("d")
+   e = 5
+   f = 6
+   g = 7
+@@ -10,5 +10,5 @@
+   i = 9
+   j = 10
+   k = 11
+-  l = lower("L")
++  l = tm_lower("L")
+ }
+`
+	if diff := cmp.Diff(want, results[0].Diff()); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
+
+func TestUpgradeSavePreservesPermissions(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:globals.tm:globals {
+  a = upper("a")
+}
+`,
+	})
+	path := filepath.Join(s.RootDir(), "globals.tm")
+	assert.NoError(t, os.Chmod(path, 0600))
+
+	results, err := upgrade.Tree(s.RootDir())
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(results))
+	assert.NoError(t, results[0].Save())
+
+	st, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.EqualInts(t, 0600, int(st.Mode().Perm()))
+}
+
+func TestUpgradeMetadata(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name        string
+		input       string
+		want        string
+		wantChanges []string
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "up-to-date metadata is untouched",
+			input: `globals {
+  name = terramate.stack.name
+  path = terramate.stack.path.absolute
+}
+`,
+		},
+		{
+			name: "deprecated metadata is rewritten preserving the source",
+			input: `# comment kept
+globals {
+  name   = terramate.name # inline comment
+  path   = "${terramate.path}/sub"
+  values = [terramate.description, tm_upper(terramate.name)]
+  other  = global.terramate.path
+}
+
+generate_hcl "file.tf" {
+  content {
+    path = terramate.path
+  }
+}
+`,
+			want: `# comment kept
+globals {
+  name   = terramate.stack.name # inline comment
+  path   = "${terramate.stack.path.absolute}/sub"
+  values = [terramate.stack.description, tm_upper(terramate.stack.name)]
+  other  = global.terramate.path
+}
+
+generate_hcl "file.tf" {
+  content {
+    path = terramate.stack.path.absolute
+  }
+}
+`,
+			wantChanges: []string{
+				"replaced deprecated terramate.name with terramate.stack.name",
+				"replaced deprecated terramate.path with terramate.stack.path.absolute",
+				"replaced deprecated terramate.description with terramate.stack.description",
+				"replaced deprecated terramate.name with terramate.stack.name",
+				"replaced deprecated terramate.path with terramate.stack.path.absolute",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changes, err := upgrade.Metadata(tc.input, "metadata.tm")
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.wantChanges, changes); diff != "" {
+				t.Fatalf("-(want) +(got):\n%s", diff)
+			}
+			want := tc.want
+			if want == "" {
+				want = tc.input
+			}
+			assert.EqualStrings(t, want, got)
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "http" {
-  content = <<-EOT
+  content = <<-EOF
 package http // import "github.com/terramate-io/terramate/http"
 
 const ErrConflict errors.Kind = "conflict (HTTP Status 409)"
@@ -16,7 +16,7 @@ func Put[T resources.Resource](ctx context.Context, client Client, payload inter
 func Request[T resources.Resource](ctx context.Context, c Client, method string, url url.URL, payload any) (res T, err error)
 type Client interface{ ... }
 type Credential interface{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-http.ignore"
 }
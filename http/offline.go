@@ -0,0 +1,50 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	stdhttp "net/http"
+	"sync/atomic"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+// ErrOffline indicates that a network access was attempted in offline mode.
+const ErrOffline errors.Kind = "network access disabled in offline mode"
+
+var offline atomic.Bool
+
+// SetOffline enables the offline mode, in which any network access is an
+// error of kind [ErrOffline].
+//
+// The offline mode replaces the default transport of the standard library,
+// so every HTTP client without a custom transport fails. The features
+// accessing the network by other means must check [CheckOnline].
+func SetOffline() {
+	offline.Store(true)
+	stdhttp.DefaultTransport = OfflineTransport{}
+}
+
+// IsOffline tells if the offline mode is enabled.
+func IsOffline() bool {
+	return offline.Load()
+}
+
+// CheckOnline returns an error of kind [ErrOffline] if the offline mode is
+// enabled. The action describes what requires the network access.
+func CheckOnline(action string) error {
+	if IsOffline() {
+		return errors.E(ErrOffline, "%s requires network access", action)
+	}
+	return nil
+}
+
+// OfflineTransport is a [stdhttp.RoundTripper] failing every request with
+// an error of kind [ErrOffline].
+type OfflineTransport struct{}
+
+// RoundTrip fails the request.
+func (OfflineTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	return nil, errors.E(ErrOffline, "%s %s", req.Method, req.URL.Redacted())
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "lets" {
-  content = <<-EOT
+  content = <<-EOF
 package lets // import "github.com/terramate-io/terramate/lets"
 
 Package lets provides parsing and evaluation of lets blocks.
@@ -12,7 +12,7 @@ type Expr struct{ ... }
 type Exprs map[string]Expr
 type Map map[string]Value
 type Value struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-lets.ignore"
 }
@@ -0,0 +1,54 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lets
+
+import (
+	"strings"
+
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrLabel indicates that a generate block label failed to interpolate
+// the lets.
+const ErrLabel errors.Kind = "invalid label template"
+
+// IsLabelTemplate tells if the generate block label interpolates values.
+func IsLabelTemplate(label string) bool {
+	return strings.Contains(label, "${") || strings.Contains(label, "%{")
+}
+
+// EvalLabel interpolates the lets referenced by the label of the generate
+// block defined at rng, eg.: "${let.region}.tfvars". The lets must be loaded
+// in ctx. Labels without interpolation are returned unchanged.
+func EvalLabel(label string, rng info.Range, ctx *eval.Context) (string, error) {
+	if !IsLabelTemplate(label) {
+		return label, nil
+	}
+	expr, diags := hclsyntax.ParseTemplate([]byte(label), rng.HostPath(), rng.ToHCLRange().Start)
+	if diags.HasErrors() {
+		return "", errors.E(ErrLabel, diags, rng, "parsing label %q", label)
+	}
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "let" {
+			return "", errors.E(ErrLabel, rng,
+				"label %q references %s but only let.* values can be interpolated",
+				label, traversal.RootName())
+		}
+	}
+	val, err := ctx.Eval(expr)
+	if err != nil {
+		return "", errors.E(ErrLabel, err, rng, "evaluating label %q", label)
+	}
+	if val.IsNull() || !val.Type().Equals(cty.String) {
+		return "", errors.E(ErrLabel, rng, "label %q must evaluate to a string", label)
+	}
+	if val.AsString() == "" {
+		return "", errors.E(ErrLabel, rng, "label %q evaluates to an empty string", label)
+	}
+	return val.AsString(), nil
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "ls" {
-  content = <<-EOT
+  content = <<-EOF
 package tmls // import "github.com/terramate-io/terramate/ls"
 
 Package tmls implements a Terramate Language Server (LSP).
@@ -11,7 +11,7 @@ const MethodExecuteCommand = "workspace/executeCommand"
 type Server struct{ ... }
     func NewServer(conn jsonrpc2.Conn) *Server
     func ServerWithLogger(conn jsonrpc2.Conn, l zerolog.Logger) *Server
-EOT
+EOF
 
   filename = "${path.module}/mock-ls.ignore"
 }
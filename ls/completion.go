@@ -0,0 +1,285 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package tmls
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/zclconf/go-cty/cty/function"
+	"go.lsp.dev/jsonrpc2"
+	lsp "go.lsp.dev/protocol"
+)
+
+func (s *Server) handleCompletion(
+	ctx context.Context,
+	reply jsonrpc2.Replier,
+	r jsonrpc2.Request,
+	log zerolog.Logger,
+) error {
+	var params lsp.CompletionParams
+	if err := json.Unmarshal(r.Params(), &params); err != nil {
+		log.Error().Err(err).Msg("failed to unmarshal params")
+		return jsonrpc2.ErrParse
+	}
+	log.Debug().Str("params", string(r.Params()))
+
+	fname := params.TextDocument.URI.Filename()
+	content, ok := s.document(fname)
+	if !ok {
+		return reply(ctx, nil, nil)
+	}
+	prefix, ok := functionPrefix(content, params.Position)
+	if !ok {
+		return reply(ctx, nil, nil)
+	}
+
+	dir := filepath.Dir(fname)
+	if !isAbsDir(dir) {
+		dir = s.workspace
+		if !isAbsDir(dir) {
+			return reply(ctx, nil, nil)
+		}
+	}
+
+	items := []lsp.CompletionItem{}
+	for _, item := range s.functionCompletions(dir) {
+		if strings.HasPrefix(item.Label, prefix) {
+			items = append(items, item)
+		}
+	}
+	return reply(ctx, lsp.CompletionList{Items: items}, nil)
+}
+
+// document returns the content of the open document or, if it's not open,
+// of the file.
+func (s *Server) document(fname string) (string, bool) {
+	s.mu.Lock()
+	content, ok := s.documents[fname]
+	s.mu.Unlock()
+	if ok {
+		return content, true
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// functionCompletions returns the completions of the functions available to
+// the configuration of the directory. The experimental functions are only
+// completed if their experiment is enabled.
+// The completions are cached until a document is saved.
+func (s *Server) functionCompletions(dir string) []lsp.CompletionItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if items, ok := s.completions[dir]; ok {
+		return items
+	}
+
+	var funcs map[string]function.Function
+	hasExperiment := func(string) bool { return false }
+	if root, ok := s.loadRoot(dir); ok {
+		funcs = root.Functions(dir)
+		hasExperiment = root.HasExperiment
+	} else {
+		funcs = s.functions.Functions(dir, nil)
+	}
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		if experiment, ok := stdlib.FunctionExperiment(name); ok && !hasExperiment(experiment) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]lsp.CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, lsp.CompletionItem{
+			Label:         name,
+			Kind:          lsp.CompletionItemKindFunction,
+			Detail:        stdlib.Signature(name, funcs[name]),
+			Documentation: funcs[name].Description(),
+		})
+	}
+
+	s.completions[dir] = items
+	return items
+}
+
+// loadRoot returns the configuration of the project of the directory, loading
+// it if it's not the project of the last completions. The s.mu lock must be
+// held.
+func (s *Server) loadRoot(dir string) (*config.Root, bool) {
+	if s.root != nil && isInsideDir(dir, s.root.HostDir()) {
+		return s.root, true
+	}
+	root, _, found, err := config.TryLoadConfig(dir, false, hcl.WithFunctions(s.functions))
+	if !found || err != nil {
+		return nil, false
+	}
+	s.root = root
+	return root, true
+}
+
+// reloadConfig reloads the configuration changed by the saved file and drops
+// the cached completions, as the configuration may enable other experiments.
+func (s *Server) reloadConfig(fname string, log zerolog.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.completions = map[string][]lsp.CompletionItem{}
+	if s.root == nil || !isInsideDir(fname, s.root.HostDir()) {
+		return
+	}
+	changed := project.PrjAbsPath(s.root.HostDir(), fname)
+	if _, err := s.root.Reload(project.Paths{changed}); err != nil {
+		// the previous configuration is kept by the failed reload.
+		log.Debug().Err(err).Msg("failed to reload the configuration")
+	}
+}
+
+// functionPrefix returns the partial function name before the position and
+// tells if a function call can be written at the position, which is the case
+// in expressions but not in attribute names, block headers, strings,
+// comments and traversals.
+func functionPrefix(content string, pos lsp.Position) (string, bool) {
+	offset, ok := byteOffset(content, pos)
+	if !ok {
+		return "", false
+	}
+	start := offset
+	for start > 0 && isIdentByte(content[start-1]) {
+		start--
+	}
+	prefix := content[start:offset]
+	if prefix != "" && !isIdentStart(prefix[0]) {
+		return "", false
+	}
+	tokens, _ := hclsyntax.LexConfig([]byte(content[:start]), "", hhcl.InitialPos)
+	return prefix, isExprContext(tokens)
+}
+
+// isExprContext tells if the end of the tokens is in an expression.
+func isExprContext(tokens hclsyntax.Tokens) bool {
+	type opener struct {
+		typ    hclsyntax.TokenType
+		inExpr bool
+	}
+	var (
+		openers []opener
+		inExpr  bool
+		last    hclsyntax.Token
+	)
+	inBody := func() bool {
+		return len(openers) == 0 || openers[len(openers)-1].typ == hclsyntax.TokenOBrace
+	}
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenEOF:
+			continue
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen,
+			hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			openers = append(openers, opener{typ: tok.Type, inExpr: inExpr})
+			inExpr = false
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen,
+			hclsyntax.TokenCQuote, hclsyntax.TokenCHeredoc, hclsyntax.TokenTemplateSeqEnd:
+			if len(openers) > 0 {
+				inExpr = openers[len(openers)-1].inExpr
+				openers = openers[:len(openers)-1]
+			}
+		case hclsyntax.TokenEqual, hclsyntax.TokenColon:
+			inExpr = true
+		case hclsyntax.TokenNewline:
+			if inBody() {
+				inExpr = false
+			}
+		case hclsyntax.TokenComment:
+			if strings.HasSuffix(string(tok.Bytes), "\n") && inBody() {
+				inExpr = false
+			}
+		}
+		last = tok
+	}
+
+	switch {
+	case last.Type == hclsyntax.TokenDot:
+		return false
+	case last.Type == hclsyntax.TokenComment &&
+		!strings.HasSuffix(string(last.Bytes), "\n") &&
+		!strings.HasSuffix(string(last.Bytes), "*/"):
+		// the comment reaches the position.
+		return false
+	}
+	if len(openers) == 0 {
+		return inExpr
+	}
+	switch openers[len(openers)-1].typ {
+	case hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+		return false
+	case hclsyntax.TokenOBrace:
+		return inExpr
+	default:
+		return true
+	}
+}
+
+// byteOffset converts the position, in UTF-16 code units as defined by
+// the LSP, to a byte offset of the content.
+func byteOffset(content string, pos lsp.Position) (int, bool) {
+	offset := 0
+	for line := uint32(0); line < pos.Line; line++ {
+		i := strings.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return 0, false
+		}
+		offset += i + 1
+	}
+	for units := uint32(0); units < pos.Character; {
+		if offset >= len(content) || content[offset] == '\n' {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(content[offset:])
+		units += uint32(utf16.RuneLen(r))
+		offset += size
+	}
+	return offset, true
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func isInsideDir(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+func isAbsDir(dir string) bool {
+	if !filepath.IsAbs(dir) {
+		return false
+	}
+	st, err := os.Stat(dir)
+	return err == nil && st.IsDir()
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package tmls_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	tmls "github.com/terramate-io/terramate/ls"
+	"github.com/terramate-io/terramate/stdlib"
+	lstest "github.com/terramate-io/terramate/test/ls"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	lsp "go.lsp.dev/protocol"
+)
+
+func TestCompletionContext(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name string
+		// code has the cursor marked with |
+		code string
+		want bool
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "attribute value",
+			code: "globals {\n  a = tm_up|\n}\n",
+			want: true,
+		},
+		{
+			name: "empty attribute value",
+			code: "globals {\n  a = |\n}\n",
+			want: true,
+		},
+		{
+			name: "function argument",
+			code: "globals {\n  a = tm_lower(tm_up|)\n}\n",
+			want: true,
+		},
+		{
+			name: "multi-line list element",
+			code: "globals {\n  a = [\n    tm_up|\n  ]\n}\n",
+			want: true,
+		},
+		{
+			name: "object attribute value",
+			code: "globals {\n  a = {\n    b = tm_up|\n  }\n}\n",
+			want: true,
+		},
+		{
+			name: "string interpolation",
+			code: "globals {\n  a = \"x-${tm_up|}\"\n}\n",
+			want: true,
+		},
+		{
+			name: "attribute after a complete attribute",
+			code: "globals {\n  a = tm_upper(\"a\")\n  tm|\n}\n",
+		},
+		{
+			name: "attribute name",
+			code: "globals {\n  tm_up|\n}\n",
+		},
+		{
+			name: "object key",
+			code: "globals {\n  a = {\n    tm_up|\n  }\n}\n",
+		},
+		{
+			name: "top level",
+			code: "tm_up|\n",
+		},
+		{
+			name: "string literal",
+			code: "globals {\n  a = \"tm_up|\"\n}\n",
+		},
+		{
+			name: "heredoc",
+			code: "globals {\n  a = <<-EOT\n    tm_up|\n  EOT\n}\n",
+		},
+		{
+			name: "comment",
+			code: "globals {\n  a = 1 # tm_up|\n}\n",
+		},
+		{
+			name: "traversal",
+			code: "globals {\n  a = global.tm_up|\n}\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := lstest.Setup(t)
+			line, char := writeWithCursor(t, f, "test.tm", tc.code)
+			f.Editor.CheckInitialize(f.Sandbox.RootDir())
+
+			got := f.Editor.Completion("test.tm", line, char)
+			if !tc.want {
+				if got != nil {
+					t.Fatalf("want no completions but got %d", len(got.Items))
+				}
+				return
+			}
+			assert.IsTrue(t, got != nil, "want completions")
+			prefix := completionPrefix(tc.code)
+			assert.IsTrue(t, hasLabel(got, "tm_upper"), "tm_upper must be completed")
+			for _, item := range got.Items {
+				assert.IsTrue(t, strings.HasPrefix(item.Label, prefix),
+					"completion %s has no %q prefix", item.Label, prefix)
+				assert.EqualInts(t, int(lsp.CompletionItemKindFunction), int(item.Kind))
+			}
+		})
+	}
+}
+
+func TestCompletionExperimentalFunctions(t *testing.T) {
+	t.Parallel()
+
+	const code = "globals {\n  a = tm_toml|\n}\n"
+
+	f := lstest.Setup(t)
+	line, char := writeWithCursor(t, f, "test.tm", code)
+	f.Editor.CheckInitialize(f.Sandbox.RootDir())
+
+	got := f.Editor.Completion("test.tm", line, char)
+	assert.IsTrue(t, got != nil, "want completions")
+	assert.EqualInts(t, 0, len(got.Items), "experiment not enabled")
+
+	// the saved configuration is reloaded.
+	f.Sandbox.RootEntry().CreateFile("experiments.tm", fmt.Sprintf(`terramate {
+  config {
+    experiments = [%q]
+  }
+}
+`, stdlib.TomlExperimentName))
+	f.Editor.Save("experiments.tm")
+	// diagnostics of experiments.tm, root.config.tm and test.tm.
+	for i := 0; i < 3; i++ {
+		r := <-f.Editor.Requests
+		assert.EqualStrings(t, lsp.MethodTextDocumentPublishDiagnostics, r.Method())
+	}
+
+	got = f.Editor.Completion("test.tm", line, char)
+	assert.IsTrue(t, got != nil, "want completions")
+	assert.IsTrue(t, hasLabel(got, "tm_tomlencode"), "tm_tomlencode must be completed")
+	assert.IsTrue(t, hasLabel(got, "tm_tomldecode"), "tm_tomldecode must be completed")
+}
+
+func TestCompletionCustomFunctions(t *testing.T) {
+	t.Parallel()
+
+	registry := stdlib.NewRegistry()
+	assert.NoError(t, registry.Register("tm_test_greet", function.New(&function.Spec{
+		Description: "Greets someone.",
+		Params: []function.Parameter{
+			{
+				Name: "name",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal("hello " + args[0].AsString()), nil
+		},
+	})))
+
+	f := lstest.SetupWithOptions(t, []tmls.Option{tmls.WithFunctions(registry)})
+	line, char := writeWithCursor(t, f, "test.tm", "globals {\n  a = tm_test_|\n}\n")
+	f.Editor.CheckInitialize(f.Sandbox.RootDir())
+
+	got := f.Editor.Completion("test.tm", line, char)
+	assert.IsTrue(t, got != nil, "want completions")
+	assert.EqualInts(t, 1, len(got.Items))
+	assert.EqualStrings(t, "tm_test_greet", got.Items[0].Label)
+	assert.EqualStrings(t, "tm_test_greet(name string) string", got.Items[0].Detail)
+	assert.EqualStrings(t, "Greets someone.", fmt.Sprint(got.Items[0].Documentation))
+}
+
+// writeWithCursor creates the file with the code without the cursor marker
+// and returns the position of the cursor.
+func writeWithCursor(t *testing.T, f lstest.Fixture, name, code string) (uint32, uint32) {
+	t.Helper()
+
+	before, after, ok := strings.Cut(code, "|")
+	assert.IsTrue(t, ok, "code has no cursor")
+	f.Sandbox.RootEntry().CreateFile(name, before+after)
+
+	lines := strings.Split(before, "\n")
+	return uint32(len(lines) - 1), uint32(len(lines[len(lines)-1]))
+}
+
+func completionPrefix(code string) string {
+	before, _, _ := strings.Cut(code, "|")
+	end := len(before)
+	start := end
+	for start > 0 && (before[start-1] == '_' ||
+		(before[start-1] >= 'a' && before[start-1] <= 'z')) {
+		start--
+	}
+	return before[start:end]
+}
+
+func hasLabel(list *lsp.CompletionList, label string) bool {
+	for _, item := range list.Items {
+		if item.Label == label {
+			return true
+		}
+	}
+	return false
+}
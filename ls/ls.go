@@ -12,12 +12,16 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/fs"
 	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/warnings"
 	"go.lsp.dev/jsonrpc2"
 	lsp "go.lsp.dev/protocol"
 	"go.lsp.dev/uri"
@@ -31,10 +35,33 @@ type Server struct {
 	conn      jsonrpc2.Conn
 	workspace string
 	handlers  handlers
+	functions *stdlib.Registry
+
+	// mu guards the fields below, including the uses of root, which is
+	// reloaded in place.
+	mu sync.Mutex
+	// documents are the contents of the open documents by filename.
+	documents map[string]string
+	// root is the configuration of the last project with completions. It's
+	// loaded once and incrementally reloaded when its files are saved.
+	root *config.Root
+	// completions are the cached function completions by directory.
+	completions map[string][]lsp.CompletionItem
 
 	log zerolog.Logger
 }
 
+// Option is a language server option.
+type Option func(s *Server)
+
+// WithFunctions is an option to make the custom functions of the registry
+// available to the configuration checked and completed by the server.
+func WithFunctions(registry *stdlib.Registry) Option {
+	return func(s *Server) {
+		s.functions = registry
+	}
+}
+
 // handler is a jsonrpc2.Handler with a custom logger.
 type handler = func(
 	ctx context.Context,
@@ -46,15 +73,20 @@ type handler = func(
 type handlers map[string]handler
 
 // NewServer creates a new language server.
-func NewServer(conn jsonrpc2.Conn) *Server {
-	return ServerWithLogger(conn, log.Logger)
+func NewServer(conn jsonrpc2.Conn, opts ...Option) *Server {
+	return ServerWithLogger(conn, log.Logger, opts...)
 }
 
 // ServerWithLogger creates a new language server with a custom logger.
-func ServerWithLogger(conn jsonrpc2.Conn, l zerolog.Logger) *Server {
+func ServerWithLogger(conn jsonrpc2.Conn, l zerolog.Logger, opts ...Option) *Server {
 	s := &Server{
-		conn: conn,
-		log:  l,
+		conn:        conn,
+		log:         l,
+		documents:   map[string]string{},
+		completions: map[string][]lsp.CompletionItem{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
 	s.buildHandlers()
 	return s
@@ -67,6 +99,7 @@ func (s *Server) buildHandlers() {
 		lsp.MethodTextDocumentDidOpen:    s.handleDocumentOpen,
 		lsp.MethodTextDocumentDidChange:  s.handleDocumentChange,
 		lsp.MethodTextDocumentDidSave:    s.handleDocumentSaved,
+		lsp.MethodTextDocumentDidClose:   s.handleDocumentClose,
 		lsp.MethodTextDocumentCompletion: s.handleCompletion,
 
 		// commands
@@ -86,6 +119,11 @@ func (s *Server) Handler(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		RawJSON("params", r.Params()).
 		Msg("handling request.")
 
+	// the warnings emitted while loading the configuration are not reported
+	// by the server, so they're discarded per request instead of growing for
+	// the lifetime of the server.
+	warnings.Reset()
+
 	if handler, ok := s.handlers[r.Method()]; ok {
 		return handler(ctx, reply, r, logger)
 	}
@@ -180,6 +218,7 @@ func (s *Server) handleDocumentOpen(
 
 	fname := params.TextDocument.URI.Filename()
 	content := params.TextDocument.Text
+	s.setDocument(fname, content)
 
 	return s.checkAndReply(ctx, reply, fname, content)
 }
@@ -204,6 +243,7 @@ func (s *Server) handleDocumentChange(
 
 	content := params.ContentChanges[0].Text
 	fname := params.TextDocument.URI.Filename()
+	s.setDocument(fname, content)
 
 	return s.checkAndReply(ctx, reply, fname, content)
 }
@@ -227,9 +267,35 @@ func (s *Server) handleDocumentSaved(
 		return nil
 	}
 
+	s.reloadConfig(fname, log)
+
 	return s.checkAndReply(ctx, reply, fname, string(content))
 }
 
+func (s *Server) handleDocumentClose(
+	ctx context.Context,
+	reply jsonrpc2.Replier,
+	r jsonrpc2.Request,
+	log zerolog.Logger,
+) error {
+	var params lsp.DidCloseTextDocumentParams
+	if err := json.Unmarshal(r.Params(), &params); err != nil {
+		log.Error().Err(err).Msg("failed to unmarshal params")
+		return jsonrpc2.ErrParse
+	}
+
+	s.mu.Lock()
+	delete(s.documents, params.TextDocument.URI.Filename())
+	s.mu.Unlock()
+	return reply(ctx, nil, nil)
+}
+
+func (s *Server) setDocument(fname, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.documents[fname] = content
+}
+
 // sendErrorDiagnostics sends diagnostics for each provided file, the ones with
 // no reported error gets an empty list of diagnostics, so the editor can clean
 // up its problems panel for it.
@@ -285,21 +351,6 @@ func (s *Server) sendErrorDiagnostics(ctx context.Context, files []string, err e
 	return nil
 }
 
-func (s *Server) handleCompletion(
-	ctx context.Context,
-	reply jsonrpc2.Replier,
-	r jsonrpc2.Request,
-	log zerolog.Logger,
-) error {
-	var params lsp.CompletionParams
-	if err := json.Unmarshal(r.Params(), &params); err != nil {
-		log.Error().Err(err).Msg("failed to unmarshal params")
-		return jsonrpc2.ErrParse
-	}
-	log.Debug().Str("params", string(r.Params()))
-	return reply(ctx, nil, nil)
-}
-
 func (s *Server) sendDiagnostics(ctx context.Context, uri lsp.URI, diags []lsp.Diagnostic) {
 	err := s.conn.Notify(ctx, lsp.MethodTextDocumentPublishDiagnostics, lsp.PublishDiagnosticsParams{
 		URI:         uri,
@@ -343,7 +394,7 @@ func listFiles(fromFile string) ([]string, error) {
 		}
 
 		filename := dirEntry.Name()
-		if strings.HasSuffix(filename, ".tm") || strings.HasSuffix(filename, ".tm.hcl") {
+		if strings.HasSuffix(filename, ".tm") || strings.HasSuffix(filename, ".tm.hcl") || fs.IsTerramateJSONFile(filename) {
 			path := filepath.Join(dir, filename)
 
 			if path == fromFile {
@@ -363,14 +414,15 @@ func listFiles(fromFile string) ([]string, error) {
 func (s *Server) checkFiles(files []string, currentFile string, currentContent string) error {
 	dir := filepath.Dir(currentFile)
 	var experiments []string
-	root, rootdir, found, err := config.TryLoadConfig(dir, false)
+	root, rootdir, found, err := config.TryLoadConfig(dir, false, hcl.WithFunctions(s.functions))
 	if !found {
 		rootdir = s.workspace
 	} else if err == nil {
 		experiments = root.Tree().Node.Experiments()
 	}
 
-	parser, err := hcl.NewTerramateParser(rootdir, dir, hcl.WithExperiments(experiments...))
+	parser, err := hcl.NewTerramateParser(rootdir, dir,
+		hcl.WithExperiments(experiments...), hcl.WithFunctions(s.functions))
 	if err != nil {
 		return errors.E(err, "failed to create terramate parser")
 	}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "mapexpr" {
-  content = <<-EOT
+  content = <<-EOF
 package mapexpr // import "github.com/terramate-io/terramate/mapexpr"
 
 Package mapexpr implements the `map` block as an HCL expression type.
@@ -9,7 +9,7 @@ Package mapexpr implements the `map` block as an HCL expression type.
 type Attributes struct{ ... }
 type MapExpr struct{ ... }
     func NewMapExpr(block *ast.MergedBlock) (*MapExpr, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-mapexpr.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "download" {
-  content = <<-EOT
+  content = <<-EOF
 package download // import "github.com/terramate-io/terramate/modvendor/download"
 
 Package download is responsible for downloading vendored modules.
@@ -17,7 +17,7 @@ type Report struct{ ... }
     func Vendor(rootdir string, vendorDir project.Path, modsrc tf.Source, ...) Report
     func VendorAll(rootdir string, vendorDir project.Path, tfdir string, ...) Report
 type Vendored struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-download.ignore"
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/terramate-io/terramate/event"
 	"github.com/terramate-io/terramate/fs"
 	"github.com/terramate-io/terramate/git"
+	"github.com/terramate-io/terramate/http"
 	"github.com/terramate-io/terramate/modvendor"
 	"github.com/terramate-io/terramate/modvendor/manifest"
 	"github.com/terramate-io/terramate/project"
@@ -39,6 +40,14 @@ const (
 
 	// ErrModRefEmpty indicates that a module source had no reference on it.
 	ErrModRefEmpty errors.Kind = "module ref is empty"
+
+	// ErrChecksumMismatch indicates that the checksum of a downloaded file
+	// doesn't match the checksum pinned in its source.
+	ErrChecksumMismatch errors.Kind = "checksum mismatch"
+
+	// ErrArchiveTooLarge indicates that the files extracted from a downloaded
+	// archive exceed the maximum extracted size.
+	ErrArchiveTooLarge errors.Kind = "archive too large"
 )
 
 type modinfo struct {
@@ -347,15 +356,21 @@ func downloadVendor(
 		return "", errors.E(ErrAlreadyVendored, "dir %q exists", modVendorDir)
 	}
 
+	if !strings.HasPrefix(modsrc.URL, "file://") {
+		if err := http.CheckOnline(fmt.Sprintf("downloading module %s", modsrc.Raw)); err != nil {
+			return "", err
+		}
+	}
+
 	// We want an initial temporary dir outside of the Terramate project
 	// to do the clone since some git setups will assume that any
 	// git clone inside a repo is a submodule.
-	clonedRepoDir, err := os.MkdirTemp("", ".tmvendor")
+	downloadDir, err := os.MkdirTemp("", ".tmvendor")
 	if err != nil {
 		return "", errors.E(err, "creating tmp clone dir")
 	}
 	defer func() {
-		if err := os.RemoveAll(clonedRepoDir); err != nil {
+		if err := os.RemoveAll(downloadDir); err != nil {
 			log.Warn().Err(err).
 				Msg("deleting tmp clone dir")
 		}
@@ -377,19 +392,6 @@ func downloadVendor(
 		}
 	}()
 
-	// Same strategy used on the Go toolchain:
-	// - https://github.com/golang/go/blob/2ebe77a2fda1ee9ff6fd9a3e08933ad1ebaea039/src/cmd/go/internal/get/get.go#L129
-
-	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
-	g, err := git.WithConfig(git.Config{
-		WorkingDir:     clonedRepoDir,
-		AllowPorcelain: true,
-		Env:            env,
-	})
-	if err != nil {
-		return "", err
-	}
-
 	event := event.VendorProgress{
 		Message:   "downloading",
 		TargetDir: modvendor.TargetDir(vendorDir, modsrc),
@@ -403,21 +405,16 @@ func downloadVendor(
 			Msg("dropped progress event, event handler is not fast enough or absent")
 	}
 
-	if err := g.Clone(modsrc.URL, clonedRepoDir); err != nil {
-		return "", err
-	}
-
-	const create = false
-
-	if err := g.Checkout(modsrc.Ref, create); err != nil {
-		return "", errors.E(err, "checking ref %s", modsrc.Ref)
+	if modsrc.IsHTTP() {
+		err = downloadHTTP(downloadDir, modsrc)
+	} else {
+		err = cloneGit(downloadDir, modsrc)
 	}
-
-	if err := os.RemoveAll(filepath.Join(clonedRepoDir, ".git")); err != nil {
-		return "", errors.E(err, "removing .git dir from cloned repo")
+	if err != nil {
+		return "", err
 	}
 
-	matcher, err := manifest.LoadFileMatcher(clonedRepoDir)
+	matcher, err := manifest.LoadFileMatcher(downloadDir)
 	if err != nil {
 		return "", err
 	}
@@ -429,11 +426,11 @@ func downloadVendor(
 			return true
 		}
 		abspath := filepath.Join(path, entry.Name())
-		relpath := strings.TrimPrefix(abspath, clonedRepoDir+pathSeparator)
+		relpath := strings.TrimPrefix(abspath, downloadDir+pathSeparator)
 		return matcher.Match(strings.Split(relpath, pathSeparator), entry.IsDir())
 	}
 
-	if err := fs.CopyDir(tmTempDir, clonedRepoDir, fileFilter); err != nil {
+	if err := fs.CopyDir(tmTempDir, downloadDir, fileFilter); err != nil {
 		return "", errors.E(err, "copying cloned module")
 	}
 
@@ -449,6 +446,37 @@ func downloadVendor(
 	return modVendorDir, nil
 }
 
+// cloneGit clones the Git source into dir, checking out the source ref.
+func cloneGit(dir string, modsrc tf.Source) error {
+	// Same strategy used on the Go toolchain:
+	// - https://github.com/golang/go/blob/2ebe77a2fda1ee9ff6fd9a3e08933ad1ebaea039/src/cmd/go/internal/get/get.go#L129
+
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	g, err := git.WithConfig(git.Config{
+		WorkingDir:     dir,
+		AllowPorcelain: true,
+		Env:            env,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := g.Clone(modsrc.URL, dir); err != nil {
+		return err
+	}
+
+	const create = false
+
+	if err := g.Checkout(modsrc.Ref, create); err != nil {
+		return errors.E(err, "checking ref %s", modsrc.Ref)
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return errors.E(err, "removing .git dir from cloned repo")
+	}
+	return nil
+}
+
 func patchFiles(rootdir string, files []string, sources *sourcesInfo) error {
 	errs := errors.L()
 	for _, fname := range files {
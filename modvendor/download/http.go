@@ -0,0 +1,255 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package download
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/tf"
+)
+
+const (
+	// httpTimeout is the maximum time downloading a file, including reading
+	// its contents.
+	httpTimeout = 10 * time.Minute
+
+	// maxExtractedSize is the maximum size of the files extracted from an
+	// archive, protecting from archives decompressing to huge files.
+	maxExtractedSize = 1 << 30
+)
+
+var httpClient = &http.Client{Timeout: httpTimeout}
+
+// downloadHTTP downloads the file of the HTTP source into dir, verifying its
+// checksum. Archives are extracted into dir, other files are written in dir
+// with the name of the source file.
+func downloadHTTP(dir string, modsrc tf.Source) error {
+	tmpfile, err := os.CreateTemp("", ".tmvendor-download")
+	if err != nil {
+		return errors.E(err, "creating tmp download file")
+	}
+	tmpname := tmpfile.Name()
+	defer func() {
+		if tmpname == "" {
+			// renamed into dir.
+			return
+		}
+		if err := os.Remove(tmpname); err != nil {
+			log.Warn().Err(err).Msg("deleting tmp download file")
+		}
+	}()
+
+	err = fetch(tmpfile, modsrc)
+	closeErr := tmpfile.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return errors.E(closeErr, "closing tmp download file")
+	}
+
+	switch modsrc.Archive {
+	case "":
+		// os.CreateTemp creates the file readable only by its owner.
+		if err := os.Chmod(tmpname, 0644); err != nil {
+			return errors.E(err, "setting the mode of the downloaded file")
+		}
+		if err := os.Rename(tmpname, filepath.Join(dir, modsrc.Filename())); err != nil {
+			return errors.E(err, "moving the downloaded file")
+		}
+		tmpname = ""
+		return nil
+	case tf.ArchiveZip:
+		return extractZip(dir, tmpname)
+	default:
+		return extractTar(dir, tmpname, modsrc.Archive == tf.ArchiveTarGz)
+	}
+}
+
+// fetch writes the contents of the source URL into w, failing if the
+// checksum of the contents doesn't match the source checksum.
+func fetch(w io.Writer, modsrc tf.Source) error {
+	ctype, want, _ := strings.Cut(modsrc.Checksum, ":")
+	var h hash.Hash
+	switch ctype {
+	case tf.ChecksumSHA256:
+		h = sha256.New()
+	case tf.ChecksumSHA512:
+		h = sha512.New()
+	default:
+		return errors.E(tf.ErrInvalidModSrc, "unsupported checksum type %q", ctype)
+	}
+
+	resp, err := httpClient.Get(modsrc.URL)
+	if err != nil {
+		return errors.E(err, "downloading %s", modsrc.URL)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Debug().Err(err).Msg("closing response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.E("downloading %s: unexpected status %s", modsrc.URL, resp.Status)
+	}
+	if _, err := io.Copy(io.MultiWriter(w, h), resp.Body); err != nil {
+		return errors.E(err, "downloading %s", modsrc.URL)
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != want {
+		return errors.E(ErrChecksumMismatch,
+			"file %s has checksum %s:%s but %s is pinned", modsrc.URL, ctype, got, modsrc.Checksum)
+	}
+	return nil
+}
+
+func extractZip(dir, archive string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return errors.E(err, "opening zip archive")
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.Debug().Err(err).Msg("closing zip archive")
+		}
+	}()
+	x := extractor{dir: dir}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			if _, err := archiveTarget(dir, f.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return errors.E(err, "reading %s from zip archive", f.Name)
+		}
+		err = x.extract(f.Name, f.Mode(), rc)
+		if closeErr := rc.Close(); err == nil && closeErr != nil {
+			err = errors.E(closeErr, "reading %s from zip archive", f.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTar(dir, archive string, gzipped bool) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return errors.E(err, "opening tar archive")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Debug().Err(err).Msg("closing tar archive")
+		}
+	}()
+	var r io.Reader = f
+	if gzipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.E(err, "opening gzip stream")
+		}
+		defer func() {
+			if err := gz.Close(); err != nil {
+				log.Debug().Err(err).Msg("closing gzip stream")
+			}
+		}()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	x := extractor{dir: dir}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.E(err, "reading tar archive")
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := archiveTarget(dir, hdr.Name); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := x.extract(hdr.Name, hdr.FileInfo().Mode(), tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractor extracts the entries of an archive inside dir, accounting the
+// extracted size.
+type extractor struct {
+	dir  string
+	size int64
+}
+
+// extract writes the archive entry name inside dir. The files are executable
+// if the entry mode is executable, the other mode bits are ignored.
+// Links and other special files are never extracted.
+func (x *extractor) extract(name string, mode os.FileMode, r io.Reader) error {
+	target, err := archiveTarget(x.dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return errors.E(err, "creating dir for %s", name)
+	}
+	perm := os.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return errors.E(err, "creating %s", name)
+	}
+	n, err := io.CopyN(f, r, maxExtractedSize-x.size+1)
+	if err == io.EOF {
+		err = nil
+	}
+	x.size += n
+	if err == nil && x.size > maxExtractedSize {
+		err = errors.E(ErrArchiveTooLarge, "extracted files exceed %d bytes", maxExtractedSize)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.E(err, "extracting %s", name)
+	}
+	return nil
+}
+
+// archiveTarget returns the host path of the archive entry name inside dir,
+// failing if the entry is outside of dir.
+func archiveTarget(dir, name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || strings.Contains("/"+name+"/", "/../") {
+		return "", errors.E("archive entry %q is outside of the archive root", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(path.Clean("/"+name))), nil
+}
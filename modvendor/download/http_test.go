@@ -0,0 +1,179 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package download_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/modvendor"
+	"github.com/terramate-io/terramate/modvendor/download"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/tf"
+)
+
+func TestVendorHTTPSources(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"/policies/deny.rego": []byte("package deny\n"),
+		"/protos.tar.gz": tarGz(t, map[string]string{
+			"api/v1/service.proto": "syntax = \"proto3\";\n",
+			"README.md":            "protos\n",
+			"gen.sh":               "#!/bin/sh\n",
+		}),
+		"/scripts.zip": zipArchive(t, map[string]string{
+			"bin/check.sh": "#!/bin/sh\n",
+		}),
+		"/evil.tar.gz": tarGz(t, map[string]string{
+			"../../escaped.txt": "evil\n",
+		}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	source := func(t *testing.T, path string, data []byte) tf.Source {
+		sum := sha256.Sum256(data)
+		return test.ParseSource(t, fmt.Sprintf("%s%s?checksum=sha256:%s",
+			server.URL, path, hex.EncodeToString(sum[:])))
+	}
+	vendordir := project.NewPath("/vendor")
+
+	t.Run("plain file", func(t *testing.T) {
+		t.Parallel()
+		rootdir := test.TempDir(t)
+		modsrc := source(t, "/policies/deny.rego", files["/policies/deny.rego"])
+		report := download.Vendor(rootdir, vendordir, modsrc, nil)
+		assert.NoError(t, report.Error)
+		assert.EqualInts(t, 1, len(report.Vendored))
+		assert.EqualInts(t, 0, len(report.Ignored))
+
+		dir := modvendor.AbsVendorDir(rootdir, vendordir, modsrc)
+		test.AssertFileContentEquals(t, filepath.Join(dir, "deny.rego"), "package deny\n")
+		assertMode(t, filepath.Join(dir, "deny.rego"), 0644)
+	})
+
+	t.Run("tar.gz archive is extracted", func(t *testing.T) {
+		t.Parallel()
+		rootdir := test.TempDir(t)
+		modsrc := source(t, "/protos.tar.gz", files["/protos.tar.gz"])
+		report := download.Vendor(rootdir, vendordir, modsrc, nil)
+		assert.NoError(t, report.Error)
+		assert.EqualInts(t, 0, len(report.Ignored))
+
+		dir := modvendor.AbsVendorDir(rootdir, vendordir, modsrc)
+		test.AssertFileContentEquals(t, filepath.Join(dir, "api", "v1", "service.proto"), "syntax = \"proto3\";\n")
+		test.AssertFileContentEquals(t, filepath.Join(dir, "README.md"), "protos\n")
+		assertMode(t, filepath.Join(dir, "README.md"), 0644)
+		assertMode(t, filepath.Join(dir, "gen.sh"), 0755)
+	})
+
+	t.Run("zip archive is extracted", func(t *testing.T) {
+		t.Parallel()
+		rootdir := test.TempDir(t)
+		modsrc := source(t, "/scripts.zip", files["/scripts.zip"])
+		report := download.Vendor(rootdir, vendordir, modsrc, nil)
+		assert.NoError(t, report.Error)
+		assert.EqualInts(t, 0, len(report.Ignored))
+
+		dir := modvendor.AbsVendorDir(rootdir, vendordir, modsrc)
+		test.AssertFileContentEquals(t, filepath.Join(dir, "bin", "check.sh"), "#!/bin/sh\n")
+		assertMode(t, filepath.Join(dir, "bin", "check.sh"), 0755)
+	})
+
+	t.Run("checksum mismatch is not vendored", func(t *testing.T) {
+		t.Parallel()
+		rootdir := test.TempDir(t)
+		modsrc := source(t, "/policies/deny.rego", []byte("other contents"))
+		report := download.Vendor(rootdir, vendordir, modsrc, nil)
+		assert.EqualInts(t, 0, len(report.Vendored))
+		assert.EqualInts(t, 1, len(report.Ignored))
+		assert.IsTrue(t, errors.IsKind(report.Ignored[0].Reason, download.ErrChecksumMismatch),
+			"unexpected reason: %v", report.Ignored[0].Reason)
+		assert.EqualInts(t, 0, len(test.ReadDir(t, rootdir)))
+	})
+
+	t.Run("archive entries outside of the archive root fail", func(t *testing.T) {
+		t.Parallel()
+		rootdir := test.TempDir(t)
+		modsrc := source(t, "/evil.tar.gz", files["/evil.tar.gz"])
+		report := download.Vendor(rootdir, vendordir, modsrc, nil)
+		assert.EqualInts(t, 0, len(report.Vendored))
+		assert.EqualInts(t, 1, len(report.Ignored))
+		assert.EqualInts(t, 0, len(test.ReadDir(t, rootdir)))
+	})
+}
+
+func assertMode(t *testing.T, path string, want os.FileMode) {
+	t.Helper()
+	st, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.IsTrue(t, st.Mode().Perm() == want, "file %s has mode %s, want %s", path, st.Mode().Perm(), want)
+}
+
+// archiveMode returns the mode of the archive entries, the scripts are
+// executable.
+func archiveMode(name string) os.FileMode {
+	if strings.HasSuffix(name, ".sh") {
+		return 0755
+	}
+	return 0644
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     int64(archiveMode(name)),
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		hdr := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		hdr.SetMode(archiveMode(name))
+		w, err := zw.CreateHeader(hdr)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
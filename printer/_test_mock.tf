@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "printer" {
-  content = <<-EOT
+  content = <<-EOF
 package printer // import "github.com/terramate-io/terramate/printer"
 
 Package printer defines funtionality for "printing" text to an io.Writer e.g.
@@ -11,7 +11,7 @@ information etc.
 var Stderr = NewPrinter(os.Stderr) ...
 type Printer struct{ ... }
     func NewPrinter(w io.Writer) *Printer
-EOT
+EOF
 
   filename = "${path.module}/mock-printer.ignore"
 }
@@ -8,17 +8,9 @@ import (
 	"io"
 	"os"
 
-	"github.com/fatih/color"
 	"github.com/terramate-io/terramate/errors"
 )
 
-var (
-	bold       = color.New(color.Bold).Sprint
-	boldYellow = color.New(color.Bold, color.FgYellow).Sprint
-	boldRed    = color.New(color.Bold, color.FgRed).Sprint
-	boldGreen  = color.New(color.Bold, color.FgGreen).Sprint
-)
-
 var (
 	// Stderr is the default stderr printer
 	Stderr = NewPrinter(os.Stderr)
@@ -60,13 +52,13 @@ func (p *Printer) Printf(format string, a ...any) {
 }
 
 // Warn prints a message with a "Warning:" prefix. The prefix is printed in
-// the boldYellow style.
+// the bold RoleWarning style.
 func (p *Printer) Warn(arg any) {
 	switch arg := arg.(type) {
 	case *errors.DetailedError:
 		p.printDetailedWarning(arg)
 	default:
-		fprintln(p, boldYellow("Warning:"), bold(arg))
+		fprintln(p, warningPrefix(), SprintBold(RoleNormal, arg))
 	}
 }
 
@@ -118,17 +110,17 @@ func (p *Printer) WarnWithDetails(title string, err error) {
 }
 
 // Error prints a message with a "Error:" prefix. The prefix is printed in
-// the boldRed style.
+// the bold RoleError style.
 func (p *Printer) Error(arg any) {
 	switch arg := arg.(type) {
 	case *errors.DetailedError:
 		p.printDetailedError(arg)
 	case error:
 		if errstr := arg.Error(); errstr != "" {
-			fprintln(p, boldRed("Error:"), bold(arg.Error()))
+			fprintln(p, errorPrefix(), SprintBold(RoleNormal, arg.Error()))
 		}
 	default:
-		fprintln(p, boldRed("Error:"), bold(arg))
+		fprintln(p, errorPrefix(), SprintBold(RoleNormal, arg))
 	}
 }
 
@@ -137,9 +129,9 @@ func (p *Printer) Errorf(format string, a ...any) {
 	p.Error(fmt.Sprintf(format, a...))
 }
 
-// Success prints a message in the boldGreen style
+// Success prints a message in the bold RoleSuccess style.
 func (p *Printer) Success(msg string) {
-	fprintln(p, boldGreen(msg))
+	fprintln(p, WithIcon(IconSuccess, SprintBold(RoleSuccess, msg)))
 }
 
 // Successf is short for Success(fmt.Sprintf(...)).
@@ -152,7 +144,7 @@ func (p *Printer) printDetailedError(err *errors.DetailedError) {
 
 	p.Error(title)
 	for _, item := range items {
-		fprintln(p, boldRed(">"), item)
+		fprintln(p, SprintBold(RoleError, ">"), item)
 	}
 }
 
@@ -161,7 +153,7 @@ func (p *Printer) printDetailedWarning(err *errors.DetailedError) {
 
 	p.Warn(title)
 	for _, item := range items {
-		fprintln(p, boldYellow(">"), item)
+		fprintln(p, SprintBold(RoleWarning, ">"), item)
 	}
 }
 
@@ -180,6 +172,14 @@ func inspectDetailedError(err *errors.DetailedError) (title string, items []stri
 	return
 }
 
+func warningPrefix() string {
+	return WithIcon(IconWarning, SprintBold(RoleWarning, "Warning:"))
+}
+
+func errorPrefix() string {
+	return WithIcon(IconFailure, SprintBold(RoleError, "Error:"))
+}
+
 func fprintln(w io.Writer, a ...any) {
 	_, _ = fmt.Fprintln(w, a...)
 }
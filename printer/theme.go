@@ -0,0 +1,173 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package printer
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/terramate-io/terramate/errors"
+	"golang.org/x/term"
+)
+
+// ErrUnknownTheme indicates the color theme does not exist.
+const ErrUnknownTheme errors.Kind = "unknown color theme"
+
+// Role is the role of a text in the output, which defines its color in the
+// configured theme.
+type Role int
+
+// Roles of the output texts.
+const (
+	RoleNormal Role = iota
+	RoleSuccess
+	RoleWarning
+	RoleError
+	RoleInfo
+	RoleHighlight
+	RolePrompt
+	RoleFaint
+)
+
+// Theme maps the roles to their colors.
+type Theme map[Role][]color.Attribute
+
+// Names of the builtin themes.
+const (
+	ThemeDefault      = "default"
+	ThemeColorblind   = "colorblind"
+	ThemeHighContrast = "high-contrast"
+	ThemeMonochrome   = "monochrome"
+)
+
+// Icons prefixing the status messages when emoji are enabled.
+const (
+	IconSuccess  = "✅"
+	IconFailure  = "❌"
+	IconWarning  = "⚠️"
+	IconRunning  = "⏳"
+	IconQueued   = "💤"
+	IconCanceled = "🚫"
+)
+
+var themes = map[string]Theme{
+	ThemeDefault: {
+		RoleSuccess:   {color.FgGreen},
+		RoleWarning:   {color.FgYellow},
+		RoleError:     {color.FgRed},
+		RoleInfo:      {color.FgBlue},
+		RoleHighlight: {color.FgYellow},
+		RolePrompt:    {color.FgGreen},
+		RoleFaint:     {color.Faint},
+	},
+	// colorblind avoids distinguishing the roles only by red and green.
+	ThemeColorblind: {
+		RoleSuccess:   {color.FgBlue},
+		RoleWarning:   {color.FgYellow},
+		RoleError:     {color.FgMagenta},
+		RoleInfo:      {color.FgCyan},
+		RoleHighlight: {color.FgYellow},
+		RolePrompt:    {color.FgBlue},
+		RoleFaint:     {color.Faint},
+	},
+	ThemeHighContrast: {
+		RoleSuccess:   {color.FgHiGreen},
+		RoleWarning:   {color.FgHiYellow},
+		RoleError:     {color.FgHiRed},
+		RoleInfo:      {color.FgHiCyan},
+		RoleHighlight: {color.FgHiWhite, color.Underline},
+		RolePrompt:    {color.FgHiGreen},
+	},
+	// monochrome only uses text attributes.
+	ThemeMonochrome: {
+		RoleError:     {color.Underline},
+		RoleHighlight: {color.Italic},
+		RolePrompt:    {color.Bold},
+		RoleFaint:     {color.Faint},
+	},
+}
+
+var (
+	theme = themes[ThemeDefault]
+	emoji = isTerminal(os.Stdout) && os.Getenv("TERM") != "dumb"
+)
+
+// Options control the style of the output.
+type Options struct {
+	// Theme is the name of the color theme. The default theme is used if empty.
+	Theme string
+
+	// NoColor disables the colors and text attributes. The colors are also
+	// disabled if the NO_COLOR environment variable is set or if the output
+	// is not a terminal.
+	NoColor bool
+
+	// NoEmoji disables the emoji icons. The emoji are also disabled if the
+	// output is not a terminal.
+	NoEmoji bool
+}
+
+// Configure configures the style of the output of all the printers.
+func Configure(opts Options) error {
+	name := opts.Theme
+	if name == "" {
+		name = ThemeDefault
+	}
+	t, ok := themes[name]
+	if !ok {
+		return errors.E(ErrUnknownTheme, "%q (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	theme = t
+	if opts.NoColor || os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
+	if opts.NoEmoji {
+		emoji = false
+	}
+	return nil
+}
+
+// ThemeNames returns the names of the builtin themes.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ColorEnabled tells if the output is colored.
+func ColorEnabled() bool { return !color.NoColor }
+
+// EmojiEnabled tells if the emoji icons are enabled.
+func EmojiEnabled() bool { return emoji }
+
+// Sprint formats its operands like fmt.Sprint, in the style of the role.
+func Sprint(role Role, a ...any) string {
+	if len(theme[role]) == 0 {
+		return fmt.Sprint(a...)
+	}
+	return color.New(theme[role]...).Sprint(a...)
+}
+
+// SprintBold is like Sprint but the text is also bold.
+func SprintBold(role Role, a ...any) string {
+	return color.New(append([]color.Attribute{color.Bold}, theme[role]...)...).Sprint(a...)
+}
+
+// WithIcon prefixes msg with the icon, if emoji are enabled.
+func WithIcon(icon, msg string) string {
+	if !emoji {
+		return msg
+	}
+	return icon + " " + msg
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
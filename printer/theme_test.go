@@ -0,0 +1,86 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/terramate-io/terramate/errors"
+)
+
+func TestThemes(t *testing.T) {
+	defer restoreOutputStyle(t)()
+
+	color.NoColor = false
+	for _, name := range ThemeNames() {
+		if err := Configure(Options{Theme: name}); err != nil {
+			t.Fatalf("theme %s: %v", name, err)
+		}
+		if got := SprintBold(RoleError, "Error:"); !strings.HasPrefix(got, "\x1b[1") {
+			t.Fatalf("theme %s: want bold error, got %q", name, got)
+		}
+	}
+
+	if err := Configure(Options{Theme: ThemeDefault}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Sprint(RoleError, "failed"), "\x1b[31mfailed\x1b[0m"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	err := Configure(Options{Theme: "unknown"})
+	if !errors.IsKind(err, ErrUnknownTheme) {
+		t.Fatalf("want ErrUnknownTheme, got %v", err)
+	}
+}
+
+func TestNoColorAndNoEmoji(t *testing.T) {
+	defer restoreOutputStyle(t)()
+
+	color.NoColor = false
+	emoji = true
+	buf := new(strings.Builder)
+	p := NewPrinter(buf)
+	p.Success("done")
+	if got := buf.String(); !strings.HasPrefix(got, IconSuccess+" \x1b[") {
+		t.Fatalf("want colored message with icon, got %q", got)
+	}
+
+	if err := Configure(Options{NoColor: true}); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	p.Success("done")
+	if got, want := buf.String(), IconSuccess+" done\n"; got != want {
+		t.Fatalf("emoji must be enabled by default: want %q, got %q", want, got)
+	}
+
+	if err := Configure(Options{NoColor: true, NoEmoji: true}); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	p.Success("done")
+	if got, want := buf.String(), "done\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	color.NoColor = false
+	if err := Configure(Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if ColorEnabled() {
+		t.Fatal("NO_COLOR must disable the colors")
+	}
+}
+
+func restoreOutputStyle(t *testing.T) func() {
+	t.Helper()
+	oldTheme, oldNoColor, oldEmoji := theme, color.NoColor, emoji
+	return func() {
+		theme, color.NoColor, emoji = oldTheme, oldNoColor, oldEmoji
+	}
+}
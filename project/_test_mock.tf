@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "project" {
-  content = <<-EOT
+  content = <<-EOF
 package project // import "github.com/terramate-io/terramate/project"
 
 Package project defines concepts that are related to a Terramate project.
@@ -14,7 +14,7 @@ type Path struct{ ... }
     func PrjAbsPath(root, abspath string) Path
 type Paths []Path
 type Runtime map[string]cty.Value
-EOT
+EOF
 
   filename = "${path.module}/mock-project.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "run" {
-  content = <<-EOT
+  content = <<-EOF
 package run // import "github.com/terramate-io/terramate/run"
 
 Package run provides facilities to run commands inside Terramate context and
@@ -15,7 +15,7 @@ func LookPath(file string, environ []string) (string, error)
 func Sort[S ~[]E, E any](root *config.Root, items S, getStack func(E) *config.Stack) (string, error)
 type EnvVars []string
     func LoadEnv(root *config.Root, st *config.Stack) (EnvVars, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-run.ignore"
 }
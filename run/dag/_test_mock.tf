@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "dag" {
-  content = <<-EOT
+  content = <<-EOF
 package dag // import "github.com/terramate-io/terramate/run/dag"
 
 Package dag provides the Directed-Acyclic-Graph (DAG) primitives required
@@ -15,7 +15,7 @@ type DAG[V any] struct{ ... }
     func Transform[D, S any](from *DAG[S], f func(id ID, v S) (D, error)) (*DAG[D], error)
 type ID string
 type Visited map[ID]struct{}
-EOT
+EOF
 
   filename = "${path.module}/mock-dag.ignore"
 }
@@ -247,6 +247,55 @@ func (d *DAG[V]) Order() []ID {
 	return order
 }
 
+// Levels returns the node ids grouped by their depth in the DAG.
+// The nodes of a level only depend on nodes of the previous levels, then all
+// nodes of a single level can be processed in parallel as long as the levels
+// are processed in order. The ids inside each level are lexicographic sorted.
+// The DAG must be validated before calling this method.
+func (d *DAG[V]) Levels() [][]ID {
+	depths := make(map[ID]int, len(d.dag))
+
+	var depthOf func(id ID) int
+	depthOf = func(id ID) int {
+		if depth, ok := depths[id]; ok {
+			return depth
+		}
+		// guards against infinite recursion in case of non-validated cyclic graphs.
+		depths[id] = 0
+
+		depth := 0
+		for _, ancestor := range d.dag[id] {
+			if ancestorDepth := depthOf(ancestor) + 1; ancestorDepth > depth {
+				depth = ancestorDepth
+			}
+		}
+		depths[id] = depth
+		return depth
+	}
+
+	for _, id := range d.IDs() {
+		depthOf(id)
+	}
+
+	// ancestors which were not added as nodes are also part of the levels,
+	// like they are part of the Order().
+	ids := make(idList, 0, len(depths))
+	for id := range depths {
+		ids = append(ids, id)
+	}
+	sort.Sort(ids)
+
+	levels := [][]ID{}
+	for _, id := range ids {
+		depth := depths[id]
+		for len(levels) <= depth {
+			levels = append(levels, []ID{})
+		}
+		levels[depth] = append(levels[depth], id)
+	}
+	return levels
+}
+
 func (d *DAG[V]) walkFrom(id ID, do func(id ID)) {
 	children := d.dag[id]
 	for _, tid := range sortedIDs(children) {
@@ -419,6 +419,82 @@ func TestTransformDAG(t *testing.T) {
 	}
 }
 
+func TestDAGLevels(t *testing.T) {
+	type testcase struct {
+		name   string
+		nodes  map[string]node
+		levels [][]dag.ID
+	}
+
+	testcases := []testcase{
+		{
+			name:   "empty dag",
+			levels: [][]dag.ID{},
+		},
+		{
+			name: "independent nodes",
+			nodes: map[string]node{
+				"B": {},
+				"A": {},
+				"C": {},
+			},
+			levels: [][]dag.ID{{"A", "B", "C"}},
+		},
+		{
+			name: "A -> (B, E), B -> (C, D), D -> E",
+			nodes: map[string]node{
+				"A": {
+					ancestors: []dag.ID{"B", "E"},
+				},
+				"B": {
+					ancestors: []dag.ID{"C", "D"},
+				},
+				"D": {
+					ancestors: []dag.ID{"E"},
+				},
+				"E": {},
+			},
+			levels: [][]dag.ID{{"C", "E"}, {"D"}, {"B"}, {"A"}},
+		},
+		{
+			name: "A before B, B before D and after C",
+			nodes: map[string]node{
+				"A": {
+					descendants: []dag.ID{"B"},
+				},
+				"B": {
+					descendants: []dag.ID{"D"},
+					ancestors:   []dag.ID{"C"},
+				},
+				"C": {},
+				"D": {},
+				"E": {},
+			},
+			levels: [][]dag.ID{{"A", "C", "E"}, {"B"}, {"D"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := dag.New[any]()
+			for id, v := range tc.nodes {
+				assert.NoError(t, d.AddNode(
+					dag.ID(id), nil, v.descendants, v.ancestors),
+				)
+			}
+
+			_, err := d.Validate()
+			assert.NoError(t, err)
+
+			levels := d.Levels()
+			assert.EqualInts(t, len(tc.levels), len(levels), "levels length mismatch: %v", levels)
+			for i := range tc.levels {
+				assertOrder(t, tc.levels[i], levels[i])
+			}
+		})
+	}
+}
+
 func assertOrder(t *testing.T, want, got []dag.ID) {
 	t.Helper()
 	assert.EqualInts(t, len(want), len(got), "length mismatch")
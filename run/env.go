@@ -5,18 +5,21 @@ package run
 
 import (
 	"os"
+	"path"
 	"sort"
 	"strings"
 
 	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/hcl/v2/hclsyntax"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/globals"
 	"github.com/terramate-io/terramate/hcl/eval"
-	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/hcl/info"
 	"golang.org/x/exp/maps"
 
 	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 const (
@@ -30,6 +33,10 @@ const (
 	// ErrInvalidEnvVarType indicates the env var attribute
 	// has an invalid type.
 	ErrInvalidEnvVarType errors.Kind = "invalid environment variable type"
+
+	// ErrExportGlobalsAsTFVars indicates that a global matched by
+	// terramate.config.run.export_globals_as_tfvars can't be exported.
+	ErrExportGlobalsAsTFVars errors.Kind = "exporting globals as terraform variables"
 )
 
 // EnvVars represents a set of environment variables to be used
@@ -37,27 +44,62 @@ const (
 // on os.Environ and can be used to set env on exec.Cmd.
 type EnvVars []string
 
+// EnvVar is an environment variable defined in terramate.config.run.env.
+type EnvVar struct {
+	Name  string
+	Value string
+
+	// Sensitive tells if the value references any sensitive global.
+	Sensitive bool
+
+	// Origin is where the effective definition of the variable is.
+	Origin info.Range
+
+	// Overrides are the definitions of the same variable in parent directories
+	// which were overridden by the effective definition, from the closest to
+	// the farthest directory.
+	Overrides []info.Range
+}
+
 // LoadEnv will load environment variables to be exported when running any command
 // inside the given stack. The order of the env vars is guaranteed to be the same
 // and is ordered lexicographically.
 // All defined `terramate.config.run.env` definitions from the provided stack dir
 // up to the root of the project are collected, and env definitions closer to the
 // stack have precedence over parent definitions.
+// The globals matched by `terramate.config.run.export_globals_as_tfvars` are
+// exported as TF_VAR_* variables, unless defined in `terramate.config.run.env`.
 func LoadEnv(root *config.Root, st *config.Stack) (EnvVars, error) {
+	vars, err := LoadEnvVars(root, st)
+	if err != nil {
+		return nil, err
+	}
+	var envVars EnvVars
+	for _, v := range vars {
+		envVars = append(envVars, v.Name+"="+v.Value)
+	}
+	return envVars, nil
+}
+
+// LoadEnvVars loads the environment variables of the given stack, like
+// [LoadEnv], but keeping the provenance of each variable.
+func LoadEnvVars(root *config.Root, st *config.Stack) ([]EnvVar, error) {
 	globalsReport := globals.ForStack(root, st)
 	if err := globalsReport.AsError(); err != nil {
 		return nil, errors.E(ErrLoadingGlobals, err)
 	}
 
-	evalctx := eval.NewContext(stdlib.Functions(st.HostDir(root), root.Tree().Node.Experiments()))
+	evalctx := root.NewEvalContext(st.HostDir(root))
 	runtime := root.Runtime()
 	runtime.Merge(st.RuntimeValues(root))
 	evalctx.SetNamespace("terramate", runtime)
 	evalctx.SetNamespace("global", globalsReport.Globals.AsValueMap())
-	evalctx.SetEnv(os.Environ())
+	evalctx.DeprecateFrom("global", globalsReport.Globals)
+	evalctx.SensitiveFrom("global", globalsReport.Globals)
+	evalctx.SetEnv(root.Environ())
 
 	tree, _ := root.Lookup(st.Dir)
-	envMap := map[string]string{}
+	envMap := map[string]*EnvVar{}
 	skipMap := map[string]struct{}{}
 
 	for {
@@ -93,8 +135,15 @@ func LoadEnv(root *config.Root, st *config.Stack) (EnvVars, error) {
 					)
 				}
 
-				if _, ok := envMap[attr.Name]; !ok {
-					envMap[attr.Name] = val.AsString()
+				if envVar, ok := envMap[attr.Name]; ok {
+					envVar.Overrides = append(envVar.Overrides, attr.Range)
+					continue
+				}
+				envMap[attr.Name] = &EnvVar{
+					Name:      attr.Name,
+					Value:     val.AsString(),
+					Sensitive: evalctx.IsSensitive(attr.Expr),
+					Origin:    attr.Range,
 				}
 			}
 		}
@@ -105,15 +154,189 @@ func LoadEnv(root *config.Root, st *config.Stack) (EnvVars, error) {
 		}
 	}
 
-	var envVars EnvVars
+	tfvars, err := globalsAsTFVars(root, globalsReport.Globals)
+	if err != nil {
+		return nil, err
+	}
+	for _, tfvar := range tfvars {
+		_, defined := envMap[tfvar.Name]
+		_, unset := skipMap[tfvar.Name]
+		if !defined && !unset {
+			envMap[tfvar.Name] = tfvar
+		}
+	}
+
 	keys := maps.Keys(envMap)
 	sort.Strings(keys)
+	envVars := make([]EnvVar, 0, len(keys))
 	for _, k := range keys {
-		envVars = append(envVars, k+"="+envMap[k])
+		envVars = append(envVars, *envMap[k])
 	}
 	return envVars, nil
 }
 
+// globalsAsTFVars returns the TF_VAR_* variables of the globals matched by the
+// terramate.config.run.export_globals_as_tfvars patterns. The variable name is
+// the key of the global, eg.: global.tfvars.region is exported as
+// TF_VAR_region, and null globals are not exported.
+func globalsAsTFVars(root *config.Root, globals *eval.Object) ([]*EnvVar, error) {
+	cfg := root.Tree().Node
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil || cfg.Terramate.Config.Run == nil {
+		return nil, nil
+	}
+	runCfg := cfg.Terramate.Config.Run
+
+	var tfvars []*EnvVar
+	exported := map[string]string{}
+	for _, pattern := range runCfg.ExportGlobalsAsTFVars {
+		for _, match := range matchGlobals(globals, strings.Split(pattern, "."), nil) {
+			key := match.path[len(match.path)-1]
+			name := "TF_VAR_" + key
+			globalPath := "global." + strings.Join(match.path, ".")
+			if other, ok := exported[name]; ok {
+				if other != globalPath {
+					return nil, errors.E(ErrExportGlobalsAsTFVars, runCfg.ExportGlobalsAsTFVarsRange,
+						"%s and %s are both exported as %s", other, globalPath, name)
+				}
+				continue
+			}
+			exported[name] = globalPath
+
+			if !hclsyntax.ValidIdentifier(key) {
+				return nil, errors.E(ErrExportGlobalsAsTFVars, runCfg.ExportGlobalsAsTFVarsRange,
+					"%s can't be exported as %s is not a valid terraform variable name", globalPath, key)
+			}
+
+			val := globalValue(match.value)
+			if val.IsNull() {
+				continue
+			}
+			encoded, err := tfvarValue(val)
+			if err != nil {
+				return nil, errors.E(ErrExportGlobalsAsTFVars, err, runCfg.ExportGlobalsAsTFVarsRange,
+					"encoding %s", globalPath)
+			}
+			tfvars = append(tfvars, &EnvVar{
+				Name:      name,
+				Value:     encoded,
+				Sensitive: isSensitive(match.value),
+				Origin:    runCfg.ExportGlobalsAsTFVarsRange,
+			})
+		}
+	}
+	return tfvars, nil
+}
+
+type globalMatch struct {
+	path  []string
+	value eval.Value
+}
+
+// matchGlobals returns the globals of the object whose path match the keys
+// patterns, sorted by path.
+func matchGlobals(obj *eval.Object, patterns []string, prefix []string) []globalMatch {
+	keys := maps.Keys(obj.Keys)
+	sort.Strings(keys)
+
+	var matches []globalMatch
+	for _, key := range keys {
+		if ok, _ := path.Match(patterns[0], key); !ok {
+			continue
+		}
+		keyPath := append(append([]string{}, prefix...), key)
+		value := obj.Keys[key]
+		if len(patterns) == 1 {
+			matches = append(matches, globalMatch{path: keyPath, value: value})
+			continue
+		}
+		if subobj, ok := value.(*eval.Object); ok {
+			matches = append(matches, matchGlobals(subobj, patterns[1:], keyPath)...)
+		}
+	}
+	return matches
+}
+
+func globalValue(value eval.Value) cty.Value {
+	switch v := value.(type) {
+	case *eval.Object:
+		return cty.ObjectVal(v.AsValueMap())
+	case eval.CtyValue:
+		return v.Raw()
+	}
+	panic(errors.E(errors.ErrInternal, "unexpected global value type %T", value))
+}
+
+// isSensitive tells if the global value or any of its nested values is
+// sensitive.
+func isSensitive(value eval.Value) bool {
+	if value.Info().Sensitive {
+		return true
+	}
+	if obj, ok := value.(*eval.Object); ok {
+		for _, v := range obj.Keys {
+			if isSensitive(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tfvarValue encodes the value as expected by Terraform in the TF_VAR_*
+// variables: primitive values are set as is and complex values are encoded
+// as JSON.
+func tfvarValue(val cty.Value) (string, error) {
+	if !val.IsWhollyKnown() {
+		return "", errors.E("value is unknown")
+	}
+	switch val.Type() {
+	case cty.String:
+		return val.AsString(), nil
+	case cty.Number:
+		return val.AsBigFloat().Text('f', -1), nil
+	case cty.Bool:
+		if val.True() {
+			return "true", nil
+		}
+		return "false", nil
+	}
+	data, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return "", errors.E(err)
+	}
+	return string(data), nil
+}
+
+// EnvForStack returns the environment of the commands executed in the given
+// stack, which is the environment of the process with the stack variables
+// defined in `terramate.config.run.env` applied on top of it.
+//
+// The TF_VAR_* variables of the stack inputs, set when the outputs sharing
+// is enabled, are not part of it as they require the outputs of other stacks.
+// See engine.Engine.StackInputsEnv.
+func EnvForStack(root *config.Root, st *config.Stack) (map[string]string, error) {
+	vars, err := LoadEnvVars(root, st)
+	if err != nil {
+		return nil, err
+	}
+	return EnvWith(vars), nil
+}
+
+// EnvWith returns the environment of the process with the given variables
+// applied on top of it.
+func EnvWith(vars []EnvVar) map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			env[name] = value
+		}
+	}
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+	return env
+}
+
 // Getenv returns the value of the environment variable named by the key,
 // which is assumed to be case-insensitive, from the given environment.
 // If the variable is not present in the environment, found is false.
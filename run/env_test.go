@@ -12,6 +12,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/run"
 	"github.com/terramate-io/terramate/test"
 	errorstest "github.com/terramate-io/terramate/test/errors"
@@ -216,6 +217,99 @@ func TestLoadRunEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "globals exported as terraform variables",
+			layout: []string{
+				"s:stacks/stack-1",
+				"s:stacks/stack-2",
+			},
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Terramate(Config(Run(
+						Expr("export_globals_as_tfvars", `["tfvars.*", "env*"]`),
+						Env(
+							Str("TF_VAR_overridden", "run.env"),
+							Expr("TF_VAR_removed", "unset"),
+						),
+					))),
+				},
+				{
+					path: "/",
+					add: Globals(
+						Str("environment", "prod"),
+						Str("not_exported", "value"),
+						Expr("tfvars", `{
+							str        = "str"
+							num        = 1.5
+							bool       = true
+							list       = ["a", "b"]
+							obj        = { a = 1 }
+							null       = null
+							overridden = "global"
+							removed    = "global"
+						}`),
+					),
+				},
+				{
+					path: "/stacks/stack-2",
+					add: Globals(
+						Labels("tfvars"),
+						Str("str", "stack-2"),
+					),
+				},
+			},
+			want: map[string]result{
+				"stacks/stack-1": {
+					env: run.EnvVars{
+						"TF_VAR_bool=true",
+						"TF_VAR_environment=prod",
+						`TF_VAR_list=["a","b"]`,
+						"TF_VAR_num=1.5",
+						`TF_VAR_obj={"a":1}`,
+						"TF_VAR_overridden=run.env",
+						"TF_VAR_str=str",
+					},
+				},
+				"stacks/stack-2": {
+					env: run.EnvVars{
+						"TF_VAR_bool=true",
+						"TF_VAR_environment=prod",
+						`TF_VAR_list=["a","b"]`,
+						"TF_VAR_num=1.5",
+						`TF_VAR_obj={"a":1}`,
+						"TF_VAR_overridden=run.env",
+						"TF_VAR_str=stack-2",
+					},
+				},
+			},
+		},
+		{
+			name: "fails if globals are exported with the same terraform variable name",
+			layout: []string{
+				"s:stack",
+			},
+			configs: []hclconfig{
+				{
+					path: "/",
+					add: Terramate(Config(Run(
+						Expr("export_globals_as_tfvars", `["a.*", "b.*"]`),
+					))),
+				},
+				{
+					path: "/",
+					add: Globals(
+						Expr("a", `{ region = "a" }`),
+						Expr("b", `{ region = "b" }`),
+					),
+				},
+			},
+			want: map[string]result{
+				"stack": {
+					enverr: errors.E(run.ErrExportGlobalsAsTFVars),
+				},
+			},
+		},
 		{
 			name: "dirs can override root env",
 			hostenv: map[string]string{
@@ -606,6 +700,34 @@ func TestLoadRunEnv(t *testing.T) {
 	}
 }
 
+func TestEnvForStack(t *testing.T) {
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/stack",
+	})
+	test.AppendFile(t, s.RootDir(), "run_env.tm", Terramate(Config(Run(Env(
+		Expr("FROM_ENV", "env.TESTING_ENV_FOR_STACK"),
+		Str("OVERRIDDEN", "root"),
+	)))).String())
+	test.AppendFile(t, filepath.Join(s.RootDir(), "stacks"), "run_env.tm", Terramate(Config(Run(Env(
+		Expr("OVERRIDDEN", "terramate.stack.path.absolute"),
+	)))).String())
+
+	t.Setenv("TESTING_ENV_FOR_STACK", "host")
+	t.Setenv("OVERRIDDEN", "host")
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	stack, err := config.LoadStack(root, project.NewPath("/stacks/stack"))
+	assert.NoError(t, err)
+
+	env, err := run.EnvForStack(root, stack)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "host", env["FROM_ENV"])
+	assert.EqualStrings(t, "host", env["TESTING_ENV_FOR_STACK"])
+	assert.EqualStrings(t, "/stacks/stack", env["OVERRIDDEN"])
+}
+
 func init() {
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
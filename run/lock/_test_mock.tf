@@ -0,0 +1,27 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "lock" {
+  content = <<-EOF
+package lock // import "github.com/terramate-io/terramate/run/lock"
+
+Package lock implements the stack locks, which prevent concurrent operations
+on the same stack, possibly from different machines. The locks are kept in a
+directory, which can be shared by the machines, in a Google Cloud Storage bucket
+or in a DynamoDB table.
+
+const ErrLocked errors.Kind = "stack is locked" ...
+const DefaultDir = ".terramate/locks"
+const DefaultGCSEndpoint = "https://storage.googleapis.com"
+const DynamoDBKey = "LockID"
+type Backend interface{ ... }
+    func New(rootdir string, cfg *hcl.RunLockConfig) (Backend, error)
+type DynamoDBBackend struct{ ... }
+    func NewDynamoDBBackend(table, region, prefix string) (*DynamoDBBackend, error)
+type FileBackend struct{ ... }
+type GCSBackend struct{ ... }
+    func NewGCSBackend(bucket, prefix string) (*GCSBackend, error)
+type Info struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-lock.ignore"
+}
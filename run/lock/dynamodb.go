@@ -0,0 +1,299 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lock
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+)
+
+// dynamoDBTimeout is the maximum time waiting for a DynamoDB request.
+const dynamoDBTimeout = 30 * time.Second
+
+// DynamoDBKey is the partition key of the locks table, which must be a
+// string. It is the same key of the Terraform S3 backend locks table, so the
+// same table can be used for both.
+const DynamoDBKey = "LockID"
+
+const dynamoDBConditionFailed = "ConditionalCheckFailedException"
+
+// DynamoDBBackend keeps the locks as items of a DynamoDB table. The items are
+// put only if they don't exist and deleted only if they have the same lock
+// ID, so the locks are safe across machines.
+type DynamoDBBackend struct {
+	Table  string
+	Region string
+	Prefix string
+
+	// Endpoint is the endpoint of the DynamoDB API, the regional endpoint
+	// if empty.
+	Endpoint string
+
+	// Credentials signs the requests, which are not signed if nil.
+	Credentials aws.CredentialsProvider
+
+	// Client is the HTTP client sending the requests.
+	Client *http.Client
+}
+
+// NewDynamoDBBackend creates a backend keeping the locks in the table,
+// authenticated by the AWS default credentials chain. The region of the AWS
+// configuration is used if region is empty.
+func NewDynamoDBBackend(table, region, prefix string) (*DynamoDBBackend, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dynamoDBTimeout)
+	defer cancel()
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, errors.E(ErrBackend, err, "loading the AWS configuration")
+	}
+	if cfg.Region == "" {
+		return nil, errors.E(ErrBackend, "no AWS region configured for the DynamoDB lock table")
+	}
+	return &DynamoDBBackend{
+		Table:       table,
+		Region:      cfg.Region,
+		Prefix:      prefix,
+		Credentials: cfg.Credentials,
+	}, nil
+}
+
+// Acquire locks the stack.
+func (b *DynamoDBBackend) Acquire(stack project.Path, purpose string) (Info, error) {
+	info := Info{
+		ID:      newID(),
+		Stack:   stack.String(),
+		Who:     who(),
+		When:    time.Now().UTC(),
+		Purpose: purpose,
+	}
+	item := b.key(stack)
+	item["ID"] = dynamoDBString(info.ID)
+	item["Stack"] = dynamoDBString(info.Stack)
+	item["Who"] = dynamoDBString(info.Who)
+	item["When"] = dynamoDBString(info.When.Format(time.RFC3339Nano))
+	item["Purpose"] = dynamoDBString(info.Purpose)
+
+	err := b.do("PutItem", map[string]any{
+		"TableName":                b.Table,
+		"Item":                     item,
+		"ConditionExpression":      "attribute_not_exists(#key)",
+		"ExpressionAttributeNames": map[string]string{"#key": DynamoDBKey},
+	}, nil)
+	if isConditionFailed(err) {
+		return Info{}, b.lockedErr(stack)
+	}
+	if err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+// Release releases the lock. It fails if the stack was locked by someone
+// else in the meantime.
+func (b *DynamoDBBackend) Release(lock Info) error {
+	stack := project.NewPath(lock.Stack)
+	err := b.do("DeleteItem", map[string]any{
+		"TableName":                 b.Table,
+		"Key":                       b.key(stack),
+		"ConditionExpression":       "#id = :id",
+		"ExpressionAttributeNames":  map[string]string{"#id": "ID"},
+		"ExpressionAttributeValues": map[string]dynamoDBValue{":id": dynamoDBString(lock.ID)},
+	}, nil)
+	if isConditionFailed(err) {
+		_, found, err := b.Get(stack)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.E(ErrNotLocked, "stack %s", stack)
+		}
+		return b.lockedErr(stack)
+	}
+	return err
+}
+
+// Get returns the current lock of the stack.
+func (b *DynamoDBBackend) Get(stack project.Path) (Info, bool, error) {
+	var resp struct {
+		Item map[string]dynamoDBValue
+	}
+	err := b.do("GetItem", map[string]any{
+		"TableName":      b.Table,
+		"Key":            b.key(stack),
+		"ConsistentRead": true,
+	}, &resp)
+	if err != nil {
+		return Info{}, false, err
+	}
+	if resp.Item == nil {
+		return Info{}, false, nil
+	}
+	info, err := dynamoDBInfo(resp.Item)
+	if err != nil {
+		return Info{}, false, errors.E(ErrBackend, err, "decoding lock of stack %s", stack)
+	}
+	return info, true, nil
+}
+
+// ForceRelease releases the lock of the stack, whoever holds it.
+func (b *DynamoDBBackend) ForceRelease(stack project.Path) (Info, error) {
+	var resp struct {
+		Attributes map[string]dynamoDBValue
+	}
+	err := b.do("DeleteItem", map[string]any{
+		"TableName":    b.Table,
+		"Key":          b.key(stack),
+		"ReturnValues": "ALL_OLD",
+	}, &resp)
+	if err != nil {
+		return Info{}, err
+	}
+	if resp.Attributes == nil {
+		return Info{}, errors.E(ErrNotLocked, "stack %s", stack)
+	}
+	info, err := dynamoDBInfo(resp.Attributes)
+	if err != nil {
+		return Info{}, errors.E(ErrBackend, err, "decoding lock of stack %s", stack)
+	}
+	return info, nil
+}
+
+func (b *DynamoDBBackend) lockedErr(stack project.Path) error {
+	info, found, err := b.Get(stack)
+	if err != nil || !found {
+		return errors.E(ErrLocked, "stack %s", stack)
+	}
+	return errors.E(ErrLocked, "stack %s: %s", stack, info)
+}
+
+// key returns the primary key of the lock item of the stack.
+func (b *DynamoDBBackend) key(stack project.Path) map[string]dynamoDBValue {
+	return map[string]dynamoDBValue{
+		DynamoDBKey: dynamoDBString(b.Prefix + lockName(stack)),
+	}
+}
+
+// dynamoDBValue is an attribute value of the DynamoDB API. The locks only
+// have string attributes.
+type dynamoDBValue struct {
+	S string `json:"S"`
+}
+
+func dynamoDBString(s string) dynamoDBValue {
+	return dynamoDBValue{S: s}
+}
+
+func dynamoDBInfo(item map[string]dynamoDBValue) (Info, error) {
+	when, err := time.Parse(time.RFC3339Nano, item["When"].S)
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{
+		ID:      item["ID"].S,
+		Stack:   item["Stack"].S,
+		Who:     item["Who"].S,
+		When:    when,
+		Purpose: item["Purpose"].S,
+	}, nil
+}
+
+// dynamoDBError is an error response of the DynamoDB API.
+type dynamoDBError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (e *dynamoDBError) Error() string {
+	return "DynamoDB responded with " + e.Type + ": " + e.Message
+}
+
+func isConditionFailed(err error) bool {
+	var apiErr *dynamoDBError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	// the type is prefixed by the API namespace, eg.:
+	// com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException
+	return strings.HasSuffix(apiErr.Type, "#"+dynamoDBConditionFailed) ||
+		apiErr.Type == dynamoDBConditionFailed
+}
+
+// do calls the action of the DynamoDB API with the given request, decoding
+// the response into resp, if not nil.
+func (b *DynamoDBBackend) do(action string, req any, resp any) error {
+	endpoint := b.Endpoint
+	if endpoint == "" {
+		endpoint = "https://dynamodb." + b.Region + ".amazonaws.com"
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.E(ErrBackend, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dynamoDBTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return errors.E(ErrBackend, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	httpReq.Header.Set("X-Amz-Target", "DynamoDB_20120810."+action)
+	if b.Credentials != nil {
+		creds, err := b.Credentials.Retrieve(ctx)
+		if err != nil {
+			return errors.E(ErrBackend, err, "retrieving the AWS credentials")
+		}
+		hash := sha256.Sum256(body)
+		err = v4.NewSigner().SignHTTP(ctx, creds, httpReq, hex.EncodeToString(hash[:]),
+			"dynamodb", b.Region, time.Now())
+		if err != nil {
+			return errors.E(ErrBackend, err, "signing the DynamoDB request")
+		}
+	}
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return errors.E(ErrBackend, err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.E(ErrBackend, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		apiErr := &dynamoDBError{}
+		if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Type == "" {
+			return errors.E(ErrBackend, "DynamoDB responded with %s: %s",
+				httpResp.Status, bytes.TrimSpace(data))
+		}
+		return errors.E(ErrBackend, apiErr)
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return errors.E(ErrBackend, err, "decoding the DynamoDB response")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lock_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/lock"
+)
+
+func TestDynamoDBBackendLock(t *testing.T) {
+	t.Parallel()
+
+	dynamodb := newFakeDynamoDB(t)
+	backend := &lock.DynamoDBBackend{
+		Table:       "locks",
+		Region:      "eu-west-1",
+		Prefix:      "tm/",
+		Endpoint:    dynamodb.URL,
+		Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+	}
+
+	stack := project.NewPath("/stacks/a")
+
+	_, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "stack must not be locked")
+
+	info, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/stacks/a", info.Stack)
+	assert.EqualStrings(t, "terraform apply", info.Purpose)
+
+	_, err = backend.Acquire(stack, "terraform destroy")
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+	assert.IsTrue(t, strings.Contains(err.Error(), info.ID), "error must describe the lock: %v", err)
+
+	got, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "stack must be locked")
+	assert.EqualStrings(t, info.ID, got.ID)
+	assert.EqualStrings(t, info.Who, got.Who)
+	assert.EqualStrings(t, info.Purpose, got.Purpose)
+	assert.IsTrue(t, info.When.Equal(got.When), "want lock time %s, got %s", info.When, got.When)
+
+	dynamodb.mu.Lock()
+	_, ok := dynamodb.items["tm/%2Fstacks%2Fa.lock"]
+	signed := dynamodb.signed
+	dynamodb.mu.Unlock()
+	assert.IsTrue(t, ok, "lock item not found in the table")
+	assert.IsTrue(t, signed, "requests must be signed")
+
+	assert.NoError(t, backend.Release(info))
+
+	err = backend.Release(info)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+}
+
+func TestDynamoDBBackendReleaseOtherLock(t *testing.T) {
+	t.Parallel()
+
+	dynamodb := newFakeDynamoDB(t)
+	backend := &lock.DynamoDBBackend{Table: "locks", Endpoint: dynamodb.URL}
+
+	stack := project.NewPath("/stack")
+	stale, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	released, err := backend.ForceRelease(stack)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, stale.ID, released.ID)
+
+	_, err = backend.ForceRelease(stack)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+
+	current, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	// the stale lock must not release the current one.
+	err = backend.Release(stale)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+	assert.NoError(t, backend.Release(current))
+}
+
+func TestDynamoDBBackendFailure(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","message":"table not found"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	backend := &lock.DynamoDBBackend{Table: "locks", Endpoint: srv.URL}
+	_, err := backend.Acquire(project.NewPath("/stack"), "terraform apply")
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrBackend), "want ErrBackend, got %v", err)
+	assert.IsTrue(t, strings.Contains(err.Error(), "table not found"), "error must have the API message: %v", err)
+}
+
+type fakeDynamoDBValue struct {
+	S string
+}
+
+// fakeDynamoDB implements the subset of the DynamoDB API used by the
+// backend, including the condition expressions it uses.
+type fakeDynamoDB struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	signed bool
+	items  map[string]map[string]fakeDynamoDBValue
+}
+
+func newFakeDynamoDB(t *testing.T) *fakeDynamoDB {
+	dynamodb := &fakeDynamoDB{items: map[string]map[string]fakeDynamoDBValue{}}
+	dynamodb.Server = httptest.NewServer(http.HandlerFunc(dynamodb.serve))
+	t.Cleanup(dynamodb.Close)
+	return dynamodb
+}
+
+func (dynamodb *fakeDynamoDB) serve(w http.ResponseWriter, r *http.Request) {
+	dynamodb.mu.Lock()
+	defer dynamodb.mu.Unlock()
+
+	if strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		dynamodb.signed = true
+	}
+
+	var req struct {
+		TableName                 string
+		Item                      map[string]fakeDynamoDBValue
+		Key                       map[string]fakeDynamoDBValue
+		ConditionExpression       string
+		ExpressionAttributeValues map[string]fakeDynamoDBValue
+		ReturnValues              string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TableName != "locks" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	conditionFailed := func() {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+	}
+
+	switch r.Header.Get("X-Amz-Target") {
+	case "DynamoDB_20120810.PutItem":
+		key := req.Item[lock.DynamoDBKey].S
+		if _, ok := dynamodb.items[key]; ok && req.ConditionExpression == "attribute_not_exists(#key)" {
+			conditionFailed()
+			return
+		}
+		dynamodb.items[key] = req.Item
+		_, _ = w.Write([]byte(`{}`))
+	case "DynamoDB_20120810.GetItem":
+		item, ok := dynamodb.items[req.Key[lock.DynamoDBKey].S]
+		if !ok {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Item": item})
+	case "DynamoDB_20120810.DeleteItem":
+		key := req.Key[lock.DynamoDBKey].S
+		item, ok := dynamodb.items[key]
+		if req.ConditionExpression == "#id = :id" && (!ok || item["ID"] != req.ExpressionAttributeValues[":id"]) {
+			conditionFailed()
+			return
+		}
+		delete(dynamodb.items, key)
+		if !ok || req.ReturnValues != "ALL_OLD" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Attributes": item})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"golang.org/x/oauth2/google"
+)
+
+// DefaultGCSEndpoint is the endpoint of the Google Cloud Storage JSON API.
+const DefaultGCSEndpoint = "https://storage.googleapis.com"
+
+// gcsTimeout is the maximum time waiting for a Google Cloud Storage request.
+const gcsTimeout = 30 * time.Second
+
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// GCSBackend keeps the locks as objects of a Google Cloud Storage bucket.
+// The objects are created only if they don't exist and deleted only if they
+// weren't replaced, so the locks are safe across machines.
+type GCSBackend struct {
+	Bucket string
+	Prefix string
+
+	// Endpoint is the endpoint of the JSON API, [DefaultGCSEndpoint] if
+	// empty.
+	Endpoint string
+
+	// Client is the HTTP client authenticating the requests.
+	Client *http.Client
+}
+
+// NewGCSBackend creates a backend keeping the locks in the bucket,
+// authenticated by the Google Application Default Credentials.
+func NewGCSBackend(bucket, prefix string) (*GCSBackend, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gcsTimeout)
+	defer cancel()
+	client, err := google.DefaultClient(ctx, gcsScope)
+	if err != nil {
+		return nil, errors.E(ErrBackend, err, "finding the Google Cloud credentials")
+	}
+	return &GCSBackend{
+		Bucket: bucket,
+		Prefix: prefix,
+		Client: client,
+	}, nil
+}
+
+// Acquire locks the stack.
+func (b *GCSBackend) Acquire(stack project.Path, purpose string) (Info, error) {
+	info := Info{
+		ID:      newID(),
+		Stack:   stack.String(),
+		Who:     who(),
+		When:    time.Now().UTC(),
+		Purpose: purpose,
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return Info{}, errors.E(ErrBackend, err)
+	}
+
+	query := url.Values{}
+	query.Set("uploadType", "media")
+	query.Set("name", b.object(stack))
+	query.Set("ifGenerationMatch", "0")
+	resp, err := b.do(http.MethodPost, "/upload/storage/v1/b/"+url.PathEscape(b.Bucket)+"/o", query, data)
+	if err != nil {
+		return Info{}, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return info, nil
+	case http.StatusPreconditionFailed:
+		return Info{}, b.lockedErr(stack)
+	default:
+		return Info{}, statusErr(resp)
+	}
+}
+
+// Release releases the lock. It fails if the stack was locked by someone
+// else in the meantime.
+func (b *GCSBackend) Release(lock Info) error {
+	stack := project.NewPath(lock.Stack)
+	current, generation, found, err := b.get(stack)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.E(ErrNotLocked, "stack %s", stack)
+	}
+	if current.ID != lock.ID {
+		return b.lockedErr(stack)
+	}
+	return b.delete(stack, generation)
+}
+
+// Get returns the current lock of the stack.
+func (b *GCSBackend) Get(stack project.Path) (Info, bool, error) {
+	info, _, found, err := b.get(stack)
+	return info, found, err
+}
+
+// ForceRelease releases the lock of the stack, whoever holds it.
+func (b *GCSBackend) ForceRelease(stack project.Path) (Info, error) {
+	info, generation, found, err := b.get(stack)
+	if err != nil {
+		return Info{}, err
+	}
+	if !found {
+		return Info{}, errors.E(ErrNotLocked, "stack %s", stack)
+	}
+	if err := b.delete(stack, generation); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+// get returns the lock of the stack and the generation of its object.
+func (b *GCSBackend) get(stack project.Path) (Info, string, bool, error) {
+	query := url.Values{}
+	query.Set("alt", "media")
+	resp, err := b.do(http.MethodGet, b.objectPath(stack), query, nil)
+	if err != nil {
+		return Info{}, "", false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Info{}, "", false, nil
+	default:
+		return Info{}, "", false, statusErr(resp)
+	}
+	var info Info
+	if err := json.Unmarshal(resp.body, &info); err != nil {
+		return Info{}, "", false, errors.E(ErrBackend, err, "decoding lock of stack %s", stack)
+	}
+	generation := resp.Header.Get("X-Goog-Generation")
+	if _, err := strconv.ParseInt(generation, 10, 64); err != nil {
+		return Info{}, "", false, errors.E(ErrBackend, "invalid generation %q of the lock of stack %s", generation, stack)
+	}
+	return info, generation, true, nil
+}
+
+// delete deletes the lock of the stack if its object has the given
+// generation, ie. it wasn't replaced by another lock.
+func (b *GCSBackend) delete(stack project.Path, generation string) error {
+	query := url.Values{}
+	query.Set("ifGenerationMatch", generation)
+	resp, err := b.do(http.MethodDelete, b.objectPath(stack), query, nil)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return errors.E(ErrNotLocked, "stack %s", stack)
+	case http.StatusPreconditionFailed:
+		return b.lockedErr(stack)
+	default:
+		return statusErr(resp)
+	}
+}
+
+func (b *GCSBackend) lockedErr(stack project.Path) error {
+	info, found, err := b.Get(stack)
+	if err != nil || !found {
+		return errors.E(ErrLocked, "stack %s", stack)
+	}
+	return errors.E(ErrLocked, "stack %s: %s", stack, info)
+}
+
+func (b *GCSBackend) object(stack project.Path) string {
+	return b.Prefix + lockName(stack)
+}
+
+func (b *GCSBackend) objectPath(stack project.Path) string {
+	return "/storage/v1/b/" + url.PathEscape(b.Bucket) + "/o/" + url.PathEscape(b.object(stack))
+}
+
+type gcsResponse struct {
+	*http.Response
+	body []byte
+}
+
+func (b *GCSBackend) do(method, path string, query url.Values, body []byte) (gcsResponse, error) {
+	endpoint := b.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultGCSEndpoint
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gcsTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+path+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return gcsResponse{}, errors.E(ErrBackend, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return gcsResponse{}, errors.E(ErrBackend, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return gcsResponse{}, errors.E(ErrBackend, err)
+	}
+	return gcsResponse{Response: resp, body: data}, nil
+}
+
+func statusErr(resp gcsResponse) error {
+	return errors.E(ErrBackend, "Google Cloud Storage responded with %s: %s",
+		resp.Status, bytes.TrimSpace(resp.body))
+}
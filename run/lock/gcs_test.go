@@ -0,0 +1,160 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lock_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/lock"
+)
+
+func TestGCSBackendLock(t *testing.T) {
+	t.Parallel()
+
+	gcs := newFakeGCS(t)
+	backend := &lock.GCSBackend{Bucket: "locks", Prefix: "tm/", Endpoint: gcs.URL}
+
+	stack := project.NewPath("/stacks/a")
+
+	_, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "stack must not be locked")
+
+	info, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/stacks/a", info.Stack)
+
+	_, err = backend.Acquire(stack, "terraform destroy")
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+	assert.IsTrue(t, strings.Contains(err.Error(), info.ID), "error must describe the lock: %v", err)
+
+	got, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "stack must be locked")
+	assert.EqualStrings(t, info.ID, got.ID)
+
+	gcs.mu.Lock()
+	_, ok := gcs.objects["tm/%2Fstacks%2Fa.lock"]
+	gcs.mu.Unlock()
+	assert.IsTrue(t, ok, "lock object not found in the bucket")
+
+	assert.NoError(t, backend.Release(info))
+
+	err = backend.Release(info)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+}
+
+func TestGCSBackendReleaseOtherLock(t *testing.T) {
+	t.Parallel()
+
+	gcs := newFakeGCS(t)
+	backend := &lock.GCSBackend{Bucket: "locks", Endpoint: gcs.URL}
+
+	stack := project.NewPath("/stack")
+	stale, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	released, err := backend.ForceRelease(stack)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, stale.ID, released.ID)
+
+	_, err = backend.ForceRelease(stack)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+
+	current, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	// the stale lock must not release the current one.
+	err = backend.Release(stale)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+	assert.NoError(t, backend.Release(current))
+}
+
+func TestGCSBackendFailure(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "access denied", http.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+
+	backend := &lock.GCSBackend{Bucket: "locks", Endpoint: srv.URL}
+	_, err := backend.Acquire(project.NewPath("/stack"), "terraform apply")
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrBackend), "want ErrBackend, got %v", err)
+}
+
+type fakeGCSObject struct {
+	data       []byte
+	generation int64
+}
+
+// fakeGCS implements the subset of the Google Cloud Storage JSON API used by
+// the backend, including the generation preconditions.
+type fakeGCS struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	generation int64
+	objects    map[string]fakeGCSObject
+}
+
+func newFakeGCS(t *testing.T) *fakeGCS {
+	gcs := &fakeGCS{objects: map[string]fakeGCSObject{}}
+	gcs.Server = httptest.NewServer(http.HandlerFunc(gcs.serve))
+	t.Cleanup(gcs.Close)
+	return gcs
+}
+
+func (gcs *fakeGCS) serve(w http.ResponseWriter, r *http.Request) {
+	gcs.mu.Lock()
+	defer gcs.mu.Unlock()
+
+	query := r.URL.Query()
+	if r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/locks/o" {
+		name := query.Get("name")
+		if _, ok := gcs.objects[name]; ok && query.Get("ifGenerationMatch") == "0" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		gcs.generation++
+		gcs.objects[name] = fakeGCSObject{data: data, generation: gcs.generation}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	name, ok := strings.CutPrefix(r.URL.Path, "/storage/v1/b/locks/o/")
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	obj, ok := gcs.objects[name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("X-Goog-Generation", strconv.FormatInt(obj.generation, 10))
+		_, _ = w.Write(obj.data)
+	case http.MethodDelete:
+		if query.Get("ifGenerationMatch") != strconv.FormatInt(obj.generation, 10) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(gcs.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
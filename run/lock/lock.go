@@ -0,0 +1,235 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package lock implements the stack locks, which prevent concurrent
+// operations on the same stack, possibly from different machines. The locks
+// are kept in a directory, which can be shared by the machines, in a Google
+// Cloud Storage bucket or in a DynamoDB table.
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+)
+
+// DefaultDir is the directory, relative to the project root, where the locks
+// are kept if terramate.config.run.lock.path is not set.
+const DefaultDir = ".terramate/locks"
+
+const (
+	// ErrLocked indicates that the stack is locked by another operation.
+	ErrLocked errors.Kind = "stack is locked"
+
+	// ErrNotLocked indicates that the stack is not locked.
+	ErrNotLocked errors.Kind = "stack is not locked"
+
+	// ErrBackend indicates a failure of the locking backend.
+	ErrBackend errors.Kind = "lock backend failure"
+)
+
+// Info is the metadata of a lock.
+type Info struct {
+	// ID uniquely identifies the lock.
+	ID string `json:"id"`
+	// Stack is the locked stack.
+	Stack string `json:"stack"`
+	// Who is the user and host holding the lock.
+	Who string `json:"who"`
+	// When is the time the lock was acquired.
+	When time.Time `json:"when"`
+	// Purpose describes the operation holding the lock.
+	Purpose string `json:"purpose"`
+}
+
+// Backend is a stack locking backend.
+type Backend interface {
+	// Acquire locks the stack, failing with ErrLocked if already locked.
+	Acquire(stack project.Path, purpose string) (Info, error)
+
+	// Release releases the given lock.
+	Release(lock Info) error
+
+	// Get returns the current lock of the stack, if any.
+	Get(stack project.Path) (Info, bool, error)
+
+	// ForceRelease releases the lock of the stack, whoever holds it, and
+	// returns the released lock.
+	ForceRelease(stack project.Path) (Info, error)
+}
+
+// New creates the backend configured by cfg, which must not be nil.
+func New(rootdir string, cfg *hcl.RunLockConfig) (Backend, error) {
+	switch cfg.Backend {
+	case "", "file":
+		dir := cfg.Path
+		if dir == "" {
+			dir = filepath.FromSlash(DefaultDir)
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(rootdir, dir)
+		}
+		return &FileBackend{Dir: dir}, nil
+	case "gcs":
+		return NewGCSBackend(cfg.Bucket, cfg.Prefix)
+	case "dynamodb":
+		return NewDynamoDBBackend(cfg.Table, cfg.Region, cfg.Prefix)
+	default:
+		return nil, errors.E(ErrBackend, "unsupported lock backend %q", cfg.Backend)
+	}
+}
+
+// FileBackend keeps the locks as files inside Dir. Sharing the directory,
+// eg.: through a network filesystem, shares the locks between machines.
+type FileBackend struct {
+	Dir string
+}
+
+// Acquire locks the stack.
+func (b *FileBackend) Acquire(stack project.Path, purpose string) (Info, error) {
+	info := Info{
+		ID:      newID(),
+		Stack:   stack.String(),
+		Who:     who(),
+		When:    time.Now().UTC(),
+		Purpose: purpose,
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return Info{}, errors.E(ErrBackend, err)
+	}
+	if err := os.MkdirAll(b.Dir, 0755); err != nil {
+		return Info{}, errors.E(ErrBackend, err)
+	}
+	// the locks are never committed, even when kept inside the project.
+	gitignore := filepath.Join(b.Dir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		if err := os.WriteFile(gitignore, []byte("*\n"), 0644); err != nil {
+			return Info{}, errors.E(ErrBackend, err)
+		}
+	}
+
+	path := b.path(stack)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return Info{}, b.lockedErr(stack)
+		}
+		return Info{}, errors.E(ErrBackend, err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return Info{}, errors.E(ErrBackend, err)
+	}
+	return info, nil
+}
+
+// Release releases the lock. It fails if the stack was locked by someone
+// else in the meantime.
+func (b *FileBackend) Release(lock Info) error {
+	stack := project.NewPath(lock.Stack)
+	current, found, err := b.Get(stack)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.E(ErrNotLocked, "stack %s", stack)
+	}
+	if current.ID != lock.ID {
+		return b.lockedErr(stack)
+	}
+	if err := os.Remove(b.path(stack)); err != nil {
+		return errors.E(ErrBackend, err)
+	}
+	return nil
+}
+
+// Get returns the current lock of the stack.
+func (b *FileBackend) Get(stack project.Path) (Info, bool, error) {
+	data, err := os.ReadFile(b.path(stack))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Info{}, false, nil
+		}
+		return Info{}, false, errors.E(ErrBackend, err)
+	}
+	var info Info
+	if err := json.Unmarshal(data, &info); err != nil {
+		return Info{}, false, errors.E(ErrBackend, err, "decoding lock of stack %s", stack)
+	}
+	return info, true, nil
+}
+
+// ForceRelease releases the lock of the stack, whoever holds it.
+func (b *FileBackend) ForceRelease(stack project.Path) (Info, error) {
+	info, found, err := b.Get(stack)
+	if err != nil {
+		return Info{}, err
+	}
+	if !found {
+		return Info{}, errors.E(ErrNotLocked, "stack %s", stack)
+	}
+	if err := os.Remove(b.path(stack)); err != nil {
+		return Info{}, errors.E(ErrBackend, err)
+	}
+	return info, nil
+}
+
+func (b *FileBackend) lockedErr(stack project.Path) error {
+	info, found, err := b.Get(stack)
+	if err != nil || !found {
+		return errors.E(ErrLocked, "stack %s", stack)
+	}
+	return errors.E(ErrLocked, "stack %s: %s", stack, info)
+}
+
+// path returns the lock file of the stack.
+func (b *FileBackend) path(stack project.Path) string {
+	return filepath.Join(b.Dir, lockName(stack))
+}
+
+// lockName returns the name of the lock of the stack. The stack path is
+// escaped, including its slashes, so all locks are kept in the same directory
+// and different stacks never share a lock.
+func lockName(stack project.Path) string {
+	return url.PathEscape(stack.String()) + ".lock"
+}
+
+// String returns a human readable description of the lock.
+func (i Info) String() string {
+	return fmt.Sprintf("locked by %s at %s (purpose: %s, lock ID: %s)",
+		i.Who, i.When.Format(time.RFC3339), i.Purpose, i.ID)
+}
+
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func who() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	} else if env := os.Getenv("USER"); env != "" {
+		name = env
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return name + "@" + host
+	}
+	return name
+}
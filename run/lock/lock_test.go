@@ -0,0 +1,109 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package lock_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/run/lock"
+)
+
+func TestFileBackendLock(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	backend, err := lock.New(rootdir, &hcl.RunLockConfig{Backend: "file"})
+	assert.NoError(t, err)
+
+	stack := project.NewPath("/stacks/a")
+
+	_, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "stack must not be locked")
+
+	info, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "/stacks/a", info.Stack)
+	assert.EqualStrings(t, "terraform apply", info.Purpose)
+
+	_, err = os.Stat(filepath.Join(rootdir, ".terramate", "locks", ".gitignore"))
+	assert.NoError(t, err)
+
+	_, err = backend.Acquire(stack, "terraform destroy")
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+
+	// other stacks are not affected.
+	other, err := backend.Acquire(project.NewPath("/stacks"), "terraform apply")
+	assert.NoError(t, err)
+
+	got, found, err := backend.Get(stack)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "stack must be locked")
+	assert.EqualStrings(t, info.ID, got.ID)
+
+	assert.NoError(t, backend.Release(info))
+	assert.NoError(t, backend.Release(other))
+
+	err = backend.Release(info)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+}
+
+func TestFileBackendReleaseOtherLock(t *testing.T) {
+	t.Parallel()
+
+	backend, err := lock.New(t.TempDir(), &hcl.RunLockConfig{})
+	assert.NoError(t, err)
+
+	stack := project.NewPath("/stack")
+	stale, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	released, err := backend.ForceRelease(stack)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, stale.ID, released.ID)
+
+	_, err = backend.ForceRelease(stack)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrNotLocked), "want ErrNotLocked, got %v", err)
+
+	current, err := backend.Acquire(stack, "terraform apply")
+	assert.NoError(t, err)
+
+	// the stale lock must not release the current one.
+	err = backend.Release(stale)
+	assert.IsTrue(t, errors.IsKind(err, lock.ErrLocked), "want ErrLocked, got %v", err)
+	assert.NoError(t, backend.Release(current))
+}
+
+func TestFileBackendCustomPath(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	backend, err := lock.New(rootdir, &hcl.RunLockConfig{Backend: "file", Path: "shared"})
+	assert.NoError(t, err)
+
+	_, err = backend.Acquire(project.NewPath("/"), "terraform apply")
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(rootdir, "shared"))
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(entries))
+}
+
+func TestFileBackendDistinctStacksDontCollide(t *testing.T) {
+	t.Parallel()
+
+	backend, err := lock.New(t.TempDir(), &hcl.RunLockConfig{})
+	assert.NoError(t, err)
+
+	for _, stack := range []string{"/a/b", "/a__b", "/a%2Fb", "/"} {
+		_, err := backend.Acquire(project.NewPath(stack), "terraform apply")
+		assert.NoError(t, err, "locking stack %s", stack)
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package lock // import \"github.com/terramate-io/terramate/run/lock\""
+  description = "package lock // import \"github.com/terramate-io/terramate/run/lock\"\n\nPackage lock implements the stack locks, which prevent concurrent operations\non the same stack, possibly from different machines. The locks are kept in a\ndirectory, which can be shared by the machines, in a Google Cloud Storage bucket\nor in a DynamoDB table.\n\nconst ErrLocked errors.Kind = \"stack is locked\" ...\nconst DefaultDir = \".terramate/locks\"\nconst DefaultGCSEndpoint = \"https://storage.googleapis.com\"\nconst DynamoDBKey = \"LockID\"\ntype Backend interface{ ... }\n    func New(rootdir string, cfg *hcl.RunLockConfig) (Backend, error)\ntype DynamoDBBackend struct{ ... }\n    func NewDynamoDBBackend(table, region, prefix string) (*DynamoDBBackend, error)\ntype FileBackend struct{ ... }\ntype GCSBackend struct{ ... }\n    func NewGCSBackend(bucket, prefix string) (*GCSBackend, error)\ntype Info struct{ ... }"
+  tags        = ["golang", "lock", "run"]
+  id          = "713e9cdd-1d6a-426c-a0f5-9eceb625ccf3"
+}
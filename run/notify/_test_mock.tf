@@ -0,0 +1,19 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "notify" {
+  content = <<-EOF
+package notify // import "github.com/terramate-io/terramate/run/notify"
+
+Package notify sends the results of the run and drift commands to the webhook
+configured in the terramate.config.notifications block.
+
+const StatusOK = "ok" ...
+const ErrNotify errors.Kind = "sending notification"
+const Timeout = 10 * time.Second
+func Send(ctx context.Context, root *config.Root, report Report) error
+type Report struct{ ... }
+type StackResult struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-notify.ignore"
+}
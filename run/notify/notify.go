@@ -0,0 +1,218 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package notify sends the results of the run and drift commands to the
+// webhook configured in the terramate.config.notifications block.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ErrNotify indicates the notification could not be sent.
+const ErrNotify errors.Kind = "sending notification"
+
+// Statuses of the stacks in a report.
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusDrifted  = "drifted"
+	StatusCanceled = "canceled"
+	StatusSkipped  = "skipped"
+)
+
+// Timeout is the maximum time waiting for the webhook response.
+const Timeout = 10 * time.Second
+
+// maxSlackStacks is the maximum number of stacks listed in a Slack message.
+const maxSlackStacks = 20
+
+// StackResult is the result of a command in a stack.
+type StackResult struct {
+	Stack  string `json:"stack"`
+	Status string `json:"status"`
+}
+
+// Report summarizes the results of a command in the selected stacks.
+type Report struct {
+	Command string
+	Stacks  []StackResult
+}
+
+// payload is the body of the notifications in the json format.
+type payload struct {
+	Command string        `json:"command"`
+	Events  []string      `json:"events"`
+	Total   int           `json:"total"`
+	Stacks  []StackResult `json:"stacks"`
+}
+
+// Send notifies the report to the webhook configured in the root
+// configuration. Nothing is sent if there's no configured webhook or if none
+// of the stacks match the configured events. Only the stacks matching the
+// events are included in the notification.
+func Send(ctx context.Context, root *config.Root, report Report) error {
+	cfg := root.Tree().Node.Notifications()
+	if cfg == nil {
+		return nil
+	}
+
+	var (
+		events []string
+		stacks []StackResult
+	)
+	for _, st := range report.Stacks {
+		event, ok := eventOf(st.Status)
+		if !ok || !slices.Contains(cfg.Events, event) {
+			continue
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+		stacks = append(stacks, st)
+	}
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	webhook, err := evalURL(root, cfg)
+	if err != nil {
+		return err
+	}
+
+	p := payload{
+		Command: report.Command,
+		Events:  events,
+		Total:   len(report.Stacks),
+		Stacks:  stacks,
+	}
+	var body []byte
+	if cfg.Format == hcl.NotificationsFormatSlack {
+		body, err = json.Marshal(slackMessage(p))
+	} else {
+		body, err = json.Marshal(p)
+	}
+	if err != nil {
+		return errors.E(ErrNotify, err, "encoding payload")
+	}
+	return post(ctx, webhook, body)
+}
+
+func eventOf(status string) (string, bool) {
+	switch status {
+	case StatusFailed:
+		return hcl.NotificationsEventFailure, true
+	case StatusDrifted:
+		return hcl.NotificationsEventDrift, true
+	default:
+		return "", false
+	}
+}
+
+// evalURL evaluates the webhook URL template. Only the terramate and env
+// namespaces are available.
+func evalURL(root *config.Root, cfg *hcl.NotificationsConfig) (string, error) {
+	evalctx := root.NewEvalContext(root.HostDir())
+	evalctx.SetNamespace("terramate", root.Runtime())
+	evalctx.SetEnv(root.Environ())
+
+	val, err := evalctx.Eval(cfg.URL)
+	if err != nil {
+		return "", errors.E(ErrNotify, err, "evaluating terramate.config.notifications.url")
+	}
+	if val.Type() != cty.String {
+		return "", errors.E(ErrNotify, cfg.URL.Range(),
+			"terramate.config.notifications.url must be a string but has type %s",
+			val.Type().FriendlyName())
+	}
+	webhook := val.AsString()
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// the URL is not part of the error because it usually contains secrets.
+		return "", errors.E(ErrNotify, cfg.URL.Range(),
+			"terramate.config.notifications.url must be an http or https URL")
+	}
+	return webhook, nil
+}
+
+func post(ctx context.Context, webhook string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return errors.E(ErrNotify, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// strip the URL, which usually contains secrets, from the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return errors.E(ErrNotify, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.E(ErrNotify, "webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// slackMessage returns a Slack message, using the Block Kit format, listing
+// the stacks of the payload.
+func slackMessage(p payload) map[string]any {
+	counts := map[string]int{}
+	for _, st := range p.Stacks {
+		counts[st.Status]++
+	}
+	var parts []string
+	for _, status := range []string{StatusFailed, StatusDrifted} {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	summary := fmt.Sprintf("*%s*: %s of %d stacks", p.Command, strings.Join(parts, ", "), p.Total)
+
+	var lines []string
+	for i, st := range p.Stacks {
+		if i == maxSlackStacks {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(p.Stacks)-maxSlackStacks))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("• `%s`: %s", st.Stack, st.Status))
+	}
+
+	section := func(text string) map[string]any {
+		return map[string]any{
+			"type": "section",
+			"text": map[string]any{
+				"type": "mrkdwn",
+				"text": text,
+			},
+		}
+	}
+	return map[string]any{
+		"text": summary,
+		"blocks": []any{
+			section(summary),
+			section(strings.Join(lines, "\n")),
+		},
+	}
+}
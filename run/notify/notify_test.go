@@ -0,0 +1,158 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/run/notify"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestSend(t *testing.T) {
+	report := notify.Report{
+		Command: "terramate run",
+		Stacks: []notify.StackResult{
+			{Stack: "/stacks/a", Status: notify.StatusOK},
+			{Stack: "/stacks/b", Status: notify.StatusFailed},
+			{Stack: "/stacks/c", Status: notify.StatusDrifted},
+			{Stack: "/stacks/d", Status: notify.StatusCanceled},
+		},
+	}
+
+	type testcase struct {
+		name   string
+		config string
+		report notify.Report
+		want   string // empty if no notification is expected.
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:   "no notifications block",
+			report: report,
+		},
+		{
+			name: "failures by default",
+			config: `notifications {
+			  url = "${env.TM_TEST_WEBHOOK}/hook"
+			}`,
+			report: report,
+			want: `{"command":"terramate run","events":["failure"],"total":4,` +
+				`"stacks":[{"stack":"/stacks/b","status":"failed"}]}`,
+		},
+		{
+			name: "failures and drifts",
+			config: `notifications {
+			  url    = "${env.TM_TEST_WEBHOOK}/hook"
+			  events = ["failure", "drift"]
+			}`,
+			report: report,
+			want: `{"command":"terramate run","events":["failure","drift"],"total":4,` +
+				`"stacks":[{"stack":"/stacks/b","status":"failed"},{"stack":"/stacks/c","status":"drifted"}]}`,
+		},
+		{
+			name: "no matching stacks",
+			config: `notifications {
+			  url    = "${env.TM_TEST_WEBHOOK}/hook"
+			  events = ["drift"]
+			}`,
+			report: notify.Report{
+				Command: "terramate run",
+				Stacks: []notify.StackResult{
+					{Stack: "/stacks/a", Status: notify.StatusFailed},
+				},
+			},
+		},
+		{
+			name: "slack format",
+			config: `notifications {
+			  url    = "${env.TM_TEST_WEBHOOK}/hook"
+			  format = "slack"
+			  events = ["failure", "drift"]
+			}`,
+			report: report,
+			want: `{"blocks":[` +
+				`{"text":{"text":"*terramate run*: 1 failed, 1 drifted of 4 stacks","type":"mrkdwn"},"type":"section"},` +
+				"{\"text\":{\"text\":\"• `/stacks/b`: failed\\n• `/stacks/c`: drifted\",\"type\":\"mrkdwn\"},\"type\":\"section\"}]," +
+				`"text":"*terramate run*: 1 failed, 1 drifted of 4 stacks"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/hook" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				got = string(body)
+			}))
+			defer srv.Close()
+			t.Setenv("TM_TEST_WEBHOOK", srv.URL)
+
+			root := loadRoot(t, tc.config)
+			assert.NoError(t, notify.Send(context.Background(), root, tc.report))
+
+			if tc.want == "" {
+				if got != "" {
+					t.Fatalf("unexpected notification: %s", got)
+				}
+				return
+			}
+			assertJSON(t, got, tc.want)
+		})
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	t.Setenv("TM_TEST_WEBHOOK", srv.URL+"/secret-token")
+
+	root := loadRoot(t, `notifications {
+	  url = env.TM_TEST_WEBHOOK
+	}`)
+	err := notify.Send(context.Background(), root, notify.Report{
+		Command: "terramate run",
+		Stacks:  []notify.StackResult{{Stack: "/stack", Status: notify.StatusFailed}},
+	})
+	assert.IsTrue(t, errors.IsKind(err, notify.ErrNotify), "want ErrNotify, got %v", err)
+	assert.IsTrue(t, !strings.Contains(err.Error(), "secret-token"), "error leaks the URL: %v", err)
+}
+
+func loadRoot(t *testing.T, notifications string) *config.Root {
+	t.Helper()
+
+	s := sandbox.NoGit(t, true)
+	if notifications != "" {
+		s.RootEntry().CreateFile("notifications.tm", "terramate {\n config {\n"+notifications+"\n}\n}\n")
+	}
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	return root
+}
+
+func assertJSON(t *testing.T, got, want string) {
+	t.Helper()
+
+	var gotv, wantv any
+	assert.NoError(t, json.Unmarshal([]byte(got), &gotv), "invalid payload: %s", got)
+	assert.NoError(t, json.Unmarshal([]byte(want), &wantv))
+	if diff := cmp.Diff(wantv, gotv); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
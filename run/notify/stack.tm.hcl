@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package notify // import \"github.com/terramate-io/terramate/run/notify\""
+  description = "package notify // import \"github.com/terramate-io/terramate/run/notify\"\n\nPackage notify sends the results of the run and drift commands to the webhook\nconfigured in the terramate.config.notifications block.\n\nconst StatusOK = \"ok\" ...\nconst ErrNotify errors.Kind = \"sending notification\"\nconst Timeout = 10 * time.Second\nfunc Send(ctx context.Context, root *config.Root, report Report) error\ntype Report struct{ ... }\ntype StackResult struct{ ... }"
+  tags        = ["golang", "notify", "run"]
+  id          = "e501d559-8d9e-42e2-8df7-8535445119bb"
+}
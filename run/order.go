@@ -14,8 +14,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
-	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/run/dag"
+	"github.com/terramate-io/terramate/warnings"
 	"golang.org/x/exp/slices"
 )
 
@@ -94,6 +94,247 @@ func BuildDAGFromStacks[S ~[]E, E any](root *config.Root, items S, getStack func
 	return newD, "", nil
 }
 
+// Levels computes the run order of the given list of stacks grouped by levels.
+// The stacks of each level only depend on stacks of the previous levels, then
+// each level can be executed in parallel as long as the levels are executed in
+// the returned order. The relative order of the items inside a level is the
+// lexicographic order of the stack directories.
+func Levels[S ~[]E, E any](root *config.Root, items S, getStack func(E) *config.Stack) ([]S, string, error) {
+	d, reason, err := buildValidStackDAG(root, items, getStack)
+	if err != nil {
+		return nil, reason, err
+	}
+
+	return reducedLevels(d, items, getStack), "", nil
+}
+
+// Edge is an ordering constraint between two stacks of the run order.
+type Edge struct {
+	// From is the directory of the stack which runs first.
+	From string
+	// To is the directory of the stack which runs after From.
+	To string
+	// Constraint describes the configuration which created the edge.
+	Constraint string
+}
+
+// LevelsWithEdges computes the run order levels like [Levels] and also returns
+// the edges between the given stacks, together with the constraint which
+// created each of them. The edges are sorted by the To and From directories.
+func LevelsWithEdges[S ~[]E, E any](root *config.Root, items S, getStack func(E) *config.Stack) ([]S, []Edge, string, error) {
+	// the parent ordering is appended to the stacks when building the DAG,
+	// then the declared ordering is saved before.
+	declared := make(map[string]orderDecl, len(items))
+	for _, item := range items {
+		st := getStack(item)
+		declared[st.Dir.String()] = orderDecl{
+			before: slices.Clone(st.Before),
+			after:  slices.Clone(st.After),
+		}
+	}
+	declOf := func(st *config.Stack) orderDecl {
+		if decl, ok := declared[st.Dir.String()]; ok {
+			return decl
+		}
+		return orderDecl{before: st.Before, after: st.After}
+	}
+
+	d, reason, err := buildValidStackDAG(root, items, getStack)
+	if err != nil {
+		return nil, nil, reason, err
+	}
+
+	type edgeKey struct{ from, to dag.ID }
+	constraints := map[edgeKey]string{}
+	for _, id := range d.IDs() {
+		to, err := d.Node(id)
+		if err != nil {
+			continue
+		}
+		for _, ancestor := range d.AncestorsOf(id) {
+			from, err := d.Node(ancestor)
+			if err != nil {
+				continue
+			}
+			constraints[edgeKey{ancestor, id}] = orderConstraint(root, from, to, declOf)
+		}
+	}
+
+	levels := reducedLevels(d, items, getStack)
+
+	var edges []Edge
+	seen := map[edgeKey]bool{}
+	for _, id := range d.IDs() {
+		for _, ancestor := range d.AncestorsOf(id) {
+			if seen[edgeKey{ancestor, id}] {
+				continue
+			}
+			seen[edgeKey{ancestor, id}] = true
+			constraint, ok := constraints[edgeKey{ancestor, id}]
+			if !ok {
+				constraint = "transitive, through stacks not selected"
+			}
+			edges = append(edges, Edge{
+				From:       string(ancestor),
+				To:         string(id),
+				Constraint: constraint,
+			})
+		}
+	}
+	slices.SortFunc(edges, func(a, b Edge) int {
+		return cmp.Or(strings.Compare(a.To, b.To), strings.Compare(a.From, b.From))
+	})
+	return levels, edges, "", nil
+}
+
+type orderDecl struct {
+	before []string
+	after  []string
+}
+
+// orderConstraint describes the configuration making the stack from run
+// before the stack to.
+func orderConstraint(root *config.Root, from, to *config.Stack, declOf func(*config.Stack) orderDecl) string {
+	for _, entry := range declOf(from).before {
+		if orderEntryMatches(root, from, entry, to) {
+			return fmt.Sprintf("before %q of %s", entry, from.Dir)
+		}
+	}
+	for _, entry := range declOf(to).after {
+		if orderEntryMatches(root, to, entry, from) {
+			return fmt.Sprintf("after %q of %s", entry, to.Dir)
+		}
+	}
+	if to.Dir.HasPrefix(from.Dir.String() + "/") {
+		return "parent stack"
+	}
+	return "unknown"
+}
+
+// orderEntryMatches tells if the before/after entry of the stack s selects
+// the target stack.
+func orderEntryMatches(root *config.Root, s *config.Stack, entry string, target *config.Stack) bool {
+	if strings.HasPrefix(entry, "tag:") {
+		paths, err := root.StacksByTagsFilters([]string{strings.TrimPrefix(entry, "tag:")})
+		return err == nil && slices.Contains(paths, target.Dir)
+	}
+	return slices.Contains(root.StacksByPaths(s.Dir, entry).Paths(), target.Dir)
+}
+
+func reducedLevels[S ~[]E, E any](d *dag.DAG[*config.Stack], items S, getStack func(E) *config.Stack) []S {
+	itemsByDir := make(map[string]S, len(items))
+	for _, item := range items {
+		dir := getStack(item).Dir.String()
+		itemsByDir[dir] = append(itemsByDir[dir], item)
+	}
+
+	// Remove stacks only pulled in for ordering, so they don't create empty levels.
+	d.Reduce(func(id dag.ID) bool {
+		_, found := itemsByDir[string(id)]
+		return !found
+	})
+
+	var levels []S
+	for _, ids := range d.Levels() {
+		var level S
+		for _, id := range ids {
+			level = append(level, itemsByDir[string(id)]...)
+		}
+		if len(level) > 0 {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// OrderRelation computes the run order of the given list of stacks and returns
+// a function telling if the order between the stacks of the directories a and
+// b is defined, by the before/after attributes or by the stacks hierarchy,
+// directly or transitively.
+func OrderRelation[S ~[]E, E any](root *config.Root, items S, getStack func(E) *config.Stack) (func(a, b string) bool, string, error) {
+	d, reason, err := buildValidStackDAG(root, items, getStack)
+	if err != nil {
+		return nil, reason, err
+	}
+
+	isAncestor := func(id, ancestor dag.ID) bool {
+		visited := dag.Visited{}
+		var walk func(id dag.ID) bool
+		walk = func(id dag.ID) bool {
+			if _, ok := visited[id]; ok {
+				return false
+			}
+			visited[id] = struct{}{}
+			for _, parent := range d.AncestorsOf(id) {
+				if parent == ancestor || walk(parent) {
+					return true
+				}
+			}
+			return false
+		}
+		return walk(id)
+	}
+
+	return func(a, b string) bool {
+		return isAncestor(dag.ID(a), dag.ID(b)) || isAncestor(dag.ID(b), dag.ID(a))
+	}, "", nil
+}
+
+// ErrAmbiguousReverseOrder indicates that the stacks can't be safely executed
+// in reverse order.
+const ErrAmbiguousReverseOrder errors.Kind = "ambiguous reverse run order"
+
+// CheckReverseOrder checks that the given list of stacks can be safely
+// executed in reverse order. A stack which wants (or is wanted by) another
+// selected stack must have its order relative to that stack defined, by the
+// before/after attributes or by the stacks hierarchy, otherwise reversing the
+// run order is ambiguous and an error of kind [ErrAmbiguousReverseOrder] is
+// returned.
+func CheckReverseOrder[S ~[]E, E any](root *config.Root, items S, getStack func(E) *config.Stack) error {
+	ordered, reason, err := OrderRelation(root, items, getStack)
+	if err != nil {
+		if errors.IsKind(err, dag.ErrCycleDetected) {
+			return errors.E(err, "cycle detected: %s", reason)
+		}
+		return err
+	}
+
+	selected := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		selected[getStack(item).Dir.String()] = struct{}{}
+	}
+
+	errs := errors.L()
+	for _, item := range items {
+		st := getStack(item)
+		for _, attr := range []struct {
+			name  string
+			paths []string
+		}{
+			{name: "wants", paths: st.Wants},
+			{name: "wanted_by", paths: st.WantedBy},
+		} {
+			for _, other := range attr.paths {
+				otherDir := other
+				if !path.IsAbs(otherDir) {
+					otherDir = path.Join(st.Dir.String(), otherDir)
+				}
+				otherDir = path.Clean(otherDir)
+				if _, ok := selected[otherDir]; !ok || otherDir == st.Dir.String() {
+					continue
+				}
+				if ordered(st.Dir.String(), otherDir) {
+					continue
+				}
+				errs.Append(errors.E(ErrAmbiguousReverseOrder,
+					"stack %s %s %s but their run order is not defined by the before/after attributes",
+					st.Dir, attr.name, otherDir))
+			}
+		}
+	}
+	return errs.AsError()
+}
+
 func buildValidStackDAG[S ~[]E, E any](
 	root *config.Root,
 	items S,
@@ -217,16 +458,24 @@ func BuildDAG(
 			st, err := os.Stat(abspath)
 			if err != nil {
 				logger.Debug().Str("path", pathstr).Err(err).Msgf("Invalid stack.%s path", fieldname)
-				printer.Stderr.Warn(
+				err := warnings.Emit(warnings.InvalidStackPath,
 					fmt.Sprintf("Stack %s references an invalid path (%s) in the '%s' attribute", s.Dir, pathstr, fieldname),
+					nil,
 				)
+				if err != nil {
+					return nil, err
+				}
 			} else if !st.IsDir() {
-				printer.Stderr.Warn(
+				err := warnings.Emit(warnings.InvalidStackPath,
 					fmt.Sprintf("Stack %s references a path (%s) that is not a directory in the '%s' attribute",
 						s.Dir,
 						pathstr, fieldname,
 					),
+					nil,
 				)
+				if err != nil {
+					return nil, err
+				}
 			} else {
 				uniqPaths[pathstr] = struct{}{}
 			}
@@ -8,8 +8,10 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/madlambda/spells/assert"
 	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/run"
 	"github.com/terramate-io/terramate/test/sandbox"
@@ -65,3 +67,131 @@ func TestStableSortOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		layout  []string
+		wantErr bool
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "no wants",
+			layout: []string{
+				"s:a",
+				"s:b:after=[\"/a\"]",
+			},
+		},
+		{
+			name: "wants with order defined by after",
+			layout: []string{
+				"s:a:wants=[\"/b\"]",
+				"s:b:after=[\"/a\"]",
+			},
+		},
+		{
+			name: "wants with order defined by the hierarchy",
+			layout: []string{
+				"s:a:wants=[\"child\"]",
+				"s:a/child",
+			},
+		},
+		{
+			name: "wants with undefined order",
+			layout: []string{
+				"s:a:wants=[\"/b\"]",
+				"s:b",
+			},
+			wantErr: true,
+		},
+		{
+			name: "wanted_by with undefined order",
+			layout: []string{
+				"s:a",
+				"s:b:wanted_by=[\"/a\"]",
+			},
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := sandbox.NoGit(t, true)
+			s.BuildTree(tc.layout)
+
+			root, err := config.LoadRoot(s.RootDir(), false)
+			assert.NoError(t, err)
+
+			stacks, err := config.LoadAllStacks(root, root.Tree())
+			assert.NoError(t, err)
+
+			err = run.CheckReverseOrder(root, stacks, func(s *config.SortableStack) *config.Stack { return s.Stack })
+			if tc.wantErr {
+				assert.IsTrue(t, errors.IsKind(err, run.ErrAmbiguousReverseOrder), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLevelsWithEdges(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:a:before=[\"/c\"]",
+		"s:b:tags=[\"net\"]",
+		"s:c:after=[\"tag:net\"]",
+		"s:c/child",
+		"s:d:after=[\"/e\"]",
+		"s:e:after=[\"/b\"]",
+	})
+
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+
+	all, err := config.LoadAllStacks(root, root.Tree())
+	assert.NoError(t, err)
+
+	// e is not selected, then d runs after b transitively.
+	var stacks config.List[*config.SortableStack]
+	for _, st := range all {
+		if st.Dir().String() != "/e" {
+			stacks = append(stacks, st)
+		}
+	}
+
+	levels, edges, _, err := run.LevelsWithEdges(root, stacks, func(s *config.SortableStack) *config.Stack { return s.Stack })
+	assert.NoError(t, err)
+
+	var gotLevels [][]string
+	for _, level := range levels {
+		var dirs []string
+		for _, st := range level {
+			dirs = append(dirs, st.Dir().String())
+		}
+		gotLevels = append(gotLevels, dirs)
+	}
+	wantLevels := [][]string{
+		{"/a", "/b"},
+		{"/c", "/d"},
+		{"/c/child"},
+	}
+	if diff := cmp.Diff(wantLevels, gotLevels); diff != "" {
+		t.Fatalf("unexpected levels: %s", diff)
+	}
+
+	wantEdges := []run.Edge{
+		{From: "/a", To: "/c", Constraint: `before "/c" of /a`},
+		{From: "/b", To: "/c", Constraint: `after "tag:net" of /c`},
+		{From: "/a", To: "/c/child", Constraint: `before "/c" of /a`},
+		{From: "/c", To: "/c/child", Constraint: "parent stack"},
+		{From: "/b", To: "/d", Constraint: "transitive, through stacks not selected"},
+	}
+	if diff := cmp.Diff(wantEdges, edges); diff != "" {
+		t.Fatalf("unexpected edges: %s", diff)
+	}
+}
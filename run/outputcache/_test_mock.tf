@@ -0,0 +1,21 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "outputcache" {
+  content = <<-EOF
+package outputcache // import "github.com/terramate-io/terramate/run/outputcache"
+
+Package outputcache implements the cache of the outputs of the stacks shared
+with other stacks, keyed by the lineage and serial of the Terraform state of
+the stack, so the sharing_backend command is only executed again when the state
+changes.
+
+const ErrSave errors.Kind = "saving cached outputs" ...
+const Dir = ".terramate/cache/outputs"
+type Cache struct{ ... }
+    func New(rootdir string) *Cache
+type State struct{ ... }
+    func LocalState(stackdir string) (State, bool, error)
+EOF
+
+  filename = "${path.module}/mock-outputcache.ignore"
+}
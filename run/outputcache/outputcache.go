@@ -0,0 +1,228 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package outputcache implements the cache of the outputs of the stacks
+// shared with other stacks, keyed by the lineage and serial of the Terraform
+// state of the stack, so the sharing_backend command is only executed again
+// when the state changes.
+//
+// The state of the stacks using the local backend is read from the state
+// file, and the state of the stacks using other backends is read through the
+// configured backend with the `state pull` command.
+package outputcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/fs"
+)
+
+// Dir is the directory, relative to the project root, where the outputs are
+// cached.
+const Dir = ".terramate/cache/outputs"
+
+const (
+	// ErrSave indicates that the outputs could not be cached.
+	ErrSave errors.Kind = "saving cached outputs"
+
+	// ErrLoad indicates that the cached outputs could not be loaded.
+	ErrLoad errors.Kind = "loading cached outputs"
+)
+
+// statePullTimeout is the maximum duration of the command reading the state
+// from a remote backend.
+const statePullTimeout = 300 * time.Second
+
+// State identifies a version of the Terraform state of a stack.
+type State struct {
+	Lineage string `json:"lineage"`
+	Serial  int64  `json:"serial"`
+}
+
+// entry is the content of a cache file.
+type entry struct {
+	State   State           `json:"state"`
+	Outputs json.RawMessage `json:"outputs"`
+}
+
+// Cache is the outputs cache of a project.
+type Cache struct {
+	rootdir string
+}
+
+// New returns the outputs cache of the project at rootdir.
+func New(rootdir string) *Cache {
+	return &Cache{rootdir: rootdir}
+}
+
+// StackState returns the version of the Terraform state of the stack at
+// stackdir. The state of the stacks initialized with a backend other than the
+// local one is read through the backend with the `state pull` command of
+// tfcmd (eg.: terraform or tofu), and the state of the other stacks is read
+// with [LocalState]. It returns false if the stack has no state.
+func StackState(stackdir, tfcmd string) (State, bool, error) {
+	remote, err := hasRemoteBackend(stackdir)
+	if err != nil {
+		return State{}, false, err
+	}
+	if remote {
+		return RemoteState(stackdir, tfcmd)
+	}
+	return LocalState(stackdir)
+}
+
+// RemoteState returns the version of the Terraform state of the stack at
+// stackdir read through its configured backend with the `state pull` command
+// of tfcmd. It returns false if the backend has no state for the stack.
+func RemoteState(stackdir, tfcmd string) (State, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), statePullTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, tfcmd, "state", "pull")
+	cmd.Dir = stackdir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return State{}, false, errors.E(ErrLoad, err, "executing %s (stderr: %s)", cmd, stderr.String())
+	}
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return State{}, false, nil
+	}
+	var st State
+	if err := json.Unmarshal(stdout.Bytes(), &st); err != nil {
+		return State{}, false, errors.E(ErrLoad, err, "decoding the state pulled by %s", cmd)
+	}
+	if st.Lineage == "" {
+		return State{}, false, nil
+	}
+	return st, true, nil
+}
+
+// hasRemoteBackend tells if the stack at stackdir is initialized with a
+// backend other than the local one, as recorded by the init command in the
+// .terraform/terraform.tfstate file.
+func hasRemoteBackend(stackdir string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(stackdir, ".terraform", "terraform.tfstate"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.E(ErrLoad, err)
+	}
+	var initState struct {
+		Backend *struct {
+			Type string `json:"type"`
+		} `json:"backend"`
+	}
+	if err := json.Unmarshal(data, &initState); err != nil {
+		return false, errors.E(ErrLoad, err, "decoding the backend of the stack")
+	}
+	return initState.Backend != nil && initState.Backend.Type != "" && initState.Backend.Type != "local", nil
+}
+
+// LocalState returns the version of the local Terraform state of the stack
+// at stackdir, taking the selected workspace into account. It returns false
+// if the stack has no local state. The local state of a stack is the
+// terraform.tfstate file of the default workspace or the
+// terraform.tfstate.d/<workspace>/terraform.tfstate file of the others.
+func LocalState(stackdir string) (State, bool, error) {
+	statefile := filepath.Join(stackdir, "terraform.tfstate")
+	workspace, err := os.ReadFile(filepath.Join(stackdir, ".terraform", "environment"))
+	if err != nil && !os.IsNotExist(err) {
+		return State{}, false, errors.E(ErrLoad, err)
+	}
+	if ws := strings.TrimSpace(string(workspace)); ws != "" && ws != "default" {
+		statefile = filepath.Join(stackdir, "terraform.tfstate.d", ws, "terraform.tfstate")
+	}
+
+	data, err := os.ReadFile(statefile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return State{}, false, nil
+		}
+		return State{}, false, errors.E(ErrLoad, err)
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return State{}, false, errors.E(ErrLoad, err, "decoding %s", statefile)
+	}
+	if st.Lineage == "" {
+		return State{}, false, nil
+	}
+	return st, true, nil
+}
+
+// Get returns the outputs cached for the stack and sharing backend command,
+// if they were cached for the given state.
+func (c *Cache) Get(stack string, command []string, st State) ([]byte, bool, error) {
+	data, err := os.ReadFile(c.path(stack, command))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.E(ErrLoad, err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		// a corrupted entry is a cache miss, it's overwritten by the next Put.
+		return nil, false, nil
+	}
+	if e.State != st {
+		return nil, false, nil
+	}
+	return e.Outputs, true, nil
+}
+
+// Put caches the outputs of the stack and sharing backend command for the
+// given state. The outputs must be valid JSON.
+//
+// The cache file is replaced atomically, so concurrent runs never read a
+// partially written entry. The cache directory is ignored by git, so caching
+// outputs never leaves untracked files in the repository.
+func (c *Cache) Put(stack string, command []string, st State, outputs []byte) error {
+	dir := filepath.Join(c.rootdir, filepath.FromSlash(Dir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.E(ErrSave, err)
+	}
+	gitignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		if err := os.WriteFile(gitignore, []byte("*\n"), 0644); err != nil {
+			return errors.E(ErrSave, err)
+		}
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, outputs); err != nil {
+		return errors.E(ErrSave, err, "invalid outputs")
+	}
+	data, err := json.Marshal(entry{State: st, Outputs: compacted.Bytes()})
+	if err != nil {
+		return errors.E(ErrSave, err)
+	}
+	if err := fs.WriteFileAtomic(c.path(stack, command), append(data, '\n'), 0644, true); err != nil {
+		return errors.E(ErrSave, err)
+	}
+	return nil
+}
+
+// path returns the cache file of the stack and command. The command is part
+// of the key because different backends can produce different outputs.
+func (c *Cache) path(stack string, command []string) string {
+	h := sha256.New()
+	h.Write([]byte(stack))
+	for _, arg := range command {
+		h.Write([]byte{0})
+		h.Write([]byte(arg))
+	}
+	return filepath.Join(c.rootdir, filepath.FromSlash(Dir), hex.EncodeToString(h.Sum(nil))+".json")
+}
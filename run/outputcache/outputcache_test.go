@@ -0,0 +1,130 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package outputcache_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/run/outputcache"
+)
+
+func TestOutputCacheGetPut(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	cache := outputcache.New(rootdir)
+	cmd := []string{"terraform", "output", "-json"}
+	st := outputcache.State{Lineage: "abc", Serial: 2}
+
+	_, found, err := cache.Get("/stack", cmd, st)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "unexpected cached outputs")
+
+	assert.NoError(t, cache.Put("/stack", cmd, st, []byte("{\n  \"a\": 1\n}\n")))
+
+	gitignore, err := os.ReadFile(filepath.Join(rootdir, ".terramate", "cache", "outputs", ".gitignore"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "*\n", string(gitignore))
+
+	got, found, err := cache.Get("/stack", cmd, st)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "outputs not cached")
+	assert.EqualStrings(t, `{"a":1}`, string(got))
+
+	for _, miss := range []struct {
+		stack string
+		cmd   []string
+		state outputcache.State
+	}{
+		{"/stack", cmd, outputcache.State{Lineage: "abc", Serial: 3}},
+		{"/stack", cmd, outputcache.State{Lineage: "def", Serial: 2}},
+		{"/other", cmd, st},
+		{"/stack", []string{"tofu", "output", "-json"}, st},
+	} {
+		_, found, err := cache.Get(miss.stack, miss.cmd, miss.state)
+		assert.NoError(t, err)
+		assert.IsTrue(t, !found, "unexpected cache hit for %v", miss)
+	}
+
+	assert.Error(t, cache.Put("/stack", cmd, st, []byte("not json")))
+
+	// the entries are replaced without leaving temporary files.
+	assert.NoError(t, cache.Put("/stack", cmd, outputcache.State{Lineage: "abc", Serial: 3}, []byte(`{"a":2}`)))
+	entries, err := os.ReadDir(filepath.Join(rootdir, ".terramate", "cache", "outputs"))
+	assert.NoError(t, err)
+	assert.EqualInts(t, 2, len(entries), "unexpected cache files: %v", entries)
+}
+
+func TestOutputCacheLocalState(t *testing.T) {
+	t.Parallel()
+
+	stackdir := t.TempDir()
+	_, found, err := outputcache.LocalState(stackdir)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "unexpected local state")
+
+	writeFile(t, filepath.Join(stackdir, "terraform.tfstate"), `{"version": 4, "serial": 5, "lineage": "abc"}`)
+	st, found, err := outputcache.LocalState(stackdir)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "local state not found")
+	assert.IsTrue(t, st == outputcache.State{Lineage: "abc", Serial: 5}, "unexpected state %v", st)
+
+	writeFile(t, filepath.Join(stackdir, ".terraform", "environment"), "prod")
+	_, found, err = outputcache.LocalState(stackdir)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "unexpected local state for the prod workspace")
+
+	writeFile(t, filepath.Join(stackdir, "terraform.tfstate.d", "prod", "terraform.tfstate"), `{"serial": 1, "lineage": "def"}`)
+	st, found, err = outputcache.LocalState(stackdir)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "local state of the prod workspace not found")
+	assert.IsTrue(t, st == outputcache.State{Lineage: "def", Serial: 1}, "unexpected state %v", st)
+}
+
+func TestOutputCacheStackStateWithRemoteBackend(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake terraform is a shell script")
+	}
+
+	stackdir := t.TempDir()
+	tfcmd := filepath.Join(t.TempDir(), "terraform")
+	writeFile(t, tfcmd, "#!/bin/sh\nif [ -f remote.tfstate ]; then cat remote.tfstate; fi\n")
+	assert.NoError(t, os.Chmod(tfcmd, 0755))
+
+	// a stale local state is ignored once the stack uses a remote backend.
+	writeFile(t, filepath.Join(stackdir, "terraform.tfstate"), `{"serial": 1, "lineage": "local"}`)
+	writeFile(t, filepath.Join(stackdir, ".terraform", "terraform.tfstate"), `{"backend": {"type": "s3"}}`)
+
+	_, found, err := outputcache.StackState(stackdir, tfcmd)
+	assert.NoError(t, err)
+	assert.IsTrue(t, !found, "unexpected state for a remote backend without state")
+
+	writeFile(t, filepath.Join(stackdir, "remote.tfstate"), `{"version": 4, "serial": 7, "lineage": "remote"}`)
+	st, found, err := outputcache.StackState(stackdir, tfcmd)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "remote state not found")
+	assert.IsTrue(t, st == outputcache.State{Lineage: "remote", Serial: 7}, "unexpected state %v", st)
+
+	writeFile(t, filepath.Join(stackdir, ".terraform", "terraform.tfstate"), `{"backend": {"type": "local"}}`)
+	st, found, err = outputcache.StackState(stackdir, tfcmd)
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "local state not found")
+	assert.IsTrue(t, st == outputcache.State{Lineage: "local", Serial: 1}, "unexpected state %v", st)
+
+	writeFile(t, filepath.Join(stackdir, ".terraform", "terraform.tfstate"), `{"backend": {"type": "s3"}}`)
+	_, _, err = outputcache.StackState(stackdir, filepath.Join(t.TempDir(), "missing"))
+	assert.IsError(t, err, errors.E(outputcache.ErrLoad))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package outputcache // import \"github.com/terramate-io/terramate/run/outputcache\""
+  description = "package outputcache // import \"github.com/terramate-io/terramate/run/outputcache\"\n\nPackage outputcache implements the cache of the outputs of the stacks shared\nwith other stacks, keyed by the lineage and serial of the Terraform state of\nthe stack, so the sharing_backend command is only executed again when the state\nchanges.\n\nconst ErrSave errors.Kind = \"saving cached outputs\" ...\nconst Dir = \".terramate/cache/outputs\"\ntype Cache struct{ ... }\n    func New(rootdir string) *Cache\ntype State struct{ ... }\n    func LocalState(stackdir string) (State, bool, error)"
+  tags        = ["golang", "outputcache", "run"]
+  id          = "2fbc9e66-9c06-4f7b-b6be-1300cb0092ef"
+}
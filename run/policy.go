@@ -0,0 +1,72 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run
+
+import (
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+)
+
+// ErrPolicyViolation indicates that a command is not allowed by the
+// terramate.config.run.policy configuration.
+const ErrPolicyViolation errors.Kind = "command not allowed by terramate.config.run.policy"
+
+// CheckPolicy checks if the command is allowed by the given policy.
+// The denied flags are only enforced if ci is false.
+// A nil policy allows any command.
+func CheckPolicy(policy *hcl.RunPolicyConfig, cmd []string, ci bool) error {
+	if policy == nil || len(cmd) == 0 {
+		return nil
+	}
+
+	cmdline := strings.Join(cmd, " ")
+	if len(policy.AllowedCommands) > 0 {
+		allowed := false
+		for _, pattern := range policy.AllowedCommands {
+			g, err := glob.Compile(pattern)
+			if err != nil {
+				return errors.E(ErrPolicyViolation, err, "invalid allowed_commands pattern %q", pattern)
+			}
+			if g.Match(cmdline) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errors.E(ErrPolicyViolation,
+				"%q does not match any of the allowed_commands patterns", cmdline)
+		}
+	}
+
+	if ci {
+		return nil
+	}
+	for _, arg := range cmd[1:] {
+		name, ok := flagName(arg)
+		if !ok {
+			continue
+		}
+		for _, flag := range policy.DeniedFlags {
+			if name == strings.TrimLeft(flag, "-") {
+				return errors.E(ErrPolicyViolation,
+					"flag %s of %q is only allowed in CI/CD", flag, cmdline)
+			}
+		}
+	}
+	return nil
+}
+
+// flagName returns the name of the flag given in the argument, without its
+// leading dashes and value, as -flag, --flag, -flag=value and --flag=value
+// are all the same flag for the Go flag package used by Terraform.
+func flagName(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "-") {
+		return "", false
+	}
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return name, true
+}
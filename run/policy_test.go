@@ -0,0 +1,119 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package run_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/run"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		policy  *hcl.RunPolicyConfig
+		cmd     []string
+		ci      bool
+		wantErr bool
+	}
+
+	policy := &hcl.RunPolicyConfig{
+		AllowedCommands: []string{"terraform *", "tofu plan*"},
+		DeniedFlags:     []string{"-auto-approve"},
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "no policy",
+			cmd:  []string{"rm", "-rf", "/"},
+		},
+		{
+			name:   "allowed command",
+			policy: policy,
+			cmd:    []string{"terraform", "plan"},
+		},
+		{
+			name:   "allowed command with pattern matching arguments",
+			policy: policy,
+			cmd:    []string{"tofu", "plan", "-out=plan.out"},
+		},
+		{
+			name:    "command not allowed",
+			policy:  policy,
+			cmd:     []string{"tofu", "apply"},
+			wantErr: true,
+		},
+		{
+			name:    "denied flag outside CI",
+			policy:  policy,
+			cmd:     []string{"terraform", "apply", "-auto-approve"},
+			wantErr: true,
+		},
+		{
+			name:    "denied flag with value outside CI",
+			policy:  policy,
+			cmd:     []string{"terraform", "apply", "-auto-approve=true"},
+			wantErr: true,
+		},
+		{
+			name:    "denied flag with double dash outside CI",
+			policy:  policy,
+			cmd:     []string{"terraform", "apply", "--auto-approve"},
+			wantErr: true,
+		},
+		{
+			name:    "denied flag with double dash and value outside CI",
+			policy:  policy,
+			cmd:     []string{"terraform", "apply", "--auto-approve=true"},
+			wantErr: true,
+		},
+		{
+			name: "denied double dash flag given with single dash",
+			policy: &hcl.RunPolicyConfig{
+				DeniedFlags: []string{"--auto-approve"},
+			},
+			cmd:     []string{"terraform", "apply", "-auto-approve"},
+			wantErr: true,
+		},
+		{
+			name:   "argument with the denied flag name",
+			policy: policy,
+			cmd:    []string{"terraform", "apply", "auto-approve"},
+		},
+		{
+			name:   "flag prefixed by the denied flag",
+			policy: policy,
+			cmd:    []string{"terraform", "apply", "-auto-approved"},
+		},
+		{
+			name:   "denied flag in CI",
+			policy: policy,
+			cmd:    []string{"terraform", "apply", "-auto-approve"},
+			ci:     true,
+		},
+		{
+			name: "only denied flags",
+			policy: &hcl.RunPolicyConfig{
+				DeniedFlags: []string{"-auto-approve"},
+			},
+			cmd: []string{"make", "deploy"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := run.CheckPolicy(tc.policy, tc.cmd, tc.ci)
+			if tc.wantErr {
+				assert.IsError(t, err, errors.E(run.ErrPolicyViolation))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
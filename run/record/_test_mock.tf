@@ -0,0 +1,23 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "record" {
+  content = <<-EOF
+package record // import "github.com/terramate-io/terramate/run/record"
+
+Package record implements the reproducibility record of a run, which
+fingerprints the environment where the stacks were executed and the order they
+were executed in.
+
+const ErrNotFound errors.Kind = "run record not found" ...
+const Dir = ".terramate/runs"
+func DetectTools(environ []string) map[string]string
+func EnvMap(environ []string) map[string]string
+func NewID(t time.Time) string
+func Save(rootdir string, r Record) (string, error)
+type Record struct{ ... }
+    func Load(rootdir, id string) (Record, error)
+type Stack struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-record.ignore"
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package record implements the reproducibility record of a run, which
+// fingerprints the environment where the stacks were executed and the order
+// they were executed in.
+package record
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/run"
+)
+
+// Dir is the directory, relative to the project root, where run records
+// are saved.
+const Dir = ".terramate/runs"
+
+const (
+	// ErrNotFound indicates that the run record does not exist.
+	ErrNotFound errors.Kind = "run record not found"
+
+	// ErrInvalidID indicates that the run record ID is invalid.
+	ErrInvalidID errors.Kind = "invalid run record ID"
+
+	// ErrSave indicates that the run record could not be saved.
+	ErrSave errors.Kind = "saving run record"
+
+	// ErrLoad indicates that the run record could not be loaded.
+	ErrLoad errors.Kind = "loading run record"
+)
+
+// toolVersionTimeout is the maximum time to wait for a tool to report its version.
+const toolVersionTimeout = 10 * time.Second
+
+// tools are the commands fingerprinted in the record, with the
+// arguments used to obtain their version.
+var tools = []struct {
+	name string
+	args []string
+}{
+	{"terraform", []string{"version"}},
+	{"tofu", []string{"version"}},
+	{"terragrunt", []string{"--version"}},
+}
+
+// Record is the fingerprint of a single run.
+type Record struct {
+	// ID is the unique identifier of the run.
+	ID string `json:"id"`
+	// CreatedAt is the time the run started.
+	CreatedAt time.Time `json:"created_at"`
+	// TerramateVersion is the version of Terramate executing the run.
+	TerramateVersion string `json:"terramate_version"`
+	// GitSHA is the commit checked out when the run started, if any.
+	GitSHA string `json:"git_sha,omitempty"`
+	// Tools maps the detected tools to their reported version.
+	Tools map[string]string `json:"tools,omitempty"`
+	// Command is the command executed in each stack.
+	Command []string `json:"command"`
+	// Reverse tells if the stacks were executed in reverse order.
+	Reverse bool `json:"reverse,omitempty"`
+	// Stacks are the stacks in the order they were executed.
+	Stacks []Stack `json:"stacks"`
+}
+
+// Stack is the record of a single stack of the run.
+type Stack struct {
+	// Dir is the stack directory relative to the project root.
+	Dir string `json:"dir"`
+	// Env maps the variables of the environment resolved from
+	// terramate.config.run.env to their record. The values are not recorded
+	// as they may be secrets, only their hash.
+	Env map[string]EnvVar `json:"env,omitempty"`
+}
+
+// EnvVar is the record of an environment variable of a stack.
+type EnvVar struct {
+	// Origin is the file, relative to the project root, with the effective
+	// definition of the variable.
+	Origin string `json:"origin"`
+	// Hash is the hex encoded SHA-256 of the variable name and value, so a
+	// change of the value can be detected without recording it. It's empty
+	// for sensitive variables, as an unsalted hash of a low entropy secret
+	// can be brute forced.
+	Hash string `json:"hash,omitempty"`
+}
+
+// NewID creates a new run record ID for a run started at the given time.
+// IDs sort lexicographically by their creation time.
+func NewID(t time.Time) string {
+	var suffix [4]byte
+	_, _ = rand.Read(suffix[:])
+	return t.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix[:])
+}
+
+// EnvMap converts the environment variables of a stack to their record,
+// replacing their values by their hash. Only the origin of the sensitive
+// variables is recorded.
+func EnvMap(vars []run.EnvVar) map[string]EnvVar {
+	if len(vars) == 0 {
+		return nil
+	}
+	env := make(map[string]EnvVar, len(vars))
+	for _, v := range vars {
+		rec := EnvVar{Origin: v.Origin.Path().String()}
+		if !v.Sensitive {
+			rec.Hash = envHash(v.Name, v.Value)
+		}
+		env[v.Name] = rec
+	}
+	return env
+}
+
+// envHash hashes the value together with the variable name, so variables
+// with the same value don't have the same hash.
+func envHash(name, value string) string {
+	sum := sha256.Sum256([]byte(name + "=" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// DetectTools returns the version of the supported tools found on the
+// given environment. Tools not found are omitted.
+func DetectTools(environ []string) map[string]string {
+	versions := map[string]string{}
+	for _, tool := range tools {
+		path, err := run.LookPath(tool.name, environ)
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), toolVersionTimeout)
+		cmd := exec.CommandContext(ctx, path, tool.args...)
+		cmd.Env = environ
+		out, err := cmd.Output()
+		cancel()
+		if err != nil {
+			continue
+		}
+		version, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		versions[tool.name] = version
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions
+}
+
+// Save saves the record in the Dir of the given project root and returns
+// the path of the saved file.
+//
+// The records directory is ignored by git, so saving records never leaves
+// untracked files in the repository.
+func Save(rootdir string, r Record) (string, error) {
+	if err := validateID(r.ID); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(rootdir, filepath.FromSlash(Dir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.E(ErrSave, err)
+	}
+	gitignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		if err := os.WriteFile(gitignore, []byte("*\n"), 0644); err != nil {
+			return "", errors.E(ErrSave, err)
+		}
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", errors.E(ErrSave, err)
+	}
+	path := filepath.Join(dir, r.ID+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", errors.E(ErrSave, err)
+	}
+	return path, nil
+}
+
+// Load loads the record with the given ID from the Dir of the given
+// project root.
+func Load(rootdir, id string) (Record, error) {
+	if err := validateID(id); err != nil {
+		return Record{}, err
+	}
+	path := filepath.Join(rootdir, filepath.FromSlash(Dir), id+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Record{}, errors.E(ErrNotFound, "run %s", id)
+		}
+		return Record{}, errors.E(ErrLoad, err)
+	}
+	var r Record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Record{}, errors.E(ErrLoad, err, "decoding %s", path)
+	}
+	return r, nil
+}
+
+func validateID(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return errors.E(ErrInvalidID, "%q", id)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package record_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/info"
+	"github.com/terramate-io/terramate/run"
+	"github.com/terramate-io/terramate/run/record"
+)
+
+func TestRecordSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	createdAt := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	want := record.Record{
+		ID:               record.NewID(createdAt),
+		CreatedAt:        createdAt,
+		TerramateVersion: "0.1.0",
+		GitSHA:           "abc",
+		Tools:            map[string]string{"terraform": "Terraform v1.5.7"},
+		Command:          []string{"terraform", "plan"},
+		Stacks: []record.Stack{
+			{Dir: "/stack2"},
+			{Dir: "/stack1", Env: map[string]record.EnvVar{
+				"A": {Origin: "/terramate.tm.hcl"},
+				"B": {Origin: "/stack1/env.tm.hcl"},
+			}},
+		},
+	}
+
+	assert.IsTrue(t, strings.HasPrefix(want.ID, "20260102T030405Z-"), "unexpected ID %s", want.ID)
+
+	path, err := record.Save(rootdir, want)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, filepath.Join(rootdir, ".terramate", "runs", want.ID+".json"), path)
+
+	gitignore, err := os.ReadFile(filepath.Join(filepath.Dir(path), ".gitignore"))
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "*\n", string(gitignore))
+
+	got, err := record.Load(rootdir, want.ID)
+	assert.NoError(t, err)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
+	}
+}
+
+func TestRecordEnvMapHashesValues(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+	origin := info.NewRange(rootdir, hcl.Range{
+		Filename: filepath.Join(rootdir, "stack", "env.tm.hcl"),
+		Start:    hcl.Pos{Line: 1, Column: 1},
+		End:      hcl.Pos{Line: 1, Column: 10},
+	})
+	got := record.EnvMap([]run.EnvVar{
+		{Name: "TOKEN", Value: "s3cr3t", Sensitive: true, Origin: origin},
+		{Name: "REGION", Value: "eu-west-1", Origin: origin},
+		{Name: "OTHER", Value: "eu-west-1", Origin: origin},
+	})
+	sum := sha256.Sum256([]byte("REGION=eu-west-1"))
+	want := map[string]record.EnvVar{
+		"TOKEN":  {Origin: "/stack/env.tm.hcl"},
+		"REGION": {Origin: "/stack/env.tm.hcl", Hash: hex.EncodeToString(sum[:])},
+	}
+	for name, w := range want {
+		if diff := cmp.Diff(w, got[name]); diff != "" {
+			t.Fatalf("%s: -(want) +(got):\n%s", name, diff)
+		}
+	}
+	assert.IsTrue(t, got["REGION"].Hash != got["OTHER"].Hash, "variables with the same value must have different hashes")
+
+	changed := record.EnvMap([]run.EnvVar{
+		{Name: "REGION", Value: "us-east-1", Origin: origin},
+	})
+	assert.IsTrue(t, got["REGION"].Hash != changed["REGION"].Hash, "changed value must have a different hash")
+}
+
+func TestRecordLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	rootdir := t.TempDir()
+
+	_, err := record.Load(rootdir, "20260102T030405Z-00000000")
+	assert.IsError(t, err, errors.E(record.ErrNotFound))
+
+	for _, id := range []string{"", "..", "../stack", `a\b`} {
+		_, err := record.Load(rootdir, id)
+		assert.IsError(t, err, errors.E(record.ErrInvalidID))
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package record // import \"github.com/terramate-io/terramate/run/record\""
+  description = "package record // import \"github.com/terramate-io/terramate/run/record\"\n\nPackage record implements the reproducibility record of a run, which\nfingerprints the environment where the stacks were executed and the order they\nwere executed in.\n\nconst ErrNotFound errors.Kind = \"run record not found\" ...\nconst Dir = \".terramate/runs\"\nfunc DetectTools(environ []string) map[string]string\nfunc EnvMap(environ []string) map[string]string\nfunc NewID(t time.Time) string\nfunc Save(rootdir string, r Record) (string, error)\ntype Record struct{ ... }\n    func Load(rootdir, id string) (Record, error)\ntype Stack struct{ ... }"
+  tags        = ["golang", "record", "run"]
+  id          = "3024d15a-0246-42b6-8660-cffd105ad0a9"
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "safeguard" {
-  content = <<-EOT
+  content = <<-EOF
 package safeguard // import "github.com/terramate-io/terramate/safeguard"
 
 Package safeguard provides types and methods for dealing with safeguards
@@ -11,7 +11,7 @@ type Keyword string
     const All Keyword = "all" ...
 type Keywords []Keyword
     func FromStrings(strs []string) (Keywords, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-safeguard.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "scheduler" {
-  content = <<-EOT
+  content = <<-EOF
 package scheduler // import "github.com/terramate-io/terramate/scheduler"
 
 Package scheduler defines schedulers to execute functions on DAGs based on a
@@ -13,7 +13,7 @@ type Parallel[V any] struct{ ... }
 type S[V any] interface{ ... }
 type Sequential[V any] struct{ ... }
     func NewSequential[V any](d *dag.DAG[V], reverse bool) *Sequential[V]
-EOT
+EOF
 
   filename = "${path.module}/mock-scheduler.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "resource" {
-  content = <<-EOT
+  content = <<-EOF
 package resource // import "github.com/terramate-io/terramate/scheduler/resource"
 
 Package resource defines different concurrent access strategies for resources.
@@ -11,7 +11,7 @@ type Bounded struct{ ... }
 type R interface{ ... }
 type Throttled struct{ ... }
     func NewThrottled(requestsPerSecond int64) *Throttled
-EOT
+EOF
 
   filename = "${path.module}/mock-resource.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "stack" {
-  content = <<-EOT
+  content = <<-EOF
 package stack // import "github.com/terramate-io/terramate/stack"
 
 Package stack defines all functionality around stacks, like loading, listing all
@@ -23,7 +23,7 @@ type Manager struct{ ... }
     func NewManager(root *config.Root) *Manager
 type RepoChecks struct{ ... }
 type Report struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-stack.ignore"
 }
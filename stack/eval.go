@@ -6,7 +6,6 @@ package stack
 import (
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/hcl/eval"
-	"github.com/terramate-io/terramate/stdlib"
 )
 
 // EvalCtx represents the evaluation context of a stack.
@@ -18,19 +17,22 @@ type EvalCtx struct {
 
 // NewEvalCtx creates a new stack evaluation context.
 func NewEvalCtx(root *config.Root, stack *config.Stack, globals *eval.Object) *EvalCtx {
-	evalctx := eval.NewContext(stdlib.Functions(stack.HostDir(root), root.Tree().Node.Experiments()))
+	evalctx := root.NewEvalContext(stack.HostDir(root))
 	evalwrapper := &EvalCtx{
 		Context: evalctx,
 		root:    root,
 	}
 	evalwrapper.SetMetadata(stack)
 	evalwrapper.SetGlobals(globals)
+	evalwrapper.SetDefinitionDir("global", stack.Dir)
 	return evalwrapper
 }
 
 // SetGlobals sets the given globals in the stack evaluation context.
 func (e *EvalCtx) SetGlobals(g *eval.Object) {
 	e.SetNamespace("global", g.AsValueMap())
+	e.DeprecateFrom("global", g)
+	e.SensitiveFrom("global", g)
 }
 
 // SetMetadata sets the given metadata in the stack evaluation context.
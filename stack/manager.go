@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
-	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -16,13 +15,13 @@ import (
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/git"
-	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
 	"github.com/terramate-io/terramate/run"
 	"github.com/terramate-io/terramate/run/dag"
 	"github.com/terramate-io/terramate/stack/trigger"
 	"github.com/terramate-io/terramate/tf"
 	"github.com/terramate-io/terramate/tg"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 type (
@@ -186,6 +185,9 @@ func (m *Manager) ListChanged(cfg ChangeConfig) (*Report, error) {
 	stackSet := map[project.Path]Entry{}
 	ignoreSet := map[project.Path]struct{}{}
 
+	// changed files not in the working tree and not inside a stack.
+	var notMaterialized project.Paths
+
 	for _, projpath := range changedFiles {
 		logger = logger.With().
 			Stringer("path", projpath).
@@ -218,7 +220,10 @@ func (m *Manager) ListChanged(cfg ChangeConfig) (*Report, error) {
 			}
 
 			if triggerInfo.Type != trigger.Changed {
-				printer.Stderr.Warnf("skipping unsupported trigger type: %s", triggerInfo.Type)
+				err := warnings.Emit(warnings.UnsupportedTrigger, fmt.Sprintf("skipping unsupported trigger type: %s", triggerInfo.Type), nil)
+				if err != nil {
+					return nil, err
+				}
 				continue
 			}
 
@@ -228,7 +233,7 @@ func (m *Manager) ListChanged(cfg ChangeConfig) (*Report, error) {
 				continue
 			}
 
-			s, err := config.NewStackFromHCL(m.root.HostDir(), cfg.Node)
+			s, err := config.LoadStack(m.root, cfg.Dir())
 			if err != nil {
 				return nil, errors.E(ErrListChanged, err)
 			}
@@ -259,11 +264,14 @@ func (m *Manager) ListChanged(cfg ChangeConfig) (*Report, error) {
 				}
 			}
 			if !found || !stackTree.IsStack() {
+				if _, err := os.Lstat(abspath); errors.Is(err, fs.ErrNotExist) {
+					notMaterialized = append(notMaterialized, projpath)
+				}
 				continue
 			}
 		}
 
-		s, err := config.NewStackFromHCL(m.root.HostDir(), stackTree.Node)
+		s, err := config.LoadStack(m.root, stackTree.Dir())
 		if err != nil {
 			return nil, errors.E(ErrListChanged, err)
 		}
@@ -274,6 +282,10 @@ func (m *Manager) ListChanged(cfg ChangeConfig) (*Report, error) {
 		}
 	}
 
+	if err := m.warnSparseExcluded(notMaterialized); err != nil {
+		return nil, errors.E(ErrListChanged, err)
+	}
+
 	allstacks, err := m.allStacks()
 	if err != nil {
 		return nil, err
@@ -303,9 +315,26 @@ rangeStacks:
 			continue rangeStacks
 		}
 
+		if changed, ok := hasChangedTemplateFiles(stack, changedFiles); ok {
+			logger.Debug().
+				Stringer("stack", stack).
+				Stringer("templatefile", changed).
+				Msg("changed.")
+
+			stack.IsChanged = true
+			stackSet[stack.Dir] = Entry{
+				Stack: stack,
+				Reason: fmt.Sprintf(
+					"stack changed because file %q of the extended template changed",
+					changed,
+				),
+			}
+			continue rangeStacks
+		}
+
 		// Terraform module change detection
 		err := m.filesApply(stack.Dir, func(fname string) error {
-			if path.Ext(fname) != ".tf" {
+			if !tf.IsConfigFile(fname) {
 				return nil
 			}
 
@@ -468,16 +497,19 @@ func (m *Manager) AddWantedOf(scopeStacks config.List[*config.SortableStack]) (c
 	reason, err := wantsDag.Validate()
 	if err != nil {
 		if errors.IsKind(err, dag.ErrCycleDetected) {
-			printer.Stderr.WarnWithDetails(
+			err = warnings.Emit(warnings.StackSelection,
 				"Stack selection clauses (wants/wanted_by) have cycles",
 				errors.E(reason, err),
 			)
 		} else {
-			printer.Stderr.WarnWithDetails(
+			err = warnings.Emit(warnings.StackSelection,
 				"Stack selection clauses (wants/wanted_by) have errors",
 				err,
 			)
 		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var selectedStacks config.List[*config.SortableStack]
@@ -580,11 +612,11 @@ func (m *Manager) tfModuleChanged(
 	modAbsPath := filepath.Join(basedir, mod.Source)
 	rootdir := m.root.Tree().RootDir()
 	if modAbsPath != rootdir && !strings.HasPrefix(modAbsPath, rootdir+string(filepath.Separator)) {
-		printer.Stderr.WarnWithDetails("skipping module call outside of the root directory", errors.E(
+		err := warnings.Emit(warnings.OutsideProject, "skipping module call outside of the root directory", errors.E(
 			"module at %q references path %s (abspath %s) outside of the project root",
 			basedir, mod.Source, modAbsPath,
 		))
-		return false, "", nil
+		return false, "", err
 	}
 	modPath := project.PrjAbsPath(m.root.HostDir(), modAbsPath)
 
@@ -592,7 +624,14 @@ func (m *Manager) tfModuleChanged(
 
 	// TODO(i4k): resolve symlinks
 
-	if err != nil || !st.IsDir() {
+	sparseExcluded := false
+	if errors.Is(err, fs.ErrNotExist) {
+		sparseExcluded, err = m.isSparseExcluded(modPath)
+		if err != nil {
+			return false, "", err
+		}
+	}
+	if !sparseExcluded && (err != nil || !st.IsDir()) {
 		return false, "", errors.E("\"source\" path %q is not a directory", modAbsPath)
 	}
 
@@ -606,13 +645,22 @@ func (m *Manager) tfModuleChanged(
 		}
 	}
 
+	if sparseExcluded {
+		// WHY: the files of the module are not in the working tree, then the
+		// modules it uses cannot be checked.
+		log.Debug().
+			Stringer("module", modPath).
+			Msg("module is excluded by the sparse checkout, skipping its module calls")
+		return false, "", nil
+	}
+
 	visited[mod.Source] = true
 
 	err = m.filesApply(modPath, func(fname string) error {
 		if changed {
 			return nil
 		}
-		if path.Ext(fname) != ".tf" {
+		if !tf.IsConfigFile(fname) {
 			return nil
 		}
 
@@ -776,6 +824,43 @@ func (m *Manager) listChangedFiles(dir string, gitBaseRef string) (project.Paths
 	return paths, nil
 }
 
+// isSparseExcluded tells if the path is tracked but not materialized in the
+// working tree because it's excluded by a sparse checkout.
+func (m *Manager) isSparseExcluded(path project.Path) (bool, error) {
+	excluded, err := m.git.SparseExcludedFiles(repoRelPath(path))
+	if err != nil {
+		return false, errors.E(err, "checking if %s is excluded by the sparse checkout", path)
+	}
+	return len(excluded) > 0, nil
+}
+
+// warnSparseExcluded warns about the changed files excluded by a sparse
+// checkout, whose stacks cannot be listed as changed.
+func (m *Manager) warnSparseExcluded(files project.Paths) error {
+	if len(files) == 0 {
+		return nil
+	}
+	relpaths := make([]string, len(files))
+	for i, file := range files {
+		relpaths[i] = repoRelPath(file)
+	}
+	excluded, err := m.git.SparseExcludedFiles(relpaths...)
+	if err != nil {
+		return errors.E(err, "checking the changed files excluded by the sparse checkout")
+	}
+	if len(excluded) > 0 {
+		return warnings.Emit(warnings.SkippedStack,
+			fmt.Sprintf("%d changed files are excluded by the sparse checkout, the stacks outside of it are not listed", len(excluded)),
+			errors.E("excluded files: %s", strings.Join(excluded, ", ")),
+		)
+	}
+	return nil
+}
+
+func repoRelPath(path project.Path) string {
+	return strings.TrimPrefix(path.String(), "/")
+}
+
 func hasChangedWatchedFiles(stack *config.Stack, changedFiles project.Paths) (project.Path, bool) {
 	for _, watchFile := range stack.Watch {
 		for _, file := range changedFiles {
@@ -787,6 +872,18 @@ func hasChangedWatchedFiles(stack *config.Stack, changedFiles project.Paths) (pr
 	return project.Path{}, false
 }
 
+func hasChangedTemplateFiles(stack *config.Stack, changedFiles project.Paths) (project.Path, bool) {
+	if stack.Extends == (project.Path{}) {
+		return project.Path{}, false
+	}
+	for _, file := range changedFiles {
+		if file.Dir() == stack.Extends {
+			return file, true
+		}
+	}
+	return project.Path{}, false
+}
+
 func checkRepoIsClean(g *git.Git) (RepoChecks, error) {
 	untracked, uncommitted, err := g.ListDirtyFiles()
 	if err != nil {
@@ -161,6 +161,20 @@ func TestListChangedStacks(t *testing.T) {
 				changed: []string{"/stack2"},
 			},
 		},
+		{
+			name: "multiple stack: single Terraform Stacks component changed",
+			repobuilder: func(t *testing.T) repository {
+				return multipleStackOneChangedModuleIn(t, "components.tfstack.hcl", `
+component "something" {
+	source = "../modules/module1"
+}
+`)
+			},
+			want: listTestResult{
+				list:    []string{"/stack1", "/stack2"},
+				changed: []string{"/stack2"},
+			},
+		},
 		{
 			name:        "single Terragrunt stack with no changes",
 			repobuilder: singleTerragruntStackWithNoChangesRepo,
@@ -242,6 +256,14 @@ func TestListChangedStacks(t *testing.T) {
 				changed: []string{"/tg-stack"},
 			},
 		},
+		{
+			name:        "Terraform stack with Terragrunt config changed due to included file changed",
+			repobuilder: terraformStackChangedDueToTerragruntIncludeChangedRepo,
+			want: listTestResult{
+				list:    []string{"/other-stack", "/stack"},
+				changed: []string{"/stack"},
+			},
+		},
 		// NOTE(i4k): The testcases below ensure dependant modules are not mark as changed when the dependency changes.
 		// In the future, the dependencies will mark the dependant as changed if a flag is provided.
 		{
@@ -610,6 +632,14 @@ module "something" {
 }
 
 func multipleStackOneChangedModule(t *testing.T) repository {
+	return multipleStackOneChangedModuleIn(t, "main.tf", `
+module "something" {
+	source = "../modules/module1"
+}
+`)
+}
+
+func multipleStackOneChangedModuleIn(t *testing.T, filename, content string) repository {
 	repo := singleMergeCommitRepoNoStack(t)
 
 	g := test.NewGitWrapper(t, repo.Dir, []string{})
@@ -638,14 +668,10 @@ func multipleStackOneChangedModule(t *testing.T) repository {
 	modules := test.Mkdir(t, repo.Dir, "modules")
 	module := test.Mkdir(t, modules, "module1")
 
-	mainFile := test.WriteFile(t, otherStack, "main.tf", `
-module "something" {
-	source = "../modules/module1"
-}
-`)
+	mainFile := test.WriteFile(t, otherStack, filename, content)
 
-	assert.NoError(t, g.Add(mainFile), "add main.tf")
-	assert.NoError(t, g.Commit("add main.tf"), "commit main.tf")
+	assert.NoError(t, g.Add(mainFile), "add %s", filename)
+	assert.NoError(t, g.Commit("add "+filename), "commit %s", filename)
 
 	addMergeCommit(t, repo.Dir, "testbranch")
 	assert.NoError(t, g.DeleteBranch("testbranch"), "delete temp branch")
@@ -1326,6 +1352,55 @@ func terragruntStackChangedDueToReferencedFileChangedRepo(t *testing.T) reposito
 	return repo
 }
 
+// terraformStackChangedDueToTerragruntIncludeChangedRepo builds a repository
+// migrating from Terragrunt, where the stack has its own Terraform files and
+// a Terragrunt config without terraform.source including a shared file.
+func terraformStackChangedDueToTerragruntIncludeChangedRepo(t *testing.T) repository {
+	repo := singleMergeCommitRepoNoStack(t)
+	stack := test.Mkdir(t, repo.Dir, "stack")
+	otherStack := test.Mkdir(t, repo.Dir, "other-stack")
+
+	root, err := config.LoadRoot(repo.Dir, false)
+	assert.NoError(t, err)
+	createStack(t, root, stack)
+	createStack(t, root, otherStack)
+
+	test.WriteFile(t, stack, "main.tf", "# empty file")
+	test.WriteFile(t, stack, "terragrunt.hcl", Doc(
+		Block("include",
+			Labels("root"),
+			Expr("path", `find_in_parent_folders("root.hcl")`),
+		),
+	).String())
+	test.WriteFile(t, otherStack, "main.tf", "# empty file")
+
+	test.WriteFile(t, repo.Dir, "root.hcl", Doc(
+		Block("remote_state",
+			Str("backend", "local"),
+		),
+	).String())
+
+	g := test.NewGitWrapper(t, repo.Dir, []string{})
+	assert.NoError(t, g.Checkout("testbranch", true), "create branch failed")
+	assert.NoError(t, g.Add(repo.Dir), "add files")
+	assert.NoError(t, g.Commit("files"), "commit files")
+
+	addMergeCommit(t, repo.Dir, "testbranch")
+	assert.NoError(t, g.DeleteBranch("testbranch"), "delete testbranch")
+
+	// now we branch again and modify the included file
+	assert.NoError(t, g.Checkout("testbranch2", true), "create branch testbranch2 failed")
+	test.WriteFile(t, repo.Dir, "root.hcl", Doc(
+		Block("remote_state",
+			Str("backend", "s3"),
+		),
+	).String())
+	assert.NoError(t, g.Add(repo.Dir), "add files")
+	assert.NoError(t, g.Commit("files"), "commit files")
+
+	return repo
+}
+
 func newManager(t *testing.T, basedir string) *stack.Manager {
 	root, err := config.LoadRoot(basedir, true)
 	assert.NoError(t, err)
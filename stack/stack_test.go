@@ -4,13 +4,16 @@
 package stack_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/madlambda/spells/assert"
 	"github.com/rs/zerolog"
+	"github.com/terramate-io/terramate"
 	"github.com/terramate-io/terramate/config"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/test/sandbox"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 func TestLoadAllFailsIfStacksIDIsNotUnique(t *testing.T) {
@@ -26,6 +29,90 @@ func TestLoadAllFailsIfStacksIDIsNotUnique(t *testing.T) {
 	assert.IsError(t, err, errors.E(config.ErrStackDuplicatedID))
 }
 
+func TestLoadAllReportsAllStackViolations(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/stack-1:id=id-1",
+		"s:stacks/stack-2:id=ID-1",
+		"s:stacks/stack-3:id=id-2",
+		"s:stacks/stack-4:id=id-2",
+		"s:stacks/stack-5:tags=[\"Invalid\"]",
+		"s:disabled",
+		"s:disabled/child",
+		"s:disabled/dir/grandchild",
+		"f:disabled/" + terramate.SkipFilename,
+		"s:skipped/nested",
+		"f:skipped/" + terramate.SkipFilename,
+	})
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	collector := &warnings.Collector{}
+	root.SetWarnings(collector)
+	_, err = config.LoadAllStacks(root, root.Tree())
+
+	var errs *errors.List
+	if !errors.As(err, &errs) {
+		t.Fatalf("want error list, got %v", err)
+	}
+	assert.EqualInts(t, 3, len(errs.Errors()), "want all violations: %s", errs.Detailed())
+	assert.IsError(t, errs.Errors()[0], errors.E(config.ErrStackValidation))
+	assert.IsError(t, errs.Errors()[1], errors.E(config.ErrStackDuplicatedID))
+	assert.IsError(t, errs.Errors()[2], errors.E(config.ErrStackDuplicatedID))
+
+	var skipped []string
+	for _, w := range collector.Warnings() {
+		if w.Code == warnings.SkippedSubtree {
+			skipped = append(skipped, w.Message)
+		}
+	}
+	assert.EqualInts(t, 3, len(skipped), "want a warning per nested stack: %v", skipped)
+	assert.IsTrue(t, strings.Contains(skipped[0], "stack /disabled/child is ignored because /disabled has"))
+	assert.IsTrue(t, strings.Contains(skipped[1], "stack /disabled/dir/grandchild is ignored because /disabled has"))
+	assert.IsTrue(t, strings.Contains(skipped[2], "stack /skipped/nested is ignored because /skipped has"))
+}
+
+func TestLoadAllPromotesStacksInSkippedDir(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:terramate.tm.hcl:terramate {
+		  config {
+		    warnings {
+		      as_errors = ["skipped-subtree"]
+		    }
+		  }
+		}`,
+		"s:stacks/stack-1",
+		"s:disabled/child",
+		"f:disabled/" + terramate.SkipFilename,
+	})
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	root.SetWarnings(&warnings.Collector{})
+	_, err = config.LoadAllStacks(root, root.Tree())
+	assert.IsError(t, err, errors.E(config.ErrStackInSkippedDir))
+	assert.IsTrue(t, strings.Contains(err.Error(), "/disabled/child"), "error must name the stack: %v", err)
+}
+
+func TestLoadAllAllowsSkippedStackDir(t *testing.T) {
+	t.Parallel()
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/stack-1",
+		"s:stacks/stack-2",
+		"f:stacks/stack-2/" + terramate.SkipFilename,
+		"s:skipped/nested",
+		"f:skipped/nested/" + terramate.SkipFilename,
+		"f:skipped/" + terramate.SkipFilename,
+	})
+	root, err := config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	stacks, err := config.LoadAllStacks(root, root.Tree())
+	assert.NoError(t, err)
+	assert.EqualInts(t, 1, len(stacks))
+}
+
 func init() {
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "trigger" {
-  content = <<-EOT
+  content = <<-EOF
 package trigger // import "github.com/terramate-io/terramate/stack/trigger"
 
 Package trigger provides functionality that help manipulate stacks triggers.
@@ -16,7 +16,7 @@ type Info struct{ ... }
     func ParseFile(path string) (Info, error)
 type Kind string
     const Changed Kind = "changed" ...
-EOT
+EOF
 
   filename = "${path.module}/mock-trigger.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "stdlib" {
-  content = <<-EOT
+  content = <<-EOF
 package stdlib // import "github.com/terramate-io/terramate/stdlib"
 
 Package stdlib implements the Terramate language functions.
@@ -22,7 +22,7 @@ func TomlEncode() function.Function
 func TryFunc() function.Function
 func VendorFunc(basedir, vendordir project.Path, stream chan<- event.VendorRequest) function.Function
 func VersionMatch() function.Function
-EOT
+EOF
 
   filename = "${path.module}/mock-stdlib.ignore"
 }
@@ -0,0 +1,148 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// ErrNonDeterministic indicates a function depending on an unpinned time or
+// randomness source was called in strict mode.
+const ErrNonDeterministic errors.Kind = "non-deterministic function"
+
+// Determinism pins the time and randomness sources of the functions, so the
+// code generation is reproducible, eg.: in CI.
+type Determinism struct {
+	// Timestamp is the RFC3339 timestamp returned by tm_timestamp. If empty,
+	// tm_timestamp returns the current time.
+	Timestamp string
+
+	// RandomSeed is the seed of tm_random. If empty, a random seed is chosen
+	// once per process.
+	RandomSeed string
+
+	// Strict makes the functions depending on unpinned sources fail with
+	// [ErrNonDeterministic], which is used when checking the generated code.
+	Strict bool
+}
+
+// nonDeterministicFunctions are the functions which can't be pinned and
+// always fail in strict mode, with the deterministic alternative.
+var nonDeterministicFunctions = map[string]string{
+	"tm_uuid": "tm_uuidv5",
+}
+
+var (
+	processSeedOnce sync.Once
+	processSeed     string
+)
+
+// Deterministic returns the functions with the time and randomness sources
+// pinned as configured.
+func Deterministic(funcs map[string]function.Function, det Determinism) map[string]function.Function {
+	funcs["tm_timestamp"] = TimestampFunc(det)
+	funcs["tm_random"] = RandomFunc(det)
+	if det.Strict {
+		for name, alternative := range nonDeterministicFunctions {
+			if _, ok := funcs[name]; ok {
+				funcs[name] = nonDeterministicFunc(name,
+					fmt.Sprintf("use %s instead", alternative))
+			}
+		}
+	}
+	return funcs
+}
+
+// TimestampFunc returns the tm_timestamp function, which returns the pinned
+// timestamp or the current time in RFC3339 format.
+func TimestampFunc(det Determinism) function.Function {
+	return function.New(&function.Spec{
+		Description: "Returns the pinned timestamp, or the current time, in RFC3339 format.",
+		Params:      []function.Parameter{},
+		Type:        function.StaticReturnType(cty.String),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			if det.Timestamp == "" {
+				if det.Strict {
+					return cty.NilVal, errors.E(ErrNonDeterministic,
+						"tm_timestamp: pin the timestamp with terramate.config.generate.timestamp or TM_TIMESTAMP")
+				}
+				return cty.StringVal(time.Now().UTC().Format(time.RFC3339)), nil
+			}
+			ts, err := time.Parse(time.RFC3339, det.Timestamp)
+			if err != nil {
+				return cty.NilVal, errors.E(err, "tm_timestamp: invalid pinned timestamp %q", det.Timestamp)
+			}
+			return cty.StringVal(ts.UTC().Format(time.RFC3339)), nil
+		},
+	})
+}
+
+// RandomFunc returns the tm_random function, which returns a pseudo-random
+// number in the [0, 1) interval derived from the given seed and the random
+// seed of the project. The same seed always returns the same number while the
+// random seed of the project is the same.
+func RandomFunc(det Determinism) function.Function {
+	return function.New(&function.Spec{
+		Description: "Returns a pseudo-random number in the [0, 1) interval derived from the seed.",
+		Params: []function.Parameter{
+			{
+				Name: "seed",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.Number),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			source := det.RandomSeed
+			if source == "" {
+				if det.Strict {
+					return cty.NilVal, errors.E(ErrNonDeterministic,
+						"tm_random: pin the random seed with terramate.config.generate.random_seed or TM_RANDOM_SEED")
+				}
+				source = getProcessSeed()
+			}
+			sum := sha256.Sum256([]byte(source + "\x00" + args[0].AsString()))
+			// the 53 most significant bits are exactly representable as a float64.
+			n := binary.BigEndian.Uint64(sum[:8]) >> 11
+			return cty.NumberFloatVal(float64(n) / (1 << 53)), nil
+		},
+	})
+}
+
+func nonDeterministicFunc(name, hint string) function.Function {
+	return function.New(&function.Spec{
+		Description: fmt.Sprintf("The %s function is not deterministic.", name),
+		VarParam: &function.Parameter{
+			Name:             "args",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.NilVal, errors.E(ErrNonDeterministic, "%s: %s", name, hint)
+		},
+	})
+}
+
+func getProcessSeed() string {
+	processSeedOnce.Do(func() {
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(errors.E(errors.ErrInternal, err, "reading random seed"))
+		}
+		processSeed = hex.EncodeToString(b[:])
+	})
+	return processSeed
+}
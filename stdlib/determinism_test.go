@@ -0,0 +1,101 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/test"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestStdlibDeterminism(t *testing.T) {
+	t.Parallel()
+
+	evalExpr := func(t *testing.T, det stdlib.Determinism, expr string) (cty.Value, error) {
+		t.Helper()
+		funcs := stdlib.Deterministic(stdlib.Functions(test.TempDir(t), nil), det)
+		return eval.NewContext(funcs).Eval(test.NewExpr(t, expr))
+	}
+
+	t.Run("pinned timestamp", func(t *testing.T) {
+		t.Parallel()
+		det := stdlib.Determinism{Timestamp: "2026-01-02T05:04:05+02:00", Strict: true}
+		val, err := evalExpr(t, det, `tm_timestamp()`)
+		assert.NoError(t, err)
+		assert.EqualStrings(t, "2026-01-02T03:04:05Z", val.AsString())
+	})
+
+	t.Run("unpinned timestamp", func(t *testing.T) {
+		t.Parallel()
+		val, err := evalExpr(t, stdlib.Determinism{}, `tm_timestamp()`)
+		assert.NoError(t, err)
+		_, err = time.Parse(time.RFC3339, val.AsString())
+		assert.NoError(t, err)
+
+		_, err = evalExpr(t, stdlib.Determinism{Strict: true}, `tm_timestamp()`)
+		assertNonDeterministic(t, err)
+	})
+
+	t.Run("invalid pinned timestamp", func(t *testing.T) {
+		t.Parallel()
+		_, err := evalExpr(t, stdlib.Determinism{Timestamp: "now"}, `tm_timestamp()`)
+		assert.Error(t, err)
+	})
+
+	t.Run("pinned random", func(t *testing.T) {
+		t.Parallel()
+		det := stdlib.Determinism{RandomSeed: "ci", Strict: true}
+		a, err := evalExpr(t, det, `tm_random("a")`)
+		assert.NoError(t, err)
+		again, err := evalExpr(t, det, `tm_random("a")`)
+		assert.NoError(t, err)
+		b, err := evalExpr(t, det, `tm_random("b")`)
+		assert.NoError(t, err)
+		other, err := evalExpr(t, stdlib.Determinism{RandomSeed: "other"}, `tm_random("a")`)
+		assert.NoError(t, err)
+
+		assert.IsTrue(t, a.Equals(again).True())
+		assert.IsTrue(t, a.Equals(b).False())
+		assert.IsTrue(t, a.Equals(other).False())
+		f, _ := a.AsBigFloat().Float64()
+		assert.IsTrue(t, f >= 0 && f < 1)
+	})
+
+	t.Run("unpinned random", func(t *testing.T) {
+		t.Parallel()
+		a, err := evalExpr(t, stdlib.Determinism{}, `tm_random("a")`)
+		assert.NoError(t, err)
+		again, err := evalExpr(t, stdlib.Determinism{}, `tm_random("a")`)
+		assert.NoError(t, err)
+		assert.IsTrue(t, a.Equals(again).True())
+
+		_, err = evalExpr(t, stdlib.Determinism{Strict: true}, `tm_random("a")`)
+		assertNonDeterministic(t, err)
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		t.Parallel()
+		_, err := evalExpr(t, stdlib.Determinism{}, `tm_uuid()`)
+		assert.NoError(t, err)
+		_, err = evalExpr(t, stdlib.Determinism{Strict: true}, `tm_uuid()`)
+		assertNonDeterministic(t, err)
+
+		val, err := evalExpr(t, stdlib.Determinism{Strict: true}, `tm_uuidv5("dns", "terramate.io")`)
+		assert.NoError(t, err)
+		assert.EqualStrings(t, "c2bd3267-22c3-52a9-b970-2a7e0993c634", val.AsString())
+	})
+}
+
+func assertNonDeterministic(t *testing.T, err error) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), string(stdlib.ErrNonDeterministic)) {
+		t.Fatalf("want %q error, got: %v", stdlib.ErrNonDeterministic, err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FunctionDoc is the documentation of a function assembled from its
+// declaration.
+type FunctionDoc struct {
+	Name        string
+	Description string
+	Params      []ParamDoc
+	// Return is the friendly name of the return type.
+	Return string
+}
+
+// ParamDoc is the documentation of a function parameter.
+type ParamDoc struct {
+	Name        string
+	Description string
+	// Type is the friendly name of the parameter type.
+	Type string
+	// Variadic tells if the parameter accepts any number of arguments.
+	Variadic bool
+}
+
+// Describe returns the documentation of the function.
+func Describe(name string, fn function.Function) FunctionDoc {
+	doc := FunctionDoc{
+		Name:        name,
+		Description: fn.Description(),
+	}
+	var types []cty.Type
+	for _, param := range fn.Params() {
+		doc.Params = append(doc.Params, ParamDoc{
+			Name:        param.Name,
+			Description: param.Description,
+			Type:        typeName(param.Type),
+		})
+		types = append(types, param.Type)
+	}
+	if varParam := fn.VarParam(); varParam != nil {
+		doc.Params = append(doc.Params, ParamDoc{
+			Name:        varParam.Name,
+			Description: varParam.Description,
+			Type:        typeName(varParam.Type),
+			Variadic:    true,
+		})
+	}
+	ret := cty.DynamicPseudoType
+	if ty, err := fn.ReturnType(types); err == nil {
+		ret = ty
+	}
+	doc.Return = typeName(ret)
+	return doc
+}
+
+// Describes returns the documentation of the given functions whose names
+// match the glob pattern (see [path.Match]), sorted by name.
+// An empty pattern matches all functions.
+func Describes(funcs map[string]function.Function, pattern string) ([]FunctionDoc, error) {
+	var docs []FunctionDoc
+	for name, fn := range funcs {
+		if pattern != "" {
+			matched, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		docs = append(docs, Describe(name, fn))
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Name < docs[j].Name
+	})
+	return docs, nil
+}
+
+// Signature returns the declared signature of the function.
+// Eg.: tm_upper(str string) string
+func (doc FunctionDoc) Signature() string {
+	params := make([]string, 0, len(doc.Params))
+	for _, param := range doc.Params {
+		name := param.Name
+		if param.Variadic {
+			name = "..." + name
+		}
+		params = append(params, name+" "+param.Type)
+	}
+	return doc.Name + "(" + strings.Join(params, ", ") + ") " + doc.Return
+}
+
+// Signature returns the declared signature of the function.
+// Eg.: tm_upper(str string) string
+func Signature(name string, fn function.Function) string {
+	return Describe(name, fn).Signature()
+}
+
+func typeName(ty cty.Type) string {
+	// capsule types are used for expressions evaluated lazily, like the
+	// tm_ternary branches.
+	if ty == cty.DynamicPseudoType || ty.IsCapsuleType() {
+		return "any"
+	}
+	return ty.FriendlyNameForConstraint()
+}
@@ -19,6 +19,7 @@ import (
 	lang "github.com/terramate-io/opentofulib/lang"
 	"github.com/terramate-io/terramate/errors"
 	"github.com/terramate-io/terramate/event"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/terramate-io/terramate/hcl/ast"
 	"github.com/terramate-io/terramate/modvendor"
 	"github.com/terramate-io/terramate/project"
@@ -48,6 +49,29 @@ func cacheRegex(pattern string, re *regexp.Regexp) {
 	regexMu.Unlock()
 }
 
+// experimentalFunctions are the functions of each experiment. The functions
+// of the experiments not enabled fail telling how to enable them.
+var experimentalFunctions = map[string]map[string]func() function.Function{
+	TomlExperimentName: {
+		"tm_tomlencode": TomlEncode,
+		"tm_tomldecode": TomlDecode,
+	},
+	K8sExperimentName: {
+		"tm_k8s_patch": K8sPatch,
+	},
+}
+
+// FunctionExperiment returns the experiment enabling the function, if it's an
+// experimental function.
+func FunctionExperiment(name string) (string, bool) {
+	for experiment, funcs := range experimentalFunctions {
+		if _, ok := funcs[name]; ok {
+			return experiment, true
+		}
+	}
+	return "", false
+}
+
 // Functions returns all the Terramate default functions.
 // The `basedir` must be an absolute path for an existent directory or it panics.
 func Functions(basedir string, experiments []string) map[string]function.Function {
@@ -63,6 +87,10 @@ func Functions(basedir string, experiments []string) map[string]function.Functio
 		panic(errors.E(errors.ErrInternal, "context basedir (%s) must be a directory", basedir))
 	}
 
+	return builtinFunctions(basedir, experiments)
+}
+
+func builtinFunctions(basedir string, experiments []string) map[string]function.Function {
 	scope := &lang.Scope{BaseDir: basedir}
 	tffuncs := scope.Functions()
 
@@ -92,9 +120,21 @@ func Functions(basedir string, experiments []string) map[string]function.Functio
 
 	tmfuncs["tm_version_match"] = VersionMatch()
 
-	if slices.Contains(experiments, "toml-functions") {
-		tmfuncs["tm_tomlencode"] = TomlEncode()
-		tmfuncs["tm_tomldecode"] = TomlDecode()
+	tmfuncs["tm_deprecated"] = DeprecatedFunc()
+
+	// time and randomness with pinnable sources, see [Deterministic].
+	tmfuncs["tm_timestamp"] = TimestampFunc(Determinism{})
+	tmfuncs["tm_random"] = RandomFunc(Determinism{})
+
+	for experiment, funcs := range experimentalFunctions {
+		enabled := slices.Contains(experiments, experiment)
+		for name, fn := range funcs {
+			if enabled {
+				tmfuncs[name] = fn()
+			} else {
+				tmfuncs[name] = notEnabledFunc(name, experiment)
+			}
+		}
 	}
 
 	tmfuncs["tm_hclencode"] = HCLEncode()
@@ -102,28 +142,21 @@ func Functions(basedir string, experiments []string) map[string]function.Functio
 	return tmfuncs
 }
 
-// NoFS returns all Terramate functions but excluding fs-related
-// functions.
-func NoFS(basedir string, experiments []string) map[string]function.Function {
-	funcs := Functions(basedir, experiments)
-	fsFuncNames := []string{
-		"tm_abspath",
-		"tm_file",
-		"tm_fileexists",
-		"tm_fileset",
-		"tm_filebase64",
-		"tm_filebase64sha256",
-		"tm_filebase64sha512",
-		"tm_filemd5",
-		"tm_filesha1",
-		"tm_filesha256",
-		"tm_filesha512",
-		"tm_templatefile",
-	}
-	for _, name := range fsFuncNames {
-		delete(funcs, name)
-	}
-	return funcs
+func notEnabledFunc(name, experiment string) function.Function {
+	return function.New(&function.Spec{
+		Description: fmt.Sprintf("Experimental function, it requires the %q experiment.", experiment),
+		VarParam: &function.Parameter{
+			Name:             "args",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.NilVal, experiments.NotEnabled(experiment, fmt.Sprintf("function %s", name))
+		},
+	})
 }
 
 // Regex is a copy of Terraform [stdlib.RegexFunc] but with cached compiled
@@ -274,6 +307,12 @@ func regexPatternResult(re *regexp.Regexp, str string, captureIdxs []int, retTyp
 // Name converts the function name into the exported Terramate name.
 func Name(name string) string { return "tm_" + name }
 
+// IsFunction tells if name is the name of a Terramate function, including the
+// experimental functions. The functions are not created.
+func IsFunction(name string) bool {
+	return isBuiltin(name)
+}
+
 // AbspathFunc returns the `tm_abspath()` hcl function.
 func AbspathFunc(basedir string) function.Function {
 	return function.New(&function.Spec{
@@ -304,6 +343,10 @@ func AbspathFunc(basedir string) function.Function {
 // The vendordir defines where modules are vendored inside the project.
 // The stream defines the event stream for tm_vendor, one event is produced
 // per successful function call.
+// Besides Terraform modules, tm_vendor supports HTTP(S) sources of arbitrary
+// files and archives pinned with a checksum, see [tf.ParseVendorSource].
+// For files that are not archives, the returned path is the path of the
+// vendored file.
 func VendorFunc(basedir, vendordir project.Path, stream chan<- event.VendorRequest) function.Function {
 	return function.New(&function.Spec{
 		Params: []function.Parameter{
@@ -316,11 +359,14 @@ func VendorFunc(basedir, vendordir project.Path, stream chan<- event.VendorReque
 		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
 			// Param spec already enforce modsrc to be string.
 			source := args[0].AsString()
-			modsrc, err := tf.ParseSource(source)
+			modsrc, err := tf.ParseVendorSource(source)
 			if err != nil {
 				return cty.NilVal, errors.E(err, "tm_vendor: invalid module source")
 			}
 			targetPath := modvendor.TargetDir(vendordir, modsrc)
+			if filename := modsrc.Filename(); filename != "" {
+				targetPath = targetPath.Join(filename)
+			}
 			result, err := filepath.Rel(basedir.String(), targetPath.String())
 			if err != nil {
 				panic(errors.E(
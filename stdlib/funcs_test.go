@@ -91,6 +91,35 @@ func TestTmVendor(t *testing.T) {
 				VendorDir: project.NewPath("/"),
 			},
 		},
+		{
+			name:      "http file returns the file path",
+			vendorDir: "/vendor",
+			targetDir: "/dir",
+			expr:      `tm_vendor("https://example.com/policies/deny.rego?checksum=sha256:4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211")`,
+			want:      "../vendor/example.com/policies/deny.rego/4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211/deny.rego",
+			wantEvent: event.VendorRequest{
+				Source:    src("https://example.com/policies/deny.rego?checksum=sha256:4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211"),
+				VendorDir: project.NewPath("/vendor"),
+			},
+		},
+		{
+			name:      "http archive returns the extracted dir",
+			vendorDir: "/vendor",
+			targetDir: "/dir",
+			expr:      `tm_vendor("https://example.com/protos.tar.gz?checksum=sha256:4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211")`,
+			want:      "../vendor/example.com/protos.tar.gz/4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211",
+			wantEvent: event.VendorRequest{
+				Source:    src("https://example.com/protos.tar.gz?checksum=sha256:4f2d1c34bd7f6e0e7cde5ff8b0a5b9d2f5a0d1e3c2b4a6978877665544332211"),
+				VendorDir: project.NewPath("/vendor"),
+			},
+		},
+		{
+			name:      "fails on http source without checksum",
+			vendorDir: "/modules",
+			targetDir: "/dir",
+			expr:      `tm_vendor("https://example.com/protos.tar.gz")`,
+			wantErr:   true,
+		},
 		{
 			name:      "fails on invalid module src",
 			vendorDir: "/modules",
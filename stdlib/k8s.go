@@ -0,0 +1,263 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib
+
+import (
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// K8sExperimentName is the name of the Kubernetes manifests experiment.
+const K8sExperimentName = experiments.K8s
+
+// ErrK8sPatch indicates a patch could not be applied to the manifests.
+const ErrK8sPatch errors.Kind = "failed to patch kubernetes manifests"
+
+// patchDirective is the key of the strategic merge directives.
+const patchDirective = "$patch"
+
+// mergeKeys are the attributes identifying the elements of a list of objects,
+// in order of precedence. Lists with elements not identified by the same key
+// are replaced by the patch.
+var mergeKeys = []string{"name", "mountPath", "containerPort"}
+
+// K8sPatch implements the `tm_k8s_patch(manifests, patches...)` function.
+func K8sPatch() function.Function {
+	return function.New(&function.Spec{
+		Description: "Applies the patches to a Kubernetes manifest, or to a list of manifests " +
+			"matching the kind and metadata.name of the patch, with strategic merge semantics.",
+		Params: []function.Parameter{
+			{
+				Name:        "manifests",
+				Description: "A manifest object or a list of manifests.",
+				Type:        cty.DynamicPseudoType,
+			},
+		},
+		VarParam: &function.Parameter{
+			Name:        "patches",
+			Description: "The patch objects, applied in order.",
+			Type:        cty.DynamicPseudoType,
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			for _, arg := range args {
+				if !arg.IsWhollyKnown() {
+					return cty.DynamicVal, nil
+				}
+			}
+			manifests := args[0]
+			for i, patch := range args[1:] {
+				var err error
+				manifests, err = k8sPatch(manifests, patch)
+				if err != nil {
+					return cty.NilVal, function.NewArgError(i+1, err)
+				}
+			}
+			return manifests, nil
+		},
+	})
+}
+
+func k8sPatch(manifests, patch cty.Value) (cty.Value, error) {
+	if patch.IsNull() || !isObject(patch) {
+		return cty.NilVal, errors.E(ErrK8sPatch, "patch must be an object but has type %s",
+			patch.Type().FriendlyName())
+	}
+	if manifests.IsNull() {
+		return cty.NilVal, errors.E(ErrK8sPatch, "manifests must not be null")
+	}
+	if isObject(manifests) {
+		return strategicMerge(manifests, patch), nil
+	}
+	if !isList(manifests) {
+		return cty.NilVal, errors.E(ErrK8sPatch,
+			"manifests must be an object or a list of objects but has type %s",
+			manifests.Type().FriendlyName())
+	}
+
+	kind, name, ok := manifestID(patch)
+	if !ok {
+		return cty.NilVal, errors.E(ErrK8sPatch,
+			"patch of a list of manifests must have the kind and metadata.name attributes")
+	}
+	docs := manifests.AsValueSlice()
+	matched := false
+	for i, doc := range docs {
+		if doc.IsNull() || !isObject(doc) {
+			continue
+		}
+		if docKind, docName, ok := manifestID(doc); ok && docKind == kind && docName == name {
+			docs[i] = strategicMerge(doc, patch)
+			matched = true
+		}
+	}
+	if !matched {
+		return cty.NilVal, errors.E(ErrK8sPatch, "no manifest of kind %q named %q", kind, name)
+	}
+	return cty.TupleVal(docs), nil
+}
+
+// strategicMerge merges the patch into the value. Objects are merged
+// recursively, null attributes of the patch delete the attribute from the
+// value and lists of objects identified by a merge key are merged by their
+// keys. The `$patch` directive replaces the object (`replace`) or deletes
+// the element of a list (`delete`).
+func strategicMerge(val, patch cty.Value) cty.Value {
+	if patch.IsNull() || val.IsNull() {
+		return withoutDirectives(patch)
+	}
+	switch {
+	case isObject(val) && isObject(patch):
+		if directive(patch) == "replace" {
+			return withoutDirectives(patch)
+		}
+		attrs := val.AsValueMap()
+		if attrs == nil {
+			attrs = map[string]cty.Value{}
+		}
+		for name, patchAttr := range patch.AsValueMap() {
+			if name == patchDirective {
+				continue
+			}
+			if patchAttr.IsNull() {
+				delete(attrs, name)
+				continue
+			}
+			if attr, ok := attrs[name]; ok {
+				attrs[name] = strategicMerge(attr, patchAttr)
+			} else {
+				attrs[name] = withoutDirectives(patchAttr)
+			}
+		}
+		return cty.ObjectVal(attrs)
+	case isList(val) && isList(patch):
+		elems := val.AsValueSlice()
+		patchElems := patch.AsValueSlice()
+		key, ok := mergeKey(append(elems[:len(elems):len(elems)], patchElems...))
+		if !ok {
+			return withoutDirectives(patch)
+		}
+		for _, patchElem := range patchElems {
+			id, _ := getAttr(patchElem, key)
+			index := -1
+			for i, elem := range elems {
+				if elemID, _ := getAttr(elem, key); elemID.RawEquals(id) {
+					index = i
+					break
+				}
+			}
+			switch {
+			case directive(patchElem) == "delete":
+				if index >= 0 {
+					elems = append(elems[:index], elems[index+1:]...)
+				}
+			case index >= 0:
+				elems[index] = strategicMerge(elems[index], patchElem)
+			default:
+				elems = append(elems, withoutDirectives(patchElem))
+			}
+		}
+		return cty.TupleVal(elems)
+	default:
+		return withoutDirectives(patch)
+	}
+}
+
+// mergeKey returns the merge key present in all the elements.
+func mergeKey(elems []cty.Value) (string, bool) {
+	if len(elems) == 0 {
+		return "", false
+	}
+	for _, key := range mergeKeys {
+		found := true
+		for _, elem := range elems {
+			if _, ok := getAttr(elem, key); !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// withoutDirectives returns the value without the `$patch` directives.
+func withoutDirectives(val cty.Value) cty.Value {
+	switch {
+	case val.IsNull():
+		return val
+	case isObject(val):
+		attrs := val.AsValueMap()
+		if len(attrs) == 0 {
+			return val
+		}
+		res := make(map[string]cty.Value, len(attrs))
+		for name, attr := range attrs {
+			if name != patchDirective {
+				res[name] = withoutDirectives(attr)
+			}
+		}
+		return cty.ObjectVal(res)
+	case isList(val):
+		elems := val.AsValueSlice()
+		for i, elem := range elems {
+			elems[i] = withoutDirectives(elem)
+		}
+		if len(elems) == 0 {
+			return val
+		}
+		return cty.TupleVal(elems)
+	default:
+		return val
+	}
+}
+
+func directive(val cty.Value) string {
+	d, ok := getAttr(val, patchDirective)
+	if !ok || d.Type() != cty.String {
+		return ""
+	}
+	return d.AsString()
+}
+
+func manifestID(val cty.Value) (kind, name string, ok bool) {
+	kindVal, ok := getAttr(val, "kind")
+	if !ok || kindVal.Type() != cty.String {
+		return "", "", false
+	}
+	metadata, ok := getAttr(val, "metadata")
+	if !ok {
+		return "", "", false
+	}
+	nameVal, ok := getAttr(metadata, "name")
+	if !ok || nameVal.Type() != cty.String {
+		return "", "", false
+	}
+	return kindVal.AsString(), nameVal.AsString(), true
+}
+
+// getAttr returns the non-null attribute of an object or map.
+func getAttr(val cty.Value, name string) (cty.Value, bool) {
+	if val.IsNull() || !isObject(val) {
+		return cty.NilVal, false
+	}
+	attr, ok := val.AsValueMap()[name]
+	if !ok || attr.IsNull() {
+		return cty.NilVal, false
+	}
+	return attr, true
+}
+
+func isObject(val cty.Value) bool {
+	return val.Type().IsObjectType() || val.Type().IsMapType()
+}
+
+func isList(val cty.Value) bool {
+	ty := val.Type()
+	return ty.IsTupleType() || ty.IsListType()
+}
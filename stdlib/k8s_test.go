@@ -0,0 +1,146 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/test"
+)
+
+func TestStdlibK8sPatch(t *testing.T) {
+	t.Parallel()
+	type testcase struct {
+		name    string
+		expr    string
+		want    string
+		wantErr bool
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "objects are merged recursively",
+			expr: `tm_k8s_patch(
+			  {kind = "Service", metadata = {name = "svc", labels = {a = "1"}}},
+			  {metadata = {labels = {b = "2"}}},
+			)`,
+			want: `{kind = "Service", metadata = {name = "svc", labels = {a = "1", b = "2"}}}`,
+		},
+		{
+			name: "null attributes are deleted",
+			expr: `tm_k8s_patch({a = 1, b = {c = 2, d = 3}}, {b = {c = null}})`,
+			want: `{a = 1, b = {d = 3}}`,
+		},
+		{
+			name: "patches are applied in order",
+			expr: `tm_k8s_patch({a = 1}, {a = 2}, {b = 3})`,
+			want: `{a = 2, b = 3}`,
+		},
+		{
+			name: "lists are merged by name",
+			expr: `tm_k8s_patch(
+			  {containers = [{name = "a", image = "a:1", args = ["x"]}, {name = "b", image = "b:1"}]},
+			  {containers = [{name = "a", image = "a:2"}, {name = "c", image = "c:1"}]},
+			)`,
+			want: `{containers = [
+			  {name = "a", image = "a:2", args = ["x"]},
+			  {name = "b", image = "b:1"},
+			  {name = "c", image = "c:1"},
+			]}`,
+		},
+		{
+			name: "lists are merged by mountPath",
+			expr: `tm_k8s_patch(
+			  {volumeMounts = [{mountPath = "/data", readOnly = false}]},
+			  {volumeMounts = [{mountPath = "/data", readOnly = true}]},
+			)`,
+			want: `{volumeMounts = [{mountPath = "/data", readOnly = true}]}`,
+		},
+		{
+			name: "lists without merge key are replaced",
+			expr: `tm_k8s_patch({args = ["a", "b"]}, {args = ["c"]})`,
+			want: `{args = ["c"]}`,
+		},
+		{
+			name: "delete directive removes list element",
+			expr: `tm_k8s_patch(
+			  {containers = [{name = "a"}, {name = "b"}]},
+			  {containers = [{name = "a", "$patch" = "delete"}]},
+			)`,
+			want: `{containers = [{name = "b"}]}`,
+		},
+		{
+			name: "replace directive replaces object",
+			expr: `tm_k8s_patch(
+			  {spec = {selector = {a = "1", b = "2"}}},
+			  {spec = {selector = {c = "3", "$patch" = "replace"}}},
+			)`,
+			want: `{spec = {selector = {c = "3"}}}`,
+		},
+		{
+			name: "list of manifests patched by kind and name",
+			expr: `tm_k8s_patch(
+			  [
+			    {kind = "Deployment", metadata = {name = "app"}, spec = {replicas = 1}},
+			    {kind = "Service", metadata = {name = "app"}, spec = {type = "ClusterIP"}},
+			  ],
+			  {kind = "Deployment", metadata = {name = "app"}, spec = {replicas = 3}},
+			)`,
+			want: `[
+			  {kind = "Deployment", metadata = {name = "app"}, spec = {replicas = 3}},
+			  {kind = "Service", metadata = {name = "app"}, spec = {type = "ClusterIP"}},
+			]`,
+		},
+		{
+			name:    "patch of list of manifests must have kind and name",
+			expr:    `tm_k8s_patch([{kind = "Service", metadata = {name = "app"}}], {spec = {}})`,
+			wantErr: true,
+		},
+		{
+			name:    "patch must match a manifest",
+			expr:    `tm_k8s_patch([{kind = "Service", metadata = {name = "app"}}], {kind = "Service", metadata = {name = "other"}})`,
+			wantErr: true,
+		},
+		{
+			name:    "patch must be an object",
+			expr:    `tm_k8s_patch({}, "patch")`,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rootdir := test.TempDir(t)
+			ctx := eval.NewContext(stdlib.Functions(rootdir, []string{stdlib.K8sExperimentName}))
+			got, err := ctx.Eval(test.NewExpr(t, tc.expr))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			wantExpr, err := ast.ParseExpression(tc.want, "want.hcl")
+			assert.NoError(t, err)
+			wantVal, err := ctx.Eval(wantExpr)
+			assert.NoError(t, err)
+			assert.EqualStrings(t,
+				string(ast.TokensForValue(wantVal).Bytes()),
+				string(ast.TokensForValue(got).Bytes()))
+		})
+	}
+}
+
+func TestStdlibK8sPatchRequiresExperiment(t *testing.T) {
+	t.Parallel()
+	ctx := eval.NewContext(stdlib.Functions(test.TempDir(t), nil))
+	_, err := ctx.Eval(test.NewExpr(t, `tm_k8s_patch({}, {})`))
+	assert.Error(t, err)
+	if !strings.Contains(err.Error(), `terramate.config.experiments = ["k8s"]`) {
+		t.Fatalf("error must show how to enable the experiment: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// ErrRegister indicates that a function could not be registered.
+const ErrRegister errors.Kind = "registering function"
+
+var (
+	builtinNamesOnce sync.Once
+	builtinNames     map[string]struct{}
+)
+
+// Registry holds custom functions added to the Terramate functions.
+// The registry is given to the configuration parser with the
+// hcl.WithFunctions option, which makes its functions available to all
+// the expressions of the project. A nil registry has no functions.
+// It's safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	funcs map[string]function.Function
+}
+
+// NewRegistry creates an empty function registry.
+func NewRegistry() *Registry {
+	return &Registry{funcs: map[string]function.Function{}}
+}
+
+// Register adds a custom function to the registry.
+//
+// The name must have the "tm_" prefix and must not conflict with a builtin
+// or an already registered function. The declared parameters and return type
+// of the function are used to show its signature to users.
+func (r *Registry) Register(name string, fn function.Function) error {
+	if !strings.HasPrefix(name, "tm_") || len(name) == len("tm_") {
+		return errors.E(ErrRegister, "function name %q must have the tm_ prefix", name)
+	}
+	if isBuiltin(name) {
+		return errors.E(ErrRegister, "function %s conflicts with a builtin function", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.funcs[name]; ok {
+		return errors.E(ErrRegister, "function %s is already registered", name)
+	}
+	r.funcs[name] = fn
+	return nil
+}
+
+// Registered returns the functions added with [Registry.Register].
+func (r *Registry) Registered() map[string]function.Function {
+	if r == nil {
+		return map[string]function.Function{}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	funcs := make(map[string]function.Function, len(r.funcs))
+	for name, fn := range r.funcs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// Functions returns the Terramate default functions, as returned by
+// [Functions], together with the registered functions.
+func (r *Registry) Functions(basedir string, experiments []string) map[string]function.Function {
+	funcs := Functions(basedir, experiments)
+	for name, fn := range r.Registered() {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+func isBuiltin(name string) bool {
+	builtinNamesOnce.Do(loadBuiltinNames)
+	_, ok := builtinNames[name]
+	return ok
+}
+
+func loadBuiltinNames() {
+	builtinNames = map[string]struct{}{}
+	for name := range builtinFunctions("/", []string{"toml-functions"}) {
+		builtinNames[name] = struct{}{}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/hcl/ast"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/sandbox"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func TestRegisterFunction(t *testing.T) {
+	t.Parallel()
+
+	greet := function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "name",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal("hello " + args[0].AsString()), nil
+		},
+	})
+
+	registry := stdlib.NewRegistry()
+	assert.NoError(t, registry.Register("tm_test_greet", greet))
+	assert.IsError(t, registry.Register("tm_test_greet", greet), errors.E(stdlib.ErrRegister))
+	assert.IsError(t, registry.Register("test_greet", greet), errors.E(stdlib.ErrRegister))
+	assert.IsError(t, registry.Register("tm_upper", greet), errors.E(stdlib.ErrRegister))
+
+	basedir := test.TempDir(t)
+	_, ok := stdlib.Functions(basedir, []string{})["tm_test_greet"]
+	assert.IsTrue(t, !ok, "registered functions must not leak to other registries")
+
+	var nilRegistry *stdlib.Registry
+	assert.EqualInts(t, len(stdlib.Functions(basedir, []string{})),
+		len(nilRegistry.Functions(basedir, []string{})))
+
+	funcs := registry.Functions(basedir, []string{})
+	evalctx := eval.NewContext(funcs)
+	expr, err := ast.ParseExpression(`tm_test_greet("terramate")`, "test.tm")
+	assert.NoError(t, err)
+	val, err := evalctx.Eval(expr)
+	assert.NoError(t, err)
+	assert.EqualStrings(t, "hello terramate", val.AsString())
+
+	assert.EqualStrings(t, "tm_test_greet(name string) string",
+		stdlib.Signature("tm_test_greet", funcs["tm_test_greet"]))
+}
+
+func TestRegistryGivenToTheParser(t *testing.T) {
+	t.Parallel()
+
+	registry := stdlib.NewRegistry()
+	assert.NoError(t, registry.Register("tm_test_answer", function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.Number),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.NumberIntVal(42), nil
+		},
+	})))
+
+	s := sandbox.NoGit(t, true)
+	root, err := config.LoadRoot(s.RootDir(), false, hcl.WithFunctions(registry))
+	assert.NoError(t, err)
+
+	expr, err := ast.ParseExpression(`tm_test_answer()`, "test.tm")
+	assert.NoError(t, err)
+	val, err := root.NewEvalContext(s.RootDir()).Eval(expr)
+	assert.NoError(t, err)
+	assert.IsTrue(t, val.RawEquals(cty.NumberIntVal(42)), "got %s", val.GoString())
+
+	root, err = config.LoadRoot(s.RootDir(), false)
+	assert.NoError(t, err)
+	_, ok := root.Functions(s.RootDir())["tm_test_answer"]
+	assert.IsTrue(t, !ok, "function must only be available with the registry")
+}
+
+func TestFunctionSignature(t *testing.T) {
+	t.Parallel()
+
+	funcs := stdlib.Functions(test.TempDir(t), []string{})
+	for name, want := range map[string]string{
+		"tm_upper":   "tm_upper(str string) string",
+		"tm_ternary": "tm_ternary(cond bool, val1 any, val2 any) any",
+	} {
+		assert.EqualStrings(t, want, stdlib.Signature(name, funcs[name]))
+	}
+}
+
+func TestDescribeFunctions(t *testing.T) {
+	t.Parallel()
+
+	funcs := stdlib.Functions(test.TempDir(t), []string{})
+	docs, err := stdlib.Describes(funcs, "tm_file*")
+	assert.NoError(t, err)
+
+	var names []string
+	for _, doc := range docs {
+		names = append(names, doc.Name)
+	}
+	want := []string{
+		"tm_file",
+		"tm_filebase64",
+		"tm_filebase64sha256",
+		"tm_filebase64sha512",
+		"tm_fileexists",
+		"tm_filemd5",
+		"tm_fileset",
+		"tm_filesha1",
+		"tm_filesha256",
+		"tm_filesha512",
+	}
+	assert.EqualInts(t, len(want), len(names))
+	for i := range want {
+		assert.EqualStrings(t, want[i], names[i])
+	}
+
+	_, err = stdlib.Describes(funcs, "tm_[")
+	assert.Error(t, err)
+
+	doc := stdlib.Describe("tm_ternary", funcs["tm_ternary"])
+	assert.EqualInts(t, 3, len(doc.Params))
+	assert.IsTrue(t, !doc.Params[2].Variadic)
+}
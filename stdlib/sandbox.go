@@ -0,0 +1,325 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl/eval"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// ErrSandbox indicates the evaluation exceeded a limit of the sandbox.
+const ErrSandbox = eval.ErrLimit
+
+// Sandbox limits the evaluation of untrusted configuration, like the stacks
+// contributed by users of internal developer platforms.
+// The sandboxed functions have no access to the filesystem, the network and
+// the environment, and their time and memory usage are bounded.
+type Sandbox struct {
+	// Timeout is the maximum duration of an evaluation. It's checked on every
+	// function call, for expression element and template part.
+	Timeout time.Duration
+
+	// MaxValueSize is the maximum size of the values created by an
+	// evaluation, which is the length of the strings, object keys and map
+	// keys plus the number of the other primitive values, summed over all the
+	// values returned by functions and created by for expressions and
+	// templates. The size of the values returned by some functions, like
+	// tm_range, is checked before calling them.
+	MaxValueSize int
+}
+
+// DefaultSandbox is the sandbox used when no limits are configured.
+var DefaultSandbox = Sandbox{
+	Timeout:      30 * time.Second,
+	MaxValueSize: 1 << 20,
+}
+
+// NewLimiter returns a limiter enforcing the limits of the sandbox.
+func (sandbox Sandbox) NewLimiter() *eval.Limiter {
+	return eval.NewLimiter(sandbox.Timeout, sandbox.MaxValueSize)
+}
+
+// NewContext returns an evaluation context with the functions restricted by
+// the sandbox, whose evaluations are bounded by its limits.
+func (sandbox Sandbox) NewContext(funcs map[string]function.Function) *eval.Context {
+	limiter := sandbox.NewLimiter()
+	ctx := eval.NewContext(Sandboxed(funcs, limiter))
+	ctx.SetLimiter(limiter)
+	return ctx
+}
+
+// fsFunctions are the functions accessing the filesystem.
+var fsFunctions = []string{
+	"tm_abspath",
+	"tm_file",
+	"tm_fileexists",
+	"tm_fileset",
+	"tm_filebase64",
+	"tm_filebase64sha256",
+	"tm_filebase64sha512",
+	"tm_filemd5",
+	"tm_filesha1",
+	"tm_filesha256",
+	"tm_filesha512",
+	"tm_templatefile",
+}
+
+// envFunctions are the functions accessing the environment.
+var envFunctions = []string{
+	"tm_pathexpand",
+}
+
+// netFunctions are the functions accessing the network.
+var netFunctions = []string{
+	"tm_vendor",
+}
+
+// unboundedFunctions are the functions whose time or memory usage can't be
+// bounded by the sandbox.
+var unboundedFunctions = []string{
+	"tm_base64gunzip",
+	"tm_bcrypt",
+	"tm_templatestring",
+}
+
+// sizeEstimators estimate the size of the values returned by the functions
+// whose results can be much bigger than their arguments, so the sandbox
+// checks it before calling them.
+var sizeEstimators = map[string]func(args []cty.Value) int{
+	"tm_range":      rangeSize,
+	"tm_setproduct": setProductSize,
+	"tm_formatlist": formatListSize,
+	"tm_format":     formatSize,
+	"tm_indent":     indentSize,
+	"tm_replace":    replaceSize,
+}
+
+// Sandboxed returns the functions restricted by the sandbox enforcing the
+// limits of the limiter. The functions accessing the filesystem, the network
+// and the environment, or whose usage of resources is unbounded, always fail and the
+// others fail if a limit is exceeded, with errors of kind [ErrSandbox].
+func Sandboxed(funcs map[string]function.Function, limiter *eval.Limiter) map[string]function.Function {
+	sandboxed := make(map[string]function.Function, len(funcs))
+	for name, fn := range funcs {
+		if slices.Contains(fsFunctions, name) || slices.Contains(netFunctions, name) ||
+			slices.Contains(envFunctions, name) || slices.Contains(unboundedFunctions, name) {
+			sandboxed[name] = unavailableFunc(name)
+			continue
+		}
+		sandboxed[name] = sandboxFunc(name, fn, limiter)
+	}
+	return sandboxed
+}
+
+// NoFS removes the functions accessing the filesystem from the context.
+func NoFS(ctx *eval.Context) {
+	for _, name := range fsFunctions {
+		ctx.DeleteFunction(name)
+	}
+}
+
+func unavailableFunc(name string) function.Function {
+	return function.New(&function.Spec{
+		Description: "Not available in sandboxed mode.",
+		VarParam: &function.Parameter{
+			Name:             "args",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowDynamicType: true,
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.NilVal, errors.E(ErrSandbox, "function %s is not available in sandboxed mode", name)
+		},
+	})
+}
+
+func sandboxFunc(name string, fn function.Function, limiter *eval.Limiter) function.Function {
+	estimate := sizeEstimators[name]
+	return function.New(&function.Spec{
+		Description: fn.Description(),
+		Params:      fn.Params(),
+		VarParam:    fn.VarParam(),
+		Type:        fn.ReturnTypeForValues,
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			defer limiter.Begin()()
+
+			if err := limiter.Check(name); err != nil {
+				return cty.NilVal, err
+			}
+			if estimate != nil {
+				if err := limiter.Fits(name, estimate(args)); err != nil {
+					return cty.NilVal, err
+				}
+			}
+			val, err := fn.Call(args)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if err := limiter.Alloc(name, eval.ValueSize(val, limiter.Limit())); err != nil {
+				return cty.NilVal, err
+			}
+			return val, nil
+		},
+	})
+}
+
+// rangeSize estimates the size of tm_range(start, limit, step), which is the
+// number of elements.
+func rangeSize(args []cty.Value) int {
+	nums := make([]*big.Float, len(args))
+	for i, arg := range args {
+		arg, _ = arg.Unmark()
+		if !arg.IsKnown() || arg.IsNull() || arg.Type() != cty.Number {
+			return 0
+		}
+		nums[i] = arg.AsBigFloat()
+	}
+	start, limit, step := big.NewFloat(0), big.NewFloat(0), big.NewFloat(1)
+	switch len(nums) {
+	case 1:
+		limit = nums[0]
+	case 2:
+		start, limit = nums[0], nums[1]
+	case 3:
+		start, limit, step = nums[0], nums[1], nums[2]
+	default:
+		return 0
+	}
+	if step.Sign() == 0 {
+		return 0
+	}
+	count := new(big.Float).Sub(limit, start)
+	count.Quo(count, step)
+	return bigSize(count)
+}
+
+// setProductSize estimates the size of tm_setproduct(sets...), which is the
+// number of combinations times the number of sets.
+func setProductSize(args []cty.Value) int {
+	count := big.NewFloat(float64(len(args)))
+	for _, arg := range args {
+		count.Mul(count, big.NewFloat(float64(collectionLen(arg))))
+	}
+	return bigSize(count)
+}
+
+// formatListSize estimates the size of tm_formatlist(format, args...), which
+// is the length of the longest list times the size of the arguments.
+func formatListSize(args []cty.Value) int {
+	if len(args) == 0 {
+		return 0
+	}
+	count := 1
+	size := stringLen(args[0])
+	for _, arg := range args[1:] {
+		count = max(count, collectionLen(arg))
+		size += eval.ValueSize(arg, math.MaxInt)
+	}
+	return bigSize(new(big.Float).Mul(big.NewFloat(float64(count)), big.NewFloat(float64(size))))
+}
+
+var formatWidth = regexp.MustCompile(`%[-+# 0]*(\d+)`)
+
+// formatSize estimates the size of tm_format(format, args...), which is the
+// size of the arguments plus the widths of the verbs.
+func formatSize(args []cty.Value) int {
+	if len(args) == 0 {
+		return 0
+	}
+	size := big.NewFloat(0)
+	for _, arg := range args {
+		size.Add(size, big.NewFloat(float64(eval.ValueSize(arg, math.MaxInt))))
+	}
+	if format, ok := knownString(args[0]); ok {
+		for _, match := range formatWidth.FindAllStringSubmatch(format, -1) {
+			width, _, err := big.ParseFloat(match[1], 10, 64, big.ToNearestEven)
+			if err == nil {
+				size.Add(size, width)
+			}
+		}
+	}
+	return bigSize(size)
+}
+
+// indentSize estimates the size of tm_indent(spaces, string), which is the
+// number of spaces times the number of lines plus the string.
+func indentSize(args []cty.Value) int {
+	if len(args) != 2 {
+		return 0
+	}
+	spaces, _ := args[0].Unmark()
+	str, ok := knownString(args[1])
+	if !ok || !spaces.IsKnown() || spaces.IsNull() || spaces.Type() != cty.Number {
+		return 0
+	}
+	size := new(big.Float).Mul(spaces.AsBigFloat(), big.NewFloat(float64(strings.Count(str, "\n")+1)))
+	return bigSize(size.Add(size, big.NewFloat(float64(len(str)))))
+}
+
+// replaceSize estimates the size of tm_replace(string, substr, replacement).
+// The replacement is counted for every possible match of regular expressions.
+func replaceSize(args []cty.Value) int {
+	if len(args) != 3 {
+		return 0
+	}
+	str, ok1 := knownString(args[0])
+	substr, ok2 := knownString(args[1])
+	repl, ok3 := knownString(args[2])
+	if !ok1 || !ok2 || !ok3 {
+		return 0
+	}
+	var matches int
+	if len(substr) > 1 && strings.HasPrefix(substr, "/") && strings.HasSuffix(substr, "/") {
+		matches = len(str) + 1
+	} else if substr != "" {
+		matches = strings.Count(str, substr)
+	}
+	size := new(big.Float).Mul(big.NewFloat(float64(matches)), big.NewFloat(float64(len(repl))))
+	return bigSize(size.Add(size, big.NewFloat(float64(len(str)))))
+}
+
+func knownString(val cty.Value) (string, bool) {
+	val, _ = val.Unmark()
+	if !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+func stringLen(val cty.Value) int {
+	str, _ := knownString(val)
+	return len(str)
+}
+
+func collectionLen(val cty.Value) int {
+	val, _ = val.Unmark()
+	if !val.IsKnown() || val.IsNull() || !val.CanIterateElements() {
+		return 1
+	}
+	return val.LengthInt()
+}
+
+// bigSize converts the estimated size to int, saturating on overflow.
+func bigSize(size *big.Float) int {
+	size.Abs(size)
+	if size.Cmp(big.NewFloat(math.MaxInt)) >= 0 {
+		return math.MaxInt
+	}
+	n, acc := size.Int64()
+	if acc == big.Below {
+		n++
+	}
+	return int(n)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package stdlib_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/stdlib"
+	"github.com/terramate-io/terramate/test"
+)
+
+func TestSandboxedFunctions(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		sandbox stdlib.Sandbox
+		expr    string
+		wantErr string
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:    "pure functions are available",
+			sandbox: stdlib.DefaultSandbox,
+			expr:    `tm_upper(tm_join("-", ["a", "b"]))`,
+		},
+		{
+			name:    "filesystem functions are not available",
+			sandbox: stdlib.DefaultSandbox,
+			expr:    `tm_file("/etc/passwd")`,
+			wantErr: "not available in sandboxed mode",
+		},
+		{
+			name:    "environment functions are not available",
+			sandbox: stdlib.DefaultSandbox,
+			expr:    `tm_pathexpand("~/.ssh")`,
+			wantErr: "not available in sandboxed mode",
+		},
+		{
+			name:    "values exceeding the max size",
+			sandbox: stdlib.Sandbox{MaxValueSize: 10},
+			expr:    `tm_range(20)`,
+			wantErr: "exceeds the maximum size of 10",
+		},
+		{
+			name:    "nested values exceeding the max size",
+			sandbox: stdlib.Sandbox{MaxValueSize: 10},
+			expr:    `tm_tolist([{a = "abcdef"}, {a = "abcdef"}])`,
+			wantErr: "exceeds the maximum size of 10",
+		},
+		{
+			name:    "values within the max size",
+			sandbox: stdlib.Sandbox{MaxValueSize: 10},
+			expr:    `tm_range(10)`,
+		},
+		{
+			name:    "values checked before calling the function",
+			sandbox: stdlib.Sandbox{MaxValueSize: 10},
+			expr:    `tm_range(1e15)`,
+			wantErr: "exceeds the maximum size of 10 creating tm_range",
+		},
+		{
+			name:    "values amplified by the function",
+			sandbox: stdlib.Sandbox{MaxValueSize: 100},
+			expr:    `tm_format("%999999999d", 1)`,
+			wantErr: "exceeds the maximum size of 100 creating tm_format",
+		},
+		{
+			name:    "values created by for expressions",
+			sandbox: stdlib.Sandbox{MaxValueSize: 20},
+			expr:    `[for i in [1, 2, 3, 4, 5] : "abcdef"]`,
+			wantErr: "exceeds the maximum size of 20 creating for expression element",
+		},
+		{
+			name:    "values created by templates",
+			sandbox: stdlib.Sandbox{MaxValueSize: 10},
+			expr:    `"${"abcdef"}-${"abcdef"}"`,
+			wantErr: "exceeds the maximum size of 10 creating template part",
+		},
+		{
+			name:    "functions with unbounded usage are not available",
+			sandbox: stdlib.DefaultSandbox,
+			expr:    `tm_templatestring("a", {})`,
+			wantErr: "not available in sandboxed mode",
+		},
+		{
+			name:    "timed out for expressions",
+			sandbox: stdlib.Sandbox{Timeout: 10 * time.Millisecond},
+			expr:    `[for i in tm_range(1000) : [for j in tm_range(1000) : [for k in tm_range(1000) : k]]]`,
+			wantErr: "evaluation timed out evaluating for expression element",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := tc.sandbox.NewContext(stdlib.Functions(test.TempDir(t), nil))
+			_, err := ctx.Eval(test.NewExpr(t, tc.expr))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			if !strings.Contains(err.Error(), string(stdlib.ErrSandbox)) ||
+				!strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error %q does not contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -11,13 +11,14 @@ import (
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/experiments"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/json"
 )
 
 // TomlExperimentName is the name for the TOML experiment.
-const TomlExperimentName = "toml-functions"
+const TomlExperimentName = experiments.TomlFunctions
 
 // ErrTomlDecode represents errors happening during decoding of TOML content.
 const ErrTomlDecode errors.Kind = "failed to decode toml content"
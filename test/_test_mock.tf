@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "test" {
-  content = <<-EOT
+  content = <<-EOF
 package test // import "github.com/terramate-io/terramate/test"
 
 Package test provides testing routines reused throughout terramate code base.
@@ -50,7 +50,7 @@ func Symlink(t testing.TB, oldname, newname string)
 func TempDir(t testing.TB) string
 func WriteFile(t testing.TB, dir string, filename string, content string) string
 func WriteRootConfig(t testing.TB, rootdir string)
-EOT
+EOF
 
   filename = "${path.module}/mock-test.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "errors" {
-  content = <<-EOT
+  content = <<-EOF
 package errors // import "github.com/terramate-io/terramate/test/errors"
 
 Package errors provides useful assert functions for handling errors on tests
@@ -12,7 +12,7 @@ func AssertErrorList(t *testing.T, err error, targets []error)
 func AssertIsErrors(t *testing.T, err error, targets []error)
 func AssertIsKind(t *testing.T, err error, k errors.Kind)
 func AssertKind(t *testing.T, got, want error)
-EOT
+EOF
 
   filename = "${path.module}/mock-errors.ignore"
 }
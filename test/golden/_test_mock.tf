@@ -0,0 +1,27 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "golden" {
+  content = <<-EOF
+package golden // import "github.com/terramate-io/terramate/test/golden"
+
+Package golden provides golden file snapshots for testing the code generated
+from Terramate configuration trees.
+
+The snapshot of a project is compared with the content of a golden file,
+after normalizing both. When the environment variable TM_TEST_UPDATE_GOLDEN is
+set to a true value the golden files are (re)written with the current snapshots
+instead, eg.:
+
+    TM_TEST_UPDATE_GOLDEN=1 go test ./...
+
+const RootDirPlaceholder = "<rootdir>"
+const UpdateEnv = "TM_TEST_UPDATE_GOLDEN"
+func Assert(t testing.TB, goldenFile string, rootdir string, got string)
+func AssertGenerated(t testing.TB, root *config.Root, goldenFile string)
+func Generated(t testing.TB, root *config.Root) string
+func Normalize(rootdir string, content string) string
+func Update() bool
+EOF
+
+  filename = "${path.module}/mock-golden.ignore"
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package golden
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/generate"
+)
+
+// Generated returns the snapshot of the code generated for the project, which
+// is the content of each generated file (with its header) preceded by a
+// "-- <path> --" line, sorted by the project path of the files. The
+// directories failing to generate code have their error in a
+// "-- <dir> (error) --" section.
+//
+// The code is loaded in memory with the vendor dir of the project and nothing
+// is written to the project.
+func Generated(t testing.TB, root *config.Root) string {
+	t.Helper()
+
+	vendorDir, err := root.VendorDir()
+	assert.NoError(t, err, "getting the vendor dir")
+
+	results, err := generate.Load(root, 0, vendorDir)
+	assert.NoError(t, err, "loading generated code")
+
+	sections := map[string]string{}
+	for _, res := range results {
+		if res.Err != nil {
+			sections[res.Dir.String()+" (error)"] = res.Err.Error()
+			continue
+		}
+		for _, file := range res.Files {
+			if !file.Condition() {
+				continue
+			}
+			filename := file.Label()
+			if !path.IsAbs(filename) {
+				filename = path.Join(res.Dir.String(), filename)
+			}
+			sections[filename] = file.Header() + file.Body()
+		}
+	}
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var snapshot strings.Builder
+	for _, name := range names {
+		snapshot.WriteString("-- " + name + " --\n")
+		snapshot.WriteString(strings.TrimRight(sections[name], "\n"))
+		snapshot.WriteString("\n")
+	}
+	return snapshot.String()
+}
+
+// AssertGenerated checks if the snapshot of the code generated for the
+// project, as returned by [Generated], matches goldenFile. In update mode
+// goldenFile is written with the snapshot instead.
+func AssertGenerated(t testing.TB, root *config.Root, goldenFile string) {
+	t.Helper()
+
+	Assert(t, goldenFile, root.HostDir(), Generated(t, root))
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package golden provides golden file snapshots for testing the code generated
+// from Terramate configuration trees.
+//
+// The snapshot of a project is compared with the content of a golden file,
+// after normalizing both. When the environment variable TM_TEST_UPDATE_GOLDEN
+// is set to a true value the golden files are (re)written with the current
+// snapshots instead, eg.:
+//
+//	TM_TEST_UPDATE_GOLDEN=1 go test ./...
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/madlambda/spells/assert"
+)
+
+// UpdateEnv is the environment variable enabling the update mode.
+const UpdateEnv = "TM_TEST_UPDATE_GOLDEN"
+
+// RootDirPlaceholder replaces the root directory of the project in the
+// normalized snapshots, so they don't depend on where the project is.
+const RootDirPlaceholder = "<rootdir>"
+
+const bom = "\ufeff"
+
+// Update tells if the golden files must be updated instead of compared.
+func Update() bool {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return update
+}
+
+// Assert checks if got matches the content of goldenFile, both normalized by
+// [Normalize] with the given rootdir. In update mode the normalized got is
+// written to goldenFile instead.
+func Assert(t testing.TB, goldenFile string, rootdir string, got string) {
+	t.Helper()
+
+	got = Normalize(rootdir, got)
+	if Update() {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(goldenFile), 0755))
+		assert.NoError(t, os.WriteFile(goldenFile, []byte(got), 0644))
+		return
+	}
+
+	want, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Errorf("reading golden file (run with %s=1 to create it): %v", UpdateEnv, err)
+		return
+	}
+	if diff := cmp.Diff(Normalize(rootdir, string(want)), got); diff != "" {
+		t.Errorf("snapshot doesn't match golden file %s (run with %s=1 to update it)", goldenFile, UpdateEnv)
+		t.Errorf("diff (-want +got):\n%s", diff)
+	}
+}
+
+// Normalize normalizes the content so it can be compared independently of the
+// platform and the location of the project at rootdir:
+//
+// - The byte order mark is removed.
+// - The line endings are converted to LF.
+// - The trailing whitespace of the lines is removed.
+// - The rootdir is replaced by [RootDirPlaceholder].
+// - The content ends with a single newline.
+//
+// The rootdir is ignored if empty.
+func Normalize(rootdir string, content string) string {
+	content = strings.TrimPrefix(content, bom)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if rootdir != "" {
+		content = strings.ReplaceAll(content, rootdir, RootDirPlaceholder)
+		content = strings.ReplaceAll(content, filepath.ToSlash(rootdir), RootDirPlaceholder)
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
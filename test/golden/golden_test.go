@@ -0,0 +1,80 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package golden_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/test"
+	"github.com/terramate-io/terramate/test/golden"
+	"github.com/terramate-io/terramate/test/sandbox"
+)
+
+func TestGoldenGenerated(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		"s:stacks/a",
+		"s:stacks/b",
+		`f:stacks/gen.tm:generate_hcl "main.tf" {
+  content {
+    locals {
+      stack = terramate.stack.path.absolute
+    }
+  }
+}
+
+generate_file "path.txt" {
+  content = terramate.root.path.fs.absolute
+}
+
+generate_file "/root.txt" {
+  context = root
+  content = "root"
+}
+`,
+	})
+
+	golden.AssertGenerated(t, s.Config(), filepath.Join("testdata", "generate.golden"))
+}
+
+func TestGoldenGeneratedVendorDir(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.NoGit(t, true)
+	s.BuildTree([]string{
+		`f:vendor.tm:vendor {
+  dir = "/vendor"
+}`,
+		"s:stack",
+		`f:stack/gen.tm:generate_file "vendor.txt" {
+  content = tm_vendor("github.com/terramate-io/terramate?ref=v1")
+}
+`,
+	})
+
+	assert.EqualStrings(t, "-- /stack/vendor.txt --\n../vendor/github.com/terramate-io/terramate/v1\n",
+		golden.Generated(t, s.Config()))
+}
+
+func TestGoldenNormalize(t *testing.T) {
+	t.Parallel()
+
+	got := golden.Normalize("/tmp/project", "\ufeffa = \"/tmp/project/file\"  \r\nb = 1\r\n\r\n")
+	assert.EqualStrings(t, "a = \"<rootdir>/file\"\nb = 1\n", got)
+}
+
+func TestGoldenUpdateMode(t *testing.T) {
+	t.Setenv(golden.UpdateEnv, "1")
+
+	goldenFile := filepath.Join(test.TempDir(t), "dir", "snapshot.golden")
+	golden.Assert(t, goldenFile, "/project", "/project/a.tf\r\n")
+	test.AssertFileContentEquals(t, goldenFile, "<rootdir>/a.tf\n")
+
+	t.Setenv(golden.UpdateEnv, "")
+	golden.Assert(t, goldenFile, "/other", "/other/a.tf")
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package golden // import \"github.com/terramate-io/terramate/test/golden\""
+  description = "package golden // import \"github.com/terramate-io/terramate/test/golden\"\n\nPackage golden provides golden file snapshots for testing the code generated\nfrom Terramate configuration trees.\n\nThe snapshot of a project is compared with the content of a golden file,\nafter normalizing both. When the environment variable TM_TEST_UPDATE_GOLDEN is\nset to a true value the golden files are (re)written with the current snapshots\ninstead, eg.:\n\n    TM_TEST_UPDATE_GOLDEN=1 go test ./...\n\nconst RootDirPlaceholder = \"<rootdir>\"\nconst UpdateEnv = \"TM_TEST_UPDATE_GOLDEN\"\nfunc Assert(t testing.TB, goldenFile string, rootdir string, got string)\nfunc AssertGenerated(t testing.TB, root *config.Root, goldenFile string)\nfunc Generated(t testing.TB, root *config.Root) string\nfunc Normalize(rootdir string, content string) string\nfunc Update() bool"
+  tags        = ["golang", "golden", "test"]
+  id          = "e0136b4a-48c2-4279-b999-0a522f2e4f12"
+}
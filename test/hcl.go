@@ -90,6 +90,8 @@ func AssertTerramateConfig(t *testing.T, got, want *hcl.Config) {
 		// Globals/Asserts/Scripts are mostly Attribute and Expr, which cannot be easily compared with cmp.Diff.
 		cmpopts.IgnoreFields(hcl.Config{}, "Globals", "Asserts", "Scripts", "Inputs", "Outputs"),
 		cmpopts.IgnoreFields(hcl.RunEnv{}, "Attributes"), // because Expr and Range
+		cmpopts.IgnoreFields(hcl.RunConfig{}, "ExportGlobalsAsTFVarsRange"),
+		cmpopts.IgnoreFields(hcl.NotificationsConfig{}, "URL"),
 		cmpopts.IgnoreFields(hcl.Config{}, "Generate"),
 	); diff != "" {
 		t.Logf("want: %+v", want)
@@ -147,6 +149,8 @@ func AssertConfigEquals(t *testing.T, got, want []config.Assert) {
 		}
 		AssertDiff(t, g.Range, w.Range, "range mismatch")
 		assert.EqualStrings(t, w.Message, g.Message, "message mismatch")
+		assert.EqualStrings(t, w.Code, g.Code, "code mismatch")
+		assert.EqualStrings(t, w.DocsURL, g.DocsURL, "docs_url mismatch")
 	}
 }
 
@@ -276,6 +280,12 @@ func assertAssertsBlock(t *testing.T, got, want []hcl.AssertConfig, ctx string)
 		assert.EqualStrings(t,
 			exprAsStr(t, w.Warning), exprAsStr(t, g.Warning),
 			"%s: warning expr mismatch", newctx)
+		assert.EqualStrings(t,
+			exprAsStr(t, w.Code), exprAsStr(t, g.Code),
+			"%s: code expr mismatch", newctx)
+		assert.EqualStrings(t,
+			exprAsStr(t, w.DocsURL), exprAsStr(t, g.DocsURL),
+			"%s: docs_url expr mismatch", newctx)
 	}
 }
 
@@ -342,6 +352,14 @@ func assertTerramateConfigBlock(t *testing.T, got, want *hcl.RootConfig) {
 		t.Fatalf("want.Experiments[%+v] != got.Experiments[%+v]", want.Experiments, got.Experiments)
 	}
 
+	if !slices.Equal(want.ConfigFileSuffixes, got.ConfigFileSuffixes) {
+		t.Fatalf("want.ConfigFileSuffixes[%+v] != got.ConfigFileSuffixes[%+v]", want.ConfigFileSuffixes, got.ConfigFileSuffixes)
+	}
+
+	if !slices.Equal(want.Subprojects, got.Subprojects) {
+		t.Fatalf("want.Subprojects[%+v] != got.Subprojects[%+v]", want.Subprojects, got.Subprojects)
+	}
+
 	assertTerramateRunBlock(t, got.Run, want.Run)
 	assertTerramateCloudBlock(t, got.Cloud, want.Cloud)
 }
@@ -438,11 +456,33 @@ func assertScriptBlocks(t *testing.T, got, want []*hcl.Script) {
 					"commands mismatch")
 			}
 
+			if (wantJob.Approval == nil) != (gotJob.Approval == nil) {
+				t.Fatalf("job.approval: want[%+v] != got[%+v]", wantJob.Approval, gotJob.Approval)
+			}
+			if wantJob.Approval != nil {
+				assertOptionalAttr(t, "job.approval.message", wantJob.Approval.Message, gotJob.Approval.Message)
+				assertOptionalAttr(t, "job.approval.file", wantJob.Approval.File, gotJob.Approval.File)
+			}
 		}
 	}
 
 }
 
+func assertOptionalAttr(t *testing.T, name string, want, got *ast.Attribute) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Fatalf("got %s[%s] but expected nil", name, exprAsStr(t, got.Expr))
+		}
+		return
+	}
+	if got == nil {
+		t.Fatalf("want %s[%s] but got nil", name, exprAsStr(t, want.Expr))
+	}
+	assert.EqualStrings(t, exprAsStr(t, want.Expr), exprAsStr(t, got.Expr), name+" mismatch")
+}
+
 func assertTerramateRunBlock(t *testing.T, got, want *hcl.RunConfig) {
 	t.Helper()
 
@@ -458,6 +498,9 @@ func assertTerramateRunBlock(t *testing.T, got, want *hcl.RunConfig) {
 		"want.Run.CheckGenCode %v != got.Run.CheckGenCode %v",
 		want.CheckGenCode, got.CheckGenCode)
 
+	AssertDiff(t, got.Policy, want.Policy, "run.policy mismatch")
+	AssertDiff(t, got.Lock, want.Lock, "run.lock mismatch")
+
 	if (want.Env == nil) != (got.Env == nil) {
 		t.Fatalf(
 			"want.Run.Env[%+v] != got.Run.Env[%+v]",
@@ -492,8 +535,8 @@ func assertTerramateCloudBlock(t *testing.T, got, want *hcl.CloudConfig) {
 		return
 	}
 
-	if *want != *got {
-		t.Fatalf("want.Cloud[%+v] != got.Cloud[%+v]", want, got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("-(want) +(got):\n%s", diff)
 	}
 }
 
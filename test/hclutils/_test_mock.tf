@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "hclutils" {
-  content = <<-EOT
+  content = <<-EOF
 package hclutils // import "github.com/terramate-io/terramate/test/hclutils"
 
 Package hclutils provides test utils related to hcl.
@@ -10,7 +10,7 @@ func End(line, column, char int) hhcl.Pos
 func FixupFiledirOnErrorsFileRanges(dir string, errs []error)
 func Mkrange(fname string, start, end hhcl.Pos) hhcl.Range
 func Start(line, column, char int) hhcl.Pos
-EOT
+EOF
 
   filename = "${path.module}/mock-hclutils.ignore"
 }
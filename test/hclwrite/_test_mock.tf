@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "hclwrite" {
-  content = <<-EOT
+  content = <<-EOF
 package hclwrite // import "github.com/terramate-io/terramate/test/hclwrite"
 
 Package hclwrite aims to provide some facilities making it easier/safer to
@@ -32,7 +32,7 @@ type BlockBuilder interface{ ... }
     func NumberInt(name string, val int64) BlockBuilder
     func String(name string, val string) BlockBuilder
 type BlockBuilderFunc func(*Block)
-EOT
+EOF
 
   filename = "${path.module}/mock-hclwrite.ignore"
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "hclutils" {
-  content = <<-EOT
+  content = <<-EOF
 package hclutils // import "github.com/terramate-io/terramate/test/hclwrite/hclutils"
 
 Package hclutils provides useful functions to build HCL documents. It is usually
@@ -49,7 +49,7 @@ func Trigger(builders ...hclwrite.BlockBuilder) *hclwrite.Block
 func Value(builders ...hclwrite.BlockBuilder) *hclwrite.Block
 func Variable(builders ...hclwrite.BlockBuilder) *hclwrite.Block
 func Vendor(builders ...hclwrite.BlockBuilder) *hclwrite.Block
-EOT
+EOF
 
   filename = "${path.module}/mock-hclutils.ignore"
 }
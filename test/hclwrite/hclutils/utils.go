@@ -108,6 +108,11 @@ func GenerateFile(builders ...hclwrite.BlockBuilder) *hclwrite.Block {
 	return Block("generate_file", builders...)
 }
 
+// GenerateYAML is a helper for a "generate_yaml" block.
+func GenerateYAML(builders ...hclwrite.BlockBuilder) *hclwrite.Block {
+	return Block("generate_yaml", builders...)
+}
+
 // Content is a helper for a "content" block.
 func Content(builders ...hclwrite.BlockBuilder) *hclwrite.Block {
 	return Block("content", builders...)
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "ls" {
-  content = <<-EOT
+  content = <<-EOF
 package ls // import "github.com/terramate-io/terramate/test/ls"
 
 Package ls provides test utilities used when testing the Terramate Language
@@ -13,7 +13,7 @@ type Editor struct{ ... }
 type Fixture struct{ ... }
     func Setup(t *testing.T, layout ...string) Fixture
     func SetupNoRootConfig(t *testing.T, layout ...string) Fixture
-EOT
+EOF
 
   filename = "${path.module}/mock-ls.ignore"
 }
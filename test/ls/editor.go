@@ -128,6 +128,43 @@ func (e *Editor) Change(path, content string) {
 	assert.NoError(t, err, "call %q", lsp.MethodTextDocumentDidChange)
 }
 
+// Save sends a didSave request to the language server.
+func (e *Editor) Save(path string) {
+	t := e.t
+	t.Helper()
+	abspath := filepath.Join(e.sandbox.RootDir(), path)
+	var saveResult interface{}
+	_, err := e.call(lsp.MethodTextDocumentDidSave, lsp.DidSaveTextDocumentParams{
+		TextDocument: lsp.TextDocumentIdentifier{
+			URI: uri.File(abspath),
+		},
+	}, &saveResult)
+	assert.NoError(t, err, "calling %s", lsp.MethodTextDocumentDidSave)
+}
+
+// Completion sends a completion request for the position of the file to the
+// language server and returns the completion list, which is nil if the
+// server has no completions for the position.
+func (e *Editor) Completion(path string, line, character uint32) *lsp.CompletionList {
+	t := e.t
+	t.Helper()
+	abspath := filepath.Join(e.sandbox.RootDir(), path)
+	var got *lsp.CompletionList
+	_, err := e.call(lsp.MethodTextDocumentCompletion, lsp.CompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{
+				URI: uri.File(abspath),
+			},
+			Position: lsp.Position{
+				Line:      line,
+				Character: character,
+			},
+		},
+	}, &got)
+	assert.NoError(t, err, "calling %q", lsp.MethodTextDocumentCompletion)
+	return got
+}
+
 // Command invokes the provided command in the LSP server.
 func (e *Editor) Command(cmd lsp.ExecuteCommandParams) (interface{}, error) {
 	t := e.t
@@ -20,7 +20,7 @@ type Fixture struct {
 	Editor  *Editor
 }
 
-func setup(t *testing.T, createRootConfig bool, layout ...string) Fixture {
+func setup(t *testing.T, createRootConfig bool, opts []tmls.Option, layout ...string) Fixture {
 	t.Helper()
 
 	s := sandbox.NoGit(t, createRootConfig)
@@ -34,7 +34,7 @@ func setup(t *testing.T, createRootConfig bool, layout ...string) Fixture {
 	editorRW, serverRW := net.Pipe()
 
 	serverConn := jsonrpc2Conn(serverRW)
-	server := tmls.NewServer(serverConn)
+	server := tmls.NewServer(serverConn, opts...)
 	serverConn.Go(context.Background(), server.Handler)
 
 	editorConn := jsonrpc2Conn(editorRW)
@@ -72,13 +72,19 @@ func setup(t *testing.T, createRootConfig bool, layout ...string) Fixture {
 // Setup a new fixture.
 func Setup(t *testing.T, layout ...string) Fixture {
 	t.Helper()
-	return setup(t, true, layout...)
+	return setup(t, true, nil, layout...)
+}
+
+// SetupWithOptions a new fixture with a server created with the options.
+func SetupWithOptions(t *testing.T, opts []tmls.Option, layout ...string) Fixture {
+	t.Helper()
+	return setup(t, true, opts, layout...)
 }
 
 // SetupNoRootConfig a new fixture without root config.
 func SetupNoRootConfig(t *testing.T, layout ...string) Fixture {
 	t.Helper()
-	return setup(t, false, layout...)
+	return setup(t, false, nil, layout...)
 }
 
 func jsonrpc2Conn(rw io.ReadWriteCloser) jsonrpc2.Conn {
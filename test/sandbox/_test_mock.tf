@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "sandbox" {
-  content = <<-EOT
+  content = <<-EOF
 package sandbox // import "github.com/terramate-io/terramate/test/sandbox"
 
 Package sandbox provides an easy way to setup isolated terramate projects that
@@ -12,6 +12,16 @@ It helps with:
 - git initialization/operations - Terraform module creation - Terramate stack
 creation
 
+It is a supported API for testing Terramate projects, so teams embedding
+Terramate can check their configuration conventions in their own Go test suites,
+eg.:
+
+    s := sandbox.New(t)
+    s.RootEntry().CreateGlobals(hclutils.Str("env", "prod"))
+    s.CreateStackWith("stacks/app", sandbox.WithStackTags("app"))
+    s.Commit("add app stack")
+
+const GlobalsFilename = "globals.tm"
 type AssertTreeOption func(o *assertTreeOptions)
     func WithStrictStackValidation() AssertTreeOption
 type DirEntry struct{ ... }
@@ -25,7 +35,14 @@ type S struct{ ... }
     func NewWithGitConfig(t testing.TB, cfg GitConfig) S
     func NoGit(t testing.TB, createProject bool) S
 type StackEntry struct{ ... }
-EOT
+type StackOption func(st *config.Stack)
+    func WithStackAfter(paths ...string) StackOption
+    func WithStackBefore(paths ...string) StackOption
+    func WithStackDescription(desc string) StackOption
+    func WithStackID(id string) StackOption
+    func WithStackName(name string) StackOption
+    func WithStackTags(tags ...string) StackOption
+EOF
 
   filename = "${path.module}/mock-sandbox.ignore"
 }
@@ -0,0 +1,96 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package sandbox
+
+import (
+	"path/filepath"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/stack"
+	"github.com/terramate-io/terramate/test/hclwrite"
+	"github.com/terramate-io/terramate/test/hclwrite/hclutils"
+)
+
+// GlobalsFilename is the name of the file created by [DirEntry.CreateGlobals].
+const GlobalsFilename = "globals.tm"
+
+// StackOption configures the stack created by [S.CreateStackWith].
+type StackOption func(st *config.Stack)
+
+// WithStackID sets the id of the stack.
+func WithStackID(id string) StackOption {
+	return func(st *config.Stack) { st.ID = id }
+}
+
+// WithStackName sets the name of the stack.
+func WithStackName(name string) StackOption {
+	return func(st *config.Stack) { st.Name = name }
+}
+
+// WithStackDescription sets the description of the stack.
+func WithStackDescription(desc string) StackOption {
+	return func(st *config.Stack) { st.Description = desc }
+}
+
+// WithStackTags sets the tags of the stack.
+func WithStackTags(tags ...string) StackOption {
+	return func(st *config.Stack) { st.Tags = tags }
+}
+
+// WithStackAfter sets the stacks that must run before the stack.
+func WithStackAfter(paths ...string) StackOption {
+	return func(st *config.Stack) { st.After = paths }
+}
+
+// WithStackBefore sets the stacks that must run after the stack.
+func WithStackBefore(paths ...string) StackOption {
+	return func(st *config.Stack) { st.Before = paths }
+}
+
+// CreateStackWith creates a stack dir with the given relative path and
+// initializes the stack configured by the given options, returning a stack
+// entry that can be used to create files inside the stack dir.
+//
+// If the path is absolute, it will be considered in relation to the sandbox
+// root dir.
+func (s S) CreateStackWith(relpath string, opts ...StackOption) StackEntry {
+	t := s.t
+	t.Helper()
+
+	if filepath.IsAbs(relpath) {
+		relpath = relpath[1:]
+	}
+
+	st := newStackEntry(t, s.RootDir(), relpath)
+	cfg := config.Stack{Dir: project.PrjAbsPath(s.RootDir(), st.Path())}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	assert.NoError(t, stack.Create(s.Config(), cfg))
+	return st
+}
+
+// CreateGlobals creates the GlobalsFilename file inside this dir entry with a
+// globals block built with the given attributes, eg.:
+//
+//	de.CreateGlobals(hclutils.Str("env", "prod"), hclutils.Number("replicas", 3))
+//
+// If the file already exists its contents will be truncated.
+func (de DirEntry) CreateGlobals(attrs ...hclwrite.BlockBuilder) FileEntry {
+	de.t.Helper()
+
+	return de.CreateFile(GlobalsFilename, "%s", hclutils.Globals(attrs...).String())
+}
+
+// Commit adds all the changed files of the sandbox and commits them, returning
+// the hash of the created commit. The sandbox must be a git repository.
+func (s S) Commit(msg string) string {
+	s.t.Helper()
+
+	git := s.Git()
+	git.CommitAll(msg)
+	return git.RevParse("HEAD")
+}
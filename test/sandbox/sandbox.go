@@ -9,6 +9,15 @@
 // - git initialization/operations
 // - Terraform module creation
 // - Terramate stack creation
+//
+// It is a supported API for testing Terramate projects, so teams embedding
+// Terramate can check their configuration conventions in their own Go test
+// suites, eg.:
+//
+//	s := sandbox.New(t)
+//	s.RootEntry().CreateGlobals(hclutils.Str("env", "prod"))
+//	s.CreateStackWith("stacks/app", sandbox.WithStackTags("app"))
+//	s.Commit("add app stack")
 package sandbox
 
 import (
@@ -188,8 +197,9 @@ func (s S) commitGitIgnore() {
 // For "run" the [:param1] is the working directory and [:param2] is the command
 // to be executed.
 //
-// This is an internal mini-lang used to simplify testcases, so it expects well
-// formed layout specification.
+// This is a mini-lang used to simplify testcases, so it expects well formed
+// layout specification. See CreateStackWith, CreateGlobals and Commit for a
+// programmatic alternative.
 func (s S) BuildTree(layout []string) {
 	s.t.Helper()
 
@@ -359,19 +369,9 @@ func (s S) CreateModule(relpath string) DirEntry {
 // If the path is absolute, it will be considered in relation to the sandbox
 // root dir.
 func (s S) CreateStack(relpath string) StackEntry {
-	t := s.t
-	t.Helper()
-
-	if filepath.IsAbs(relpath) {
-		relpath = relpath[1:]
-	}
+	s.t.Helper()
 
-	st := newStackEntry(t, s.RootDir(), relpath)
-	assert.NoError(t, stack.Create(
-		s.Config(),
-		config.Stack{Dir: project.PrjAbsPath(s.RootDir(), st.Path())},
-	))
-	return st
+	return s.CreateStackWith(relpath)
 }
 
 // StackEntry gets the stack entry of the stack identified by relpath.
@@ -696,6 +696,10 @@ func buildTree(t testing.TB, root *config.Root, environ []string, layout []strin
 				cfg.Stack.Description = value
 			case "tags":
 				cfg.Stack.Tags = parseListSpec(t, name, value)
+			case "extends":
+				cfg.Stack.Extends = value
+			case "exec_dir":
+				cfg.Stack.ExecDir = value
 			default:
 				t.Fatal("attribute " + parts[0] + " not supported.")
 			}
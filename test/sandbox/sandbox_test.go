@@ -6,6 +6,9 @@ package sandbox_test
 import (
 	"testing"
 
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/test/hclwrite/hclutils"
 	"github.com/terramate-io/terramate/test/sandbox"
 )
 
@@ -25,3 +28,30 @@ func TestSandboxWithArbitraryRepoConfig(t *testing.T) {
 	git.RevParse(localBranch)
 	git.RevParse(remote + "/" + remoteBranch)
 }
+
+func TestSandboxBuildProjectProgrammatically(t *testing.T) {
+	t.Parallel()
+
+	s := sandbox.New(t)
+	s.RootEntry().CreateGlobals(hclutils.Str("env", "prod"))
+	s.CreateStackWith("/stacks/app",
+		sandbox.WithStackName("app"),
+		sandbox.WithStackTags("app", "prod"),
+		sandbox.WithStackAfter("/stacks/db"),
+	)
+	s.CreateStackWith("stacks/db", sandbox.WithStackID("db"))
+	head := s.Commit("add stacks")
+
+	assert.EqualStrings(t, head, s.Git().RevParse("HEAD"))
+
+	app := s.LoadStack(project.NewPath("/stacks/app"))
+	assert.EqualStrings(t, "app", app.Name)
+	assert.EqualInts(t, 2, len(app.Tags))
+	assert.EqualStrings(t, "/stacks/db", app.After[0])
+
+	db := s.LoadStack(project.NewPath("/stacks/db"))
+	assert.EqualStrings(t, "db", db.ID)
+
+	globals := s.LoadStackGlobals(s.Config(), app)
+	assert.EqualStrings(t, "prod", globals.AsValueMap()["env"].AsString())
+}
@@ -3,7 +3,7 @@
 
 stack {
   name        = "package sandbox // import \"github.com/terramate-io/terramate/test/sandbox\""
-  description = "package sandbox // import \"github.com/terramate-io/terramate/test/sandbox\"\n\nPackage sandbox provides an easy way to setup isolated terramate projects that\ncan be used on testing, acting like sandboxes.\n\nIt helps with:\n\n- git initialization/operations - Terraform module creation - Terramate stack\ncreation\n\ntype AssertTreeOption func(o *assertTreeOptions)\n    func WithStrictStackValidation() AssertTreeOption\ntype DirEntry struct{ ... }\ntype FileEntry struct{ ... }\ntype Git struct{ ... }\n    func NewGit(t testing.TB, repodir string) *Git\n    func NewGitWithConfig(t testing.TB, cfg GitConfig) *Git\ntype GitConfig struct{ ... }\ntype S struct{ ... }\n    func New(t testing.TB) S\n    func NewWithGitConfig(t testing.TB, cfg GitConfig) S\n    func NoGit(t testing.TB, createProject bool) S\ntype StackEntry struct{ ... }"
+  description = "package sandbox // import \"github.com/terramate-io/terramate/test/sandbox\"\n\nPackage sandbox provides an easy way to setup isolated terramate projects that\ncan be used on testing, acting like sandboxes.\n\nIt helps with:\n\n- git initialization/operations - Terraform module creation - Terramate stack\ncreation\n\nIt is a supported API for testing Terramate projects, so teams embedding\nTerramate can check their configuration conventions in their own Go test suites,\neg.:\n\n    s := sandbox.New(t)\n    s.RootEntry().CreateGlobals(hclutils.Str(\"env\", \"prod\"))\n    s.CreateStackWith(\"stacks/app\", sandbox.WithStackTags(\"app\"))\n    s.Commit(\"add app stack\")\n\nconst GlobalsFilename = \"globals.tm\"\ntype AssertTreeOption func(o *assertTreeOptions)\n    func WithStrictStackValidation() AssertTreeOption\ntype DirEntry struct{ ... }\ntype FileEntry struct{ ... }\ntype Git struct{ ... }\n    func NewGit(t testing.TB, repodir string) *Git\n    func NewGitWithConfig(t testing.TB, cfg GitConfig) *Git\ntype GitConfig struct{ ... }\ntype S struct{ ... }\n    func New(t testing.TB) S\n    func NewWithGitConfig(t testing.TB, cfg GitConfig) S\n    func NoGit(t testing.TB, createProject bool) S\ntype StackEntry struct{ ... }\ntype StackOption func(st *config.Stack)\n    func WithStackAfter(paths ...string) StackOption\n    func WithStackBefore(paths ...string) StackOption\n    func WithStackDescription(desc string) StackOption\n    func WithStackID(id string) StackOption\n    func WithStackName(name string) StackOption\n    func WithStackTags(tags ...string) StackOption"
   tags        = ["golang", "sandbox", "test"]
   id          = "b88ff761-d470-4bab-b830-c7dcc54e93f4"
 }
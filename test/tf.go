@@ -10,11 +10,11 @@ import (
 	"github.com/terramate-io/terramate/tf"
 )
 
-// ParseSource calls [tf.ParseSource] failing the test if it fails.
+// ParseSource calls [tf.ParseVendorSource] failing the test if it fails.
 func ParseSource(t *testing.T, source string) tf.Source {
 	t.Helper()
 
-	modsrc, err := tf.ParseSource(source)
+	modsrc, err := tf.ParseVendorSource(source)
 	assert.NoError(t, err)
 	return modsrc
 }
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "tf" {
-  content = <<-EOT
+  content = <<-EOF
 package tf // import "github.com/terramate-io/terramate/tf"
 
 Package tf provides parsing and abstractions specific to Terraform.
@@ -13,7 +13,7 @@ type Module struct{ ... }
     func ParseModules(path string) ([]Module, error)
 type Source struct{ ... }
     func ParseSource(modsource string) (Source, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-tf.ignore"
 }
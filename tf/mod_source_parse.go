@@ -28,8 +28,17 @@ type Source struct {
 	Subdir string
 
 	// Ref is the specific reference of this source, if any.
+	// For HTTP sources it is the hex encoded checksum of the file.
 	Ref string
 
+	// Checksum is the checksum pinning the file of HTTP sources, in the
+	// format <type>:<hex>. It is empty for Git sources.
+	Checksum string
+
+	// Archive is the archive format of HTTP sources, if the downloaded file
+	// must be extracted, or empty if the file is vendored as is.
+	Archive string
+
 	// Raw source
 	Raw string
 }
@@ -42,6 +51,132 @@ const (
 	ErrInvalidModSrc errors.Kind = "invalid module source"
 )
 
+// Supported archive formats of HTTP sources.
+const (
+	ArchiveZip   = "zip"
+	ArchiveTar   = "tar"
+	ArchiveTarGz = "tar.gz"
+)
+
+// Supported checksum types of HTTP sources.
+const (
+	ChecksumSHA256 = "sha256"
+	ChecksumSHA512 = "sha512"
+)
+
+// IsHTTP tells if the source is a file downloaded with HTTP(S) instead of a
+// Git repository.
+func (s Source) IsHTTP() bool {
+	return s.Checksum != ""
+}
+
+// Filename returns the name of the vendored file for HTTP sources that are not
+// archives, or an empty string otherwise.
+func (s Source) Filename() string {
+	if !s.IsHTTP() || s.Archive != "" {
+		return ""
+	}
+	return path.Base(s.Path)
+}
+
+// ParseVendorSource parses the given source of a vendored dependency.
+// Besides the module sources supported by [ParseSource], it supports
+// HTTP(S) URLs of arbitrary files and archives, which must be pinned with a
+// checksum query parameter, like:
+//
+//	https://example.com/policies.tar.gz?checksum=sha256:<hex>
+//
+// Archives are detected by the file extension (.zip, .tar, .tar.gz and .tgz)
+// or by the archive query parameter. Use archive=false to vendor an archive
+// file without extracting it.
+func ParseVendorSource(source string) (Source, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		return ParseSource(source)
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return Source{}, errors.E(ErrInvalidModSrc, err, "%s is not a URL", source)
+	}
+	if u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		return Source{}, errors.E(ErrInvalidModSrc,
+			"source %q is missing the file name", source)
+	}
+	query := u.Query()
+	checksum := query.Get("checksum")
+	if checksum == "" {
+		return Source{}, errors.E(ErrInvalidModSrc,
+			"source %q must be pinned with a checksum=<type>:<hex> query parameter", source)
+	}
+	ctype, digest, ok := strings.Cut(checksum, ":")
+	digest = strings.ToLower(digest)
+	if !ok || !validChecksum(ctype, digest) {
+		return Source{}, errors.E(ErrInvalidModSrc,
+			"source %q has invalid checksum %q: expected %s:<hex> or %s:<hex>",
+			source, checksum, ChecksumSHA256, ChecksumSHA512)
+	}
+	archive, err := archiveFormat(u.Path, query.Get("archive"))
+	if err != nil {
+		return Source{}, errors.E(ErrInvalidModSrc, err, "source %q", source)
+	}
+	query.Del("checksum")
+	query.Del("archive")
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	return Source{
+		Raw:        source,
+		URL:        u.String(),
+		Path:       path.Join(strings.Replace(u.Host, ":", "-", -1), u.Path),
+		PathScheme: u.Scheme,
+		Ref:        digest,
+		Checksum:   ctype + ":" + digest,
+		Archive:    archive,
+	}, nil
+}
+
+func validChecksum(ctype, digest string) bool {
+	var size int
+	switch ctype {
+	case ChecksumSHA256:
+		size = 64
+	case ChecksumSHA512:
+		size = 128
+	default:
+		return false
+	}
+	if len(digest) != size {
+		return false
+	}
+	for _, r := range digest {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func archiveFormat(urlpath, param string) (string, error) {
+	switch param {
+	case "":
+	case "false":
+		return "", nil
+	case ArchiveZip, ArchiveTar, ArchiveTarGz:
+		return param, nil
+	case "tgz":
+		return ArchiveTarGz, nil
+	default:
+		return "", errors.E("unsupported archive format %q", param)
+	}
+	switch {
+	case strings.HasSuffix(urlpath, ".zip"):
+		return ArchiveZip, nil
+	case strings.HasSuffix(urlpath, ".tar"):
+		return ArchiveTar, nil
+	case strings.HasSuffix(urlpath, ".tar.gz"), strings.HasSuffix(urlpath, ".tgz"):
+		return ArchiveTarGz, nil
+	}
+	return "", nil
+}
+
 // ParseSource parses the given modsource string.
 // The modsource must be a valid Terraform Git/Github source reference as documented in:
 //
@@ -4,6 +4,7 @@
 package tf_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/madlambda/spells/assert"
@@ -490,3 +491,114 @@ func TestParseGitSources(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVendorSources(t *testing.T) {
+	t.Parallel()
+
+	const sha256sum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	type testcase struct {
+		name   string
+		source string
+		want   tf.Source
+		err    error
+	}
+
+	tcases := []testcase{
+		{
+			name:   "git sources are parsed as module sources",
+			source: "github.com/terramate-io/example?ref=v1",
+			want: tf.Source{
+				URL:        "https://github.com/terramate-io/example.git",
+				Path:       "github.com/terramate-io/example",
+				PathScheme: "https",
+				Ref:        "v1",
+			},
+		},
+		{
+			name:   "plain file",
+			source: "https://example.com/policies/deny.rego?checksum=sha256:" + sha256sum,
+			want: tf.Source{
+				URL:        "https://example.com/policies/deny.rego",
+				Path:       "example.com/policies/deny.rego",
+				PathScheme: "https",
+				Ref:        sha256sum,
+				Checksum:   "sha256:" + sha256sum,
+			},
+		},
+		{
+			name:   "archive detected by extension keeps other query parameters",
+			source: "http://example.com:8080/protos.tgz?version=2&checksum=sha256:" + sha256sum,
+			want: tf.Source{
+				URL:        "http://example.com:8080/protos.tgz?version=2",
+				Path:       "example.com-8080/protos.tgz",
+				PathScheme: "http",
+				Ref:        sha256sum,
+				Checksum:   "sha256:" + sha256sum,
+				Archive:    tf.ArchiveTarGz,
+			},
+		},
+		{
+			name:   "archive format given explicitly",
+			source: "https://example.com/download?archive=zip&checksum=sha256:" + strings.ToUpper(sha256sum),
+			want: tf.Source{
+				URL:        "https://example.com/download",
+				Path:       "example.com/download",
+				PathScheme: "https",
+				Ref:        sha256sum,
+				Checksum:   "sha256:" + sha256sum,
+				Archive:    tf.ArchiveZip,
+			},
+		},
+		{
+			name:   "archive extraction disabled",
+			source: "https://example.com/bundle.zip?archive=false&checksum=sha256:" + sha256sum,
+			want: tf.Source{
+				URL:        "https://example.com/bundle.zip",
+				Path:       "example.com/bundle.zip",
+				PathScheme: "https",
+				Ref:        sha256sum,
+				Checksum:   "sha256:" + sha256sum,
+			},
+		},
+		{
+			name:   "missing checksum",
+			source: "https://example.com/bundle.zip",
+			err:    errors.E(tf.ErrInvalidModSrc),
+		},
+		{
+			name:   "invalid checksum type",
+			source: "https://example.com/bundle.zip?checksum=md5:" + sha256sum,
+			err:    errors.E(tf.ErrInvalidModSrc),
+		},
+		{
+			name:   "invalid checksum size",
+			source: "https://example.com/bundle.zip?checksum=sha256:abcd",
+			err:    errors.E(tf.ErrInvalidModSrc),
+		},
+		{
+			name:   "unsupported archive",
+			source: "https://example.com/bundle.rar?archive=rar&checksum=sha256:" + sha256sum,
+			err:    errors.E(tf.ErrInvalidModSrc),
+		},
+		{
+			name:   "missing file name",
+			source: "https://example.com/?checksum=sha256:" + sha256sum,
+			err:    errors.E(tf.ErrInvalidModSrc),
+		},
+	}
+
+	for _, tcase := range tcases {
+		tcase := tcase
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tf.ParseVendorSource(tcase.source)
+			assert.IsError(t, err, tcase.err)
+			if tcase.err != nil {
+				return
+			}
+			tcase.want.Raw = tcase.source
+			test.AssertDiff(t, got, tcase.want)
+		})
+	}
+}
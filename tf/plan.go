@@ -0,0 +1,38 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package tf
+
+import (
+	"encoding/json"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/tfjson"
+)
+
+// PlanHasChanges tells if the JSON plan, as shown by `terraform show -json`,
+// changes any resource or output. Reading data sources is not a change.
+func PlanHasChanges(jsonPlan []byte) (bool, error) {
+	var plan tfjson.Plan
+	if err := json.Unmarshal(jsonPlan, &plan); err != nil {
+		return false, errors.E(err, "unmarshaling Terraform JSON plan")
+	}
+	if err := plan.Validate(); err != nil {
+		return false, errors.E(err, "validating Terraform JSON plan")
+	}
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change != nil && isChange(rc.Change.Actions) {
+			return true, nil
+		}
+	}
+	for _, change := range plan.OutputChanges {
+		if change != nil && isChange(change.Actions) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isChange(actions tfjson.Actions) bool {
+	return len(actions) > 0 && !actions.NoOp() && !actions.Read()
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package tf_test
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/tf"
+)
+
+func TestPlanHasChanges(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		plan    string
+		want    bool
+		wantErr bool
+	}
+
+	for _, tc := range []testcase{
+		{
+			name: "empty plan",
+			plan: `{"format_version": "1.2"}`,
+		},
+		{
+			name: "no-op and read actions",
+			plan: `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "null_resource.a", "change": {"actions": ["no-op"]}},
+    {"address": "data.external.b", "change": {"actions": ["read"]}}
+  ],
+  "output_changes": {
+    "out": {"actions": ["no-op"]}
+  }
+}`,
+		},
+		{
+			name: "resource change",
+			plan: `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "null_resource.a", "change": {"actions": ["no-op"]}},
+    {"address": "null_resource.b", "change": {"actions": ["delete", "create"]}}
+  ]
+}`,
+			want: true,
+		},
+		{
+			name: "output change",
+			plan: `{
+  "format_version": "1.2",
+  "output_changes": {
+    "out": {"actions": ["update"]}
+  }
+}`,
+			want: true,
+		},
+		{
+			name:    "invalid JSON",
+			plan:    `{`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format version",
+			plan:    `{"format_version": "99.0"}`,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tf.PlanHasChanges([]byte(tc.plan))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.IsTrue(t, got == tc.want, "want %t but got %t", tc.want, got)
+		})
+	}
+}
@@ -5,6 +5,8 @@ package tf
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/hcl/v2/hclparse"
@@ -23,6 +25,20 @@ type Module struct {
 // ErrHCLSyntax represents a HCL syntax error
 const ErrHCLSyntax errors.Kind = "HCL syntax error"
 
+// Extensions of the Terraform Stacks configuration files.
+const (
+	StackFileExt      = ".tfstack.hcl"
+	DeploymentFileExt = ".tfdeploy.hcl"
+)
+
+// IsConfigFile tells if the file is a Terraform configuration file, which
+// is either a classic .tf file or a Terraform Stacks file.
+func IsConfigFile(fname string) bool {
+	return filepath.Ext(fname) == ".tf" ||
+		strings.HasSuffix(fname, StackFileExt) ||
+		strings.HasSuffix(fname, DeploymentFileExt)
+}
+
 // IsLocal tells if module source is a local directory.
 func (m Module) IsLocal() bool {
 	// As specified here: https://www.terraform.io/docs/language/modules/sources.html#local-paths
@@ -31,6 +47,8 @@ func (m Module) IsLocal() bool {
 }
 
 // ParseModules parses blocks of type "module" containing a single label.
+// The "component" blocks of Terraform Stacks files are also parsed as
+// modules, as they are also instances of modules defined by their source.
 func ParseModules(path string) ([]Module, error) {
 	logger := log.With().
 		Str("action", "ParseModules()").
@@ -55,7 +73,7 @@ func ParseModules(path string) ([]Module, error) {
 
 	var modules []Module
 	for _, block := range body.Blocks {
-		if block.Type != "module" {
+		if block.Type != "module" && block.Type != "component" {
 			continue
 		}
 
@@ -64,24 +82,24 @@ func ParseModules(path string) ([]Module, error) {
 		if len(block.Labels) == 1 {
 			moduleName = block.Labels[0]
 		} else {
-			logger.Debug().Msgf("ignoring module block with %d labels", len(block.Labels))
+			logger.Debug().Msgf("ignoring %s block with %d labels", block.Type, len(block.Labels))
 
 			continue
 		}
 		logger = logger.With().
-			Str("module", moduleName).
+			Str(block.Type, moduleName).
 			Logger()
 
 		source, ok, err := findStringAttr(block, "source")
 		if err != nil {
 			logger.Debug().
 				Err(err).
-				Msg("ignoring module block without source")
+				Msgf("ignoring %s block without source", block.Type)
 
 			continue
 		}
 		if !ok {
-			logger.Debug().Msg("ignoring module block without source")
+			logger.Debug().Msgf("ignoring %s block without source", block.Type)
 
 			continue
 		}
@@ -92,7 +110,8 @@ func ParseModules(path string) ([]Module, error) {
 }
 
 // IsStack tells if the file defined by path is a potential stack.
-// Eg.: has a backend block or a provider block.
+// Eg.: has a backend block or a provider block, or it's a Terraform Stacks
+// file with component or deployment blocks.
 func IsStack(path string) (bool, error) {
 	logger := log.With().
 		Str("action", "IsStack").
@@ -119,7 +138,7 @@ func IsStack(path string) (bool, error) {
 
 				return true, nil
 			}
-		case "provider":
+		case "provider", "component", "deployment":
 			return true, nil
 		}
 	}
@@ -151,3 +170,76 @@ func findStringAttr(block *hclsyntax.Block, attrName string) (string, bool, erro
 
 	return "", false, nil
 }
+
+// RequiredProvider is a provider requirement declared in the
+// required_providers block of a Terraform module.
+type RequiredProvider struct {
+	Name    string
+	Source  string
+	Version string
+}
+
+// ParseRequiredProviders parses the provider requirements declared in the
+// required_providers blocks of the terraform blocks of the file or, for
+// Terraform Stacks files, in the top-level required_providers blocks.
+// The legacy syntax, where the requirement is the version constraint string,
+// is also supported.
+func ParseRequiredProviders(path string) ([]RequiredProvider, error) {
+	p := hclparse.NewParser()
+	f, diags := p.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, errors.E(ErrHCLSyntax, diags)
+	}
+
+	body := f.Body.(*hclsyntax.Body)
+
+	var reqBlocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "required_providers" && strings.HasSuffix(path, StackFileExt):
+			reqBlocks = append(reqBlocks, block)
+		case block.Type == "terraform":
+			for _, reqBlock := range block.Body.Blocks {
+				if reqBlock.Type == "required_providers" {
+					reqBlocks = append(reqBlocks, reqBlock)
+				}
+			}
+		}
+	}
+
+	var providers []RequiredProvider
+	for _, reqBlock := range reqBlocks {
+		attrs := ast.AsHCLAttributes(reqBlock.Body.Attributes)
+		for _, attr := range ast.SortRawAttributes(attrs) {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, errors.E(diags, "evaluating required provider %s", attr.Name)
+			}
+			provider := RequiredProvider{Name: attr.Name}
+			switch {
+			case val.Type() == cty.String:
+				provider.Version = val.AsString()
+			case val.Type().IsObjectType():
+				for name, field := range map[string]*string{
+					"source":  &provider.Source,
+					"version": &provider.Version,
+				} {
+					if !val.Type().HasAttribute(name) {
+						continue
+					}
+					attrVal := val.GetAttr(name)
+					if attrVal.Type() != cty.String {
+						return nil, errors.E(attr.Expr.Range(),
+							"required provider %s.%s is not a string", attr.Name, name)
+					}
+					*field = attrVal.AsString()
+				}
+			default:
+				return nil, errors.E(attr.Expr.Range(),
+					"required provider %s must be a string or an object", attr.Name)
+			}
+			providers = append(providers, provider)
+		}
+	}
+	return providers, nil
+}
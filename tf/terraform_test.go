@@ -134,6 +134,32 @@ module "bleh" {
 				},
 			},
 		},
+		{
+			name: "terraform stacks components",
+			input: cfgfile{
+				filename: "main.tfstack.hcl",
+				body: `
+component "vpc" {
+	source = "./modules/vpc"
+}
+component "cluster" {
+	for_each = var.regions
+	source   = "app.terraform.io/org/cluster/aws"
+}
+provider "aws" "this" {}
+`,
+			},
+			want: want{
+				modules: []tf.Module{
+					{
+						Source: "./modules/vpc",
+					},
+					{
+						Source: "app.terraform.io/org/cluster/aws",
+					},
+				},
+			},
+		},
 		{
 			name: "ignored if source is not a string",
 			input: cfgfile{
@@ -348,6 +374,28 @@ func TestTerraformHasBackend(t *testing.T) {
 				isStack: true,
 			},
 		},
+		{
+			name: "terraform stacks component block defined",
+			layout: []string{
+				tfFileLayout(`component "vpc" {
+					source = "./modules/vpc"
+				}`),
+			},
+			want: want{
+				isStack: true,
+			},
+		},
+		{
+			name: "terraform stacks deployment block defined",
+			layout: []string{
+				tfFileLayout(`deployment "production" {
+					inputs = {}
+				}`),
+			},
+			want: want{
+				isStack: true,
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -372,6 +420,21 @@ func TestTerraformHasBackend(t *testing.T) {
 	}
 }
 
+func TestIsConfigFile(t *testing.T) {
+	t.Parallel()
+
+	for fname, want := range map[string]bool{
+		"main.tf":                  true,
+		"components.tfstack.hcl":   true,
+		"deployments.tfdeploy.hcl": true,
+		"stack.tm.hcl":             false,
+		"main.tf.json":             false,
+		"tfstack.hcl.bak":          false,
+	} {
+		assert.IsTrue(t, tf.IsConfigFile(fname) == want, "IsConfigFile(%q) != %t", fname, want)
+	}
+}
+
 // some helpers to easy build file ranges.
 func mkrange(fname string, start, end hhcl.Pos) hhcl.Range {
 	if start.Byte == end.Byte {
@@ -405,3 +468,98 @@ var end = start
 func init() {
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
+
+func TestParseRequiredProviders(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name     string
+		filename string
+		content  string
+		want     []tf.RequiredProvider
+		wantErr  bool
+	}
+
+	for _, tc := range []testcase{
+		{
+			name:    "no terraform block",
+			content: `resource "null_resource" "a" {}`,
+		},
+		{
+			name:     "terraform stacks file",
+			filename: "providers.tfstack.hcl",
+			content: `required_providers {
+				aws = {
+					source  = "hashicorp/aws"
+					version = "~> 5.0"
+				}
+			}`,
+			want: []tf.RequiredProvider{
+				{Name: "aws", Source: "hashicorp/aws", Version: "~> 5.0"},
+			},
+		},
+		{
+			name: "top-level required_providers outside terraform stacks files",
+			content: `required_providers {
+				aws = {
+					source = "hashicorp/aws"
+				}
+			}`,
+		},
+		{
+			name: "object and legacy syntax",
+			content: `terraform {
+				required_providers {
+					null = ">= 3.0"
+					aws = {
+						source  = "hashicorp/aws"
+						version = "~> 5.0"
+					}
+					random = {
+						source = "hashicorp/random"
+					}
+				}
+			}`,
+			want: []tf.RequiredProvider{
+				{Name: "aws", Source: "hashicorp/aws", Version: "~> 5.0"},
+				{Name: "null", Version: ">= 3.0"},
+				{Name: "random", Source: "hashicorp/random"},
+			},
+		},
+		{
+			name: "invalid requirement",
+			content: `terraform {
+				required_providers {
+					aws = 1
+				}
+			}`,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := tc.filename
+			if filename == "" {
+				filename = "main.tf"
+			}
+			dir := test.TempDir(t)
+			test.WriteFile(t, dir, filename, tc.content)
+			got, err := tf.ParseRequiredProviders(filepath.Join(dir, filename))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualInts(t, len(tc.want), len(got), "number of providers: %v", got)
+			for i, want := range tc.want {
+				if got[i] != want {
+					t.Errorf("provider %d: want %+v but got %+v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
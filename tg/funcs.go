@@ -34,8 +34,8 @@ import (
 	tgconfig "github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/mitchellh/go-homedir"
-	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 )
@@ -239,9 +239,12 @@ func tgFileFuncImpl(_ *tgconfig.ParsingContext, rootdir string, mod *Module) fun
 				path = filepath.Join(basedir, path)
 			}
 			if path != rootdir && !strings.HasPrefix(path, rootdir+string(filepath.Separator)) {
-				printer.Stderr.WarnWithDetails("Terramate change detection cannot track files outside the project. Ignoring",
+				err := warnings.Emit(warnings.OutsideProject, "Terramate change detection cannot track files outside the project. Ignoring",
 					tmerrors.E("The file(%q) is outside project root %q", path, rootdir),
 				)
+				if err != nil {
+					return cty.NilVal, err
+				}
 			} else {
 				mod.DependsOn = append(mod.DependsOn, project.PrjAbsPath(rootdir, path))
 			}
@@ -19,8 +19,8 @@ import (
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/rs/zerolog/log"
 	"github.com/terramate-io/terramate/errors"
-	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/project"
+	"github.com/terramate-io/terramate/warnings"
 	"github.com/zclconf/go-cty/cty/function"
 )
 
@@ -30,7 +30,10 @@ const ErrParsing errors.Kind = "parsing Terragrunt file"
 type (
 	// Module is a Terragrunt module.
 	Module struct {
-		Path       project.Path  `json:"path"`
+		Path project.Path `json:"path"`
+
+		// Source is the terraform.source of the module. It's empty if the
+		// module runs the Terraform files of its own directory.
 		Source     string        `json:"source"`
 		ConfigFile project.Path  `json:"config"`
 		After      project.Paths `json:"after,omitempty"`
@@ -112,13 +115,25 @@ func LoadModule(rootdir string, dir project.Path, fname string, trackDependencie
 		return nil, false, errors.E(ErrParsing, err, "parsing module at %s", cfgOpts.WorkingDir)
 	}
 
-	if tgConfig.Terraform == nil || tgConfig.Terraform.Source == nil {
-		// not a runnable module
-		return nil, false, nil
+	var source string
+	if tgConfig.Terraform != nil && tgConfig.Terraform.Source != nil {
+		source = *tgConfig.Terraform.Source
+		logger.Trace().Msgf("found terraform.source = %q", source)
+	} else {
+		// Like Terragrunt, a module without terraform.source runs the Terraform
+		// files of its directory, which is common in repositories migrating
+		// between Terragrunt and Terramate. Otherwise it's not a runnable
+		// module (eg.: a root configuration included by other modules).
+		tfFiles, err := filepath.Glob(filepath.Join(absDir, "*.tf"))
+		if err != nil {
+			return nil, false, errors.E(err, "looking for Terraform files in %s", absDir)
+		}
+		if len(tfFiles) == 0 {
+			return nil, false, nil
+		}
+		logger.Trace().Msg("found module without terraform.source")
 	}
 
-	logger.Trace().Msgf("found terraform.source = %q", *tgConfig.Terraform.Source)
-
 	stack, err := configstack.FindStackInSubfolders(cfgOpts, nil)
 	if err != nil {
 		return nil, false, errors.E(err, "parsing module at %s", cfgOpts.WorkingDir)
@@ -141,7 +156,7 @@ func LoadModule(rootdir string, dir project.Path, fname string, trackDependencie
 		panic(errors.E(errors.ErrInternal, "%s found but module not found in subfolders. Please report this bug.", cfgfile))
 	}
 
-	mod.Source = *tgConfig.Terraform.Source
+	mod.Source = source
 
 	dependsOn := map[project.Path]struct{}{}
 
@@ -195,7 +210,9 @@ func LoadModule(rootdir string, dir project.Path, fname string, trackDependencie
 				Msg("found dependency (in dependency.config_path)")
 
 			if depAbsPath != rootdir && !strings.HasPrefix(depAbsPath, rootdir+string(filepath.Separator)) {
-				warnDependencyOutsideProject(mod, depAbsPath, "dependency.config_path")
+				if err := warnDependencyOutsideProject(mod, depAbsPath, "dependency.config_path"); err != nil {
+					return nil, true, err
+				}
 
 				continue
 			}
@@ -226,7 +243,9 @@ func LoadModule(rootdir string, dir project.Path, fname string, trackDependencie
 				Msg("found dependency (in dependencies.paths)")
 
 			if depPath != rootdir && !strings.HasPrefix(depAbsPath, rootdir+string(filepath.Separator)) {
-				warnDependencyOutsideProject(mod, depAbsPath, "dependencies.paths")
+				if err := warnDependencyOutsideProject(mod, depAbsPath, "dependencies.paths"); err != nil {
+					return nil, true, err
+				}
 
 				continue
 			}
@@ -334,8 +353,8 @@ func newTerragruntOptions(dir string, cfgfile string) *options.TerragruntOptions
 	return opts
 }
 
-func warnDependencyOutsideProject(mod *Module, dep string, field string) {
-	printer.Stderr.WarnWithDetails(fmt.Sprintf("Dependency outside of Terramate project detected in `%s` configuration. Ignoring.", field),
+func warnDependencyOutsideProject(mod *Module, dep string, field string) error {
+	return warnings.Emit(warnings.OutsideProject, fmt.Sprintf("Dependency outside of Terramate project detected in `%s` configuration. Ignoring.", field),
 		errors.E("The Terragrunt module %s depends on the module at %s, which is located outside of the your current"+
 			" Terramate project. To resolve this ensure the dependent module is part of your Terramate project.",
 			mod.Path, dep),
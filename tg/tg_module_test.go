@@ -47,6 +47,27 @@ func TestTerragruntScanModules(t *testing.T) {
 				`f:terragrunt.hcl:` + Block("terraform").String(),
 			},
 		},
+		{
+			name: "config without terraform.source in directory with Terraform files",
+			layout: []string{
+				`f:terragrunt.hcl:` + Block("remote_state",
+					Str("backend", "local"),
+				).String(),
+				`f:stack/main.tf:# empty file`,
+				`f:stack/terragrunt.hcl:` + Block("include",
+					Expr("path", `find_in_parent_folders()`),
+				).String(),
+			},
+			want: want{
+				modules: tg.Modules{
+					{
+						Path:       project.NewPath("/stack"),
+						ConfigFile: project.NewPath("/stack/terragrunt.hcl"),
+						DependsOn:  project.Paths{project.NewPath("/terragrunt.hcl")},
+					},
+				},
+			},
+		},
 		{
 			name: "invalid configuration",
 			layout: []string{
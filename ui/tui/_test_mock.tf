@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "tui" {
-  content = <<-EOT
+  content = <<-EOF
 package tui // import "github.com/terramate-io/terramate/ui/tui"
 
 Package tui provides the Terminal User Interface (TUI) of the CLI.
@@ -27,7 +27,7 @@ type Option func(*CLI) error
     func WithStdin(r io.Reader) Option
     func WithStdout(w io.Writer) Option
     func WithVersion(v string) Option
-EOT
+EOF
 
   filename = "${path.module}/mock-tui.ignore"
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/terramate-io/terramate/ui/tui/out"
 
 	tel "github.com/terramate-io/terramate/ui/tui/telemetry"
+	"github.com/terramate-io/terramate/warnings"
 )
 
 // CLI is the Terramate command-line interface opaque type.
@@ -87,6 +88,10 @@ type state struct {
 	uimode  engine.UIMode
 
 	changeDetectionEnabled bool
+
+	// warningsReport is the file where the emitted warnings are written as
+	// JSON, if any.
+	warningsReport string
 }
 
 // Option is a function that modifies the CLI behavior.
@@ -183,6 +188,9 @@ func (c *CLI) Exec(args []string) {
 	_ = ConfigureLogging(defaultLogLevel, defaultLogFmt, defaultLogDest,
 		c.state.stdout, c.state.stderr)
 
+	// the warnings are collected per command.
+	warnings.Reset()
+
 	if len(args) == 0 {
 		// WHY: avoid default kong error, print help
 		args = []string{"--help"}
@@ -253,6 +261,9 @@ func (c *CLI) Exec(args []string) {
 		if err != nil {
 			printer.Stderr.FatalWithDetails(fmt.Sprintf("executing %q", cmd.Name()), err)
 		}
+		if err := c.checkWarnings(); err != nil {
+			printer.Stderr.FatalWithDetails("warnings promoted to errors", err)
+		}
 		return
 	}
 
@@ -281,6 +292,13 @@ Please see https://terramate.io/docs/cli/configuration/project-setup for details
 	defer c.sendAndWaitForAnalytics()
 
 	c.state.engine = engine
+
+	// the warnings emitted while loading the configuration fail the command
+	// before it executes anything.
+	if err := c.checkWarnings(); err != nil {
+		printer.Stderr.FatalWithDetails("warnings promoted to errors", err)
+	}
+
 	cmd, found, cont, err := c.afterConfigHandler(ctx, c)
 	if err != nil {
 		printer.Stderr.Fatal(err)
@@ -295,9 +313,32 @@ Please see https://terramate.io/docs/cli/configuration/project-setup for details
 	}
 
 	err = cmd.Exec(context.TODO())
+	werr := c.checkWarnings()
 	if err != nil {
 		printer.Stderr.Fatal(err)
 	}
+	if werr != nil {
+		printer.Stderr.FatalWithDetails("warnings promoted to errors", werr)
+	}
+}
+
+// checkWarnings writes the emitted warnings to the warnings report, if
+// requested, and fails if any of them is promoted to error by the
+// terramate.config.warnings.as_errors configuration.
+func (c *CLI) checkWarnings() error {
+	if c.state.warningsReport != "" {
+		if err := warnings.Default.WriteJSON(c.state.warningsReport); err != nil {
+			return err
+		}
+	}
+	if c.state.engine == nil {
+		return nil
+	}
+	cfg := c.state.engine.RootNode()
+	if cfg.Terramate == nil || cfg.Terramate.Config == nil || cfg.Terramate.Config.Warnings == nil {
+		return nil
+	}
+	return warnings.Default.Promoted(cfg.Terramate.Config.Warnings.AsErrors)
 }
 
 // InitAnalytics initializes the analytics record.
@@ -356,19 +397,7 @@ func (c *CLI) isTelemetryEnabled() bool {
 }
 
 func (c *CLI) cloudOrgName() string {
-	orgName := os.Getenv("TM_CLOUD_ORGANIZATION")
-	if orgName != "" {
-		return orgName
-	}
-
-	cfg := c.state.engine.Config().Tree().Node
-	if cfg.Terramate != nil &&
-		cfg.Terramate.Config != nil &&
-		cfg.Terramate.Config.Cloud != nil {
-		return cfg.Terramate.Config.Cloud.Organization
-	}
-
-	return ""
+	return c.state.engine.CloudOrgName()
 }
 
 // ConfigureLogging configures Terramate global logging.
@@ -398,7 +427,7 @@ func ConfigureLogging(logLevel, logFmt, logdest string, stdout, stderr io.Writer
 	case "text": // no color
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: output, NoColor: true, TimeFormat: time.RFC3339})
 	default: // default: console mode using color
-		log.Logger = log.Output(zerolog.ConsoleWriter{Out: output, NoColor: false, TimeFormat: time.RFC3339})
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: output, NoColor: !printer.ColorEnabled(), TimeFormat: time.RFC3339})
 	}
 	return nil
 }
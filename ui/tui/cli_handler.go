@@ -14,18 +14,40 @@ import (
 	"github.com/terramate-io/terramate/commands"
 	clonecmd "github.com/terramate-io/terramate/commands/clone"
 	clouddriftshowcmd "github.com/terramate-io/terramate/commands/cloud/drift/show"
+	cloudflushcmd "github.com/terramate-io/terramate/commands/cloud/flush"
 	cloudinfocmd "github.com/terramate-io/terramate/commands/cloud/info"
 	logincmd "github.com/terramate-io/terramate/commands/cloud/login"
+	cloudorgcmd "github.com/terramate-io/terramate/commands/cloud/org"
+	cloudsyncinventorycmd "github.com/terramate-io/terramate/commands/cloud/sync/inventory"
 	compcmd "github.com/terramate-io/terramate/commands/completions"
+	debugshowconfigcmd "github.com/terramate-io/terramate/commands/debug/show/config"
+	debugshowfunctionscmd "github.com/terramate-io/terramate/commands/debug/show/functions"
 	generateoriginscmd "github.com/terramate-io/terramate/commands/debug/show/generate_origins"
 	debugglobalscmd "github.com/terramate-io/terramate/commands/debug/show/globals"
+	debugshowimports "github.com/terramate-io/terramate/commands/debug/show/imports"
 	debugshowmetadatacmd "github.com/terramate-io/terramate/commands/debug/show/metadata"
+	debugshowrunenv "github.com/terramate-io/terramate/commands/debug/show/run_env"
 	debugshowruntimeenv "github.com/terramate-io/terramate/commands/debug/show/runtime_env"
+	docscmd "github.com/terramate-io/terramate/commands/experimental/docs"
+	driftruncmd "github.com/terramate-io/terramate/commands/experimental/driftrun"
 	evalcmd "github.com/terramate-io/terramate/commands/experimental/eval"
+	gitdriverscmd "github.com/terramate-io/terramate/commands/experimental/gitdrivers"
+	grepcmd "github.com/terramate-io/terramate/commands/experimental/grep"
+	impactcmd "github.com/terramate-io/terramate/commands/experimental/impact"
+	importsverifycmd "github.com/terramate-io/terramate/commands/experimental/importsverify"
+	lintcmd "github.com/terramate-io/terramate/commands/experimental/lint"
+	providerscheckcmd "github.com/terramate-io/terramate/commands/experimental/providerscheck"
+	reruncmd "github.com/terramate-io/terramate/commands/experimental/rerun"
+	rewritemetadatacmd "github.com/terramate-io/terramate/commands/experimental/rewritemetadata"
 	rungraphcmd "github.com/terramate-io/terramate/commands/experimental/rungraph"
+	runordercmd "github.com/terramate-io/terramate/commands/experimental/runorder"
+	servecmd "github.com/terramate-io/terramate/commands/experimental/serve"
+	unlockcmd "github.com/terramate-io/terramate/commands/experimental/unlock"
+	upgradeconfigcmd "github.com/terramate-io/terramate/commands/experimental/upgradeconfig"
 	vendordownloadcmd "github.com/terramate-io/terramate/commands/experimental/vendordownload"
 	fmtcmd "github.com/terramate-io/terramate/commands/fmt"
 	gencmd "github.com/terramate-io/terramate/commands/generate"
+	initprojectcmd "github.com/terramate-io/terramate/commands/initproject"
 	reqvercmd "github.com/terramate-io/terramate/commands/requiredversion"
 	runcmd "github.com/terramate-io/terramate/commands/run"
 	scriptinfocmd "github.com/terramate-io/terramate/commands/script/info"
@@ -40,11 +62,15 @@ import (
 	"github.com/terramate-io/terramate/config/filter"
 	"github.com/terramate-io/terramate/engine"
 	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/hcl"
+	"github.com/terramate-io/terramate/http"
 	"github.com/terramate-io/terramate/printer"
 	"github.com/terramate-io/terramate/safeguard"
+	"github.com/terramate-io/terramate/stdlib"
 	"github.com/terramate-io/terramate/ui/tui/cliconfig"
 	"github.com/terramate-io/terramate/ui/tui/clitest"
 	"github.com/terramate-io/terramate/ui/tui/out"
+	"github.com/terramate-io/terramate/ui/tui/progress"
 
 	"github.com/alecthomas/kong"
 
@@ -58,6 +84,19 @@ type handlerState struct {
 	tags filter.TagClause
 }
 
+// configureOutput configures the theme, colors and emoji of the printers.
+func configureOutput(parsedArgs *FlagSpec, clicfg cliconfig.Config) error {
+	theme := parsedArgs.Theme
+	if theme == "" {
+		theme = clicfg.Theme
+	}
+	return printer.Configure(printer.Options{
+		Theme:   theme,
+		NoColor: parsedArgs.NoColor || clicfg.DisableColor,
+		NoEmoji: parsedArgs.NoEmoji || clicfg.DisableEmoji,
+	})
+}
+
 func handleRootVersionFlagAlone(parsedSpec any, _ *CLI) (name string, val any, run func(c *CLI, value any) error, isset bool) {
 	p := parsedSpec.(*FlagSpec)
 	if p.VersionFlag {
@@ -88,12 +127,6 @@ func DefaultBeforeConfigHandler(ctx context.Context, c *CLI) (cmd commands.Execu
 	// profiler is only started if Terramate is built with -tags profiler
 	startProfiler(parsedArgs.CPUProfiling)
 
-	err = ConfigureLogging(parsedArgs.LogLevel, parsedArgs.LogFmt,
-		parsedArgs.LogDestination, c.state.stdout, c.state.stderr)
-	if err != nil {
-		return nil, false, false, err
-	}
-
 	c.state.verbose = parsedArgs.Verbose
 
 	if parsedArgs.Quiet {
@@ -112,6 +145,19 @@ func DefaultBeforeConfigHandler(ctx context.Context, c *CLI) (cmd commands.Execu
 
 	// cmdline flags override configuration file.
 
+	err = configureOutput(parsedArgs, c.clicfg)
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	// the logging is configured after the output, so the log colors
+	// follow the --no-color flag.
+	err = ConfigureLogging(parsedArgs.LogLevel, parsedArgs.LogFmt,
+		parsedArgs.LogDestination, c.state.stdout, c.state.stderr)
+	if err != nil {
+		return nil, false, false, err
+	}
+
 	if parsedArgs.DisableCheckpoint {
 		c.clicfg.DisableCheckpoint = parsedArgs.DisableCheckpoint
 	}
@@ -120,6 +166,27 @@ func DefaultBeforeConfigHandler(ctx context.Context, c *CLI) (cmd commands.Execu
 		c.clicfg.DisableCheckpointSignature = parsedArgs.DisableCheckpointSignature
 	}
 
+	if parsedArgs.Offline {
+		c.clicfg.Offline = true
+	}
+
+	if parsedArgs.Org != "" {
+		c.clicfg.CloudOrganization = parsedArgs.Org
+	}
+
+	c.state.warningsReport = parsedArgs.WarningsReport
+
+	if c.clicfg.Offline {
+		// the update checks and the telemetry are skipped instead of failing.
+		c.clicfg.DisableCheckpoint = true
+		c.clicfg.DisableTelemetry = true
+		http.SetOffline()
+	}
+
+	if parsedArgs.Sandbox {
+		c.hclOptions = append(c.hclOptions, hcl.WithSandbox(stdlib.DefaultSandbox))
+	}
+
 	if c.clicfg.UserTerramateDir == "" {
 		homeTmDir, err := userTerramateDir()
 		if err != nil {
@@ -145,12 +212,50 @@ func DefaultBeforeConfigHandler(ctx context.Context, c *CLI) (cmd commands.Execu
 
 	switch command {
 	case "version":
+		// WHY: the version command runs before changing the working dir.
+		wd, err := c.resolveWorkingDir(parsedArgs.Chdir)
+		if err != nil {
+			return nil, false, false, err
+		}
 		return &version.Spec{
-			Version:  c.version,
-			InfoChan: c.checkpointResponse,
+			Version:    c.version,
+			Format:     parsedArgs.Version.Format,
+			WorkingDir: wd,
+			InfoChan:   c.checkpointResponse,
+			Printers:   c.printers,
 		}, true, false, nil
 	case "install-completions":
 		return &compcmd.Spec{}, true, false, nil
+	case "debug show imports":
+		// WHY: import cycles make the project fail to load.
+		wd, err := c.resolveWorkingDir(parsedArgs.Chdir)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return &debugshowimports.Spec{
+			WorkingDir: wd,
+			Printers:   c.printers,
+			Format:     parsedArgs.Debug.Show.Imports.Format,
+		}, true, false, nil
+	case "init-project", "init-project <dir>":
+		// WHY: there's no project to load yet.
+		wd, err := c.resolveWorkingDir(parsedArgs.Chdir)
+		if err != nil {
+			return nil, false, false, err
+		}
+		dir := parsedArgs.InitProject.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(wd, dir)
+		}
+		return &initprojectcmd.Spec{
+			Dir:      dir,
+			Profile:  parsedArgs.InitProject.Profile,
+			CI:       parsedArgs.InitProject.CI,
+			Force:    parsedArgs.InitProject.Force,
+			Version:  c.version,
+			Stdin:    c.state.stdin,
+			Printers: c.printers,
+		}, true, false, nil
 	case "experimental cloud login": // Deprecated: use cloud login
 		fallthrough
 	case "cloud login":
@@ -204,6 +309,24 @@ func DefaultBeforeConfigHandler(ctx context.Context, c *CLI) (cmd commands.Execu
 	return nil, false, true, nil
 }
 
+// resolveWorkingDir returns the working directory selected by the --chdir
+// flag, with the symlinks evaluated, for the commands handled before changing
+// the working directory.
+func (c *CLI) resolveWorkingDir(chdir string) (string, error) {
+	wd := c.state.wd
+	if chdir != "" {
+		wd = chdir
+		if !filepath.IsAbs(wd) {
+			wd = filepath.Join(c.state.wd, wd)
+		}
+	}
+	evalwd, err := filepath.EvalSymlinks(wd)
+	if err != nil {
+		return "", errors.E(ErrSetup, err, "evaluating symlinks on working dir: %s", wd)
+	}
+	return evalwd, nil
+}
+
 // DefaultAfterConfigHandler implements the default flags handling for when
 // the config is already parsed.
 // Use [WithSpecHandler] if you need a different behavior.
@@ -256,6 +379,8 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			DetailedExitCode: parsedArgs.Fmt.DetailedExitCode,
 			Files:            parsedArgs.Fmt.Files,
 			Printers:         c.printers,
+
+			ConfigFileSuffixes: c.Config().Tree().Node.ConfigFileSuffixes(),
 		}, true, false, nil
 	case "create <path>":
 		c.InitAnalytics("create")
@@ -317,6 +442,8 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			tel.StringFlag("filter-deployment-status", parsedArgs.List.DeploymentStatus),
 			tel.StringFlag("filter-target", parsedArgs.List.Target),
 			tel.BoolFlag("run-order", parsedArgs.List.RunOrder),
+			tel.BoolFlag("tree", parsedArgs.List.Tree),
+			tel.BoolFlag("pick", parsedArgs.List.Pick),
 		)
 		expStatus := parsedArgs.List.ExperimentalStatus
 		cloudStatus := parsedArgs.List.Status
@@ -350,21 +477,53 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 				DriftStatus:      parsedArgs.List.DriftStatus,
 			},
 			RunOrder: parsedArgs.List.RunOrder,
+			Tree:     parsedArgs.List.Tree,
 			Tags:     parsedArgs.Tags,
 			NoTags:   parsedArgs.NoTags,
+			Pick:     parsedArgs.List.Pick,
+			Stacks:   parsedArgs.List.Stack,
+			Args:     kctx.Args,
 		}, true, false, nil
 
 	case "generate":
 		c.InitAnalytics("generate",
 			tel.BoolFlag("detailed-exit-code", parsedArgs.Generate.DetailedExitCode),
 			tel.BoolFlag("parallel", parsedArgs.Generate.Parallel > 0),
+			tel.BoolFlag("report-slowest", parsedArgs.Generate.ReportSlowest > 0),
+			tel.BoolFlag("record-inputs", parsedArgs.Generate.RecordInputs),
+			tel.BoolFlag("verify-inputs", parsedArgs.Generate.VerifyInputs),
+			tel.BoolFlag("check", parsedArgs.Generate.Check),
+			tel.BoolFlag("why", parsedArgs.Generate.Why),
+			tel.BoolFlag("json", parsedArgs.Generate.JSON),
+			tel.StringFlag("progress", parsedArgs.Generate.Progress),
+			tel.BoolFlag("changed", parsedArgs.Changed),
+			tel.BoolFlag("pick", parsedArgs.Generate.Pick),
 		)
+		reporter, err := progress.New(parsedArgs.Generate.Progress, c.state.stderr)
+		if err != nil {
+			return nil, false, false, err
+		}
+		gitfilter, err := engine.NewGitFilter(parsedArgs.Changed, parsedArgs.GitChangeBase, nil, nil)
+		if err != nil {
+			return nil, false, false, err
+		}
 		return &gencmd.Spec{
 			Engine:           c.state.engine,
 			WorkingDir:       c.state.wd,
 			DetailedExitCode: parsedArgs.Generate.DetailedExitCode,
 			Parallel:         parsedArgs.Generate.Parallel,
 			PrintReport:      true,
+			SlowestBlocks:    parsedArgs.Generate.ReportSlowest,
+			RecordInputs:     parsedArgs.Generate.RecordInputs,
+			VerifyInputs:     parsedArgs.Generate.VerifyInputs,
+			Check:            parsedArgs.Generate.Check,
+			Why:              parsedArgs.Generate.Why,
+			JSON:             parsedArgs.Generate.JSON,
+			Progress:         reporter,
+			GitFilter:        gitfilter,
+			Stacks:           parsedArgs.Generate.Stack,
+			Pick:             parsedArgs.Generate.Pick,
+			Args:             kctx.Args,
 			Printers:         c.printers,
 		}, true, false, nil
 	case "experimental clone <srcdir> <destdir>":
@@ -379,7 +538,13 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Printers:        c.printers,
 		}, true, false, nil
 	case "experimental vendor download <source> <ref>":
-		c.InitAnalytics("vendor-download")
+		c.InitAnalytics("vendor-download",
+			tel.StringFlag("progress", parsedArgs.Experimental.Vendor.Download.Progress),
+		)
+		reporter, err := progress.New(parsedArgs.Experimental.Vendor.Download.Progress, c.state.stderr)
+		if err != nil {
+			return nil, false, false, err
+		}
 		return &vendordownloadcmd.Spec{
 			WorkingDir: c.state.wd,
 			Engine:     c.state.engine,
@@ -387,6 +552,7 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Source:     parsedArgs.Experimental.Vendor.Download.Source,
 			Reference:  parsedArgs.Experimental.Vendor.Download.Reference,
 			Dir:        parsedArgs.Experimental.Vendor.Download.Dir,
+			Progress:   reporter,
 		}, true, false, nil
 	case "run":
 		return nil, false, false, errors.E("no command specified")
@@ -403,13 +569,25 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			tel.BoolFlag("sync-preview", parsedArgs.Run.SyncPreview),
 			tel.StringFlag("terraform-planfile", parsedArgs.Run.TerraformPlanFile),
 			tel.StringFlag("tofu-planfile", parsedArgs.Run.TofuPlanFile),
+			tel.BoolFlag("skip-no-op-plans", parsedArgs.Run.SkipNoOpPlans),
 			tel.StringFlag("layer", string(parsedArgs.Run.Layer)),
 			tel.BoolFlag("terragrunt", parsedArgs.Run.Terragrunt),
 			tel.BoolFlag("reverse", parsedArgs.Run.Reverse),
 			tel.BoolFlag("parallel", parsedArgs.Run.Parallel > 0),
 			tel.BoolFlag("output-sharing", parsedArgs.Run.EnableSharing),
 			tel.BoolFlag("output-mocks", parsedArgs.Run.MockOnFail),
+			tel.BoolFlag("refresh-outputs", parsedArgs.Run.RefreshOutputs),
+			tel.BoolFlag("record", parsedArgs.Run.Record),
+			tel.BoolFlag("tui", parsedArgs.Run.TUI),
+			tel.BoolFlag("pick", parsedArgs.Run.Pick),
+			tel.StringFlag("progress", parsedArgs.Run.Progress),
+			tel.BoolFlag("report", parsedArgs.Run.Report != ""),
+			tel.BoolFlag("dedup-errors", parsedArgs.Run.DedupErrors),
 		)
+		reporter, err := progress.New(parsedArgs.Run.Progress, c.state.stderr)
+		if err != nil {
+			return nil, false, false, err
+		}
 		sf, err := setupSafeguards(parsedArgs, parsedArgs.Run.runSafeguardsCliSpec)
 		if err != nil {
 			return nil, false, false, err
@@ -453,17 +631,27 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			TechnologyLayer:   parsedArgs.Run.Layer,
 			TerraformPlanFile: parsedArgs.Run.TerraformPlanFile,
 			TofuPlanFile:      parsedArgs.Run.TofuPlanFile,
+			SkipNoOpPlans:     parsedArgs.Run.SkipNoOpPlans,
 			Terragrunt:        parsedArgs.Run.Terragrunt,
 			EnableSharing:     parsedArgs.Run.EnableSharing,
 			MockOnFail:        parsedArgs.Run.MockOnFail,
 			EvalCmd:           parsedArgs.Run.Eval,
+			Record:            parsedArgs.Run.Record,
+			TUI:               parsedArgs.Run.TUI,
+			Progress:          reporter,
+			ReportFile:        parsedArgs.Run.Report,
+			DedupErrors:       parsedArgs.Run.DedupErrors,
 			Target:            parsedArgs.Run.Target,
 			FromTarget:        parsedArgs.Run.FromTarget,
 			Tags:              parsedArgs.Tags,
 			NoTags:            parsedArgs.NoTags,
+			Pick:              parsedArgs.Run.Pick,
+			Stacks:            parsedArgs.Run.Stack,
+			Args:              kctx.Args,
 			OutputsSharingOptions: engine.OutputsSharingOptions{
 				IncludeOutputDependencies: parsedArgs.Run.IncludeOutputDependencies,
 				OnlyOutputDependencies:    parsedArgs.Run.OnlyOutputDependencies,
+				RefreshOutputs:            parsedArgs.Run.RefreshOutputs,
 			},
 		}, true, false, nil
 
@@ -491,6 +679,34 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Printers:  c.printers,
 			Verbosity: parsedArgs.Verbose,
 		}, true, false, nil
+	case "cloud flush":
+		c.InitAnalytics("cloud-flush")
+		return &cloudflushcmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+		}, true, false, nil
+	case "cloud org list":
+		c.InitAnalytics("cloud-org-list")
+		return &cloudorgcmd.ListSpec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+		}, true, false, nil
+	case "cloud org switch <name>":
+		c.InitAnalytics("cloud-org-switch")
+		return &cloudorgcmd.SwitchSpec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			OrgName:  parsedArgs.Cloud.Org.Switch.Name,
+		}, true, false, nil
+	case "cloud sync inventory":
+		c.InitAnalytics("cloud-sync-inventory",
+			tel.BoolFlag("target", parsedArgs.Cloud.Sync.Inventory.Target != ""),
+		)
+		return &cloudsyncinventorycmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			Target:   parsedArgs.Cloud.Sync.Inventory.Target,
+		}, true, false, nil
 	case "cloud drift show":
 		c.InitAnalytics("cloud-drift-show")
 		return &clouddriftshowcmd.Spec{
@@ -658,6 +874,7 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			tel.StringFlag("target", parsedArgs.Script.Run.Target),
 			tel.BoolFlag("reverse", parsedArgs.Script.Run.Reverse),
 			tel.BoolFlag("parallel", parsedArgs.Script.Run.Parallel > 0),
+			tel.BoolFlag("skip-no-op-plans", parsedArgs.Script.Run.SkipNoOpPlans),
 		)
 		sf, err := setupSafeguards(parsedArgs, parsedArgs.Script.Run.runSafeguardsCliSpec)
 		if err != nil {
@@ -672,6 +889,7 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 		if err != nil {
 			return nil, false, false, err
 		}
+		labels, scriptArgs := splitScriptArgs(parsedArgs.Script.Run.Cmds)
 		return &scriptruncmd.Spec{
 			Engine:     c.Engine(),
 			WorkingDir: c.state.wd,
@@ -688,6 +906,7 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			ContinueOnError: parsedArgs.Script.Run.ContinueOnError,
 			Parallel:        parsedArgs.Script.Run.Parallel,
 			NoRecursive:     parsedArgs.Script.Run.NoRecursive,
+			SkipNoOpPlans:   parsedArgs.Script.Run.SkipNoOpPlans,
 			Target:          parsedArgs.Script.Run.Target,
 			FromTarget:      parsedArgs.Script.Run.FromTarget,
 			Tags:            parsedArgs.Tags,
@@ -695,16 +914,20 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			OutputsSharingOptions: engine.OutputsSharingOptions{
 				IncludeOutputDependencies: parsedArgs.Script.Run.IncludeOutputDependencies,
 				OnlyOutputDependencies:    parsedArgs.Script.Run.OnlyOutputDependencies,
+				RefreshOutputs:            parsedArgs.Script.Run.RefreshOutputs,
 			},
 			StatusFilters: runcmd.StatusFilters{
 				StackStatus:      parsedArgs.Script.Run.Status,
 				DriftStatus:      parsedArgs.Script.Run.DriftStatus,
 				DeploymentStatus: parsedArgs.Script.Run.DeploymentStatus,
 			},
-			Labels: parsedArgs.Script.Run.Cmds,
+			Labels: labels,
+			Args:   scriptArgs,
 		}, true, false, nil
 	case "debug show globals":
-		c.InitAnalytics("debug-show-globals")
+		c.InitAnalytics("debug-show-globals",
+			tel.BoolFlag("trace", parsedArgs.Debug.Show.Globals.Trace != ""),
+		)
 		gitfilter, err := engine.NewGitFilter(
 			parsedArgs.Changed,
 			parsedArgs.GitChangeBase,
@@ -719,6 +942,7 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Engine:     c.Engine(),
 			Printers:   c.printers,
 			GitFilter:  gitfilter,
+			Trace:      parsedArgs.Debug.Show.Globals.Trace,
 		}, true, false, nil
 
 	case "debug show generate-origins":
@@ -755,6 +979,32 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Printers:   c.printers,
 			GitFilter:  gitfilter,
 		}, true, false, nil
+	case "debug show config":
+		c.InitAnalytics("debug-show-config")
+		gitfilter, err := engine.NewGitFilter(
+			parsedArgs.Changed,
+			parsedArgs.GitChangeBase,
+			nil, nil,
+		)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return &debugshowconfigcmd.Spec{
+			WorkingDir: c.state.wd,
+			Engine:     c.Engine(),
+			Printers:   c.printers,
+			GitFilter:  gitfilter,
+		}, true, false, nil
+	case "debug show functions", "debug show functions <pattern>":
+		c.InitAnalytics("debug-show-functions",
+			tel.BoolFlag("pattern", parsedArgs.Debug.Show.Functions.Pattern != ""),
+		)
+		return &debugshowfunctionscmd.Spec{
+			WorkingDir: c.state.wd,
+			Engine:     c.Engine(),
+			Printers:   c.printers,
+			Pattern:    parsedArgs.Debug.Show.Functions.Pattern,
+		}, true, false, nil
 	case "debug show runtime-env":
 		c.InitAnalytics("debug-show-runtime-env")
 		gitfilter, err := engine.NewGitFilter(
@@ -771,6 +1021,26 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Printers:   c.printers,
 			GitFilter:  gitfilter,
 		}, true, false, nil
+	case "debug show run-env":
+		c.InitAnalytics("debug-show-run-env",
+			tel.StringFlag("format", parsedArgs.Debug.Show.RunEnv.Format),
+			tel.BoolFlag("enable-sharing", parsedArgs.Debug.Show.RunEnv.EnableSharing),
+		)
+		gitfilter, err := engine.NewGitFilter(
+			parsedArgs.Changed,
+			parsedArgs.GitChangeBase,
+			nil, nil,
+		)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return &debugshowrunenv.Spec{
+			Engine:        c.Engine(),
+			Printers:      c.printers,
+			GitFilter:     gitfilter,
+			Format:        parsedArgs.Debug.Show.RunEnv.Format,
+			EnableSharing: parsedArgs.Debug.Show.RunEnv.EnableSharing,
+		}, true, false, nil
 
 	case "experimental run-graph":
 		c.InitAnalytics("graph")
@@ -781,13 +1051,189 @@ func DefaultAfterConfigHandler(ctx context.Context, c *CLI) (commands.Executor,
 			Label:      parsedArgs.Experimental.RunGraph.Label,
 			OutputFile: parsedArgs.Experimental.RunGraph.Outfile,
 		}, true, false, nil
+	case "experimental upgrade-config":
+		c.InitAnalytics("upgrade-config",
+			tel.BoolFlag("dry-run", parsedArgs.Experimental.UpgradeConfig.DryRun),
+		)
+		return &upgradeconfigcmd.Spec{
+			WorkingDir: c.state.wd,
+			DryRun:     parsedArgs.Experimental.UpgradeConfig.DryRun,
+			Printers:   c.printers,
+
+			ConfigFileSuffixes: c.Config().Tree().Node.ConfigFileSuffixes(),
+		}, true, false, nil
+	case "experimental rewrite-metadata":
+		c.InitAnalytics("rewrite-metadata",
+			tel.BoolFlag("dry-run", parsedArgs.Experimental.RewriteMetadata.DryRun),
+		)
+		return &rewritemetadatacmd.Spec{
+			WorkingDir: c.state.wd,
+			DryRun:     parsedArgs.Experimental.RewriteMetadata.DryRun,
+			Printers:   c.printers,
+
+			ConfigFileSuffixes: c.Config().Tree().Node.ConfigFileSuffixes(),
+		}, true, false, nil
+	case "experimental rerun <id>":
+		c.InitAnalytics("rerun",
+			tel.BoolFlag("continue-on-error", parsedArgs.Experimental.Rerun.ContinueOnError),
+		)
+		return &reruncmd.Spec{
+			Engine:          c.Engine(),
+			ID:              parsedArgs.Experimental.Rerun.ID,
+			Quiet:           parsedArgs.Quiet,
+			ContinueOnError: parsedArgs.Experimental.Rerun.ContinueOnError,
+			Printers:        c.printers,
+			Stdout:          c.state.stdout,
+			Stderr:          c.state.stderr,
+			Stdin:           c.state.stdin,
+		}, true, false, nil
+	case "experimental impact":
+		c.InitAnalytics("impact",
+			tel.BoolFlag("file", parsedArgs.Experimental.Impact.File != ""),
+			tel.BoolFlag("global", parsedArgs.Experimental.Impact.Global != ""),
+		)
+		return &impactcmd.Spec{
+			WorkingDir: c.state.wd,
+			Engine:     c.Engine(),
+			Printers:   c.printers,
+			File:       parsedArgs.Experimental.Impact.File,
+			Global:     parsedArgs.Experimental.Impact.Global,
+		}, true, false, nil
+	case "experimental lint":
+		c.InitAnalytics("lint",
+			tel.BoolFlag("ordering", parsedArgs.Experimental.Lint.Ordering),
+		)
+		return &lintcmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			Ordering: parsedArgs.Experimental.Lint.Ordering,
+		}, true, false, nil
+	case "experimental drift run <cmd>":
+		c.InitAnalytics("drift-run",
+			tel.BoolFlag("all", parsedArgs.Experimental.Drift.Run.All),
+			tel.BoolFlag("shard", parsedArgs.Experimental.Drift.Run.Shard != ""),
+			tel.BoolFlag("parallel", parsedArgs.Experimental.Drift.Run.MaxParallel > 1),
+			tel.BoolFlag("sync-drift", parsedArgs.Experimental.Drift.Run.SyncDriftStatus),
+		)
+		return &driftruncmd.Spec{
+			Engine:            c.Engine(),
+			WorkingDir:        c.state.wd,
+			Printers:          c.printers,
+			Stdout:            c.state.stdout,
+			Stderr:            c.state.stderr,
+			Stdin:             c.state.stdin,
+			Command:           parsedArgs.Experimental.Drift.Run.Command,
+			All:               parsedArgs.Experimental.Drift.Run.All,
+			MaxParallel:       parsedArgs.Experimental.Drift.Run.MaxParallel,
+			Shard:             parsedArgs.Experimental.Drift.Run.Shard,
+			Jitter:            parsedArgs.Experimental.Drift.Run.Jitter,
+			Seed:              parsedArgs.Experimental.Drift.Run.Seed,
+			SyncDriftStatus:   parsedArgs.Experimental.Drift.Run.SyncDriftStatus,
+			TerraformPlanFile: parsedArgs.Experimental.Drift.Run.TerraformPlanFile,
+			TofuPlanFile:      parsedArgs.Experimental.Drift.Run.TofuPlanFile,
+			Terragrunt:        parsedArgs.Experimental.Drift.Run.Terragrunt,
+			Target:            parsedArgs.Experimental.Drift.Run.Target,
+			Tags:              parsedArgs.Tags,
+			NoTags:            parsedArgs.NoTags,
+		}, true, false, nil
+	case "experimental grep <pattern>":
+		c.InitAnalytics("grep")
+		gitfilter, err := engine.NewGitFilter(
+			parsedArgs.Changed,
+			parsedArgs.GitChangeBase,
+			nil, nil,
+		)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return &grepcmd.Spec{
+			Engine:    c.Engine(),
+			Printers:  c.printers,
+			GitFilter: gitfilter,
+			Pattern:   parsedArgs.Experimental.Grep.Pattern,
+		}, true, false, nil
+	case "experimental imports verify":
+		c.InitAnalytics("imports-verify",
+			tel.BoolFlag("strict", parsedArgs.Experimental.Imports.Verify.Strict),
+		)
+		return &importsverifycmd.Spec{
+			WorkingDir: c.state.wd,
+			Engine:     c.Engine(),
+			Printers:   c.printers,
+			Strict:     parsedArgs.Experimental.Imports.Verify.Strict,
+		}, true, false, nil
+	case "experimental providers check":
+		c.InitAnalytics("providers-check")
+		return &providerscheckcmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+		}, true, false, nil
+	case "experimental docs generate":
+		c.InitAnalytics("docs-generate",
+			tel.BoolFlag("globals", len(parsedArgs.Experimental.Docs.Generate.Global) != 0),
+		)
+		return &docscmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			Output:   parsedArgs.Experimental.Docs.Generate.Output,
+			Globals:  parsedArgs.Experimental.Docs.Generate.Global,
+		}, true, false, nil
+	case "experimental serve":
+		c.InitAnalytics("serve")
+		return &servecmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			Address:  parsedArgs.Experimental.Serve.Address,
+		}, true, false, nil
+	case "experimental unlock <stack>":
+		c.InitAnalytics("unlock")
+		return &unlockcmd.Spec{
+			WorkingDir: c.state.wd,
+			Engine:     c.Engine(),
+			Printers:   c.printers,
+			Stack:      parsedArgs.Experimental.Unlock.Stack,
+		}, true, false, nil
+	case "experimental git-install-drivers":
+		c.InitAnalytics("git-install-drivers",
+			tel.BoolFlag("hook", parsedArgs.Experimental.GitInstallDrivers.Hook),
+		)
+		return &gitdriverscmd.Spec{
+			Engine:   c.Engine(),
+			Printers: c.printers,
+			Hook:     parsedArgs.Experimental.GitInstallDrivers.Hook,
+		}, true, false, nil
+	case "experimental run-order":
+		c.InitAnalytics("run-order",
+			tel.BoolFlag("filter-changed", parsedArgs.Changed),
+			tel.BoolFlag("filter-tags", len(parsedArgs.Tags) != 0),
+			tel.BoolFlag("levels", parsedArgs.Experimental.RunOrder.Levels),
+			tel.StringFlag("format", parsedArgs.Experimental.RunOrder.Format),
+		)
+		gitfilter, err := engine.NewGitFilter(
+			parsedArgs.Changed,
+			parsedArgs.GitChangeBase,
+			parsedArgs.Experimental.RunOrder.EnableChangeDetection,
+			parsedArgs.Experimental.RunOrder.DisableChangeDetection,
+		)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return &runordercmd.Spec{
+			Engine:    c.Engine(),
+			GitFilter: gitfilter,
+			Tags:      parsedArgs.Tags,
+			NoTags:    parsedArgs.NoTags,
+			Levels:    parsedArgs.Experimental.RunOrder.Levels,
+			Format:    parsedArgs.Experimental.RunOrder.Format,
+			Printers:  c.printers,
+		}, true, false, nil
 	default:
 		return nil, false, false, errors.E("unexpected command sequence")
 	}
 }
 
 func checkScriptEnabled(cfg *config.Root) {
-	if cfg.HasExperiment("scripts") {
+	if cfg.HasExperiment(hcl.ScriptsExperimentName) {
 		return
 	}
 
@@ -803,6 +1249,17 @@ terramate {
 	os.Exit(1)
 }
 
+// splitScriptArgs splits the positional arguments of script run into the
+// script labels and the script arguments given after the "--" separator.
+func splitScriptArgs(cmds []string) (labels []string, args []string) {
+	for i, cmd := range cmds {
+		if cmd == "--" {
+			return cmds[:i], cmds[i+1:]
+		}
+	}
+	return cmds, nil
+}
+
 func envVarIsSet(val string) bool {
 	return val != "" && val != "0" && val != "false"
 }
@@ -843,5 +1300,6 @@ func setupSafeguards(parsedArgs *FlagSpec, runflags runSafeguardsCliSpec) (sf ru
 		sf = runcmd.Safeguards{}
 		sf.ReEnabled = true
 	}
+	sf.CheckSelectedGenCode = runflags.CheckSelectedGenCode
 	return sf, nil
 }
@@ -4,6 +4,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/terramate-io/terramate/cloud/api/preview"
 	"github.com/terramate-io/terramate/safeguard"
 	"github.com/willabides/kongplete"
@@ -38,6 +40,13 @@ type FlagSpec struct {
 		NoGenerate     bool     `help:"Do not run code generation after creating the new stack."`
 	} `cmd:"" help:"Create or import stacks."`
 
+	InitProject struct {
+		Dir     string `arg:"" optional:"true" name:"dir" predictor:"file" help:"Directory of the new project, defaults to the working directory."`
+		Profile string `help:"Cloud provider of the project: 'aws', 'gcp' or 'azure'. Asked interactively if not set."`
+		CI      string `name:"ci" help:"CI/CD system of the workflow files: 'github', 'gitlab' or 'none'. Asked interactively if not set."`
+		Force   bool   `help:"Overwrite existing files."`
+	} `cmd:"" name:"init-project" help:"Scaffold a new project with the recommended layout."`
+
 	Fmt struct {
 		Files            []string `arg:"" optional:"true" predictor:"file" help:"List of files to be formatted."`
 		Check            bool     `hidden:"" help:"Lists unformatted files but do not change them. (Exits with 0 if all is formatted, 1 otherwise)"`
@@ -50,8 +59,10 @@ type FlagSpec struct {
 		cloudFilterFlags
 		Target   string `help:"Select the deployment target of the filtered stacks."`
 		RunOrder bool   `default:"false" help:"Sort listed stacks by order of execution"`
+		Tree     bool   `default:"false" help:"Show the run order as a tree of levels with the constraint ordering each stack. Requires --run-order."`
 
 		changeDetectionFlags
+		stackSelectionFlags
 	} `cmd:"" help:"List stacks."`
 
 	Run struct {
@@ -61,8 +72,17 @@ type FlagSpec struct {
 	} `cmd:"" help:"Run command in the stacks"`
 
 	Generate struct {
-		Parallel         int  `env:"TM_ARG_GENERATE_PARALLEL" short:"j" optional:"true" help:"Set the parallelism of code generation"`
-		DetailedExitCode bool `default:"false" help:"Return a detailed exit code: 0 nothing changed, 1 an error happened, 2 changes were made."`
+		Parallel         int    `env:"TM_ARG_GENERATE_PARALLEL" short:"j" optional:"true" help:"Set the parallelism of code generation"`
+		DetailedExitCode bool   `default:"false" help:"Return a detailed exit code: 0 nothing changed, 1 an error happened, 2 changes were made."`
+		ReportSlowest    int    `optional:"true" placeholder:"N" help:"Report the N generate blocks which took longer to evaluate and format."`
+		RecordInputs     bool   `default:"false" help:"Record a snapshot of the inputs of each generated file in .terramate/generate-state.json."`
+		VerifyInputs     bool   `default:"false" help:"Show the inputs which drifted since the last generation recorded with --record-inputs."`
+		Check            bool   `default:"false" help:"Lists outdated generated files but do not change them, failing on unpinned time and randomness functions. (Exits with 0 if all is up to date, 1 otherwise)"`
+		Why              bool   `default:"false" help:"Like --check, but also shows the changes of each outdated generated file."`
+		JSON             bool   `name:"json" default:"false" help:"Print the report as JSON, including the code and docs_url of the failed assertions."`
+		Progress         string `optional:"true" default:"none" enum:"none,ndjson" help:"Stream structured progress events to stderr: 'none' or 'ndjson'."`
+
+		stackSelectionFlags
 	} `cmd:"" help:"Run Code Generation in stacks."`
 
 	Script struct {
@@ -75,16 +95,31 @@ type FlagSpec struct {
 			runScriptFlags `envprefix:"TM_ARG_RUN_"`
 			runSafeguardsCliSpec
 			outputsSharingFlags
+
+			SkipNoOpPlans bool `default:"false" help:"Skip the remaining commands of a stack when the plan file of a command has no changes."`
 		} `cmd:"" help:"Run a Terramate Script in stacks."`
 	} `cmd:"" help:"Use Terramate Scripts"`
 
 	Debug struct {
 		Show struct {
-			Metadata        struct{} `cmd:"" help:"Show metadata available in stacks."`
-			Globals         struct{} `cmd:"" help:"Show globals available in stacks."`
+			Metadata struct{} `cmd:"" help:"Show metadata available in stacks."`
+			Globals  struct {
+				Trace string `help:"Show the override chain of the given global, eg.: --trace team.name."`
+			} `cmd:"" help:"Show globals available in stacks."`
 			GenerateOrigins struct {
 			} `cmd:"" help:"Show details about generated code in stacks."`
 			RuntimeEnv struct{} `cmd:"" help:"Show available run-time environment variables (ENV) in stacks."`
+			RunEnv     struct {
+				Format        string `default:"text" enum:"text,json" help:"Output format: 'text' or 'json'."`
+				EnableSharing bool   `help:"Show the TF_VAR_* variables of the stack inputs, loading the outputs of the stacks they depend on."`
+			} `cmd:"" help:"Show the resolved environment of the commands executed in stacks."`
+			Config    struct{} `cmd:"" help:"Show globals, generate blocks and run-time environment of stacks with the location of each definition."`
+			Functions struct {
+				Pattern string `arg:"" optional:"true" name:"pattern" help:"Glob pattern filtering the function names, eg.: 'tm_file*'."`
+			} `cmd:"" help:"Show the signature and description of the functions available in expressions."`
+			Imports struct {
+				Format string `default:"dot" enum:"dot,json" help:"Output format: 'dot' or 'json'."`
+			} `cmd:"" help:"Show the import graph of the configuration files, with the unused imported files and the import cycles."`
 		} `cmd:"" help:"Show configuration details of stacks."`
 	} `cmd:"" help:"Debug Terramate configuration."`
 
@@ -100,6 +135,18 @@ type FlagSpec struct {
 				Target string `help:"Show stacks from the given deployment target."`
 			} `cmd:"" help:"Show the current drift of a stack."`
 		} `cmd:"" help:"Interact with Terramate Cloud Drift Detection."`
+		Flush struct{} `cmd:"" help:"Send the sync payloads queued while Terramate Cloud was unreachable."`
+		Org   struct {
+			List   struct{} `cmd:"" help:"List the organizations you are a member of."`
+			Switch struct {
+				Name string `arg:"" name:"name" help:"Name of the organization."`
+			} `cmd:"" help:"Select the organization used when the project sets none."`
+		} `cmd:"" help:"Manage the Terramate Cloud organizations."`
+		Sync struct {
+			Inventory struct {
+				Target string `help:"Set the deployment target of the synced stacks."`
+			} `cmd:"" help:"Sync the stacks, with their metadata, independently of deployments."`
+		} `cmd:"" help:"Sync the project with Terramate Cloud."`
 	} `cmd:"" help:"Interact with Terramate Cloud"`
 
 	Trigger struct {
@@ -133,11 +180,90 @@ type FlagSpec struct {
 			Label   string `short:"l" default:"stack.name" help:"Label used in graph nodes (it could be either \"stack.name\" or \"stack.dir\""`
 		} `cmd:"" help:"Generate a graph of the execution order"`
 
+		RunOrder struct {
+			Levels bool   `default:"false" help:"Group the stacks by levels of stacks that can be executed in parallel."`
+			Format string `default:"text" enum:"text,json" help:"Output format: 'text' or 'json'."`
+
+			changeDetectionFlags
+		} `cmd:"" help:"Show the run order of the selected stacks"`
+
+		Impact struct {
+			File   string `predictor:"file" default:"" help:"Configuration file to analyze."`
+			Global string `default:"" help:"Global to analyze, eg.: --global a.b"`
+		} `cmd:"" help:"Show the stacks whose generated code or run environment depend on a file or global."`
+
+		Lint struct {
+			Ordering bool `help:"Report stacks sharing state, outputs or imported resources without before/after ordering."`
+		} `cmd:"" help:"Report unused globals and lets, globals overridden in all stacks and generate blocks never enabled."`
+
+		Drift struct {
+			Run struct {
+				All               bool          `default:"false" help:"Select all the stacks of the project instead of the stacks in the working directory."`
+				MaxParallel       int           `default:"1" help:"Maximum number of stacks executed concurrently."`
+				Shard             string        `default:"" placeholder:"N/TOTAL" help:"Only run the Nth of TOTAL deterministic shards of the selected stacks, eg.: 2/10."`
+				Jitter            time.Duration `default:"0s" help:"Wait a random duration up to the given one before starting, eg.: 30s."`
+				Seed              int64         `default:"0" help:"Seed of the random execution order of the stacks (random if not set)."`
+				SyncDriftStatus   bool          `default:"false" help:"Synchronize the drift status of the stacks to Terramate Cloud after all of them are executed."`
+				TerraformPlanFile string        `default:"" help:"Add details of the Terraform Plan file to the synchronization to Terramate Cloud."`
+				TofuPlanFile      string        `default:"" help:"Add details of the OpenTofu Plan file to the synchronization to Terramate Cloud."`
+				Terragrunt        bool          `default:"false" help:"Use terragrunt when generating planfile for Terramate Cloud sync."`
+				Target            string        `default:"" help:"Set the deployment target for stacks synchronized to Terramate Cloud."`
+				Command           []string      `arg:"" name:"cmd" predictor:"file" passthrough:"" help:"Drift detection command, exiting with 2 when the stack drifted."`
+			} `cmd:"" help:"Run the drift detection of the stacks, designed for scheduled CI jobs."`
+		} `cmd:"" help:"Manage the drift detection of the stacks."`
+
+		Grep struct {
+			Pattern string `arg:"" name:"pattern" help:"Regular expression searched in the evaluated globals and generated code."`
+		} `cmd:"" help:"Search the evaluated globals and the generated code of the stacks."`
+
+		Serve struct {
+			Address string `default:"127.0.0.1:0" help:"Address the server listens on. A random port is used by default."`
+		} `cmd:"" help:"Serve a local JSON-RPC endpoint answering queries about the project kept loaded in memory."`
+
+		Unlock struct {
+			Stack string `arg:"" name:"stack" predictor:"file" help:"The stack path."`
+		} `cmd:"" help:"Release the lock of a stack left behind by an aborted execution."`
+
+		GitInstallDrivers struct {
+			Hook bool `default:"false" help:"Install a post-merge hook running 'terramate generate'."`
+		} `cmd:"" help:"Configure git to resolve merge conflicts of generated files by regeneration."`
+
+		Imports struct {
+			Verify struct {
+				Strict bool `default:"false" help:"Fail if any imported file is not pinned with import.sha256."`
+			} `cmd:"" help:"Verify the integrity of the imported files and show the ones not pinned."`
+		} `cmd:"" help:"Manages the imported configuration files."`
+
+		Providers struct {
+			Check struct{} `cmd:"" help:"Check that the Terraform code of the stacks does not drift from the providers constraints."`
+		} `cmd:"" help:"Manages the provider requirements generated from the providers blocks."`
+
+		Docs struct {
+			Generate struct {
+				Output string   `default:"/STACKS.md" help:"Project path of the generated Markdown file."`
+				Global []string `help:"Global documented for each stack, eg.: --global aws.region"`
+			} `cmd:"" help:"Generate a Markdown inventory of the stacks with their description, tags, ordering and globals."`
+		} `cmd:"" help:"Manage the generated documentation of the project."`
+
+		UpgradeConfig struct {
+			DryRun bool `default:"false" help:"Show the diff of the upgrade without changing any file."`
+		} `cmd:"" help:"Rewrite deprecated configuration to its up-to-date form."`
+
+		RewriteMetadata struct {
+			DryRun bool `default:"false" help:"Show the diff of the rewrite without changing any file."`
+		} `cmd:"" help:"Rewrite references to deprecated metadata, eg.: terramate.path to terramate.stack.path.absolute."`
+
+		Rerun struct {
+			ID              string `arg:"" name:"id" help:"ID of the recorded run."`
+			ContinueOnError bool   `default:"false" help:"Continue executing next stacks when a command returns an error."`
+		} `cmd:"" help:"Replay a run recorded with 'terramate run --record' on the same stacks and order."`
+
 		Vendor struct {
 			Download struct {
 				Dir       string `short:"d" predictor:"file" default:"" help:"dir to vendor downloaded project"`
 				Source    string `arg:"" name:"source" help:"Terraform module source URL, must be Git/Github and should not contain a reference"`
 				Reference string `arg:"" name:"ref" help:"Reference of the Terraform module to vendor"`
+				Progress  string `optional:"true" default:"none" enum:"none,ndjson" help:"Stream structured progress events to stderr: 'none' or 'ndjson'."`
 			} `cmd:"" help:"Downloads a Terraform module and stores it on the project vendor dir"`
 		} `cmd:"" help:"Manages vendored Terraform modules"`
 
@@ -170,7 +296,9 @@ type FlagSpec struct {
 
 	InstallCompletions kongplete.InstallCompletions `cmd:"" help:"Install shell completions."`
 
-	Version struct{} `cmd:"" help:"Show Terramate version"`
+	Version struct {
+		Format string `default:"text" enum:"text,json" help:"Output format: 'text' or 'json'."`
+	} `cmd:"" help:"Show Terramate version"`
 }
 
 type globalCliFlags struct {
@@ -185,12 +313,20 @@ type globalCliFlags struct {
 	LogDestination string   `env:"LOG_DESTINATION" optional:"true" default:"stderr" enum:"stderr,stdout" help:"Destination channel of log messages: 'stderr' or 'stdout'."`
 	Quiet          bool     `env:"QUIET" optional:"false" help:"Disable outputs."`
 	Verbose        int      `env:"VERBOSE" short:"v" optional:"true" default:"0" type:"counter" help:"Increase verboseness of output"`
+	Theme          string   `env:"THEME" optional:"true" help:"Color theme of the output: 'default', 'colorblind', 'high-contrast' or 'monochrome'."`
+	NoColor        bool     `env:"NO_COLOR" optional:"true" default:"false" help:"Disable colors in the output. The NO_COLOR environment variable is also honored."`
+	NoEmoji        bool     `env:"NO_EMOJI" optional:"true" default:"false" help:"Disable emoji in the output."`
+	Sandbox        bool     `env:"SANDBOX" optional:"true" default:"false" help:"Evaluate the configuration in sandboxed mode, without filesystem and environment access and with bounded time and memory."`
+	Offline        bool     `env:"OFFLINE" optional:"true" default:"false" help:"Disable all network access, failing the features which require it, eg.: cloud sync and vendoring of remote modules."`
+	Org            string   `optional:"true" help:"Set the Terramate Cloud organization, overriding TM_CLOUD_ORGANIZATION and the project configuration."`
+	WarningsReport string   `env:"WARNINGS_REPORT" optional:"true" predictor:"file" help:"Write the warnings emitted by the command, with their codes, as JSON to the given file."`
 }
 
 type runSafeguardsCliSpec struct {
 	// Note: The `name` and `short` are being used to define the -X flag without longer version.
 	DisableSafeguardsAll            bool               `default:"false" name:"disable-safeguards=all" short:"X" help:"Disable all safeguards."`
 	DisableSafeguards               safeguard.Keywords `env:"TM_DISABLE_SAFEGUARDS" enum:"git,all,none,git-untracked,git-uncommitted,outdated-code,git-out-of-sync" help:"Disable specific safeguards: 'all', 'none', 'git', 'git-untracked', 'git-uncommitted', 'git-out-of-sync', and/or 'outdated-code'."`
+	CheckSelectedGenCode            bool               `default:"false" env:"TM_CHECK_SELECTED_GEN_CODE" help:"Check the generated code of the selected stacks is up to date, when the 'outdated-code' safeguard is disabled."`
 	DeprecatedDisableCheckGenCode   bool               `hidden:"" default:"false" name:"disable-check-gen-code" env:"TM_DISABLE_CHECK_GEN_CODE" help:"Disable outdated generated code check (DEPRECATED)."`
 	DeprecatedDisableCheckGitRemote bool               `hidden:"" default:"false" name:"disable-check-git-remote" env:"TM_DISABLE_CHECK_GIT_REMOTE" help:"Disable checking if local default branch is updated with remote (DEPRECATED)."`
 }
@@ -213,9 +349,15 @@ type changeDetectionFlags struct {
 	DisableChangeDetection []string `help:"Disable specific change detection modes" enum:"git-untracked,git-uncommitted"`
 }
 
+type stackSelectionFlags struct {
+	Pick  bool     `default:"false" help:"Pick the stacks interactively, with fuzzy search and filters by tag and change status. Requires a terminal."`
+	Stack []string `help:"Select only the given stack directories, like in the command printed by --pick."`
+}
+
 type outputsSharingFlags struct {
 	IncludeOutputDependencies bool `help:"Include stacks that are dependencies of the selected stacks. (requires outputs-sharing experiment enabled)"`
 	OnlyOutputDependencies    bool `help:"Only include stacks that are dependencies of the selected stacks. (requires outputs-sharing experiment enabled)"`
+	RefreshOutputs            bool `help:"Ignore the cached outputs of the dependencies and execute the sharing_backend command again. (requires outputs-sharing experiment enabled)"`
 }
 
 type cloudTargetFlags struct {
@@ -253,6 +395,8 @@ type runCommandFlags struct {
 	changeDetectionFlags
 	cloudTargetFlags
 
+	stackSelectionFlags
+
 	EnableSharing bool `env:"ENABLE_SHARING" help:"Enable sharing of stack outputs as stack inputs."`
 	MockOnFail    bool `env:"MOCK_ON_FAIL" help:"Mock the output values if command fails."`
 
@@ -261,12 +405,18 @@ type runCommandFlags struct {
 	TerraformPlanFile string `env:"TERRAFORM_PLAN_FILE" default:"" help:"Add details of the Terraform Plan file to the synchronization to Terramate Cloud."`
 	TofuPlanFile      string `env:"TOFU_PLAN_FILE" default:"" help:"Add details of the OpenTofu Plan file to the synchronization to Terramate Cloud."`
 	DebugPreviewURL   string `hidden:"true" default:"" help:"Create a debug preview URL to Terramate Cloud details."`
+	SkipNoOpPlans     bool   `env:"SKIP_NO_OP_PLANS" default:"false" help:"Skip the command in the stacks whose plan file, given by --terraform-plan-file or --tofu-plan-file, has no changes."`
 
 	commonRunFlags
 
-	Eval       bool     `env:"EVAL" default:"false" help:"Evaluate command arguments as HCL strings interpolating Globals, Functions and Metadata."`
-	Terragrunt bool     `env:"TERRAGRUNT" default:"false" help:"Use terragrunt when generating planfile for Terramate Cloud sync."`
-	Command    []string `arg:"" name:"cmd" predictor:"file" passthrough:"" help:"Command to execute"`
+	Eval        bool     `env:"EVAL" default:"false" help:"Evaluate command arguments as HCL strings interpolating Globals, Functions and Metadata."`
+	Terragrunt  bool     `env:"TERRAGRUNT" default:"false" help:"Use terragrunt when generating planfile for Terramate Cloud sync."`
+	Record      bool     `env:"RECORD" default:"false" help:"Record a fingerprint of the run in .terramate/runs so it can be replayed with 'terramate experimental rerun'."`
+	TUI         bool     `env:"TUI" default:"false" help:"Show a live table of the stacks status instead of the interleaved output of the commands. Requires a terminal."`
+	Progress    string   `env:"PROGRESS" optional:"true" default:"none" enum:"none,ndjson" help:"Stream structured progress events to stderr: 'none' or 'ndjson'."`
+	Report      string   `env:"REPORT" predictor:"file" default:"" help:"Write a JSON report of the run, with the status, duration, CPU time and peak memory of each stack, to the given file."`
+	DedupErrors bool     `env:"DEDUP_ERRORS" default:"false" help:"Buffer the stderr of the stacks and print it only once for the stacks failing with the same error."`
+	Command     []string `arg:"" name:"cmd" predictor:"file" passthrough:"" help:"Command to execute"`
 }
 
 type runScriptFlags struct {
@@ -275,7 +425,7 @@ type runScriptFlags struct {
 	cloudTargetFlags
 	commonRunFlags
 
-	Cmds []string `arg:"" optional:"true" passthrough:"" help:"Script to execute."`
+	Cmds []string `arg:"" optional:"true" passthrough:"" help:"Script to execute, followed by the script arguments after --."`
 }
 
 func migrateFlagAliases(parsedArgs *FlagSpec) {
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cliauth" {
-  content = <<-EOT
+  content = <<-EOF
 package auth // import "github.com/terramate-io/terramate/cmd/terramate/cli/tmcloud/auth"
 
 Package auth provides the helper functions for loading the Terramate Cloud
@@ -14,7 +14,7 @@ func GoogleLogin(output out.O, clicfg cliconfig.Config) error
 type APIKey struct{ ... }
 type Credencial interface{ ... }
     func ProbingPrecedence(output out.O, client *cloud.Client, clicfg cliconfig.Config) []Credencial
-EOT
+EOF
 
   filename = "${path.module}/mock-cliauth.ignore"
 }
@@ -0,0 +1,460 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cliauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/ui/tui/cliconfig"
+)
+
+const (
+	// ciOIDCProviderEnv selects the CI OIDC provider, overriding the
+	// detection based on the oidc blocks of the CLI configuration.
+	ciOIDCProviderEnv = "TM_CLOUD_OIDC_PROVIDER"
+	// ciOIDCIssuerEnv overrides the expected issuer of the OIDC token.
+	ciOIDCIssuerEnv = "TM_CLOUD_OIDC_ISSUER"
+	// ciOIDCAudienceEnv overrides the audience requested for the OIDC token.
+	ciOIDCAudienceEnv = "TM_CLOUD_OIDC_AUDIENCE"
+
+	genericOIDCTokenEnv     = "TM_CLOUD_OIDC_TOKEN"
+	genericOIDCTokenFileEnv = "TM_CLOUD_OIDC_TOKEN_FILE"
+
+	// azureDevopsServiceConnectionEnv sets the service connection of the
+	// Azure DevOps workload identity federation.
+	azureDevopsServiceConnectionEnv = "TM_AZURE_DEVOPS_SERVICE_CONNECTION_ID"
+
+	defaultCIOIDCTimeout = 60 * time.Second
+)
+
+// ciOIDCProvider describes how to obtain an OIDC token from a CI provider.
+type ciOIDCProvider struct {
+	// title is the human readable name of the provider.
+	title string
+	// detect tells if the provider is available in the current environment.
+	detect func() bool
+	// defaultAudience returns the audience requested when none is configured.
+	defaultAudience func(region cloud.Region) string
+	// token obtains a new OIDC token for the given audience.
+	token func(ctx context.Context, audience string) (string, error)
+	// claims are the claims shown in the credential information,
+	// as pairs of display name and claim name.
+	claims [][2]string
+}
+
+// ciOIDCProviders are the supported CI providers, keyed by the name used in
+// the oidc blocks of the CLI configuration and the TM_CLOUD_OIDC_PROVIDER
+// environment variable.
+var ciOIDCProviders = map[string]ciOIDCProvider{
+	"circleci": {
+		title:           "CircleCI OIDC",
+		detect:          func() bool { return os.Getenv("CIRCLECI") == "true" },
+		defaultAudience: func(cloud.Region) string { return "" },
+		token:           circleciOIDCToken,
+		claims: [][2]string{
+			{"project", "oidc.circleci.com/project-id"},
+			{"repository", "oidc.circleci.com/vcs-origin"},
+		},
+	},
+	"buildkite": {
+		title:           "Buildkite OIDC",
+		detect:          func() bool { return os.Getenv("BUILDKITE") == "true" },
+		defaultAudience: oidcAudience,
+		token:           buildkiteOIDCToken,
+		claims: [][2]string{
+			{"organization", "organization_slug"},
+			{"pipeline", "pipeline_slug"},
+		},
+	},
+	"azuredevops": {
+		title: "Azure DevOps OIDC",
+		detect: func() bool {
+			return os.Getenv("TF_BUILD") != "" && os.Getenv("SYSTEM_OIDCREQUESTURI") != ""
+		},
+		defaultAudience: func(cloud.Region) string { return "" },
+		token:           azureDevopsOIDCToken,
+	},
+	"generic": {
+		title: "CI OIDC",
+		detect: func() bool {
+			return os.Getenv(genericOIDCTokenEnv) != "" || os.Getenv(genericOIDCTokenFileEnv) != ""
+		},
+		defaultAudience: func(cloud.Region) string { return "" },
+		token:           genericOIDCToken,
+	},
+}
+
+// ciOIDC is a credential based on the OIDC token issued by a CI provider.
+// The provider must be explicitly enabled, either with an oidc block in the
+// CLI configuration or with the TM_CLOUD_OIDC_PROVIDER environment variable.
+type ciOIDC struct {
+	mu        sync.RWMutex
+	token     string
+	jwtClaims jwt.MapClaims
+	expireAt  time.Time
+
+	providerName string
+	provider     ciOIDCProvider
+	issuer       string
+	audience     string
+
+	orgs resources.MemberOrganizations
+
+	printers  printer.Printers
+	verbosity int
+	clicfg    cliconfig.Config
+	client    *cloud.Client
+}
+
+func newCIOIDC(printers printer.Printers, verbosity int, clicfg cliconfig.Config, client *cloud.Client) *ciOIDC {
+	return &ciOIDC{
+		client:    client,
+		clicfg:    clicfg,
+		printers:  printers,
+		verbosity: verbosity,
+	}
+}
+
+func (c *ciOIDC) Load() (bool, error) {
+	name, found, err := c.selectProvider()
+	if err != nil || !found {
+		return found, err
+	}
+	c.providerName = name
+	c.provider = ciOIDCProviders[name]
+
+	cfg := c.clicfg.OIDC[name]
+	c.issuer = cfg.Issuer
+	if issuer := os.Getenv(ciOIDCIssuerEnv); issuer != "" {
+		c.issuer = issuer
+	}
+	c.audience = cfg.Audience
+	if audience := os.Getenv(ciOIDCAudienceEnv); audience != "" {
+		c.audience = audience
+	}
+	if c.audience == "" {
+		c.audience = c.provider.defaultAudience(c.client.Region())
+	}
+
+	if err := c.Refresh(); err != nil {
+		return true, err
+	}
+	c.client.SetCredential(c)
+
+	if c.verbosity > 0 {
+		c.printers.Stdout.Println(fmt.Sprintf("%s token loaded", c.provider.title))
+	}
+	return true, c.fetchDetails()
+}
+
+// selectProvider returns the name of the provider set in the environment or,
+// if none, the first provider configured in the CLI configuration that is
+// available in the current environment.
+func (c *ciOIDC) selectProvider() (string, bool, error) {
+	if name := os.Getenv(ciOIDCProviderEnv); name != "" {
+		if _, ok := ciOIDCProviders[name]; !ok {
+			return "", true, errors.E("%s: unsupported OIDC provider %q (supported: %s)",
+				ciOIDCProviderEnv, name, strings.Join(supportedCIOIDCProviders(), ", "))
+		}
+		return name, true, nil
+	}
+	for _, name := range supportedCIOIDCProviders() {
+		if _, ok := c.clicfg.OIDC[name]; ok && ciOIDCProviders[name].detect() {
+			return name, true, nil
+		}
+	}
+	for name := range c.clicfg.OIDC {
+		if _, ok := ciOIDCProviders[name]; !ok {
+			return "", true, errors.E("oidc block: unsupported OIDC provider %q (supported: %s)",
+				name, strings.Join(supportedCIOIDCProviders(), ", "))
+		}
+	}
+	return "", false, nil
+}
+
+func (c *ciOIDC) Name() string {
+	return c.provider.title
+}
+
+func (c *ciOIDC) HasExpiration() bool {
+	return true
+}
+
+func (c *ciOIDC) IsExpired() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().After(c.expireAt)
+}
+
+func (c *ciOIDC) ExpireAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.expireAt
+}
+
+// Refresh obtains a new token from the CI provider.
+func (c *ciOIDC) Refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCIOIDCTimeout)
+	defer cancel()
+
+	token, err := c.provider.token(ctx, c.audience)
+	if err != nil {
+		return errors.E(err, "requesting new %s token", c.provider.title)
+	}
+	claims, err := tokenClaims(token)
+	if err != nil {
+		return err
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.E(`%s JWT token has no "exp" field`, c.provider.title)
+	}
+	if c.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != c.issuer {
+			return errors.E("%s token issued by %q but %q is expected", c.provider.title, iss, c.issuer)
+		}
+	}
+	sec, dec := math.Modf(exp)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.jwtClaims = claims
+	c.expireAt = time.Unix(int64(sec), int64(dec*(1e9)))
+	return nil
+}
+
+func (c *ciOIDC) Claims() jwt.MapClaims {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.jwtClaims
+}
+
+func (c *ciOIDC) DisplayClaims() []keyValue {
+	claims := c.Claims()
+	kvs := []keyValue{}
+	for _, claim := range append([][2]string{{"issuer", "iss"}, {"subject", "sub"}}, c.provider.claims...) {
+		if value, ok := claims[claim[1]].(string); ok {
+			kvs = append(kvs, keyValue{key: claim[0], value: value})
+		}
+	}
+	return kvs
+}
+
+func (c *ciOIDC) Token() (string, error) {
+	if c.IsExpired() {
+		if err := c.Refresh(); err != nil {
+			return "", err
+		}
+		if c.IsExpired() {
+			return "", errors.E("%s token is expired. Please increase the job timeout.", c.provider.title)
+		}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token, nil
+}
+
+func (c *ciOIDC) ApplyCredentials(req *http.Request) error {
+	return applyJWTBasedCredentials(req, c)
+}
+
+func (c *ciOIDC) RedactCredentials(req *http.Request) {
+	redactJWTBasedCredentials(req)
+}
+
+// Validate if the credential is ready to be used.
+func (c *ciOIDC) fetchDetails() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCloudTimeout)
+	defer cancel()
+	orgs, err := c.client.MemberOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+	c.orgs = orgs
+	return nil
+}
+
+// Info display the credential details.
+func (c *ciOIDC) Info(selectedOrgName string) {
+	printer.Stdout.Println(fmt.Sprintf("provider: %s", c.Name()))
+	if selectedOrgName == "" {
+		printer.Stderr.ErrorWithDetails(
+			"Missing cloud configuration",
+			errors.E("Please set TM_CLOUD_ORGANIZATION environment variable or "+
+				"terramate.config.cloud.organization configuration attribute to a specific organization",
+			),
+		)
+		return
+	}
+	trustedOrgs := c.orgs.TrustedOrgs()
+	org, found := trustedOrgs.LookupByName(selectedOrgName)
+	if !found {
+		printer.Stdout.Println("status: untrusted")
+		printer.Stderr.Error(errors.E("selected organization %s not found among trusted organizations", selectedOrgName))
+		return
+	}
+
+	printer.Stdout.Println("status: trusted")
+	printer.Stdout.Println(fmt.Sprintf("selected organization: %s", org))
+
+	for _, kv := range c.DisplayClaims() {
+		printer.Stdout.Println(fmt.Sprintf("%s: %s", kv.key, kv.value))
+	}
+}
+
+// Organizations that the CI OIDC token belong to.
+func (c *ciOIDC) Organizations() resources.MemberOrganizations {
+	return c.orgs
+}
+
+func supportedCIOIDCProviders() []string {
+	names := make([]string, 0, len(ciOIDCProviders))
+	for name := range ciOIDCProviders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// circleciOIDCToken returns the token injected by CircleCI in the job
+// environment or, if a custom audience is requested, issues a new token
+// with the CircleCI CLI.
+func circleciOIDCToken(ctx context.Context, audience string) (string, error) {
+	if audience == "" {
+		for _, env := range []string{"CIRCLE_OIDC_TOKEN_V2", "CIRCLE_OIDC_TOKEN"} {
+			if token := os.Getenv(env); token != "" {
+				return token, nil
+			}
+		}
+		return "", errors.E("CIRCLE_OIDC_TOKEN_V2 environment variable is not set")
+	}
+	claims, err := json.Marshal(map[string]string{"aud": audience})
+	if err != nil {
+		return "", errors.E(err, "encoding CircleCI OIDC claims")
+	}
+	return execOIDCToken(ctx, "circleci", "run", "oidc", "get", "--claims", string(claims))
+}
+
+// buildkiteOIDCToken issues a new token with the Buildkite agent.
+func buildkiteOIDCToken(ctx context.Context, audience string) (string, error) {
+	args := []string{"oidc", "request-token"}
+	if audience != "" {
+		args = append(args, "--audience", audience)
+	}
+	return execOIDCToken(ctx, "buildkite-agent", args...)
+}
+
+// azureDevopsOIDCToken requests a new token to the OIDC endpoint of the
+// pipeline job, using the job access token. The access token is only
+// available to the scripts that map it explicitly, eg.:
+//
+//	env:
+//	  SYSTEM_ACCESSTOKEN: $(System.AccessToken)
+//
+// The tokens are issued for the workload identity federation of the service
+// connection set in TM_AZURE_DEVOPS_SERVICE_CONNECTION_ID, if any, and have a
+// fixed audience.
+func azureDevopsOIDCToken(ctx context.Context, audience string) (token string, err error) {
+	if audience != "" {
+		return "", errors.E("Azure DevOps OIDC tokens do not support custom audiences")
+	}
+	accessToken := os.Getenv("SYSTEM_ACCESSTOKEN")
+	if accessToken == "" {
+		return "", errors.E("SYSTEM_ACCESSTOKEN environment variable is not set: " +
+			"map it to $(System.AccessToken) in the env of the pipeline step")
+	}
+	u, err := url.Parse(os.Getenv("SYSTEM_OIDCREQUESTURI"))
+	if err != nil {
+		return "", errors.E(err, "invalid SYSTEM_OIDCREQUESTURI environment variable")
+	}
+	qr := u.Query()
+	qr.Set("api-version", "7.1-preview.1")
+	if id := os.Getenv(azureDevopsServiceConnectionEnv); id != "" {
+		qr.Set("serviceConnectionId", id)
+	}
+	u.RawQuery = qr.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), nil)
+	if err != nil {
+		return "", errors.E(err, "creating Azure DevOps OIDC request")
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", errors.E(err, "requesting POST %s", u.Redacted())
+	}
+	defer func() {
+		err = errors.L(err, resp.Body.Close()).AsError()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.E(err, "reading response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.E("unexpected status code: %s while requesting POST %s", resp.Status, u.Redacted())
+	}
+	var tokresp struct {
+		OIDCToken string `json:"oidcToken"`
+	}
+	if err := json.Unmarshal(data, &tokresp); err != nil {
+		return "", errors.E(err, "unmarshaling Azure DevOps OIDC JSON response")
+	}
+	if tokresp.OIDCToken == "" {
+		return "", errors.E("Azure DevOps OIDC response has no token")
+	}
+	return tokresp.OIDCToken, nil
+}
+
+// genericOIDCToken returns the token set in the TM_CLOUD_OIDC_TOKEN
+// environment variable or, if unset, read from the file set in the
+// TM_CLOUD_OIDC_TOKEN_FILE environment variable. The file is read on each
+// refresh, so runners rotating the token in place are supported.
+func genericOIDCToken(_ context.Context, _ string) (string, error) {
+	if token := os.Getenv(genericOIDCTokenEnv); token != "" {
+		return token, nil
+	}
+	fname := os.Getenv(genericOIDCTokenFileEnv)
+	if fname == "" {
+		return "", errors.E("%s or %s environment variable must be set",
+			genericOIDCTokenEnv, genericOIDCTokenFileEnv)
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return "", errors.E(err, "reading OIDC token file")
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func execOIDCToken(ctx context.Context, name string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.E(err, "executing %s: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cliauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/cloud"
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/ui/tui/cliconfig"
+)
+
+const (
+	testAzureAccessToken       = "azure-access-token"
+	testAzureServiceConnection = "service-connection"
+)
+
+func TestAzureDevopsOIDCToken(t *testing.T) {
+	srv := newFakeAzureDevops(t, testJWT(t, time.Hour))
+	setAzureDevopsEnv(t, srv.URL)
+
+	token, err := azureDevopsOIDCToken(context.Background(), "")
+	assert.NoError(t, err)
+	assert.EqualStrings(t, srv.oidcToken, token)
+}
+
+func TestAzureDevopsOIDCTokenFailures(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		audience  string
+		env       map[string]string
+		oidcToken string
+		status    int
+		want      string
+	}{
+		{
+			name:     "custom audience",
+			audience: "terramate",
+			want:     "do not support custom audiences",
+		},
+		{
+			name: "missing access token",
+			env:  map[string]string{"SYSTEM_ACCESSTOKEN": ""},
+			want: "SYSTEM_ACCESSTOKEN environment variable is not set",
+		},
+		{
+			name: "invalid access token",
+			env:  map[string]string{"SYSTEM_ACCESSTOKEN": "invalid"},
+			want: "unexpected status code: 401 Unauthorized",
+		},
+		{
+			name:   "server failure",
+			status: http.StatusInternalServerError,
+			want:   "unexpected status code: 500 Internal Server Error",
+		},
+		{
+			name: "no token",
+			want: "Azure DevOps OIDC response has no token",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newFakeAzureDevops(t, tc.oidcToken)
+			srv.status = tc.status
+			setAzureDevopsEnv(t, srv.URL)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := azureDevopsOIDCToken(context.Background(), tc.audience)
+			assert.IsTrue(t, err != nil, "want error %q", tc.want)
+			assert.IsTrue(t, strings.Contains(err.Error(), tc.want),
+				"error %q does not contain %q", err, tc.want)
+		})
+	}
+}
+
+func TestCIOIDCExchangeAzureDevops(t *testing.T) {
+	oidcToken := testJWT(t, time.Hour)
+	azure := newFakeAzureDevops(t, oidcToken)
+	setAzureDevopsEnv(t, azure.URL)
+	t.Setenv(ciOIDCProviderEnv, "")
+	t.Setenv(ciOIDCIssuerEnv, "")
+	t.Setenv(ciOIDCAudienceEnv, "")
+
+	var gotAuth string
+	tmc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != cloud.MembershipsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resources.MemberOrganizations{
+			{Name: "terramate", UUID: "0000-1111", Status: "active"},
+		})
+	}))
+	t.Cleanup(tmc.Close)
+
+	client := cloud.NewClient(cloud.WithBaseURL(tmc.URL))
+	clicfg := cliconfig.Config{
+		OIDC: map[string]cliconfig.OIDCConfig{
+			"azuredevops": {Issuer: "https://vstoken.dev.azure.com/org"},
+		},
+	}
+	cred := newCIOIDC(printer.DefaultPrinters, 0, clicfg, client)
+
+	found, err := cred.Load()
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "Azure DevOps must be detected")
+	assert.EqualStrings(t, "Azure DevOps OIDC", cred.Name())
+	assert.EqualStrings(t, "Bearer "+oidcToken, gotAuth)
+	assert.EqualInts(t, 1, len(cred.Organizations()))
+	assert.EqualStrings(t, "terramate", cred.Organizations()[0].Name)
+	assert.IsTrue(t, !cred.IsExpired(), "token must not be expired")
+
+	token, err := cred.Token()
+	assert.NoError(t, err)
+	assert.EqualStrings(t, oidcToken, token)
+}
+
+func TestCIOIDCExchangeRejectsUnexpectedIssuer(t *testing.T) {
+	azure := newFakeAzureDevops(t, testJWT(t, time.Hour))
+	setAzureDevopsEnv(t, azure.URL)
+	t.Setenv(ciOIDCProviderEnv, "azuredevops")
+	t.Setenv(ciOIDCIssuerEnv, "https://other.issuer")
+	t.Setenv(ciOIDCAudienceEnv, "")
+
+	client := cloud.NewClient(cloud.WithBaseURL("http://127.0.0.1:1"))
+	cred := newCIOIDC(printer.DefaultPrinters, 0, cliconfig.Config{}, client)
+
+	found, err := cred.Load()
+	assert.IsTrue(t, found, "provider set in the environment must be found")
+	assert.IsTrue(t, err != nil && strings.Contains(err.Error(), `but "https://other.issuer" is expected`),
+		"unexpected error: %v", err)
+}
+
+// fakeAzureDevops implements the OIDC token endpoint of the Azure DevOps
+// pipeline jobs.
+type fakeAzureDevops struct {
+	*httptest.Server
+
+	oidcToken string
+	status    int
+}
+
+func newFakeAzureDevops(t *testing.T, oidcToken string) *fakeAzureDevops {
+	t.Helper()
+
+	fake := &fakeAzureDevops{oidcToken: oidcToken}
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method != http.MethodPost || r.URL.Path != "/oidctoken":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.Header.Get("Authorization") != "Bearer "+testAzureAccessToken:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		case query.Get("api-version") == "" ||
+			query.Get("serviceConnectionId") != testAzureServiceConnection:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		case fake.status != 0:
+			w.WriteHeader(fake.status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"oidcToken": fake.oidcToken})
+	}))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func setAzureDevopsEnv(t *testing.T, url string) {
+	t.Setenv("TF_BUILD", "True")
+	t.Setenv("SYSTEM_OIDCREQUESTURI", url+"/oidctoken")
+	t.Setenv("SYSTEM_ACCESSTOKEN", testAzureAccessToken)
+	t.Setenv(azureDevopsServiceConnectionEnv, testAzureServiceConnection)
+}
+
+func testJWT(t *testing.T, expiresIn time.Duration) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://vstoken.dev.azure.com/org",
+		"sub": "sc://org/project/connection",
+		"exp": time.Now().Add(expiresIn).Unix(),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	return token
+}
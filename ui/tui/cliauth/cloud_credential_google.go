@@ -15,7 +15,6 @@ import (
 	stdhttp "net/http"
 	"net/url"
 	"os"
-	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -99,6 +98,10 @@ type (
 		Provider     string `json:"provider"`
 		IDToken      string `json:"id_token"`
 		RefreshToken string `json:"refresh_token"`
+
+		// Organization is the organization selected with
+		// `terramate cloud org switch`.
+		Organization string `json:"organization,omitempty"`
 	}
 )
 
@@ -422,50 +425,62 @@ func (h *tokenHandler) handleErr(w stdhttp.ResponseWriter) {
 }
 
 func saveCredential(printers printer.Printers, verbosity int, providerID string, cred credentialInfo, clicfg cliconfig.Config) error {
+	store := NewCredentialStore(clicfg)
 	cachePayload := cachedCredential{
 		Provider:     providerID,
 		IDToken:      cred.IDToken,
 		RefreshToken: cred.RefreshToken,
 	}
 
-	data, err := stdjson.Marshal(&cachePayload)
-	if err != nil {
-		return errors.E(err, "failed to JSON marshal the credentials")
+	// the selected organization is kept across logins.
+	if previous, found, err := readCachedCredential(store); err == nil && found {
+		cachePayload.Organization = previous.Organization
 	}
 
-	credfile := filepath.Join(clicfg.UserTerramateDir, credfile)
-	err = os.WriteFile(credfile, data, 0600)
+	err := writeCachedCredential(store, cachePayload)
 	if err != nil {
 		return errors.E(err, "failed to cache credentials")
 	}
 
 	if verbosity > 0 {
-		printers.Stdout.Println(fmt.Sprintf("credentials cached at %s", credfile))
+		printers.Stdout.Println(fmt.Sprintf("credentials cached at %s", store.Location()))
 	}
 	return nil
 }
 
 func (g *googleCredential) loadCredential() (cachedCredential, bool, error) {
-	credFile := filepath.Join(g.clicfg.UserTerramateDir, credfile)
-	_, err := os.Lstat(credFile)
-	if err != nil {
-		return cachedCredential{}, false, nil
+	store := NewCredentialStore(g.clicfg)
+	cred, found, err := readCachedCredential(store)
+	if err != nil || !found {
+		return cachedCredential{}, found, err
 	}
-	contents, err := os.ReadFile(credFile)
-	if err != nil {
-		return cachedCredential{}, true, err
+	if g.verbosity > 0 {
+		g.printers.Stdout.Println(fmt.Sprintf("credentials loaded from %s", store.Location()))
+	}
+	return cred, true, nil
+}
+
+func readCachedCredential(store CredentialStore) (cachedCredential, bool, error) {
+	contents, found, err := store.Load()
+	if err != nil || !found {
+		return cachedCredential{}, found, err
 	}
 	var cred cachedCredential
 	err = stdjson.Unmarshal(contents, &cred)
 	if err != nil {
 		return cachedCredential{}, true, err
 	}
-	if g.verbosity > 0 {
-		g.printers.Stdout.Println(fmt.Sprintf("credentials loaded from %s", credFile))
-	}
 	return cred, true, nil
 }
 
+func writeCachedCredential(store CredentialStore, cred cachedCredential) error {
+	data, err := stdjson.Marshal(&cred)
+	if err != nil {
+		return errors.E(err, "failed to JSON marshal the credentials")
+	}
+	return store.Save(data)
+}
+
 func endpointURL(endpoint string, idpKey string) *url.URL {
 	u, err := url.Parse(endpoint)
 	if err != nil {
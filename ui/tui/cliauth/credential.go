@@ -50,6 +50,7 @@ func ProbingPrecedence(printers printer.Printers, verbosity int, client *cloud.C
 		newAPIKey(printers, verbosity, client),
 		newGithubOIDC(printers, verbosity, client),
 		newGitlabOIDC(printers, verbosity, client),
+		newCIOIDC(printers, verbosity, clicfg, client),
 		newGoogleCredential(printers, verbosity, clicfg, client),
 	}
 }
@@ -59,6 +60,35 @@ func CredentialFile(clicfg cliconfig.Config) string {
 	return filepath.Join(clicfg.UserTerramateDir, credfile)
 }
 
+// SelectedOrganization returns the organization selected with
+// [SelectOrganization], or an empty string if none is selected.
+func SelectedOrganization(clicfg cliconfig.Config) string {
+	cred, found, err := readCachedCredential(NewCredentialStore(clicfg))
+	if err != nil || !found {
+		return ""
+	}
+	return cred.Organization
+}
+
+// SelectOrganization stores the organization in the cached credential, to
+// be used when no organization is set for the project.
+func SelectOrganization(clicfg cliconfig.Config, orgName string) error {
+	store := NewCredentialStore(clicfg)
+	cred, found, err := readCachedCredential(store)
+	if err != nil {
+		return errors.E(err, "reading the cached credentials")
+	}
+	if !found {
+		return errors.E(ErrLoginRequired,
+			"selecting an organization requires the credentials of `terramate cloud login`")
+	}
+	cred.Organization = orgName
+	if err := writeCachedCredential(store, cred); err != nil {
+		return errors.E(err, "saving the selected organization")
+	}
+	return nil
+}
+
 func tokenClaims(token string) (jwt.MapClaims, error) {
 	jwtParser := &jwt.Parser{}
 	tokParsed, _, err := jwtParser.ParseUnverified(token, jwt.MapClaims{})
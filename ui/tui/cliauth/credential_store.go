@@ -0,0 +1,129 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cliauth
+
+import (
+	"os"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/ui/tui/cliconfig"
+)
+
+// ErrKeyringUnavailable indicates that the OS keyring cannot be used.
+const ErrKeyringUnavailable errors.Kind = "OS keyring is not available"
+
+const (
+	keyringService = "terramate"
+	keyringAccount = "cloud-credentials"
+
+	// keyringTimeout is the maximum time waiting for the keyring commands,
+	// which block while the keyring is locked waiting to be unlocked.
+	keyringTimeout = 30 * time.Second
+)
+
+// CredentialStore stores the cached cloud credential.
+type CredentialStore interface {
+	// Location describes where the credential is stored.
+	Location() string
+
+	// Load loads the credential, returning false if it's not stored.
+	Load() ([]byte, bool, error)
+
+	// Save stores the credential, replacing the existing one.
+	Save(data []byte) error
+}
+
+// NewCredentialStore returns the credential store configured with the
+// credential_storage attribute of the CLI configuration. By default, the OS
+// keyring is used if available, falling back to the credential file.
+func NewCredentialStore(clicfg cliconfig.Config) CredentialStore {
+	file := fileStore{path: CredentialFile(clicfg)}
+	switch clicfg.CredentialStorage {
+	case cliconfig.CredentialStorageFile:
+		return file
+	case cliconfig.CredentialStorageKeyring:
+		return newKeyringStore(keyringService, keyringAccount)
+	default:
+		keyring := newKeyringStore(keyringService, keyringAccount)
+		if !keyring.available() {
+			return file
+		}
+		return &autoStore{keyring: keyring, file: file}
+	}
+}
+
+// keyringStore is a [CredentialStore] backed by the OS keyring.
+type keyringStore interface {
+	CredentialStore
+
+	// available tells if the keyring can be used in this environment.
+	available() bool
+}
+
+// fileStore stores the credential in a plaintext file readable only by
+// the user.
+type fileStore struct {
+	path string
+}
+
+func (f fileStore) Location() string { return f.path }
+
+func (f fileStore) Load() ([]byte, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, true, errors.E(err, "reading %s", f.path)
+	}
+	return data, true, nil
+}
+
+func (f fileStore) Save(data []byte) error {
+	if err := os.WriteFile(f.path, data, 0600); err != nil {
+		return errors.E(err, "writing %s", f.path)
+	}
+	return nil
+}
+
+// autoStore stores the credential in the OS keyring, falling back to the
+// credential file if the keyring fails. The credential file of previous
+// versions is still loaded and removed once the keyring is used.
+type autoStore struct {
+	keyring keyringStore
+	file    fileStore
+
+	// used is the store of the last loaded or saved credential.
+	used CredentialStore
+}
+
+func (a *autoStore) Location() string {
+	if a.used == nil {
+		return a.keyring.Location()
+	}
+	return a.used.Location()
+}
+
+func (a *autoStore) Load() ([]byte, bool, error) {
+	data, found, err := a.keyring.Load()
+	if err == nil && found {
+		a.used = a.keyring
+		return data, true, nil
+	}
+	a.used = a.file
+	return a.file.Load()
+}
+
+func (a *autoStore) Save(data []byte) error {
+	if err := a.keyring.Save(data); err != nil {
+		a.used = a.file
+		return a.file.Save(data)
+	}
+	a.used = a.keyring
+	if err := os.Remove(a.file.path); err != nil && !os.IsNotExist(err) {
+		return errors.E(err, "removing the plaintext credentials %s", a.file.path)
+	}
+	return nil
+}
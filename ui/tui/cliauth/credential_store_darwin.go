@@ -0,0 +1,81 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build darwin
+
+package cliauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+// keychainItemNotFound is the exit status of the security command when the
+// keychain item does not exist.
+const keychainItemNotFound = 44
+
+// keychainStore stores the credential in the macOS Keychain through the
+// security command.
+type keychainStore struct {
+	service string
+	account string
+}
+
+func newKeyringStore(service, account string) keyringStore {
+	return keychainStore{service: service, account: account}
+}
+
+func (k keychainStore) Location() string {
+	return fmt.Sprintf("macOS Keychain (service=%s, account=%s)", k.service, k.account)
+}
+
+func (k keychainStore) available() bool {
+	_, err := exec.LookPath("security")
+	return err == nil
+}
+
+func (k keychainStore) Load() ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "security", "find-generic-password", "-s", k.service, "-a", k.account, "-w")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if stderrors.As(err, &exitErr) && exitErr.ExitCode() == keychainItemNotFound {
+			return nil, false, nil
+		}
+		return nil, false, errors.E(ErrKeyringUnavailable, err, "security find-generic-password: %s", stderr.String())
+	}
+	data, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(out)))
+	if err != nil {
+		return nil, true, errors.E(err, "decoding the keychain item")
+	}
+	return data, true, nil
+}
+
+func (k keychainStore) Save(data []byte) error {
+	// the password is written to the interactive mode of the security
+	// command, so it's not exposed in the process arguments.
+	password := hex.EncodeToString([]byte(base64.StdEncoding.EncodeToString(data)))
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "security", "-i")
+	cmd.Stdin = bytes.NewBufferString(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		strconv.Quote(k.service), strconv.Quote(k.account), password))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.E(ErrKeyringUnavailable, err, "security add-generic-password: %s", stderr.String())
+	}
+	return nil
+}
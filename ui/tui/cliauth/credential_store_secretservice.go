@@ -0,0 +1,75 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !darwin && !windows
+
+package cliauth
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+// secretServiceStore stores the credential with the Secret Service API
+// (GNOME Keyring, KWallet, etc) through the secret-tool command.
+type secretServiceStore struct {
+	service string
+	account string
+}
+
+func newKeyringStore(service, account string) keyringStore {
+	return secretServiceStore{service: service, account: account}
+}
+
+func (s secretServiceStore) Location() string {
+	return fmt.Sprintf("Secret Service (service=%s, account=%s)", s.service, s.account)
+}
+
+func (s secretServiceStore) available() bool {
+	_, err := exec.LookPath("secret-tool")
+	return err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+}
+
+func (s secretServiceStore) Load() ([]byte, bool, error) {
+	if !s.available() {
+		return nil, false, errors.E(ErrKeyringUnavailable, "secret-tool and a D-Bus session are required")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "secret-tool", "lookup", "service", s.service, "account", s.account)
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if ctx.Err() == nil && stderrors.As(err, &exitErr) && stderr.Len() == 0 {
+			// secret-tool exits with status 1 and no message if not found.
+			return nil, false, nil
+		}
+		return nil, false, errors.E(ErrKeyringUnavailable, err, "secret-tool lookup: %s", stderr.String())
+	}
+	return bytes.TrimSuffix(data, []byte("\n")), true, nil
+}
+
+func (s secretServiceStore) Save(data []byte) error {
+	if !s.available() {
+		return errors.E(ErrKeyringUnavailable, "secret-tool and a D-Bus session are required")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "secret-tool", "store", "--label=Terramate Cloud credentials",
+		"service", s.service, "account", s.account)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.E(ErrKeyringUnavailable, err, "secret-tool store: %s", stderr.String())
+	}
+	return nil
+}
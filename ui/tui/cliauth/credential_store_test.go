@@ -0,0 +1,44 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package cliauth
+
+import (
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"github.com/terramate-io/terramate/ui/tui/cliconfig"
+)
+
+func TestSelectOrganizationKeptAcrossLogins(t *testing.T) {
+	t.Parallel()
+
+	clicfg := cliconfig.Config{
+		UserTerramateDir:  t.TempDir(),
+		CredentialStorage: cliconfig.CredentialStorageFile,
+	}
+
+	err := SelectOrganization(clicfg, "terramate")
+	assert.IsError(t, err, errors.E(ErrLoginRequired))
+	assert.EqualStrings(t, "", SelectedOrganization(clicfg))
+
+	login := func(token string) {
+		err := saveCredential(printer.DefaultPrinters, 0, "Google",
+			credentialInfo{IDToken: token, RefreshToken: "refresh"}, clicfg)
+		assert.NoError(t, err)
+	}
+
+	login("token1")
+	assert.NoError(t, SelectOrganization(clicfg, "terramate"))
+	assert.EqualStrings(t, "terramate", SelectedOrganization(clicfg))
+
+	login("token2")
+	assert.EqualStrings(t, "terramate", SelectedOrganization(clicfg))
+
+	cred, found, err := readCachedCredential(NewCredentialStore(clicfg))
+	assert.NoError(t, err)
+	assert.IsTrue(t, found, "credential must be cached")
+	assert.EqualStrings(t, "token2", cred.IDToken)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package cliauth
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+
+	// credMaxBlobSize is the maximum size of the credential blob of the
+	// generic credentials (CRED_MAX_CREDENTIAL_BLOB_SIZE).
+	credMaxBlobSize = 5 * 512
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// winCredential is the CREDENTIALW structure of the Windows API.
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialManagerStore stores the credential in the Windows Credential
+// Manager.
+type credentialManagerStore struct {
+	service string
+	account string
+}
+
+func newKeyringStore(service, account string) keyringStore {
+	return credentialManagerStore{service: service, account: account}
+}
+
+func (c credentialManagerStore) target() string {
+	return c.service + ":" + c.account
+}
+
+func (c credentialManagerStore) Location() string {
+	return fmt.Sprintf("Windows Credential Manager (target=%s)", c.target())
+}
+
+func (c credentialManagerStore) available() bool {
+	return procCredReadW.Find() == nil && procCredWriteW.Find() == nil
+}
+
+func (c credentialManagerStore) Load() ([]byte, bool, error) {
+	target, err := syscall.UTF16PtrFromString(c.target())
+	if err != nil {
+		return nil, false, errors.E(err, "invalid credential target")
+	}
+	var cred *winCredential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if err == errorNotFound {
+			return nil, false, nil
+		}
+		return nil, false, errors.E(ErrKeyringUnavailable, err, "reading the Windows Credential Manager")
+	}
+	defer func() { _, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(cred))) }()
+
+	data := make([]byte, cred.CredentialBlobSize)
+	copy(data, unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize))
+	return data, true, nil
+}
+
+func (c credentialManagerStore) Save(data []byte) error {
+	target, err := syscall.UTF16PtrFromString(c.target())
+	if err != nil {
+		return errors.E(err, "invalid credential target")
+	}
+	user, err := syscall.UTF16PtrFromString(c.account)
+	if err != nil {
+		return errors.E(err, "invalid credential account")
+	}
+	if len(data) == 0 {
+		return errors.E("empty credential")
+	}
+	if len(data) > credMaxBlobSize {
+		return errors.E(ErrKeyringUnavailable,
+			"credential of %d bytes exceeds the Windows Credential Manager limit of %d bytes",
+			len(data), credMaxBlobSize)
+	}
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(data)),
+		CredentialBlob:     &data[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return errors.E(ErrKeyringUnavailable, err, "writing the Windows Credential Manager")
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "cliconfig" {
-  content = <<-EOT
+  content = <<-EOF
 package cliconfig // import "github.com/terramate-io/terramate/cmd/terramate/cli/cliconfig"
 
 Package cliconfig implements the parser and load of Terramate CLI Configuration
@@ -13,7 +13,7 @@ const Filename = ".terramaterc"
 type Config struct{ ... }
     func Load() (cfg Config, err error)
     func LoadFrom(fname string) (Config, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-cliconfig.ignore"
 }
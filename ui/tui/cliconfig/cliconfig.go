@@ -23,6 +23,26 @@ const (
 
 	// ErrUnrecognizedAttribute indicates the attribute is unrecognized.
 	ErrUnrecognizedAttribute errors.Kind = "unrecognized attribute"
+
+	// ErrUnrecognizedBlock indicates the block is unrecognized.
+	ErrUnrecognizedBlock errors.Kind = "unrecognized block"
+
+	// ErrInvalidBlock indicates the block is invalid.
+	ErrInvalidBlock errors.Kind = "invalid block"
+
+	// ErrInvalidAttributeValue indicates the attribute has an invalid value.
+	ErrInvalidAttributeValue errors.Kind = "attribute with invalid value"
+)
+
+// Storages of the cached cloud credential.
+const (
+	// CredentialStorageAuto uses the OS keyring if available, falling back
+	// to the credential file.
+	CredentialStorageAuto = "auto"
+	// CredentialStorageKeyring uses the OS keyring.
+	CredentialStorageKeyring = "keyring"
+	// CredentialStorageFile uses the credential file.
+	CredentialStorageFile = "file"
 )
 
 // Config is the evaluated CLI configuration options.
@@ -31,6 +51,39 @@ type Config struct {
 	DisableCheckpointSignature bool
 	DisableTelemetry           bool
 	UserTerramateDir           string
+
+	// Theme is the color theme of the output.
+	Theme string
+	// DisableColor disables the colors of the output.
+	DisableColor bool
+	// DisableEmoji disables the emoji of the output.
+	DisableEmoji bool
+
+	// Offline disables all the features accessing the network, which fail
+	// when used instead of reaching the network.
+	Offline bool
+
+	// CloudOrganization is the Terramate Cloud organization set with the
+	// --org flag, which overrides the organization of the project.
+	CloudOrganization string
+
+	// CredentialStorage is the storage of the cached cloud credential:
+	// "auto" (the default), "keyring" or "file".
+	CredentialStorage string
+
+	// OIDC maps the name of a CI provider to the OIDC settings used to
+	// authenticate to Terramate Cloud from it.
+	OIDC map[string]OIDCConfig
+}
+
+// OIDCConfig is the configuration of the OIDC token exchange of a CI provider.
+type OIDCConfig struct {
+	// Issuer is the expected issuer ("iss" claim) of the OIDC token.
+	// If empty, the issuer is not checked.
+	Issuer string
+	// Audience is the audience requested for the OIDC token.
+	// If empty, the provider default is used.
+	Audience string
 }
 
 // Load loads (parses and evaluates) all CLI configuration files.
@@ -87,14 +140,97 @@ func LoadFrom(fname string) (Config, error) {
 				return Config{}, err
 			}
 			cfg.UserTerramateDir = val.AsString()
+		case "theme":
+			if err := checkStrType(val, name); err != nil {
+				return Config{}, err
+			}
+			cfg.Theme = val.AsString()
+		case "disable_color":
+			if err := checkBoolType(val, name); err != nil {
+				return Config{}, err
+			}
+			cfg.DisableColor = val.True()
+		case "disable_emoji":
+			if err := checkBoolType(val, name); err != nil {
+				return Config{}, err
+			}
+			cfg.DisableEmoji = val.True()
+		case "offline":
+			if err := checkBoolType(val, name); err != nil {
+				return Config{}, err
+			}
+			cfg.Offline = val.True()
+		case "credential_storage":
+			if err := checkStrType(val, name); err != nil {
+				return Config{}, err
+			}
+			switch storage := val.AsString(); storage {
+			case CredentialStorageAuto, CredentialStorageKeyring, CredentialStorageFile:
+				cfg.CredentialStorage = storage
+			default:
+				return Config{}, errors.E(ErrInvalidAttributeValue,
+					`%q attribute must be "auto", "keyring" or "file" but %q was given`, name, storage)
+			}
 		default:
 			return cfg, errors.E(ErrUnrecognizedAttribute, name)
 		}
 	}
 
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "oidc":
+			name, oidcCfg, err := loadOIDCBlock(block)
+			if err != nil {
+				return Config{}, err
+			}
+			if _, ok := cfg.OIDC[name]; ok {
+				return Config{}, errors.E(ErrInvalidBlock, block.Range(), "duplicated oidc block for provider %q", name)
+			}
+			if cfg.OIDC == nil {
+				cfg.OIDC = map[string]OIDCConfig{}
+			}
+			cfg.OIDC[name] = oidcCfg
+		default:
+			return cfg, errors.E(ErrUnrecognizedBlock, block.Range(), block.Type)
+		}
+	}
+
 	return cfg, nil
 }
 
+func loadOIDCBlock(block *hclsyntax.Block) (string, OIDCConfig, error) {
+	if len(block.Labels) != 1 || block.Labels[0] == "" {
+		return "", OIDCConfig{}, errors.E(ErrInvalidBlock, block.Range(),
+			"oidc block requires the CI provider name as its single label")
+	}
+	if len(block.Body.Blocks) > 0 {
+		return "", OIDCConfig{}, errors.E(ErrUnrecognizedBlock, block.Body.Blocks[0].Range(),
+			block.Body.Blocks[0].Type)
+	}
+	var cfg OIDCConfig
+	for name, attr := range block.Body.Attributes {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return "", OIDCConfig{}, errors.E(diags, eval.ErrEval, `failed to evaluate the "oidc.%s" attribute`, name)
+		}
+		switch name {
+		case "issuer":
+			if err := checkStrType(val, name); err != nil {
+				return "", OIDCConfig{}, err
+			}
+			cfg.Issuer = val.AsString()
+		case "audience":
+			if err := checkStrType(val, name); err != nil {
+				return "", OIDCConfig{}, err
+			}
+			cfg.Audience = val.AsString()
+		default:
+			return "", OIDCConfig{}, errors.E(ErrUnrecognizedAttribute, "oidc.%s", name)
+		}
+	}
+	return block.Labels[0], cfg, nil
+}
+
 func checkBoolType(val cty.Value, name string) error {
 	if !val.Type().Equals(cty.Bool) {
 		return errors.E(
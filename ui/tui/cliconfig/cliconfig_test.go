@@ -147,6 +147,127 @@ func TestLoad(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid output options",
+			cfg: `
+				theme         = "colorblind"
+				disable_color = true
+				disable_emoji = true
+			`,
+			want: want{
+				cfg: cliconfig.Config{
+					Theme:        "colorblind",
+					DisableColor: true,
+					DisableEmoji: true,
+				},
+			},
+		},
+		{
+			name: "valid offline",
+			cfg:  `offline = true`,
+			want: want{
+				cfg: cliconfig.Config{
+					Offline: true,
+				},
+			},
+		},
+		{
+			name: "offline with wrong type",
+			cfg:  `offline = "yes"`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidAttributeType),
+			},
+		},
+		{
+			name: "valid credential_storage",
+			cfg:  `credential_storage = "keyring"`,
+			want: want{
+				cfg: cliconfig.Config{
+					CredentialStorage: cliconfig.CredentialStorageKeyring,
+				},
+			},
+		},
+		{
+			name: "credential_storage with invalid value",
+			cfg:  `credential_storage = "vault"`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidAttributeValue),
+			},
+		},
+		{
+			name: "theme with wrong type",
+			cfg:  `theme = true`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidAttributeType),
+			},
+		},
+		{
+			name: "oidc blocks",
+			cfg: `
+				oidc "circleci" {
+					issuer   = "https://oidc.circleci.com/org/abc"
+					audience = "terramate"
+				}
+				oidc "generic" {}
+			`,
+			want: want{
+				cfg: cliconfig.Config{
+					OIDC: map[string]cliconfig.OIDCConfig{
+						"circleci": {
+							Issuer:   "https://oidc.circleci.com/org/abc",
+							Audience: "terramate",
+						},
+						"generic": {},
+					},
+				},
+			},
+		},
+		{
+			name: "oidc block without label",
+			cfg:  `oidc {}`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidBlock),
+			},
+		},
+		{
+			name: "duplicated oidc block",
+			cfg: `
+				oidc "buildkite" {}
+				oidc "buildkite" {}
+			`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidBlock),
+			},
+		},
+		{
+			name: "oidc block with wrong attribute type",
+			cfg: `
+				oidc "buildkite" {
+					audience = 1
+				}
+			`,
+			want: want{
+				err: errors.E(cliconfig.ErrInvalidAttributeType),
+			},
+		},
+		{
+			name: "oidc block with unrecognized attribute",
+			cfg: `
+				oidc "buildkite" {
+					subject = "a"
+				}
+			`,
+			want: want{
+				err: errors.E(cliconfig.ErrUnrecognizedAttribute),
+			},
+		},
+		{
+			name: "unrecognized block",
+			cfg:  `unrecognized {}`,
+			want: want{
+				err: errors.E(cliconfig.ErrUnrecognizedBlock),
+			},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "clitest" {
-  content = <<-EOT
+  content = <<-EOF
 package clitest // import "github.com/terramate-io/terramate/cmd/terramate/cli/clitest"
 
 Package clitest provides constants and errors kind reused by the cli
@@ -9,7 +9,7 @@ implementation and the e2e test infrastructure.
 
 const CloudDisablingMessage = "disabling the cloud features" ...
 const ErrCloud errors.Kind = "unprocessable cloud feature" ...
-EOT
+EOF
 
   filename = "${path.module}/mock-clitest.ignore"
 }
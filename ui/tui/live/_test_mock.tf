@@ -0,0 +1,17 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "live" {
+  content = <<-EOF
+package live // import "github.com/terramate-io/terramate/ui/tui/live"
+
+Package live implements a live view of the status of the stacks executed by the
+run command, used instead of the interleaved output of the commands.
+
+type Status int
+    const Queued Status = iota ...
+type View struct{ ... }
+    func New(w io.Writer, stacks []string) *View
+EOF
+
+  filename = "${path.module}/mock-live.ignore"
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package live
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadKeysStopsWithoutConsumingInput(t *testing.T) {
+	t.Parallel()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+	defer func() { _ = w.Close() }()
+
+	v := New(&bytes.Buffer{}, []string{"/a", "/b"})
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		v.readKeys(r, done)
+		close(stopped)
+	}()
+
+	if _, err := w.WriteString("j"); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		v.mu.Lock()
+		selected := v.selected
+		v.mu.Unlock()
+		if selected == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("key was not applied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(done)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading of the keys must stop with the view")
+	}
+
+	if _, err := w.WriteString("k"); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "k" {
+		t.Fatalf("input after the view stopped must not be consumed, got %q", buf)
+	}
+}
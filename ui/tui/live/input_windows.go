@@ -0,0 +1,26 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package live
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// waitInput waits up to the timeout for f to have input and tells if it has.
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+	event, err := windows.WaitForSingleObject(windows.Handle(f.Fd()), uint32(timeout.Milliseconds()))
+	switch {
+	case err != nil:
+		return false, err
+	case event == uint32(windows.WAIT_TIMEOUT):
+		return false, nil
+	default:
+		return true, nil
+	}
+}
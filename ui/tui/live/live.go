@@ -0,0 +1,486 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package live implements a live view of the status of the stacks executed
+// by the run command, used instead of the interleaved output of the commands.
+package live
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate/printer"
+	"golang.org/x/term"
+)
+
+// Status is the execution status of a stack.
+type Status int
+
+// Execution statuses of the stacks.
+const (
+	Queued Status = iota
+	Running
+	Success
+	Failed
+	Canceled
+)
+
+// expandedLines is the number of output lines shown for an expanded stack.
+const expandedLines = 10
+
+// maxOutputSize is the maximum size of the output kept for each stack. Only
+// the last lines of a larger output are kept.
+const maxOutputSize = 1 << 20
+
+// inputPollInterval is the interval in which the reading of the keyboard
+// input checks if the view was stopped.
+const inputPollInterval = 100 * time.Millisecond
+
+const help = "j/k or arrows: select, enter: expand output, ctrl-c: interrupt"
+
+// icon returns the emoji icon of the status.
+func (s Status) icon() string {
+	switch s {
+	case Queued:
+		return printer.IconQueued
+	case Running:
+		return printer.IconRunning
+	case Success:
+		return printer.IconSuccess
+	case Failed:
+		return printer.IconFailure
+	default:
+		return printer.IconCanceled
+	}
+}
+
+// role returns the printer role of the status.
+func (s Status) role() printer.Role {
+	switch s {
+	case Running:
+		return printer.RoleHighlight
+	case Success:
+		return printer.RoleSuccess
+	case Failed:
+		return printer.RoleError
+	case Canceled:
+		return printer.RoleWarning
+	default:
+		return printer.RoleFaint
+	}
+}
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case Queued:
+		return "queued"
+	case Running:
+		return "running"
+	case Success:
+		return "success"
+	case Failed:
+		return "failed"
+	case Canceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// View is a live table of the stacks, with their status, execution duration
+// and the last line of their output.
+type View struct {
+	mu sync.Mutex
+	w  io.Writer
+
+	stacks []*stack
+	index  map[string]*stack
+
+	selected int
+	expanded bool
+	stopped  bool
+
+	// lines is the number of lines of the last drawn frame.
+	lines int
+	// width is the terminal width. Lines are not truncated if zero.
+	width int
+
+	// OnInterrupt is called when ctrl-c is pressed.
+	OnInterrupt func()
+}
+
+type stack struct {
+	name     string
+	status   Status
+	started  time.Time
+	finished time.Time
+	output   bytes.Buffer
+	// omitted is the size of the output discarded to keep it within
+	// maxOutputSize.
+	omitted int
+}
+
+// New creates a view of the given stacks, in the order they are shown,
+// drawing its frames on w.
+func New(w io.Writer, stacks []string) *View {
+	v := &View{
+		w:     w,
+		index: map[string]*stack{},
+	}
+	for _, name := range stacks {
+		st := &stack{name: name}
+		v.stacks = append(v.stacks, st)
+		v.index[name] = st
+	}
+	return v
+}
+
+// Output returns the writer of the output of the stack commands.
+func (v *View) Output(name string) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		if st, ok := v.index[name]; ok {
+			st.write(p)
+		}
+		return len(p), nil
+	})
+}
+
+// Running marks the stack as running.
+func (v *View) Running(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if st, ok := v.index[name]; ok {
+		st.status = Running
+		st.started = time.Now()
+	}
+}
+
+// Done marks the stack as finished with the given status.
+func (v *View) Done(name string, status Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if st, ok := v.index[name]; ok {
+		st.status = status
+		st.finished = time.Now()
+	}
+}
+
+// Show draws the view on every interval, and reads the key bindings from in
+// if it's a terminal. The returned function stops the view, drawing its last
+// frame and restoring the terminal.
+func (v *View) Show(in *os.File, interval time.Duration) (stop func()) {
+	if out, ok := v.w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(out.Fd())); err == nil {
+			v.width = width
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	restore := func() {}
+	if fd := int(in.Fd()); term.IsTerminal(fd) {
+		if state, err := term.MakeRaw(fd); err == nil {
+			restore = func() { _ = term.Restore(fd, state) }
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v.readKeys(in, done)
+			}()
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			v.Draw()
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+		v.Draw()
+		v.mu.Lock()
+		v.stopped = true
+		v.mu.Unlock()
+		restore()
+	}
+}
+
+// Input applies the key bindings read from r until it's closed or the view
+// is stopped.
+func (v *View) Input(r io.Reader) {
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 && !v.key(string(buf[:n])) {
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readKeys applies the key bindings read from the terminal until the done
+// channel is closed. The terminal is only read when it has input, so no
+// input is consumed after the view is stopped.
+func (v *View) readKeys(in *os.File, done <-chan struct{}) {
+	buf := make([]byte, 16)
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		ready, err := waitInput(in, inputPollInterval)
+		if err != nil {
+			return
+		}
+		if !ready {
+			continue
+		}
+		n, err := in.Read(buf)
+		if n > 0 && !v.key(string(buf[:n])) {
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// key applies the key binding and tells if more keys are expected.
+func (v *View) key(key string) bool {
+	if key == "\x03" {
+		if v.OnInterrupt != nil {
+			v.OnInterrupt()
+		}
+		return true
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.stopped {
+		return false
+	}
+	switch key {
+	case "j", "\x1b[B":
+		if v.selected < len(v.stacks)-1 {
+			v.selected++
+		}
+	case "k", "\x1b[A":
+		if v.selected > 0 {
+			v.selected--
+		}
+	case "\r", "\n", " ":
+		v.expanded = !v.expanded
+	}
+	return true
+}
+
+// Draw draws the current frame, replacing the previous one.
+func (v *View) Draw() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.stopped {
+		return
+	}
+
+	lines := v.render(time.Now())
+	var frame strings.Builder
+	if v.lines > 0 {
+		fmt.Fprintf(&frame, "\x1b[%dA\r\x1b[J", v.lines)
+	}
+	for _, line := range lines {
+		frame.WriteString(line)
+		frame.WriteString("\r\n")
+	}
+	v.lines = len(lines)
+	_, _ = io.WriteString(v.w, frame.String())
+}
+
+// render returns the lines of the frame.
+func (v *View) render(now time.Time) []string {
+	namewidth := len("STACK")
+	for _, st := range v.stacks {
+		namewidth = max(namewidth, len(st.name))
+	}
+	statuswidth := len("canceled")
+	header := "STATUS"
+	if printer.EmojiEnabled() {
+		// the icons are rendered in two columns.
+		statuswidth += 2
+		header = "   " + header
+	}
+	row := func(marker, name, status, duration, last string, role printer.Role) string {
+		prefix := fmt.Sprintf("%s %-*s  ", marker, namewidth, name)
+		line := v.truncate(strings.TrimRight(
+			fmt.Sprintf("%s%-*s  %8s  %s", prefix, statuswidth, status, duration, last), " "))
+		// only the status is colored and only if it's not truncated.
+		if end := len(prefix) + len(status); len(line) >= end {
+			line = prefix + printer.Sprint(role, status) + line[end:]
+		}
+		return line
+	}
+
+	var counts [Canceled + 1]int
+	lines := []string{row(" ", "STACK", header, "DURATION", "LAST LINE", printer.RoleNormal)}
+	for i, st := range v.stacks {
+		counts[st.status]++
+
+		marker := " "
+		if i == v.selected {
+			marker = ">"
+		}
+		status := printer.WithIcon(st.status.icon(), st.status.String())
+		lines = append(lines, row(marker, st.name, status, st.duration(now), lastLine(st.output.String()), st.status.role()))
+
+		if i == v.selected && v.expanded {
+			output := outputLines(st.output.String())
+			if len(output) > expandedLines {
+				output = output[len(output)-expandedLines:]
+			}
+			for _, line := range output {
+				lines = append(lines, v.truncate("    | "+line))
+			}
+		}
+	}
+	lines = append(lines,
+		v.truncate(fmt.Sprintf("%d stacks: %d queued, %d running, %d success, %d failed, %d canceled",
+			len(v.stacks), counts[Queued], counts[Running], counts[Success], counts[Failed], counts[Canceled])),
+		v.truncate(help),
+	)
+	return lines
+}
+
+// WriteOutputs writes the output of the stacks with any of the given
+// statuses to w.
+func (v *View) WriteOutputs(w io.Writer, statuses ...Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, st := range v.stacks {
+		if !slices.Contains(statuses, st.status) || st.output.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "terramate: output of stack %s (%s):\n", st.name, st.status)
+		if st.omitted > 0 {
+			fmt.Fprintf(w, "(%d bytes of output omitted)\n", st.omitted)
+		}
+		for _, line := range outputLines(st.output.String()) {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+func (v *View) truncate(line string) string {
+	if v.width <= 0 {
+		return line
+	}
+	if runes := []rune(line); len(runes) >= v.width {
+		return string(runes[:v.width-1])
+	}
+	return line
+}
+
+// write appends p to the output, discarding its first lines if it exceeds
+// maxOutputSize.
+func (st *stack) write(p []byte) {
+	st.output.Write(p)
+	if st.output.Len() <= maxOutputSize {
+		return
+	}
+	out := st.output.Bytes()
+	keep := out[len(out)-maxOutputSize:]
+	if i := bytes.IndexByte(keep, '\n'); i >= 0 && i < len(keep)-1 {
+		keep = keep[i+1:]
+	}
+	st.omitted += len(out) - len(keep)
+	st.output.Next(len(out) - len(keep))
+}
+
+func (st *stack) duration(now time.Time) string {
+	switch {
+	case st.started.IsZero():
+		return ""
+	case st.finished.IsZero():
+		return now.Sub(st.started).Round(100 * time.Millisecond).String()
+	default:
+		return st.finished.Sub(st.started).Round(100 * time.Millisecond).String()
+	}
+}
+
+// outputLines returns the lines of the output as shown in a terminal: only
+// the content after the last carriage return of each line is kept, and
+// the control characters are removed.
+func outputLines(output string) []string {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+			line = line[idx+1:]
+		}
+		lines[i] = stripControl(line)
+	}
+	return lines
+}
+
+func lastLine(output string) string {
+	lines := outputLines(output)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// stripControl removes the control characters and the ANSI escape sequences
+// from line.
+func stripControl(line string) string {
+	var b strings.Builder
+	inEscape := false
+	for _, r := range line {
+		switch {
+		case inEscape:
+			// CSI sequences end with a letter.
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		case r == '\x1b':
+			inEscape = true
+		case r == '\t':
+			b.WriteString("    ")
+		case r < ' ' || r == '\x7f':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,115 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package live_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/ui/tui/live"
+)
+
+func TestViewDraw(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	view := live.New(&buf, []string{"/stacks/a", "/stacks/b", "/stacks/c"})
+
+	view.Running("/stacks/a")
+	fmt.Fprint(view.Output("/stacks/a"), "Refreshing state...\r\x1b[32mApply complete!\x1b[0m\n\n")
+	view.Done("/stacks/a", live.Success)
+	view.Running("/stacks/b")
+	fmt.Fprint(view.Output("/stacks/b"), "line 1\nline 2\n")
+
+	view.Draw()
+	frame := lines(buf.String())
+	assert.EqualInts(t, 6, len(frame))
+	assertMatch(t, frame[0], `^  STACK\s+STATUS\s+DURATION\s+LAST LINE$`)
+	assertMatch(t, frame[1], `^> /stacks/a\s+success\s+\S+\s+Apply complete!$`)
+	assertMatch(t, frame[2], `^  /stacks/b\s+running\s+\S+\s+line 2$`)
+	assertMatch(t, frame[3], `^  /stacks/c\s+queued$`)
+	assert.EqualStrings(t, "3 stacks: 1 queued, 1 running, 1 success, 0 failed, 0 canceled", frame[4])
+
+	// selecting and expanding the second stack shows its output.
+	buf.Reset()
+	view.Input(strings.NewReader("j"))
+	view.Input(strings.NewReader("\r"))
+	view.Draw()
+	frame = lines(buf.String())
+	if !strings.HasPrefix(frame[0], "\x1b[6A\r\x1b[J") {
+		t.Fatalf("frame must replace the previous one: %q", frame[0])
+	}
+	assertMatch(t, frame[2], `^> /stacks/b\s+running`)
+	assert.EqualStrings(t, "    | line 1", frame[3])
+	assert.EqualStrings(t, "    | line 2", frame[4])
+	assertMatch(t, frame[5], `^  /stacks/c\s+queued`)
+}
+
+func TestViewInterrupt(t *testing.T) {
+	t.Parallel()
+
+	view := live.New(&bytes.Buffer{}, []string{"/stack"})
+	interrupted := 0
+	view.OnInterrupt = func() { interrupted++ }
+	view.Input(strings.NewReader("\x03"))
+	assert.EqualInts(t, 1, interrupted)
+}
+
+func TestViewWriteOutputs(t *testing.T) {
+	t.Parallel()
+
+	view := live.New(&bytes.Buffer{}, []string{"/stacks/a", "/stacks/b"})
+	fmt.Fprint(view.Output("/stacks/a"), "ok\n")
+	view.Done("/stacks/a", live.Success)
+	fmt.Fprint(view.Output("/stacks/b"), "error: invalid\n")
+	view.Done("/stacks/b", live.Failed)
+
+	var buf bytes.Buffer
+	view.WriteOutputs(&buf, live.Failed)
+	assert.EqualStrings(t, "terramate: output of stack /stacks/b (failed):\nerror: invalid\n", buf.String())
+
+	buf.Reset()
+	view.WriteOutputs(&buf, live.Success, live.Failed)
+	assert.EqualStrings(t, "terramate: output of stack /stacks/a (success):\nok\n"+
+		"terramate: output of stack /stacks/b (failed):\nerror: invalid\n", buf.String())
+}
+
+func TestViewOutputIsBounded(t *testing.T) {
+	t.Parallel()
+
+	view := live.New(&bytes.Buffer{}, []string{"/stack"})
+	out := view.Output("/stack")
+	line := strings.Repeat("x", 1023) + "\n"
+	for i := 0; i < 4096; i++ {
+		fmt.Fprint(out, line)
+	}
+	fmt.Fprint(out, "last line\n")
+	view.Done("/stack", live.Success)
+
+	var buf bytes.Buffer
+	view.WriteOutputs(&buf, live.Success)
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.EqualStrings(t, "terramate: output of stack /stack (success):", got[0])
+	assertMatch(t, got[1], `^\(\d+ bytes of output omitted\)$`)
+	assert.EqualStrings(t, line[:len(line)-1], got[2], "only whole lines are kept")
+	assert.EqualStrings(t, "last line", got[len(got)-1])
+	if size := buf.Len(); size > 1<<20+1024 {
+		t.Fatalf("output must be bounded but has %d bytes", size)
+	}
+}
+
+func lines(frame string) []string {
+	return strings.Split(strings.TrimSuffix(frame, "\r\n"), "\r\n")
+}
+
+func assertMatch(t *testing.T, line, pattern string) {
+	t.Helper()
+	if !regexp.MustCompile(pattern).MatchString(line) {
+		t.Fatalf("line %q does not match %q", line, pattern)
+	}
+}
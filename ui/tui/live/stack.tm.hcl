@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package live // import \"github.com/terramate-io/terramate/ui/tui/live\""
+  description = "package live // import \"github.com/terramate-io/terramate/ui/tui/live\"\n\nPackage live implements a live view of the status of the stacks executed by the\nrun command, used instead of the interleaved output of the commands.\n\ntype Status int\n    const Queued Status = iota ...\ntype View struct{ ... }\n    func New(w io.Writer, stacks []string) *View"
+  tags        = ["golang", "live", "tui", "ui"]
+  id          = "a30b26b5-e322-413b-9f98-f00970442a0c"
+}
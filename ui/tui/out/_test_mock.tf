@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "out" {
-  content = <<-EOT
+  content = <<-EOF
 package out // import "github.com/terramate-io/terramate/cmd/terramate/cli/out"
 
 Package out provides output functionality, including verboseness level and
@@ -9,7 +9,7 @@ normal/error messages support.
 
 type O struct{ ... }
     func New(verboseness int, stdout, stderr io.Writer) O
-EOT
+EOF
 
   filename = "${path.module}/mock-out.ignore"
 }
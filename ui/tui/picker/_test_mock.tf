@@ -0,0 +1,20 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "picker" {
+  content = <<-EOF
+package picker // import "github.com/terramate-io/terramate/ui/tui/picker"
+
+Package picker implements an interactive picker of stacks, with fuzzy search and
+filters by tag and change status.
+
+const ErrCanceled errors.Kind = "stack selection canceled" ...
+func Command(args []string, passthrough int, stacks []string) string
+func Match(query string, item Item) bool
+func PickStacks(e *engine.Engine, gitfilter engine.GitFilter, ...) (config.List[*config.SortableStack], error)
+type Item struct{ ... }
+type Picker struct{ ... }
+    func New(w io.Writer, items []Item) *Picker
+EOF
+
+  filename = "${path.module}/mock-picker.ignore"
+}
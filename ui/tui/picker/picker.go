@@ -0,0 +1,345 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package picker implements an interactive picker of stacks, with fuzzy
+// search and filters by tag and change status.
+package picker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+	"golang.org/x/term"
+)
+
+const (
+	// ErrCanceled indicates the picker was canceled by the user.
+	ErrCanceled errors.Kind = "stack selection canceled"
+
+	// ErrNotTerminal indicates the picker input is not a terminal.
+	ErrNotTerminal errors.Kind = "the stack picker requires a terminal"
+)
+
+// maxVisible is the maximum number of items shown at once.
+const maxVisible = 15
+
+const help = "type to search (tag:<name>, :changed), arrows: move, tab: toggle, ctrl-a: toggle all, enter: confirm, esc: cancel"
+
+// Item is a stack which can be picked.
+type Item struct {
+	Dir     string
+	Tags    []string
+	Changed bool
+}
+
+// Picker is a searchable list of stacks where a subset of them is selected.
+type Picker struct {
+	w     io.Writer
+	items []Item
+
+	query    string
+	cursor   int
+	selected map[string]bool
+
+	done     bool
+	canceled bool
+
+	// lines is the number of lines of the last drawn frame.
+	lines int
+	// width is the terminal width. Lines are not truncated if zero.
+	width int
+}
+
+// New creates a picker of the given items, in the order they are shown,
+// drawing its frames on w.
+func New(w io.Writer, items []Item) *Picker {
+	return &Picker{
+		w:        w,
+		items:    items,
+		selected: map[string]bool{},
+	}
+}
+
+// Run shows the picker, reading the keys from in until the selection is
+// confirmed, and returns the directories of the selected stacks.
+// The in file must be a terminal.
+func (p *Picker) Run(in *os.File) ([]string, error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.E(ErrNotTerminal)
+	}
+	if out, ok := p.w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(out.Fd())); err == nil {
+			p.width = width
+		}
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, errors.E(err, "setting up the terminal")
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	p.Draw()
+	p.Input(in)
+	return p.Selected()
+}
+
+// Input applies the keys read from r, drawing a new frame after each of
+// them, until the selection is confirmed or canceled or r is closed.
+func (p *Picker) Input(r io.Reader) {
+	buf := make([]byte, 16)
+	for !p.done {
+		n, err := r.Read(buf)
+		if n > 0 {
+			p.key(string(buf[:n]))
+			p.Draw()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Selected returns the directories of the selected stacks, in the order of
+// the items. If no stack was toggled, the stack under the cursor is selected.
+func (p *Picker) Selected() ([]string, error) {
+	if p.canceled || !p.done {
+		return nil, errors.E(ErrCanceled)
+	}
+	var dirs []string
+	for _, item := range p.items {
+		if p.selected[item.Dir] {
+			dirs = append(dirs, item.Dir)
+		}
+	}
+	if len(dirs) == 0 {
+		if visible := p.visible(); len(visible) > 0 {
+			dirs = append(dirs, visible[p.cursor].Dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, errors.E(ErrCanceled, "no stack selected")
+	}
+	return dirs, nil
+}
+
+// key applies the key binding.
+func (p *Picker) key(key string) {
+	switch key {
+	case "\x03", "\x1b":
+		p.canceled = true
+		p.done = true
+	case "\r", "\n":
+		p.done = true
+	case "\x1b[A", "\x10":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "\x1b[B", "\x0e":
+		if p.cursor < len(p.visible())-1 {
+			p.cursor++
+		}
+	case "\t":
+		if visible := p.visible(); len(visible) > 0 {
+			dir := visible[p.cursor].Dir
+			p.selected[dir] = !p.selected[dir]
+		}
+	case "\x01":
+		visible := p.visible()
+		all := true
+		for _, item := range visible {
+			all = all && p.selected[item.Dir]
+		}
+		for _, item := range visible {
+			p.selected[item.Dir] = !all
+		}
+	case "\x7f", "\b":
+		if runes := []rune(p.query); len(runes) > 0 {
+			p.setQuery(string(runes[:len(runes)-1]))
+		}
+	default:
+		if strings.IndexFunc(key, unicode.IsControl) == -1 {
+			p.setQuery(p.query + key)
+		}
+	}
+}
+
+func (p *Picker) setQuery(query string) {
+	p.query = query
+	p.cursor = 0
+}
+
+// visible returns the items matching the query.
+func (p *Picker) visible() []Item {
+	var items []Item
+	for _, item := range p.items {
+		if Match(p.query, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Draw draws the current frame, replacing the previous one.
+func (p *Picker) Draw() {
+	lines := p.render()
+	var frame strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&frame, "\x1b[%dA\r\x1b[J", p.lines)
+	}
+	for _, line := range lines {
+		frame.WriteString(line)
+		frame.WriteString("\r\n")
+	}
+	p.lines = len(lines)
+	_, _ = io.WriteString(p.w, frame.String())
+}
+
+// render returns the lines of the frame.
+func (p *Picker) render() []string {
+	lines := []string{p.truncate("> " + p.query)}
+	visible := p.visible()
+
+	// the window of visible items follows the cursor.
+	start := 0
+	if p.cursor >= maxVisible {
+		start = p.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(visible))
+	for i := start; i < end; i++ {
+		item := visible[i]
+		marker := " "
+		if i == p.cursor {
+			marker = ">"
+		}
+		check := "[ ]"
+		if p.selected[item.Dir] {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %s %s", marker, check, item.Dir)
+		var attrs []string
+		if item.Changed {
+			attrs = append(attrs, "changed")
+		}
+		for _, tag := range item.Tags {
+			attrs = append(attrs, "#"+tag)
+		}
+		line = p.truncate(line + "  " + strings.Join(attrs, " "))
+		line = strings.TrimRight(line, " ")
+		if i == p.cursor {
+			line = printer.Sprint(printer.RoleHighlight, line)
+		}
+		lines = append(lines, line)
+	}
+
+	selected := 0
+	for _, item := range p.items {
+		if p.selected[item.Dir] {
+			selected++
+		}
+	}
+	lines = append(lines,
+		p.truncate(fmt.Sprintf("%d/%d stacks, %d selected", len(visible), len(p.items), selected)),
+		p.truncate(help),
+	)
+	return lines
+}
+
+func (p *Picker) truncate(line string) string {
+	if p.width <= 0 {
+		return line
+	}
+	if runes := []rune(line); len(runes) >= p.width {
+		return string(runes[:p.width-1])
+	}
+	return line
+}
+
+// Match tells if the item matches all the terms of the query. The terms
+// `tag:<name>` and `:changed` filter the stacks by tag and change status and
+// the other terms are fuzzy matched against the stack directory: their
+// characters must appear in the directory in the same order.
+func Match(query string, item Item) bool {
+	for _, word := range strings.Fields(query) {
+		switch {
+		case word == ":changed":
+			if !item.Changed {
+				return false
+			}
+		case strings.HasPrefix(word, "tag:"):
+			found := false
+			for _, tag := range item.Tags {
+				found = found || tag == word[len("tag:"):]
+			}
+			if !found {
+				return false
+			}
+		default:
+			if !fuzzyMatch(strings.ToLower(word), strings.ToLower(item.Dir)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func fuzzyMatch(pattern, s string) bool {
+	for _, r := range pattern {
+		idx := strings.IndexRune(s, r)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(string(r)):]
+	}
+	return true
+}
+
+// safeArg matches the arguments which don't need quoting in a shell.
+var safeArg = regexp.MustCompile(`^[A-Za-z0-9_/.,:=@%+-]+$`)
+
+// Command returns the command line equivalent to args, which selected the
+// stacks with the picker flag, selecting the given stacks with the --stack
+// flag instead. The last passthrough arguments of args are the command
+// executed in the stacks, which must stay at the end of the command line.
+func Command(args []string, passthrough int, stacks []string) string {
+	passthrough = min(passthrough, len(args))
+	flags := args[:len(args)-passthrough]
+	cmd := args[len(args)-passthrough:]
+
+	var sep []string
+	if len(flags) > 0 && flags[len(flags)-1] == "--" {
+		flags, sep = flags[:len(flags)-1], []string{"--"}
+	}
+
+	line := []string{"terramate"}
+	for i := 0; i < len(flags); i++ {
+		arg := flags[i]
+		switch {
+		case arg == "--pick" || strings.HasPrefix(arg, "--pick="),
+			strings.HasPrefix(arg, "--stack="):
+		case arg == "--stack":
+			// the previous selection is replaced by the picked stacks.
+			i++
+		default:
+			line = append(line, arg)
+		}
+	}
+	for _, stack := range stacks {
+		line = append(line, "--stack="+stack)
+	}
+	line = append(line, sep...)
+	line = append(line, cmd...)
+
+	for i, arg := range line {
+		if !safeArg.MatchString(arg) {
+			line[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(line, " ")
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package picker_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/ui/tui/picker"
+)
+
+var items = []picker.Item{
+	{Dir: "/stacks/dev/network", Tags: []string{"dev", "network"}},
+	{Dir: "/stacks/dev/app", Tags: []string{"dev"}, Changed: true},
+	{Dir: "/stacks/prod/network", Tags: []string{"prod", "network"}, Changed: true},
+	{Dir: "/stacks/prod/app", Tags: []string{"prod"}},
+}
+
+func TestPickerMatch(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		query string
+		want  []string
+	}{
+		{
+			query: "",
+			want:  []string{"/stacks/dev/network", "/stacks/dev/app", "/stacks/prod/network", "/stacks/prod/app"},
+		},
+		{
+			query: "prdnet",
+			want:  []string{"/stacks/prod/network"},
+		},
+		{
+			query: "APP",
+			want:  []string{"/stacks/dev/app", "/stacks/prod/app"},
+		},
+		{
+			query: "tag:network",
+			want:  []string{"/stacks/dev/network", "/stacks/prod/network"},
+		},
+		{
+			query: ":changed tag:dev",
+			want:  []string{"/stacks/dev/app"},
+		},
+		{
+			query: "tag:net",
+		},
+	} {
+		var got []string
+		for _, item := range items {
+			if picker.Match(tc.query, item) {
+				got = append(got, item.Dir)
+			}
+		}
+		assert.EqualInts(t, len(tc.want), len(got), "query %q: %v", tc.query, got)
+		for i := range tc.want {
+			assert.EqualStrings(t, tc.want[i], got[i], "query %q", tc.query)
+		}
+	}
+}
+
+func TestPickerSelection(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		keys  string
+		want  []string
+		error error
+	}{
+		{
+			name: "cursor stack is picked if none toggled",
+			keys: "\x1b[B\r",
+			want: []string{"/stacks/dev/app"},
+		},
+		{
+			name: "toggled stacks in order",
+			keys: "\x1b[B\x1b[B\t\x1b[A\x1b[A\t\r",
+			want: []string{"/stacks/dev/network", "/stacks/prod/network"},
+		},
+		{
+			name: "toggle all matching the query",
+			keys: "prod\x01\r",
+			want: []string{"/stacks/prod/network", "/stacks/prod/app"},
+		},
+		{
+			name: "backspace edits the query",
+			keys: "prodx\x7f\x01\r",
+			want: []string{"/stacks/prod/network", "/stacks/prod/app"},
+		},
+		{
+			name:  "escape cancels",
+			keys:  "\t\x1b",
+			error: errors.E(picker.ErrCanceled),
+		},
+		{
+			name:  "no match",
+			keys:  "nothing\r",
+			error: errors.E(picker.ErrCanceled),
+		},
+		{
+			name:  "input closed",
+			keys:  "\t",
+			error: errors.E(picker.ErrCanceled),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := picker.New(&bytes.Buffer{}, items)
+			for _, key := range splitKeys(tc.keys) {
+				p.Input(strings.NewReader(key))
+			}
+			got, err := p.Selected()
+			if tc.error != nil {
+				assert.IsError(t, err, tc.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualInts(t, len(tc.want), len(got), "%v", got)
+			for i := range tc.want {
+				assert.EqualStrings(t, tc.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestPickerDraw(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := picker.New(&buf, items)
+	p.Input(strings.NewReader("tag:prod"))
+	p.Input(strings.NewReader("\t"))
+
+	frames := strings.Split(buf.String(), "\x1b[5A\r\x1b[J")
+	frame := strings.Split(strings.TrimSuffix(frames[len(frames)-1], "\r\n"), "\r\n")
+	assert.EqualInts(t, 5, len(frame), "%q", frame)
+	assert.EqualStrings(t, "> tag:prod", frame[0])
+	assert.EqualStrings(t, "> [x] /stacks/prod/network  changed #prod #network", frame[1])
+	assert.EqualStrings(t, "  [ ] /stacks/prod/app  #prod", frame[2])
+	assert.EqualStrings(t, "2/4 stacks, 1 selected", frame[3])
+}
+
+func TestPickerCommand(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		args        []string
+		passthrough int
+		want        string
+	}{
+		{
+			name: "list",
+			args: []string{"list", "--pick", "--changed"},
+			want: "terramate list --changed --stack=/a --stack=/b",
+		},
+		{
+			name:        "run with separator",
+			args:        []string{"-C", "dir", "run", "--pick", "--", "echo", "hello world"},
+			passthrough: 2,
+			want:        "terramate -C dir run --stack=/a --stack=/b -- echo 'hello world'",
+		},
+		{
+			name:        "run replacing previous selection",
+			args:        []string{"run", "--stack", "/c", "--stack=/d", "--pick=true", "ls"},
+			passthrough: 1,
+			want:        "terramate run --stack=/a --stack=/b ls",
+		},
+	} {
+		got := picker.Command(tc.args, tc.passthrough, []string{"/a", "/b"})
+		assert.EqualStrings(t, tc.want, got, tc.name)
+	}
+}
+
+// splitKeys splits the input in the keys read by the terminal.
+func splitKeys(input string) []string {
+	var keys []string
+	for len(input) > 0 {
+		n := 1
+		if strings.HasPrefix(input, "\x1b[") {
+			n = 3
+		}
+		keys = append(keys, input[:n])
+		input = input[n:]
+	}
+	return keys
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package picker // import \"github.com/terramate-io/terramate/ui/tui/picker\""
+  description = "package picker // import \"github.com/terramate-io/terramate/ui/tui/picker\"\n\nPackage picker implements an interactive picker of stacks, with fuzzy search and\nfilters by tag and change status.\n\nconst ErrCanceled errors.Kind = \"stack selection canceled\" ...\nfunc Command(args []string, passthrough int, stacks []string) string\nfunc Match(query string, item Item) bool\nfunc PickStacks(e *engine.Engine, gitfilter engine.GitFilter, ...) (config.List[*config.SortableStack], error)\ntype Item struct{ ... }\ntype Picker struct{ ... }\n    func New(w io.Writer, items []Item) *Picker"
+  tags        = ["golang", "picker", "tui", "ui"]
+  id          = "af04599d-48d6-47ae-915b-2574332d7d49"
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package picker
+
+import (
+	"io"
+	"os"
+
+	"github.com/terramate-io/terramate/cloud/api/resources"
+	cloudstack "github.com/terramate-io/terramate/cloud/api/stack"
+	"github.com/terramate-io/terramate/config"
+	"github.com/terramate-io/terramate/engine"
+)
+
+// PickStacks shows the picker of the given stacks on out, reading the keys
+// from in, and returns the picked stacks. The stacks changed in the git
+// history of gitfilter are marked as changed, if change detection is
+// available.
+func PickStacks(
+	e *engine.Engine,
+	gitfilter engine.GitFilter,
+	stacks config.List[*config.SortableStack],
+	in *os.File,
+	out io.Writer,
+) (config.List[*config.SortableStack], error) {
+	changed := changedStacks(e, gitfilter)
+	items := make([]Item, len(stacks))
+	for i, st := range stacks {
+		items[i] = Item{
+			Dir:     st.Dir().String(),
+			Tags:    st.Tags,
+			Changed: st.IsChanged || changed[st.Dir().String()],
+		}
+	}
+	dirs, err := New(out, items).Run(in)
+	if err != nil {
+		return nil, err
+	}
+	return e.SelectStackDirs(stacks, dirs)
+}
+
+// changedStacks returns the changed stacks, ignoring errors of the change
+// detection as the changes are only informative.
+func changedStacks(e *engine.Engine, gitfilter engine.GitFilter) map[string]bool {
+	changed := map[string]bool{}
+	if !e.Project().IsRepo() {
+		return changed
+	}
+	gitfilter.IsChanged = true
+	report, err := e.ListStacks(gitfilter, cloudstack.AnyTarget, resources.NoStatusFilters(), false)
+	if err != nil {
+		return changed
+	}
+	for _, entry := range report.Stacks {
+		changed[entry.Stack.Dir.String()] = true
+	}
+	return changed
+}
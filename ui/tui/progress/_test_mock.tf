@@ -0,0 +1,20 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "progress" {
+  content = <<-EOF
+package progress // import "github.com/terramate-io/terramate/ui/tui/progress"
+
+Package progress implements the machine readable progress output of the long
+running commands, streamed as the events happen so CI wrappers and other user
+interfaces can show the progress of the command.
+
+const FormatNone = "none" ...
+const ErrInvalidFormat errors.Kind = "invalid progress format"
+type Counter struct{ ... }
+type Event struct{ ... }
+type Reporter struct{ ... }
+    func New(format string, w io.Writer) (*Reporter, error)
+EOF
+
+  filename = "${path.module}/mock-progress.ignore"
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package progress implements the machine readable progress output of the
+// long running commands, streamed as the events happen so CI wrappers and
+// other user interfaces can show the progress of the command.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate/errors"
+)
+
+// ErrInvalidFormat indicates that the progress format is not supported.
+const ErrInvalidFormat errors.Kind = "invalid progress format"
+
+// Supported progress formats.
+const (
+	FormatNone   = "none"
+	FormatNDJSON = "ndjson"
+)
+
+// Event is a progress event.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Phase   string    `json:"phase"`
+	Stack   string    `json:"stack,omitempty"`
+	Percent int       `json:"percent"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Reporter writes the progress events as they happen.
+// A nil Reporter discards all the events, so commands can report progress
+// unconditionally. It is safe to use from multiple goroutines.
+type Reporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// New creates a reporter writing the events to w in the given format.
+// It returns a nil reporter for the [FormatNone] format.
+func New(format string, w io.Writer) (*Reporter, error) {
+	switch format {
+	case "", FormatNone:
+		return nil, nil
+	case FormatNDJSON:
+		return &Reporter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, errors.E(ErrInvalidFormat, "%q", format)
+	}
+}
+
+// Send writes the event. The event time is set if not already.
+// Failures writing the event are ignored, the progress is informative only.
+func (r *Reporter) Send(ev Event) {
+	if r == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.enc.Encode(ev)
+}
+
+// Counter tracks the progress of a phase processing a known number of items.
+type Counter struct {
+	r     *Reporter
+	phase string
+
+	mu    sync.Mutex
+	done  int
+	total int
+}
+
+// Phase starts a new phase of total items, sending its initial event with
+// the given message.
+func (r *Reporter) Phase(phase string, total int, message string) *Counter {
+	r.Send(Event{Phase: phase, Message: message})
+	return &Counter{r: r, phase: phase, total: total}
+}
+
+// Start sends an event for the item of the stack being started, without
+// changing the progress.
+func (c *Counter) Start(stack, message string) {
+	c.mu.Lock()
+	percent := Percent(c.done, c.total)
+	c.mu.Unlock()
+	c.r.Send(Event{Phase: c.phase, Stack: stack, Percent: percent, Message: message})
+}
+
+// Done sends an event for the item of the stack being finished, updating the
+// progress.
+func (c *Counter) Done(stack, message string) {
+	c.mu.Lock()
+	c.done++
+	percent := Percent(c.done, c.total)
+	c.mu.Unlock()
+	c.r.Send(Event{Phase: c.phase, Stack: stack, Percent: percent, Message: message})
+}
+
+// Finish sends the final event of the phase.
+func (c *Counter) Finish(message string) {
+	c.r.Send(Event{Phase: c.phase, Percent: 100, Message: message})
+}
+
+// Percent returns the percentage of the done items from the total.
+func Percent(done, total int) int {
+	if total <= 0 || done >= total {
+		return 100
+	}
+	return done * 100 / total
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package progress_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/madlambda/spells/assert"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/ui/tui/progress"
+)
+
+func TestProgressNDJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r, err := progress.New(progress.FormatNDJSON, &buf)
+	assert.NoError(t, err)
+
+	c := r.Phase("run", 4, "running 4 stacks")
+	c.Start("/a", "running")
+	c.Done("/a", "succeeded")
+	c.Done("/b", "failed")
+	c.Finish("finished")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.EqualInts(t, 5, len(lines))
+
+	var events []progress.Event
+	for _, line := range lines {
+		var ev progress.Event
+		assert.NoError(t, json.Unmarshal([]byte(line), &ev))
+		assert.IsTrue(t, !ev.Time.IsZero(), "event without time: %s", line)
+		events = append(events, ev)
+	}
+
+	want := []progress.Event{
+		{Phase: "run", Percent: 0, Message: "running 4 stacks"},
+		{Phase: "run", Stack: "/a", Percent: 0, Message: "running"},
+		{Phase: "run", Stack: "/a", Percent: 25, Message: "succeeded"},
+		{Phase: "run", Stack: "/b", Percent: 50, Message: "failed"},
+		{Phase: "run", Percent: 100, Message: "finished"},
+	}
+	for i, ev := range events {
+		assert.EqualStrings(t, want[i].Phase, ev.Phase)
+		assert.EqualStrings(t, want[i].Stack, ev.Stack)
+		assert.EqualInts(t, want[i].Percent, ev.Percent)
+		assert.EqualStrings(t, want[i].Message, ev.Message)
+	}
+}
+
+func TestProgressNone(t *testing.T) {
+	t.Parallel()
+
+	r, err := progress.New(progress.FormatNone, nil)
+	assert.NoError(t, err)
+	assert.IsTrue(t, r == nil)
+
+	// a nil reporter discards the events.
+	c := r.Phase("generate", 1, "")
+	c.Done("/a", "saved")
+	c.Finish("finished")
+
+	_, err = progress.New("xml", nil)
+	assert.IsTrue(t, errors.IsKind(err, progress.ErrInvalidFormat))
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package progress // import \"github.com/terramate-io/terramate/ui/tui/progress\""
+  description = "package progress // import \"github.com/terramate-io/terramate/ui/tui/progress\"\n\nPackage progress implements the machine readable progress output of the long\nrunning commands, streamed as the events happen so CI wrappers and other user\ninterfaces can show the progress of the command.\n\nconst FormatNone = \"none\" ...\nconst ErrInvalidFormat errors.Kind = \"invalid progress format\"\ntype Counter struct{ ... }\ntype Event struct{ ... }\ntype Reporter struct{ ... }\n    func New(format string, w io.Writer) (*Reporter, error)"
+  tags        = ["golang", "progress", "tui", "ui"]
+  id          = "89b5a9d5-ef76-485f-b0f7-cf8d014e8b92"
+}
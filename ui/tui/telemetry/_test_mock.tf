@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "telemetry" {
-  content = <<-EOT
+  content = <<-EOF
 package telemetry // import "github.com/terramate-io/terramate/cmd/terramate/cli/telemetry"
 
 var DefaultRecord = NewRecord()
@@ -25,7 +25,7 @@ type PlatformType int
 type Record struct{ ... }
     func NewRecord() *Record
 type SendMessageParams struct{ ... }
-EOT
+EOF
 
   filename = "${path.module}/mock-telemetry.ignore"
 }
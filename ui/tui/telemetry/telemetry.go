@@ -38,6 +38,8 @@ const (
 	AuthOIDCGitlab
 	// AuthAPIKey represents API key authentication.
 	AuthAPIKey
+	// AuthOIDCCI represents OIDC authentication from other CI providers.
+	AuthOIDCCI
 )
 
 // Message is the analytics data that will be collected.
@@ -70,6 +72,8 @@ func DetectAuthTypeFromEnv(credpath string) AuthType {
 		return AuthOIDCGithub
 	} else if isEnvVarSet("TM_GITLAB_ID_TOKEN") {
 		return AuthOIDCGitlab
+	} else if isEnvVarSet("TM_CLOUD_OIDC_PROVIDER") || isEnvVarSet("TM_CLOUD_OIDC_TOKEN") || isEnvVarSet("TM_CLOUD_OIDC_TOKEN_FILE") {
+		return AuthOIDCCI
 	}
 	return getAuthProviderFromCredentials(credpath)
 }
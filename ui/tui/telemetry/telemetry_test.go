@@ -20,6 +20,9 @@ func TestDetectAuthTypeFromEnv(t *testing.T) {
 	tests := map[string]AuthType{
 		"ACTIONS_ID_TOKEN_REQUEST_TOKEN": AuthOIDCGithub,
 		"TM_GITLAB_ID_TOKEN":             AuthOIDCGitlab,
+		"TM_CLOUD_OIDC_PROVIDER":         AuthOIDCCI,
+		"TM_CLOUD_OIDC_TOKEN":            AuthOIDCCI,
+		"TM_CLOUD_OIDC_TOKEN_FILE":       AuthOIDCCI,
 	}
 
 	for k, want := range tests {
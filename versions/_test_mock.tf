@@ -1,7 +1,7 @@
 // TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
 
 resource "local_file" "versions" {
-  content = <<-EOT
+  content = <<-EOF
 package versions // import "github.com/terramate-io/terramate/versions"
 
 Package versions provide helper functions for version constraint matching.
@@ -9,7 +9,7 @@ Package versions provide helper functions for version constraint matching.
 const ErrCheck errors.Kind = "version check error"
 func Check(version string, constraint string, allowPrereleases bool) error
 func Match(version, constraint string, allowPrereleases bool) (bool, error)
-EOT
+EOF
 
   filename = "${path.module}/mock-versions.ignore"
 }
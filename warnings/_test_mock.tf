@@ -0,0 +1,24 @@
+// TERRAMATE: GENERATED AUTOMATICALLY DO NOT EDIT
+
+resource "local_file" "warnings" {
+  content = <<-EOF
+package warnings // import "github.com/terramate-io/terramate/warnings"
+
+Package warnings implements the collection of the warnings emitted by Terramate,
+which can be promoted to errors by the project configuration and reported as
+JSON.
+
+const ErrPromoted errors.Kind = "warning promoted to error"
+var Default = &Collector{}
+func Emit(code Code, msg string, details error)
+func Log(code Code, msg string, rng fmt.Stringer, details error)
+func Record(w Warning)
+type Code string
+    const All Code = "all" ...
+    func Codes() []Code
+type Collector struct{ ... }
+type Warning struct{ ... }
+EOF
+
+  filename = "${path.module}/mock-warnings.ignore"
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+stack {
+  name        = "package warnings // import \"github.com/terramate-io/terramate/warnings\""
+  description = "package warnings // import \"github.com/terramate-io/terramate/warnings\"\n\nPackage warnings implements the collection of the warnings emitted by Terramate,\nwhich can be promoted to errors by the project configuration and reported as\nJSON.\n\nconst ErrPromoted errors.Kind = \"warning promoted to error\"\nvar Default = &Collector{}\nfunc Emit(code Code, msg string, details error)\nfunc Log(code Code, msg string, rng fmt.Stringer, details error)\nfunc Record(w Warning)\ntype Code string\n    const All Code = \"all\" ...\n    func Codes() []Code\ntype Collector struct{ ... }\ntype Warning struct{ ... }"
+  tags        = ["golang", "warnings"]
+  id          = "16d7af2e-3f9d-4af4-9cf2-010d6a8699e3"
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+// Package warnings implements the collection of the warnings emitted by
+// Terramate, which can be promoted to errors by the project configuration and
+// reported as JSON.
+package warnings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/printer"
+)
+
+// Code identifies a kind of warning.
+type Code string
+
+// Available warning codes.
+const (
+	// All matches all the warning codes.
+	All Code = "all"
+
+	// DeprecatedVariable is a reference to a deprecated variable.
+	DeprecatedVariable Code = "deprecated-variable"
+
+	// ConfigSchema is a configuration schema issue which is only an error
+	// in strict mode.
+	ConfigSchema Code = "config-schema"
+
+	// Experiment is an issue in the terramate.config.experiments attribute.
+	Experiment Code = "experiment"
+
+	// DuplicatedStackID is a stack ID shared by multiple stacks.
+	DuplicatedStackID Code = "duplicated-stack-id"
+
+	// IgnoredSymlink is a symlink ignored by the config loading.
+	IgnoredSymlink Code = "ignored-symlink"
+
+	// TmgenDisabled is a .tmgen file ignored as the experiment is disabled.
+	TmgenDisabled Code = "tmgen-disabled"
+
+	// TruncatedField is a script field truncated to its maximum length.
+	TruncatedField Code = "truncated-field"
+
+	// InvalidStackPath is a stack attribute referencing an invalid path.
+	InvalidStackPath Code = "invalid-stack-path"
+
+	// StackSelection is an issue in the wants/wanted_by stack attributes.
+	StackSelection Code = "stack-selection"
+
+	// SkippedStack is a stack skipped by a command, the message tells the
+	// reason.
+	SkippedStack Code = "skipped-stack"
+
+	// OutsideProject is a reference to a path outside of the project,
+	// which is ignored.
+	OutsideProject Code = "outside-project"
+
+	// SkippedSubtree is a stack nested inside a directory skipped by a skip
+	// file, which is ignored together with the directory.
+	SkippedSubtree Code = "skipped-subtree"
+
+	// RootConfigLocation is a root configuration found outside of the
+	// project root directory, which is ignored.
+	RootConfigLocation Code = "root-config-location"
+
+	// UnsupportedTrigger is a trigger file of an unsupported type.
+	UnsupportedTrigger Code = "unsupported-trigger"
+
+	// BaseRefFetch is an issue fetching the missing base ref of the change
+	// detection.
+	BaseRefFetch Code = "base-ref-fetch"
+
+	// StackLock is a failure releasing the lock of a stack.
+	StackLock Code = "stack-lock"
+
+	// OutputsSharing is a failure of a sharing_backend command whose
+	// outputs are mocked.
+	OutputsSharing Code = "outputs-sharing"
+
+	// OutputsCache is a failure loading or saving the outputs cache.
+	OutputsCache Code = "outputs-cache"
+
+	// PlanCheck is a failure checking if a plan has changes.
+	PlanCheck Code = "plan-check"
+)
+
+// ErrPromoted indicates a warning promoted to error.
+const ErrPromoted errors.Kind = "warning promoted to error"
+
+// Warning is an emitted warning.
+type Warning struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+
+	// Details about the warning, if any.
+	Details string `json:"details,omitempty"`
+
+	// Range of the configuration causing the warning, if any.
+	Range string `json:"range,omitempty"`
+}
+
+// Collector collects the emitted warnings. It's safe for concurrent use.
+type Collector struct {
+	mu       sync.Mutex
+	warnings []Warning
+	asErrors []Code
+}
+
+// Default is the collector used by the package level functions. It's shared
+// by the whole process, so it must be reset with [Reset] at the start of each
+// command.
+var Default = &Collector{}
+
+// Codes returns all the valid warning codes, except for [All].
+func Codes() []Code {
+	return []Code{
+		DeprecatedVariable,
+		ConfigSchema,
+		Experiment,
+		DuplicatedStackID,
+		IgnoredSymlink,
+		TmgenDisabled,
+		TruncatedField,
+		InvalidStackPath,
+		StackSelection,
+		SkippedStack,
+		OutsideProject,
+		SkippedSubtree,
+		RootConfigLocation,
+		UnsupportedTrigger,
+		BaseRefFetch,
+		StackLock,
+		OutputsSharing,
+		OutputsCache,
+		PlanCheck,
+	}
+}
+
+// IsValid tells if the code is valid.
+func (code Code) IsValid() bool {
+	if code == All {
+		return true
+	}
+	for _, c := range Codes() {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAsErrors sets the codes of the warnings promoted to errors when they
+// are emitted.
+func SetAsErrors(codes []Code) { Default.SetAsErrors(codes) }
+
+// Emit records a warning with the given code and prints it to stderr.
+// The details are optional.
+// If the code is promoted to error then the warning is not printed and the
+// error is returned, so the caller can abort.
+func Emit(code Code, msg string, details error) error { return Default.Emit(code, msg, details) }
+
+// Log records a warning with the given code and logs it.
+// The range and details are optional.
+// If the code is promoted to error then the warning is not logged and the
+// error is returned, so the caller can abort.
+func Log(code Code, msg string, rng fmt.Stringer, details error) error {
+	return Default.Log(code, msg, rng, details)
+}
+
+// Record records a warning which is reported by the caller.
+func Record(w Warning) { Default.record(w) }
+
+// Reset discards the warnings collected by the package level functions and
+// the codes promoted to errors.
+func Reset() { Default.Reset() }
+
+// SetAsErrors sets the codes of the warnings promoted to errors when they
+// are emitted.
+func (c *Collector) SetAsErrors(codes []Code) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.asErrors = append([]Code{}, codes...)
+}
+
+// Emit records a warning with the given code and prints it to stderr.
+// The details are optional.
+// If the code is promoted to error then the warning is not printed and the
+// error is returned, so the caller can abort.
+func (c *Collector) Emit(code Code, msg string, details error) error {
+	w := Warning{Code: code, Message: msg, Details: errString(details)}
+	c.record(w)
+	if c.isPromoted(code) {
+		return promotedError(w, details)
+	}
+	if details != nil {
+		printer.Stderr.WarnWithDetails(msg, details)
+	} else {
+		printer.Stderr.Warn(msg)
+	}
+	return nil
+}
+
+// Log records a warning with the given code and logs it.
+// The range and details are optional.
+// If the code is promoted to error then the warning is not logged and the
+// error is returned, so the caller can abort.
+func (c *Collector) Log(code Code, msg string, rng fmt.Stringer, details error) error {
+	w := Warning{Code: code, Message: msg, Details: errString(details)}
+	if rng != nil {
+		w.Range = rng.String()
+	}
+	c.record(w)
+	if c.isPromoted(code) {
+		return promotedError(w, details)
+	}
+
+	event := log.Warn().Str("code", string(code))
+	if details != nil {
+		event = event.Err(details)
+	}
+	if w.Range != "" {
+		event = event.Str("range", w.Range)
+	}
+	event.Msg(msg)
+	return nil
+}
+
+// Reset discards the collected warnings and the codes promoted to errors.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = nil
+	c.asErrors = nil
+}
+
+// Warnings returns the collected warnings in the emitted order.
+func (c *Collector) Warnings() []Warning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Warning{}, c.warnings...)
+}
+
+// Promoted returns an error with all the collected warnings whose code is
+// in the given list of codes, or nil if there's none.
+func (c *Collector) Promoted(codes []Code) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	errs := errors.L()
+	for _, w := range c.Warnings() {
+		if matches(codes, w.Code) {
+			errs.Append(promotedError(w, nil))
+		}
+	}
+	return errs.AsError()
+}
+
+// WriteJSON writes the collected warnings as JSON to the given file.
+func (c *Collector) WriteJSON(filename string) error {
+	data, err := json.MarshalIndent(struct {
+		Warnings []Warning `json:"warnings"`
+	}{Warnings: c.Warnings()}, "", "  ")
+	if err != nil {
+		return errors.E(err, "encoding warnings")
+	}
+	if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+		return errors.E(err, "writing warnings to %s", filename)
+	}
+	return nil
+}
+
+func (c *Collector) record(w Warning) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, w)
+}
+
+func (c *Collector) isPromoted(code Code) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return matches(c.asErrors, code)
+}
+
+func matches(codes []Code, code Code) bool {
+	for _, c := range codes {
+		if c == All || c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func promotedError(w Warning, details error) error {
+	msg := w.Message
+	if w.Range != "" {
+		msg = w.Range + ": " + msg
+	}
+	if details != nil {
+		return errors.E(ErrPromoted, details, "%s: %s", w.Code, msg)
+	}
+	return errors.E(ErrPromoted, "%s: %s", w.Code, msg)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Terramate GmbH
+// SPDX-License-Identifier: MPL-2.0
+
+package warnings_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hhcl "github.com/terramate-io/hcl/v2"
+	"github.com/terramate-io/terramate/errors"
+	"github.com/terramate-io/terramate/warnings"
+)
+
+func TestCollectorPromoted(t *testing.T) {
+	t.Parallel()
+
+	c := &warnings.Collector{}
+	c.Log(warnings.DeprecatedVariable, "deprecated variable referenced", hhcl.Range{
+		Filename: "file.tm",
+		Start:    hhcl.Pos{Line: 1, Column: 1},
+		End:      hhcl.Pos{Line: 1, Column: 5},
+	}, errors.E("global.old: use global.new"))
+	c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, nil)
+
+	if err := c.Promoted(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Promoted([]warnings.Code{warnings.SkippedStack}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := c.Promoted([]warnings.Code{warnings.DeprecatedVariable})
+	if !errors.IsKind(err, warnings.ErrPromoted) {
+		t.Fatalf("want %s error but got: %v", warnings.ErrPromoted, err)
+	}
+	if got := len(errors.L(err).Errors()); got != 1 {
+		t.Fatalf("want 1 promoted warning but got %d: %v", got, err)
+	}
+
+	err = c.Promoted([]warnings.Code{warnings.All})
+	if got := len(errors.L(err).Errors()); got != 2 {
+		t.Fatalf("want 2 promoted warnings but got %d: %v", got, err)
+	}
+}
+
+func TestCollectorPromotedOnEmit(t *testing.T) {
+	t.Parallel()
+
+	c := &warnings.Collector{}
+	c.SetAsErrors([]warnings.Code{warnings.SkippedStack})
+
+	if err := c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := c.Emit(warnings.SkippedStack, "skipping stack /stack", errors.E("details"))
+	if !errors.IsKind(err, warnings.ErrPromoted) {
+		t.Fatalf("want %s error but got: %v", warnings.ErrPromoted, err)
+	}
+	if got := len(c.Warnings()); got != 2 {
+		t.Fatalf("want 2 recorded warnings but got %d", got)
+	}
+
+	c.SetAsErrors([]warnings.Code{warnings.All})
+	err = c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, nil)
+	if !errors.IsKind(err, warnings.ErrPromoted) {
+		t.Fatalf("want %s error but got: %v", warnings.ErrPromoted, err)
+	}
+}
+
+func TestCollectorReset(t *testing.T) {
+	t.Parallel()
+
+	c := &warnings.Collector{}
+	c.SetAsErrors([]warnings.Code{warnings.All})
+	if err := c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, nil); err == nil {
+		t.Fatal("want promoted warning")
+	}
+
+	c.Reset()
+	if got := len(c.Warnings()); got != 0 {
+		t.Fatalf("want no warnings after reset but got %d", got)
+	}
+	if err := c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, nil); err != nil {
+		t.Fatalf("unexpected error after reset: %v", err)
+	}
+	if got := len(c.Warnings()); got != 1 {
+		t.Fatalf("want 1 recorded warning but got %d", got)
+	}
+}
+
+func TestCollectorWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	c := &warnings.Collector{}
+	c.Log(warnings.ConfigSchema, "ignoring configuration issue", nil, errors.E("unrecognized attribute"))
+	c.Log(warnings.SkippedStack, "skipping stack /stack without an ID", nil, nil)
+
+	filename := filepath.Join(t.TempDir(), "warnings.json")
+	if err := c.WriteJSON(filename); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Warnings []warnings.Warning `json:"warnings"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []warnings.Warning{
+		{
+			Code:    warnings.ConfigSchema,
+			Message: "ignoring configuration issue",
+			Details: "unrecognized attribute",
+		},
+		{
+			Code:    warnings.SkippedStack,
+			Message: "skipping stack /stack without an ID",
+		},
+	}
+	if diff := cmp.Diff(want, got.Warnings); diff != "" {
+		t.Fatalf("unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestCodeIsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range append(warnings.Codes(), warnings.All) {
+		if !code.IsValid() {
+			t.Errorf("code %s must be valid", code)
+		}
+	}
+	if warnings.Code("unknown").IsValid() {
+		t.Errorf("unknown code must be invalid")
+	}
+}